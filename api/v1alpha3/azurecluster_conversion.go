@@ -72,6 +72,8 @@ func (src *AzureCluster) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.NetworkSpec.NodeOutboundLB = restored.Spec.NetworkSpec.NodeOutboundLB
 
 	dst.Spec.NetworkSpec.PrivateDNSZoneName = restored.Spec.NetworkSpec.PrivateDNSZoneName
+	dst.Spec.NetworkSpec.PrivateDNSZone = restored.Spec.NetworkSpec.PrivateDNSZone
+	dst.Spec.NetworkSpec.Vnet.SubscriptionReference = restored.Spec.NetworkSpec.Vnet.SubscriptionReference
 
 	dst.Spec.NetworkSpec.APIServerLB.FrontendIPsCount = restored.Spec.NetworkSpec.APIServerLB.FrontendIPsCount
 	dst.Spec.NetworkSpec.APIServerLB.IdleTimeoutInMinutes = restored.Spec.NetworkSpec.APIServerLB.IdleTimeoutInMinutes
@@ -85,6 +87,7 @@ func (src *AzureCluster) ConvertTo(dstRaw conversion.Hub) error {
 	}
 
 	dst.Spec.CloudProviderConfigOverrides = restored.Spec.CloudProviderConfigOverrides
+	dst.Spec.DriftPolicy = restored.Spec.DriftPolicy
 	dst.Spec.BastionSpec = restored.Spec.BastionSpec
 
 	// Here we manually restore outbound security rules. Since v1alpha3 only supports ingress ("Inbound") rules, all v1alpha4/v1beta1 outbound rules are dropped when an AzureCluster
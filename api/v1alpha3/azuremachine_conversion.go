@@ -72,6 +72,9 @@ func (src *AzureMachine) ConvertTo(dstRaw conversion.Hub) error {
 
 	dst.Spec.SubnetName = restored.Spec.SubnetName
 
+	restoreAzureMachineSecuritySettings(&restored.Spec, &dst.Spec)
+	restoreAzureMachineDiskSettings(&restored.Spec, &dst.Spec)
+
 	dst.Status.LongRunningOperationStates = restored.Status.LongRunningOperationStates
 
 	return nil
@@ -126,7 +129,12 @@ func Convert_v1alpha3_OSDisk_To_v1beta1_OSDisk(in *OSDisk, out *infrav1.OSDisk,
 	if in.DiskSizeGB != 0 {
 		out.DiskSizeGB = &in.DiskSizeGB
 	}
-	out.DiffDiskSettings = (*infrav1.DiffDiskSettings)(in.DiffDiskSettings)
+	if in.DiffDiskSettings != nil {
+		out.DiffDiskSettings = &infrav1.DiffDiskSettings{}
+		if err := Convert_v1alpha3_DiffDiskSettings_To_v1beta1_DiffDiskSettings(in.DiffDiskSettings, out.DiffDiskSettings, s); err != nil {
+			return err
+		}
+	}
 	out.CachingType = in.CachingType
 	out.ManagedDisk = &infrav1.ManagedDiskParameters{}
 
@@ -139,7 +147,12 @@ func Convert_v1beta1_OSDisk_To_v1alpha3_OSDisk(in *infrav1.OSDisk, out *OSDisk,
 	if in.DiskSizeGB != nil {
 		out.DiskSizeGB = *in.DiskSizeGB
 	}
-	out.DiffDiskSettings = (*DiffDiskSettings)(in.DiffDiskSettings)
+	if in.DiffDiskSettings != nil {
+		out.DiffDiskSettings = &DiffDiskSettings{}
+		if err := Convert_v1beta1_DiffDiskSettings_To_v1alpha3_DiffDiskSettings(in.DiffDiskSettings, out.DiffDiskSettings, s); err != nil {
+			return err
+		}
+	}
 	out.CachingType = in.CachingType
 
 	if in.ManagedDisk != nil {
@@ -193,3 +206,57 @@ func Convert_v1beta1_Image_To_v1alpha3_Image(in *infrav1.Image, out *Image, s ap
 func Convert_v1beta1_SpotVMOptions_To_v1alpha3_SpotVMOptions(in *infrav1.SpotVMOptions, out *SpotVMOptions, s apiconversion.Scope) error {
 	return autoConvert_v1beta1_SpotVMOptions_To_v1alpha3_SpotVMOptions(in, out, s)
 }
+
+// Convert_v1beta1_SecurityProfile_To_v1alpha3_SecurityProfile converts a SecurityProfile from v1beta1 to v1alpha3.
+func Convert_v1beta1_SecurityProfile_To_v1alpha3_SecurityProfile(in *infrav1.SecurityProfile, out *SecurityProfile, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_SecurityProfile_To_v1alpha3_SecurityProfile(in, out, s)
+}
+
+// Convert_v1beta1_DataDisk_To_v1alpha3_DataDisk converts a DataDisk from v1beta1 to v1alpha3.
+func Convert_v1beta1_DataDisk_To_v1alpha3_DataDisk(in *infrav1.DataDisk, out *DataDisk, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_DataDisk_To_v1alpha3_DataDisk(in, out, s)
+}
+
+// Convert_v1beta1_DiffDiskSettings_To_v1alpha3_DiffDiskSettings converts DiffDiskSettings from v1beta1 to v1alpha3.
+func Convert_v1beta1_DiffDiskSettings_To_v1alpha3_DiffDiskSettings(in *infrav1.DiffDiskSettings, out *DiffDiskSettings, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_DiffDiskSettings_To_v1alpha3_DiffDiskSettings(in, out, s)
+}
+
+// restoreAzureMachineSecuritySettings restores the security type, UEFI settings and managed disk
+// security profiles which do not exist in v1alpha3.
+func restoreAzureMachineSecuritySettings(restored, dst *infrav1.AzureMachineSpec) {
+	if restored.SecurityProfile != nil && dst.SecurityProfile != nil {
+		dst.SecurityProfile.SecurityType = restored.SecurityProfile.SecurityType
+		dst.SecurityProfile.UefiSettings = restored.SecurityProfile.UefiSettings
+	}
+
+	restoreManagedDiskSecurityProfile(restored.OSDisk.ManagedDisk, dst.OSDisk.ManagedDisk)
+	for i := range dst.DataDisks {
+		if i < len(restored.DataDisks) {
+			restoreManagedDiskSecurityProfile(restored.DataDisks[i].ManagedDisk, dst.DataDisks[i].ManagedDisk)
+		}
+	}
+}
+
+func restoreManagedDiskSecurityProfile(restored, dst *infrav1.ManagedDiskParameters) {
+	if restored != nil && dst != nil {
+		dst.SecurityProfile = restored.SecurityProfile
+	}
+}
+
+// restoreAzureMachineDiskSettings restores the ephemeral OS disk placement and the data disk
+// performance and delete settings which do not exist in v1alpha3.
+func restoreAzureMachineDiskSettings(restored, dst *infrav1.AzureMachineSpec) {
+	if restored.OSDisk.DiffDiskSettings != nil && dst.OSDisk.DiffDiskSettings != nil {
+		dst.OSDisk.DiffDiskSettings.Placement = restored.OSDisk.DiffDiskSettings.Placement
+	}
+
+	for i := range dst.DataDisks {
+		if i < len(restored.DataDisks) {
+			dst.DataDisks[i].DiskIOPSReadWrite = restored.DataDisks[i].DiskIOPSReadWrite
+			dst.DataDisks[i].DiskMBpsReadWrite = restored.DataDisks[i].DiskMBpsReadWrite
+			dst.DataDisks[i].LogicalSectorSize = restored.DataDisks[i].LogicalSectorSize
+			dst.DataDisks[i].DeletePolicy = restored.DataDisks[i].DeletePolicy
+		}
+	}
+}
@@ -176,21 +176,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.DataDisk)(nil), (*DataDisk)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_DataDisk_To_v1alpha3_DataDisk(a.(*v1beta1.DataDisk), b.(*DataDisk), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*DiffDiskSettings)(nil), (*v1beta1.DiffDiskSettings)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_DiffDiskSettings_To_v1beta1_DiffDiskSettings(a.(*DiffDiskSettings), b.(*v1beta1.DiffDiskSettings), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.DiffDiskSettings)(nil), (*DiffDiskSettings)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_DiffDiskSettings_To_v1alpha3_DiffDiskSettings(a.(*v1beta1.DiffDiskSettings), b.(*DiffDiskSettings), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*DiskEncryptionSetParameters)(nil), (*v1beta1.DiskEncryptionSetParameters)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_DiskEncryptionSetParameters_To_v1beta1_DiskEncryptionSetParameters(a.(*DiskEncryptionSetParameters), b.(*v1beta1.DiskEncryptionSetParameters), scope)
 	}); err != nil {
@@ -226,11 +216,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.SecurityProfile)(nil), (*SecurityProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_SecurityProfile_To_v1alpha3_SecurityProfile(a.(*v1beta1.SecurityProfile), b.(*SecurityProfile), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*SpotVMOptions)(nil), (*v1beta1.SpotVMOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_SpotVMOptions_To_v1beta1_SpotVMOptions(a.(*SpotVMOptions), b.(*v1beta1.SpotVMOptions), scope)
 	}); err != nil {
@@ -366,6 +351,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.DataDisk)(nil), (*DataDisk)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DataDisk_To_v1alpha3_DataDisk(a.(*v1beta1.DataDisk), b.(*DataDisk), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.DiffDiskSettings)(nil), (*DiffDiskSettings)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DiffDiskSettings_To_v1alpha3_DiffDiskSettings(a.(*v1beta1.DiffDiskSettings), b.(*DiffDiskSettings), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.FrontendIP)(nil), (*FrontendIP)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_FrontendIP_To_v1alpha3_FrontendIP(a.(*v1beta1.FrontendIP), b.(*FrontendIP), scope)
 	}); err != nil {
@@ -411,6 +406,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.SecurityProfile)(nil), (*SecurityProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_SecurityProfile_To_v1alpha3_SecurityProfile(a.(*v1beta1.SecurityProfile), b.(*SecurityProfile), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.SecurityRule)(nil), (*IngressRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_SecurityRule_To_v1alpha3_IngressRule(a.(*v1beta1.SecurityRule), b.(*IngressRule), scope)
 	}); err != nil {
@@ -871,7 +871,15 @@ func autoConvert_v1alpha3_AzureMachineSpec_To_v1beta1_AzureMachineSpec(in *Azure
 	} else {
 		out.SpotVMOptions = nil
 	}
-	out.SecurityProfile = (*v1beta1.SecurityProfile)(unsafe.Pointer(in.SecurityProfile))
+	if in.SecurityProfile != nil {
+		in, out := &in.SecurityProfile, &out.SecurityProfile
+		*out = new(v1beta1.SecurityProfile)
+		if err := Convert_v1alpha3_SecurityProfile_To_v1beta1_SecurityProfile(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SecurityProfile = nil
+	}
 	return nil
 }
 
@@ -920,7 +928,15 @@ func autoConvert_v1beta1_AzureMachineSpec_To_v1alpha3_AzureMachineSpec(in *v1bet
 	} else {
 		out.SpotVMOptions = nil
 	}
-	out.SecurityProfile = (*SecurityProfile)(unsafe.Pointer(in.SecurityProfile))
+	if in.SecurityProfile != nil {
+		in, out := &in.SecurityProfile, &out.SecurityProfile
+		*out = new(SecurityProfile)
+		if err := Convert_v1beta1_SecurityProfile_To_v1alpha3_SecurityProfile(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SecurityProfile = nil
+	}
 	// WARNING: in.SubnetName requires manual conversion: does not exist in peer-type
 	// WARNING: in.DNSServers requires manual conversion: does not exist in peer-type
 	// WARNING: in.VMExtensions requires manual conversion: does not exist in peer-type
@@ -1188,14 +1204,13 @@ func autoConvert_v1beta1_DataDisk_To_v1alpha3_DataDisk(in *v1beta1.DataDisk, out
 	}
 	out.Lun = (*int32)(unsafe.Pointer(in.Lun))
 	out.CachingType = in.CachingType
+	// WARNING: in.DiskIOPSReadWrite requires manual conversion: does not exist in peer-type
+	// WARNING: in.DiskMBpsReadWrite requires manual conversion: does not exist in peer-type
+	// WARNING: in.LogicalSectorSize requires manual conversion: does not exist in peer-type
+	// WARNING: in.DeletePolicy requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_DataDisk_To_v1alpha3_DataDisk is an autogenerated conversion function.
-func Convert_v1beta1_DataDisk_To_v1alpha3_DataDisk(in *v1beta1.DataDisk, out *DataDisk, s conversion.Scope) error {
-	return autoConvert_v1beta1_DataDisk_To_v1alpha3_DataDisk(in, out, s)
-}
-
 func autoConvert_v1alpha3_DiffDiskSettings_To_v1beta1_DiffDiskSettings(in *DiffDiskSettings, out *v1beta1.DiffDiskSettings, s conversion.Scope) error {
 	out.Option = in.Option
 	return nil
@@ -1208,14 +1223,10 @@ func Convert_v1alpha3_DiffDiskSettings_To_v1beta1_DiffDiskSettings(in *DiffDiskS
 
 func autoConvert_v1beta1_DiffDiskSettings_To_v1alpha3_DiffDiskSettings(in *v1beta1.DiffDiskSettings, out *DiffDiskSettings, s conversion.Scope) error {
 	out.Option = in.Option
+	// WARNING: in.Placement requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_DiffDiskSettings_To_v1alpha3_DiffDiskSettings is an autogenerated conversion function.
-func Convert_v1beta1_DiffDiskSettings_To_v1alpha3_DiffDiskSettings(in *v1beta1.DiffDiskSettings, out *DiffDiskSettings, s conversion.Scope) error {
-	return autoConvert_v1beta1_DiffDiskSettings_To_v1alpha3_DiffDiskSettings(in, out, s)
-}
-
 func autoConvert_v1alpha3_DiskEncryptionSetParameters_To_v1beta1_DiskEncryptionSetParameters(in *DiskEncryptionSetParameters, out *v1beta1.DiskEncryptionSetParameters, s conversion.Scope) error {
 	out.ID = in.ID
 	return nil
@@ -1485,14 +1496,11 @@ func Convert_v1alpha3_SecurityProfile_To_v1beta1_SecurityProfile(in *SecurityPro
 
 func autoConvert_v1beta1_SecurityProfile_To_v1alpha3_SecurityProfile(in *v1beta1.SecurityProfile, out *SecurityProfile, s conversion.Scope) error {
 	out.EncryptionAtHost = (*bool)(unsafe.Pointer(in.EncryptionAtHost))
+	// WARNING: in.SecurityType requires manual conversion: does not exist in peer-type
+	// WARNING: in.UefiSettings requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_SecurityProfile_To_v1alpha3_SecurityProfile is an autogenerated conversion function.
-func Convert_v1beta1_SecurityProfile_To_v1alpha3_SecurityProfile(in *v1beta1.SecurityProfile, out *SecurityProfile, s conversion.Scope) error {
-	return autoConvert_v1beta1_SecurityProfile_To_v1alpha3_SecurityProfile(in, out, s)
-}
-
 func autoConvert_v1alpha3_SpotVMOptions_To_v1beta1_SpotVMOptions(in *SpotVMOptions, out *v1beta1.SpotVMOptions, s conversion.Scope) error {
 	out.MaxPrice = (*resource.Quantity)(unsafe.Pointer(in.MaxPrice))
 	return nil
@@ -1573,6 +1581,7 @@ func autoConvert_v1beta1_VnetSpec_To_v1alpha3_VnetSpec(in *v1beta1.VnetSpec, out
 	out.ID = in.ID
 	out.Name = in.Name
 	// WARNING: in.Peerings requires manual conversion: does not exist in peer-type
+	// WARNING: in.SubscriptionReference requires manual conversion: does not exist in peer-type
 	// WARNING: in.VnetClassSpec requires manual conversion: does not exist in peer-type
 	return nil
 }
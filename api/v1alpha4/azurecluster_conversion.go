@@ -41,6 +41,13 @@ func (src *AzureCluster) ConvertTo(dstRaw conversion.Hub) error {
 	// Restore list of virtual network peerings
 	dst.Spec.NetworkSpec.Vnet.Peerings = restored.Spec.NetworkSpec.Vnet.Peerings
 
+	// Restore the subscription of the virtual network and the private DNS zone.
+	dst.Spec.NetworkSpec.Vnet.SubscriptionReference = restored.Spec.NetworkSpec.Vnet.SubscriptionReference
+	dst.Spec.NetworkSpec.PrivateDNSZone = restored.Spec.NetworkSpec.PrivateDNSZone
+
+	// Restore drift policy.
+	dst.Spec.DriftPolicy = restored.Spec.DriftPolicy
+
 	// Restore API Server LB IP tags.
 	for _, restoredFrontendIP := range restored.Spec.NetworkSpec.APIServerLB.FrontendIPs {
 		for i, dstFrontendIP := range dst.Spec.NetworkSpec.APIServerLB.FrontendIPs {
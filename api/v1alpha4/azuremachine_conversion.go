@@ -56,6 +56,9 @@ func (src *AzureMachine) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.SpotVMOptions.EvictionPolicy = restored.Spec.SpotVMOptions.EvictionPolicy
 	}
 
+	restoreAzureMachineSecuritySettings(&restored.Spec, &dst.Spec)
+	restoreAzureMachineDiskSettings(&restored.Spec, &dst.Spec)
+
 	return nil
 }
 
@@ -114,3 +117,62 @@ func Convert_v1beta1_AzureMachineSpec_To_v1alpha4_AzureMachineSpec(in *infrav1.A
 func Convert_v1beta1_SpotVMOptions_To_v1alpha4_SpotVMOptions(in *infrav1.SpotVMOptions, out *SpotVMOptions, s apiconversion.Scope) error {
 	return autoConvert_v1beta1_SpotVMOptions_To_v1alpha4_SpotVMOptions(in, out, s)
 }
+
+// Convert_v1beta1_SecurityProfile_To_v1alpha4_SecurityProfile converts a SecurityProfile from v1beta1 to v1alpha4.
+func Convert_v1beta1_SecurityProfile_To_v1alpha4_SecurityProfile(in *infrav1.SecurityProfile, out *SecurityProfile, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_SecurityProfile_To_v1alpha4_SecurityProfile(in, out, s)
+}
+
+// Convert_v1beta1_ManagedDiskParameters_To_v1alpha4_ManagedDiskParameters converts ManagedDiskParameters from v1beta1 to v1alpha4.
+func Convert_v1beta1_ManagedDiskParameters_To_v1alpha4_ManagedDiskParameters(in *infrav1.ManagedDiskParameters, out *ManagedDiskParameters, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_ManagedDiskParameters_To_v1alpha4_ManagedDiskParameters(in, out, s)
+}
+
+// Convert_v1beta1_DataDisk_To_v1alpha4_DataDisk converts a DataDisk from v1beta1 to v1alpha4.
+func Convert_v1beta1_DataDisk_To_v1alpha4_DataDisk(in *infrav1.DataDisk, out *DataDisk, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_DataDisk_To_v1alpha4_DataDisk(in, out, s)
+}
+
+// Convert_v1beta1_DiffDiskSettings_To_v1alpha4_DiffDiskSettings converts DiffDiskSettings from v1beta1 to v1alpha4.
+func Convert_v1beta1_DiffDiskSettings_To_v1alpha4_DiffDiskSettings(in *infrav1.DiffDiskSettings, out *DiffDiskSettings, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_DiffDiskSettings_To_v1alpha4_DiffDiskSettings(in, out, s)
+}
+
+// restoreAzureMachineSecuritySettings restores the security type, UEFI settings and managed disk
+// security profiles which do not exist in v1alpha4.
+func restoreAzureMachineSecuritySettings(restored, dst *infrav1.AzureMachineSpec) {
+	if restored.SecurityProfile != nil && dst.SecurityProfile != nil {
+		dst.SecurityProfile.SecurityType = restored.SecurityProfile.SecurityType
+		dst.SecurityProfile.UefiSettings = restored.SecurityProfile.UefiSettings
+	}
+
+	restoreManagedDiskSecurityProfile(restored.OSDisk.ManagedDisk, dst.OSDisk.ManagedDisk)
+	for i := range dst.DataDisks {
+		if i < len(restored.DataDisks) {
+			restoreManagedDiskSecurityProfile(restored.DataDisks[i].ManagedDisk, dst.DataDisks[i].ManagedDisk)
+		}
+	}
+}
+
+func restoreManagedDiskSecurityProfile(restored, dst *infrav1.ManagedDiskParameters) {
+	if restored != nil && dst != nil {
+		dst.SecurityProfile = restored.SecurityProfile
+	}
+}
+
+// restoreAzureMachineDiskSettings restores the ephemeral OS disk placement and the data disk
+// performance and delete settings which do not exist in v1alpha4.
+func restoreAzureMachineDiskSettings(restored, dst *infrav1.AzureMachineSpec) {
+	if restored.OSDisk.DiffDiskSettings != nil && dst.OSDisk.DiffDiskSettings != nil {
+		dst.OSDisk.DiffDiskSettings.Placement = restored.OSDisk.DiffDiskSettings.Placement
+	}
+
+	for i := range dst.DataDisks {
+		if i < len(restored.DataDisks) {
+			dst.DataDisks[i].DiskIOPSReadWrite = restored.DataDisks[i].DiskIOPSReadWrite
+			dst.DataDisks[i].DiskMBpsReadWrite = restored.DataDisks[i].DiskMBpsReadWrite
+			dst.DataDisks[i].LogicalSectorSize = restored.DataDisks[i].LogicalSectorSize
+			dst.DataDisks[i].DeletePolicy = restored.DataDisks[i].DeletePolicy
+		}
+	}
+}
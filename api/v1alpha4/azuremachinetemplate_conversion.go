@@ -58,6 +58,9 @@ func (src *AzureMachineTemplate) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.Template.Spec.SpotVMOptions.EvictionPolicy = restored.Spec.Template.Spec.SpotVMOptions.EvictionPolicy
 	}
 
+	restoreAzureMachineSecuritySettings(&restored.Spec.Template.Spec, &dst.Spec.Template.Spec)
+	restoreAzureMachineDiskSettings(&restored.Spec.Template.Spec, &dst.Spec.Template.Spec)
+
 	return nil
 }
 
@@ -267,21 +267,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.DataDisk)(nil), (*DataDisk)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_DataDisk_To_v1alpha4_DataDisk(a.(*v1beta1.DataDisk), b.(*DataDisk), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*DiffDiskSettings)(nil), (*v1beta1.DiffDiskSettings)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_DiffDiskSettings_To_v1beta1_DiffDiskSettings(a.(*DiffDiskSettings), b.(*v1beta1.DiffDiskSettings), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.DiffDiskSettings)(nil), (*DiffDiskSettings)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_DiffDiskSettings_To_v1alpha4_DiffDiskSettings(a.(*v1beta1.DiffDiskSettings), b.(*DiffDiskSettings), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*DiskEncryptionSetParameters)(nil), (*v1beta1.DiskEncryptionSetParameters)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_DiskEncryptionSetParameters_To_v1beta1_DiskEncryptionSetParameters(a.(*DiskEncryptionSetParameters), b.(*v1beta1.DiskEncryptionSetParameters), scope)
 	}); err != nil {
@@ -312,11 +302,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.ManagedDiskParameters)(nil), (*ManagedDiskParameters)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_ManagedDiskParameters_To_v1alpha4_ManagedDiskParameters(a.(*v1beta1.ManagedDiskParameters), b.(*ManagedDiskParameters), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*OSDisk)(nil), (*v1beta1.OSDisk)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_OSDisk_To_v1beta1_OSDisk(a.(*OSDisk), b.(*v1beta1.OSDisk), scope)
 	}); err != nil {
@@ -367,11 +352,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.SecurityProfile)(nil), (*SecurityProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_SecurityProfile_To_v1alpha4_SecurityProfile(a.(*v1beta1.SecurityProfile), b.(*SecurityProfile), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*SecurityRule)(nil), (*v1beta1.SecurityRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_SecurityRule_To_v1beta1_SecurityRule(a.(*SecurityRule), b.(*v1beta1.SecurityRule), scope)
 	}); err != nil {
@@ -462,6 +442,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.DataDisk)(nil), (*DataDisk)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DataDisk_To_v1alpha4_DataDisk(a.(*v1beta1.DataDisk), b.(*DataDisk), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.DiffDiskSettings)(nil), (*DiffDiskSettings)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DiffDiskSettings_To_v1alpha4_DiffDiskSettings(a.(*v1beta1.DiffDiskSettings), b.(*DiffDiskSettings), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.FrontendIP)(nil), (*FrontendIP)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_FrontendIP_To_v1alpha4_FrontendIP(a.(*v1beta1.FrontendIP), b.(*FrontendIP), scope)
 	}); err != nil {
@@ -477,6 +467,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.ManagedDiskParameters)(nil), (*ManagedDiskParameters)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ManagedDiskParameters_To_v1alpha4_ManagedDiskParameters(a.(*v1beta1.ManagedDiskParameters), b.(*ManagedDiskParameters), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.NatGateway)(nil), (*NatGateway)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_NatGateway_To_v1alpha4_NatGateway(a.(*v1beta1.NatGateway), b.(*NatGateway), scope)
 	}); err != nil {
@@ -497,6 +492,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.SecurityProfile)(nil), (*SecurityProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_SecurityProfile_To_v1alpha4_SecurityProfile(a.(*v1beta1.SecurityProfile), b.(*SecurityProfile), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.SpotVMOptions)(nil), (*SpotVMOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_SpotVMOptions_To_v1alpha4_SpotVMOptions(a.(*v1beta1.SpotVMOptions), b.(*SpotVMOptions), scope)
 	}); err != nil {
@@ -1007,7 +1007,17 @@ func autoConvert_v1alpha4_AzureMachineSpec_To_v1beta1_AzureMachineSpec(in *Azure
 	if err := Convert_v1alpha4_OSDisk_To_v1beta1_OSDisk(&in.OSDisk, &out.OSDisk, s); err != nil {
 		return err
 	}
-	out.DataDisks = *(*[]v1beta1.DataDisk)(unsafe.Pointer(&in.DataDisks))
+	if in.DataDisks != nil {
+		in, out := &in.DataDisks, &out.DataDisks
+		*out = make([]v1beta1.DataDisk, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha4_DataDisk_To_v1beta1_DataDisk(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.DataDisks = nil
+	}
 	out.SSHPublicKey = in.SSHPublicKey
 	out.AdditionalTags = *(*v1beta1.Tags)(unsafe.Pointer(&in.AdditionalTags))
 	out.AllocatePublicIP = in.AllocatePublicIP
@@ -1022,7 +1032,15 @@ func autoConvert_v1alpha4_AzureMachineSpec_To_v1beta1_AzureMachineSpec(in *Azure
 	} else {
 		out.SpotVMOptions = nil
 	}
-	out.SecurityProfile = (*v1beta1.SecurityProfile)(unsafe.Pointer(in.SecurityProfile))
+	if in.SecurityProfile != nil {
+		in, out := &in.SecurityProfile, &out.SecurityProfile
+		*out = new(v1beta1.SecurityProfile)
+		if err := Convert_v1alpha4_SecurityProfile_To_v1beta1_SecurityProfile(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SecurityProfile = nil
+	}
 	out.SubnetName = in.SubnetName
 	return nil
 }
@@ -1051,7 +1069,17 @@ func autoConvert_v1beta1_AzureMachineSpec_To_v1alpha4_AzureMachineSpec(in *v1bet
 	if err := Convert_v1beta1_OSDisk_To_v1alpha4_OSDisk(&in.OSDisk, &out.OSDisk, s); err != nil {
 		return err
 	}
-	out.DataDisks = *(*[]DataDisk)(unsafe.Pointer(&in.DataDisks))
+	if in.DataDisks != nil {
+		in, out := &in.DataDisks, &out.DataDisks
+		*out = make([]DataDisk, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_DataDisk_To_v1alpha4_DataDisk(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.DataDisks = nil
+	}
 	out.SSHPublicKey = in.SSHPublicKey
 	out.AdditionalTags = *(*Tags)(unsafe.Pointer(&in.AdditionalTags))
 	// WARNING: in.AdditionalCapabilities requires manual conversion: does not exist in peer-type
@@ -1067,7 +1095,15 @@ func autoConvert_v1beta1_AzureMachineSpec_To_v1alpha4_AzureMachineSpec(in *v1bet
 	} else {
 		out.SpotVMOptions = nil
 	}
-	out.SecurityProfile = (*SecurityProfile)(unsafe.Pointer(in.SecurityProfile))
+	if in.SecurityProfile != nil {
+		in, out := &in.SecurityProfile, &out.SecurityProfile
+		*out = new(SecurityProfile)
+		if err := Convert_v1beta1_SecurityProfile_To_v1alpha4_SecurityProfile(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SecurityProfile = nil
+	}
 	out.SubnetName = in.SubnetName
 	// WARNING: in.DNSServers requires manual conversion: does not exist in peer-type
 	// WARNING: in.VMExtensions requires manual conversion: does not exist in peer-type
@@ -1411,7 +1447,15 @@ func Convert_v1beta1_CloudProviderConfigOverrides_To_v1alpha4_CloudProviderConfi
 func autoConvert_v1alpha4_DataDisk_To_v1beta1_DataDisk(in *DataDisk, out *v1beta1.DataDisk, s conversion.Scope) error {
 	out.NameSuffix = in.NameSuffix
 	out.DiskSizeGB = in.DiskSizeGB
-	out.ManagedDisk = (*v1beta1.ManagedDiskParameters)(unsafe.Pointer(in.ManagedDisk))
+	if in.ManagedDisk != nil {
+		in, out := &in.ManagedDisk, &out.ManagedDisk
+		*out = new(v1beta1.ManagedDiskParameters)
+		if err := Convert_v1alpha4_ManagedDiskParameters_To_v1beta1_ManagedDiskParameters(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ManagedDisk = nil
+	}
 	out.Lun = (*int32)(unsafe.Pointer(in.Lun))
 	out.CachingType = in.CachingType
 	return nil
@@ -1425,17 +1469,24 @@ func Convert_v1alpha4_DataDisk_To_v1beta1_DataDisk(in *DataDisk, out *v1beta1.Da
 func autoConvert_v1beta1_DataDisk_To_v1alpha4_DataDisk(in *v1beta1.DataDisk, out *DataDisk, s conversion.Scope) error {
 	out.NameSuffix = in.NameSuffix
 	out.DiskSizeGB = in.DiskSizeGB
-	out.ManagedDisk = (*ManagedDiskParameters)(unsafe.Pointer(in.ManagedDisk))
+	if in.ManagedDisk != nil {
+		in, out := &in.ManagedDisk, &out.ManagedDisk
+		*out = new(ManagedDiskParameters)
+		if err := Convert_v1beta1_ManagedDiskParameters_To_v1alpha4_ManagedDiskParameters(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ManagedDisk = nil
+	}
 	out.Lun = (*int32)(unsafe.Pointer(in.Lun))
 	out.CachingType = in.CachingType
+	// WARNING: in.DiskIOPSReadWrite requires manual conversion: does not exist in peer-type
+	// WARNING: in.DiskMBpsReadWrite requires manual conversion: does not exist in peer-type
+	// WARNING: in.LogicalSectorSize requires manual conversion: does not exist in peer-type
+	// WARNING: in.DeletePolicy requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_DataDisk_To_v1alpha4_DataDisk is an autogenerated conversion function.
-func Convert_v1beta1_DataDisk_To_v1alpha4_DataDisk(in *v1beta1.DataDisk, out *DataDisk, s conversion.Scope) error {
-	return autoConvert_v1beta1_DataDisk_To_v1alpha4_DataDisk(in, out, s)
-}
-
 func autoConvert_v1alpha4_DiffDiskSettings_To_v1beta1_DiffDiskSettings(in *DiffDiskSettings, out *v1beta1.DiffDiskSettings, s conversion.Scope) error {
 	out.Option = in.Option
 	return nil
@@ -1448,14 +1499,10 @@ func Convert_v1alpha4_DiffDiskSettings_To_v1beta1_DiffDiskSettings(in *DiffDiskS
 
 func autoConvert_v1beta1_DiffDiskSettings_To_v1alpha4_DiffDiskSettings(in *v1beta1.DiffDiskSettings, out *DiffDiskSettings, s conversion.Scope) error {
 	out.Option = in.Option
+	// WARNING: in.Placement requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_DiffDiskSettings_To_v1alpha4_DiffDiskSettings is an autogenerated conversion function.
-func Convert_v1beta1_DiffDiskSettings_To_v1alpha4_DiffDiskSettings(in *v1beta1.DiffDiskSettings, out *DiffDiskSettings, s conversion.Scope) error {
-	return autoConvert_v1beta1_DiffDiskSettings_To_v1alpha4_DiffDiskSettings(in, out, s)
-}
-
 func autoConvert_v1alpha4_DiskEncryptionSetParameters_To_v1beta1_DiskEncryptionSetParameters(in *DiskEncryptionSetParameters, out *v1beta1.DiskEncryptionSetParameters, s conversion.Scope) error {
 	out.ID = in.ID
 	return nil
@@ -1624,14 +1671,10 @@ func Convert_v1alpha4_ManagedDiskParameters_To_v1beta1_ManagedDiskParameters(in
 func autoConvert_v1beta1_ManagedDiskParameters_To_v1alpha4_ManagedDiskParameters(in *v1beta1.ManagedDiskParameters, out *ManagedDiskParameters, s conversion.Scope) error {
 	out.StorageAccountType = in.StorageAccountType
 	out.DiskEncryptionSet = (*DiskEncryptionSetParameters)(unsafe.Pointer(in.DiskEncryptionSet))
+	// WARNING: in.SecurityProfile requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_ManagedDiskParameters_To_v1alpha4_ManagedDiskParameters is an autogenerated conversion function.
-func Convert_v1beta1_ManagedDiskParameters_To_v1alpha4_ManagedDiskParameters(in *v1beta1.ManagedDiskParameters, out *ManagedDiskParameters, s conversion.Scope) error {
-	return autoConvert_v1beta1_ManagedDiskParameters_To_v1alpha4_ManagedDiskParameters(in, out, s)
-}
-
 func autoConvert_v1alpha4_NatGateway_To_v1beta1_NatGateway(in *NatGateway, out *v1beta1.NatGateway, s conversion.Scope) error {
 	out.ID = in.ID
 	// WARNING: in.Name requires manual conversion: does not exist in peer-type
@@ -1733,8 +1776,24 @@ func autoConvert_v1beta1_NetworkSpec_To_v1alpha4_NetworkSpec(in *v1beta1.Network
 func autoConvert_v1alpha4_OSDisk_To_v1beta1_OSDisk(in *OSDisk, out *v1beta1.OSDisk, s conversion.Scope) error {
 	out.OSType = in.OSType
 	out.DiskSizeGB = (*int32)(unsafe.Pointer(in.DiskSizeGB))
-	out.ManagedDisk = (*v1beta1.ManagedDiskParameters)(unsafe.Pointer(in.ManagedDisk))
-	out.DiffDiskSettings = (*v1beta1.DiffDiskSettings)(unsafe.Pointer(in.DiffDiskSettings))
+	if in.ManagedDisk != nil {
+		in, out := &in.ManagedDisk, &out.ManagedDisk
+		*out = new(v1beta1.ManagedDiskParameters)
+		if err := Convert_v1alpha4_ManagedDiskParameters_To_v1beta1_ManagedDiskParameters(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ManagedDisk = nil
+	}
+	if in.DiffDiskSettings != nil {
+		in, out := &in.DiffDiskSettings, &out.DiffDiskSettings
+		*out = new(v1beta1.DiffDiskSettings)
+		if err := Convert_v1alpha4_DiffDiskSettings_To_v1beta1_DiffDiskSettings(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.DiffDiskSettings = nil
+	}
 	out.CachingType = in.CachingType
 	return nil
 }
@@ -1747,8 +1806,24 @@ func Convert_v1alpha4_OSDisk_To_v1beta1_OSDisk(in *OSDisk, out *v1beta1.OSDisk,
 func autoConvert_v1beta1_OSDisk_To_v1alpha4_OSDisk(in *v1beta1.OSDisk, out *OSDisk, s conversion.Scope) error {
 	out.OSType = in.OSType
 	out.DiskSizeGB = (*int32)(unsafe.Pointer(in.DiskSizeGB))
-	out.ManagedDisk = (*ManagedDiskParameters)(unsafe.Pointer(in.ManagedDisk))
-	out.DiffDiskSettings = (*DiffDiskSettings)(unsafe.Pointer(in.DiffDiskSettings))
+	if in.ManagedDisk != nil {
+		in, out := &in.ManagedDisk, &out.ManagedDisk
+		*out = new(ManagedDiskParameters)
+		if err := Convert_v1beta1_ManagedDiskParameters_To_v1alpha4_ManagedDiskParameters(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ManagedDisk = nil
+	}
+	if in.DiffDiskSettings != nil {
+		in, out := &in.DiffDiskSettings, &out.DiffDiskSettings
+		*out = new(DiffDiskSettings)
+		if err := Convert_v1beta1_DiffDiskSettings_To_v1alpha4_DiffDiskSettings(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.DiffDiskSettings = nil
+	}
 	out.CachingType = in.CachingType
 	return nil
 }
@@ -1879,14 +1954,11 @@ func Convert_v1alpha4_SecurityProfile_To_v1beta1_SecurityProfile(in *SecurityPro
 
 func autoConvert_v1beta1_SecurityProfile_To_v1alpha4_SecurityProfile(in *v1beta1.SecurityProfile, out *SecurityProfile, s conversion.Scope) error {
 	out.EncryptionAtHost = (*bool)(unsafe.Pointer(in.EncryptionAtHost))
+	// WARNING: in.SecurityType requires manual conversion: does not exist in peer-type
+	// WARNING: in.UefiSettings requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_SecurityProfile_To_v1alpha4_SecurityProfile is an autogenerated conversion function.
-func Convert_v1beta1_SecurityProfile_To_v1alpha4_SecurityProfile(in *v1beta1.SecurityProfile, out *SecurityProfile, s conversion.Scope) error {
-	return autoConvert_v1beta1_SecurityProfile_To_v1alpha4_SecurityProfile(in, out, s)
-}
-
 func autoConvert_v1alpha4_SecurityRule_To_v1beta1_SecurityRule(in *SecurityRule, out *v1beta1.SecurityRule, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Description = in.Description
@@ -2005,6 +2077,7 @@ func autoConvert_v1beta1_VnetSpec_To_v1alpha4_VnetSpec(in *v1beta1.VnetSpec, out
 	out.ID = in.ID
 	out.Name = in.Name
 	// WARNING: in.Peerings requires manual conversion: does not exist in peer-type
+	// WARNING: in.SubscriptionReference requires manual conversion: does not exist in peer-type
 	// WARNING: in.VnetClassSpec requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -23,6 +23,7 @@ import (
 	"regexp"
 
 	valid "github.com/asaskevich/govalidator"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -84,6 +85,7 @@ func (c *AzureCluster) validateClusterSpec(old *AzureCluster) field.ErrorList {
 		oldNetworkSpec = old.Spec.NetworkSpec
 	}
 	allErrs = append(allErrs, validateNetworkSpec(c.Spec.NetworkSpec, oldNetworkSpec, field.NewPath("spec").Child("networkSpec"))...)
+	allErrs = append(allErrs, validateVnetSubscriptionNatGateways(c.Spec.SubscriptionID, c.Spec.NetworkSpec, field.NewPath("spec").Child("networkSpec"))...)
 
 	var oldCloudProviderConfigOverrides *CloudProviderConfigOverrides
 	if old != nil {
@@ -157,6 +159,8 @@ func validateNetworkSpec(networkSpec NetworkSpec, old NetworkSpec, fldPath *fiel
 
 	allErrs = append(allErrs, validatePrivateDNSZoneName(networkSpec.PrivateDNSZoneName, networkSpec.APIServerLB.Type, fldPath.Child("privateDNSZoneName"))...)
 
+	allErrs = append(allErrs, validateSubscriptionReferences(networkSpec, fldPath)...)
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -285,6 +289,54 @@ func validateVnetPeerings(peerings VnetPeerings, fldPath *field.Path) field.Erro
 	return allErrs
 }
 
+// validateSubscriptionReferences validates that all references to the same subscription in a NetworkSpec
+// use the same identity.
+func validateSubscriptionReferences(networkSpec NetworkSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	identities := make(map[string]*corev1.ObjectReference)
+
+	validate := func(ref SubscriptionReference, refPath *field.Path) {
+		if ref.SubscriptionID == "" {
+			return
+		}
+		identity, ok := identities[ref.SubscriptionID]
+		if !ok {
+			identities[ref.SubscriptionID] = ref.IdentityRef
+			return
+		}
+		if !reflect.DeepEqual(identity, ref.IdentityRef) {
+			allErrs = append(allErrs, field.Invalid(refPath.Child("identityRef"), ref.IdentityRef,
+				fmt.Sprintf("all references to subscription %s must use the same identityRef", ref.SubscriptionID)))
+		}
+	}
+
+	validate(networkSpec.Vnet.SubscriptionReference, fldPath.Child("vnet"))
+	for i, peering := range networkSpec.Vnet.Peerings {
+		validate(peering.SubscriptionReference, fldPath.Child("vnet", "peerings").Index(i))
+	}
+	if networkSpec.PrivateDNSZone != nil {
+		validate(networkSpec.PrivateDNSZone.SubscriptionReference, fldPath.Child("privateDNSZone"))
+	}
+	return allErrs
+}
+
+// validateVnetSubscriptionNatGateways validates that the subnets of a vnet in another subscription than the cluster
+// don't have NAT gateways. The resources associated with a subnet must be in the subscription of its vnet, and the NAT
+// gateways and their public IPs are created in the subscription of the cluster.
+func validateVnetSubscriptionNatGateways(subscriptionID string, networkSpec NetworkSpec, fldPath *field.Path) field.ErrorList {
+	if networkSpec.Vnet.SubscriptionID == "" || networkSpec.Vnet.SubscriptionID == subscriptionID {
+		return nil
+	}
+	var allErrs field.ErrorList
+	for i, subnet := range networkSpec.Subnets {
+		if subnet.IsNatGatewayEnabled() {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("subnets").Index(i).Child("natGateway"),
+				"NAT gateways are not supported for a vnet in another subscription than the cluster"))
+		}
+	}
+	return allErrs
+}
+
 // validateLoadBalancerName validates the Name of a Load Balancer.
 func validateLoadBalancerName(name string, fldPath *field.Path) *field.Error {
 	if success, _ := regexp.Match(loadBalancerRegex, []byte(name)); !success {
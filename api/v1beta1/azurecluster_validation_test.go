@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/pointer"
@@ -956,6 +957,151 @@ func TestPrivateDNSZoneName(t *testing.T) {
 	}
 }
 
+func TestValidateSubscriptionReferences(t *testing.T) {
+	g := NewWithT(t)
+
+	hubIdentity := &corev1.ObjectReference{Kind: "AzureClusterIdentity", Name: "hub-identity", Namespace: "default"}
+	otherIdentity := &corev1.ObjectReference{Kind: "AzureClusterIdentity", Name: "other-identity", Namespace: "default"}
+
+	testcases := []struct {
+		name    string
+		network NetworkSpec
+		wantErr bool
+	}{
+		{
+			name:    "no subscription references",
+			network: NetworkSpec{},
+			wantErr: false,
+		},
+		{
+			name: "all references to the hub subscription use the same identity",
+			network: NetworkSpec{
+				Vnet: VnetSpec{
+					Peerings: VnetPeerings{
+						{VnetPeeringClassSpec: VnetPeeringClassSpec{
+							RemoteVnetName:        "hub-vnet",
+							SubscriptionReference: SubscriptionReference{SubscriptionID: "hub-sub", IdentityRef: hubIdentity},
+						}},
+					},
+				},
+				NetworkClassSpec: NetworkClassSpec{
+					PrivateDNSZone: &PrivateDNSZoneReference{
+						ResourceGroup:         "dns-rg",
+						SubscriptionReference: SubscriptionReference{SubscriptionID: "hub-sub", IdentityRef: hubIdentity},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "different subscriptions may use different identities",
+			network: NetworkSpec{
+				Vnet: VnetSpec{
+					SubscriptionReference: SubscriptionReference{SubscriptionID: "spoke-sub", IdentityRef: otherIdentity},
+					Peerings: VnetPeerings{
+						{VnetPeeringClassSpec: VnetPeeringClassSpec{
+							RemoteVnetName:        "hub-vnet",
+							SubscriptionReference: SubscriptionReference{SubscriptionID: "hub-sub", IdentityRef: hubIdentity},
+						}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "references to the same subscription use different identities",
+			network: NetworkSpec{
+				Vnet: VnetSpec{
+					Peerings: VnetPeerings{
+						{VnetPeeringClassSpec: VnetPeeringClassSpec{
+							RemoteVnetName:        "hub-vnet",
+							SubscriptionReference: SubscriptionReference{SubscriptionID: "hub-sub", IdentityRef: hubIdentity},
+						}},
+					},
+				},
+				NetworkClassSpec: NetworkClassSpec{
+					PrivateDNSZone: &PrivateDNSZoneReference{
+						SubscriptionReference: SubscriptionReference{SubscriptionID: "hub-sub"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateSubscriptionReferences(test.network, field.NewPath("spec", "networkSpec"))
+			if test.wantErr {
+				g.Expect(err).NotTo(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateVnetSubscriptionNatGateways(t *testing.T) {
+	g := NewWithT(t)
+
+	natGatewaySubnets := Subnets{
+		{
+			SubnetClassSpec: SubnetClassSpec{Role: SubnetNode, Name: "node-subnet"},
+			NatGateway:      NatGateway{NatGatewayClassSpec: NatGatewayClassSpec{Name: "node-natgw"}},
+		},
+	}
+	testcases := []struct {
+		name    string
+		network NetworkSpec
+		wantErr bool
+	}{
+		{
+			name:    "NAT gateways for a vnet in the cluster subscription",
+			network: NetworkSpec{Subnets: natGatewaySubnets},
+			wantErr: false,
+		},
+		{
+			name: "NAT gateways for a vnet explicitly in the cluster subscription",
+			network: NetworkSpec{
+				Vnet:    VnetSpec{SubscriptionReference: SubscriptionReference{SubscriptionID: "cluster-sub"}},
+				Subnets: natGatewaySubnets,
+			},
+			wantErr: false,
+		},
+		{
+			name: "no NAT gateways for a vnet in another subscription",
+			network: NetworkSpec{
+				Vnet:    VnetSpec{SubscriptionReference: SubscriptionReference{SubscriptionID: "spoke-sub"}},
+				Subnets: Subnets{{SubnetClassSpec: SubnetClassSpec{Role: SubnetNode, Name: "node-subnet"}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "NAT gateways for a vnet in another subscription",
+			network: NetworkSpec{
+				Vnet:    VnetSpec{SubscriptionReference: SubscriptionReference{SubscriptionID: "spoke-sub"}},
+				Subnets: natGatewaySubnets,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateVnetSubscriptionNatGateways("cluster-sub", test.network, field.NewPath("spec", "networkSpec"))
+			if test.wantErr {
+				g.Expect(err).NotTo(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
 func TestValidateNodeOutboundLB(t *testing.T) {
 	g := NewWithT(t)
 
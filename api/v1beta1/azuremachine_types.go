@@ -28,6 +28,11 @@ const (
 	// MachineFinalizer allows ReconcileAzureMachine to clean up Azure resources associated with AzureMachine before
 	// removing it from the apiserver.
 	MachineFinalizer = "azuremachine.infrastructure.cluster.x-k8s.io"
+
+	// AdoptAnnotation is set on an AzureMachine to bring an existing VM under the management of CAPZ instead of
+	// creating one. Its value is "true" to adopt the VM named after the AzureMachine, or the name or resource ID of
+	// the VM to adopt.
+	AdoptAnnotation = "sigs.k8s.io/cluster-api-provider-azure-adopt"
 )
 
 // AzureMachineSpec defines the desired state of AzureMachine.
@@ -19,6 +19,7 @@ package v1beta1
 import (
 	"encoding/base64"
 	"fmt"
+	"reflect"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/google/uuid"
@@ -50,6 +51,10 @@ func ValidateAzureMachineSpec(spec AzureMachineSpec) field.ErrorList {
 		allErrs = append(allErrs, errs...)
 	}
 
+	if errs := ValidateSecurityProfile(spec.SecurityProfile, spec.Image, spec.OSDisk, spec.DataDisks, field.NewPath("securityProfile")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
 	return allErrs
 }
 
@@ -140,7 +145,38 @@ func ValidateDataDisks(dataDisks []DataDisk, fieldPath *field.Path) field.ErrorL
 
 		// validate cachingType
 		allErrs = append(allErrs, validateCachingType(disk.CachingType, fieldPath, disk.ManagedDisk)...)
+
+		// validate the provisioned performance and logical sector size
+		allErrs = append(allErrs, ValidateDataDiskPerformance(disk, fieldPath)...)
+	}
+	return allErrs
+}
+
+// ValidateDataDiskPerformance validates that the IOPS, throughput and logical sector size of a data disk
+// are only set on ultra disks.
+func ValidateDataDiskPerformance(disk DataDisk, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !disk.HasProvisionedPerformance() {
+		return allErrs
+	}
+
+	if disk.ManagedDisk == nil || disk.ManagedDisk.StorageAccountType != string(compute.StorageAccountTypesUltraSSDLRS) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("managedDisk").Child("storageAccountType"), disk.ManagedDisk, fmt.Sprintf("diskIOPSReadWrite, diskMBpsReadWrite and logicalSectorSize can only be set when storageAccountType is '%s'", compute.StorageAccountTypesUltraSSDLRS)))
+	}
+
+	if disk.DiskIOPSReadWrite != nil && *disk.DiskIOPSReadWrite <= 0 {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("diskIOPSReadWrite"), *disk.DiskIOPSReadWrite, "diskIOPSReadWrite must be greater than 0"))
 	}
+
+	if disk.DiskMBpsReadWrite != nil && *disk.DiskMBpsReadWrite <= 0 {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("diskMBpsReadWrite"), *disk.DiskMBpsReadWrite, "diskMBpsReadWrite must be greater than 0"))
+	}
+
+	if disk.LogicalSectorSize != nil && *disk.LogicalSectorSize != 512 && *disk.LogicalSectorSize != 4096 {
+		allErrs = append(allErrs, field.NotSupported(fieldPath.Child("logicalSectorSize"), *disk.LogicalSectorSize, []string{"512", "4096"}))
+	}
+
 	return allErrs
 }
 
@@ -188,44 +224,161 @@ func validateManagedDisk(m *ManagedDiskParameters, fieldPath *field.Path, isOSDi
 	return allErrs
 }
 
+// ValidateSecurityProfile validates the security type and UEFI settings of a virtual machine against
+// its image and the confidential encryption settings of its disks.
+func ValidateSecurityProfile(securityProfile *SecurityProfile, image *Image, osDisk OSDisk, dataDisks []DataDisk, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	var securityType SecurityTypes
+	var uefiSettings *UefiSettings
+	if securityProfile != nil {
+		securityType = securityProfile.SecurityType
+		uefiSettings = securityProfile.UefiSettings
+	}
+
+	if uefiSettings != nil && securityType == "" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("uefiSettings"),
+			"uefiSettings can only be set when securityType is TrustedLaunch or ConfidentialVM"))
+	}
+
+	// The default images are Hyper-V generation V1 images, which don't support trusted launch and confidential VMs.
+	if securityType != "" && image == nil {
+		allErrs = append(allErrs, field.Required(field.NewPath("image"),
+			"an image supporting Hyper-V generation V2 must be set when securityType is TrustedLaunch or ConfidentialVM"))
+	}
+
+	for i, disk := range dataDisks {
+		if disk.ManagedDisk != nil && disk.ManagedDisk.SecurityProfile != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("dataDisks").Index(i).Child("managedDisk", "securityProfile"),
+				"securityProfile is only supported on the OS disk"))
+		}
+	}
+
+	osDiskPath := field.NewPath("osDisk", "managedDisk", "securityProfile")
+	var diskSecurityProfile *VMDiskSecurityProfile
+	if osDisk.ManagedDisk != nil {
+		diskSecurityProfile = osDisk.ManagedDisk.SecurityProfile
+	}
+
+	if securityType != SecurityTypesConfidentialVM {
+		if diskSecurityProfile != nil {
+			allErrs = append(allErrs, field.Forbidden(osDiskPath,
+				"securityProfile can only be set on the OS disk when securityType is ConfidentialVM"))
+		}
+		return allErrs
+	}
+
+	if uefiSettings == nil || uefiSettings.VTpmEnabled == nil || !*uefiSettings.VTpmEnabled {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("uefiSettings", "vTpmEnabled"), vTpmEnabled(uefiSettings),
+			"vTpmEnabled must be true when securityType is ConfidentialVM"))
+	}
+
+	if diskSecurityProfile == nil || diskSecurityProfile.SecurityEncryptionType == "" {
+		allErrs = append(allErrs, field.Required(osDiskPath.Child("securityEncryptionType"),
+			"securityEncryptionType must be set when securityType is ConfidentialVM"))
+		return allErrs
+	}
+
+	if osDisk.DiffDiskSettings != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("osDisk", "diffDiskSettings"),
+			"ephemeral OS disks are not supported when securityType is ConfidentialVM"))
+	}
+
+	if diskSecurityProfile.SecurityEncryptionType == SecurityEncryptionTypeDiskWithVMGuestState {
+		if uefiSettings == nil || uefiSettings.SecureBootEnabled == nil || !*uefiSettings.SecureBootEnabled {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("uefiSettings", "secureBootEnabled"), secureBootEnabled(uefiSettings),
+				"secureBootEnabled must be true when securityEncryptionType is DiskWithVMGuestState"))
+		}
+		if securityProfile.EncryptionAtHost != nil && *securityProfile.EncryptionAtHost {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("encryptionAtHost"),
+				"encryptionAtHost cannot be enabled when securityEncryptionType is DiskWithVMGuestState"))
+		}
+	} else if diskSecurityProfile.DiskEncryptionSet != nil {
+		allErrs = append(allErrs, field.Forbidden(osDiskPath.Child("diskEncryptionSet"),
+			"diskEncryptionSet can only be set when securityEncryptionType is DiskWithVMGuestState"))
+	}
+
+	return allErrs
+}
+
+func vTpmEnabled(uefiSettings *UefiSettings) *bool {
+	if uefiSettings == nil {
+		return nil
+	}
+	return uefiSettings.VTpmEnabled
+}
+
+func secureBootEnabled(uefiSettings *UefiSettings) *bool {
+	if uefiSettings == nil {
+		return nil
+	}
+	return uefiSettings.SecureBootEnabled
+}
+
 // ValidateDataDisksUpdate validates updates to Data disks.
+// Data disks can be grown and new data disks can be added, but existing data disks cannot be removed or otherwise modified
+// apart from their delete policy.
 func ValidateDataDisksUpdate(oldDataDisks, newDataDisks []DataDisk, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	diskErrMsg := "adding/removing data disks after machine creation is not allowed"
+	diskErrMsg := "removing data disks after machine creation is not allowed"
 	fieldErrMsg := "modifying data disk's fields after machine creation is not allowed"
 
-	if len(oldDataDisks) != len(newDataDisks) {
-		allErrs = append(allErrs, field.Invalid(fieldPath, newDataDisks, diskErrMsg))
-		return allErrs
+	newDisks := make(map[string]DataDisk)
+	for _, disk := range newDataDisks {
+		newDisks[disk.NameSuffix] = disk
 	}
 
-	oldDisks := make(map[string]DataDisk)
+	for _, oldDisk := range oldDataDisks {
+		if _, ok := newDisks[oldDisk.NameSuffix]; !ok {
+			allErrs = append(allErrs, field.Invalid(fieldPath, newDataDisks, diskErrMsg))
+			return allErrs
+		}
+	}
 
+	oldDisks := make(map[string]DataDisk)
 	for _, disk := range oldDataDisks {
 		oldDisks[disk.NameSuffix] = disk
 	}
 
 	for i, newDisk := range newDataDisks {
-		if oldDisk, ok := oldDisks[newDisk.NameSuffix]; ok {
-			if newDisk.DiskSizeGB != oldDisk.DiskSizeGB {
-				allErrs = append(allErrs, field.Invalid(fieldPath.Index(i).Child("diskSizeGB"), newDataDisks, fieldErrMsg))
-			}
+		oldDisk, ok := oldDisks[newDisk.NameSuffix]
+		if !ok {
+			// new data disks are validated together with the existing ones below.
+			continue
+		}
 
-			allErrs = append(allErrs, validateManagedDisksUpdate(oldDisk.ManagedDisk, newDisk.ManagedDisk, fieldPath.Index(i).Child("managedDisk"))...)
+		if newDisk.DiskSizeGB < oldDisk.DiskSizeGB {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Index(i).Child("diskSizeGB"), newDisk.DiskSizeGB, "data disks can only be grown after machine creation"))
+		}
 
-			if (newDisk.Lun != nil && oldDisk.Lun != nil) && (*newDisk.Lun != *oldDisk.Lun) {
-				allErrs = append(allErrs, field.Invalid(fieldPath.Index(i).Child("lun"), newDataDisks, fieldErrMsg))
-			} else if (newDisk.Lun != nil && oldDisk.Lun == nil) || (newDisk.Lun == nil && oldDisk.Lun != nil) {
-				allErrs = append(allErrs, field.Invalid(fieldPath.Index(i).Child("lun"), newDataDisks, fieldErrMsg))
-			}
+		allErrs = append(allErrs, validateManagedDisksUpdate(oldDisk.ManagedDisk, newDisk.ManagedDisk, fieldPath.Index(i).Child("managedDisk"))...)
 
-			if newDisk.CachingType != oldDisk.CachingType {
-				allErrs = append(allErrs, field.Invalid(fieldPath.Index(i).Child("cachingType"), newDataDisks, fieldErrMsg))
-			}
-		} else {
-			allErrs = append(allErrs, field.Invalid(fieldPath.Index(i).Child("nameSuffix"), newDataDisks, diskErrMsg))
+		if (newDisk.Lun != nil && oldDisk.Lun != nil) && (*newDisk.Lun != *oldDisk.Lun) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Index(i).Child("lun"), newDataDisks, fieldErrMsg))
+		} else if (newDisk.Lun != nil && oldDisk.Lun == nil) || (newDisk.Lun == nil && oldDisk.Lun != nil) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Index(i).Child("lun"), newDataDisks, fieldErrMsg))
 		}
+
+		if newDisk.CachingType != oldDisk.CachingType {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Index(i).Child("cachingType"), newDataDisks, fieldErrMsg))
+		}
+
+		if !reflect.DeepEqual(newDisk.DiskIOPSReadWrite, oldDisk.DiskIOPSReadWrite) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Index(i).Child("diskIOPSReadWrite"), newDataDisks, fieldErrMsg))
+		}
+
+		if !reflect.DeepEqual(newDisk.DiskMBpsReadWrite, oldDisk.DiskMBpsReadWrite) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Index(i).Child("diskMBpsReadWrite"), newDataDisks, fieldErrMsg))
+		}
+
+		if !reflect.DeepEqual(newDisk.LogicalSectorSize, oldDisk.LogicalSectorSize) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Index(i).Child("logicalSectorSize"), newDataDisks, fieldErrMsg))
+		}
+	}
+
+	if len(newDataDisks) > len(oldDataDisks) {
+		allErrs = append(allErrs, ValidateDataDisks(newDataDisks, fieldPath)...)
 	}
 
 	return allErrs
@@ -430,6 +430,72 @@ func TestAzureMachine_ValidateDataDisks(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid ultra disk with provisioned performance and logical sector size",
+			disks: []DataDisk{
+				{
+					NameSuffix: "my_disk_1",
+					DiskSizeGB: 256,
+					ManagedDisk: &ManagedDiskParameters{
+						StorageAccountType: string(compute.StorageAccountTypesUltraSSDLRS),
+					},
+					Lun:               to.Int32Ptr(0),
+					CachingType:       string(compute.CachingTypesNone),
+					DiskIOPSReadWrite: to.Int64Ptr(10000),
+					DiskMBpsReadWrite: to.Int64Ptr(400),
+					LogicalSectorSize: to.Int32Ptr(4096),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid provisioned performance on a premium disk",
+			disks: []DataDisk{
+				{
+					NameSuffix: "my_disk_1",
+					DiskSizeGB: 256,
+					ManagedDisk: &ManagedDiskParameters{
+						StorageAccountType: string(compute.StorageAccountTypesPremiumLRS),
+					},
+					Lun:               to.Int32Ptr(0),
+					CachingType:       string(compute.CachingTypesNone),
+					DiskIOPSReadWrite: to.Int64Ptr(10000),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid zero throughput on an ultra disk",
+			disks: []DataDisk{
+				{
+					NameSuffix: "my_disk_1",
+					DiskSizeGB: 256,
+					ManagedDisk: &ManagedDiskParameters{
+						StorageAccountType: string(compute.StorageAccountTypesUltraSSDLRS),
+					},
+					Lun:               to.Int32Ptr(0),
+					CachingType:       string(compute.CachingTypesNone),
+					DiskMBpsReadWrite: to.Int64Ptr(0),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid logical sector size on an ultra disk",
+			disks: []DataDisk{
+				{
+					NameSuffix: "my_disk_1",
+					DiskSizeGB: 256,
+					ManagedDisk: &ManagedDiskParameters{
+						StorageAccountType: string(compute.StorageAccountTypesUltraSSDLRS),
+					},
+					Lun:               to.Int32Ptr(0),
+					CachingType:       string(compute.CachingTypesNone),
+					LogicalSectorSize: to.Int32Ptr(1024),
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, test := range testcases {
@@ -499,6 +565,171 @@ func TestAzureMachine_ValidateSystemAssignedIdentity(t *testing.T) {
 	}
 }
 
+func TestAzureMachine_ValidateSecurityProfile(t *testing.T) {
+	g := NewWithT(t)
+
+	confidentialOSDisk := func(encryptionType SecurityEncryptionType) OSDisk {
+		osDisk := generateValidOSDisk()
+		osDisk.ManagedDisk.SecurityProfile = &VMDiskSecurityProfile{SecurityEncryptionType: encryptionType}
+		return osDisk
+	}
+
+	tests := []struct {
+		name            string
+		securityProfile *SecurityProfile
+		osDisk          OSDisk
+		dataDisks       []DataDisk
+		defaultImage    bool
+		wantErr         bool
+	}{
+		{
+			name:    "no security profile",
+			osDisk:  generateValidOSDisk(),
+			wantErr: false,
+		},
+		{
+			name:         "no security profile with the default image",
+			osDisk:       generateValidOSDisk(),
+			defaultImage: true,
+			wantErr:      false,
+		},
+		{
+			name: "trusted launch with the default image",
+			securityProfile: &SecurityProfile{
+				SecurityType: SecurityTypesTrustedLaunch,
+				UefiSettings: &UefiSettings{SecureBootEnabled: to.BoolPtr(true), VTpmEnabled: to.BoolPtr(true)},
+			},
+			osDisk:       generateValidOSDisk(),
+			defaultImage: true,
+			wantErr:      true,
+		},
+		{
+			name: "trusted launch with secure boot and vTPM",
+			securityProfile: &SecurityProfile{
+				SecurityType: SecurityTypesTrustedLaunch,
+				UefiSettings: &UefiSettings{SecureBootEnabled: to.BoolPtr(true), VTpmEnabled: to.BoolPtr(true)},
+			},
+			osDisk:  generateValidOSDisk(),
+			wantErr: false,
+		},
+		{
+			name: "uefi settings without security type",
+			securityProfile: &SecurityProfile{
+				UefiSettings: &UefiSettings{SecureBootEnabled: to.BoolPtr(true)},
+			},
+			osDisk:  generateValidOSDisk(),
+			wantErr: true,
+		},
+		{
+			name: "OS disk security profile without confidential VM",
+			securityProfile: &SecurityProfile{
+				SecurityType: SecurityTypesTrustedLaunch,
+			},
+			osDisk:  confidentialOSDisk(SecurityEncryptionTypeVMGuestStateOnly),
+			wantErr: true,
+		},
+		{
+			name: "confidential VM with VMGuestStateOnly encryption",
+			securityProfile: &SecurityProfile{
+				SecurityType: SecurityTypesConfidentialVM,
+				UefiSettings: &UefiSettings{VTpmEnabled: to.BoolPtr(true)},
+			},
+			osDisk:  confidentialOSDisk(SecurityEncryptionTypeVMGuestStateOnly),
+			wantErr: false,
+		},
+		{
+			name: "confidential VM without vTPM",
+			securityProfile: &SecurityProfile{
+				SecurityType: SecurityTypesConfidentialVM,
+				UefiSettings: &UefiSettings{SecureBootEnabled: to.BoolPtr(true)},
+			},
+			osDisk:  confidentialOSDisk(SecurityEncryptionTypeVMGuestStateOnly),
+			wantErr: true,
+		},
+		{
+			name: "confidential VM without OS disk security encryption type",
+			securityProfile: &SecurityProfile{
+				SecurityType: SecurityTypesConfidentialVM,
+				UefiSettings: &UefiSettings{VTpmEnabled: to.BoolPtr(true)},
+			},
+			osDisk:  generateValidOSDisk(),
+			wantErr: true,
+		},
+		{
+			name: "confidential VM with DiskWithVMGuestState encryption and secure boot",
+			securityProfile: &SecurityProfile{
+				SecurityType: SecurityTypesConfidentialVM,
+				UefiSettings: &UefiSettings{SecureBootEnabled: to.BoolPtr(true), VTpmEnabled: to.BoolPtr(true)},
+			},
+			osDisk:  confidentialOSDisk(SecurityEncryptionTypeDiskWithVMGuestState),
+			wantErr: false,
+		},
+		{
+			name: "confidential VM with DiskWithVMGuestState encryption without secure boot",
+			securityProfile: &SecurityProfile{
+				SecurityType: SecurityTypesConfidentialVM,
+				UefiSettings: &UefiSettings{VTpmEnabled: to.BoolPtr(true)},
+			},
+			osDisk:  confidentialOSDisk(SecurityEncryptionTypeDiskWithVMGuestState),
+			wantErr: true,
+		},
+		{
+			name: "confidential VM with DiskWithVMGuestState encryption and encryption at host",
+			securityProfile: &SecurityProfile{
+				EncryptionAtHost: to.BoolPtr(true),
+				SecurityType:     SecurityTypesConfidentialVM,
+				UefiSettings:     &UefiSettings{SecureBootEnabled: to.BoolPtr(true), VTpmEnabled: to.BoolPtr(true)},
+			},
+			osDisk:  confidentialOSDisk(SecurityEncryptionTypeDiskWithVMGuestState),
+			wantErr: true,
+		},
+		{
+			name: "confidential VM with a data disk security profile",
+			securityProfile: &SecurityProfile{
+				SecurityType: SecurityTypesConfidentialVM,
+				UefiSettings: &UefiSettings{VTpmEnabled: to.BoolPtr(true)},
+			},
+			osDisk: confidentialOSDisk(SecurityEncryptionTypeVMGuestStateOnly),
+			dataDisks: []DataDisk{
+				{
+					NameSuffix: "my_disk",
+					DiskSizeGB: 64,
+					Lun:        to.Int32Ptr(0),
+					ManagedDisk: &ManagedDiskParameters{
+						StorageAccountType: "Premium_LRS",
+						SecurityProfile:    &VMDiskSecurityProfile{SecurityEncryptionType: SecurityEncryptionTypeVMGuestStateOnly},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			image := &Image{
+				Marketplace: &AzureMarketplaceImage{
+					ImagePlan: ImagePlan{
+						Publisher: "Canonical",
+						Offer:     "0001-com-ubuntu-server-focal",
+						SKU:       "20_04-lts-gen2",
+					},
+					Version: "latest",
+				},
+			}
+			if tc.defaultImage {
+				image = nil
+			}
+			err := ValidateSecurityProfile(tc.securityProfile, image, tc.osDisk, tc.dataDisks, field.NewPath("securityProfile"))
+			if tc.wantErr {
+				g.Expect(err).NotTo(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
 func TestAzureMachine_ValidateDataDisksUpdate(t *testing.T) {
 	g := NewWithT(t)
 
@@ -612,7 +843,7 @@ func TestAzureMachine_ValidateDataDisksUpdate(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "data disks cannot be added after machine creation",
+			name: "data disks cannot be removed after machine creation",
 			disks: []DataDisk{
 				{
 					NameSuffix: "my_disk_1",
@@ -647,7 +878,7 @@ func TestAzureMachine_ValidateDataDisksUpdate(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "data disks cannot be removed after machine creation",
+			name: "data disks can be added after machine creation",
 			disks: []DataDisk{
 				{
 					NameSuffix: "my_disk_1",
@@ -679,6 +910,133 @@ func TestAzureMachine_ValidateDataDisksUpdate(t *testing.T) {
 					CachingType: string(compute.PossibleCachingTypesValues()[0]),
 				},
 			},
+			wantErr: false,
+		},
+		{
+			name: "data disks can be grown after machine creation",
+			disks: []DataDisk{
+				{
+					NameSuffix: "my_disk_1",
+					DiskSizeGB: 256,
+					ManagedDisk: &ManagedDiskParameters{
+						StorageAccountType: "Standard_LRS",
+					},
+					Lun:         to.Int32Ptr(0),
+					CachingType: string(compute.PossibleCachingTypesValues()[0]),
+				},
+			},
+			oldDisks: []DataDisk{
+				{
+					NameSuffix: "my_disk_1",
+					DiskSizeGB: 128,
+					ManagedDisk: &ManagedDiskParameters{
+						StorageAccountType: "Standard_LRS",
+					},
+					Lun:         to.Int32Ptr(0),
+					CachingType: string(compute.PossibleCachingTypesValues()[0]),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "data disks cannot be shrunk after machine creation",
+			disks: []DataDisk{
+				{
+					NameSuffix: "my_disk_1",
+					DiskSizeGB: 64,
+					ManagedDisk: &ManagedDiskParameters{
+						StorageAccountType: "Standard_LRS",
+					},
+					Lun:         to.Int32Ptr(0),
+					CachingType: string(compute.PossibleCachingTypesValues()[0]),
+				},
+			},
+			oldDisks: []DataDisk{
+				{
+					NameSuffix: "my_disk_1",
+					DiskSizeGB: 128,
+					ManagedDisk: &ManagedDiskParameters{
+						StorageAccountType: "Standard_LRS",
+					},
+					Lun:         to.Int32Ptr(0),
+					CachingType: string(compute.PossibleCachingTypesValues()[0]),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "added data disks must not reuse a lun",
+			disks: []DataDisk{
+				{
+					NameSuffix:  "my_disk_1",
+					DiskSizeGB:  64,
+					Lun:         to.Int32Ptr(0),
+					CachingType: string(compute.PossibleCachingTypesValues()[0]),
+				},
+				{
+					NameSuffix:  "my_disk_2",
+					DiskSizeGB:  64,
+					Lun:         to.Int32Ptr(0),
+					CachingType: string(compute.PossibleCachingTypesValues()[0]),
+				},
+			},
+			oldDisks: []DataDisk{
+				{
+					NameSuffix:  "my_disk_1",
+					DiskSizeGB:  64,
+					Lun:         to.Int32Ptr(0),
+					CachingType: string(compute.PossibleCachingTypesValues()[0]),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "delete policy can be changed after machine creation",
+			disks: []DataDisk{
+				{
+					NameSuffix:   "my_disk_1",
+					DiskSizeGB:   64,
+					Lun:          to.Int32Ptr(0),
+					CachingType:  string(compute.PossibleCachingTypesValues()[0]),
+					DeletePolicy: DiskDeletePolicyRetain,
+				},
+			},
+			oldDisks: []DataDisk{
+				{
+					NameSuffix:  "my_disk_1",
+					DiskSizeGB:  64,
+					Lun:         to.Int32Ptr(0),
+					CachingType: string(compute.PossibleCachingTypesValues()[0]),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid disk IOPS update",
+			disks: []DataDisk{
+				{
+					NameSuffix: "my_disk_1",
+					DiskSizeGB: 256,
+					ManagedDisk: &ManagedDiskParameters{
+						StorageAccountType: "UltraSSD_LRS",
+					},
+					Lun:               to.Int32Ptr(0),
+					CachingType:       string(compute.CachingTypesNone),
+					DiskIOPSReadWrite: to.Int64Ptr(20000),
+				},
+			},
+			oldDisks: []DataDisk{
+				{
+					NameSuffix: "my_disk_1",
+					DiskSizeGB: 256,
+					ManagedDisk: &ManagedDiskParameters{
+						StorageAccountType: "UltraSSD_LRS",
+					},
+					Lun:               to.Int32Ptr(0),
+					CachingType:       string(compute.CachingTypesNone),
+					DiskIOPSReadWrite: to.Int64Ptr(10000),
+				},
+			},
 			wantErr: true,
 		},
 	}
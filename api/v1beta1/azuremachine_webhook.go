@@ -95,12 +95,7 @@ func (m *AzureMachine) ValidateUpdate(oldRaw runtime.Object) error {
 		)
 	}
 
-	if !reflect.DeepEqual(m.Spec.DataDisks, old.Spec.DataDisks) {
-		allErrs = append(allErrs,
-			field.Invalid(field.NewPath("spec", "dataDisks"),
-				m.Spec.DataDisks, "field is immutable"),
-		)
-	}
+	allErrs = append(allErrs, ValidateDataDisksUpdate(old.Spec.DataDisks, m.Spec.DataDisks, field.NewPath("spec", "dataDisks"))...)
 
 	if !reflect.DeepEqual(m.Spec.SSHPublicKey, old.Spec.SSHPublicKey) {
 		allErrs = append(allErrs,
@@ -290,7 +290,7 @@ func TestAzureMachine_ValidateUpdate(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "invalidTest: azuremachine.spec.DataDisks is immutable",
+			name: "invalidTest: azuremachine.spec.DataDisks cannot be shrunk",
 			oldMachine: &AzureMachine{
 				Spec: AzureMachineSpec{
 					DataDisks: []DataDisk{
@@ -312,7 +312,7 @@ func TestAzureMachine_ValidateUpdate(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "validTest: azuremachine.spec.DataDisks is immutable",
+			name: "validTest: azuremachine.spec.DataDisks is unchanged",
 			oldMachine: &AzureMachine{
 				Spec: AzureMachineSpec{
 					DataDisks: []DataDisk{
@@ -333,6 +333,28 @@ func TestAzureMachine_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "validTest: azuremachine.spec.DataDisks can be grown",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					DataDisks: []DataDisk{
+						{
+							DiskSizeGB: 128,
+						},
+					},
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					DataDisks: []DataDisk{
+						{
+							DiskSizeGB: 256,
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "invalidTest: azuremachine.spec.SSHPublicKey is immutable",
 			oldMachine: &AzureMachine{
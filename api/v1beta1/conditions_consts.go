@@ -48,6 +48,9 @@ const (
 	BootstrapInProgressReason = "BootstrapInProgress"
 	// BootstrapFailedReason is used to indicate the bootstrap process ran into an error.
 	BootstrapFailedReason = "BootstrapFailed"
+	// VMAdoptedCondition means an existing VM matches its AzureMachine and is owned by CAPZ, with its network
+	// interfaces, disks and public IPs.
+	VMAdoptedCondition clusterv1.ConditionType = "VMAdopted"
 )
 
 // AzureMachinePool Conditions and Reasons.
@@ -82,6 +85,25 @@ const (
 	ManagedClusterRunningCondition clusterv1.ConditionType = "ManagedClusterRunning"
 	// AgentPoolsReadyCondition means the AKS agent pools exist and are ready to be used.
 	AgentPoolsReadyCondition clusterv1.ConditionType = "AgentPoolsReady"
+	// MaintenanceConfigurationsReadyCondition means the AKS planned maintenance configurations exist and are up to date.
+	MaintenanceConfigurationsReadyCondition clusterv1.ConditionType = "MaintenanceConfigurationsReady"
+	// NodePoolsUpgradedCondition means all the AKS node pools run the Kubernetes version of their MachinePool.
+	NodePoolsUpgradedCondition clusterv1.ConditionType = "NodePoolsUpgraded"
+	// AgentPoolUpgradedCondition means the AKS agent pool runs the Kubernetes version of its MachinePool.
+	AgentPoolUpgradedCondition clusterv1.ConditionType = "AgentPoolUpgraded"
+	// ManagedClusterAdoptedCondition means an existing AKS cluster matches its AzureManagedControlPlane and is owned by CAPZ.
+	ManagedClusterAdoptedCondition clusterv1.ConditionType = "ManagedClusterAdopted"
+
+	// WaitingForControlPlaneUpgradeReason means the node pools wait for the control plane to run their version.
+	WaitingForControlPlaneUpgradeReason = "WaitingForControlPlaneUpgrade"
+	// WaitingForNodePoolUpgradesReason means the node pool waits for the node pools upgraded before it.
+	WaitingForNodePoolUpgradesReason = "WaitingForNodePoolUpgrades"
+	// NodePoolUpgradingReason means a node pool is being upgraded.
+	NodePoolUpgradingReason = "NodePoolUpgrading"
+	// VersionSkewReason means the version of a node pool is not supported by the version of the control plane.
+	VersionSkewReason = "VersionSkew"
+	// AdoptionFailedReason means an existing AKS cluster could not be adopted.
+	AdoptionFailedReason = "AdoptionFailed"
 )
 
 // Azure Services Conditions and Reasons.
@@ -122,6 +144,12 @@ const (
 	DisksReadyCondition clusterv1.ConditionType = "DisksReady"
 	// NetworkInterfaceReadyCondition means the network interfaces exist and are ready to be used.
 	NetworkInterfaceReadyCondition clusterv1.ConditionType = "NetworkInterfacesReady"
+	// ChangesAppliedCondition means the desired state has been applied to the Azure resources. It is only set in
+	// dry-run mode, where it is false when changes were planned but not applied.
+	ChangesAppliedCondition clusterv1.ConditionType = "ChangesApplied"
+	// DriftDetectedCondition means Azure resources managed by CAPZ were modified outside of CAPZ. It is true when the
+	// changes were detected but not reverted because of the DriftPolicy.
+	DriftDetectedCondition clusterv1.ConditionType = "DriftDetected"
 
 	// CreatingReason means the resource is being created.
 	CreatingReason = "Creating"
@@ -135,4 +163,10 @@ const (
 	DeletionFailedReason = "DeletionFailed"
 	// UpdatingReason means the resource is being updated.
 	UpdatingReason = "Updating"
+	// DryRunReason means the resource has changes that were not applied because of dry-run mode.
+	DryRunReason = "DryRun"
+	// DriftReportedReason means changes made outside of CAPZ were detected and left as they are.
+	DriftReportedReason = "DriftReported"
+	// DriftRemediatedReason means changes made outside of CAPZ were detected and reverted.
+	DriftRemediatedReason = "DriftRemediated"
 )
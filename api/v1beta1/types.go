@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
@@ -108,6 +109,8 @@ type VnetSpec struct {
 	// +optional
 	Peerings VnetPeerings `json:"peerings,omitempty"`
 
+	SubscriptionReference `json:",inline"`
+
 	VnetClassSpec `json:",inline"`
 }
 
@@ -124,6 +127,34 @@ type VnetPeeringClassSpec struct {
 
 	// RemoteVnetName defines name of the remote virtual network.
 	RemoteVnetName string `json:"remoteVnetName"`
+
+	SubscriptionReference `json:",inline"`
+}
+
+// SubscriptionReference specifies the subscription of Azure resources which are not in the subscription of the
+// AzureCluster, e.g. a hub virtual network in a central connectivity subscription, and the identity used to manage them.
+type SubscriptionReference struct {
+	// SubscriptionID is the ID of the subscription of the resource.
+	// Defaults to the subscription of the AzureCluster.
+	// +optional
+	SubscriptionID string `json:"subscriptionID,omitempty"`
+
+	// IdentityRef is a reference to the AzureClusterIdentity used to manage the resources of the subscription.
+	// All the references to a subscription must use the same identity.
+	// Defaults to the identity of the AzureCluster.
+	// +optional
+	IdentityRef *corev1.ObjectReference `json:"identityRef,omitempty"`
+}
+
+// PrivateDNSZoneReference specifies an existing Azure Private DNS zone outside of the resource group of the
+// AzureCluster, e.g. a zone shared by several clusters in a central connectivity subscription.
+type PrivateDNSZoneReference struct {
+	// ResourceGroup is the name of the resource group of the private DNS zone.
+	// Defaults to the resource group of the AzureCluster.
+	// +optional
+	ResourceGroup string `json:"resourceGroup,omitempty"`
+
+	SubscriptionReference `json:",inline"`
 }
 
 // VnetPeerings is a slice of VnetPeering.
@@ -491,7 +522,7 @@ const (
 )
 
 // IdentityType represents different types of identities.
-// +kubebuilder:validation:Enum=ServicePrincipal;UserAssignedMSI;ManualServicePrincipal;ServicePrincipalCertificate
+// +kubebuilder:validation:Enum=ServicePrincipal;UserAssignedMSI;ManualServicePrincipal;ServicePrincipalCertificate;WorkloadIdentity
 type IdentityType string
 
 const (
@@ -506,6 +537,22 @@ const (
 
 	// ServicePrincipalCertificate represents a service principal using a certificate as secret.
 	ServicePrincipalCertificate IdentityType = "ServicePrincipalCertificate"
+
+	// WorkloadIdentity represents a service principal or user-assigned managed identity authenticated with a
+	// federated credential trusting the projected service account token of the controller.
+	WorkloadIdentity IdentityType = "WorkloadIdentity"
+)
+
+// DriftPolicy defines what happens to Azure resources modified outside of CAPZ.
+// +kubebuilder:validation:Enum=Remediate;Report
+type DriftPolicy string
+
+const (
+	// DriftPolicyRemediate reverts the changes made outside of CAPZ to the fields CAPZ owns.
+	DriftPolicyRemediate DriftPolicy = "Remediate"
+
+	// DriftPolicyReport leaves the resources modified outside of CAPZ as they are and reports the changes.
+	DriftPolicyReport DriftPolicy = "Report"
 )
 
 // OSDisk defines the operating system disk for a VM.
@@ -548,6 +595,43 @@ type DataDisk struct {
 	// +optional
 	// +kubebuilder:validation:Enum=None;ReadOnly;ReadWrite
 	CachingType string `json:"cachingType,omitempty"`
+	// DiskIOPSReadWrite is the number of IOPS provisioned for the data disk.
+	// It can only be set on UltraSSD_LRS disks.
+	// +optional
+	DiskIOPSReadWrite *int64 `json:"diskIOPSReadWrite,omitempty"`
+	// DiskMBpsReadWrite is the throughput in MBps provisioned for the data disk.
+	// It can only be set on UltraSSD_LRS disks.
+	// +optional
+	DiskMBpsReadWrite *int64 `json:"diskMBpsReadWrite,omitempty"`
+	// LogicalSectorSize is the logical sector size in bytes of the data disk.
+	// It can only be set on UltraSSD_LRS disks and is not supported on AzureMachinePools.
+	// +kubebuilder:validation:Enum=512;4096
+	// +optional
+	LogicalSectorSize *int32 `json:"logicalSectorSize,omitempty"`
+	// DeletePolicy specifies what happens to the data disk when the machine is deleted.
+	// Delete removes the disk, Detach keeps it and Retain keeps it and releases it from the cluster.
+	// Defaults to Delete. It is not supported on AzureMachinePools.
+	// +kubebuilder:validation:Enum=Delete;Detach;Retain
+	// +optional
+	DeletePolicy DiskDeletePolicy `json:"deletePolicy,omitempty"`
+}
+
+// DiskDeletePolicy specifies what happens to a data disk when its machine is deleted.
+type DiskDeletePolicy string
+
+const (
+	// DiskDeletePolicyDelete deletes the data disk together with the machine.
+	DiskDeletePolicyDelete DiskDeletePolicy = "Delete"
+	// DiskDeletePolicyDetach keeps the data disk after the machine is deleted. The disk stays tagged as owned by the cluster.
+	DiskDeletePolicyDetach DiskDeletePolicy = "Detach"
+	// DiskDeletePolicyRetain keeps the data disk after the machine is deleted and removes its cluster ownership tag.
+	DiskDeletePolicyRetain DiskDeletePolicy = "Retain"
+)
+
+// HasProvisionedPerformance returns true if the data disk sets its IOPS, throughput or logical sector size.
+// Such a disk is created before the virtual machine it is attached to.
+func (d DataDisk) HasProvisionedPerformance() bool {
+	return d.DiskIOPSReadWrite != nil || d.DiskMBpsReadWrite != nil || d.LogicalSectorSize != nil
 }
 
 // VMExtension specifies the parameters for a custom VM extension.
@@ -572,6 +656,35 @@ type ManagedDiskParameters struct {
 	StorageAccountType string `json:"storageAccountType,omitempty"`
 	// +optional
 	DiskEncryptionSet *DiskEncryptionSetParameters `json:"diskEncryptionSet,omitempty"`
+	// SecurityProfile specifies the security profile for the managed disk.
+	// It is only supported on the OS disk of a confidential virtual machine.
+	// +optional
+	SecurityProfile *VMDiskSecurityProfile `json:"securityProfile,omitempty"`
+}
+
+// SecurityEncryptionType specifies the encryption type of the managed disk of a confidential virtual machine.
+type SecurityEncryptionType string
+
+const (
+	// SecurityEncryptionTypeVMGuestStateOnly encrypts only the VMGuestState blob of the managed disk.
+	SecurityEncryptionTypeVMGuestStateOnly SecurityEncryptionType = "VMGuestStateOnly"
+	// SecurityEncryptionTypeDiskWithVMGuestState encrypts the managed disk along with the VMGuestState blob.
+	SecurityEncryptionTypeDiskWithVMGuestState SecurityEncryptionType = "DiskWithVMGuestState"
+)
+
+// VMDiskSecurityProfile specifies the security profile settings for the managed disk of a confidential virtual machine.
+type VMDiskSecurityProfile struct {
+	// DiskEncryptionSet specifies the customer managed disk encryption set used to encrypt the managed disk
+	// and the VMGuestState blob.
+	// +optional
+	DiskEncryptionSet *DiskEncryptionSetParameters `json:"diskEncryptionSet,omitempty"`
+	// SecurityEncryptionType specifies the encryption type of the managed disk.
+	// It is set to DiskWithVMGuestState to encrypt the managed disk along with the VMGuestState blob,
+	// and to VMGuestStateOnly to encrypt only the VMGuestState blob.
+	// It can only be set when the SecurityType of the virtual machine is ConfidentialVM.
+	// +kubebuilder:validation:Enum=VMGuestStateOnly;DiskWithVMGuestState
+	// +optional
+	SecurityEncryptionType SecurityEncryptionType `json:"securityEncryptionType,omitempty"`
 }
 
 // DiskEncryptionSetParameters defines disk encryption options.
@@ -587,8 +700,23 @@ type DiffDiskSettings struct {
 	// See https://docs.microsoft.com/en-us/azure/virtual-machines/ephemeral-os-disks for full details
 	// +kubebuilder:validation:Enum=Local
 	Option string `json:"option"`
+	// Placement specifies where the ephemeral OS disk is placed, either on the cache disk or on the
+	// resource (temp) disk of the VM. Azure defaults to the cache disk.
+	// +kubebuilder:validation:Enum=CacheDisk;ResourceDisk
+	// +optional
+	Placement DiffDiskPlacement `json:"placement,omitempty"`
 }
 
+// DiffDiskPlacement specifies the placement of an ephemeral OS disk.
+type DiffDiskPlacement string
+
+const (
+	// DiffDiskPlacementCacheDisk places the ephemeral OS disk on the cache disk of the VM.
+	DiffDiskPlacementCacheDisk DiffDiskPlacement = "CacheDisk"
+	// DiffDiskPlacementResourceDisk places the ephemeral OS disk on the resource (temp) disk of the VM.
+	DiffDiskPlacementResourceDisk DiffDiskPlacement = "ResourceDisk"
+)
+
 // SubnetRole defines the unique role of a subnet.
 type SubnetRole string
 
@@ -673,6 +801,42 @@ type SecurityProfile struct {
 	// set. Default is disabled.
 	// +optional
 	EncryptionAtHost *bool `json:"encryptionAtHost,omitempty"`
+	// SecurityType specifies the security type of the virtual machine or virtual machine scale set.
+	// It must be set to TrustedLaunch or ConfidentialVM to enable UefiSettings.
+	// +kubebuilder:validation:Enum=TrustedLaunch;ConfidentialVM
+	// +optional
+	SecurityType SecurityTypes `json:"securityType,omitempty"`
+	// UefiSettings specifies the security settings like secure boot and vTPM used while creating
+	// the virtual machine or virtual machine scale set.
+	// +optional
+	UefiSettings *UefiSettings `json:"uefiSettings,omitempty"`
+}
+
+// SecurityTypes specifies the security type of a virtual machine or virtual machine scale set.
+type SecurityTypes string
+
+const (
+	// SecurityTypesTrustedLaunch enables trusted launch, which protects against boot kits, rootkits
+	// and kernel-level malware.
+	SecurityTypesTrustedLaunch SecurityTypes = "TrustedLaunch"
+	// SecurityTypesConfidentialVM enables confidential computing, which encrypts the memory and the
+	// guest state of the virtual machine.
+	SecurityTypesConfidentialVM SecurityTypes = "ConfidentialVM"
+)
+
+// UefiSettings specifies the security settings like secure boot and vTPM used while creating
+// a virtual machine or virtual machine scale set.
+type UefiSettings struct {
+	// SecureBootEnabled specifies whether secure boot should be enabled on the virtual machine.
+	// Secure boot verifies the digital signature of all boot components and halts the boot
+	// process if signature verification fails.
+	// +optional
+	SecureBootEnabled *bool `json:"secureBootEnabled,omitempty"`
+	// VTpmEnabled specifies whether vTPM should be enabled on the virtual machine.
+	// When true it enables the virtualized trusted platform module measurements to create
+	// a known good boot integrity policy baseline.
+	// +optional
+	VTpmEnabled *bool `json:"vTpmEnabled,omitempty"`
 }
 
 // AddressRecord specifies a DNS record mapping a hostname to an IPV4 or IPv6 address.
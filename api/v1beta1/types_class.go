@@ -50,6 +50,13 @@ type AzureClusterClassSpec struct {
 	// Note: All cloud provider config values can be customized by creating the secret beforehand. CloudProviderConfigOverrides is only used when the secret is managed by the Azure Provider.
 	// +optional
 	CloudProviderConfigOverrides *CloudProviderConfigOverrides `json:"cloudProviderConfigOverrides,omitempty"`
+
+	// DriftPolicy defines what happens when Azure resources managed by CAPZ, such as security group rules, load balancer
+	// rules or subnet associations, are modified outside of CAPZ. "Remediate" reverts the changes to the fields CAPZ owns,
+	// "Report" leaves the resources as they are and reports the changes in the DriftDetected condition.
+	// Defaults to "Remediate".
+	// +optional
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
 }
 
 // NetworkClassSpec defines the NetworkSpec properties that may be shared across several Azure clusters.
@@ -57,6 +64,11 @@ type NetworkClassSpec struct {
 	// PrivateDNSZoneName defines the zone name for the Azure Private DNS.
 	// +optional
 	PrivateDNSZoneName string `json:"privateDNSZoneName,omitempty"`
+
+	// PrivateDNSZone specifies where the Azure Private DNS zone is when it is not in the resource group of the
+	// AzureCluster.
+	// +optional
+	PrivateDNSZone *PrivateDNSZoneReference `json:"privateDNSZone,omitempty"`
 }
 
 // VnetClassSpec defines the VnetSpec properties that may be shared across several Azure clusters.
@@ -964,6 +964,21 @@ func (in *DataDisk) DeepCopyInto(out *DataDisk) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.DiskIOPSReadWrite != nil {
+		in, out := &in.DiskIOPSReadWrite, &out.DiskIOPSReadWrite
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DiskMBpsReadWrite != nil {
+		in, out := &in.DiskMBpsReadWrite, &out.DiskMBpsReadWrite
+		*out = new(int64)
+		**out = **in
+	}
+	if in.LogicalSectorSize != nil {
+		in, out := &in.LogicalSectorSize, &out.LogicalSectorSize
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataDisk.
@@ -1197,6 +1212,11 @@ func (in *ManagedDiskParameters) DeepCopyInto(out *ManagedDiskParameters) {
 		*out = new(DiskEncryptionSetParameters)
 		**out = **in
 	}
+	if in.SecurityProfile != nil {
+		in, out := &in.SecurityProfile, &out.SecurityProfile
+		*out = new(VMDiskSecurityProfile)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedDiskParameters.
@@ -1244,6 +1264,11 @@ func (in *NatGatewayClassSpec) DeepCopy() *NatGatewayClassSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkClassSpec) DeepCopyInto(out *NetworkClassSpec) {
 	*out = *in
+	if in.PrivateDNSZone != nil {
+		in, out := &in.PrivateDNSZone, &out.PrivateDNSZone
+		*out = new(PrivateDNSZoneReference)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkClassSpec.
@@ -1278,7 +1303,7 @@ func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
 		*out = new(LoadBalancerSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	out.NetworkClassSpec = in.NetworkClassSpec
+	in.NetworkClassSpec.DeepCopyInto(&out.NetworkClassSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
@@ -1294,7 +1319,7 @@ func (in *NetworkSpec) DeepCopy() *NetworkSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkTemplateSpec) DeepCopyInto(out *NetworkTemplateSpec) {
 	*out = *in
-	out.NetworkClassSpec = in.NetworkClassSpec
+	in.NetworkClassSpec.DeepCopyInto(&out.NetworkClassSpec)
 	in.Vnet.DeepCopyInto(&out.Vnet)
 	if in.Subnets != nil {
 		in, out := &in.Subnets, &out.Subnets
@@ -1356,6 +1381,22 @@ func (in *OSDisk) DeepCopy() *OSDisk {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateDNSZoneReference) DeepCopyInto(out *PrivateDNSZoneReference) {
+	*out = *in
+	in.SubscriptionReference.DeepCopyInto(&out.SubscriptionReference)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateDNSZoneReference.
+func (in *PrivateDNSZoneReference) DeepCopy() *PrivateDNSZoneReference {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateDNSZoneReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PublicIPSpec) DeepCopyInto(out *PublicIPSpec) {
 	*out = *in
@@ -1485,6 +1526,11 @@ func (in *SecurityProfile) DeepCopyInto(out *SecurityProfile) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.UefiSettings != nil {
+		in, out := &in.UefiSettings, &out.UefiSettings
+		*out = new(UefiSettings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityProfile.
@@ -1725,6 +1771,26 @@ func (in Subnets) DeepCopy() Subnets {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubscriptionReference) DeepCopyInto(out *SubscriptionReference) {
+	*out = *in
+	if in.IdentityRef != nil {
+		in, out := &in.IdentityRef, &out.IdentityRef
+		*out = new(corev1.ObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionReference.
+func (in *SubscriptionReference) DeepCopy() *SubscriptionReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SubscriptionReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in Tags) DeepCopyInto(out *Tags) {
 	{
@@ -1746,6 +1812,31 @@ func (in Tags) DeepCopy() Tags {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UefiSettings) DeepCopyInto(out *UefiSettings) {
+	*out = *in
+	if in.SecureBootEnabled != nil {
+		in, out := &in.SecureBootEnabled, &out.SecureBootEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VTpmEnabled != nil {
+		in, out := &in.VTpmEnabled, &out.VTpmEnabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UefiSettings.
+func (in *UefiSettings) DeepCopy() *UefiSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(UefiSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserAssignedIdentity) DeepCopyInto(out *UserAssignedIdentity) {
 	*out = *in
@@ -1761,6 +1852,26 @@ func (in *UserAssignedIdentity) DeepCopy() *UserAssignedIdentity {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMDiskSecurityProfile) DeepCopyInto(out *VMDiskSecurityProfile) {
+	*out = *in
+	if in.DiskEncryptionSet != nil {
+		in, out := &in.DiskEncryptionSet, &out.DiskEncryptionSet
+		*out = new(DiskEncryptionSetParameters)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMDiskSecurityProfile.
+func (in *VMDiskSecurityProfile) DeepCopy() *VMDiskSecurityProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(VMDiskSecurityProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMExtension) DeepCopyInto(out *VMExtension) {
 	*out = *in
@@ -1820,6 +1931,7 @@ func (in *VnetClassSpec) DeepCopy() *VnetClassSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VnetPeeringClassSpec) DeepCopyInto(out *VnetPeeringClassSpec) {
 	*out = *in
+	in.SubscriptionReference.DeepCopyInto(&out.SubscriptionReference)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VnetPeeringClassSpec.
@@ -1835,7 +1947,7 @@ func (in *VnetPeeringClassSpec) DeepCopy() *VnetPeeringClassSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VnetPeeringSpec) DeepCopyInto(out *VnetPeeringSpec) {
 	*out = *in
-	out.VnetPeeringClassSpec = in.VnetPeeringClassSpec
+	in.VnetPeeringClassSpec.DeepCopyInto(&out.VnetPeeringClassSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VnetPeeringSpec.
@@ -1853,7 +1965,9 @@ func (in VnetPeerings) DeepCopyInto(out *VnetPeerings) {
 	{
 		in := &in
 		*out = make(VnetPeerings, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -1872,7 +1986,9 @@ func (in VnetPeeringsTemplateSpec) DeepCopyInto(out *VnetPeeringsTemplateSpec) {
 	{
 		in := &in
 		*out = make(VnetPeeringsTemplateSpec, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -1892,8 +2008,11 @@ func (in *VnetSpec) DeepCopyInto(out *VnetSpec) {
 	if in.Peerings != nil {
 		in, out := &in.Peerings, &out.Peerings
 		*out = make(VnetPeerings, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
+	in.SubscriptionReference.DeepCopyInto(&out.SubscriptionReference)
 	in.VnetClassSpec.DeepCopyInto(&out.VnetClassSpec)
 }
 
@@ -1914,7 +2033,9 @@ func (in *VnetTemplateSpec) DeepCopyInto(out *VnetTemplateSpec) {
 	if in.Peerings != nil {
 		in, out := &in.Peerings, &out.Peerings
 		*out = make(VnetPeeringsTemplateSpec, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
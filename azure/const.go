@@ -29,7 +29,27 @@ const (
 	// for annotation formatting rules.
 	RGTagsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-tags-rg"
 
+	// MaintenanceConfigurationsLastAppliedAnnotation is the key for the AzureManagedControlPlane object annotation
+	// which tracks the names of the maintenance configurations created by CAPZ, so that the ones removed from the spec
+	// can be deleted.
+	MaintenanceConfigurationsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-maintenance-configurations"
+
 	// ReplicasManagedByAutoscalerAnnotation is set to true in the corresponding capi machine pool
 	// when an external autoscaler manages the node count of the associated machine pool.
 	ReplicasManagedByAutoscalerAnnotation = "cluster.x-k8s.io/replicas-managed-by-autoscaler"
+
+	// DryRunAnnotation is set to "true" on an AzureCluster, AzureMachine or AzureManagedControlPlane to compute the
+	// changes to its Azure resources without applying them. The planned changes are reported in the
+	// ChangesApplied condition and in an event.
+	DryRunAnnotation = "sigs.k8s.io/cluster-api-provider-azure-dry-run"
+
+	// LastAppliedSpecsAnnotation is the key for the AzureCluster and AzureMachine object annotation which tracks the
+	// hashes of the specs last applied to their Azure resources, so that changes to the spec are not mistaken for
+	// changes made outside of CAPZ.
+	LastAppliedSpecsAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-specs"
+
+	// VMAdoptedResourcesAnnotation is the key for the AzureMachine object annotation which tracks the names of the
+	// network interfaces, disks and public IPs of an adopted VM, which are managed in place of the ones named after
+	// the AzureMachine.
+	VMAdoptedResourcesAnnotation = "sigs.k8s.io/cluster-api-provider-azure-adopted-resources"
 )
@@ -24,26 +24,35 @@ import (
 func AgentPoolToManagedClusterAgentPoolProfile(pool containerservice.AgentPool) containerservice.ManagedClusterAgentPoolProfile {
 	properties := pool.ManagedClusterAgentPoolProfileProperties
 	return containerservice.ManagedClusterAgentPoolProfile{
-		Name:                 pool.Name, // Note: if converting from agentPoolSpec.Parameters(), this field will not be set
-		VMSize:               properties.VMSize,
-		OsType:               properties.OsType,
-		OsDiskSizeGB:         properties.OsDiskSizeGB,
-		Count:                properties.Count,
-		Type:                 properties.Type,
-		OrchestratorVersion:  properties.OrchestratorVersion,
-		VnetSubnetID:         properties.VnetSubnetID,
-		Mode:                 properties.Mode,
-		EnableAutoScaling:    properties.EnableAutoScaling,
-		MaxCount:             properties.MaxCount,
-		MinCount:             properties.MinCount,
-		NodeTaints:           properties.NodeTaints,
-		AvailabilityZones:    properties.AvailabilityZones,
-		MaxPods:              properties.MaxPods,
-		OsDiskType:           properties.OsDiskType,
-		NodeLabels:           properties.NodeLabels,
-		EnableUltraSSD:       properties.EnableUltraSSD,
-		EnableNodePublicIP:   properties.EnableNodePublicIP,
-		NodePublicIPPrefixID: properties.NodePublicIPPrefixID,
-		ScaleSetPriority:     properties.ScaleSetPriority,
+		Name:                      pool.Name, // Note: if converting from agentPoolSpec.Parameters(), this field will not be set
+		VMSize:                    properties.VMSize,
+		OsType:                    properties.OsType,
+		OsDiskSizeGB:              properties.OsDiskSizeGB,
+		Count:                     properties.Count,
+		Type:                      properties.Type,
+		OrchestratorVersion:       properties.OrchestratorVersion,
+		VnetSubnetID:              properties.VnetSubnetID,
+		PodSubnetID:               properties.PodSubnetID,
+		Mode:                      properties.Mode,
+		EnableAutoScaling:         properties.EnableAutoScaling,
+		MaxCount:                  properties.MaxCount,
+		MinCount:                  properties.MinCount,
+		NodeTaints:                properties.NodeTaints,
+		AvailabilityZones:         properties.AvailabilityZones,
+		MaxPods:                   properties.MaxPods,
+		OsDiskType:                properties.OsDiskType,
+		NodeLabels:                properties.NodeLabels,
+		EnableUltraSSD:            properties.EnableUltraSSD,
+		EnableNodePublicIP:        properties.EnableNodePublicIP,
+		NodePublicIPPrefixID:      properties.NodePublicIPPrefixID,
+		ScaleSetPriority:          properties.ScaleSetPriority,
+		KubeletConfig:             properties.KubeletConfig,
+		LinuxOSConfig:             properties.LinuxOSConfig,
+		UpgradeSettings:           properties.UpgradeSettings,
+		SpotMaxPrice:              properties.SpotMaxPrice,
+		OsSKU:                     properties.OsSKU,
+		EnableEncryptionAtHost:    properties.EnableEncryptionAtHost,
+		ProximityPlacementGroupID: properties.ProximityPlacementGroupID,
+		EnableFIPS:                properties.EnableFIPS,
 	}
 }
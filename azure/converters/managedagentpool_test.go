@@ -81,6 +81,36 @@ func Test_AgentPoolToManagedClusterAgentPoolProfile(t *testing.T) {
 				}))
 			},
 		},
+		{
+			name: "Should set node configuration values correctly",
+			pool: containerservice.AgentPool{
+				Name: to.StringPtr("agentpool1"),
+				ManagedClusterAgentPoolProfileProperties: &containerservice.ManagedClusterAgentPoolProfileProperties{
+					KubeletConfig:             &containerservice.KubeletConfig{CPUManagerPolicy: to.StringPtr("static")},
+					LinuxOSConfig:             &containerservice.LinuxOSConfig{TransparentHugePageEnabled: to.StringPtr("never")},
+					UpgradeSettings:           &containerservice.AgentPoolUpgradeSettings{MaxSurge: to.StringPtr("33%")},
+					SpotMaxPrice:              to.Float64Ptr(-1),
+					OsSKU:                     containerservice.OSSKUCBLMariner,
+					EnableEncryptionAtHost:    to.BoolPtr(true),
+					ProximityPlacementGroupID: to.StringPtr("ppg-id"),
+					EnableFIPS:                to.BoolPtr(true),
+				},
+			},
+
+			expect: func(g *GomegaWithT, result containerservice.ManagedClusterAgentPoolProfile) {
+				g.Expect(result).To(Equal(containerservice.ManagedClusterAgentPoolProfile{
+					Name:                      to.StringPtr("agentpool1"),
+					KubeletConfig:             &containerservice.KubeletConfig{CPUManagerPolicy: to.StringPtr("static")},
+					LinuxOSConfig:             &containerservice.LinuxOSConfig{TransparentHugePageEnabled: to.StringPtr("never")},
+					UpgradeSettings:           &containerservice.AgentPoolUpgradeSettings{MaxSurge: to.StringPtr("33%")},
+					SpotMaxPrice:              to.Float64Ptr(-1),
+					OsSKU:                     containerservice.OSSKUCBLMariner,
+					EnableEncryptionAtHost:    to.BoolPtr(true),
+					ProximityPlacementGroupID: to.StringPtr("ppg-id"),
+					EnableFIPS:                to.BoolPtr(true),
+				}))
+			},
+		},
 	}
 
 	for _, c := range cases {
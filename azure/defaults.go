@@ -22,6 +22,8 @@ import (
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/ot"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/ratelimit"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	"sigs.k8s.io/cluster-api-provider-azure/version"
 )
@@ -281,6 +283,11 @@ func AvailabilitySetID(subscriptionID, resourceGroup, availabilitySetName string
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/availabilitySets/%s", subscriptionID, resourceGroup, availabilitySetName)
 }
 
+// DiskID returns the azure resource ID for a given managed disk.
+func DiskID(subscriptionID, resourceGroup, diskName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/disks/%s", subscriptionID, resourceGroup, diskName)
+}
+
 // GetBootstrappingVMExtension returns the CAPZ Bootstrapping VM extension.
 // The CAPZ Bootstrapping extension is a simple clone of https://github.com/Azure/custom-script-extension-linux for Linux or
 // https://docs.microsoft.com/en-us/azure/virtual-machines/extensions/custom-script-windows for Windows.
@@ -323,11 +330,23 @@ func UserAgent() string {
 
 // SetAutoRestClientDefaults set authorizer and user agent for autorest client.
 func SetAutoRestClientDefaults(c *autorest.Client, auth autorest.Authorizer) {
+	var limiter *ratelimit.Limiter
+	if rateLimited, ok := auth.(*ratelimit.Authorizer); ok {
+		auth, limiter = rateLimited.Authorizer, rateLimited.Limiter
+	}
 	c.Authorizer = auth
 	// Wrap the original Sender on the autorest.Client c.
 	// The wrapped Sender should set the x-ms-correlation-request-id on the given
 	// request, then pass the new request to the underlying Sender.
 	c.Sender = autorest.DecorateSender(c.Sender, msCorrelationIDSendDecorator)
+	// Propagate the trace context of the request to Azure.
+	c.Sender = autorest.DecorateSender(c.Sender, traceContextSendDecorator)
+	// Record the latency, status and ARM throttling budget of every request.
+	c.Sender = autorest.DecorateSender(c.Sender, ot.AzureAPIMetricsSendDecorator)
+	// Wait for the rate limiter shared by the clients using the same credentials, outside of the latency metrics.
+	if limiter != nil {
+		c.Sender = autorest.DecorateSender(c.Sender, limiter.SendDecorator)
+	}
 	// The default number of retries is 3. This means the client will attempt to retry operation results like resource
 	// conflicts (HTTP 409). For a reconciling controller, this is undesirable behavior since if the controller runs
 	// into an error reconciling, the controller would be better off to end with an error and try again later.
@@ -353,3 +372,10 @@ func msCorrelationIDSendDecorator(snd autorest.Sender) autorest.Sender {
 		return snd.Do(r)
 	})
 }
+
+func traceContextSendDecorator(snd autorest.Sender) autorest.Sender {
+	return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		tele.InjectTraceContext(r.Context(), r.Header)
+		return snd.Do(r)
+	})
+}
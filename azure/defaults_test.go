@@ -23,9 +23,15 @@ import (
 	"net/http/httptest"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
 	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/ratelimit"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
@@ -117,3 +123,69 @@ func TestMSCorrelationIDSendDecorator(t *testing.T) {
 		receivedReq.Header.Get(string(tele.CorrIDKeyVal)),
 	).To(Equal(string(corrID)))
 }
+
+func TestTraceContextSendDecorator(t *testing.T) {
+	g := NewWithT(t)
+
+	propagator := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	defer otel.SetTextMapPropagator(propagator)
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext)
+	member, err := baggage.NewMember("tenant", "secret")
+	g.Expect(err).NotTo(HaveOccurred())
+	bag, err := baggage.New(member)
+	g.Expect(err).NotTo(HaveOccurred())
+	ctx = baggage.ContextWithBaggage(ctx, bag)
+
+	var received http.Header
+	newSender := autorest.DecorateSender(autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		received = r.Header
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	}), traceContextSendDecorator)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", "/abc", http.NoBody)
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = newSender.Do(req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(received.Get("traceparent")).To(Equal("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"))
+	g.Expect(received.Get("baggage")).To(BeEmpty())
+}
+
+func TestSetAutoRestClientDefaultsRateLimited(t *testing.T) {
+	g := NewWithT(t)
+	defer ratelimit.Configure(ratelimit.Options{})
+	ratelimit.Configure(ratelimit.Options{ReadsPerHour: 3600})
+
+	auth := ratelimit.NewAuthorizer(autorest.NullAuthorizer{}, "key")
+	g.Expect(auth).To(BeAssignableToTypeOf(&ratelimit.Authorizer{}))
+
+	sent := 0
+	c := autorest.NewClientWithUserAgent("")
+	c.Sender = autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		sent++
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+	SetAutoRestClientDefaults(&c, auth)
+	g.Expect(c.Authorizer).To(Equal(autorest.NullAuthorizer{}))
+
+	// The burst of 360 reads is sent right away, the next read waits for the limiter.
+	for i := 0; i < 361; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/abc", http.NoBody)
+		g.Expect(err).NotTo(HaveOccurred())
+		_, err = c.Sender.Do(req)
+		cancel()
+		if i < 360 {
+			g.Expect(err).NotTo(HaveOccurred())
+		} else {
+			g.Expect(err).To(HaveOccurred())
+		}
+	}
+	g.Expect(sent).To(Equal(360))
+}
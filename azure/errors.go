@@ -19,10 +19,12 @@ package azure
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 )
 
@@ -153,3 +155,182 @@ func IsOperationNotDoneError(target error) bool {
 	}
 	return errors.As(target, &OperationNotDoneError{})
 }
+
+// DryRunError is used to represent a change to an Azure resource that was planned but not applied because of
+// dry-run mode.
+type DryRunError struct {
+	Change PlannedChange
+}
+
+// NewDryRunError returns a new DryRunError for a planned change.
+func NewDryRunError(change PlannedChange) DryRunError {
+	return DryRunError{
+		Change: change,
+	}
+}
+
+// Error returns the error represented as a string.
+func (dre DryRunError) Error() string {
+	return fmt.Sprintf("%s of Azure resource %s/%s was not applied in dry-run mode", dre.Change.Action, dre.Change.ResourceGroup, dre.Change.Resource)
+}
+
+// Is returns true if the target is a DryRunError.
+func (dre DryRunError) Is(target error) bool {
+	return IsDryRunError(target)
+}
+
+// IsDryRunError returns true if the target is a DryRunError.
+func IsDryRunError(target error) bool {
+	reconcileErr := &ReconcileError{}
+	if errors.As(target, reconcileErr) {
+		return IsDryRunError(reconcileErr.error)
+	}
+	return errors.As(target, &DryRunError{})
+}
+
+// ErrorCategory describes how CAPZ recovers from an error returned by Azure Resource Manager.
+type ErrorCategory string
+
+const (
+	// UnknownErrorCategory is used for errors which are not classified, such as not found errors that callers check
+	// for. They are retried with the default backoff.
+	UnknownErrorCategory ErrorCategory = "Unknown"
+	// TransientErrorCategory is used for errors which go away on their own, such as throttling or capacity errors.
+	// They are retried after the time requested by Azure.
+	TransientErrorCategory ErrorCategory = "Transient"
+	// UserActionableErrorCategory is used for errors which need an action from the user outside of CAPZ, such as a
+	// policy denial, a missing role assignment or an exhausted quota. They are retried with a long backoff.
+	UserActionableErrorCategory ErrorCategory = "UserActionable"
+	// TerminalErrorCategory is used for errors caused by an invalid spec, such as an invalid parameter or a VM size
+	// which is not available. They are not retried.
+	TerminalErrorCategory ErrorCategory = "Terminal"
+)
+
+// errorCategories maps the codes of Azure Resource Manager errors to their category.
+var errorCategories = map[string]ErrorCategory{
+	// Transient errors.
+	"AllocationFailed":                 TransientErrorCategory,
+	"AnotherOperationInProgress":       TransientErrorCategory,
+	"InternalServerError":              TransientErrorCategory,
+	"OperationPreempted":               TransientErrorCategory,
+	"OverconstrainedAllocationRequest": TransientErrorCategory,
+	"RetryableError":                   TransientErrorCategory,
+	"ServerTimeout":                    TransientErrorCategory,
+	"ServiceUnavailable":               TransientErrorCategory,
+	"SubscriptionRequestsThrottled":    TransientErrorCategory,
+	"TooManyRequests":                  TransientErrorCategory,
+	"ZonalAllocationFailed":            TransientErrorCategory,
+
+	// Errors which need an action from the user.
+	"AuthorizationFailed":               UserActionableErrorCategory,
+	"LinkedAuthorizationFailed":         UserActionableErrorCategory,
+	"MissingSubscriptionRegistration":   UserActionableErrorCategory,
+	"NetworkInterfaceCountLimitReached": UserActionableErrorCategory,
+	"OperationNotAllowed":               UserActionableErrorCategory,
+	"PublicIPCountLimitReached":         UserActionableErrorCategory,
+	"QuotaExceeded":                     UserActionableErrorCategory,
+	"ReadOnlyDisabledSubscription":      UserActionableErrorCategory,
+	"RequestDisallowedByPolicy":         UserActionableErrorCategory,
+	"ScopeLocked":                       UserActionableErrorCategory,
+	"SubscriptionNotRegistered":         UserActionableErrorCategory,
+
+	// Terminal errors.
+	"InvalidParameter":                                  TerminalErrorCategory,
+	"InvalidRequestContent":                             TerminalErrorCategory,
+	"InvalidRequestFormat":                              TerminalErrorCategory,
+	"InvalidResourceName":                               TerminalErrorCategory,
+	"InvalidTemplate":                                   TerminalErrorCategory,
+	"LinkedInvalidPropertyId":                           TerminalErrorCategory,
+	"PropertyChangeNotAllowed":                          TerminalErrorCategory,
+	"SkuNotAvailable":                                   TerminalErrorCategory,
+	"UnsupportedAvailabilityZone":                       TerminalErrorCategory,
+	"VMSizeIsNotPermittedToEnableAcceleratedNetworking": TerminalErrorCategory,
+}
+
+// ErrorCode returns the code of the Azure Resource Manager error wrapped by err, or an empty string if there is none.
+func ErrorCode(err error) string {
+	if serr := serviceError(err); serr != nil {
+		return serr.Code
+	}
+	return ""
+}
+
+// ClassifyError returns the category of the Azure Resource Manager error wrapped by err, which determines how it is
+// retried. The code of the error is used first, then the codes of its details, which carry the actual cause of
+// generic errors such as "DeploymentFailed". Errors without a known code are classified by their HTTP status code:
+// throttling and server errors are transient and other errors are unknown. The category of an aggregate of errors is
+// the most severe category of its errors.
+func ClassifyError(err error) ErrorCategory {
+	reconcileErr := &ReconcileError{}
+	if errors.As(err, reconcileErr) {
+		return ClassifyError(reconcileErr.error)
+	}
+	var agg kerrors.Aggregate
+	if errors.As(err, &agg) {
+		category := UnknownErrorCategory
+		for _, e := range agg.Errors() {
+			if c := ClassifyError(e); errorCategorySeverity[c] > errorCategorySeverity[category] {
+				category = c
+			}
+		}
+		return category
+	}
+
+	if serr := serviceError(err); serr != nil {
+		if category, ok := errorCategories[serr.Code]; ok {
+			return category
+		}
+		for _, detail := range serr.Details {
+			if code, ok := detail["code"].(string); ok {
+				if category, ok := errorCategories[code]; ok {
+					return category
+				}
+			}
+		}
+	}
+
+	derr := autorest.DetailedError{}
+	if errors.As(err, &derr) {
+		if code, ok := derr.StatusCode.(int); ok && (code == http.StatusTooManyRequests || code >= http.StatusInternalServerError) {
+			return TransientErrorCategory
+		}
+	}
+	return UnknownErrorCategory
+}
+
+// errorCategorySeverity orders the error categories from the one which is the easiest to recover from.
+var errorCategorySeverity = map[ErrorCategory]int{
+	UnknownErrorCategory:        0,
+	TransientErrorCategory:      1,
+	UserActionableErrorCategory: 2,
+	TerminalErrorCategory:       3,
+}
+
+// serviceError returns the Azure Resource Manager error wrapped by err, which is an azure.RequestError for failed
+// requests and an azure.ServiceError for failed long-running operations. For an aggregate of errors, it returns the
+// first one found.
+func serviceError(err error) *azure.ServiceError {
+	reconcileErr := &ReconcileError{}
+	if errors.As(err, reconcileErr) {
+		return serviceError(reconcileErr.error)
+	}
+	var agg kerrors.Aggregate
+	if errors.As(err, &agg) {
+		for _, e := range agg.Errors() {
+			if serr := serviceError(e); serr != nil {
+				return serr
+			}
+		}
+		return nil
+	}
+
+	rerr := &azure.RequestError{}
+	if errors.As(err, &rerr) && rerr.ServiceError != nil {
+		return rerr.ServiceError
+	}
+	serr := &azure.ServiceError{}
+	if errors.As(err, &serr) {
+		return serr
+	}
+	return nil
+}
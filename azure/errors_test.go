@@ -0,0 +1,135 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	. "github.com/onsi/gomega"
+	pkgerrors "github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+func requestError(statusCode int, code string) error {
+	return autorest.DetailedError{
+		StatusCode: statusCode,
+		Original:   &azure.RequestError{ServiceError: &azure.ServiceError{Code: code}},
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	cases := []struct {
+		name         string
+		err          error
+		expectedCode string
+		expected     ErrorCategory
+	}{
+		{
+			name:         "policy denial",
+			err:          pkgerrors.Wrap(requestError(http.StatusForbidden, "RequestDisallowedByPolicy"), "failed to create resource"),
+			expectedCode: "RequestDisallowedByPolicy",
+			expected:     UserActionableErrorCategory,
+		},
+		{
+			name:         "quota exhaustion",
+			err:          requestError(http.StatusConflict, "OperationNotAllowed"),
+			expectedCode: "OperationNotAllowed",
+			expected:     UserActionableErrorCategory,
+		},
+		{
+			name:         "invalid parameter",
+			err:          requestError(http.StatusBadRequest, "InvalidParameter"),
+			expectedCode: "InvalidParameter",
+			expected:     TerminalErrorCategory,
+		},
+		{
+			name:         "failed long-running operation",
+			err:          &azure.ServiceError{Code: "SkuNotAvailable"},
+			expectedCode: "SkuNotAvailable",
+			expected:     TerminalErrorCategory,
+		},
+		{
+			name: "error with a generic code is classified by its details",
+			err: &azure.ServiceError{
+				Code:    "DeploymentFailed",
+				Details: []map[string]interface{}{{"code": "Conflict"}, {"code": "QuotaExceeded"}},
+			},
+			expectedCode: "DeploymentFailed",
+			expected:     UserActionableErrorCategory,
+		},
+		{
+			name:         "allocation failure",
+			err:          requestError(http.StatusOK, "AllocationFailed"),
+			expectedCode: "AllocationFailed",
+			expected:     TransientErrorCategory,
+		},
+		{
+			name:     "throttling without a code",
+			err:      autorest.DetailedError{StatusCode: http.StatusTooManyRequests},
+			expected: TransientErrorCategory,
+		},
+		{
+			name:     "server error without a code",
+			err:      autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusBadGateway}, "Bad Gateway"),
+			expected: TransientErrorCategory,
+		},
+		{
+			name:         "not found errors are not classified",
+			err:          requestError(http.StatusNotFound, "ResourceNotFound"),
+			expectedCode: "ResourceNotFound",
+			expected:     UnknownErrorCategory,
+		},
+		{
+			name:     "errors not returned by Azure are not classified",
+			err:      errors.New("error"),
+			expected: UnknownErrorCategory,
+		},
+		{
+			name:         "reconcile errors are classified by the error they wrap",
+			err:          WithTransientError(requestError(http.StatusForbidden, "AuthorizationFailed"), time.Minute),
+			expectedCode: "AuthorizationFailed",
+			expected:     UserActionableErrorCategory,
+		},
+		{
+			name: "aggregates are classified by their most severe error",
+			err: WithTerminalError(kerrors.NewAggregate([]error{
+				errors.New("error"),
+				requestError(http.StatusConflict, "QuotaExceeded"),
+				requestError(http.StatusBadRequest, "InvalidParameter"),
+				requestError(http.StatusOK, "AllocationFailed"),
+			})),
+			expectedCode: "QuotaExceeded",
+			expected:     TerminalErrorCategory,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			g.Expect(ClassifyError(c.err)).To(Equal(c.expected))
+			g.Expect(ErrorCode(c.err)).To(Equal(c.expectedCode))
+		})
+	}
+}
@@ -37,6 +37,18 @@ type ServiceReconciler interface {
 	Reconciler
 }
 
+// DependentServiceReconciler is a ServiceReconciler which declares the names of the services that must be reconciled
+// before it, and deleted after it.
+type DependentServiceReconciler interface {
+	ServiceReconciler
+	DependsOn() []string
+}
+
+// Adopter is an Azure service which can bring existing resources created outside of CAPZ under its management.
+type Adopter interface {
+	Adopt(ctx context.Context) error
+}
+
 // Authorizer is an interface which can get the subscription ID, base URI, and authorizer for an Azure service.
 type Authorizer interface {
 	SubscriptionID() string
@@ -52,6 +64,7 @@ type Authorizer interface {
 // NetworkDescriber is an interface which can get common Azure Cluster Networking information.
 type NetworkDescriber interface {
 	Vnet() *infrav1.VnetSpec
+	VnetSubscriptionID() string
 	IsVnetManaged() bool
 	ControlPlaneSubnet() infrav1.SubnetSpec
 	Subnets() infrav1.Subnets
@@ -79,6 +92,7 @@ type ClusterDescriber interface {
 	AvailabilitySetEnabled() bool
 	CloudProviderConfigOverrides() *infrav1.CloudProviderConfigOverrides
 	FailureDomains() []string
+	DriftPolicy() infrav1.DriftPolicy
 }
 
 // AsyncStatusUpdater is an interface used to keep track of long running operations in Status that has Conditions and Futures.
@@ -118,6 +132,20 @@ type ResourceSpecGetter interface {
 	Parameters(existing interface{}) (params interface{}, err error)
 }
 
+// DriftDetector is implemented by the ResourceSpecGetters which can detect changes made outside of CAPZ to the fields
+// they own in an existing resource. Parameters of a DriftDetector must return the parameters reverting those changes.
+type DriftDetector interface {
+	// Drift returns the paths of the fields owned by the spec which differ in the existing resource.
+	Drift(existing interface{}) ([]string, error)
+}
+
+// ChangeDescriber is implemented by the ResourceSpecGetters whose parameters can't be compared with the existing
+// resource field by field, e.g. because they are patches rather than the desired resource.
+type ChangeDescriber interface {
+	// ChangedFields returns the paths of the fields of the existing resource which the parameters change.
+	ChangedFields(existing, parameters interface{}) ([]string, error)
+}
+
 // ResourceSpecGetterWithHeaders is a ResourceSpecGetter that can return custom headers to be added to API calls.
 type ResourceSpecGetterWithHeaders interface {
 	ResourceSpecGetter
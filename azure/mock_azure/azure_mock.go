@@ -161,6 +161,137 @@ func (mr *MockServiceReconcilerMockRecorder) Reconcile(ctx interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockServiceReconciler)(nil).Reconcile), ctx)
 }
 
+// MockDependentServiceReconciler is a mock of DependentServiceReconciler interface.
+type MockDependentServiceReconciler struct {
+	ctrl     *gomock.Controller
+	recorder *MockDependentServiceReconcilerMockRecorder
+}
+
+// MockDependentServiceReconcilerMockRecorder is the mock recorder for MockDependentServiceReconciler.
+type MockDependentServiceReconcilerMockRecorder struct {
+	mock *MockDependentServiceReconciler
+}
+
+// NewMockDependentServiceReconciler creates a new mock instance.
+func NewMockDependentServiceReconciler(ctrl *gomock.Controller) *MockDependentServiceReconciler {
+	mock := &MockDependentServiceReconciler{ctrl: ctrl}
+	mock.recorder = &MockDependentServiceReconcilerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDependentServiceReconciler) EXPECT() *MockDependentServiceReconcilerMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockDependentServiceReconciler) Delete(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDependentServiceReconcilerMockRecorder) Delete(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDependentServiceReconciler)(nil).Delete), ctx)
+}
+
+// DependsOn mocks base method.
+func (m *MockDependentServiceReconciler) DependsOn() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DependsOn")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// DependsOn indicates an expected call of DependsOn.
+func (mr *MockDependentServiceReconcilerMockRecorder) DependsOn() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DependsOn", reflect.TypeOf((*MockDependentServiceReconciler)(nil).DependsOn))
+}
+
+// IsManaged mocks base method.
+func (m *MockDependentServiceReconciler) IsManaged(ctx context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsManaged", ctx)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsManaged indicates an expected call of IsManaged.
+func (mr *MockDependentServiceReconcilerMockRecorder) IsManaged(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsManaged", reflect.TypeOf((*MockDependentServiceReconciler)(nil).IsManaged), ctx)
+}
+
+// Name mocks base method.
+func (m *MockDependentServiceReconciler) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockDependentServiceReconcilerMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockDependentServiceReconciler)(nil).Name))
+}
+
+// Reconcile mocks base method.
+func (m *MockDependentServiceReconciler) Reconcile(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockDependentServiceReconcilerMockRecorder) Reconcile(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockDependentServiceReconciler)(nil).Reconcile), ctx)
+}
+
+// MockAdopter is a mock of Adopter interface.
+type MockAdopter struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdopterMockRecorder
+}
+
+// MockAdopterMockRecorder is the mock recorder for MockAdopter.
+type MockAdopterMockRecorder struct {
+	mock *MockAdopter
+}
+
+// NewMockAdopter creates a new mock instance.
+func NewMockAdopter(ctrl *gomock.Controller) *MockAdopter {
+	mock := &MockAdopter{ctrl: ctrl}
+	mock.recorder = &MockAdopterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdopter) EXPECT() *MockAdopterMockRecorder {
+	return m.recorder
+}
+
+// Adopt mocks base method.
+func (m *MockAdopter) Adopt(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Adopt", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Adopt indicates an expected call of Adopt.
+func (mr *MockAdopterMockRecorder) Adopt(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Adopt", reflect.TypeOf((*MockAdopter)(nil).Adopt), ctx)
+}
+
 // MockAuthorizer is a mock of Authorizer interface.
 type MockAuthorizer struct {
 	ctrl     *gomock.Controller
@@ -541,6 +672,20 @@ func (mr *MockNetworkDescriberMockRecorder) Vnet() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Vnet", reflect.TypeOf((*MockNetworkDescriber)(nil).Vnet))
 }
 
+// VnetSubscriptionID mocks base method.
+func (m *MockNetworkDescriber) VnetSubscriptionID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VnetSubscriptionID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// VnetSubscriptionID indicates an expected call of VnetSubscriptionID.
+func (mr *MockNetworkDescriberMockRecorder) VnetSubscriptionID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VnetSubscriptionID", reflect.TypeOf((*MockNetworkDescriber)(nil).VnetSubscriptionID))
+}
+
 // MockClusterDescriber is a mock of ClusterDescriber interface.
 type MockClusterDescriber struct {
 	ctrl     *gomock.Controller
@@ -690,6 +835,20 @@ func (mr *MockClusterDescriberMockRecorder) ClusterName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterName", reflect.TypeOf((*MockClusterDescriber)(nil).ClusterName))
 }
 
+// DriftPolicy mocks base method.
+func (m *MockClusterDescriber) DriftPolicy() v1beta1.DriftPolicy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DriftPolicy")
+	ret0, _ := ret[0].(v1beta1.DriftPolicy)
+	return ret0
+}
+
+// DriftPolicy indicates an expected call of DriftPolicy.
+func (mr *MockClusterDescriberMockRecorder) DriftPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DriftPolicy", reflect.TypeOf((*MockClusterDescriber)(nil).DriftPolicy))
+}
+
 // FailureDomains mocks base method.
 func (m *MockClusterDescriber) FailureDomains() []string {
 	m.ctrl.T.Helper()
@@ -1090,6 +1249,20 @@ func (mr *MockClusterScoperMockRecorder) ControlPlaneSubnet() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ControlPlaneSubnet", reflect.TypeOf((*MockClusterScoper)(nil).ControlPlaneSubnet))
 }
 
+// DriftPolicy mocks base method.
+func (m *MockClusterScoper) DriftPolicy() v1beta1.DriftPolicy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DriftPolicy")
+	ret0, _ := ret[0].(v1beta1.DriftPolicy)
+	return ret0
+}
+
+// DriftPolicy indicates an expected call of DriftPolicy.
+func (mr *MockClusterScoperMockRecorder) DriftPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DriftPolicy", reflect.TypeOf((*MockClusterScoper)(nil).DriftPolicy))
+}
+
 // FailureDomains mocks base method.
 func (m *MockClusterScoper) FailureDomains() []string {
 	m.ctrl.T.Helper()
@@ -1326,6 +1499,20 @@ func (mr *MockClusterScoperMockRecorder) Vnet() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Vnet", reflect.TypeOf((*MockClusterScoper)(nil).Vnet))
 }
 
+// VnetSubscriptionID mocks base method.
+func (m *MockClusterScoper) VnetSubscriptionID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VnetSubscriptionID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// VnetSubscriptionID indicates an expected call of VnetSubscriptionID.
+func (mr *MockClusterScoperMockRecorder) VnetSubscriptionID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VnetSubscriptionID", reflect.TypeOf((*MockClusterScoper)(nil).VnetSubscriptionID))
+}
+
 // MockManagedClusterScoper is a mock of ManagedClusterScoper interface.
 type MockManagedClusterScoper struct {
 	ctrl     *gomock.Controller
@@ -1475,6 +1662,20 @@ func (mr *MockManagedClusterScoperMockRecorder) ClusterName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterName", reflect.TypeOf((*MockManagedClusterScoper)(nil).ClusterName))
 }
 
+// DriftPolicy mocks base method.
+func (m *MockManagedClusterScoper) DriftPolicy() v1beta1.DriftPolicy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DriftPolicy")
+	ret0, _ := ret[0].(v1beta1.DriftPolicy)
+	return ret0
+}
+
+// DriftPolicy indicates an expected call of DriftPolicy.
+func (mr *MockManagedClusterScoperMockRecorder) DriftPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DriftPolicy", reflect.TypeOf((*MockManagedClusterScoper)(nil).DriftPolicy))
+}
+
 // FailureDomains mocks base method.
 func (m *MockManagedClusterScoper) FailureDomains() []string {
 	m.ctrl.T.Helper()
@@ -1653,6 +1854,44 @@ func (mr *MockResourceSpecGetterMockRecorder) ResourceName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceName", reflect.TypeOf((*MockResourceSpecGetter)(nil).ResourceName))
 }
 
+// MockDriftDetector is a mock of DriftDetector interface.
+type MockDriftDetector struct {
+	ctrl     *gomock.Controller
+	recorder *MockDriftDetectorMockRecorder
+}
+
+// MockDriftDetectorMockRecorder is the mock recorder for MockDriftDetector.
+type MockDriftDetectorMockRecorder struct {
+	mock *MockDriftDetector
+}
+
+// NewMockDriftDetector creates a new mock instance.
+func NewMockDriftDetector(ctrl *gomock.Controller) *MockDriftDetector {
+	mock := &MockDriftDetector{ctrl: ctrl}
+	mock.recorder = &MockDriftDetectorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDriftDetector) EXPECT() *MockDriftDetectorMockRecorder {
+	return m.recorder
+}
+
+// Drift mocks base method.
+func (m *MockDriftDetector) Drift(existing interface{}) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Drift", existing)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Drift indicates an expected call of Drift.
+func (mr *MockDriftDetectorMockRecorder) Drift(existing interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Drift", reflect.TypeOf((*MockDriftDetector)(nil).Drift), existing)
+}
+
 // MockResourceSpecGetterWithHeaders is a mock of ResourceSpecGetterWithHeaders interface.
 type MockResourceSpecGetterWithHeaders struct {
 	ctrl     *gomock.Controller
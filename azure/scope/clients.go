@@ -25,8 +25,10 @@ import (
 	"strings"
 
 	"github.com/Azure/go-autorest/autorest"
-	"github.com/Azure/go-autorest/autorest/azure"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/ratelimit"
 )
 
 // AzureClients contains all the Azure clients used by the scopes.
@@ -72,6 +74,35 @@ func (c *AzureClients) HashKey() string {
 	return base64.URLEncoding.EncodeToString(hasher.Sum(nil))
 }
 
+// forSubscription returns a copy of the clients that authorizes requests to another subscription
+// with the same credentials.
+func (c *AzureClients) forSubscription(subscriptionID string) *AzureClients {
+	clients := *c
+	clients.Values = make(map[string]string, len(c.Values))
+	for k, v := range c.Values {
+		clients.Values[k] = v
+	}
+	clients.Values[auth.SubscriptionID] = subscriptionID
+	return &clients
+}
+
+// subscriptionAuthorizer implements azure.Authorizer for the clients of a subscription referenced by a cluster.
+type subscriptionAuthorizer struct {
+	*AzureClients
+}
+
+var _ azure.Authorizer = (*subscriptionAuthorizer)(nil)
+
+// BaseURI returns the Azure ResourceManagerEndpoint.
+func (a *subscriptionAuthorizer) BaseURI() string {
+	return a.ResourceManagerEndpoint
+}
+
+// Authorizer returns the Azure client Authorizer, rate limited with the other clients using the same credentials.
+func (a *subscriptionAuthorizer) Authorizer() autorest.Authorizer {
+	return ratelimit.NewAuthorizer(a.AzureClients.Authorizer, a.HashKey())
+}
+
 func (c *AzureClients) setCredentials(subscriptionID, environmentName string) error {
 	settings, err := c.getSettingsFromEnvironment(environmentName)
 	if err != nil {
@@ -86,8 +117,7 @@ func (c *AzureClients) setCredentials(subscriptionID, environmentName string) er
 	}
 
 	c.EnvironmentSettings = settings
-	c.ResourceManagerEndpoint = settings.Environment.ResourceManagerEndpoint
-	c.ResourceManagerVMDNSSuffix = settings.Environment.ResourceManagerVMDNSSuffix
+	c.setEndpoints(settings)
 	c.Values[auth.ClientID] = strings.TrimSuffix(c.Values[auth.ClientID], "\n")
 	c.Values[auth.ClientSecret] = strings.TrimSuffix(c.Values[auth.ClientSecret], "\n")
 	c.Values[auth.SubscriptionID] = strings.TrimSuffix(subscriptionID, "\n")
@@ -117,8 +147,7 @@ func (c *AzureClients) setCredentialsWithProvider(ctx context.Context, subscript
 	}
 
 	c.EnvironmentSettings = settings
-	c.ResourceManagerEndpoint = settings.Environment.ResourceManagerEndpoint
-	c.ResourceManagerVMDNSSuffix = settings.Environment.ResourceManagerVMDNSSuffix
+	c.setEndpoints(settings)
 	c.Values[auth.SubscriptionID] = strings.TrimSuffix(subscriptionID, "\n")
 	c.Values[auth.TenantID] = strings.TrimSuffix(credentialsProvider.GetTenantID(), "\n")
 	c.Values[auth.ClientID] = strings.TrimSuffix(credentialsProvider.GetClientID(), "\n")
@@ -133,6 +162,17 @@ func (c *AzureClients) setCredentialsWithProvider(ctx context.Context, subscript
 	return err
 }
 
+// setEndpoints sets the Resource Manager endpoint and VM DNS suffix from the environment settings,
+// unless they were already set, e.g. to point the clients at a fake Resource Manager in tests.
+func (c *AzureClients) setEndpoints(settings auth.EnvironmentSettings) {
+	if c.ResourceManagerEndpoint == "" {
+		c.ResourceManagerEndpoint = settings.Environment.ResourceManagerEndpoint
+	}
+	if c.ResourceManagerVMDNSSuffix == "" {
+		c.ResourceManagerVMDNSSuffix = settings.Environment.ResourceManagerVMDNSSuffix
+	}
+}
+
 func (c *AzureClients) getSettingsFromEnvironment(environmentName string) (s auth.EnvironmentSettings, err error) {
 	s = auth.EnvironmentSettings{
 		Values: map[string]string{},
@@ -149,9 +189,9 @@ func (c *AzureClients) getSettingsFromEnvironment(environmentName string) (s aut
 	setValue(s, auth.Password)
 	setValue(s, auth.Resource)
 	if v := s.Values[auth.EnvironmentName]; v == "" {
-		s.Environment = azure.PublicCloud
+		s.Environment = azureautorest.PublicCloud
 	} else {
-		s.Environment, err = azure.EnvironmentFromName(v)
+		s.Environment, err = azureautorest.EnvironmentFromName(v)
 	}
 	if s.Values[auth.Resource] == "" {
 		s.Values[auth.Resource] = s.Environment.ResourceManagerEndpoint
@@ -24,6 +24,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/to"
@@ -40,8 +41,10 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routetables"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vnetpeerings"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/ratelimit"
 	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -88,6 +91,11 @@ func NewClusterScope(ctx context.Context, params ClusterScopeParams) (*ClusterSc
 		}
 	}
 
+	subscriptionClients, err := newSubscriptionClients(ctx, params)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to configure azure settings and credentials for subscription references")
+	}
+
 	if params.Cache == nil {
 		params.Cache = &ClusterCache{}
 	}
@@ -104,18 +112,69 @@ func NewClusterScope(ctx context.Context, params ClusterScopeParams) (*ClusterSc
 		AzureCluster: params.AzureCluster,
 		patchHelper:  helper,
 		cache:        params.Cache,
+		dryRunPlan:   &dryRunPlan{},
+		driftReport:  newDriftReport(params.AzureCluster),
+
+		subscriptionClients: subscriptionClients,
 	}, nil
 }
 
+// newSubscriptionClients configures the clients of the subscriptions referenced by the AzureCluster with an identity
+// other than the AzureCluster's, keyed by subscription ID.
+func newSubscriptionClients(ctx context.Context, params ClusterScopeParams) (map[string]*AzureClients, error) {
+	subscriptionClients := make(map[string]*AzureClients)
+	for _, ref := range SubscriptionReferences(params.AzureCluster) {
+		if ref.SubscriptionID == "" || ref.IdentityRef == nil {
+			continue
+		}
+		if _, ok := subscriptionClients[ref.SubscriptionID]; ok {
+			continue
+		}
+		credentialsProvider, err := newAzureClusterCredentialsProvider(ctx, params.Client, params.AzureCluster, ref.IdentityRef)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to init credentials provider for subscription %s", ref.SubscriptionID)
+		}
+		clients := &AzureClients{
+			ResourceManagerEndpoint:    params.AzureClients.ResourceManagerEndpoint,
+			ResourceManagerVMDNSSuffix: params.AzureClients.ResourceManagerVMDNSSuffix,
+		}
+		if err := clients.setCredentialsWithProvider(ctx, ref.SubscriptionID, params.AzureCluster.Spec.AzureEnvironment, credentialsProvider); err != nil {
+			return nil, errors.Wrapf(err, "failed to configure credentials for subscription %s", ref.SubscriptionID)
+		}
+		subscriptionClients[ref.SubscriptionID] = clients
+	}
+	return subscriptionClients, nil
+}
+
+// SubscriptionReferences returns the references to subscriptions other than the cluster's in the AzureCluster spec.
+func SubscriptionReferences(azureCluster *infrav1.AzureCluster) []infrav1.SubscriptionReference {
+	networkSpec := azureCluster.Spec.NetworkSpec
+	refs := []infrav1.SubscriptionReference{networkSpec.Vnet.SubscriptionReference}
+	for _, peering := range networkSpec.Vnet.Peerings {
+		refs = append(refs, peering.SubscriptionReference)
+	}
+	if networkSpec.PrivateDNSZone != nil {
+		refs = append(refs, networkSpec.PrivateDNSZone.SubscriptionReference)
+	}
+	return refs
+}
+
 // ClusterScope defines the basic context for an actuator to operate upon.
 type ClusterScope struct {
 	Client      client.Client
 	patchHelper *patch.Helper
 	cache       *ClusterCache
+	// mu guards the AzureCluster fields that services read and update while they are reconciled concurrently.
+	mu sync.RWMutex
+	*dryRunPlan
+	*driftReport
 
 	AzureClients
 	Cluster      *clusterv1.Cluster
 	AzureCluster *infrav1.AzureCluster
+
+	// subscriptionClients are the clients of the subscriptions referenced with their own identity, keyed by subscription ID.
+	subscriptionClients map[string]*AzureClients
 }
 
 // ClusterCache stores ClusterCache data locally so we don't have to hit the API multiple times within the same reconcile loop.
@@ -128,9 +187,21 @@ func (s *ClusterScope) BaseURI() string {
 	return s.ResourceManagerEndpoint
 }
 
-// Authorizer returns the Azure client Authorizer.
+// Authorizer returns the Azure client Authorizer, rate limited with the other clients using the same credentials.
 func (s *ClusterScope) Authorizer() autorest.Authorizer {
-	return s.AzureClients.Authorizer
+	return ratelimit.NewAuthorizer(s.AzureClients.Authorizer, s.HashKey())
+}
+
+// AuthorizerFor returns an Authorizer for the given subscription. Subscriptions referenced without an identity
+// use the AzureCluster's identity.
+func (s *ClusterScope) AuthorizerFor(subscriptionID string) azure.Authorizer {
+	if subscriptionID == "" || subscriptionID == s.SubscriptionID() {
+		return s
+	}
+	if clients, ok := s.subscriptionClients[subscriptionID]; ok {
+		return &subscriptionAuthorizer{clients}
+	}
+	return &subscriptionAuthorizer{s.AzureClients.forSubscription(subscriptionID)}
 }
 
 // PublicIPSpecs returns the public IP specs.
@@ -236,6 +307,7 @@ func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
 			SubscriptionID:       s.SubscriptionID(),
 			ClusterName:          s.ClusterName(),
 			Location:             s.Location(),
+			VNetSubscriptionID:   s.VnetSubscriptionID(),
 			VNetName:             s.Vnet().Name,
 			VNetResourceGroup:    s.Vnet().ResourceGroup,
 			SubnetName:           s.ControlPlaneSubnet().Name,
@@ -258,6 +330,7 @@ func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
 			SubscriptionID:       s.SubscriptionID(),
 			ClusterName:          s.ClusterName(),
 			Location:             s.Location(),
+			VNetSubscriptionID:   s.VnetSubscriptionID(),
 			VNetName:             s.Vnet().Name,
 			VNetResourceGroup:    s.Vnet().ResourceGroup,
 			FrontendIPConfigs:    s.NodeOutboundLB().FrontendIPs,
@@ -278,6 +351,7 @@ func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
 			SubscriptionID:       s.SubscriptionID(),
 			ClusterName:          s.ClusterName(),
 			Location:             s.Location(),
+			VNetSubscriptionID:   s.VnetSubscriptionID(),
 			VNetName:             s.Vnet().Name,
 			VNetResourceGroup:    s.Vnet().ResourceGroup,
 			FrontendIPConfigs:    s.ControlPlaneOutboundLB().FrontendIPs,
@@ -296,12 +370,12 @@ func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
 // RouteTableSpecs returns the subnet route tables.
 func (s *ClusterScope) RouteTableSpecs() []azure.ResourceSpecGetter {
 	var specs []azure.ResourceSpecGetter
-	for _, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
+	for _, subnet := range s.Subnets() {
 		if subnet.RouteTable.Name != "" {
 			specs = append(specs, &routetables.RouteTableSpec{
 				Name:           subnet.RouteTable.Name,
 				Location:       s.Location(),
-				ResourceGroup:  s.ResourceGroup(),
+				ResourceGroup:  s.SubnetAssociationsResourceGroup(),
 				ClusterName:    s.ClusterName(),
 				AdditionalTags: s.AdditionalTags(),
 			})
@@ -341,12 +415,13 @@ func (s *ClusterScope) NatGatewaySpecs() []azure.ResourceSpecGetter {
 
 // NSGSpecs returns the security group specs.
 func (s *ClusterScope) NSGSpecs() []azure.ResourceSpecGetter {
-	nsgspecs := make([]azure.ResourceSpecGetter, len(s.AzureCluster.Spec.NetworkSpec.Subnets))
-	for i, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
+	subnets := s.Subnets()
+	nsgspecs := make([]azure.ResourceSpecGetter, len(subnets))
+	for i, subnet := range subnets {
 		nsgspecs[i] = &securitygroups.NSGSpec{
 			Name:           subnet.SecurityGroup.Name,
 			SecurityRules:  subnet.SecurityGroup.SecurityRules,
-			ResourceGroup:  s.ResourceGroup(),
+			ResourceGroup:  s.SubnetAssociationsResourceGroup(),
 			Location:       s.Location(),
 			ClusterName:    s.ClusterName(),
 			AdditionalTags: s.AdditionalTags(),
@@ -358,18 +433,19 @@ func (s *ClusterScope) NSGSpecs() []azure.ResourceSpecGetter {
 
 // SubnetSpecs returns the subnets specs.
 func (s *ClusterScope) SubnetSpecs() []azure.ResourceSpecGetter {
-	numberOfSubnets := len(s.AzureCluster.Spec.NetworkSpec.Subnets)
+	clusterSubnets := s.Subnets()
+	numberOfSubnets := len(clusterSubnets)
 	if s.IsAzureBastionEnabled() {
 		numberOfSubnets++
 	}
 
 	subnetSpecs := make([]azure.ResourceSpecGetter, 0, numberOfSubnets)
 
-	for _, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
+	for _, subnet := range clusterSubnets {
 		subnetSpec := &subnets.SubnetSpec{
 			Name:              subnet.Name,
-			ResourceGroup:     s.ResourceGroup(),
-			SubscriptionID:    s.SubscriptionID(),
+			ResourceGroup:     s.SubnetAssociationsResourceGroup(),
+			SubscriptionID:    s.VnetSubscriptionID(),
 			CIDRs:             subnet.CIDRBlocks,
 			VNetName:          s.Vnet().Name,
 			VNetResourceGroup: s.Vnet().ResourceGroup,
@@ -387,8 +463,8 @@ func (s *ClusterScope) SubnetSpecs() []azure.ResourceSpecGetter {
 		azureBastionSubnet := s.AzureCluster.Spec.BastionSpec.AzureBastion.Subnet
 		subnetSpecs = append(subnetSpecs, &subnets.SubnetSpec{
 			Name:              azureBastionSubnet.Name,
-			ResourceGroup:     s.ResourceGroup(),
-			SubscriptionID:    s.SubscriptionID(),
+			ResourceGroup:     s.SubnetAssociationsResourceGroup(),
+			SubscriptionID:    s.VnetSubscriptionID(),
 			CIDRs:             azureBastionSubnet.CIDRBlocks,
 			VNetName:          s.Vnet().Name,
 			VNetResourceGroup: s.Vnet().ResourceGroup,
@@ -417,21 +493,24 @@ func (s *ClusterScope) GroupSpec() azure.ResourceSpecGetter {
 func (s *ClusterScope) VnetPeeringSpecs() []azure.ResourceSpecGetter {
 	peeringSpecs := make([]azure.ResourceSpecGetter, 2*len(s.Vnet().Peerings))
 	for i, peering := range s.Vnet().Peerings {
+		remoteSubscriptionID := s.peeringSubscriptionID(peering)
 		forwardPeering := &vnetpeerings.VnetPeeringSpec{
-			PeeringName:         azure.GenerateVnetPeeringName(s.Vnet().Name, peering.RemoteVnetName),
-			SourceVnetName:      s.Vnet().Name,
-			SourceResourceGroup: s.Vnet().ResourceGroup,
-			RemoteVnetName:      peering.RemoteVnetName,
-			RemoteResourceGroup: peering.ResourceGroup,
-			SubscriptionID:      s.SubscriptionID(),
+			PeeringName:          azure.GenerateVnetPeeringName(s.Vnet().Name, peering.RemoteVnetName),
+			SourceSubscriptionID: s.VnetSubscriptionID(),
+			SourceVnetName:       s.Vnet().Name,
+			SourceResourceGroup:  s.Vnet().ResourceGroup,
+			RemoteSubscriptionID: remoteSubscriptionID,
+			RemoteVnetName:       peering.RemoteVnetName,
+			RemoteResourceGroup:  peering.ResourceGroup,
 		}
 		reversePeering := &vnetpeerings.VnetPeeringSpec{
-			PeeringName:         azure.GenerateVnetPeeringName(peering.RemoteVnetName, s.Vnet().Name),
-			SourceVnetName:      peering.RemoteVnetName,
-			SourceResourceGroup: peering.ResourceGroup,
-			RemoteVnetName:      s.Vnet().Name,
-			RemoteResourceGroup: s.Vnet().ResourceGroup,
-			SubscriptionID:      s.SubscriptionID(),
+			PeeringName:          azure.GenerateVnetPeeringName(peering.RemoteVnetName, s.Vnet().Name),
+			SourceSubscriptionID: remoteSubscriptionID,
+			SourceVnetName:       peering.RemoteVnetName,
+			SourceResourceGroup:  peering.ResourceGroup,
+			RemoteSubscriptionID: s.VnetSubscriptionID(),
+			RemoteVnetName:       s.Vnet().Name,
+			RemoteResourceGroup:  s.Vnet().ResourceGroup,
 		}
 		peeringSpecs[i*2] = forwardPeering
 		peeringSpecs[i*2+1] = reversePeering
@@ -440,6 +519,14 @@ func (s *ClusterScope) VnetPeeringSpecs() []azure.ResourceSpecGetter {
 	return peeringSpecs
 }
 
+// peeringSubscriptionID returns the ID of the subscription of the remote virtual network of a peering.
+func (s *ClusterScope) peeringSubscriptionID(peering infrav1.VnetPeeringSpec) string {
+	if peering.SubscriptionID != "" {
+		return peering.SubscriptionID
+	}
+	return s.SubscriptionID()
+}
+
 // VNetSpec returns the virtual network spec.
 func (s *ClusterScope) VNetSpec() azure.ResourceSpecGetter {
 	return &virtualnetworks.VNetSpec{
@@ -452,12 +539,28 @@ func (s *ClusterScope) VNetSpec() azure.ResourceSpecGetter {
 	}
 }
 
+// PrivateDNSZoneSubscriptionID returns the ID of the subscription of the private DNS zone.
+func (s *ClusterScope) PrivateDNSZoneSubscriptionID() string {
+	if zone := s.AzureCluster.Spec.NetworkSpec.PrivateDNSZone; zone != nil && zone.SubscriptionID != "" {
+		return zone.SubscriptionID
+	}
+	return s.SubscriptionID()
+}
+
+// PrivateDNSZoneResourceGroup returns the resource group of the private DNS zone.
+func (s *ClusterScope) PrivateDNSZoneResourceGroup() string {
+	if zone := s.AzureCluster.Spec.NetworkSpec.PrivateDNSZone; zone != nil && zone.ResourceGroup != "" {
+		return zone.ResourceGroup
+	}
+	return s.ResourceGroup()
+}
+
 // PrivateDNSSpec returns the private dns zone spec.
 func (s *ClusterScope) PrivateDNSSpec() (zoneSpec azure.ResourceSpecGetter, linkSpec, recordSpec []azure.ResourceSpecGetter) {
 	if s.IsAPIServerPrivate() {
 		zone := privatedns.ZoneSpec{
 			Name:           s.GetPrivateDNSZoneName(),
-			ResourceGroup:  s.ResourceGroup(),
+			ResourceGroup:  s.PrivateDNSZoneResourceGroup(),
 			ClusterName:    s.ClusterName(),
 			AdditionalTags: s.AdditionalTags(),
 		}
@@ -466,10 +569,10 @@ func (s *ClusterScope) PrivateDNSSpec() (zoneSpec azure.ResourceSpecGetter, link
 		links[0] = privatedns.LinkSpec{
 			Name:              azure.GenerateVNetLinkName(s.Vnet().Name),
 			ZoneName:          s.GetPrivateDNSZoneName(),
-			SubscriptionID:    s.SubscriptionID(),
+			SubscriptionID:    s.VnetSubscriptionID(),
 			VNetResourceGroup: s.Vnet().ResourceGroup,
 			VNetName:          s.Vnet().Name,
-			ResourceGroup:     s.ResourceGroup(),
+			ResourceGroup:     s.PrivateDNSZoneResourceGroup(),
 			ClusterName:       s.ClusterName(),
 			AdditionalTags:    s.AdditionalTags(),
 		}
@@ -477,10 +580,10 @@ func (s *ClusterScope) PrivateDNSSpec() (zoneSpec azure.ResourceSpecGetter, link
 			links[i+1] = privatedns.LinkSpec{
 				Name:              azure.GenerateVNetLinkName(peering.RemoteVnetName),
 				ZoneName:          s.GetPrivateDNSZoneName(),
-				SubscriptionID:    s.SubscriptionID(),
+				SubscriptionID:    s.peeringSubscriptionID(peering),
 				VNetResourceGroup: peering.ResourceGroup,
 				VNetName:          peering.RemoteVnetName,
-				ResourceGroup:     s.ResourceGroup(),
+				ResourceGroup:     s.PrivateDNSZoneResourceGroup(),
 				ClusterName:       s.ClusterName(),
 				AdditionalTags:    s.AdditionalTags(),
 			}
@@ -493,7 +596,7 @@ func (s *ClusterScope) PrivateDNSSpec() (zoneSpec azure.ResourceSpecGetter, link
 				IP:       s.APIServerPrivateIP(),
 			},
 			ZoneName:      s.GetPrivateDNSZoneName(),
-			ResourceGroup: s.ResourceGroup(),
+			ResourceGroup: s.PrivateDNSZoneResourceGroup(),
 		}
 
 		return zone, links, records
@@ -515,7 +618,7 @@ func (s *ClusterScope) AzureBastion() *infrav1.AzureBastion {
 // AzureBastionSpec returns the bastion spec.
 func (s *ClusterScope) AzureBastionSpec() azure.ResourceSpecGetter {
 	if s.IsAzureBastionEnabled() {
-		subnetID := azure.SubnetID(s.SubscriptionID(), s.ResourceGroup(), s.Vnet().Name, s.AzureBastion().Subnet.Name)
+		subnetID := azure.SubnetID(s.VnetSubscriptionID(), s.ResourceGroup(), s.Vnet().Name, s.AzureBastion().Subnet.Name)
 		publicIPID := azure.PublicIPID(s.SubscriptionID(), s.ResourceGroup(), s.AzureBastion().PublicIP.Name)
 
 		return &bastionhosts.AzureBastionSpec{
@@ -536,8 +639,28 @@ func (s *ClusterScope) Vnet() *infrav1.VnetSpec {
 	return &s.AzureCluster.Spec.NetworkSpec.Vnet
 }
 
+// VnetSubscriptionID returns the ID of the subscription of the cluster Vnet.
+func (s *ClusterScope) VnetSubscriptionID() string {
+	if s.Vnet().SubscriptionID != "" {
+		return s.Vnet().SubscriptionID
+	}
+	return s.SubscriptionID()
+}
+
+// SubnetAssociationsResourceGroup returns the resource group of the route tables and security groups of the subnets.
+// They must be in the subscription of the Vnet, so they are in the resource group of the Vnet when it is in another
+// subscription than the cluster.
+func (s *ClusterScope) SubnetAssociationsResourceGroup() string {
+	if s.VnetSubscriptionID() != s.SubscriptionID() {
+		return s.Vnet().ResourceGroup
+	}
+	return s.ResourceGroup()
+}
+
 // IsVnetManaged returns true if the vnet is managed.
 func (s *ClusterScope) IsVnetManaged() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if s.cache.isVnetManaged != nil {
 		return to.Bool(s.cache.isVnetManaged)
 	}
@@ -556,21 +679,25 @@ func (s *ClusterScope) IsIPv6Enabled() bool {
 	return false
 }
 
-// Subnets returns the cluster subnets.
+// Subnets returns a copy of the cluster subnets.
 func (s *ClusterScope) Subnets() infrav1.Subnets {
-	return s.AzureCluster.Spec.NetworkSpec.Subnets
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.AzureCluster.Spec.NetworkSpec.Subnets.DeepCopy()
 }
 
 // ControlPlaneSubnet returns the cluster control plane subnet.
 func (s *ClusterScope) ControlPlaneSubnet() infrav1.SubnetSpec {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	subnet, _ := s.AzureCluster.Spec.NetworkSpec.GetControlPlaneSubnet()
-	return subnet
+	return *subnet.DeepCopy()
 }
 
 // NodeSubnets returns the subnets with the node role.
 func (s *ClusterScope) NodeSubnets() []infrav1.SubnetSpec {
 	subnets := []infrav1.SubnetSpec{}
-	for _, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
+	for _, subnet := range s.Subnets() {
 		if subnet.Role == infrav1.SubnetNode {
 			subnets = append(subnets, subnet)
 		}
@@ -581,6 +708,14 @@ func (s *ClusterScope) NodeSubnets() []infrav1.SubnetSpec {
 
 // Subnet returns the subnet with the provided name.
 func (s *ClusterScope) Subnet(name string) infrav1.SubnetSpec {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	subnet := s.subnet(name)
+	return *subnet.DeepCopy()
+}
+
+// subnet returns the subnet with the provided name. The caller must hold mu.
+func (s *ClusterScope) subnet(name string) infrav1.SubnetSpec {
 	for _, sn := range s.AzureCluster.Spec.NetworkSpec.Subnets {
 		if sn.Name == name {
 			return sn
@@ -592,6 +727,12 @@ func (s *ClusterScope) Subnet(name string) infrav1.SubnetSpec {
 
 // SetSubnet sets the subnet spec for the subnet with the same name.
 func (s *ClusterScope) SetSubnet(subnetSpec infrav1.SubnetSpec) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.setSubnet(subnetSpec)
+}
+
+func (s *ClusterScope) setSubnet(subnetSpec infrav1.SubnetSpec) {
 	for i, sn := range s.AzureCluster.Spec.NetworkSpec.Subnets {
 		if sn.Name == subnetSpec.Name {
 			s.AzureCluster.Spec.NetworkSpec.Subnets[i] = subnetSpec
@@ -602,32 +743,37 @@ func (s *ClusterScope) SetSubnet(subnetSpec infrav1.SubnetSpec) {
 
 // SetNatGatewayIDInSubnets sets the NAT Gateway ID in the subnets with the same name.
 func (s *ClusterScope) SetNatGatewayIDInSubnets(name string, id string) {
-	for _, subnet := range s.Subnets() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
 		if subnet.NatGateway.Name == name {
 			subnet.NatGateway.ID = id
-			s.SetSubnet(subnet)
+			s.setSubnet(subnet)
 		}
 	}
 }
 
 // UpdateSubnetCIDRs updates the subnet CIDRs for the subnet with the same name.
 func (s *ClusterScope) UpdateSubnetCIDRs(name string, cidrBlocks []string) {
-	subnetSpecInfra := s.Subnet(name)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	subnetSpecInfra := s.subnet(name)
 	subnetSpecInfra.CIDRBlocks = cidrBlocks
-	s.SetSubnet(subnetSpecInfra)
+	s.setSubnet(subnetSpecInfra)
 }
 
 // UpdateSubnetID updates the subnet ID for the subnet with the same name.
 func (s *ClusterScope) UpdateSubnetID(name string, id string) {
-	subnetSpecInfra := s.Subnet(name)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	subnetSpecInfra := s.subnet(name)
 	subnetSpecInfra.ID = id
-	s.SetSubnet(subnetSpecInfra)
+	s.setSubnet(subnetSpecInfra)
 }
 
 // ControlPlaneRouteTable returns the cluster controlplane routetable.
 func (s *ClusterScope) ControlPlaneRouteTable() infrav1.RouteTable {
-	subnet, _ := s.AzureCluster.Spec.NetworkSpec.GetControlPlaneSubnet()
-	return subnet.RouteTable
+	return s.ControlPlaneSubnet().RouteTable
 }
 
 // APIServerLB returns the cluster API Server load balancer.
@@ -733,6 +879,14 @@ func (s *ClusterScope) CloudProviderConfigOverrides() *infrav1.CloudProviderConf
 	return s.AzureCluster.Spec.CloudProviderConfigOverrides
 }
 
+// DriftPolicy returns what happens to the cluster's Azure resources modified outside of CAPZ.
+func (s *ClusterScope) DriftPolicy() infrav1.DriftPolicy {
+	if s.AzureCluster.Spec.DriftPolicy == "" {
+		return infrav1.DriftPolicyRemediate
+	}
+	return s.AzureCluster.Spec.DriftPolicy
+}
+
 // GenerateFQDN generates a fully qualified domain name, based on a hash, cluster name and cluster location.
 func (s *ClusterScope) GenerateFQDN(ipName string) string {
 	h := fnv.New32a()
@@ -768,6 +922,9 @@ func (s *ClusterScope) PatchObject(ctx context.Context) error {
 	defer done()
 
 	conditions.SetSummary(s.AzureCluster)
+	if err := s.saveLastAppliedSpecs(s.AzureCluster); err != nil {
+		return errors.Wrap(err, "failed to save the last applied specs")
+	}
 
 	return s.patchHelper.Patch(
 		ctx,
@@ -777,6 +934,8 @@ func (s *ClusterScope) PatchObject(ctx context.Context) error {
 			infrav1.ResourceGroupReadyCondition,
 			infrav1.RouteTablesReadyCondition,
 			infrav1.NetworkInfrastructureReadyCondition,
+			infrav1.ChangesAppliedCondition,
+			infrav1.DriftDetectedCondition,
 			infrav1.VnetPeeringReadyCondition,
 			infrav1.DisksReadyCondition,
 			infrav1.NATGatewaysReadyCondition,
@@ -912,26 +1071,36 @@ func (s *ClusterScope) SetDNSName() {
 // SetLongRunningOperationState will set the future on the AzureCluster status to allow the resource to continue
 // in the next reconciliation.
 func (s *ClusterScope) SetLongRunningOperationState(future *infrav1.Future) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	futures.Set(s.AzureCluster, future)
 }
 
 // GetLongRunningOperationState will get the future on the AzureCluster status.
 func (s *ClusterScope) GetLongRunningOperationState(name, service, futureType string) *infrav1.Future {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	return futures.Get(s.AzureCluster, name, service, futureType)
 }
 
 // DeleteLongRunningOperationState will delete the future from the AzureCluster status.
 func (s *ClusterScope) DeleteLongRunningOperationState(name, service, futureType string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	futures.Delete(s.AzureCluster, name, service, futureType)
 }
 
 // UpdateDeleteStatus updates a condition on the AzureCluster status after a DELETE operation.
 func (s *ClusterScope) UpdateDeleteStatus(condition clusterv1.ConditionType, service string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	switch {
 	case err == nil:
 		conditions.MarkFalse(s.AzureCluster, condition, infrav1.DeletedReason, clusterv1.ConditionSeverityInfo, "%s successfully deleted", service)
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(s.AzureCluster, condition, infrav1.DeletingReason, clusterv1.ConditionSeverityInfo, "%s deleting", service)
+	case azure.IsDryRunError(err):
+		conditions.MarkFalse(s.AzureCluster, condition, infrav1.DryRunReason, clusterv1.ConditionSeverityInfo, "%s was not deleted in dry-run mode", service)
 	default:
 		conditions.MarkFalse(s.AzureCluster, condition, infrav1.DeletionFailedReason, clusterv1.ConditionSeverityError, "%s failed to delete. err: %s", service, err.Error())
 	}
@@ -939,11 +1108,15 @@ func (s *ClusterScope) UpdateDeleteStatus(condition clusterv1.ConditionType, ser
 
 // UpdatePutStatus updates a condition on the AzureCluster status after a PUT operation.
 func (s *ClusterScope) UpdatePutStatus(condition clusterv1.ConditionType, service string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	switch {
 	case err == nil:
 		conditions.MarkTrue(s.AzureCluster, condition)
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(s.AzureCluster, condition, infrav1.CreatingReason, clusterv1.ConditionSeverityInfo, "%s creating or updating", service)
+	case azure.IsDryRunError(err):
+		conditions.MarkFalse(s.AzureCluster, condition, infrav1.DryRunReason, clusterv1.ConditionSeverityInfo, "%s has changes that were not applied in dry-run mode", service)
 	default:
 		conditions.MarkFalse(s.AzureCluster, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to create or update. err: %s", service, err.Error())
 	}
@@ -951,6 +1124,8 @@ func (s *ClusterScope) UpdatePutStatus(condition clusterv1.ConditionType, servic
 
 // UpdatePatchStatus updates a condition on the AzureCluster status after a PATCH operation.
 func (s *ClusterScope) UpdatePatchStatus(condition clusterv1.ConditionType, service string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	switch {
 	case err == nil:
 		conditions.MarkTrue(s.AzureCluster, condition)
@@ -961,8 +1136,15 @@ func (s *ClusterScope) UpdatePatchStatus(condition clusterv1.ConditionType, serv
 	}
 }
 
+// IsDryRun returns true if the changes to the AzureCluster's resources should be planned but not applied.
+func (s *ClusterScope) IsDryRun() bool {
+	return azure.IsDryRunEnabled(s.AzureCluster.GetAnnotations())
+}
+
 // AnnotationJSON returns a map[string]interface from a JSON annotation.
 func (s *ClusterScope) AnnotationJSON(annotation string) (map[string]interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	out := map[string]interface{}{}
 	jsonAnnotation := s.AzureCluster.GetAnnotations()[annotation]
 	if jsonAnnotation == "" {
@@ -990,6 +1172,8 @@ func (s *ClusterScope) UpdateAnnotationJSON(annotation string, content map[strin
 
 // SetAnnotation sets a key value annotation on the AzureCluster.
 func (s *ClusterScope) SetAnnotation(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if s.AzureCluster.Annotations == nil {
 		s.AzureCluster.Annotations = map[string]string{}
 	}
@@ -997,12 +1181,13 @@ func (s *ClusterScope) SetAnnotation(key, value string) {
 }
 
 // TagsSpecs returns the tag specs for the AzureCluster.
-func (s *ClusterScope) TagsSpecs() []azure.TagsSpec {
-	return []azure.TagsSpec{
-		{
-			Scope:      azure.ResourceGroupID(s.SubscriptionID(), s.ResourceGroup()),
-			Tags:       s.AdditionalTags(),
-			Annotation: azure.RGTagsLastAppliedAnnotation,
+func (s *ClusterScope) TagsSpecs() []azure.ResourceSpecGetter {
+	return []azure.ResourceSpecGetter{
+		&tags.TagsSpec{
+			Scope:       azure.ResourceGroupID(s.SubscriptionID(), s.ResourceGroup()),
+			Tags:        s.AdditionalTags(),
+			ClusterName: s.ClusterName(),
+			Annotation:  azure.RGTagsLastAppliedAnnotation,
 		},
 	}
 }
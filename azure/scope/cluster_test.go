@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/Azure/go-autorest/autorest"
@@ -38,6 +39,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routetables"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vnetpeerings"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -780,12 +782,12 @@ func TestPublicIPSpecs(t *testing.T) {
 func TestRouteTableSpecs(t *testing.T) {
 	tests := []struct {
 		name         string
-		clusterScope ClusterScope
+		clusterScope *ClusterScope
 		want         []azure.ResourceSpecGetter
 	}{
 		{
 			name: "returns nil if no subnets are specified",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{
 						NetworkSpec: infrav1.NetworkSpec{
@@ -799,7 +801,7 @@ func TestRouteTableSpecs(t *testing.T) {
 		},
 		{
 			name: "returns specified route tables if present",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -864,12 +866,12 @@ func TestRouteTableSpecs(t *testing.T) {
 func TestNatGatewaySpecs(t *testing.T) {
 	tests := []struct {
 		name         string
-		clusterScope ClusterScope
+		clusterScope *ClusterScope
 		want         []azure.ResourceSpecGetter
 	}{
 		{
 			name: "returns nil if no subnets are specified",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{
 						NetworkSpec: infrav1.NetworkSpec{
@@ -883,7 +885,7 @@ func TestNatGatewaySpecs(t *testing.T) {
 		},
 		{
 			name: "returns specified node NAT gateway if present",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -943,7 +945,7 @@ func TestNatGatewaySpecs(t *testing.T) {
 		},
 		{
 			name: "returns specified node NAT gateway if present and ignores duplicate",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1021,7 +1023,7 @@ func TestNatGatewaySpecs(t *testing.T) {
 		},
 		{
 			name: "returns specified node NAT gateway if present and ignores control plane nat gateway",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1112,12 +1114,12 @@ func TestNatGatewaySpecs(t *testing.T) {
 func TestNSGSpecs(t *testing.T) {
 	tests := []struct {
 		name         string
-		clusterScope ClusterScope
+		clusterScope *ClusterScope
 		want         []azure.ResourceSpecGetter
 	}{
 		{
 			name: "returns empty if no subnets are specified",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{
 						NetworkSpec: infrav1.NetworkSpec{
@@ -1130,7 +1132,7 @@ func TestNSGSpecs(t *testing.T) {
 		},
 		{
 			name: "returns specified security groups if present",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1193,12 +1195,12 @@ func TestNSGSpecs(t *testing.T) {
 func TestSubnetSpecs(t *testing.T) {
 	tests := []struct {
 		name         string
-		clusterScope ClusterScope
+		clusterScope *ClusterScope
 		want         []azure.ResourceSpecGetter
 	}{
 		{
 			name: "returns empty if no subnets are specified",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{
 						NetworkSpec: infrav1.NetworkSpec{
@@ -1212,7 +1214,7 @@ func TestSubnetSpecs(t *testing.T) {
 		},
 		{
 			name: "returns specified subnet spec",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1289,7 +1291,7 @@ func TestSubnetSpecs(t *testing.T) {
 
 		{
 			name: "returns specified subnet spec and bastion spec if enabled",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1414,15 +1416,85 @@ func TestSubnetSpecs(t *testing.T) {
 	}
 }
 
+func TestSubnetAssociationsInVnetSubscription(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-cluster",
+			},
+		},
+		AzureClients: AzureClients{
+			EnvironmentSettings: auth.EnvironmentSettings{
+				Values: map[string]string{
+					auth.SubscriptionID: "123",
+				},
+			},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				ResourceGroup: "my-rg",
+				AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+					Location: "centralIndia",
+				},
+				NetworkSpec: infrav1.NetworkSpec{
+					Vnet: infrav1.VnetSpec{
+						ID:                    "fake-vnet-id-1",
+						Name:                  "fake-vnet-1",
+						ResourceGroup:         "spoke-rg",
+						SubscriptionReference: infrav1.SubscriptionReference{SubscriptionID: "spoke-sub"},
+					},
+					Subnets: infrav1.Subnets{
+						{
+							SubnetClassSpec: infrav1.SubnetClassSpec{
+								Role:       infrav1.SubnetNode,
+								CIDRBlocks: []string{"192.168.1.1/16"},
+								Name:       "fake-subnet-1",
+							},
+							RouteTable: infrav1.RouteTable{
+								Name: "fake-route-table-1",
+							},
+							SecurityGroup: infrav1.SecurityGroup{
+								Name: "fake-security-group-1",
+							},
+						},
+					},
+				},
+			},
+		},
+		cache: &ClusterCache{},
+	}
+
+	// The route tables and security groups of a vnet in another subscription are created in the resource group of the vnet.
+	g.Expect(clusterScope.SubnetAssociationsResourceGroup()).To(Equal("spoke-rg"))
+	g.Expect(clusterScope.RouteTableSpecs()).To(ConsistOf(WithTransform(func(spec azure.ResourceSpecGetter) string {
+		return spec.ResourceGroupName()
+	}, Equal("spoke-rg"))))
+	g.Expect(clusterScope.NSGSpecs()).To(ConsistOf(WithTransform(func(spec azure.ResourceSpecGetter) string {
+		return spec.ResourceGroupName()
+	}, Equal("spoke-rg"))))
+	subnetSpecs := clusterScope.SubnetSpecs()
+	g.Expect(subnetSpecs).To(HaveLen(1))
+	subnetSpec := subnetSpecs[0].(*subnets.SubnetSpec)
+	g.Expect(subnetSpec.ResourceGroup).To(Equal("spoke-rg"))
+	g.Expect(subnetSpec.SubscriptionID).To(Equal("spoke-sub"))
+	g.Expect(subnetSpec.VNetResourceGroup).To(Equal("spoke-rg"))
+
+	// Without a vnet subscription they are created in the resource group of the cluster.
+	clusterScope.AzureCluster.Spec.NetworkSpec.Vnet.SubscriptionReference = infrav1.SubscriptionReference{}
+	g.Expect(clusterScope.SubnetAssociationsResourceGroup()).To(Equal("my-rg"))
+}
+
 func TestIsVnetManaged(t *testing.T) {
 	tests := []struct {
 		name         string
-		clusterScope ClusterScope
+		clusterScope *ClusterScope
 		want         bool
 	}{
 		{
 			name: "VNET ID is empty",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1443,7 +1515,7 @@ func TestIsVnetManaged(t *testing.T) {
 		},
 		{
 			name: "Wrong tags",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1467,7 +1539,7 @@ func TestIsVnetManaged(t *testing.T) {
 		},
 		{
 			name: "Has owning tags",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1491,7 +1563,7 @@ func TestIsVnetManaged(t *testing.T) {
 		},
 		{
 			name: "Has cached value of false",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{},
 				},
@@ -1503,7 +1575,7 @@ func TestIsVnetManaged(t *testing.T) {
 		},
 		{
 			name: "Has cached value of true",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{},
 				},
@@ -1533,12 +1605,12 @@ func TestIsVnetManaged(t *testing.T) {
 func TestAzureBastionSpec(t *testing.T) {
 	tests := []struct {
 		name         string
-		clusterScope ClusterScope
+		clusterScope *ClusterScope
 		want         azure.ResourceSpecGetter
 	}{
 		{
 			name: "returns nil if no subnets are specified",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{
 						NetworkSpec: infrav1.NetworkSpec{
@@ -1551,7 +1623,7 @@ func TestAzureBastionSpec(t *testing.T) {
 		},
 		{
 			name: "returns bastion spec if enabled",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1757,6 +1829,74 @@ func TestSubnet(t *testing.T) {
 	}
 }
 
+func TestSubnetsConcurrentAccess(t *testing.T) {
+	g := NewWithT(t)
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-cluster",
+			},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				NetworkSpec: infrav1.NetworkSpec{
+					Subnets: infrav1.Subnets{
+						{
+							SubnetClassSpec: infrav1.SubnetClassSpec{
+								Role: infrav1.SubnetNode,
+								Name: "node-subnet",
+							},
+							NatGateway: infrav1.NatGateway{
+								NatGatewayClassSpec: infrav1.NatGatewayClassSpec{
+									Name: "node-natgw",
+								},
+							},
+							RouteTable: infrav1.RouteTable{
+								Name: "node-routetable",
+							},
+							SecurityGroup: infrav1.SecurityGroup{
+								Name: "node-nsg",
+							},
+						},
+					},
+				},
+			},
+		},
+		cache: &ClusterCache{},
+	}
+
+	// The natgateways, routetables and securitygroups services are reconciled concurrently, run with -race.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(4)
+		id := fmt.Sprintf("natgw-id-%d", i)
+		go func() {
+			defer wg.Done()
+			clusterScope.SetNatGatewayIDInSubnets("node-natgw", id)
+		}()
+		go func() {
+			defer wg.Done()
+			clusterScope.RouteTableSpecs()
+		}()
+		go func() {
+			defer wg.Done()
+			clusterScope.NSGSpecs()
+		}()
+		go func() {
+			defer wg.Done()
+			clusterScope.NodeSubnets()
+		}()
+	}
+	wg.Wait()
+
+	// Changes to the returned subnets don't change the AzureCluster.
+	subnet := clusterScope.Subnet("node-subnet")
+	subnet.NatGateway.ID = "changed"
+	clusterScope.Subnets()[0].RouteTable.Name = "changed"
+	g.Expect(clusterScope.Subnet("node-subnet").NatGateway.ID).To(HavePrefix("natgw-id-"))
+	g.Expect(clusterScope.Subnet("node-subnet").RouteTable.Name).To(Equal("node-routetable"))
+}
+
 func TestControlPlaneRouteTable(t *testing.T) {
 	tests := []struct {
 		clusterName             string
@@ -2530,3 +2670,124 @@ func TestFailureDomains(t *testing.T) {
 		})
 	}
 }
+
+func TestVnetPeeringSpecs(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterScope := &ClusterScope{
+		AzureClients: AzureClients{
+			EnvironmentSettings: auth.EnvironmentSettings{
+				Values: map[string]string{
+					auth.SubscriptionID: "123",
+				},
+			},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				NetworkSpec: infrav1.NetworkSpec{
+					Vnet: infrav1.VnetSpec{
+						Name:          "spoke-vnet",
+						ResourceGroup: "spoke-rg",
+						Peerings: infrav1.VnetPeerings{
+							{
+								VnetPeeringClassSpec: infrav1.VnetPeeringClassSpec{
+									RemoteVnetName: "hub-vnet",
+									ResourceGroup:  "hub-rg",
+									SubscriptionReference: infrav1.SubscriptionReference{
+										SubscriptionID: "456",
+									},
+								},
+							},
+							{
+								VnetPeeringClassSpec: infrav1.VnetPeeringClassSpec{
+									RemoteVnetName: "other-vnet",
+									ResourceGroup:  "other-rg",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	g.Expect(clusterScope.VnetPeeringSpecs()).To(Equal([]azure.ResourceSpecGetter{
+		&vnetpeerings.VnetPeeringSpec{
+			PeeringName:          "spoke-vnet-To-hub-vnet",
+			SourceSubscriptionID: "123",
+			SourceVnetName:       "spoke-vnet",
+			SourceResourceGroup:  "spoke-rg",
+			RemoteSubscriptionID: "456",
+			RemoteVnetName:       "hub-vnet",
+			RemoteResourceGroup:  "hub-rg",
+		},
+		&vnetpeerings.VnetPeeringSpec{
+			PeeringName:          "hub-vnet-To-spoke-vnet",
+			SourceSubscriptionID: "456",
+			SourceVnetName:       "hub-vnet",
+			SourceResourceGroup:  "hub-rg",
+			RemoteSubscriptionID: "123",
+			RemoteVnetName:       "spoke-vnet",
+			RemoteResourceGroup:  "spoke-rg",
+		},
+		&vnetpeerings.VnetPeeringSpec{
+			PeeringName:          "spoke-vnet-To-other-vnet",
+			SourceSubscriptionID: "123",
+			SourceVnetName:       "spoke-vnet",
+			SourceResourceGroup:  "spoke-rg",
+			RemoteSubscriptionID: "123",
+			RemoteVnetName:       "other-vnet",
+			RemoteResourceGroup:  "other-rg",
+		},
+		&vnetpeerings.VnetPeeringSpec{
+			PeeringName:          "other-vnet-To-spoke-vnet",
+			SourceSubscriptionID: "123",
+			SourceVnetName:       "other-vnet",
+			SourceResourceGroup:  "other-rg",
+			RemoteSubscriptionID: "123",
+			RemoteVnetName:       "spoke-vnet",
+			RemoteResourceGroup:  "spoke-rg",
+		},
+	}))
+}
+
+func TestAuthorizerFor(t *testing.T) {
+	g := NewWithT(t)
+
+	hubClients := &AzureClients{
+		EnvironmentSettings: auth.EnvironmentSettings{
+			Values: map[string]string{
+				auth.SubscriptionID: "hub",
+				auth.ClientID:       "hub-client",
+			},
+		},
+		Authorizer:              autorest.NullAuthorizer{},
+		ResourceManagerEndpoint: "https://management.azure.com/",
+	}
+	clusterScope := &ClusterScope{
+		AzureClients: AzureClients{
+			EnvironmentSettings: auth.EnvironmentSettings{
+				Values: map[string]string{
+					auth.SubscriptionID: "123",
+					auth.ClientID:       "cluster-client",
+				},
+			},
+			Authorizer:              autorest.NullAuthorizer{},
+			ResourceManagerEndpoint: "https://management.azure.com/",
+		},
+		subscriptionClients: map[string]*AzureClients{"hub": hubClients},
+	}
+
+	g.Expect(clusterScope.AuthorizerFor("")).To(BeIdenticalTo(clusterScope))
+	g.Expect(clusterScope.AuthorizerFor("123")).To(BeIdenticalTo(clusterScope))
+
+	hub := clusterScope.AuthorizerFor("hub")
+	g.Expect(hub.SubscriptionID()).To(Equal("hub"))
+	g.Expect(hub.ClientID()).To(Equal("hub-client"))
+
+	other := clusterScope.AuthorizerFor("456")
+	g.Expect(other.SubscriptionID()).To(Equal("456"))
+	g.Expect(other.ClientID()).To(Equal("cluster-client"))
+	g.Expect(other.BaseURI()).To(Equal("https://management.azure.com/"))
+	g.Expect(clusterScope.SubscriptionID()).To(Equal("123"))
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"encoding/json"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+// driftReport collects the changes made outside of CAPZ detected by the services of a scope, and the hashes of the
+// specs last applied to the Azure resources of the scope.
+type driftReport struct {
+	mu     sync.Mutex
+	drifts []azure.ResourceDrift
+	// lastAppliedSpecs are loaded from and saved to the LastAppliedSpecsAnnotation of the object of the scope.
+	lastAppliedSpecs map[string]string
+	changed          bool
+}
+
+// newDriftReport returns a driftReport with the last applied specs of the given object. An invalid annotation is
+// ignored, as if no spec was known.
+func newDriftReport(obj metav1.Object) *driftReport {
+	r := &driftReport{lastAppliedSpecs: map[string]string{}}
+	if annotation := obj.GetAnnotations()[azure.LastAppliedSpecsAnnotation]; annotation != "" {
+		if err := json.Unmarshal([]byte(annotation), &r.lastAppliedSpecs); err != nil {
+			r.lastAppliedSpecs = map[string]string{}
+		}
+	}
+	return r
+}
+
+// RecordDrift records a change made outside of CAPZ to an Azure resource.
+func (r *driftReport) RecordDrift(drift azure.ResourceDrift) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.drifts = append(r.drifts, drift)
+}
+
+// Drifts returns the changes recorded so far.
+func (r *driftReport) Drifts() []azure.ResourceDrift {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]azure.ResourceDrift(nil), r.drifts...)
+}
+
+// LastAppliedSpec returns the hash of the spec last applied to the resource with the given key, or "" if unknown.
+func (r *driftReport) LastAppliedSpec(key string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lastAppliedSpecs[key]
+}
+
+// SetLastAppliedSpec records the hash of the spec last applied to the resource with the given key.
+func (r *driftReport) SetLastAppliedSpec(key, hash string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.lastAppliedSpecs == nil {
+		r.lastAppliedSpecs = map[string]string{}
+	}
+	if r.lastAppliedSpecs[key] != hash {
+		r.lastAppliedSpecs[key] = hash
+		r.changed = true
+	}
+}
+
+// saveLastAppliedSpecs saves the last applied specs in the annotation of the given object if they changed.
+func (r *driftReport) saveLastAppliedSpecs(obj metav1.Object) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.changed {
+		return nil
+	}
+	b, err := json.Marshal(r.lastAppliedSpecs)
+	if err != nil {
+		return err
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[azure.LastAppliedSpecsAnnotation] = string(b)
+	obj.SetAnnotations(annotations)
+	r.changed = false
+	return nil
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+func TestDriftReportLastAppliedSpecs(t *testing.T) {
+	g := NewWithT(t)
+	azureMachine := &infrav1.AzureMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				azure.LastAppliedSpecsAnnotation: `{"securitygroups/my-rg//my-nsg":"0123456789abcdef"}`,
+			},
+		},
+	}
+
+	r := newDriftReport(azureMachine)
+	g.Expect(r.LastAppliedSpec("securitygroups/my-rg//my-nsg")).To(Equal("0123456789abcdef"))
+	g.Expect(r.LastAppliedSpec("subnets/my-rg/my-vnet/my-subnet")).To(BeEmpty())
+
+	// The annotation is left as is when nothing changed.
+	r.SetLastAppliedSpec("securitygroups/my-rg//my-nsg", "0123456789abcdef")
+	azureMachine.Annotations = nil
+	g.Expect(r.saveLastAppliedSpecs(azureMachine)).To(Succeed())
+	g.Expect(azureMachine.Annotations).To(BeEmpty())
+
+	r.SetLastAppliedSpec("subnets/my-rg/my-vnet/my-subnet", "fedcba9876543210")
+	g.Expect(r.saveLastAppliedSpecs(azureMachine)).To(Succeed())
+	g.Expect(azureMachine.Annotations).To(HaveKeyWithValue(azure.LastAppliedSpecsAnnotation,
+		`{"securitygroups/my-rg//my-nsg":"0123456789abcdef","subnets/my-rg/my-vnet/my-subnet":"fedcba9876543210"}`))
+
+	// An invalid annotation is ignored.
+	azureMachine.Annotations[azure.LastAppliedSpecsAnnotation] = "not json"
+	g.Expect(newDriftReport(azureMachine).LastAppliedSpec("securitygroups/my-rg//my-nsg")).To(BeEmpty())
+}
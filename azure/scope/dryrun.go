@@ -0,0 +1,43 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"sync"
+
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+// dryRunPlan collects the changes planned by the services of a scope in dry-run mode.
+type dryRunPlan struct {
+	mu      sync.Mutex
+	changes []azure.PlannedChange
+}
+
+// RecordPlannedChange records a change that was not applied because of dry-run mode.
+func (p *dryRunPlan) RecordPlannedChange(change azure.PlannedChange) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.changes = append(p.changes, change)
+}
+
+// PlannedChanges returns the changes recorded so far.
+func (p *dryRunPlan) PlannedChanges() []azure.PlannedChange {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]azure.PlannedChange(nil), p.changes...)
+}
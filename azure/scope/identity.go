@@ -19,7 +19,10 @@ package scope
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"os"
 	"reflect"
+	"strings"
 
 	aadpodid "github.com/Azure/aad-pod-identity/pkg/apis/aadpodidentity"
 	aadpodv1 "github.com/Azure/aad-pod-identity/pkg/apis/aadpodidentity/v1"
@@ -39,7 +42,16 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-const azureSecretKey = "clientSecret"
+const (
+	azureSecretKey = "clientSecret"
+
+	// federatedTokenFileEnvVar is the environment variable set by the Azure Workload Identity webhook with the path
+	// of the projected service account token.
+	federatedTokenFileEnvVar = "AZURE_FEDERATED_TOKEN_FILE"
+	// defaultFederatedTokenFile is the path where the Azure Workload Identity webhook projects the service account
+	// token, used when federatedTokenFileEnvVar is not set.
+	defaultFederatedTokenFile = "/var/run/secrets/azure/tokens/azure-identity-token"
+)
 
 // CredentialsProvider defines the behavior for azure identity based credential providers.
 type CredentialsProvider interface {
@@ -76,7 +88,12 @@ func NewAzureClusterCredentialsProvider(ctx context.Context, kubeClient client.C
 		return nil, errors.New("failed to generate new AzureClusterCredentialsProvider from empty identityName")
 	}
 
-	ref := azureCluster.Spec.IdentityRef
+	return newAzureClusterCredentialsProvider(ctx, kubeClient, azureCluster, azureCluster.Spec.IdentityRef)
+}
+
+// newAzureClusterCredentialsProvider creates a new AzureClusterCredentialsProvider for an identity referenced by the AzureCluster,
+// either by the AzureCluster itself or by one of its subscription references.
+func newAzureClusterCredentialsProvider(ctx context.Context, kubeClient client.Client, azureCluster *infrav1.AzureCluster, ref *corev1.ObjectReference) (*AzureClusterCredentialsProvider, error) {
 	// if the namespace isn't specified then assume it's in the same namespace as the AzureCluster
 	namespace := ref.Namespace
 	if namespace == "" {
@@ -135,23 +152,63 @@ func (p *ManagedControlPlaneCredentialsProvider) GetAuthorizer(ctx context.Conte
 }
 
 // GetAuthorizer returns an Azure authorizer based on the provided azure identity and cluster metadata.
+// The underlying token is shared with every other scope using the same identity, so that it is only refreshed once.
 func (p *AzureCredentialsProvider) GetAuthorizer(ctx context.Context, resourceManagerEndpoint, activeDirectoryEndpoint string, clusterMeta metav1.ObjectMeta) (autorest.Authorizer, error) {
-	var spt *adal.ServicePrincipalToken
 	switch p.Identity.Spec.Type {
 	case infrav1.ServicePrincipal, infrav1.ServicePrincipalCertificate, infrav1.UserAssignedMSI:
+		// The AzureIdentity and AzureIdentityBinding are specific to the cluster, so they are created even if the
+		// token is cached.
 		if err := createAzureIdentityWithBindings(ctx, p.Identity, resourceManagerEndpoint, activeDirectoryEndpoint, clusterMeta, p.Client); err != nil {
 			return nil, err
 		}
+	case infrav1.ManualServicePrincipal, infrav1.WorkloadIdentity:
+	default:
+		return nil, errors.Errorf("identity type %s not supported", p.Identity.Spec.Type)
+	}
+
+	secret, err := p.getSecret(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get client secret")
+	}
+
+	key := tokenCacheKey{
+		identityType:            p.Identity.Spec.Type,
+		tenantID:                p.GetTenantID(),
+		clientID:                p.GetClientID(),
+		secretNamespace:         p.Identity.Spec.ClientSecret.Namespace,
+		secretName:              p.Identity.Spec.ClientSecret.Name,
+		resourceManagerEndpoint: resourceManagerEndpoint,
+		activeDirectoryEndpoint: activeDirectoryEndpoint,
+	}
+	var secretResourceVersion string
+	if secret != nil {
+		secretResourceVersion = secret.ResourceVersion
+	}
 
+	spt, err := credentialTokenCache.getOrCreate(ctx, key, secretResourceVersion, func() (*adal.ServicePrincipalToken, error) {
+		return p.newServicePrincipalToken(resourceManagerEndpoint, activeDirectoryEndpoint, secret)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return autorest.NewBearerAuthorizer(spt), nil
+}
+
+// newServicePrincipalToken creates a token for the identity. The token is only acquired from AAD once it is used.
+func (p *AzureCredentialsProvider) newServicePrincipalToken(resourceManagerEndpoint, activeDirectoryEndpoint string, secret *corev1.Secret) (*adal.ServicePrincipalToken, error) {
+	switch p.Identity.Spec.Type {
+	case infrav1.ServicePrincipal, infrav1.ServicePrincipalCertificate, infrav1.UserAssignedMSI:
 		msiEndpoint, err := adal.GetMSIVMEndpoint()
 		if err != nil {
 			return nil, errors.Errorf("failed to get MSI endpoint: %v", err)
 		}
 
-		spt, err = adal.NewServicePrincipalTokenFromMSIWithUserAssignedID(msiEndpoint, resourceManagerEndpoint, p.Identity.Spec.ClientID)
+		spt, err := adal.NewServicePrincipalTokenFromMSIWithUserAssignedID(msiEndpoint, resourceManagerEndpoint, p.Identity.Spec.ClientID)
 		if err != nil {
 			return nil, errors.Errorf("failed to get token from service principal identity: %v", err)
 		}
+		return spt, nil
 
 	case infrav1.ManualServicePrincipal:
 		oauthConfig, err := adal.NewOAuthConfig(activeDirectoryEndpoint, p.GetTenantID())
@@ -159,21 +216,57 @@ func (p *AzureCredentialsProvider) GetAuthorizer(ctx context.Context, resourceMa
 			return nil, err
 		}
 
-		clientSecret, err := p.GetClientSecret(ctx)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to get client secret")
+		var clientSecret string
+		if secret != nil {
+			clientSecret = string(secret.Data[azureSecretKey])
 		}
 
-		spt, err = adal.NewServicePrincipalToken(*oauthConfig, p.Identity.Spec.ClientID, clientSecret, resourceManagerEndpoint)
+		spt, err := adal.NewServicePrincipalToken(*oauthConfig, p.Identity.Spec.ClientID, clientSecret, resourceManagerEndpoint)
 		if err != nil {
 			return nil, errors.Errorf("failed to get token from service principal identity: %v", err)
 		}
+		return spt, nil
 
-	default:
-		return nil, errors.Errorf("identity type %s not supported", p.Identity.Spec.Type)
+	case infrav1.WorkloadIdentity:
+		oauthConfig, err := adal.NewOAuthConfig(activeDirectoryEndpoint, p.GetTenantID())
+		if err != nil {
+			return nil, err
+		}
+
+		spt, err := adal.NewServicePrincipalTokenWithSecret(*oauthConfig, p.Identity.Spec.ClientID, resourceManagerEndpoint, &federatedTokenSecret{tokenFile: getFederatedTokenFile()})
+		if err != nil {
+			return nil, errors.Errorf("failed to get token from workload identity: %v", err)
+		}
+		return spt, nil
 	}
 
-	return autorest.NewBearerAuthorizer(spt), nil
+	return nil, errors.Errorf("identity type %s not supported", p.Identity.Spec.Type)
+}
+
+// federatedTokenSecret is an adal.ServicePrincipalSecret which authenticates with a projected service account token
+// that is trusted by a federated identity credential of the AAD application.
+type federatedTokenSecret struct {
+	tokenFile string
+}
+
+// SetAuthenticationValues sets the service account token as the client assertion. The token is read again every time
+// as the kubelet rotates it before it expires.
+func (s *federatedTokenSecret) SetAuthenticationValues(_ *adal.ServicePrincipalToken, v *url.Values) error {
+	token, err := os.ReadFile(s.tokenFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read federated token file %s", s.tokenFile)
+	}
+	v.Set("client_assertion_type", "urn:ietf:params:oauth:client-assertion-type:jwt-bearer")
+	v.Set("client_assertion", strings.TrimSpace(string(token)))
+	return nil
+}
+
+// getFederatedTokenFile returns the path of the projected service account token used by WorkloadIdentity.
+func getFederatedTokenFile() string {
+	if tokenFile, ok := os.LookupEnv(federatedTokenFileEnvVar); ok && tokenFile != "" {
+		return tokenFile
+	}
+	return defaultFederatedTokenFile
 }
 
 // GetClientID returns the Client ID associated with the AzureCredentialsProvider's Identity.
@@ -186,21 +279,33 @@ func (p *AzureCredentialsProvider) GetClientID() string {
 // If using another type of credentials, such a Certificate, we return an empty string.
 func (p *AzureCredentialsProvider) GetClientSecret(ctx context.Context) (string, error) {
 	if p.hasClientSecret() {
-		secretRef := p.Identity.Spec.ClientSecret
-		key := types.NamespacedName{
-			Namespace: secretRef.Namespace,
-			Name:      secretRef.Name,
-		}
-		secret := &corev1.Secret{}
-
-		if err := p.Client.Get(ctx, key, secret); err != nil {
-			return "", errors.Wrap(err, "Unable to fetch ClientSecret")
+		secret, err := p.getSecret(ctx)
+		if err != nil || secret == nil {
+			return "", err
 		}
 		return string(secret.Data[azureSecretKey]), nil
 	}
 	return "", nil
 }
 
+// getSecret returns the Secret referenced by the Identity, or nil if it does not reference one.
+func (p *AzureCredentialsProvider) getSecret(ctx context.Context) (*corev1.Secret, error) {
+	secretRef := p.Identity.Spec.ClientSecret
+	if secretRef.Name == "" {
+		return nil, nil
+	}
+	key := types.NamespacedName{
+		Namespace: secretRef.Namespace,
+		Name:      secretRef.Name,
+	}
+	secret := &corev1.Secret{}
+
+	if err := p.Client.Get(ctx, key, secret); err != nil {
+		return nil, errors.Wrap(err, "Unable to fetch ClientSecret")
+	}
+	return secret, nil
+}
+
 // GetTenantID returns the Tenant ID associated with the AzureCredentialsProvider's Identity.
 func (p *AzureCredentialsProvider) GetTenantID() string {
 	return p.Identity.Spec.TenantID
@@ -18,10 +18,17 @@ package scope
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 
 	aadpodid "github.com/Azure/aad-pod-identity/pkg/apis/aadpodidentity"
 	aadpodv1 "github.com/Azure/aad-pod-identity/pkg/apis/aadpodidentity/v1"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -341,6 +348,15 @@ func TestHasClientSecret(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "workload identity",
+			identity: &infrav1.AzureClusterIdentity{
+				Spec: infrav1.AzureClusterIdentitySpec{
+					Type: infrav1.WorkloadIdentity,
+				},
+			},
+			want: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -353,3 +369,68 @@ func TestHasClientSecret(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAuthorizerWorkloadIdentity(t *testing.T) {
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	_ = aadpodv1.AddToScheme(scheme)
+
+	tokenFile := filepath.Join(t.TempDir(), "azure-identity-token")
+	g.Expect(os.WriteFile(tokenFile, []byte("my-service-account-token\n"), 0600)).To(Succeed())
+	t.Setenv(federatedTokenFileEnvVar, tokenFile)
+
+	var form url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.URL.Path).To(Equal("/my-tenant-id/oauth2/token"))
+		g.Expect(r.ParseForm()).To(Succeed())
+		form = r.PostForm
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"my-access-token","expires_in":"3600","expires_on":"4102444800","resource":"https://management.azure.com/","token_type":"Bearer"}`))
+	}))
+	defer server.Close()
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	p := &AzureCredentialsProvider{
+		Client: fakeClient,
+		Identity: &infrav1.AzureClusterIdentity{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-identity",
+				Namespace: "default",
+			},
+			Spec: infrav1.AzureClusterIdentitySpec{
+				Type:     infrav1.WorkloadIdentity,
+				TenantID: "my-tenant-id",
+				ClientID: "my-client-id",
+			},
+		},
+	}
+
+	authorizer, err := p.GetAuthorizer(context.TODO(), "https://management.azure.com/", server.URL+"/", metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	bearer, ok := authorizer.(*autorest.BearerAuthorizer)
+	g.Expect(ok).To(BeTrue())
+	spt, ok := bearer.TokenProvider().(*adal.ServicePrincipalToken)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(spt.Refresh()).To(Succeed())
+	g.Expect(spt.OAuthToken()).To(Equal("my-access-token"))
+	g.Expect(form.Get("client_id")).To(Equal("my-client-id"))
+	g.Expect(form.Get("client_assertion_type")).To(Equal("urn:ietf:params:oauth:client-assertion-type:jwt-bearer"))
+	g.Expect(form.Get("client_assertion")).To(Equal("my-service-account-token"))
+	g.Expect(form.Get("client_secret")).To(BeEmpty())
+
+	// workload identity does not rely on aad-pod-identity.
+	identities := &aadpodv1.AzureIdentityList{}
+	g.Expect(fakeClient.List(context.TODO(), identities)).To(Succeed())
+	g.Expect(identities.Items).To(BeEmpty())
+}
+
+func TestFederatedTokenSecretMissingFile(t *testing.T) {
+	g := NewWithT(t)
+
+	secret := &federatedTokenSecret{tokenFile: filepath.Join(t.TempDir(), "missing")}
+	err := secret.SetAuthenticationValues(nil, &url.Values{})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("failed to read federated token file"))
+}
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -37,6 +38,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/roleassignments"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachineimages"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachines"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vmextensions"
@@ -85,6 +87,8 @@ func NewMachineScope(params MachineScopeParams) (*MachineScope, error) {
 		patchHelper:   helper,
 		ClusterScoper: params.ClusterScope,
 		cache:         params.Cache,
+		dryRunPlan:    &dryRunPlan{},
+		driftReport:   newDriftReport(params.AzureMachine),
 	}, nil
 }
 
@@ -97,6 +101,8 @@ type MachineScope struct {
 	Machine      *clusterv1.Machine
 	AzureMachine *infrav1.AzureMachine
 	cache        *MachineCache
+	*dryRunPlan
+	*driftReport
 }
 
 // MachineCache stores common machine information so we don't have to hit the API multiple times within the same reconcile loop.
@@ -151,6 +157,7 @@ func (m *MachineScope) VMSpec() azure.ResourceSpecGetter {
 		Name:                   m.Name(),
 		Location:               m.Location(),
 		ResourceGroup:          m.ResourceGroup(),
+		SubscriptionID:         m.SubscriptionID(),
 		ClusterName:            m.ClusterName(),
 		Role:                   m.Role(),
 		NICIDs:                 m.NICIDs(),
@@ -177,22 +184,30 @@ func (m *MachineScope) VMSpec() azure.ResourceSpecGetter {
 }
 
 // TagsSpecs returns the tags for the AzureMachine.
-func (m *MachineScope) TagsSpecs() []azure.TagsSpec {
-	return []azure.TagsSpec{
-		{
-			Scope:      azure.VMID(m.SubscriptionID(), m.ResourceGroup(), m.Name()),
-			Tags:       m.AdditionalTags(),
-			Annotation: azure.VMTagsLastAppliedAnnotation,
+func (m *MachineScope) TagsSpecs() []azure.ResourceSpecGetter {
+	return []azure.ResourceSpecGetter{
+		&tags.TagsSpec{
+			Scope:       azure.VMID(m.SubscriptionID(), m.ResourceGroup(), m.Name()),
+			Tags:        m.AdditionalTags(),
+			ClusterName: m.ClusterName(),
+			Annotation:  azure.VMTagsLastAppliedAnnotation,
 		},
 	}
 }
 
 // PublicIPSpecs returns the public IP specs.
 func (m *MachineScope) PublicIPSpecs() []azure.ResourceSpecGetter {
+	var names []string
+	if adopted, ok := m.AdoptedResources(); ok {
+		names = adopted.PublicIPs
+	} else if m.AzureMachine.Spec.AllocatePublicIP {
+		names = []string{azure.GenerateNodePublicIPName(m.Name())}
+	}
+
 	var specs []azure.ResourceSpecGetter
-	if m.AzureMachine.Spec.AllocatePublicIP {
+	for _, name := range names {
 		specs = append(specs, &publicips.PublicIPSpec{
-			Name:           azure.GenerateNodePublicIPName(m.Name()),
+			Name:           name,
 			ResourceGroup:  m.ResourceGroup(),
 			ClusterName:    m.ClusterName(),
 			DNSName:        "",    // Set to default value
@@ -234,6 +249,7 @@ func (m *MachineScope) NICSpecs() []azure.ResourceSpecGetter {
 		Location:              m.Location(),
 		SubscriptionID:        m.SubscriptionID(),
 		MachineName:           m.Name(),
+		VNetSubscriptionID:    m.VnetSubscriptionID(),
 		VNetName:              m.Vnet().Name,
 		VNetResourceGroup:     m.Vnet().ResourceGroup,
 		SubnetName:            m.AzureMachine.Spec.SubnetName,
@@ -271,6 +287,17 @@ func (m *MachineScope) NICSpecs() []azure.ResourceSpecGetter {
 		spec.SKU = &m.cache.VMSKU
 	}
 
+	// The network interfaces of an adopted VM are managed in place of the one named after the AzureMachine.
+	if adopted, ok := m.AdoptedResources(); ok && len(adopted.NetworkInterfaces) > 0 {
+		specs := make([]azure.ResourceSpecGetter, len(adopted.NetworkInterfaces))
+		for i, name := range adopted.NetworkInterfaces {
+			nicSpec := *spec
+			nicSpec.Name = name
+			specs[i] = &nicSpec
+		}
+		return specs
+	}
+
 	return []azure.ResourceSpecGetter{spec}
 }
 
@@ -286,6 +313,28 @@ func (m *MachineScope) NICIDs() []string {
 
 // DiskSpecs returns the disk specs.
 func (m *MachineScope) DiskSpecs() []azure.ResourceSpecGetter {
+	if adopted, ok := m.AdoptedResources(); ok {
+		var diskSpecs []azure.ResourceSpecGetter
+		if adopted.OSDisk != "" {
+			diskSpecs = append(diskSpecs, &disks.DiskSpec{
+				Name:          adopted.OSDisk,
+				ResourceGroup: m.ResourceGroup(),
+			})
+		}
+		for _, name := range adopted.DataDisks {
+			diskSpec := &disks.DiskSpec{
+				Name:          name,
+				ResourceGroup: m.ResourceGroup(),
+				ClusterName:   m.ClusterName(),
+			}
+			if dd, ok := m.adoptedDataDisk(name, adopted); ok {
+				diskSpec.DeletePolicy = dd.DeletePolicy
+			}
+			diskSpecs = append(diskSpecs, diskSpec)
+		}
+		return diskSpecs
+	}
+
 	diskSpecs := make([]azure.ResourceSpecGetter, 1+len(m.AzureMachine.Spec.DataDisks))
 	diskSpecs[0] = &disks.DiskSpec{
 		Name:          azure.GenerateOSDiskName(m.Name()),
@@ -296,8 +345,64 @@ func (m *MachineScope) DiskSpecs() []azure.ResourceSpecGetter {
 		diskSpecs[i+1] = &disks.DiskSpec{
 			Name:          azure.GenerateDataDiskName(m.Name(), dd.NameSuffix),
 			ResourceGroup: m.ResourceGroup(),
+			ClusterName:   m.ClusterName(),
+			DeletePolicy:  dd.DeletePolicy,
+		}
+	}
+	return diskSpecs
+}
+
+// adoptedDataDisk returns the data disk of the AzureMachine which matches a data disk of the adopted VM, by name or
+// else by LUN.
+func (m *MachineScope) adoptedDataDisk(name string, adopted azure.AdoptedVMResources) (infrav1.DataDisk, bool) {
+	for _, dd := range m.AzureMachine.Spec.DataDisks {
+		if strings.EqualFold(azure.GenerateDataDiskName(m.Name(), dd.NameSuffix), name) {
+			return dd, true
+		}
+	}
+	lun, ok := adopted.DataDiskLUNs[name]
+	if !ok {
+		return infrav1.DataDisk{}, false
+	}
+	for _, dd := range m.AzureMachine.Spec.DataDisks {
+		if dd.Lun != nil && *dd.Lun == lun {
+			return dd, true
 		}
 	}
+	return infrav1.DataDisk{}, false
+}
+
+// DataDiskSpecs returns the specs of the data disks of the machine. The data disks with provisioned performance
+// settings are created before the VM, all other data disks are created with the VM. Adopted machines already have
+// all of their disks.
+func (m *MachineScope) DataDiskSpecs() []azure.ResourceSpecGetter {
+	if _, ok := m.AdoptedResources(); ok {
+		return nil
+	}
+
+	var diskSpecs []azure.ResourceSpecGetter
+	for _, dd := range m.AzureMachine.Spec.DataDisks {
+		spec := &disks.DiskSpec{
+			Name:              azure.GenerateDataDiskName(m.Name(), dd.NameSuffix),
+			ResourceGroup:     m.ResourceGroup(),
+			Location:          m.Location(),
+			Zone:              m.AvailabilityZone(),
+			ClusterName:       m.ClusterName(),
+			AdditionalTags:    m.AdditionalTags(),
+			SizeGB:            dd.DiskSizeGB,
+			CreateWithVM:      !dd.HasProvisionedPerformance(),
+			DiskIOPSReadWrite: dd.DiskIOPSReadWrite,
+			DiskMBpsReadWrite: dd.DiskMBpsReadWrite,
+			LogicalSectorSize: dd.LogicalSectorSize,
+		}
+		if dd.ManagedDisk != nil {
+			spec.StorageAccountType = dd.ManagedDisk.StorageAccountType
+			if dd.ManagedDisk.DiskEncryptionSet != nil {
+				spec.DiskEncryptionSetID = dd.ManagedDisk.DiskEncryptionSet.ID
+			}
+		}
+		diskSpecs = append(diskSpecs, spec)
+	}
 	return diskSpecs
 }
 
@@ -348,6 +453,11 @@ func (m *MachineScope) VMExtensionSpecs() []azure.ResourceSpecGetter {
 		})
 	}
 
+	// An adopted VM is already bootstrapped.
+	if m.IsAdopting() {
+		return extensionSpecs
+	}
+
 	bootstrapExtensionSpec := azure.GetBootstrappingVMExtension(m.AzureMachine.Spec.OSDisk.OSType, m.CloudEnvironment(), m.Name())
 
 	if bootstrapExtensionSpec != nil {
@@ -394,6 +504,9 @@ func (m *MachineScope) Name() string {
 	if id := m.GetVMID(); id != "" {
 		return id
 	}
+	if name := m.adoptedVMName(); name != "" {
+		return name
+	}
 	// Windows Machine names cannot be longer than 15 chars
 	if m.AzureMachine.Spec.OSDisk.OSType == azure.WindowsOS && len(m.AzureMachine.Name) > 15 {
 		return strings.TrimSuffix(m.AzureMachine.Name[0:9], "-") + "-" + m.AzureMachine.Name[len(m.AzureMachine.Name)-5:]
@@ -401,6 +514,65 @@ func (m *MachineScope) Name() string {
 	return m.AzureMachine.Name
 }
 
+// IsAdopting returns true if the AzureMachine adopts an existing VM instead of creating one.
+func (m *MachineScope) IsAdopting() bool {
+	value := m.AzureMachine.GetAnnotations()[infrav1.AdoptAnnotation]
+	return value != "" && value != "false"
+}
+
+// adoptedVMName returns the name of the VM the AzureMachine adopts when it is not named after the AzureMachine.
+func (m *MachineScope) adoptedVMName() string {
+	value := m.AzureMachine.GetAnnotations()[infrav1.AdoptAnnotation]
+	if value == "" || value == "true" || value == "false" {
+		return ""
+	}
+	if parsed, err := azureautorest.ParseResourceID(value); err == nil {
+		return parsed.ResourceName
+	}
+	return value
+}
+
+// AdoptedVMID returns the resource ID of the VM the AzureMachine adopts, if it is set instead of the name of the VM.
+func (m *MachineScope) AdoptedVMID() string {
+	value := m.AzureMachine.GetAnnotations()[infrav1.AdoptAnnotation]
+	if _, err := azureautorest.ParseResourceID(value); err != nil {
+		return ""
+	}
+	return value
+}
+
+// AdoptedResources returns the names of the resources of the VM adopted by the AzureMachine, and whether the VM is
+// adopted.
+func (m *MachineScope) AdoptedResources() (azure.AdoptedVMResources, bool) {
+	var resources azure.AdoptedVMResources
+	value, ok := m.AzureMachine.GetAnnotations()[azure.VMAdoptedResourcesAnnotation]
+	if !ok {
+		return resources, false
+	}
+	if err := json.Unmarshal([]byte(value), &resources); err != nil {
+		return resources, false
+	}
+	return resources, true
+}
+
+// SetAdoptedResources records the names of the resources of the VM adopted by the AzureMachine.
+func (m *MachineScope) SetAdoptedResources(resources azure.AdoptedVMResources) {
+	b, err := json.Marshal(resources)
+	if err != nil {
+		return
+	}
+	m.SetAnnotation(azure.VMAdoptedResourcesAnnotation, string(b))
+}
+
+// UpdateAdoptionStatus updates the VMAdopted condition of the AzureMachine after adopting an existing VM.
+func (m *MachineScope) UpdateAdoptionStatus(err error) {
+	if err != nil {
+		conditions.MarkFalse(m.AzureMachine, infrav1.VMAdoptedCondition, infrav1.AdoptionFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return
+	}
+	conditions.MarkTrue(m.AzureMachine, infrav1.VMAdoptedCondition)
+}
+
 // Namespace returns the namespace name.
 func (m *MachineScope) Namespace() string {
 	return m.AzureMachine.Namespace
@@ -596,6 +768,9 @@ func (m *MachineScope) SetAddresses(addrs []corev1.NodeAddress) {
 // PatchObject persists the machine spec and status.
 func (m *MachineScope) PatchObject(ctx context.Context) error {
 	conditions.SetSummary(m.AzureMachine)
+	if err := m.saveLastAppliedSpecs(m.AzureMachine); err != nil {
+		return errors.Wrap(err, "failed to save the last applied specs")
+	}
 
 	return m.patchHelper.Patch(
 		ctx,
@@ -605,6 +780,9 @@ func (m *MachineScope) PatchObject(ctx context.Context) error {
 			infrav1.VMRunningCondition,
 			infrav1.AvailabilitySetReadyCondition,
 			infrav1.NetworkInterfaceReadyCondition,
+			infrav1.ChangesAppliedCondition,
+			infrav1.DriftDetectedCondition,
+			infrav1.VMAdoptedCondition,
 		}})
 }
 
@@ -718,6 +896,8 @@ func (m *MachineScope) UpdateDeleteStatus(condition clusterv1.ConditionType, ser
 		conditions.MarkFalse(m.AzureMachine, condition, infrav1.DeletedReason, clusterv1.ConditionSeverityInfo, "%s successfully deleted", service)
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(m.AzureMachine, condition, infrav1.DeletingReason, clusterv1.ConditionSeverityInfo, "%s deleting", service)
+	case azure.IsDryRunError(err):
+		conditions.MarkFalse(m.AzureMachine, condition, infrav1.DryRunReason, clusterv1.ConditionSeverityInfo, "%s was not deleted in dry-run mode", service)
 	default:
 		conditions.MarkFalse(m.AzureMachine, condition, infrav1.DeletionFailedReason, clusterv1.ConditionSeverityError, "%s failed to delete. err: %s", service, err.Error())
 	}
@@ -730,6 +910,8 @@ func (m *MachineScope) UpdatePutStatus(condition clusterv1.ConditionType, servic
 		conditions.MarkTrue(m.AzureMachine, condition)
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(m.AzureMachine, condition, infrav1.CreatingReason, clusterv1.ConditionSeverityInfo, "%s creating or updating", service)
+	case azure.IsDryRunError(err):
+		conditions.MarkFalse(m.AzureMachine, condition, infrav1.DryRunReason, clusterv1.ConditionSeverityInfo, "%s has changes that were not applied in dry-run mode", service)
 	default:
 		conditions.MarkFalse(m.AzureMachine, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to create or update. err: %s", service, err.Error())
 	}
@@ -746,3 +928,8 @@ func (m *MachineScope) UpdatePatchStatus(condition clusterv1.ConditionType, serv
 		conditions.MarkFalse(m.AzureMachine, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to update. err: %s", service, err.Error())
 	}
 }
+
+// IsDryRun returns true if the changes to the AzureMachine's resources should be planned but not applied.
+func (m *MachineScope) IsDryRun() bool {
+	return azure.IsDryRunEnabled(m.AzureMachine.GetAnnotations())
+}
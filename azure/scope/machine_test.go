@@ -32,6 +32,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/disks"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/inboundnatrules"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/networkinterfaces"
@@ -41,9 +42,51 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachineimages"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachineimages/mock_virtualmachineimages"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vmextensions"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
+func TestMachineScope_AdoptedVMID(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation string
+		want       string
+	}{
+		{
+			name:       "the AzureMachine adopts a VM by resource ID",
+			annotation: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/legacy-node-1",
+			want:       "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/legacy-node-1",
+		},
+		{
+			name:       "the AzureMachine adopts a VM by name",
+			annotation: "legacy-node-1",
+			want:       "",
+		},
+		{
+			name:       "the AzureMachine adopts the VM named after it",
+			annotation: "true",
+			want:       "",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			machineScope := MachineScope{
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine-name",
+						Annotations: map[string]string{
+							infrav1.AdoptAnnotation: tt.annotation,
+						},
+					},
+				},
+			}
+			g.Expect(machineScope.AdoptedVMID()).To(Equal(tt.want))
+		})
+	}
+}
+
 func TestMachineScope_Name(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -51,6 +94,48 @@ func TestMachineScope_Name(t *testing.T) {
 		want         string
 		testLength   bool
 	}{
+		{
+			name: "if the AzureMachine adopts a VM by name, use it",
+			machineScope: MachineScope{
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine-name",
+						Annotations: map[string]string{
+							infrav1.AdoptAnnotation: "legacy-node-1",
+						},
+					},
+				},
+			},
+			want: "legacy-node-1",
+		},
+		{
+			name: "if the AzureMachine adopts a VM by resource ID, use its name",
+			machineScope: MachineScope{
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine-name",
+						Annotations: map[string]string{
+							infrav1.AdoptAnnotation: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/legacy-node-1",
+						},
+					},
+				},
+			},
+			want: "legacy-node-1",
+		},
+		{
+			name: "if the AzureMachine adopts the VM named after it, use its name",
+			machineScope: MachineScope{
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine-name",
+						Annotations: map[string]string{
+							infrav1.AdoptAnnotation: "true",
+						},
+					},
+				},
+			},
+			want: "machine-name",
+		},
 		{
 			name: "if provider ID exists, use it",
 			machineScope: MachineScope{
@@ -496,6 +581,43 @@ func TestMachineScope_VMExtensionSpecs(t *testing.T) {
 		machineScope MachineScope
 		want         []azure.ResourceSpecGetter
 	}{
+		{
+			name: "If the AzureMachine adopts a VM, it does not return the bootstrap ExtensionSpec",
+			machineScope: MachineScope{
+				Machine: &clusterv1.Machine{},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine-name",
+						Annotations: map[string]string{
+							infrav1.AdoptAnnotation: "true",
+						},
+					},
+					Spec: infrav1.AzureMachineSpec{
+						OSDisk: infrav1.OSDisk{
+							OSType: "Linux",
+						},
+					},
+				},
+				ClusterScoper: &ClusterScope{
+					AzureClients: AzureClients{
+						EnvironmentSettings: auth.EnvironmentSettings{
+							Environment: autorestazure.Environment{
+								Name: autorestazure.PublicCloud.Name,
+							},
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+							AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+								Location: "westus",
+							},
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{},
+		},
 		{
 			name: "If OS type is Linux and cloud is AzurePublicCloud, it returns ExtensionSpec",
 			machineScope: MachineScope{
@@ -1698,6 +1820,138 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					SubscriptionID:            "123",
 					MachineName:               "machine-name",
 					SubnetName:                "subnet1",
+					VNetSubscriptionID:        "123",
+					VNetName:                  "vnet1",
+					VNetResourceGroup:         "rg1",
+					PublicLBName:              "outbound-lb",
+					PublicLBAddressPoolName:   "outbound-lb-outboundBackendPool",
+					PublicLBNATRuleName:       "",
+					InternalLBName:            "",
+					InternalLBAddressPoolName: "",
+					PublicIPName:              "",
+					AcceleratedNetworking:     nil,
+					DNSServers:                nil,
+					IPv6Enabled:               false,
+					EnableIPForwarding:        false,
+					SKU:                       nil,
+					ClusterName:               "cluster",
+					AdditionalTags: infrav1.Tags{
+						"kubernetes.io_cluster_cluster": "owned",
+					},
+				},
+			},
+		},
+		{
+			name: "Node Machine adopting a VM with two network interfaces",
+			machineScope: MachineScope{
+				ClusterScoper: &ClusterScope{
+					AzureClients: AzureClients{
+						EnvironmentSettings: auth.EnvironmentSettings{
+							Values: map[string]string{
+								auth.SubscriptionID: "123",
+							},
+						},
+					},
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "cluster",
+							Namespace: "default",
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "cluster",
+							Namespace: "default",
+							OwnerReferences: []metav1.OwnerReference{
+								{
+									APIVersion: "cluster.x-k8s.io/v1beta1",
+									Kind:       "Cluster",
+									Name:       "cluster",
+								},
+							},
+						},
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+							AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+								Location: "westus",
+							},
+							NetworkSpec: infrav1.NetworkSpec{
+								Vnet: infrav1.VnetSpec{
+									Name:          "vnet1",
+									ResourceGroup: "rg1",
+								},
+								Subnets: []infrav1.SubnetSpec{
+									{
+										SubnetClassSpec: infrav1.SubnetClassSpec{
+											Role: infrav1.SubnetNode,
+											Name: "subnet1",
+										},
+									},
+								},
+								NodeOutboundLB: &infrav1.LoadBalancerSpec{
+									Name: "outbound-lb",
+								},
+							},
+						},
+					},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine",
+						Annotations: map[string]string{
+							infrav1.AdoptAnnotation:            "machine-name",
+							azure.VMAdoptedResourcesAnnotation: `{"networkInterfaces":["legacy-nic-1","legacy-nic-2"]}`,
+						},
+					},
+					Spec: infrav1.AzureMachineSpec{
+						ProviderID: to.StringPtr("azure://compute/virtual-machines/machine-name"),
+						SubnetName: "subnet1",
+					},
+				},
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "machine",
+						Labels: map[string]string{
+							// clusterv1.MachineControlPlaneLabelName: "true",
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&networkinterfaces.NICSpec{
+					Name:                      "legacy-nic-1",
+					ResourceGroup:             "my-rg",
+					Location:                  "westus",
+					SubscriptionID:            "123",
+					MachineName:               "machine-name",
+					SubnetName:                "subnet1",
+					VNetSubscriptionID:        "123",
+					VNetName:                  "vnet1",
+					VNetResourceGroup:         "rg1",
+					PublicLBName:              "outbound-lb",
+					PublicLBAddressPoolName:   "outbound-lb-outboundBackendPool",
+					PublicLBNATRuleName:       "",
+					InternalLBName:            "",
+					InternalLBAddressPoolName: "",
+					PublicIPName:              "",
+					AcceleratedNetworking:     nil,
+					DNSServers:                nil,
+					IPv6Enabled:               false,
+					EnableIPForwarding:        false,
+					SKU:                       nil,
+					ClusterName:               "cluster",
+					AdditionalTags: infrav1.Tags{
+						"kubernetes.io_cluster_cluster": "owned",
+					},
+				},
+				&networkinterfaces.NICSpec{
+					Name:                      "legacy-nic-2",
+					ResourceGroup:             "my-rg",
+					Location:                  "westus",
+					SubscriptionID:            "123",
+					MachineName:               "machine-name",
+					SubnetName:                "subnet1",
+					VNetSubscriptionID:        "123",
 					VNetName:                  "vnet1",
 					VNetResourceGroup:         "rg1",
 					PublicLBName:              "outbound-lb",
@@ -1803,6 +2057,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					SubscriptionID:            "123",
 					MachineName:               "machine-name",
 					SubnetName:                "subnet1",
+					VNetSubscriptionID:        "123",
 					VNetName:                  "vnet1",
 					VNetResourceGroup:         "rg1",
 					PublicLBName:              "outbound-lb",
@@ -1910,6 +2165,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					SubscriptionID:            "123",
 					MachineName:               "machine-name",
 					SubnetName:                "subnet1",
+					VNetSubscriptionID:        "123",
 					VNetName:                  "vnet1",
 					VNetResourceGroup:         "rg1",
 					PublicLBName:              "",
@@ -2011,6 +2267,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					SubscriptionID:            "123",
 					MachineName:               "machine-name",
 					SubnetName:                "subnet1",
+					VNetSubscriptionID:        "123",
 					VNetName:                  "vnet1",
 					VNetResourceGroup:         "rg1",
 					PublicLBName:              "",
@@ -2117,6 +2374,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					SubscriptionID:            "123",
 					MachineName:               "machine-name",
 					SubnetName:                "subnet1",
+					VNetSubscriptionID:        "123",
 					VNetName:                  "vnet1",
 					VNetResourceGroup:         "rg1",
 					PublicLBName:              "",
@@ -2220,6 +2478,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					SubscriptionID:            "123",
 					MachineName:               "machine-name",
 					SubnetName:                "subnet1",
+					VNetSubscriptionID:        "123",
 					VNetName:                  "vnet1",
 					VNetResourceGroup:         "rg1",
 					PublicLBName:              "api-lb",
@@ -2324,6 +2583,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					SubscriptionID:            "123",
 					MachineName:               "machine-name",
 					SubnetName:                "subnet1",
+					VNetSubscriptionID:        "123",
 					VNetName:                  "vnet1",
 					VNetResourceGroup:         "rg1",
 					PublicLBName:              "api-lb",
@@ -2355,12 +2615,211 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 	}
 }
 
+func TestMachineScope_DeleteAdoptedDisks(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+	machineScope := &MachineScope{
+		ClusterScoper: &ClusterScope{
+			Cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster",
+				},
+			},
+			AzureCluster: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					ResourceGroup: "my-rg",
+				},
+			},
+		},
+		AzureMachine: &infrav1.AzureMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-azure-machine",
+				Annotations: map[string]string{
+					infrav1.AdoptAnnotation:            "legacy-node-1",
+					azure.VMAdoptedResourcesAnnotation: `{"osDisk":"legacy-node-1-os","dataDisks":["legacy-data"],"dataDiskLUNs":{"legacy-data":0}}`,
+				},
+			},
+			Spec: infrav1.AzureMachineSpec{
+				DataDisks: []infrav1.DataDisk{
+					{
+						NameSuffix:   "data",
+						Lun:          to.Int32Ptr(0),
+						DeletePolicy: infrav1.DiskDeletePolicyRetain,
+					},
+				},
+			},
+		},
+		Machine: &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "machine",
+			},
+		},
+	}
+
+	// The OS disk is deleted, the retained data disk is released from the cluster instead.
+	gomock.InOrder(
+		reconcilerMock.EXPECT().DeleteResource(gomockinternal.AContext(), &disks.DiskSpec{
+			Name:          "legacy-node-1-os",
+			ResourceGroup: "my-rg",
+		}, "disks").Return(nil),
+		reconcilerMock.EXPECT().CreateOrUpdateResource(gomockinternal.AContext(), gomock.Any(), "disks").
+			DoAndReturn(func(_ context.Context, spec azure.ResourceSpecGetter, _ string) (interface{}, error) {
+				g.Expect(spec).NotTo(BeAssignableToTypeOf(&disks.DiskSpec{}))
+				g.Expect(spec.ResourceName()).To(Equal("legacy-data"))
+				return nil, nil
+			}),
+	)
+
+	s := &disks.Service{
+		Scope:      machineScope,
+		Reconciler: reconcilerMock,
+	}
+	g.Expect(s.Delete(context.TODO())).To(Succeed())
+}
+
 func TestDiskSpecs(t *testing.T) {
 	testcases := []struct {
 		name         string
 		machineScope MachineScope
 		want         []azure.ResourceSpecGetter
 	}{
+		{
+			name: "disks of an adopted VM",
+			machineScope: MachineScope{
+				ClusterScoper: &ClusterScope{
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "cluster",
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "cluster",
+						},
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+						},
+					},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-azure-machine",
+						Annotations: map[string]string{
+							infrav1.AdoptAnnotation:            "legacy-node-1",
+							azure.VMAdoptedResourcesAnnotation: `{"networkInterfaces":["legacy-node-1-nic"],"osDisk":"legacy-node-1-os","dataDisks":["legacy-node-1-data"]}`,
+						},
+					},
+					Spec: infrav1.AzureMachineSpec{
+						OSDisk: infrav1.OSDisk{
+							DiskSizeGB: to.Int32Ptr(30),
+							OSType:     "Linux",
+						},
+						DataDisks: []infrav1.DataDisk{
+							{
+								NameSuffix: "etcddisk",
+							},
+						},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine",
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&disks.DiskSpec{
+					Name:          "legacy-node-1-os",
+					ResourceGroup: "my-rg",
+				},
+				&disks.DiskSpec{
+					Name:          "legacy-node-1-data",
+					ResourceGroup: "my-rg",
+					ClusterName:   "cluster",
+				},
+			},
+		},
+		{
+			name: "adopted VM data disks matched by name or LUN",
+			machineScope: MachineScope{
+				ClusterScoper: &ClusterScope{
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "cluster",
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "cluster",
+						},
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+						},
+					},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-azure-machine",
+						Annotations: map[string]string{
+							infrav1.AdoptAnnotation:            "legacy-node-1",
+							azure.VMAdoptedResourcesAnnotation: `{"osDisk":"legacy-node-1-os","dataDisks":["legacy-node-1_etcddisk","legacy-data","other-data"],"dataDiskLUNs":{"legacy-node-1_etcddisk":0,"legacy-data":1,"other-data":2}}`,
+						},
+					},
+					Spec: infrav1.AzureMachineSpec{
+						OSDisk: infrav1.OSDisk{
+							DiskSizeGB: to.Int32Ptr(30),
+							OSType:     "Linux",
+						},
+						DataDisks: []infrav1.DataDisk{
+							{
+								NameSuffix:   "etcddisk",
+								Lun:          to.Int32Ptr(3),
+								DeletePolicy: infrav1.DiskDeletePolicyDetach,
+							},
+							{
+								NameSuffix:   "data",
+								Lun:          to.Int32Ptr(1),
+								DeletePolicy: infrav1.DiskDeletePolicyRetain,
+							},
+						},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine",
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&disks.DiskSpec{
+					Name:          "legacy-node-1-os",
+					ResourceGroup: "my-rg",
+				},
+				&disks.DiskSpec{
+					Name:          "legacy-node-1_etcddisk",
+					ResourceGroup: "my-rg",
+					ClusterName:   "cluster",
+					DeletePolicy:  infrav1.DiskDeletePolicyDetach,
+				},
+				&disks.DiskSpec{
+					Name:          "legacy-data",
+					ResourceGroup: "my-rg",
+					ClusterName:   "cluster",
+					DeletePolicy:  infrav1.DiskDeletePolicyRetain,
+				},
+				&disks.DiskSpec{
+					Name:          "other-data",
+					ResourceGroup: "my-rg",
+					ClusterName:   "cluster",
+				},
+			},
+		},
 		{
 			name: "only os disk",
 			machineScope: MachineScope{
@@ -2451,6 +2910,7 @@ func TestDiskSpecs(t *testing.T) {
 				&disks.DiskSpec{
 					Name:          "my-azure-machine_etcddisk",
 					ResourceGroup: "my-rg",
+					ClusterName:   "cluster",
 				},
 			},
 		}, {
@@ -2485,7 +2945,8 @@ func TestDiskSpecs(t *testing.T) {
 								NameSuffix: "etcddisk",
 							},
 							{
-								NameSuffix: "otherdisk",
+								NameSuffix:   "otherdisk",
+								DeletePolicy: infrav1.DiskDeletePolicyRetain,
 							},
 						},
 					},
@@ -2504,10 +2965,13 @@ func TestDiskSpecs(t *testing.T) {
 				&disks.DiskSpec{
 					Name:          "my-azure-machine_etcddisk",
 					ResourceGroup: "my-rg",
+					ClusterName:   "cluster",
 				},
 				&disks.DiskSpec{
 					Name:          "my-azure-machine_otherdisk",
 					ResourceGroup: "my-rg",
+					ClusterName:   "cluster",
+					DeletePolicy:  infrav1.DiskDeletePolicyRetain,
 				},
 			},
 		},
@@ -2524,3 +2988,122 @@ func TestDiskSpecs(t *testing.T) {
 		})
 	}
 }
+
+func TestDataDiskSpecs(t *testing.T) {
+	testcases := []struct {
+		name         string
+		machineScope MachineScope
+		want         []azure.ResourceSpecGetter
+	}{
+		{
+			name: "data disks with and without provisioned performance",
+			machineScope: MachineScope{
+				ClusterScoper: &ClusterScope{
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "cluster",
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "cluster",
+						},
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+							AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+								Location: "westus3",
+							},
+						},
+					},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-azure-machine",
+					},
+					Spec: infrav1.AzureMachineSpec{
+						OSDisk: infrav1.OSDisk{
+							DiskSizeGB: to.Int32Ptr(30),
+							OSType:     "Linux",
+						},
+						DataDisks: []infrav1.DataDisk{
+							{
+								NameSuffix: "otherdisk",
+								DiskSizeGB: 64,
+							},
+							{
+								NameSuffix: "etcddisk",
+								DiskSizeGB: 256,
+								ManagedDisk: &infrav1.ManagedDiskParameters{
+									StorageAccountType: "UltraSSD_LRS",
+									DiskEncryptionSet: &infrav1.DiskEncryptionSetParameters{
+										ID: "my-des-id",
+									},
+								},
+								DiskIOPSReadWrite: to.Int64Ptr(10000),
+								DiskMBpsReadWrite: to.Int64Ptr(400),
+								LogicalSectorSize: to.Int32Ptr(4096),
+							},
+						},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine",
+					},
+					Spec: clusterv1.MachineSpec{
+						FailureDomain: to.StringPtr("1"),
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&disks.DiskSpec{
+					Name:           "my-azure-machine_otherdisk",
+					ResourceGroup:  "my-rg",
+					Location:       "westus3",
+					Zone:           "1",
+					ClusterName:    "cluster",
+					AdditionalTags: infrav1.Tags{"kubernetes.io_cluster_cluster": "owned"},
+					SizeGB:         64,
+					CreateWithVM:   true,
+				},
+				&disks.DiskSpec{
+					Name:                "my-azure-machine_etcddisk",
+					ResourceGroup:       "my-rg",
+					Location:            "westus3",
+					Zone:                "1",
+					ClusterName:         "cluster",
+					AdditionalTags:      infrav1.Tags{"kubernetes.io_cluster_cluster": "owned"},
+					SizeGB:              256,
+					StorageAccountType:  "UltraSSD_LRS",
+					DiskEncryptionSetID: "my-des-id",
+					DiskIOPSReadWrite:   to.Int64Ptr(10000),
+					DiskMBpsReadWrite:   to.Int64Ptr(400),
+					LogicalSectorSize:   to.Int32Ptr(4096),
+				},
+			},
+		},
+		{
+			name: "no data disks",
+			machineScope: MachineScope{
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-azure-machine",
+					},
+					Spec: infrav1.AzureMachineSpec{},
+				},
+			},
+			want: nil,
+		},
+	}
+
+	for _, tt := range testcases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			result := tt.machineScope.DataDiskSpecs()
+			g.Expect(result).To(BeEquivalentTo(tt.want))
+		})
+	}
+}
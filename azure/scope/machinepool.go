@@ -107,15 +107,17 @@ func NewMachinePoolScope(params MachinePoolScopeParams) (*MachinePoolScope, erro
 }
 
 // ScaleSetSpec returns the scale set spec.
-func (m *MachinePoolScope) ScaleSetSpec() azure.ScaleSetSpec {
-	return azure.ScaleSetSpec{
+func (m *MachinePoolScope) ScaleSetSpec() azure.ResourceSpecGetter {
+	return &scalesets.ScaleSetSpec{
 		Name:                         m.Name(),
+		ResourceGroup:                m.ResourceGroup(),
 		Size:                         m.AzureMachinePool.Spec.Template.VMSize,
 		Capacity:                     int64(to.Int32(m.MachinePool.Spec.Replicas)),
 		SSHKeyData:                   m.AzureMachinePool.Spec.Template.SSHPublicKey,
 		OSDisk:                       m.AzureMachinePool.Spec.Template.OSDisk,
 		DataDisks:                    m.AzureMachinePool.Spec.Template.DataDisks,
 		SubnetName:                   m.AzureMachinePool.Spec.Template.SubnetName,
+		VNetSubscriptionID:           m.VnetSubscriptionID(),
 		VNetName:                     m.Vnet().Name,
 		VNetResourceGroup:            m.Vnet().ResourceGroup,
 		PublicLBName:                 m.OutboundLBName(infrav1.Node),
@@ -127,6 +129,11 @@ func (m *MachinePoolScope) ScaleSetSpec() azure.ScaleSetSpec {
 		SpotVMOptions:                m.AzureMachinePool.Spec.Template.SpotVMOptions,
 		FailureDomains:               m.MachinePool.Spec.FailureDomains,
 		TerminateNotificationTimeout: m.AzureMachinePool.Spec.Template.TerminateNotificationTimeout,
+		Location:                     m.Location(),
+		SubscriptionID:               m.SubscriptionID(),
+		ClusterName:                  m.ClusterName(),
+		AdditionalTags:               m.AdditionalTags(),
+		VMSSExtensionSpecs:           m.VMSSExtensionSpecs(),
 	}
 }
 
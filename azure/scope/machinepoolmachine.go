@@ -31,6 +31,7 @@ import (
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesetvms"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
@@ -163,6 +164,15 @@ func (s *MachinePoolMachineScope) ScaleSetName() string {
 	return s.MachinePoolScope.Name()
 }
 
+// ScaleSetVMSpec returns the VMSS VM spec.
+func (s *MachinePoolMachineScope) ScaleSetVMSpec() azure.ResourceSpecGetter {
+	return &scalesetvms.ScaleSetVMSpec{
+		InstanceID:    s.InstanceID(),
+		ScaleSetName:  s.ScaleSetName(),
+		ResourceGroup: s.ResourceGroup(),
+	}
+}
+
 // SetLongRunningOperationState will set the future on the AzureMachinePoolMachine status to allow the resource to continue
 // in the next reconciliation.
 func (s *MachinePoolMachineScope) SetLongRunningOperationState(future *infrav1.Future) {
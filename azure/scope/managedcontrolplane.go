@@ -19,6 +19,8 @@ package scope
 import (
 	"context"
 	"encoding/json"
+	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/Azure/go-autorest/autorest"
@@ -30,10 +32,14 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/maintenanceconfigurations"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/managedclusters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routetables"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/ratelimit"
 	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
 	"sigs.k8s.io/cluster-api-provider-azure/util/maps"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
@@ -101,6 +107,7 @@ func NewManagedControlPlaneScope(ctx context.Context, params ManagedControlPlane
 		ManagedMachinePools: params.ManagedMachinePools,
 		patchHelper:         helper,
 		cache:               params.Cache,
+		dryRunPlan:          &dryRunPlan{},
 	}, nil
 }
 
@@ -115,6 +122,15 @@ type ManagedControlPlaneScope struct {
 	Cluster             *clusterv1.Cluster
 	ControlPlane        *infrav1exp.AzureManagedControlPlane
 	ManagedMachinePools []ManagedMachinePool
+	*dryRunPlan
+
+	adoptedMachinePools []adoptedMachinePool
+}
+
+// adoptedMachinePool is an AzureManagedMachinePool updated with the properties of an adopted agent pool.
+type adoptedMachinePool struct {
+	before           *infrav1exp.AzureManagedMachinePool
+	infraMachinePool *infrav1exp.AzureManagedMachinePool
 }
 
 // ManagedControlPlaneCache stores ManagedControlPlane data locally so we don't have to hit the API multiple times within the same reconcile loop.
@@ -175,9 +191,17 @@ func (s *ManagedControlPlaneScope) BaseURI() string {
 	return s.AzureClients.ResourceManagerEndpoint
 }
 
-// Authorizer returns the Azure client Authorizer.
+// Authorizer returns the Azure client Authorizer, rate limited with the other clients using the same credentials.
 func (s *ManagedControlPlaneScope) Authorizer() autorest.Authorizer {
-	return s.AzureClients.Authorizer
+	return ratelimit.NewAuthorizer(s.AzureClients.Authorizer, s.HashKey())
+}
+
+// AuthorizerFor returns an Authorizer for the given subscription, using the AzureManagedControlPlane's identity.
+func (s *ManagedControlPlaneScope) AuthorizerFor(subscriptionID string) azure.Authorizer {
+	if subscriptionID == "" || subscriptionID == s.SubscriptionID() {
+		return s
+	}
+	return &subscriptionAuthorizer{s.AzureClients.forSubscription(subscriptionID)}
 }
 
 // PatchObject persists the cluster configuration and status.
@@ -187,6 +211,14 @@ func (s *ManagedControlPlaneScope) PatchObject(ctx context.Context) error {
 
 	conditions.SetSummary(s.ControlPlane)
 
+	// The AzureManagedMachinePools of an adopted AKS cluster are updated with the properties of its agent pools.
+	for _, pool := range s.adoptedMachinePools {
+		if err := s.Client.Patch(ctx, pool.infraMachinePool, client.MergeFrom(pool.before)); err != nil {
+			return errors.Wrapf(err, "failed to patch AzureManagedMachinePool %s", pool.infraMachinePool.Name)
+		}
+	}
+	s.adoptedMachinePools = nil
+
 	return s.patchHelper.Patch(
 		ctx,
 		s.ControlPlane,
@@ -194,9 +226,14 @@ func (s *ManagedControlPlaneScope) PatchObject(ctx context.Context) error {
 			clusterv1.ReadyCondition,
 			infrav1.ResourceGroupReadyCondition,
 			infrav1.VNetReadyCondition,
+			infrav1.RouteTablesReadyCondition,
 			infrav1.SubnetsReadyCondition,
 			infrav1.ManagedClusterRunningCondition,
+			infrav1.ManagedClusterAdoptedCondition,
 			infrav1.AgentPoolsReadyCondition,
+			infrav1.MaintenanceConfigurationsReadyCondition,
+			infrav1.NodePoolsUpgradedCondition,
+			infrav1.ChangesAppliedCondition,
 		}})
 }
 
@@ -258,19 +295,50 @@ func (s *ManagedControlPlaneScope) NodeNatGateway() infrav1.NatGateway {
 
 // SubnetSpecs returns the subnets specs.
 func (s *ManagedControlPlaneScope) SubnetSpecs() []azure.ResourceSpecGetter {
-	return []azure.ResourceSpecGetter{
-		&subnets.SubnetSpec{
-			Name:              s.NodeSubnet().Name,
+	var specs []azure.ResourceSpecGetter
+	for _, subnet := range s.managedSubnets() {
+		spec := &subnets.SubnetSpec{
+			Name:              subnet.Name,
 			ResourceGroup:     s.ResourceGroup(),
 			SubscriptionID:    s.SubscriptionID(),
-			CIDRs:             s.NodeSubnet().CIDRBlocks,
+			CIDRs:             []string{subnet.CIDRBlock},
 			VNetName:          s.Vnet().Name,
 			VNetResourceGroup: s.Vnet().ResourceGroup,
 			IsVNetManaged:     s.IsVnetManaged(),
 			Role:              infrav1.SubnetNode,
-			ServiceEndpoints:  s.NodeSubnet().ServiceEndpoints,
-		},
+			ServiceEndpoints:  subnet.ServiceEndpoints,
+		}
+		if subnet.RouteTable != nil {
+			spec.RouteTableName = subnet.RouteTable.Name
+		}
+		specs = append(specs, spec)
 	}
+	return specs
+}
+
+// RouteTableSpecs returns the route tables of the subnets.
+func (s *ManagedControlPlaneScope) RouteTableSpecs() []azure.ResourceSpecGetter {
+	var specs []azure.ResourceSpecGetter
+	seen := make(map[string]bool)
+	for _, subnet := range s.managedSubnets() {
+		if subnet.RouteTable == nil || subnet.RouteTable.Name == "" || seen[subnet.RouteTable.Name] {
+			continue
+		}
+		seen[subnet.RouteTable.Name] = true
+		specs = append(specs, &routetables.RouteTableSpec{
+			Name:           subnet.RouteTable.Name,
+			Location:       s.Location(),
+			ResourceGroup:  s.ResourceGroup(),
+			ClusterName:    s.ClusterName(),
+			AdditionalTags: s.AdditionalTags(),
+		})
+	}
+	return specs
+}
+
+// managedSubnets returns the default node subnet followed by the additional subnets of the virtual network.
+func (s *ManagedControlPlaneScope) managedSubnets() []infrav1exp.ManagedControlPlaneSubnet {
+	return append([]infrav1exp.ManagedControlPlaneSubnet{s.ControlPlane.Spec.VirtualNetwork.Subnet}, s.ControlPlane.Spec.VirtualNetwork.Subnets...)
 }
 
 // Subnets returns the subnets specs.
@@ -280,13 +348,7 @@ func (s *ManagedControlPlaneScope) Subnets() infrav1.Subnets {
 
 // NodeSubnet returns the cluster node subnet.
 func (s *ManagedControlPlaneScope) NodeSubnet() infrav1.SubnetSpec {
-	return infrav1.SubnetSpec{
-		SubnetClassSpec: infrav1.SubnetClassSpec{
-			CIDRBlocks:       []string{s.ControlPlane.Spec.VirtualNetwork.Subnet.CIDRBlock},
-			Name:             s.ControlPlane.Spec.VirtualNetwork.Subnet.Name,
-			ServiceEndpoints: s.ControlPlane.Spec.VirtualNetwork.Subnet.ServiceEndpoints,
-		},
-	}
+	return toSubnetSpec(s.ControlPlane.Spec.VirtualNetwork.Subnet)
 }
 
 // SetSubnet sets the passed subnet spec into the scope.
@@ -314,27 +376,36 @@ func (s *ManagedControlPlaneScope) ControlPlaneSubnet() infrav1.SubnetSpec {
 
 // NodeSubnets returns the subnets with the node role.
 func (s *ManagedControlPlaneScope) NodeSubnets() []infrav1.SubnetSpec {
-	return []infrav1.SubnetSpec{
-		{
-			SubnetClassSpec: infrav1.SubnetClassSpec{
-				CIDRBlocks:       []string{s.ControlPlane.Spec.VirtualNetwork.Subnet.CIDRBlock},
-				Name:             s.ControlPlane.Spec.VirtualNetwork.Subnet.Name,
-				ServiceEndpoints: s.ControlPlane.Spec.VirtualNetwork.Subnet.ServiceEndpoints,
-			},
-		},
+	var nodeSubnets []infrav1.SubnetSpec
+	for _, subnet := range s.managedSubnets() {
+		nodeSubnets = append(nodeSubnets, toSubnetSpec(subnet))
 	}
+	return nodeSubnets
 }
 
 // Subnet returns the subnet with the provided name.
 func (s *ManagedControlPlaneScope) Subnet(name string) infrav1.SubnetSpec {
-	subnet := infrav1.SubnetSpec{}
-	if name == s.ControlPlane.Spec.VirtualNetwork.Subnet.Name {
-		subnet.Name = s.ControlPlane.Spec.VirtualNetwork.Subnet.Name
-		subnet.CIDRBlocks = []string{s.ControlPlane.Spec.VirtualNetwork.Subnet.CIDRBlock}
-		subnet.ServiceEndpoints = s.ControlPlane.Spec.VirtualNetwork.Subnet.ServiceEndpoints
+	for _, subnet := range s.managedSubnets() {
+		if subnet.Name == name {
+			return toSubnetSpec(subnet)
+		}
 	}
+	return infrav1.SubnetSpec{}
+}
 
-	return subnet
+// toSubnetSpec converts a managed control plane subnet to a subnet spec.
+func toSubnetSpec(subnet infrav1exp.ManagedControlPlaneSubnet) infrav1.SubnetSpec {
+	subnetSpec := infrav1.SubnetSpec{
+		SubnetClassSpec: infrav1.SubnetClassSpec{
+			CIDRBlocks:       []string{subnet.CIDRBlock},
+			Name:             subnet.Name,
+			ServiceEndpoints: subnet.ServiceEndpoints,
+		},
+	}
+	if subnet.RouteTable != nil {
+		subnetSpec.RouteTable = *subnet.RouteTable
+	}
+	return subnetSpec
 }
 
 // IsIPv6Enabled returns true if a cluster is ipv6 enabled.
@@ -343,6 +414,11 @@ func (s *ManagedControlPlaneScope) IsIPv6Enabled() bool {
 	return false
 }
 
+// VnetSubscriptionID returns the ID of the subscription of the cluster Vnet.
+func (s *ManagedControlPlaneScope) VnetSubscriptionID() string {
+	return s.SubscriptionID()
+}
+
 // IsVnetManaged returns true if the vnet is managed.
 func (s *ManagedControlPlaneScope) IsVnetManaged() bool {
 	if s.cache.isVnetManaged != nil {
@@ -404,6 +480,12 @@ func (s *ManagedControlPlaneScope) CloudProviderConfigOverrides() *infrav1.Cloud
 	return nil
 }
 
+// DriftPolicy returns what happens to the cluster's Azure resources modified outside of CAPZ.
+// Managed clusters always remediate the changes, as their resources do not detect drift.
+func (s *ManagedControlPlaneScope) DriftPolicy() infrav1.DriftPolicy {
+	return infrav1.DriftPolicyRemediate
+}
+
 // FailureDomains returns the failure domains for the cluster.
 func (s *ManagedControlPlaneScope) FailureDomains() []string {
 	return []string{}
@@ -419,6 +501,7 @@ func (s *ManagedControlPlaneScope) ManagedClusterSpec(ctx context.Context) azure
 	managedClusterSpec := managedclusters.ManagedClusterSpec{
 		Name:              s.ControlPlane.Name,
 		ResourceGroup:     s.ControlPlane.Spec.ResourceGroupName,
+		ClusterName:       s.ClusterName(),
 		NodeResourceGroup: s.ControlPlane.Spec.NodeResourceGroupName,
 		Location:          s.ControlPlane.Spec.Location,
 		Tags:              s.ControlPlane.Spec.AdditionalTags,
@@ -497,9 +580,91 @@ func (s *ManagedControlPlaneScope) ManagedClusterSpec(ctx context.Context) azure
 		}
 	}
 
+	if s.ControlPlane.Spec.AutoScalerProfile != nil {
+		managedClusterSpec.AutoScalerProfile = &managedclusters.AutoScalerProfile{
+			BalanceSimilarNodeGroups:      s.ControlPlane.Spec.AutoScalerProfile.BalanceSimilarNodeGroups,
+			Expander:                      (*string)(s.ControlPlane.Spec.AutoScalerProfile.Expander),
+			MaxEmptyBulkDelete:            s.ControlPlane.Spec.AutoScalerProfile.MaxEmptyBulkDelete,
+			MaxGracefulTerminationSec:     s.ControlPlane.Spec.AutoScalerProfile.MaxGracefulTerminationSec,
+			MaxNodeProvisionTime:          s.ControlPlane.Spec.AutoScalerProfile.MaxNodeProvisionTime,
+			MaxTotalUnreadyPercentage:     s.ControlPlane.Spec.AutoScalerProfile.MaxTotalUnreadyPercentage,
+			NewPodScaleUpDelay:            s.ControlPlane.Spec.AutoScalerProfile.NewPodScaleUpDelay,
+			OkTotalUnreadyCount:           s.ControlPlane.Spec.AutoScalerProfile.OkTotalUnreadyCount,
+			ScanInterval:                  s.ControlPlane.Spec.AutoScalerProfile.ScanInterval,
+			ScaleDownDelayAfterAdd:        s.ControlPlane.Spec.AutoScalerProfile.ScaleDownDelayAfterAdd,
+			ScaleDownDelayAfterDelete:     s.ControlPlane.Spec.AutoScalerProfile.ScaleDownDelayAfterDelete,
+			ScaleDownDelayAfterFailure:    s.ControlPlane.Spec.AutoScalerProfile.ScaleDownDelayAfterFailure,
+			ScaleDownUnneededTime:         s.ControlPlane.Spec.AutoScalerProfile.ScaleDownUnneededTime,
+			ScaleDownUnreadyTime:          s.ControlPlane.Spec.AutoScalerProfile.ScaleDownUnreadyTime,
+			ScaleDownUtilizationThreshold: s.ControlPlane.Spec.AutoScalerProfile.ScaleDownUtilizationThreshold,
+			SkipNodesWithLocalStorage:     s.ControlPlane.Spec.AutoScalerProfile.SkipNodesWithLocalStorage,
+			SkipNodesWithSystemPods:       s.ControlPlane.Spec.AutoScalerProfile.SkipNodesWithSystemPods,
+		}
+	}
+
+	if s.ControlPlane.Spec.AutoUpgradeProfile != nil && s.ControlPlane.Spec.AutoUpgradeProfile.UpgradeChannel != nil {
+		managedClusterSpec.AutoUpgradeProfile = &managedclusters.AutoUpgradeProfile{
+			UpgradeChannel: string(*s.ControlPlane.Spec.AutoUpgradeProfile.UpgradeChannel),
+		}
+	}
+
+	if s.ControlPlane.Spec.Identity != nil {
+		managedClusterSpec.Identity = &managedclusters.Identity{
+			Type:                           string(s.ControlPlane.Spec.Identity.Type),
+			UserAssignedIdentityResourceID: s.ControlPlane.Spec.Identity.UserAssignedIdentityResourceID,
+		}
+	}
+
+	managedClusterSpec.KubeletUserAssignedIdentity = s.ControlPlane.Spec.KubeletUserAssignedIdentity
+
+	if s.ControlPlane.Spec.OIDCIssuerProfile != nil {
+		managedClusterSpec.OIDCIssuerProfile = &managedclusters.OIDCIssuerProfile{
+			Enabled: s.ControlPlane.Spec.OIDCIssuerProfile.Enabled,
+		}
+	}
+
+	if s.ControlPlane.Spec.SecurityProfile != nil && s.ControlPlane.Spec.SecurityProfile.WorkloadIdentity != nil {
+		managedClusterSpec.SecurityProfile = &managedclusters.SecurityProfile{
+			WorkloadIdentity: &managedclusters.WorkloadIdentity{
+				Enabled: s.ControlPlane.Spec.SecurityProfile.WorkloadIdentity.Enabled,
+			},
+		}
+	}
+
 	return &managedClusterSpec
 }
 
+// ManagedClusterName returns the name of the AKS cluster.
+func (s *ManagedControlPlaneScope) ManagedClusterName() string {
+	return s.ControlPlane.Name
+}
+
+// MaintenanceConfigurationSpecs returns the planned maintenance configuration specs of the AKS cluster.
+func (s *ManagedControlPlaneScope) MaintenanceConfigurationSpecs() []azure.ResourceSpecGetter {
+	specs := make([]azure.ResourceSpecGetter, 0, len(s.ControlPlane.Spec.MaintenanceConfigurations))
+	for _, config := range s.ControlPlane.Spec.MaintenanceConfigurations {
+		spec := &maintenanceconfigurations.MaintenanceConfigurationSpec{
+			Name:          config.Name,
+			ResourceGroup: s.ResourceGroup(),
+			ClusterName:   s.ManagedClusterName(),
+		}
+		for _, timeInWeek := range config.TimeInWeek {
+			spec.TimeInWeek = append(spec.TimeInWeek, maintenanceconfigurations.TimeInWeek{
+				Day:       string(timeInWeek.Day),
+				HourSlots: timeInWeek.HourSlots,
+			})
+		}
+		for _, timeSpan := range config.NotAllowedTime {
+			spec.NotAllowedTime = append(spec.NotAllowedTime, maintenanceconfigurations.TimeSpan{
+				Start: timeSpan.Start.Time,
+				End:   timeSpan.End.Time,
+			})
+		}
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
 // GetAllAgentPoolSpecs gets a slice of azure.AgentPoolSpec for the list of agent pools.
 func (s *ManagedControlPlaneScope) GetAllAgentPoolSpecs() ([]azure.ResourceSpecGetter, error) {
 	var (
@@ -530,11 +695,101 @@ func (s *ManagedControlPlaneScope) GetAllAgentPoolSpecs() ([]azure.ResourceSpecG
 	return ammps, nil
 }
 
+// SetControlPlaneVersion sets the Kubernetes version the AKS control plane runs.
+func (s *ManagedControlPlaneScope) SetControlPlaneVersion(version string) {
+	s.ControlPlane.Status.Version = normalizeVersion(version)
+}
+
+// UpdateNodePoolUpgrades allows the next node pool to upgrade to the version of its MachinePool once the control
+// plane runs that version, and reports the progress of the upgrade of the node pools.
+func (s *ManagedControlPlaneScope) UpdateNodePoolUpgrades() {
+	var pending []ManagedMachinePool
+	for _, pool := range s.ManagedMachinePools {
+		if nodePoolNeedsUpgrade(pool) {
+			pending = append(pending, pool)
+		}
+	}
+
+	if len(pending) == 0 {
+		s.ControlPlane.Status.UpgradingNodePools = nil
+		conditions.MarkTrue(s.ControlPlane, infrav1.NodePoolsUpgradedCondition)
+		return
+	}
+
+	var order []string
+	if s.ControlPlane.Spec.NodePoolUpgradeSettings != nil {
+		order = s.ControlPlane.Spec.NodePoolUpgradeSettings.Order
+	}
+	sortNodePoolsForUpgrade(pending, order)
+
+	// Node pools are upgraded one at a time, and only to a version the control plane already runs.
+	next := pending[0]
+	version := normalizeVersion(*next.MachinePool.Spec.Template.Spec.Version)
+	if semver.Compare(version, s.ControlPlane.Status.Version) > 0 {
+		s.ControlPlane.Status.UpgradingNodePools = nil
+		conditions.MarkFalse(s.ControlPlane, infrav1.NodePoolsUpgradedCondition, infrav1.WaitingForControlPlaneUpgradeReason, clusterv1.ConditionSeverityInfo,
+			"waiting for the control plane to run %s", version)
+		return
+	}
+
+	s.ControlPlane.Status.UpgradingNodePools = []string{next.InfraMachinePool.Name}
+	conditions.MarkFalse(s.ControlPlane, infrav1.NodePoolsUpgradedCondition, infrav1.NodePoolUpgradingReason, clusterv1.ConditionSeverityInfo,
+		"upgrading node pool %s to %s, %d of %d node pools left to upgrade", next.InfraMachinePool.Name, version, len(pending), len(s.ManagedMachinePools))
+}
+
+// nodePoolNeedsUpgrade returns true if an existing node pool does not run the version of its MachinePool.
+func nodePoolNeedsUpgrade(pool ManagedMachinePool) bool {
+	if pool.MachinePool == nil || pool.MachinePool.Spec.Template.Spec.Version == nil ||
+		pool.InfraMachinePool == nil || pool.InfraMachinePool.Status.Version == "" {
+		return false
+	}
+	return semver.Compare(normalizeVersion(*pool.MachinePool.Spec.Template.Spec.Version), pool.InfraMachinePool.Status.Version) != 0
+}
+
+// sortNodePoolsForUpgrade sorts node pools in the order in which they are upgraded: the node pools listed in order
+// first, then System node pools, then the other node pools, by name.
+func sortNodePoolsForUpgrade(pools []ManagedMachinePool, order []string) {
+	rank := make(map[string]int, len(order))
+	for i, name := range order {
+		rank[name] = i
+	}
+	sort.SliceStable(pools, func(i, j int) bool {
+		a, b := pools[i].InfraMachinePool, pools[j].InfraMachinePool
+		rankA, listedA := rank[a.Name]
+		rankB, listedB := rank[b.Name]
+		switch {
+		case listedA && listedB:
+			return rankA < rankB
+		case listedA != listedB:
+			return listedA
+		}
+		systemA := a.Spec.Mode == string(infrav1exp.NodePoolModeSystem)
+		systemB := b.Spec.Mode == string(infrav1exp.NodePoolModeSystem)
+		if systemA != systemB {
+			return systemA
+		}
+		return a.Name < b.Name
+	})
+}
+
+// normalizeVersion returns a Kubernetes version with a "v" prefix.
+func normalizeVersion(version string) string {
+	if version == "" {
+		return ""
+	}
+	return "v" + strings.TrimPrefix(version, "v")
+}
+
 // SetControlPlaneEndpoint sets a control plane endpoint.
 func (s *ManagedControlPlaneScope) SetControlPlaneEndpoint(endpoint clusterv1.APIEndpoint) {
 	s.ControlPlane.Spec.ControlPlaneEndpoint = endpoint
 }
 
+// SetOIDCIssuerURL sets the URL of the OIDC issuer of the AKS cluster.
+func (s *ManagedControlPlaneScope) SetOIDCIssuerURL(url string) {
+	s.ControlPlane.Status.OIDCIssuerURL = url
+}
+
 // MakeEmptyKubeConfigSecret creates an empty secret object that is used for storing kubeconfig secret data.
 func (s *ManagedControlPlaneScope) MakeEmptyKubeConfigSecret() corev1.Secret {
 	return corev1.Secret{
@@ -581,6 +836,8 @@ func (s *ManagedControlPlaneScope) UpdateDeleteStatus(condition clusterv1.Condit
 		conditions.MarkFalse(s.ControlPlane, condition, infrav1.DeletedReason, clusterv1.ConditionSeverityInfo, "%s successfully deleted", service)
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(s.ControlPlane, condition, infrav1.DeletingReason, clusterv1.ConditionSeverityInfo, "%s deleting", service)
+	case azure.IsDryRunError(err):
+		conditions.MarkFalse(s.ControlPlane, condition, infrav1.DryRunReason, clusterv1.ConditionSeverityInfo, "%s was not deleted in dry-run mode", service)
 	default:
 		conditions.MarkFalse(s.ControlPlane, condition, infrav1.DeletionFailedReason, clusterv1.ConditionSeverityError, "%s failed to delete. err: %s", service, err.Error())
 	}
@@ -593,6 +850,8 @@ func (s *ManagedControlPlaneScope) UpdatePutStatus(condition clusterv1.Condition
 		conditions.MarkTrue(s.ControlPlane, condition)
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(s.ControlPlane, condition, infrav1.CreatingReason, clusterv1.ConditionSeverityInfo, "%s creating or updating", service)
+	case azure.IsDryRunError(err):
+		conditions.MarkFalse(s.ControlPlane, condition, infrav1.DryRunReason, clusterv1.ConditionSeverityInfo, "%s has changes that were not applied in dry-run mode", service)
 	default:
 		conditions.MarkFalse(s.ControlPlane, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to create or update. err: %s", service, err.Error())
 	}
@@ -610,6 +869,97 @@ func (s *ManagedControlPlaneScope) UpdatePatchStatus(condition clusterv1.Conditi
 	}
 }
 
+// UpdateAdoptionStatus updates the ManagedClusterAdopted condition on the AzureManagedControlPlane status after adopting
+// an existing AKS cluster.
+func (s *ManagedControlPlaneScope) UpdateAdoptionStatus(err error) {
+	if err != nil {
+		conditions.MarkFalse(s.ControlPlane, infrav1.ManagedClusterAdoptedCondition, infrav1.AdoptionFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return
+	}
+	conditions.MarkTrue(s.ControlPlane, infrav1.ManagedClusterAdoptedCondition)
+}
+
+// IsAdopting returns true if the AzureManagedControlPlane adopts an existing AKS cluster instead of creating it.
+func (s *ManagedControlPlaneScope) IsAdopting() bool {
+	return s.ControlPlane.GetAnnotations()[infrav1exp.AdoptAnnotation] == "true"
+}
+
+// SetAdoptedProperties sets the properties of an adopted AKS cluster that the AzureManagedControlPlane leaves unset.
+func (s *ManagedControlPlaneScope) SetAdoptedProperties(properties azure.AdoptedManagedClusterProperties) {
+	spec := &s.ControlPlane.Spec
+	if spec.SSHPublicKey == "" {
+		spec.SSHPublicKey = properties.SSHPublicKey
+	}
+	if spec.NetworkPlugin == nil && properties.NetworkPlugin != "" {
+		spec.NetworkPlugin = to.StringPtr(properties.NetworkPlugin)
+	}
+	if spec.NetworkPolicy == nil && properties.NetworkPolicy != "" {
+		spec.NetworkPolicy = to.StringPtr(properties.NetworkPolicy)
+	}
+	if spec.LoadBalancerSKU == nil && properties.LoadBalancerSKU != "" {
+		spec.LoadBalancerSKU = to.StringPtr(properties.LoadBalancerSKU)
+	}
+	if spec.DNSServiceIP == nil && properties.DNSServiceIP != "" {
+		spec.DNSServiceIP = to.StringPtr(properties.DNSServiceIP)
+	}
+	// The tags of the AKS cluster are kept, unless the AzureManagedControlPlane sets them to another value.
+	for key, value := range properties.Tags {
+		if key == infrav1.ClusterTagKey(s.ClusterName()) {
+			continue
+		}
+		if spec.AdditionalTags == nil {
+			spec.AdditionalTags = infrav1.Tags{}
+		}
+		if _, ok := spec.AdditionalTags[key]; !ok {
+			spec.AdditionalTags[key] = value
+		}
+	}
+
+	for _, pool := range s.ManagedMachinePools {
+		agentPool, ok := properties.AgentPools[to.String(pool.InfraMachinePool.Spec.Name)]
+		if !ok {
+			continue
+		}
+		before := pool.InfraMachinePool.DeepCopy()
+		setAdoptedAgentPoolProperties(pool.InfraMachinePool, agentPool)
+		if !reflect.DeepEqual(before.Spec, pool.InfraMachinePool.Spec) {
+			s.adoptedMachinePools = append(s.adoptedMachinePools, adoptedMachinePool{
+				before:           before,
+				infraMachinePool: pool.InfraMachinePool,
+			})
+		}
+	}
+}
+
+// setAdoptedAgentPoolProperties sets the properties of an adopted agent pool that the AzureManagedMachinePool leaves
+// unset.
+func setAdoptedAgentPoolProperties(infraMachinePool *infrav1exp.AzureManagedMachinePool, properties azure.AdoptedAgentPoolProperties) {
+	spec := &infraMachinePool.Spec
+	if spec.OSDiskSizeGB == nil && to.Int32(properties.OSDiskSizeGB) != 0 {
+		spec.OSDiskSizeGB = properties.OSDiskSizeGB
+	}
+	if spec.OsDiskType == nil && properties.OSDiskType != "" {
+		spec.OsDiskType = to.StringPtr(properties.OSDiskType)
+	}
+	if spec.MaxPods == nil && properties.MaxPods != nil {
+		spec.MaxPods = properties.MaxPods
+	}
+	if len(spec.NodeLabels) == 0 && len(properties.NodeLabels) > 0 {
+		spec.NodeLabels = properties.NodeLabels
+	}
+	if spec.Scaling == nil && properties.MinCount != nil && properties.MaxCount != nil {
+		spec.Scaling = &infrav1exp.ManagedMachinePoolScaling{
+			MinSize: properties.MinCount,
+			MaxSize: properties.MaxCount,
+		}
+	}
+}
+
+// IsDryRun returns true if the changes to the AzureManagedControlPlane's resources should be planned but not applied.
+func (s *ManagedControlPlaneScope) IsDryRun() bool {
+	return azure.IsDryRunEnabled(s.ControlPlane.GetAnnotations())
+}
+
 // AnnotationJSON returns a map[string]interface from a JSON annotation.
 func (s *ManagedControlPlaneScope) AnnotationJSON(annotation string) (map[string]interface{}, error) {
 	out := map[string]interface{}{}
@@ -646,12 +996,13 @@ func (s *ManagedControlPlaneScope) SetAnnotation(key, value string) {
 }
 
 // TagsSpecs returns the tag specs for the ManagedControlPlane.
-func (s *ManagedControlPlaneScope) TagsSpecs() []azure.TagsSpec {
-	return []azure.TagsSpec{
-		{
-			Scope:      azure.ResourceGroupID(s.SubscriptionID(), s.ResourceGroup()),
-			Tags:       s.AdditionalTags(),
-			Annotation: azure.RGTagsLastAppliedAnnotation,
+func (s *ManagedControlPlaneScope) TagsSpecs() []azure.ResourceSpecGetter {
+	return []azure.ResourceSpecGetter{
+		&tags.TagsSpec{
+			Scope:       azure.ResourceGroupID(s.SubscriptionID(), s.ResourceGroup()),
+			Tags:        s.AdditionalTags(),
+			ClusterName: s.ClusterName(),
+			Annotation:  azure.RGTagsLastAppliedAnnotation,
 		},
 	}
 }
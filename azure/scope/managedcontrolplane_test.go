@@ -19,18 +19,25 @@ package scope
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/to"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/agentpools"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/maintenanceconfigurations"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/managedclusters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routetables"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -178,6 +185,96 @@ func TestManagedControlPlaneScope_PoolVersion(t *testing.T) {
 	}
 }
 
+func TestManagedControlPlaneScope_UpdateNodePoolUpgrades(t *testing.T) {
+	newPool := func(name string, mode infrav1exp.NodePoolMode, version, currentVersion string) ManagedMachinePool {
+		infraMachinePool := getAzureMachinePool(name, mode)
+		infraMachinePool.Status.Version = currentVersion
+		return ManagedMachinePool{
+			MachinePool:      getMachinePoolWithVersion(name, version),
+			InfraMachinePool: infraMachinePool,
+		}
+	}
+
+	cases := []struct {
+		Name                string
+		ControlPlaneVersion string
+		Order               []string
+		Pools               []ManagedMachinePool
+		ExpectedUpgrading   []string
+		ExpectedReason      string
+	}{
+		{
+			Name:                "all node pools are up to date",
+			ControlPlaneVersion: "v1.24.3",
+			Pools: []ManagedMachinePool{
+				newPool("pool0", infrav1exp.NodePoolModeSystem, "v1.24.3", "v1.24.3"),
+				newPool("pool1", infrav1exp.NodePoolModeUser, "1.24.3", "v1.24.3"),
+				newPool("pool2", infrav1exp.NodePoolModeUser, "v1.24.3", ""),
+			},
+		},
+		{
+			Name:                "node pools wait for the control plane",
+			ControlPlaneVersion: "v1.23.5",
+			Pools: []ManagedMachinePool{
+				newPool("pool0", infrav1exp.NodePoolModeSystem, "v1.24.3", "v1.23.5"),
+			},
+			ExpectedReason: infrav1.WaitingForControlPlaneUpgradeReason,
+		},
+		{
+			Name:                "system node pools upgrade first",
+			ControlPlaneVersion: "v1.24.3",
+			Pools: []ManagedMachinePool{
+				newPool("pool0", infrav1exp.NodePoolModeUser, "v1.24.3", "v1.23.5"),
+				newPool("pool1", infrav1exp.NodePoolModeSystem, "v1.24.3", "v1.23.5"),
+				newPool("pool2", infrav1exp.NodePoolModeSystem, "v1.24.3", "v1.24.3"),
+			},
+			ExpectedUpgrading: []string{"pool1"},
+			ExpectedReason:    infrav1.NodePoolUpgradingReason,
+		},
+		{
+			Name:                "node pools upgrade in the configured order",
+			ControlPlaneVersion: "v1.24.3",
+			Order:               []string{"pool2", "pool0"},
+			Pools: []ManagedMachinePool{
+				newPool("pool0", infrav1exp.NodePoolModeUser, "v1.24.3", "v1.23.5"),
+				newPool("pool1", infrav1exp.NodePoolModeSystem, "v1.24.3", "v1.23.5"),
+				newPool("pool2", infrav1exp.NodePoolModeUser, "v1.24.3", "v1.23.5"),
+			},
+			ExpectedUpgrading: []string{"pool2"},
+			ExpectedReason:    infrav1.NodePoolUpgradingReason,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			g := NewWithT(t)
+			s := &ManagedControlPlaneScope{
+				ControlPlane: &infrav1exp.AzureManagedControlPlane{
+					Spec: infrav1exp.AzureManagedControlPlaneSpec{
+						NodePoolUpgradeSettings: &infrav1exp.ManagedControlPlaneNodePoolUpgradeSettings{
+							Order: c.Order,
+						},
+					},
+					Status: infrav1exp.AzureManagedControlPlaneStatus{
+						Version:            c.ControlPlaneVersion,
+						UpgradingNodePools: []string{"stale"},
+					},
+				},
+				ManagedMachinePools: c.Pools,
+			}
+
+			s.UpdateNodePoolUpgrades()
+			g.Expect(s.ControlPlane.Status.UpgradingNodePools).To(Equal(c.ExpectedUpgrading))
+			if c.ExpectedReason == "" {
+				g.Expect(conditions.IsTrue(s.ControlPlane, infrav1.NodePoolsUpgradedCondition)).To(BeTrue())
+			} else {
+				g.Expect(conditions.GetReason(s.ControlPlane, infrav1.NodePoolsUpgradedCondition)).To(Equal(c.ExpectedReason))
+			}
+		})
+	}
+}
+
 func TestManagedControlPlaneScope_AddonProfiles(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = expv1.AddToScheme(scheme)
@@ -271,6 +368,145 @@ func TestManagedControlPlaneScope_AddonProfiles(t *testing.T) {
 	}
 }
 
+func TestManagedControlPlaneScope_MaintenanceConfigurationSpecs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = expv1.AddToScheme(scheme)
+	_ = infrav1exp.AddToScheme(scheme)
+
+	christmas := metav1.Date(2022, time.December, 24, 0, 0, 0, 0, time.UTC)
+	boxingDay := metav1.Date(2022, time.December, 26, 0, 0, 0, 0, time.UTC)
+	input := ManagedControlPlaneScopeParams{
+		AzureClients: AzureClients{
+			Authorizer: autorest.NullAuthorizer{},
+		},
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster1",
+				Namespace: "default",
+			},
+		},
+		ControlPlane: &infrav1exp.AzureManagedControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster1-control-plane",
+				Namespace: "default",
+			},
+			Spec: infrav1exp.AzureManagedControlPlaneSpec{
+				SubscriptionID:    "00000000-0000-0000-0000-000000000000",
+				ResourceGroupName: "my-rg",
+				MaintenanceConfigurations: []infrav1exp.MaintenanceConfiguration{
+					{
+						Name:           "default",
+						TimeInWeek:     []infrav1exp.TimeInWeek{{Day: "Saturday", HourSlots: []int32{1, 2}}},
+						NotAllowedTime: []infrav1exp.TimeSpan{{Start: christmas, End: boxingDay}},
+					},
+				},
+			},
+		},
+		ManagedMachinePools: []ManagedMachinePool{
+			{
+				MachinePool:      getMachinePool("pool0"),
+				InfraMachinePool: getAzureMachinePool("pool0", infrav1exp.NodePoolModeSystem),
+			},
+		},
+	}
+
+	g := NewWithT(t)
+	input.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(input.ControlPlane).Build()
+	s, err := NewManagedControlPlaneScope(context.TODO(), input)
+	g.Expect(err).To(Succeed())
+	g.Expect(s.MaintenanceConfigurationSpecs()).To(Equal([]azure.ResourceSpecGetter{
+		&maintenanceconfigurations.MaintenanceConfigurationSpec{
+			Name:           "default",
+			ResourceGroup:  "my-rg",
+			ClusterName:    "cluster1-control-plane",
+			TimeInWeek:     []maintenanceconfigurations.TimeInWeek{{Day: "Saturday", HourSlots: []int32{1, 2}}},
+			NotAllowedTime: []maintenanceconfigurations.TimeSpan{{Start: christmas.Time, End: boxingDay.Time}},
+		},
+	}))
+}
+
+func TestManagedControlPlaneScope_SubnetSpecs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = expv1.AddToScheme(scheme)
+	_ = infrav1exp.AddToScheme(scheme)
+
+	routeTable := &infrav1.RouteTable{Name: "my-routetable"}
+	input := ManagedControlPlaneScopeParams{
+		AzureClients: AzureClients{
+			Authorizer: autorest.NullAuthorizer{},
+		},
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster1",
+				Namespace: "default",
+			},
+		},
+		ControlPlane: &infrav1exp.AzureManagedControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster1-control-plane",
+				Namespace: "default",
+			},
+			Spec: infrav1exp.AzureManagedControlPlaneSpec{
+				SubscriptionID:    "00000000-0000-0000-0000-000000000000",
+				ResourceGroupName: "my-rg",
+				Location:          "westus2",
+				VirtualNetwork: infrav1exp.ManagedControlPlaneVirtualNetwork{
+					Name:          "my-vnet",
+					CIDRBlock:     "10.0.0.0/8",
+					ResourceGroup: "my-vnet-rg",
+					Subnet:        infrav1exp.ManagedControlPlaneSubnet{Name: "nodes", CIDRBlock: "10.240.0.0/16", RouteTable: routeTable},
+					Subnets: []infrav1exp.ManagedControlPlaneSubnet{
+						{Name: "pool1-nodes", CIDRBlock: "10.241.0.0/16", RouteTable: routeTable},
+						{Name: "pool1-pods", CIDRBlock: "10.242.0.0/16"},
+					},
+				},
+			},
+		},
+		ManagedMachinePools: []ManagedMachinePool{
+			{
+				MachinePool:      getMachinePool("pool0"),
+				InfraMachinePool: getAzureMachinePool("pool0", infrav1exp.NodePoolModeSystem),
+			},
+		},
+	}
+
+	g := NewWithT(t)
+	input.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(input.ControlPlane).Build()
+	s, err := NewManagedControlPlaneScope(context.TODO(), input)
+	g.Expect(err).To(Succeed())
+	s.cache.isVnetManaged = to.BoolPtr(true)
+
+	subnetSpec := func(name, cidr, routeTableName string) *subnets.SubnetSpec {
+		return &subnets.SubnetSpec{
+			Name:              name,
+			ResourceGroup:     "my-rg",
+			SubscriptionID:    "00000000-0000-0000-0000-000000000000",
+			CIDRs:             []string{cidr},
+			VNetName:          "my-vnet",
+			VNetResourceGroup: "my-vnet-rg",
+			IsVNetManaged:     true,
+			RouteTableName:    routeTableName,
+			Role:              infrav1.SubnetNode,
+		}
+	}
+	g.Expect(s.SubnetSpecs()).To(Equal([]azure.ResourceSpecGetter{
+		subnetSpec("nodes", "10.240.0.0/16", "my-routetable"),
+		subnetSpec("pool1-nodes", "10.241.0.0/16", "my-routetable"),
+		subnetSpec("pool1-pods", "10.242.0.0/16", ""),
+	}))
+	g.Expect(s.RouteTableSpecs()).To(Equal([]azure.ResourceSpecGetter{
+		&routetables.RouteTableSpec{
+			Name:           "my-routetable",
+			ResourceGroup:  "my-rg",
+			Location:       "westus2",
+			ClusterName:    "cluster1",
+			AdditionalTags: infrav1.Tags{},
+		},
+	}))
+	g.Expect(s.Subnet("pool1-pods").CIDRBlocks).To(Equal([]string{"10.242.0.0/16"}))
+	g.Expect(s.NodeSubnets()).To(HaveLen(3))
+}
+
 func TestManagedControlPlaneScope_OSType(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = expv1.AddToScheme(scheme)
@@ -406,6 +642,118 @@ func TestManagedControlPlaneScope_OSType(t *testing.T) {
 	}
 }
 
+func TestManagedControlPlaneScope_SetAdoptedProperties(t *testing.T) {
+	g := NewWithT(t)
+	s := &ManagedControlPlaneScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "cluster1",
+			},
+		},
+		ControlPlane: &infrav1exp.AzureManagedControlPlane{
+			Spec: infrav1exp.AzureManagedControlPlaneSpec{
+				NetworkPolicy:  to.StringPtr("calico"),
+				AdditionalTags: infrav1.Tags{"team": "b"},
+			},
+		},
+	}
+
+	s.SetAdoptedProperties(azure.AdoptedManagedClusterProperties{
+		SSHPublicKey:    "c3NoLXJzYSBBQUFB",
+		NetworkPlugin:   "kubenet",
+		NetworkPolicy:   "azure",
+		LoadBalancerSKU: "Standard",
+		Tags: map[string]string{
+			"team": "a",
+			"env":  "prod",
+			"sigs.k8s.io_cluster-api-provider-azure_cluster_cluster1": "owned",
+		},
+	})
+	g.Expect(s.ControlPlane.Spec.SSHPublicKey).To(Equal("c3NoLXJzYSBBQUFB"))
+	g.Expect(s.ControlPlane.Spec.NetworkPlugin).To(Equal(to.StringPtr("kubenet")))
+	g.Expect(s.ControlPlane.Spec.NetworkPolicy).To(Equal(to.StringPtr("calico")))
+	g.Expect(s.ControlPlane.Spec.LoadBalancerSKU).To(Equal(to.StringPtr("Standard")))
+	g.Expect(s.ControlPlane.Spec.DNSServiceIP).To(BeNil())
+	g.Expect(s.ControlPlane.Spec.AdditionalTags).To(Equal(infrav1.Tags{"team": "b", "env": "prod"}))
+}
+
+func TestManagedControlPlaneScope_SetAdoptedAgentPoolProperties(t *testing.T) {
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	_ = expv1.AddToScheme(scheme)
+	_ = infrav1exp.AddToScheme(scheme)
+
+	controlPlane := &infrav1exp.AzureManagedControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster1",
+			Namespace: "default",
+		},
+		Spec: infrav1exp.AzureManagedControlPlaneSpec{
+			Version:        "v1.20.1",
+			SubscriptionID: "00000000-0000-0000-0000-000000000000",
+		},
+	}
+	pool0 := getAzureMachinePoolWithMaxPods("pool0", 50)
+	pool1 := getAzureMachinePool("pool1", infrav1exp.NodePoolModeUser)
+	pool2 := getAzureMachinePool("pool2", infrav1exp.NodePoolModeUser)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(controlPlane, pool0, pool1, pool2).Build()
+	s, err := NewManagedControlPlaneScope(context.TODO(), ManagedControlPlaneScopeParams{
+		AzureClients: AzureClients{
+			Authorizer: autorest.NullAuthorizer{},
+		},
+		Client: fakeClient,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster1",
+				Namespace: "default",
+			},
+		},
+		ControlPlane: controlPlane,
+		ManagedMachinePools: []ManagedMachinePool{
+			{MachinePool: getMachinePool("pool0"), InfraMachinePool: pool0},
+			{MachinePool: getMachinePool("pool1"), InfraMachinePool: pool1},
+			{MachinePool: getMachinePool("pool2"), InfraMachinePool: pool2},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s.SetAdoptedProperties(azure.AdoptedManagedClusterProperties{
+		AgentPools: map[string]azure.AdoptedAgentPoolProperties{
+			"pool0": {
+				MaxPods: to.Int32Ptr(30),
+			},
+			"pool1": {
+				OSDiskSizeGB: to.Int32Ptr(128),
+				OSDiskType:   "Ephemeral",
+				MaxPods:      to.Int32Ptr(30),
+				NodeLabels:   map[string]string{"workload": "batch"},
+				MinCount:     to.Int32Ptr(1),
+				MaxCount:     to.Int32Ptr(5),
+			},
+		},
+	})
+	g.Expect(s.PatchObject(context.TODO())).To(Succeed())
+
+	// The AzureManagedMachinePool keeps the properties it sets.
+	patched := &infrav1exp.AzureManagedMachinePool{}
+	g.Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(pool0), patched)).To(Succeed())
+	g.Expect(patched.Spec.MaxPods).To(Equal(to.Int32Ptr(50)))
+
+	g.Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(pool1), patched)).To(Succeed())
+	g.Expect(patched.Spec.OSDiskSizeGB).To(Equal(to.Int32Ptr(128)))
+	g.Expect(patched.Spec.OsDiskType).To(Equal(to.StringPtr("Ephemeral")))
+	g.Expect(patched.Spec.MaxPods).To(Equal(to.Int32Ptr(30)))
+	g.Expect(patched.Spec.NodeLabels).To(Equal(map[string]string{"workload": "batch"}))
+	g.Expect(patched.Spec.Scaling).To(Equal(&infrav1exp.ManagedMachinePoolScaling{
+		MinSize: to.Int32Ptr(1),
+		MaxSize: to.Int32Ptr(5),
+	}))
+
+	// The AzureManagedMachinePool of an agent pool created once the cluster is adopted is left as it is.
+	g.Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(pool2), patched)).To(Succeed())
+	g.Expect(patched.Spec).To(Equal(pool2.Spec))
+}
+
 func TestManagedControlPlaneScope_IsVnetManagedCache(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = expv1.AddToScheme(scheme)
@@ -19,10 +19,12 @@ package scope
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
+	"golang.org/x/mod/semver"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/agentpools"
@@ -107,6 +109,7 @@ func (s *ManagedMachinePoolScope) PatchObject(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scope.ManagedMachinePoolScope.PatchObject")
 	defer done()
 
+	s.setAgentPoolUpgradedCondition()
 	conditions.SetSummary(s.InfraMachinePool)
 
 	return s.patchHelper.Patch(
@@ -114,6 +117,7 @@ func (s *ManagedMachinePoolScope) PatchObject(ctx context.Context) error {
 		s.InfraMachinePool,
 		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
 			clusterv1.ReadyCondition,
+			infrav1.AgentPoolUpgradedCondition,
 		}})
 }
 
@@ -147,6 +151,10 @@ func buildAgentPoolSpec(managedControlPlane *infrav1exp.AzureManagedControlPlane
 	var normalizedVersion *string
 	if machinePool.Spec.Template.Spec.Version != nil {
 		v := strings.TrimPrefix(*machinePool.Spec.Template.Spec.Version, "v")
+		// An existing agent pool keeps its version until the AzureManagedControlPlane allows it to upgrade.
+		if current := managedMachinePool.Status.Version; current != "" && !isNodePoolUpgradeAllowed(managedControlPlane, managedMachinePool.Name) {
+			v = strings.TrimPrefix(current, "v")
+		}
 		normalizedVersion = &v
 	}
 
@@ -155,6 +163,11 @@ func buildAgentPoolSpec(managedControlPlane *infrav1exp.AzureManagedControlPlane
 		replicas = *machinePool.Spec.Replicas
 	}
 
+	nodeSubnetName := managedControlPlane.Spec.VirtualNetwork.Subnet.Name
+	if managedMachinePool.Spec.NodeSubnetName != nil {
+		nodeSubnetName = *managedMachinePool.Spec.NodeSubnetName
+	}
+
 	agentPoolSpec := &agentpools.AgentPoolSpec{
 		Name:          to.String(managedMachinePool.Spec.Name),
 		ResourceGroup: managedControlPlane.Spec.ResourceGroupName,
@@ -167,17 +180,30 @@ func buildAgentPoolSpec(managedControlPlane *infrav1exp.AzureManagedControlPlane
 			managedControlPlane.Spec.SubscriptionID,
 			managedControlPlane.Spec.VirtualNetwork.ResourceGroup,
 			managedControlPlane.Spec.VirtualNetwork.Name,
-			managedControlPlane.Spec.VirtualNetwork.Subnet.Name,
+			nodeSubnetName,
 		),
-		Mode:                 managedMachinePool.Spec.Mode,
-		MaxPods:              managedMachinePool.Spec.MaxPods,
-		AvailabilityZones:    managedMachinePool.Spec.AvailabilityZones,
-		OsDiskType:           managedMachinePool.Spec.OsDiskType,
-		EnableUltraSSD:       managedMachinePool.Spec.EnableUltraSSD,
-		Headers:              maps.FilterByKeyPrefix(agentPoolAnnotations, azure.CustomHeaderPrefix),
-		EnableNodePublicIP:   managedMachinePool.Spec.EnableNodePublicIP,
-		NodePublicIPPrefixID: managedMachinePool.Spec.NodePublicIPPrefixID,
-		ScaleSetPriority:     managedMachinePool.Spec.ScaleSetPriority,
+		Mode:                      managedMachinePool.Spec.Mode,
+		MaxPods:                   managedMachinePool.Spec.MaxPods,
+		AvailabilityZones:         managedMachinePool.Spec.AvailabilityZones,
+		OsDiskType:                managedMachinePool.Spec.OsDiskType,
+		EnableUltraSSD:            managedMachinePool.Spec.EnableUltraSSD,
+		Headers:                   maps.FilterByKeyPrefix(agentPoolAnnotations, azure.CustomHeaderPrefix),
+		EnableNodePublicIP:        managedMachinePool.Spec.EnableNodePublicIP,
+		NodePublicIPPrefixID:      managedMachinePool.Spec.NodePublicIPPrefixID,
+		ScaleSetPriority:          managedMachinePool.Spec.ScaleSetPriority,
+		OSSKU:                     managedMachinePool.Spec.OSSKU,
+		EnableEncryptionAtHost:    managedMachinePool.Spec.EnableEncryptionAtHost,
+		ProximityPlacementGroupID: managedMachinePool.Spec.ProximityPlacementGroupID,
+		EnableFIPS:                managedMachinePool.Spec.EnableFIPS,
+	}
+
+	if managedMachinePool.Spec.PodSubnetName != nil {
+		agentPoolSpec.PodSubnetID = azure.SubnetID(
+			managedControlPlane.Spec.SubscriptionID,
+			managedControlPlane.Spec.VirtualNetwork.ResourceGroup,
+			managedControlPlane.Spec.VirtualNetwork.Name,
+			*managedMachinePool.Spec.PodSubnetName,
+		)
 	}
 
 	if managedMachinePool.Spec.OSDiskSizeGB != nil {
@@ -198,6 +224,44 @@ func buildAgentPoolSpec(managedControlPlane *infrav1exp.AzureManagedControlPlane
 		agentPoolSpec.MinCount = managedMachinePool.Spec.Scaling.MinSize
 	}
 
+	if managedMachinePool.Spec.UpgradeSettings != nil && managedMachinePool.Spec.UpgradeSettings.MaxSurge != nil {
+		agentPoolSpec.MaxSurge = managedMachinePool.Spec.UpgradeSettings.MaxSurge
+	} else if managedControlPlane.Spec.NodePoolUpgradeSettings != nil {
+		agentPoolSpec.MaxSurge = managedControlPlane.Spec.NodePoolUpgradeSettings.MaxSurge
+	}
+
+	if managedMachinePool.Spec.SpotMaxPrice != nil {
+		agentPoolSpec.SpotMaxPrice = to.Float64Ptr(managedMachinePool.Spec.SpotMaxPrice.AsApproximateFloat64())
+	}
+
+	if kubeletConfig := managedMachinePool.Spec.KubeletConfig; kubeletConfig != nil {
+		agentPoolSpec.KubeletConfig = &agentpools.KubeletConfig{
+			CPUManagerPolicy:      kubeletConfig.CPUManagerPolicy,
+			CPUCfsQuota:           kubeletConfig.CPUCfsQuota,
+			CPUCfsQuotaPeriod:     kubeletConfig.CPUCfsQuotaPeriod,
+			ImageGcHighThreshold:  kubeletConfig.ImageGcHighThreshold,
+			ImageGcLowThreshold:   kubeletConfig.ImageGcLowThreshold,
+			TopologyManagerPolicy: kubeletConfig.TopologyManagerPolicy,
+			AllowedUnsafeSysctls:  kubeletConfig.AllowedUnsafeSysctls,
+			FailSwapOn:            kubeletConfig.FailSwapOn,
+			ContainerLogMaxSizeMB: kubeletConfig.ContainerLogMaxSizeMB,
+			ContainerLogMaxFiles:  kubeletConfig.ContainerLogMaxFiles,
+			PodMaxPids:            kubeletConfig.PodMaxPids,
+		}
+	}
+
+	if linuxOSConfig := managedMachinePool.Spec.LinuxOSConfig; linuxOSConfig != nil {
+		agentPoolSpec.LinuxOSConfig = &agentpools.LinuxOSConfig{
+			SwapFileSizeMB:             linuxOSConfig.SwapFileSizeMB,
+			TransparentHugePageDefrag:  linuxOSConfig.TransparentHugePageDefrag,
+			TransparentHugePageEnabled: linuxOSConfig.TransparentHugePageEnabled,
+		}
+		if linuxOSConfig.Sysctls != nil {
+			sysctls := agentpools.SysctlConfig(*linuxOSConfig.Sysctls)
+			agentPoolSpec.LinuxOSConfig.Sysctls = &sysctls
+		}
+	}
+
 	if len(managedMachinePool.Spec.NodeLabels) > 0 {
 		agentPoolSpec.NodeLabels = make(map[string]*string, len(managedMachinePool.Spec.NodeLabels))
 		for k, v := range managedMachinePool.Spec.NodeLabels {
@@ -208,6 +272,72 @@ func buildAgentPoolSpec(managedControlPlane *infrav1exp.AzureManagedControlPlane
 	return agentPoolSpec
 }
 
+// isNodePoolUpgradeAllowed returns true if the AzureManagedControlPlane allows the node pool to upgrade.
+func isNodePoolUpgradeAllowed(managedControlPlane *infrav1exp.AzureManagedControlPlane, name string) bool {
+	for _, upgrading := range managedControlPlane.Status.UpgradingNodePools {
+		if upgrading == name {
+			return true
+		}
+	}
+	return false
+}
+
+// setAgentPoolUpgradedCondition reports whether the agent pool runs the version of its MachinePool, or why it does
+// not yet.
+func (s *ManagedMachinePoolScope) setAgentPoolUpgradedCondition() {
+	current := s.InfraMachinePool.Status.Version
+	if current == "" || s.MachinePool.Spec.Template.Spec.Version == nil {
+		return
+	}
+
+	version := normalizeVersion(*s.MachinePool.Spec.Template.Spec.Version)
+	controlPlaneVersion := s.ControlPlane.Status.Version
+	switch {
+	case semver.Compare(version, current) == 0:
+		conditions.MarkTrue(s.InfraMachinePool, infrav1.AgentPoolUpgradedCondition)
+	case semver.Compare(version, controlPlaneVersion) > 0:
+		conditions.MarkFalse(s.InfraMachinePool, infrav1.AgentPoolUpgradedCondition, infrav1.WaitingForControlPlaneUpgradeReason, clusterv1.ConditionSeverityInfo,
+			"waiting for the control plane to run %s", version)
+	case isVersionSkewed(version, controlPlaneVersion):
+		conditions.MarkFalse(s.InfraMachinePool, infrav1.AgentPoolUpgradedCondition, infrav1.VersionSkewReason, clusterv1.ConditionSeverityError,
+			"%s is more than %d minor versions older than the control plane version %s", version, infrav1exp.MaxNodePoolMinorVersionSkew, controlPlaneVersion)
+	case !isNodePoolUpgradeAllowed(s.ControlPlane, s.InfraMachinePool.Name):
+		conditions.MarkFalse(s.InfraMachinePool, infrav1.AgentPoolUpgradedCondition, infrav1.WaitingForNodePoolUpgradesReason, clusterv1.ConditionSeverityInfo,
+			"waiting for the node pools upgraded before this one")
+	default:
+		conditions.MarkFalse(s.InfraMachinePool, infrav1.AgentPoolUpgradedCondition, infrav1.NodePoolUpgradingReason, clusterv1.ConditionSeverityInfo,
+			"upgrading from %s to %s", current, version)
+	}
+}
+
+// isVersionSkewed returns true if a node pool version is older than the oldest version AKS supports for the control
+// plane version.
+func isVersionSkewed(nodePoolVersion, controlPlaneVersion string) bool {
+	nodePoolMinor, err := minorVersion(nodePoolVersion)
+	if err != nil {
+		return false
+	}
+	controlPlaneMinor, err := minorVersion(controlPlaneVersion)
+	if err != nil {
+		return false
+	}
+	return controlPlaneMinor-nodePoolMinor > infrav1exp.MaxNodePoolMinorVersionSkew
+}
+
+// minorVersion returns the minor version of a Kubernetes version.
+func minorVersion(version string) (int, error) {
+	majorMinor := semver.MajorMinor(version)
+	if majorMinor == "" {
+		return 0, errors.Errorf("invalid version %q", version)
+	}
+	return strconv.Atoi(majorMinor[strings.Index(majorMinor, ".")+1:])
+}
+
+// SetAgentPoolVersion sets the Kubernetes version the nodes of the agent pool run.
+func (s *ManagedMachinePoolScope) SetAgentPoolVersion(version string) {
+	s.InfraMachinePool.Status.Version = normalizeVersion(version)
+}
+
 // SetAgentPoolProviderIDList sets a list of agent pool's Azure VM IDs.
 func (s *ManagedMachinePoolScope) SetAgentPoolProviderIDList(providerIDs []string) {
 	s.InfraMachinePool.Spec.ProviderIDList = providerIDs
@@ -25,13 +25,16 @@ import (
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/agentpools"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -524,6 +527,232 @@ func TestManagedMachinePoolScope_OSDiskType(t *testing.T) {
 	}
 }
 
+func TestManagedMachinePoolScope_Subnets(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = expv1.AddToScheme(scheme)
+	_ = infrav1exp.AddToScheme(scheme)
+
+	g := NewWithT(t)
+	infraMachinePool := getAzureMachinePool("pool1", infrav1exp.NodePoolModeUser)
+	infraMachinePool.Spec.NodeSubnetName = to.StringPtr("pool1-nodes")
+	infraMachinePool.Spec.PodSubnetName = to.StringPtr("pool1-pods")
+	input := ManagedMachinePoolScopeParams{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster1",
+				Namespace: "default",
+			},
+		},
+		ControlPlane: &infrav1exp.AzureManagedControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster1",
+				Namespace: "default",
+			},
+			Spec: infrav1exp.AzureManagedControlPlaneSpec{
+				SubscriptionID: "00000000-0000-0000-0000-000000000000",
+				VirtualNetwork: infrav1exp.ManagedControlPlaneVirtualNetwork{
+					Name:          "my-vnet",
+					ResourceGroup: "my-rg",
+					Subnet:        infrav1exp.ManagedControlPlaneSubnet{Name: "nodes"},
+				},
+			},
+		},
+		ManagedMachinePool: ManagedMachinePool{
+			MachinePool:      getMachinePool("pool1"),
+			InfraMachinePool: infraMachinePool,
+		},
+	}
+	input.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(input.MachinePool, input.InfraMachinePool, input.ControlPlane).Build()
+	s, err := NewManagedMachinePoolScope(context.TODO(), input)
+	g.Expect(err).To(Succeed())
+
+	agentPool := s.AgentPoolSpec().(*agentpools.AgentPoolSpec)
+	g.Expect(agentPool.VnetSubnetID).To(Equal("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/pool1-nodes"))
+	g.Expect(agentPool.PodSubnetID).To(Equal("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/pool1-pods"))
+}
+
+func TestManagedMachinePoolScope_NodeConfiguration(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = expv1.AddToScheme(scheme)
+	_ = infrav1exp.AddToScheme(scheme)
+
+	g := NewWithT(t)
+	spotMaxPrice := resource.MustParse("0.5")
+	infraMachinePool := getAzureMachinePool("pool1", infrav1exp.NodePoolModeUser)
+	infraMachinePool.Spec.KubeletConfig = &infrav1exp.KubeletConfig{
+		CPUManagerPolicy: to.StringPtr("static"),
+		FailSwapOn:       to.BoolPtr(false),
+	}
+	infraMachinePool.Spec.LinuxOSConfig = &infrav1exp.LinuxOSConfig{
+		SwapFileSizeMB: to.Int32Ptr(1500),
+		Sysctls:        &infrav1exp.SysctlConfig{NetIpv4TCPTwReuse: to.BoolPtr(true)},
+	}
+	infraMachinePool.Spec.UpgradeSettings = &infrav1exp.ManagedMachinePoolUpgradeSettings{MaxSurge: to.StringPtr("33%")}
+	infraMachinePool.Spec.ScaleSetPriority = to.StringPtr("Spot")
+	infraMachinePool.Spec.SpotMaxPrice = &spotMaxPrice
+	infraMachinePool.Spec.OSSKU = to.StringPtr("CBLMariner")
+	infraMachinePool.Spec.EnableEncryptionAtHost = to.BoolPtr(true)
+	infraMachinePool.Spec.EnableFIPS = to.BoolPtr(true)
+	input := ManagedMachinePoolScopeParams{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster1",
+				Namespace: "default",
+			},
+		},
+		ControlPlane: &infrav1exp.AzureManagedControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster1",
+				Namespace: "default",
+			},
+			Spec: infrav1exp.AzureManagedControlPlaneSpec{
+				SubscriptionID: "00000000-0000-0000-0000-000000000000",
+			},
+		},
+		ManagedMachinePool: ManagedMachinePool{
+			MachinePool:      getMachinePool("pool1"),
+			InfraMachinePool: infraMachinePool,
+		},
+	}
+	input.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(input.MachinePool, input.InfraMachinePool, input.ControlPlane).Build()
+	s, err := NewManagedMachinePoolScope(context.TODO(), input)
+	g.Expect(err).To(Succeed())
+
+	agentPool := s.AgentPoolSpec().(*agentpools.AgentPoolSpec)
+	g.Expect(agentPool.KubeletConfig).To(Equal(&agentpools.KubeletConfig{
+		CPUManagerPolicy: to.StringPtr("static"),
+		FailSwapOn:       to.BoolPtr(false),
+	}))
+	g.Expect(agentPool.LinuxOSConfig).To(Equal(&agentpools.LinuxOSConfig{
+		SwapFileSizeMB: to.Int32Ptr(1500),
+		Sysctls:        &agentpools.SysctlConfig{NetIpv4TCPTwReuse: to.BoolPtr(true)},
+	}))
+	g.Expect(agentPool.MaxSurge).To(Equal(to.StringPtr("33%")))
+	g.Expect(agentPool.SpotMaxPrice).To(Equal(to.Float64Ptr(0.5)))
+	g.Expect(agentPool.OSSKU).To(Equal(to.StringPtr("CBLMariner")))
+	g.Expect(agentPool.EnableEncryptionAtHost).To(Equal(to.BoolPtr(true)))
+	g.Expect(agentPool.EnableFIPS).To(Equal(to.BoolPtr(true)))
+}
+
+func TestManagedMachinePoolScope_Upgrade(t *testing.T) {
+	cases := []struct {
+		Name                string
+		ControlPlaneVersion string
+		UpgradingNodePools  []string
+		PoolVersion         string
+		Version             string
+		ExpectedVersion     string
+		ExpectedReason      string
+	}{
+		{
+			Name:                "new node pool uses the version of the MachinePool",
+			ControlPlaneVersion: "v1.23.5",
+			Version:             "v1.24.3",
+			ExpectedVersion:     "1.24.3",
+		},
+		{
+			Name:                "node pool waits for the control plane",
+			ControlPlaneVersion: "v1.23.5",
+			PoolVersion:         "v1.23.5",
+			Version:             "v1.24.3",
+			ExpectedVersion:     "1.23.5",
+			ExpectedReason:      infrav1.WaitingForControlPlaneUpgradeReason,
+		},
+		{
+			Name:                "node pool waits for the node pools upgraded before it",
+			ControlPlaneVersion: "v1.24.3",
+			UpgradingNodePools:  []string{"pool0"},
+			PoolVersion:         "v1.23.5",
+			Version:             "v1.24.3",
+			ExpectedVersion:     "1.23.5",
+			ExpectedReason:      infrav1.WaitingForNodePoolUpgradesReason,
+		},
+		{
+			Name:                "node pool upgrades when the control plane allows it",
+			ControlPlaneVersion: "v1.24.3",
+			UpgradingNodePools:  []string{"pool1"},
+			PoolVersion:         "v1.23.5",
+			Version:             "v1.24.3",
+			ExpectedVersion:     "1.24.3",
+			ExpectedReason:      infrav1.NodePoolUpgradingReason,
+		},
+		{
+			Name:                "node pool is too old for the control plane",
+			ControlPlaneVersion: "v1.24.3",
+			PoolVersion:         "v1.21.1",
+			Version:             "v1.21.2",
+			ExpectedVersion:     "1.21.1",
+			ExpectedReason:      infrav1.VersionSkewReason,
+		},
+		{
+			Name:                "node pool is up to date",
+			ControlPlaneVersion: "v1.24.3",
+			PoolVersion:         "v1.24.3",
+			Version:             "v1.24.3",
+			ExpectedVersion:     "1.24.3",
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			g := NewWithT(t)
+			controlPlane := &infrav1exp.AzureManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cluster1",
+					Namespace: "default",
+				},
+				Spec: infrav1exp.AzureManagedControlPlaneSpec{
+					SubscriptionID: "00000000-0000-0000-0000-000000000000",
+				},
+				Status: infrav1exp.AzureManagedControlPlaneStatus{
+					Version:            c.ControlPlaneVersion,
+					UpgradingNodePools: c.UpgradingNodePools,
+				},
+			}
+			infraMachinePool := getAzureMachinePool("pool1", infrav1exp.NodePoolModeUser)
+			infraMachinePool.Status.Version = c.PoolVersion
+			s := &ManagedMachinePoolScope{
+				MachinePool:      getMachinePoolWithVersion("pool1", c.Version),
+				ControlPlane:     controlPlane,
+				InfraMachinePool: infraMachinePool,
+			}
+
+			agentPool := buildAgentPoolSpec(s.ControlPlane, s.MachinePool, s.InfraMachinePool, nil).(*agentpools.AgentPoolSpec)
+			g.Expect(agentPool.Version).To(Equal(to.StringPtr(c.ExpectedVersion)))
+
+			s.setAgentPoolUpgradedCondition()
+			switch {
+			case c.PoolVersion == "":
+				g.Expect(conditions.Has(s.InfraMachinePool, infrav1.AgentPoolUpgradedCondition)).To(BeFalse())
+			case c.ExpectedReason == "":
+				g.Expect(conditions.IsTrue(s.InfraMachinePool, infrav1.AgentPoolUpgradedCondition)).To(BeTrue())
+			default:
+				g.Expect(conditions.GetReason(s.InfraMachinePool, infrav1.AgentPoolUpgradedCondition)).To(Equal(c.ExpectedReason))
+			}
+		})
+	}
+}
+
+func TestManagedMachinePoolScope_MaxSurgeDefault(t *testing.T) {
+	g := NewWithT(t)
+	controlPlane := &infrav1exp.AzureManagedControlPlane{
+		Spec: infrav1exp.AzureManagedControlPlaneSpec{
+			NodePoolUpgradeSettings: &infrav1exp.ManagedControlPlaneNodePoolUpgradeSettings{
+				MaxSurge: to.StringPtr("50%"),
+			},
+		},
+	}
+
+	agentPool := buildAgentPoolSpec(controlPlane, getMachinePool("pool0"), getAzureMachinePool("pool0", infrav1exp.NodePoolModeUser), nil).(*agentpools.AgentPoolSpec)
+	g.Expect(agentPool.MaxSurge).To(Equal(to.StringPtr("50%")))
+
+	infraMachinePool := getAzureMachinePool("pool1", infrav1exp.NodePoolModeUser)
+	infraMachinePool.Spec.UpgradeSettings = &infrav1exp.ManagedMachinePoolUpgradeSettings{MaxSurge: to.StringPtr("1")}
+	agentPool = buildAgentPoolSpec(controlPlane, getMachinePool("pool1"), infraMachinePool, nil).(*agentpools.AgentPoolSpec)
+	g.Expect(agentPool.MaxSurge).To(Equal(to.StringPtr("1")))
+}
+
 func getAzureMachinePool(name string, mode infrav1exp.NodePoolMode) *infrav1exp.AzureManagedMachinePool {
 	return &infrav1exp.AzureManagedMachinePool{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,119 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/adal"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/ot"
+)
+
+// tokenCacheUnusedTimeout is the time after which a token unused by any scope is dropped from the cache, e.g. because its
+// AzureClusterIdentity was deleted or its client ID changed. It is the lifetime of Azure AD access tokens, so a dropped
+// token would have to be refreshed anyway.
+const tokenCacheUnusedTimeout = time.Hour
+
+// tokenCacheKey identifies the credentials of an AzureClusterIdentity for a given cloud.
+type tokenCacheKey struct {
+	identityType            infrav1.IdentityType
+	tenantID                string
+	clientID                string
+	secretNamespace         string
+	secretName              string
+	resourceManagerEndpoint string
+	activeDirectoryEndpoint string
+}
+
+// tokenCacheEntry is a token shared by all the scopes using the same credentials.
+type tokenCacheEntry struct {
+	secretResourceVersion string
+	token                 *adal.ServicePrincipalToken
+	// used is the last time the token was returned by getOrCreate.
+	used time.Time
+}
+
+// tokenCache shares service principal tokens across scopes so that a token is only refreshed once for all the
+// clusters using the same identity, instead of once per cluster.
+type tokenCache struct {
+	mu      sync.Mutex
+	entries map[tokenCacheKey]tokenCacheEntry
+	// droppedAt is the last time the unused tokens were dropped.
+	droppedAt time.Time
+}
+
+// credentialTokenCache is the process-wide token cache used by AzureCredentialsProvider.
+var credentialTokenCache = newTokenCache()
+
+func newTokenCache() *tokenCache {
+	return &tokenCache{
+		entries:   make(map[tokenCacheKey]tokenCacheEntry),
+		droppedAt: time.Now(),
+	}
+}
+
+// getOrCreate returns the cached token for the given key, or calls create and caches the new token if there is none.
+// A cached token created with a different version of the client secret is evicted and replaced by a new one, and the
+// tokens unused for tokenCacheUnusedTimeout are evicted.
+func (c *tokenCache) getOrCreate(ctx context.Context, key tokenCacheKey, secretResourceVersion string, create func() (*adal.ServicePrincipalToken, error)) (*adal.ServicePrincipalToken, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	c.dropUnused(ctx, now)
+
+	entry, ok := c.entries[key]
+	if ok && entry.secretResourceVersion == secretResourceVersion {
+		ot.RecordTokenCacheLookup(ctx, ot.TokenCacheHit)
+		entry.used = now
+		c.entries[key] = entry
+		return entry.token, nil
+	}
+	ot.RecordTokenCacheLookup(ctx, ot.TokenCacheMiss)
+
+	token, err := create()
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		ot.RecordTokenCacheEviction(ctx)
+	}
+	c.entries[key] = tokenCacheEntry{
+		secretResourceVersion: secretResourceVersion,
+		token:                 token,
+		used:                  now,
+	}
+	return token, nil
+}
+
+// dropUnused evicts the tokens unused for tokenCacheUnusedTimeout. The tokens are checked at most once per timeout.
+// It must be called with mu held.
+func (c *tokenCache) dropUnused(ctx context.Context, now time.Time) {
+	if now.Sub(c.droppedAt) < tokenCacheUnusedTimeout {
+		return
+	}
+	c.droppedAt = now
+	for key, entry := range c.entries {
+		if now.Sub(entry.used) >= tokenCacheUnusedTimeout {
+			delete(c.entries, key)
+			ot.RecordTokenCacheEviction(ctx)
+		}
+	}
+}
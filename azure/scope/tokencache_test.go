@@ -0,0 +1,157 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestTokenCacheGetOrCreate(t *testing.T) {
+	g := NewWithT(t)
+
+	cache := newTokenCache()
+	key := tokenCacheKey{
+		identityType: infrav1.ManualServicePrincipal,
+		tenantID:     "my-tenant-id",
+		clientID:     "my-client-id",
+	}
+	var created int
+	create := func() (*adal.ServicePrincipalToken, error) {
+		created++
+		return &adal.ServicePrincipalToken{}, nil
+	}
+
+	first, err := cache.getOrCreate(context.TODO(), key, "1", create)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(created).To(Equal(1))
+
+	// the same credentials share the token.
+	second, err := cache.getOrCreate(context.TODO(), key, "1", create)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(created).To(Equal(1))
+	g.Expect(second).To(BeIdenticalTo(first))
+
+	// other credentials get their own token.
+	otherKey := key
+	otherKey.resourceManagerEndpoint = "https://management.usgovcloudapi.net/"
+	other, err := cache.getOrCreate(context.TODO(), otherKey, "1", create)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(created).To(Equal(2))
+	g.Expect(other).NotTo(BeIdenticalTo(first))
+
+	// a new version of the secret replaces the token.
+	updated, err := cache.getOrCreate(context.TODO(), key, "2", create)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(created).To(Equal(3))
+	g.Expect(updated).NotTo(BeIdenticalTo(first))
+	g.Expect(cache.entries).To(HaveLen(2))
+	g.Expect(cache.entries[key].token).To(BeIdenticalTo(updated))
+
+	// tokens that fail to be created are not cached.
+	_, err = cache.getOrCreate(context.TODO(), otherKey, "2", func() (*adal.ServicePrincipalToken, error) {
+		return nil, errors.New("failed to create token")
+	})
+	g.Expect(err).To(MatchError("failed to create token"))
+	g.Expect(cache.entries[otherKey].token).To(BeIdenticalTo(other))
+}
+
+func TestTokenCacheDropUnused(t *testing.T) {
+	g := NewWithT(t)
+
+	cache := newTokenCache()
+	create := func() (*adal.ServicePrincipalToken, error) {
+		return &adal.ServicePrincipalToken{}, nil
+	}
+	unusedKey := tokenCacheKey{identityType: infrav1.ManualServicePrincipal, clientID: "deleted-client-id"}
+	usedKey := tokenCacheKey{identityType: infrav1.ManualServicePrincipal, clientID: "my-client-id"}
+	_, err := cache.getOrCreate(context.TODO(), unusedKey, "1", create)
+	g.Expect(err).NotTo(HaveOccurred())
+	used, err := cache.getOrCreate(context.TODO(), usedKey, "1", create)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// tokens are kept until the timeout has passed since the last check.
+	unused := cache.entries[unusedKey]
+	unused.used = time.Now().Add(-2 * tokenCacheUnusedTimeout)
+	cache.entries[unusedKey] = unused
+	_, err = cache.getOrCreate(context.TODO(), usedKey, "1", create)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cache.entries).To(HaveLen(2))
+
+	// tokens unused for the timeout are dropped, the others are kept.
+	cache.droppedAt = time.Now().Add(-tokenCacheUnusedTimeout)
+	token, err := cache.getOrCreate(context.TODO(), usedKey, "1", create)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(token).To(BeIdenticalTo(used))
+	g.Expect(cache.entries).To(HaveLen(1))
+	g.Expect(cache.entries).To(HaveKey(usedKey))
+}
+
+func TestGetAuthorizerSharesTokenAcrossClusters(t *testing.T) {
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-client-secret",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			azureSecretKey: []byte("my-secret"),
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	p := &AzureCredentialsProvider{
+		Client: fakeClient,
+		Identity: &infrav1.AzureClusterIdentity{
+			Spec: infrav1.AzureClusterIdentitySpec{
+				Type:         infrav1.ManualServicePrincipal,
+				TenantID:     "shared-tenant-id",
+				ClientID:     "shared-client-id",
+				ClientSecret: corev1.SecretReference{Name: "my-client-secret", Namespace: "default"},
+			},
+		},
+	}
+	getToken := func(clusterName string) *adal.ServicePrincipalToken {
+		authorizer, err := p.GetAuthorizer(context.TODO(), "https://management.azure.com/", "https://login.microsoftonline.com/", metav1.ObjectMeta{Name: clusterName, Namespace: "default"})
+		g.Expect(err).NotTo(HaveOccurred())
+		spt, ok := authorizer.(*autorest.BearerAuthorizer).TokenProvider().(*adal.ServicePrincipalToken)
+		g.Expect(ok).To(BeTrue())
+		return spt
+	}
+
+	first := getToken("cluster-one")
+	g.Expect(getToken("cluster-two")).To(BeIdenticalTo(first))
+
+	// rotating the secret evicts the cached token.
+	secret.Data[azureSecretKey] = []byte("my-new-secret")
+	g.Expect(fakeClient.Update(context.TODO(), secret)).To(Succeed())
+	g.Expect(getToken("cluster-one")).NotTo(BeIdenticalTo(first))
+}
@@ -41,6 +41,7 @@ type AgentPoolScope interface {
 	AgentPoolSpec() azure.ResourceSpecGetter
 	SetAgentPoolProviderIDList([]string)
 	SetAgentPoolReplicas(int32)
+	SetAgentPoolVersion(string)
 	SetAgentPoolReady(bool)
 	SetCAPIMachinePoolReplicas(replicas *int32)
 	SetCAPIMachinePoolAnnotation(key, value string)
@@ -89,6 +90,13 @@ func (s *Service) Reconcile(ctx context.Context) error {
 			} else { // Otherwise, remove the annotation.
 				s.scope.RemoveCAPIMachinePoolAnnotation(azure.ReplicasManagedByAutoscalerAnnotation)
 			}
+
+			// Update the Kubernetes version the nodes run, which lags behind the desired version during an upgrade. The
+			// nodes run the desired version once the agent pool is provisioned.
+			if agentPool.ManagedClusterAgentPoolProfileProperties != nil &&
+				to.String(agentPool.ProvisioningState) == string(infrav1.Succeeded) {
+				s.scope.SetAgentPoolVersion(to.String(agentPool.OrchestratorVersion))
+			}
 		}
 	} else {
 		return nil
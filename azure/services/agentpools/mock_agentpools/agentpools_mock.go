@@ -219,6 +219,20 @@ func (mr *MockAgentPoolScopeMockRecorder) DeleteLongRunningOperationState(arg0,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLongRunningOperationState", reflect.TypeOf((*MockAgentPoolScope)(nil).DeleteLongRunningOperationState), arg0, arg1, arg2)
 }
 
+// DriftPolicy mocks base method.
+func (m *MockAgentPoolScope) DriftPolicy() v1beta1.DriftPolicy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DriftPolicy")
+	ret0, _ := ret[0].(v1beta1.DriftPolicy)
+	return ret0
+}
+
+// DriftPolicy indicates an expected call of DriftPolicy.
+func (mr *MockAgentPoolScopeMockRecorder) DriftPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DriftPolicy", reflect.TypeOf((*MockAgentPoolScope)(nil).DriftPolicy))
+}
+
 // FailureDomains mocks base method.
 func (m *MockAgentPoolScope) FailureDomains() []string {
 	m.ctrl.T.Helper()
@@ -365,6 +379,18 @@ func (mr *MockAgentPoolScopeMockRecorder) SetAgentPoolReplicas(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAgentPoolReplicas", reflect.TypeOf((*MockAgentPoolScope)(nil).SetAgentPoolReplicas), arg0)
 }
 
+// SetAgentPoolVersion mocks base method.
+func (m *MockAgentPoolScope) SetAgentPoolVersion(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAgentPoolVersion", arg0)
+}
+
+// SetAgentPoolVersion indicates an expected call of SetAgentPoolVersion.
+func (mr *MockAgentPoolScopeMockRecorder) SetAgentPoolVersion(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAgentPoolVersion", reflect.TypeOf((*MockAgentPoolScope)(nil).SetAgentPoolVersion), arg0)
+}
+
 // SetCAPIMachinePoolAnnotation mocks base method.
 func (m *MockAgentPoolScope) SetCAPIMachinePoolAnnotation(key, value string) {
 	m.ctrl.T.Helper()
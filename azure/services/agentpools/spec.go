@@ -55,6 +55,10 @@ type AgentPoolSpec struct {
 	// VnetSubnetID is the Azure Resource ID for the subnet which should contain nodes.
 	VnetSubnetID string
 
+	// PodSubnetID is the Azure Resource ID for the subnet from which pod IPs are dynamically allocated. If empty, pod IPs
+	// are allocated from the node subnet.
+	PodSubnetID string
+
 	// Mode represents mode of an agent pool. Possible values include: 'System', 'User'.
 	Mode string
 
@@ -99,8 +103,58 @@ type AgentPoolSpec struct {
 
 	// ScaleSetPriority specifies the ScaleSetPriority for the node pool. Allowed values are 'Spot' and 'Regular'
 	ScaleSetPriority *string `json:"scaleSetPriority,omitempty"`
+
+	// KubeletConfig specifies the kubelet configuration of the nodes in the agent pool.
+	KubeletConfig *KubeletConfig
+
+	// LinuxOSConfig specifies the custom Linux OS settings and configurations of the nodes in the agent pool.
+	LinuxOSConfig *LinuxOSConfig
+
+	// MaxSurge is the maximum number or percentage of nodes that are surged during an upgrade.
+	MaxSurge *string
+
+	// SpotMaxPrice is the maximum price per hour of the Spot VMs of the agent pool, or -1 for the on-demand price.
+	SpotMaxPrice *float64
+
+	// OSSKU specifies the OS SKU used by the agent pool. Allowed values are 'Ubuntu' and 'CBLMariner'.
+	OSSKU *string
+
+	// EnableEncryptionAtHost enables host-based encryption of the disks of the nodes in the agent pool.
+	EnableEncryptionAtHost *bool
+
+	// ProximityPlacementGroupID is the resource ID of the proximity placement group of the agent pool.
+	ProximityPlacementGroupID *string
+
+	// EnableFIPS enables the use of a FIPS-enabled OS for the agent pool.
+	EnableFIPS *bool
+}
+
+// KubeletConfig specifies the kubelet configuration of the nodes of an agent pool.
+type KubeletConfig struct {
+	CPUManagerPolicy      *string
+	CPUCfsQuota           *bool
+	CPUCfsQuotaPeriod     *string
+	ImageGcHighThreshold  *int32
+	ImageGcLowThreshold   *int32
+	TopologyManagerPolicy *string
+	AllowedUnsafeSysctls  []string
+	FailSwapOn            *bool
+	ContainerLogMaxSizeMB *int32
+	ContainerLogMaxFiles  *int32
+	PodMaxPids            *int32
+}
+
+// LinuxOSConfig specifies the custom Linux OS settings and configurations of the nodes of an agent pool.
+type LinuxOSConfig struct {
+	SwapFileSizeMB             *int32
+	Sysctls                    *SysctlConfig
+	TransparentHugePageDefrag  *string
+	TransparentHugePageEnabled *string
 }
 
+// SysctlConfig specifies the sysctl settings of the nodes of an agent pool.
+type SysctlConfig containerservice.SysctlConfig
+
 // ResourceName returns the name of the agent pool.
 func (s *AgentPoolSpec) ResourceName() string {
 	return s.Name
@@ -148,6 +202,7 @@ func (s *AgentPoolSpec) Parameters(existing interface{}) (params interface{}, er
 				MaxCount:            existingPool.MaxCount,
 				NodeLabels:          existingPool.NodeLabels,
 				NodeTaints:          existingPool.NodeTaints,
+				UpgradeSettings:     existingPool.UpgradeSettings,
 			},
 		}
 
@@ -160,10 +215,21 @@ func (s *AgentPoolSpec) Parameters(existing interface{}) (params interface{}, er
 				MinCount:            s.MinCount,
 				MaxCount:            s.MaxCount,
 				NodeLabels:          s.NodeLabels,
-				NodeTaints:          existingPool.NodeTaints,
+				NodeTaints:          &s.NodeTaints,
+				UpgradeSettings:     existingPool.UpgradeSettings,
 			},
 		}
 
+		// No taints is either an empty or a nil list in the existing agent pool.
+		if len(s.NodeTaints) == 0 && (existingProfile.NodeTaints == nil || len(*existingProfile.NodeTaints) == 0) {
+			normalizedProfile.NodeTaints = existingProfile.NodeTaints
+		}
+
+		// The surge is only updated when it is set in the spec.
+		if s.MaxSurge != nil {
+			normalizedProfile.UpgradeSettings = &containerservice.AgentPoolUpgradeSettings{MaxSurge: s.MaxSurge}
+		}
+
 		// When autoscaling is set, the count of the nodes differ based on the autoscaler and should not depend on the
 		// count present in MachinePool or AzureManagedMachinePool, hence we should not make an update API call based
 		// on difference in count.
@@ -196,6 +262,13 @@ func (s *AgentPoolSpec) Parameters(existing interface{}) (params interface{}, er
 	var nodeTaints *[]string
 	if len(s.NodeTaints) > 0 {
 		nodeTaints = &s.NodeTaints
+	} else if existing != nil {
+		// An empty list removes the taints of an existing agent pool.
+		nodeTaints = &[]string{}
+	}
+	var upgradeSettings *containerservice.AgentPoolUpgradeSettings
+	if s.MaxSurge != nil {
+		upgradeSettings = &containerservice.AgentPoolUpgradeSettings{MaxSurge: s.MaxSurge}
 	}
 	var sku *string
 	if s.SKU != "" {
@@ -205,33 +278,86 @@ func (s *AgentPoolSpec) Parameters(existing interface{}) (params interface{}, er
 	if s.VnetSubnetID != "" {
 		vnetSubnetID = &s.VnetSubnetID
 	}
+	var podSubnetID *string
+	if s.PodSubnetID != "" {
+		podSubnetID = &s.PodSubnetID
+	}
 
 	return containerservice.AgentPool{
 		ManagedClusterAgentPoolProfileProperties: &containerservice.ManagedClusterAgentPoolProfileProperties{
-			AvailabilityZones:    availabilityZones,
-			Count:                replicas,
-			EnableAutoScaling:    s.EnableAutoScaling,
-			EnableUltraSSD:       s.EnableUltraSSD,
-			MaxCount:             s.MaxCount,
-			MaxPods:              s.MaxPods,
-			MinCount:             s.MinCount,
-			Mode:                 containerservice.AgentPoolMode(s.Mode),
-			NodeLabels:           nodeLabels,
-			NodeTaints:           nodeTaints,
-			OrchestratorVersion:  s.Version,
-			OsDiskSizeGB:         &s.OSDiskSizeGB,
-			OsDiskType:           containerservice.OSDiskType(to.String(s.OsDiskType)),
-			OsType:               containerservice.OSType(to.String(s.OSType)),
-			ScaleSetPriority:     containerservice.ScaleSetPriority(to.String(s.ScaleSetPriority)),
-			Type:                 containerservice.AgentPoolTypeVirtualMachineScaleSets,
-			VMSize:               sku,
-			VnetSubnetID:         vnetSubnetID,
-			EnableNodePublicIP:   s.EnableNodePublicIP,
-			NodePublicIPPrefixID: s.NodePublicIPPrefixID,
+			AvailabilityZones:         availabilityZones,
+			Count:                     replicas,
+			EnableAutoScaling:         s.EnableAutoScaling,
+			EnableUltraSSD:            s.EnableUltraSSD,
+			MaxCount:                  s.MaxCount,
+			MaxPods:                   s.MaxPods,
+			MinCount:                  s.MinCount,
+			Mode:                      containerservice.AgentPoolMode(s.Mode),
+			NodeLabels:                nodeLabels,
+			NodeTaints:                nodeTaints,
+			OrchestratorVersion:       s.Version,
+			OsDiskSizeGB:              &s.OSDiskSizeGB,
+			OsDiskType:                containerservice.OSDiskType(to.String(s.OsDiskType)),
+			OsType:                    containerservice.OSType(to.String(s.OSType)),
+			PodSubnetID:               podSubnetID,
+			ScaleSetPriority:          containerservice.ScaleSetPriority(to.String(s.ScaleSetPriority)),
+			Type:                      containerservice.AgentPoolTypeVirtualMachineScaleSets,
+			VMSize:                    sku,
+			VnetSubnetID:              vnetSubnetID,
+			EnableNodePublicIP:        s.EnableNodePublicIP,
+			NodePublicIPPrefixID:      s.NodePublicIPPrefixID,
+			KubeletConfig:             s.kubeletConfig(),
+			LinuxOSConfig:             s.linuxOSConfig(),
+			UpgradeSettings:           upgradeSettings,
+			SpotMaxPrice:              s.SpotMaxPrice,
+			OsSKU:                     containerservice.OSSKU(to.String(s.OSSKU)),
+			EnableEncryptionAtHost:    s.EnableEncryptionAtHost,
+			ProximityPlacementGroupID: s.ProximityPlacementGroupID,
+			EnableFIPS:                s.EnableFIPS,
 		},
 	}, nil
 }
 
+// kubeletConfig returns the kubelet configuration of the agent pool.
+func (s *AgentPoolSpec) kubeletConfig() *containerservice.KubeletConfig {
+	if s.KubeletConfig == nil {
+		return nil
+	}
+	kubeletConfig := &containerservice.KubeletConfig{
+		CPUManagerPolicy:      s.KubeletConfig.CPUManagerPolicy,
+		CPUCfsQuota:           s.KubeletConfig.CPUCfsQuota,
+		CPUCfsQuotaPeriod:     s.KubeletConfig.CPUCfsQuotaPeriod,
+		ImageGcHighThreshold:  s.KubeletConfig.ImageGcHighThreshold,
+		ImageGcLowThreshold:   s.KubeletConfig.ImageGcLowThreshold,
+		TopologyManagerPolicy: s.KubeletConfig.TopologyManagerPolicy,
+		FailSwapOn:            s.KubeletConfig.FailSwapOn,
+		ContainerLogMaxSizeMB: s.KubeletConfig.ContainerLogMaxSizeMB,
+		ContainerLogMaxFiles:  s.KubeletConfig.ContainerLogMaxFiles,
+		PodMaxPids:            s.KubeletConfig.PodMaxPids,
+	}
+	if len(s.KubeletConfig.AllowedUnsafeSysctls) > 0 {
+		kubeletConfig.AllowedUnsafeSysctls = &s.KubeletConfig.AllowedUnsafeSysctls
+	}
+	return kubeletConfig
+}
+
+// linuxOSConfig returns the Linux OS configuration of the agent pool.
+func (s *AgentPoolSpec) linuxOSConfig() *containerservice.LinuxOSConfig {
+	if s.LinuxOSConfig == nil {
+		return nil
+	}
+	linuxOSConfig := &containerservice.LinuxOSConfig{
+		SwapFileSizeMB:             s.LinuxOSConfig.SwapFileSizeMB,
+		TransparentHugePageDefrag:  s.LinuxOSConfig.TransparentHugePageDefrag,
+		TransparentHugePageEnabled: s.LinuxOSConfig.TransparentHugePageEnabled,
+	}
+	if s.LinuxOSConfig.Sysctls != nil {
+		sysctls := containerservice.SysctlConfig(*s.LinuxOSConfig.Sysctls)
+		linuxOSConfig.Sysctls = &sysctls
+	}
+	return linuxOSConfig
+}
+
 // mergeSystemNodeLabels appends any kubernetes.azure.com-prefixed labels from the AKS label set
 // into the local capz label set.
 func mergeSystemNodeLabels(capz, aks map[string]*string) map[string]*string {
@@ -247,6 +247,82 @@ func fakeAgentPoolWithAutoscalingAndCount(enableAutoScaling bool, count int32) c
 	}
 }
 
+func fakeAgentPoolSpecWithPodSubnet() AgentPoolSpec {
+	spec := fakeAgentPoolSpecWithAutoscaling
+	spec.PodSubnetID = "fake-pod-subnet-id"
+	return spec
+}
+
+func fakeAgentPoolWithPodSubnet() containerservice.AgentPool {
+	pool := fakeAgentPoolWithProvisioningState("")
+	pool.PodSubnetID = to.StringPtr("fake-pod-subnet-id")
+	return pool
+}
+
+func fakeAgentPoolSpecWithNodeConfig() AgentPoolSpec {
+	spec := fakeAgentPoolSpecWithAutoscaling
+	spec.KubeletConfig = &KubeletConfig{
+		CPUManagerPolicy:     to.StringPtr("static"),
+		AllowedUnsafeSysctls: []string{"net.*"},
+		FailSwapOn:           to.BoolPtr(false),
+	}
+	spec.LinuxOSConfig = &LinuxOSConfig{
+		SwapFileSizeMB: to.Int32Ptr(1500),
+		Sysctls:        &SysctlConfig{NetCoreSomaxconn: to.Int32Ptr(4096)},
+	}
+	spec.MaxSurge = to.StringPtr("33%")
+	spec.SpotMaxPrice = to.Float64Ptr(0.5)
+	spec.OSSKU = to.StringPtr("CBLMariner")
+	spec.EnableEncryptionAtHost = to.BoolPtr(true)
+	spec.ProximityPlacementGroupID = to.StringPtr("fake-ppg-id")
+	spec.EnableFIPS = to.BoolPtr(true)
+	return spec
+}
+
+func fakeAgentPoolWithNodeConfig() containerservice.AgentPool {
+	pool := fakeAgentPoolWithProvisioningState("")
+	pool.KubeletConfig = &containerservice.KubeletConfig{
+		CPUManagerPolicy:     to.StringPtr("static"),
+		AllowedUnsafeSysctls: &[]string{"net.*"},
+		FailSwapOn:           to.BoolPtr(false),
+	}
+	pool.LinuxOSConfig = &containerservice.LinuxOSConfig{
+		SwapFileSizeMB: to.Int32Ptr(1500),
+		Sysctls:        &containerservice.SysctlConfig{NetCoreSomaxconn: to.Int32Ptr(4096)},
+	}
+	pool.UpgradeSettings = &containerservice.AgentPoolUpgradeSettings{MaxSurge: to.StringPtr("33%")}
+	pool.SpotMaxPrice = to.Float64Ptr(0.5)
+	pool.OsSKU = containerservice.OSSKUCBLMariner
+	pool.EnableEncryptionAtHost = to.BoolPtr(true)
+	pool.ProximityPlacementGroupID = to.StringPtr("fake-ppg-id")
+	pool.EnableFIPS = to.BoolPtr(true)
+	return pool
+}
+
+func fakeAgentPoolWithTaints(provisioningState string, taints *[]string) containerservice.AgentPool {
+	pool := fakeAgentPoolWithProvisioningState(provisioningState)
+	pool.NodeTaints = taints
+	return pool
+}
+
+func fakeAgentPoolSpecWithMaxSurge(maxSurge string) AgentPoolSpec {
+	spec := fakeAgentPoolSpecWithAutoscaling
+	spec.MaxSurge = to.StringPtr(maxSurge)
+	return spec
+}
+
+func fakeAgentPoolWithMaxSurge(provisioningState string, maxSurge string) containerservice.AgentPool {
+	pool := fakeAgentPoolWithProvisioningState(provisioningState)
+	pool.UpgradeSettings = &containerservice.AgentPoolUpgradeSettings{MaxSurge: to.StringPtr(maxSurge)}
+	return pool
+}
+
+func fakeAgentPoolSpecWithoutTaints() AgentPoolSpec {
+	spec := fakeAgentPoolSpecWithAutoscaling
+	spec.NodeTaints = nil
+	return spec
+}
+
 func TestParameters(t *testing.T) {
 	testcases := []struct {
 		name          string
@@ -353,6 +429,55 @@ func TestParameters(t *testing.T) {
 			expected:      fakeAgentPoolWithProvisioningState(""),
 			expectedError: nil,
 		},
+		{
+			name:          "parameters with a pod subnet without an existing agent pool",
+			spec:          fakeAgentPoolSpecWithPodSubnet(),
+			existing:      nil,
+			expected:      fakeAgentPoolWithPodSubnet(),
+			expectedError: nil,
+		},
+		{
+			name:          "parameters with node configuration without an existing agent pool",
+			spec:          fakeAgentPoolSpecWithNodeConfig(),
+			existing:      nil,
+			expected:      fakeAgentPoolWithNodeConfig(),
+			expectedError: nil,
+		},
+		{
+			name:          "parameters with an existing agent pool and update needed on node taints",
+			spec:          fakeAgentPoolSpecWithAutoscaling,
+			existing:      fakeAgentPoolWithTaints("Succeeded", &[]string{"fake-old-taint"}),
+			expected:      fakeAgentPoolWithProvisioningState(""),
+			expectedError: nil,
+		},
+		{
+			name:          "parameters with an existing agent pool and update needed to remove node taints",
+			spec:          fakeAgentPoolSpecWithoutTaints(),
+			existing:      fakeAgentPoolWithTaints("Succeeded", &[]string{"fake-taint"}),
+			expected:      fakeAgentPoolWithTaints("", &[]string{}),
+			expectedError: nil,
+		},
+		{
+			name:          "parameters with an existing agent pool without node taints up to date",
+			spec:          fakeAgentPoolSpecWithoutTaints(),
+			existing:      fakeAgentPoolWithTaints("Succeeded", nil),
+			expected:      nil,
+			expectedError: nil,
+		},
+		{
+			name:          "parameters with an existing agent pool and update needed on max surge",
+			spec:          fakeAgentPoolSpecWithMaxSurge("2"),
+			existing:      fakeAgentPoolWithMaxSurge("Succeeded", "1"),
+			expected:      fakeAgentPoolWithMaxSurge("", "2"),
+			expectedError: nil,
+		},
+		{
+			name:          "parameters with an existing agent pool with max surge unset in the spec up to date",
+			spec:          fakeAgentPoolSpecWithAutoscaling,
+			existing:      fakeAgentPoolWithMaxSurge("Succeeded", "1"),
+			expected:      nil,
+			expectedError: nil,
+		},
 	}
 	for _, tc := range testcases {
 		tc := tc
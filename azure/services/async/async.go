@@ -29,6 +29,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/util/diff"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
@@ -94,7 +95,8 @@ func processOngoingOperation(ctx context.Context, scope FutureScope, client Futu
 
 	// Resource has been created/deleted/updated.
 	log.V(2).Info("long running operation has completed", "service", serviceName, "resource", resourceName)
-	return client.Result(ctx, sdkFuture, future.Type)
+	result, err = client.Result(ctx, sdkFuture, future.Type)
+	return result, classifyError(err, err)
 }
 
 // CreateOrUpdateResource implements the logic for creating a new, or updating an existing, resource Asynchronously.
@@ -116,22 +118,71 @@ func (s *Service) CreateOrUpdateResource(ctx context.Context, spec azure.Resourc
 	var existingResource interface{}
 	if existing, err := s.Creator.Get(ctx, spec); err != nil && !azure.ResourceNotFound(err) {
 		errWrapped := errors.Wrapf(err, "failed to get existing resource %s/%s (service: %s)", rgName, resourceName, serviceName)
-		return nil, azure.WithTransientError(errWrapped, getRetryAfterFromError(err))
+		if azure.ClassifyError(err) == azure.UnknownErrorCategory {
+			return nil, azure.WithTransientError(errWrapped, getRetryAfterFromError(err))
+		}
+		return nil, classifyError(err, errWrapped)
 	} else if err == nil {
 		existingResource = existing
 		log.V(2).Info("successfully got existing resource", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
 	}
 
+	// Check whether the existing resource was modified outside of CAPZ and, depending on the drift policy, leave it as is.
+	drift, err := newDriftCheck(s.Scope, spec, existingResource, serviceName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to detect drift of resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+	}
+	if drift.isReportOnly() {
+		drift.record(false)
+		// The spec didn't change, record it as applied in case it wasn't known yet.
+		drift.applied()
+		log.V(2).Info("resource was modified outside of CAPZ, not reverting the changes", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
+		return existingResource, nil
+	}
+
 	// Construct parameters using the resource spec and information from the existing resource, if there is one.
 	parameters, err := spec.Parameters(existingResource)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get desired parameters for resource %s/%s (service: %s)", rgName, resourceName, serviceName)
 	} else if parameters == nil {
 		// Nothing to do, don't create or update the resource and return the existing resource.
+		drift.applied()
 		log.V(2).Info("resource up to date", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
 		return existingResource, nil
 	}
 
+	// In dry-run mode, record the change instead of applying it. An existing resource is returned as is so that
+	// the changes to the resources depending on it can be planned too.
+	if dr, ok := dryRunner(s.Scope); ok {
+		change := azure.PlannedChange{
+			Service:       serviceName,
+			ResourceGroup: rgName,
+			Resource:      resourceName,
+			Action:        azure.PlannedCreate,
+		}
+		drift.record(false)
+		if existingResource == nil {
+			dr.RecordPlannedChange(change)
+			log.V(2).Info("dry-run: not creating resource", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
+			return nil, azure.WithTransientError(azure.NewDryRunError(change), reconciler.DefaultReconcilerRequeue)
+		}
+
+		change.Action = azure.PlannedUpdate
+		if describer, ok := spec.(azure.ChangeDescriber); ok {
+			change.ChangedFields, err = describer.ChangedFields(existingResource, parameters)
+		} else {
+			change.ChangedFields, err = diff.ChangedFields(existingResource, parameters)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compute changes to resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+		}
+		if len(change.ChangedFields) > 0 {
+			dr.RecordPlannedChange(change)
+			log.V(2).Info("dry-run: not updating resource", "service", serviceName, "resource", resourceName, "resourceGroup", rgName, "changedFields", change.ChangedFields)
+		}
+		return existingResource, nil
+	}
+
 	// Create or update the resource with the desired parameters.
 	logMessageVerbPrefix := "creat"
 	if existingResource != nil {
@@ -139,6 +190,9 @@ func (s *Service) CreateOrUpdateResource(ctx context.Context, spec azure.Resourc
 	}
 	log.V(2).Info(fmt.Sprintf("%sing resource", logMessageVerbPrefix), "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
 	result, sdkFuture, err := s.Creator.CreateOrUpdateAsync(ctx, spec, parameters)
+	// The drift is only remediated once the update succeeded. It is detected again if it failed, or once the long
+	// running operation is done if it didn't revert it.
+	drift.record(err == nil && sdkFuture == nil)
 	errWrapped := errors.Wrapf(err, fmt.Sprintf("failed to %se resource %s/%s (service: %s)", logMessageVerbPrefix, rgName, resourceName, serviceName))
 	if sdkFuture != nil {
 		future, err := converters.SDKToFuture(sdkFuture, infrav1.PutFuture, serviceName, resourceName, rgName)
@@ -148,9 +202,10 @@ func (s *Service) CreateOrUpdateResource(ctx context.Context, spec azure.Resourc
 		s.Scope.SetLongRunningOperationState(future)
 		return nil, azure.WithTransientError(azure.NewOperationNotDoneError(future), getRequeueAfterFromFuture(sdkFuture))
 	} else if err != nil {
-		return nil, errWrapped
+		return nil, classifyError(err, errWrapped)
 	}
 
+	drift.applied()
 	log.V(2).Info(fmt.Sprintf("successfully %sed resource", logMessageVerbPrefix), "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
 	return result, nil
 }
@@ -171,6 +226,19 @@ func (s *Service) DeleteResource(ctx context.Context, spec azure.ResourceSpecGet
 		return err
 	}
 
+	// In dry-run mode, record the deletion instead of deleting the resource.
+	if dr, ok := dryRunner(s.Scope); ok {
+		change := azure.PlannedChange{
+			Service:       serviceName,
+			ResourceGroup: rgName,
+			Resource:      resourceName,
+			Action:        azure.PlannedDelete,
+		}
+		dr.RecordPlannedChange(change)
+		log.V(2).Info("dry-run: not deleting resource", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
+		return azure.WithTransientError(azure.NewDryRunError(change), reconciler.DefaultReconcilerRequeue)
+	}
+
 	// No long running operation is active, so delete the resource.
 	log.V(2).Info("deleting resource", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
 	sdkFuture, err := s.Deleter.DeleteAsync(ctx, spec)
@@ -186,13 +254,32 @@ func (s *Service) DeleteResource(ctx context.Context, spec azure.ResourceSpecGet
 			// already deleted
 			return nil
 		}
-		return errors.Wrapf(err, "failed to delete resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+		return classifyError(err, errors.Wrapf(err, "failed to delete resource %s/%s (service: %s)", rgName, resourceName, serviceName))
 	}
 
 	log.V(2).Info("successfully deleted resource", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
 	return nil
 }
 
+// classifyError wraps errWrapped, which wraps the error err returned by Azure, into an azure.ReconcileError
+// depending on the category of err so that the controllers do not retry errors at the default requeue when they
+// cannot be recovered from or need an action from the user. Errors which are not classified or which are already
+// azure.ReconcileErrors are returned as they are.
+func classifyError(err, errWrapped error) error {
+	if errors.As(err, &azure.ReconcileError{}) {
+		return errWrapped
+	}
+	switch azure.ClassifyError(err) {
+	case azure.TerminalErrorCategory:
+		return azure.WithTerminalError(errWrapped)
+	case azure.UserActionableErrorCategory:
+		return azure.WithTransientError(errWrapped, reconciler.DefaultUserActionableErrorRequeue)
+	case azure.TransientErrorCategory:
+		return azure.WithTransientError(errWrapped, getRetryAfterFromError(err))
+	}
+	return errWrapped
+}
+
 // getRequeueAfterFromFuture returns the max between the `RETRY-AFTER` header and the default requeue time.
 // This ensures we respect the retry-after header if it is set and avoid retrying too often during an API throttling event.
 func getRequeueAfterFromFuture(sdkFuture azureautorest.FutureAPI) time.Duration {
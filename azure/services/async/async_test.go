@@ -62,6 +62,14 @@ var (
 	fakeInternalError      = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error")
 	fakeNotFoundError      = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not Found")
 	errCtxExceeded         = errors.New("ctx exceeded")
+	fakePolicyError        = autorest.DetailedError{
+		StatusCode: 403,
+		Original:   &azureautorest.RequestError{ServiceError: &azureautorest.ServiceError{Code: "RequestDisallowedByPolicy"}},
+	}
+	fakeInvalidParameterError = autorest.DetailedError{
+		StatusCode: 400,
+		Original:   &azureautorest.RequestError{ServiceError: &azureautorest.ServiceError{Code: "InvalidParameter"}},
+	}
 )
 
 // TestProcessOngoingOperation tests the processOngoingOperation function.
@@ -302,6 +310,32 @@ func TestCreateOrUpdateResource(t *testing.T) {
 				c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{}), &fakeResourceParameters).Return(nil, nil, fakeInternalError)
 			},
 		},
+		{
+			name:          "async create is denied by policy",
+			expectedError: "Code=\"RequestDisallowedByPolicy\" Message=\"\". Object will be requeued after 10m0s",
+			serviceName:   "test-service",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				r.ResourceName().Return("test-resource")
+				r.ResourceGroupName().Return("test-group")
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(&fakeExistingResource, nil)
+				r.Parameters(&fakeExistingResource).Return(&fakeResourceParameters, nil)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{}), &fakeResourceParameters).Return(nil, nil, fakePolicyError)
+			},
+		},
+		{
+			name:          "async create fails with an invalid parameter",
+			expectedError: "reconcile error that cannot be recovered occurred: failed to update resource test-group/test-resource (service: test-service)",
+			serviceName:   "test-service",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				r.ResourceName().Return("test-resource")
+				r.ResourceGroupName().Return("test-group")
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(&fakeExistingResource, nil)
+				r.Parameters(&fakeExistingResource).Return(&fakeResourceParameters, nil)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{}), &fakeResourceParameters).Return(nil, nil, fakeInvalidParameterError)
+			},
+		},
 		{
 			name:          "create async exits before completing",
 			expectedError: "operation type PUT on Azure resource test-group/test-resource is not done. Object will be requeued after 15s",
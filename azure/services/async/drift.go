@@ -0,0 +1,128 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package async
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+// driftCheck tracks the changes made outside of CAPZ to the fields a spec owns in an existing resource while the
+// resource is reconciled.
+type driftCheck struct {
+	reporter DriftReporter
+	dryRun   bool
+	// key identifies the resource among the resources of the scope.
+	key string
+	// specHash is the hash of the spec being reconciled.
+	specHash string
+	// drift is the change made outside of CAPZ, if any.
+	drift *azure.ResourceDrift
+	// reportOnly is true if the drift policy is to leave the drifted fields as they are.
+	reportOnly bool
+}
+
+// newDriftCheck compares the existing resource, if any, with the fields the spec owns, if the scope is a
+// DriftReporter and the spec a DriftDetector. It returns nil otherwise.
+//
+// The differences are only drift if the spec is the one last applied to the resource: when the spec was changed
+// since then, they are the changes to apply. When the last applied spec isn't known, e.g. for resources created by an
+// older version of CAPZ, the differences are assumed to be drift.
+func newDriftCheck(scope FutureScope, spec azure.ResourceSpecGetter, existing interface{}, serviceName string) (*driftCheck, error) {
+	reporter, ok := scope.(DriftReporter)
+	if !ok {
+		return nil, nil
+	}
+	detector, ok := spec.(azure.DriftDetector)
+	if !ok {
+		return nil, nil
+	}
+
+	hash, err := specHash(spec)
+	if err != nil {
+		return nil, err
+	}
+	_, dryRun := dryRunner(scope)
+	c := &driftCheck{
+		reporter: reporter,
+		dryRun:   dryRun,
+		key:      strings.Join([]string{serviceName, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName()}, "/"),
+		specHash: hash,
+	}
+	if existing == nil {
+		return c, nil
+	}
+
+	fields, err := detector.Drift(existing)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return c, nil
+	}
+	if lastApplied := reporter.LastAppliedSpec(c.key); lastApplied != "" && lastApplied != hash {
+		return c, nil
+	}
+
+	c.drift = &azure.ResourceDrift{
+		Service:       serviceName,
+		ResourceGroup: spec.ResourceGroupName(),
+		Resource:      spec.ResourceName(),
+		Fields:        fields,
+	}
+	c.reportOnly = reporter.DriftPolicy() == infrav1.DriftPolicyReport
+	return c, nil
+}
+
+// isReportOnly returns true if the resource has drifted and the drift policy is to only report it, in which case the
+// resource must not be updated.
+func (c *driftCheck) isReportOnly() bool {
+	return c != nil && c.drift != nil && c.reportOnly
+}
+
+// record records the drift, if any, once it is known whether the update reverting it succeeded.
+func (c *driftCheck) record(remediated bool) {
+	if c == nil || c.drift == nil {
+		return
+	}
+	c.drift.Remediated = remediated
+	c.reporter.RecordDrift(*c.drift)
+}
+
+// applied records that the resource is up to date with the spec, so that later changes to the spec are not mistaken
+// for drift. Nothing is recorded in dry-run mode, as nothing is applied.
+func (c *driftCheck) applied() {
+	if c == nil || c.dryRun {
+		return
+	}
+	c.reporter.SetLastAppliedSpec(c.key, c.specHash)
+}
+
+// specHash returns a hash of the JSON representation of the spec.
+func specHash(spec azure.ResourceSpecGetter) (string, error) {
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(b)
+	return hex.EncodeToString(hash[:8]), nil
+}
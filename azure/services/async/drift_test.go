@@ -0,0 +1,192 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package async
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+// driftScope is a FutureScope which is also a DriftReporter.
+type driftScope struct {
+	*mock_async.MockFutureScope
+	*mock_async.MockDriftReporter
+}
+
+// driftDetectorSpec is a ResourceSpecGetter which is also a DriftDetector.
+type driftDetectorSpec struct {
+	*mock_azure.MockResourceSpecGetter
+	drift []string
+	// Rules is a field of the spec, whose changes change its hash.
+	Rules []string
+}
+
+func (s driftDetectorSpec) Drift(existing interface{}) ([]string, error) {
+	return s.drift, nil
+}
+
+func TestCreateOrUpdateResourceDrift(t *testing.T) {
+	existing := resources.GenericResource{Location: to.StringPtr("westus")}
+	key := "test-service/test-group//test-resource"
+	specHashOf := func(rules ...string) string {
+		hash, err := specHash(driftDetectorSpec{Rules: rules})
+		if err != nil {
+			panic(err)
+		}
+		return hash
+	}
+	testcases := []struct {
+		name           string
+		drift          []string
+		expectedResult interface{}
+		expectedError  string
+		expect         func(s *mock_async.MockFutureScopeMockRecorder, d *mock_async.MockDriftReporterMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder)
+	}{
+		{
+			name:           "drift is remediated",
+			drift:          []string{"properties.networkSecurityGroup.id"},
+			expectedResult: &fakeExistingResource,
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, d *mock_async.MockDriftReporterMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.Any()).Return(existing, nil)
+				d.LastAppliedSpec(key).Return(specHashOf("rule"))
+				d.DriftPolicy().Return(infrav1.DriftPolicyRemediate)
+				r.Parameters(existing).Return(&fakeResourceParameters, nil)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.Any(), &fakeResourceParameters).Return(&fakeExistingResource, nil, nil)
+				d.RecordDrift(azure.ResourceDrift{
+					Service:       "test-service",
+					ResourceGroup: "test-group",
+					Resource:      "test-resource",
+					Fields:        []string{"properties.networkSecurityGroup.id"},
+					Remediated:    true,
+				})
+				d.SetLastAppliedSpec(key, specHashOf("rule"))
+			},
+		},
+		{
+			name:          "drift is not remediated when the update fails",
+			drift:         []string{"properties.networkSecurityGroup.id"},
+			expectedError: "failed to update resource test-group/test-resource (service: test-service): #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, d *mock_async.MockDriftReporterMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.Any()).Return(existing, nil)
+				d.LastAppliedSpec(key).Return("")
+				d.DriftPolicy().Return(infrav1.DriftPolicyRemediate)
+				r.Parameters(existing).Return(&fakeResourceParameters, nil)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.Any(), &fakeResourceParameters).Return(nil, nil, fakeInternalError)
+				d.RecordDrift(azure.ResourceDrift{
+					Service:       "test-service",
+					ResourceGroup: "test-group",
+					Resource:      "test-resource",
+					Fields:        []string{"properties.networkSecurityGroup.id"},
+				})
+			},
+		},
+		{
+			name:           "drift is only reported",
+			drift:          []string{"properties.networkSecurityGroup.id"},
+			expectedResult: existing,
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, d *mock_async.MockDriftReporterMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.Any()).Return(existing, nil)
+				d.LastAppliedSpec(key).Return("")
+				d.DriftPolicy().Return(infrav1.DriftPolicyReport)
+				d.RecordDrift(azure.ResourceDrift{
+					Service:       "test-service",
+					ResourceGroup: "test-group",
+					Resource:      "test-resource",
+					Fields:        []string{"properties.networkSecurityGroup.id"},
+				})
+				d.SetLastAppliedSpec(key, specHashOf("rule"))
+			},
+		},
+		{
+			name:           "spec changes are applied when drift is only reported",
+			drift:          []string{"properties.networkSecurityGroup.id"},
+			expectedResult: &fakeExistingResource,
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, d *mock_async.MockDriftReporterMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.Any()).Return(existing, nil)
+				d.LastAppliedSpec(key).Return(specHashOf("old-rule"))
+				r.Parameters(existing).Return(&fakeResourceParameters, nil)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.Any(), &fakeResourceParameters).Return(&fakeExistingResource, nil, nil)
+				d.SetLastAppliedSpec(key, specHashOf("rule"))
+			},
+		},
+		{
+			name:           "no drift",
+			expectedResult: existing,
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, d *mock_async.MockDriftReporterMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.Any()).Return(existing, nil)
+				r.Parameters(existing).Return(nil, nil)
+				d.SetLastAppliedSpec(key, specHashOf("rule"))
+			},
+		},
+		{
+			name:           "spec of a new resource is recorded as applied",
+			expectedResult: &fakeExistingResource,
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, d *mock_async.MockDriftReporterMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.Any()).Return(nil, fakeNotFoundError)
+				r.Parameters(nil).Return(&fakeResourceParameters, nil)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.Any(), &fakeResourceParameters).Return(&fakeExistingResource, nil, nil)
+				d.SetLastAppliedSpec(key, specHashOf("rule"))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_async.NewMockFutureScope(mockCtrl)
+			reporterMock := mock_async.NewMockDriftReporter(mockCtrl)
+			creatorMock := mock_async.NewMockCreator(mockCtrl)
+			specMock := mock_azure.NewMockResourceSpecGetter(mockCtrl)
+
+			specMock.EXPECT().ResourceName().Return("test-resource").AnyTimes()
+			specMock.EXPECT().ResourceGroupName().Return("test-group").AnyTimes()
+			specMock.EXPECT().OwnerResourceName().Return("").AnyTimes()
+			tc.expect(scopeMock.EXPECT(), reporterMock.EXPECT(), creatorMock.EXPECT(), specMock.EXPECT())
+
+			s := New(driftScope{scopeMock, reporterMock}, creatorMock, nil)
+			result, err := s.CreateOrUpdateResource(context.TODO(), driftDetectorSpec{specMock, tc.drift, []string{"rule"}}, "test-service")
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(result).To(Equal(tc.expectedResult))
+			}
+		})
+	}
+}
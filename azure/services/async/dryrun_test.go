@@ -0,0 +1,165 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package async
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+// dryRunScope is a FutureScope which is also a DryRunner.
+type dryRunScope struct {
+	*mock_async.MockFutureScope
+	*mock_async.MockDryRunner
+}
+
+func TestCreateOrUpdateResourceDryRun(t *testing.T) {
+	existing := resources.GenericResource{Location: to.StringPtr("westus")}
+	testcases := []struct {
+		name           string
+		expectedError  string
+		expectedResult interface{}
+		expect         func(s *mock_async.MockFutureScopeMockRecorder, d *mock_async.MockDryRunnerMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder)
+	}{
+		{
+			name:          "resource is not created",
+			expectedError: "create of Azure resource test-group/test-resource was not applied in dry-run mode. Object will be requeued after 15s",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, d *mock_async.MockDryRunnerMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				r.ResourceName().Return("test-resource")
+				r.ResourceGroupName().Return("test-group")
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(nil, fakeNotFoundError)
+				r.Parameters(nil).Return(&fakeResourceParameters, nil)
+				d.IsDryRun().Return(true)
+				d.RecordPlannedChange(azure.PlannedChange{
+					Service:       "test-service",
+					ResourceGroup: "test-group",
+					Resource:      "test-resource",
+					Action:        azure.PlannedCreate,
+				})
+			},
+		},
+		{
+			name:           "resource is not updated",
+			expectedResult: existing,
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, d *mock_async.MockDryRunnerMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				r.ResourceName().Return("test-resource")
+				r.ResourceGroupName().Return("test-group")
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(existing, nil)
+				r.Parameters(existing).Return(resources.GenericResource{Location: to.StringPtr("eastus")}, nil)
+				d.IsDryRun().Return(true)
+				d.RecordPlannedChange(azure.PlannedChange{
+					Service:       "test-service",
+					ResourceGroup: "test-group",
+					Resource:      "test-resource",
+					Action:        azure.PlannedUpdate,
+					ChangedFields: []string{"location"},
+				})
+			},
+		},
+		{
+			name:           "update without changed fields is not recorded",
+			expectedResult: existing,
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, d *mock_async.MockDryRunnerMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				r.ResourceName().Return("test-resource")
+				r.ResourceGroupName().Return("test-group")
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(existing, nil)
+				r.Parameters(existing).Return(existing, nil)
+				d.IsDryRun().Return(true)
+			},
+		},
+		{
+			name:           "resource is updated when not in dry-run mode",
+			expectedResult: &fakeExistingResource,
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, d *mock_async.MockDryRunnerMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				r.ResourceName().Return("test-resource")
+				r.ResourceGroupName().Return("test-group")
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(existing, nil)
+				r.Parameters(existing).Return(&fakeResourceParameters, nil)
+				d.IsDryRun().Return(false)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{}), &fakeResourceParameters).Return(&fakeExistingResource, nil, nil)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_async.NewMockFutureScope(mockCtrl)
+			dryRunnerMock := mock_async.NewMockDryRunner(mockCtrl)
+			creatorMock := mock_async.NewMockCreator(mockCtrl)
+			specMock := mock_azure.NewMockResourceSpecGetter(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), dryRunnerMock.EXPECT(), creatorMock.EXPECT(), specMock.EXPECT())
+
+			s := New(dryRunScope{scopeMock, dryRunnerMock}, creatorMock, nil)
+			result, err := s.CreateOrUpdateResource(context.TODO(), specMock, "test-service")
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+				g.Expect(azure.IsDryRunError(err)).To(BeTrue())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(result).To(Equal(tc.expectedResult))
+			}
+		})
+	}
+}
+
+func TestDeleteResourceDryRun(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scopeMock := mock_async.NewMockFutureScope(mockCtrl)
+	dryRunnerMock := mock_async.NewMockDryRunner(mockCtrl)
+	deleterMock := mock_async.NewMockDeleter(mockCtrl)
+	specMock := mock_azure.NewMockResourceSpecGetter(mockCtrl)
+
+	specMock.EXPECT().ResourceName().Return("test-resource")
+	specMock.EXPECT().ResourceGroupName().Return("test-group")
+	scopeMock.EXPECT().GetLongRunningOperationState("test-resource", "test-service", infrav1.DeleteFuture).Return(nil)
+	dryRunnerMock.EXPECT().IsDryRun().Return(true)
+	dryRunnerMock.EXPECT().RecordPlannedChange(azure.PlannedChange{
+		Service:       "test-service",
+		ResourceGroup: "test-group",
+		Resource:      "test-resource",
+		Action:        azure.PlannedDelete,
+	})
+
+	s := New(dryRunScope{scopeMock, dryRunnerMock}, nil, deleterMock)
+	err := s.DeleteResource(context.TODO(), specMock, "test-service")
+	g.Expect(err).To(MatchError("delete of Azure resource test-group/test-resource was not applied in dry-run mode. Object will be requeued after 15s"))
+	g.Expect(azure.IsDryRunError(err)).To(BeTrue())
+}
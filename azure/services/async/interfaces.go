@@ -20,6 +20,7 @@ import (
 	"context"
 
 	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 )
 
@@ -28,6 +29,24 @@ type FutureScope interface {
 	azure.AsyncStatusUpdater
 }
 
+// DryRunner is a scope that can compute the changes to Azure resources without applying them.
+type DryRunner interface {
+	IsDryRun() bool
+	RecordPlannedChange(azure.PlannedChange)
+	PlannedChanges() []azure.PlannedChange
+}
+
+// DriftReporter is a scope that records the changes made outside of CAPZ to the Azure resources it manages.
+type DriftReporter interface {
+	DriftPolicy() infrav1.DriftPolicy
+	RecordDrift(azure.ResourceDrift)
+	Drifts() []azure.ResourceDrift
+	// LastAppliedSpec returns the hash of the spec last applied to the resource with the given key, or "" if unknown.
+	LastAppliedSpec(key string) string
+	// SetLastAppliedSpec records the hash of the spec last applied to the resource with the given key.
+	SetLastAppliedSpec(key, hash string)
+}
+
 // FutureHandler is a client that can check on the progress of a future.
 type FutureHandler interface {
 	// IsDone returns true if the operation is complete.
@@ -128,6 +128,158 @@ func (mr *MockFutureScopeMockRecorder) UpdatePutStatus(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePutStatus", reflect.TypeOf((*MockFutureScope)(nil).UpdatePutStatus), arg0, arg1, arg2)
 }
 
+// MockDryRunner is a mock of DryRunner interface.
+type MockDryRunner struct {
+	ctrl     *gomock.Controller
+	recorder *MockDryRunnerMockRecorder
+}
+
+// MockDryRunnerMockRecorder is the mock recorder for MockDryRunner.
+type MockDryRunnerMockRecorder struct {
+	mock *MockDryRunner
+}
+
+// NewMockDryRunner creates a new mock instance.
+func NewMockDryRunner(ctrl *gomock.Controller) *MockDryRunner {
+	mock := &MockDryRunner{ctrl: ctrl}
+	mock.recorder = &MockDryRunnerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDryRunner) EXPECT() *MockDryRunnerMockRecorder {
+	return m.recorder
+}
+
+// IsDryRun mocks base method.
+func (m *MockDryRunner) IsDryRun() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsDryRun")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsDryRun indicates an expected call of IsDryRun.
+func (mr *MockDryRunnerMockRecorder) IsDryRun() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDryRun", reflect.TypeOf((*MockDryRunner)(nil).IsDryRun))
+}
+
+// PlannedChanges mocks base method.
+func (m *MockDryRunner) PlannedChanges() []azure0.PlannedChange {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlannedChanges")
+	ret0, _ := ret[0].([]azure0.PlannedChange)
+	return ret0
+}
+
+// PlannedChanges indicates an expected call of PlannedChanges.
+func (mr *MockDryRunnerMockRecorder) PlannedChanges() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlannedChanges", reflect.TypeOf((*MockDryRunner)(nil).PlannedChanges))
+}
+
+// RecordPlannedChange mocks base method.
+func (m *MockDryRunner) RecordPlannedChange(arg0 azure0.PlannedChange) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordPlannedChange", arg0)
+}
+
+// RecordPlannedChange indicates an expected call of RecordPlannedChange.
+func (mr *MockDryRunnerMockRecorder) RecordPlannedChange(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordPlannedChange", reflect.TypeOf((*MockDryRunner)(nil).RecordPlannedChange), arg0)
+}
+
+// MockDriftReporter is a mock of DriftReporter interface.
+type MockDriftReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockDriftReporterMockRecorder
+}
+
+// MockDriftReporterMockRecorder is the mock recorder for MockDriftReporter.
+type MockDriftReporterMockRecorder struct {
+	mock *MockDriftReporter
+}
+
+// NewMockDriftReporter creates a new mock instance.
+func NewMockDriftReporter(ctrl *gomock.Controller) *MockDriftReporter {
+	mock := &MockDriftReporter{ctrl: ctrl}
+	mock.recorder = &MockDriftReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDriftReporter) EXPECT() *MockDriftReporterMockRecorder {
+	return m.recorder
+}
+
+// DriftPolicy mocks base method.
+func (m *MockDriftReporter) DriftPolicy() v1beta1.DriftPolicy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DriftPolicy")
+	ret0, _ := ret[0].(v1beta1.DriftPolicy)
+	return ret0
+}
+
+// DriftPolicy indicates an expected call of DriftPolicy.
+func (mr *MockDriftReporterMockRecorder) DriftPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DriftPolicy", reflect.TypeOf((*MockDriftReporter)(nil).DriftPolicy))
+}
+
+// Drifts mocks base method.
+func (m *MockDriftReporter) Drifts() []azure0.ResourceDrift {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Drifts")
+	ret0, _ := ret[0].([]azure0.ResourceDrift)
+	return ret0
+}
+
+// Drifts indicates an expected call of Drifts.
+func (mr *MockDriftReporterMockRecorder) Drifts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Drifts", reflect.TypeOf((*MockDriftReporter)(nil).Drifts))
+}
+
+// LastAppliedSpec mocks base method.
+func (m *MockDriftReporter) LastAppliedSpec(key string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastAppliedSpec", key)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// LastAppliedSpec indicates an expected call of LastAppliedSpec.
+func (mr *MockDriftReporterMockRecorder) LastAppliedSpec(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastAppliedSpec", reflect.TypeOf((*MockDriftReporter)(nil).LastAppliedSpec), key)
+}
+
+// RecordDrift mocks base method.
+func (m *MockDriftReporter) RecordDrift(arg0 azure0.ResourceDrift) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordDrift", arg0)
+}
+
+// RecordDrift indicates an expected call of RecordDrift.
+func (mr *MockDriftReporterMockRecorder) RecordDrift(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordDrift", reflect.TypeOf((*MockDriftReporter)(nil).RecordDrift), arg0)
+}
+
+// SetLastAppliedSpec mocks base method.
+func (m *MockDriftReporter) SetLastAppliedSpec(key, hash string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetLastAppliedSpec", key, hash)
+}
+
+// SetLastAppliedSpec indicates an expected call of SetLastAppliedSpec.
+func (mr *MockDriftReporterMockRecorder) SetLastAppliedSpec(key, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLastAppliedSpec", reflect.TypeOf((*MockDriftReporter)(nil).SetLastAppliedSpec), key, hash)
+}
+
 // MockFutureHandler is a mock of FutureHandler interface.
 type MockFutureHandler struct {
 	ctrl     *gomock.Controller
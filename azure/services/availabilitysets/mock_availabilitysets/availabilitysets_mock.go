@@ -205,6 +205,20 @@ func (mr *MockAvailabilitySetScopeMockRecorder) DeleteLongRunningOperationState(
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLongRunningOperationState", reflect.TypeOf((*MockAvailabilitySetScope)(nil).DeleteLongRunningOperationState), arg0, arg1, arg2)
 }
 
+// DriftPolicy mocks base method.
+func (m *MockAvailabilitySetScope) DriftPolicy() v1beta1.DriftPolicy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DriftPolicy")
+	ret0, _ := ret[0].(v1beta1.DriftPolicy)
+	return ret0
+}
+
+// DriftPolicy indicates an expected call of DriftPolicy.
+func (mr *MockAvailabilitySetScopeMockRecorder) DriftPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DriftPolicy", reflect.TypeOf((*MockAvailabilitySetScope)(nil).DriftPolicy))
+}
+
 // FailureDomains mocks base method.
 func (m *MockAvailabilitySetScope) FailureDomains() []string {
 	m.ctrl.T.Helper()
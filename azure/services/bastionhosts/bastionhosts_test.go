@@ -60,8 +60,8 @@ func TestReconcileBastionHosts(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_bastionhosts.MockBastionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.AzureBastionSpec().Return(&fakeAzureBastionSpec)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeAzureBastionSpec, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.BastionHostReadyCondition, serviceName, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeAzureBastionSpec, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.BastionHostReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -76,8 +76,8 @@ func TestReconcileBastionHosts(t *testing.T) {
 			expectedError: internalError.Error(),
 			expect: func(s *mock_bastionhosts.MockBastionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.AzureBastionSpec().Return(&fakeAzureBastionSpec)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeAzureBastionSpec, serviceName).Return(nil, internalError)
-				s.UpdatePutStatus(infrav1.BastionHostReadyCondition, serviceName, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeAzureBastionSpec, ServiceName).Return(nil, internalError)
+				s.UpdatePutStatus(infrav1.BastionHostReadyCondition, ServiceName, internalError)
 			},
 		},
 	}
@@ -121,8 +121,8 @@ func TestDeleteBastionHost(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_bastionhosts.MockBastionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.AzureBastionSpec().Return(&fakeAzureBastionSpec)
-				r.DeleteResource(gomockinternal.AContext(), &fakeAzureBastionSpec, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.BastionHostReadyCondition, serviceName, nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeAzureBastionSpec, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.BastionHostReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -130,8 +130,8 @@ func TestDeleteBastionHost(t *testing.T) {
 			expectedError: internalError.Error(),
 			expect: func(s *mock_bastionhosts.MockBastionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.AzureBastionSpec().Return(&fakeAzureBastionSpec)
-				r.DeleteResource(gomockinternal.AContext(), &fakeAzureBastionSpec, serviceName).Return(internalError)
-				s.UpdateDeleteStatus(infrav1.BastionHostReadyCondition, serviceName, internalError)
+				r.DeleteResource(gomockinternal.AContext(), &fakeAzureBastionSpec, ServiceName).Return(internalError)
+				s.UpdateDeleteStatus(infrav1.BastionHostReadyCondition, ServiceName, internalError)
 			},
 		},
 		{
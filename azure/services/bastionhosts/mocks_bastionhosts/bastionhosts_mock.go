@@ -275,6 +275,20 @@ func (mr *MockBastionScopeMockRecorder) DeleteLongRunningOperationState(arg0, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLongRunningOperationState", reflect.TypeOf((*MockBastionScope)(nil).DeleteLongRunningOperationState), arg0, arg1, arg2)
 }
 
+// DriftPolicy mocks base method.
+func (m *MockBastionScope) DriftPolicy() v1beta1.DriftPolicy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DriftPolicy")
+	ret0, _ := ret[0].(v1beta1.DriftPolicy)
+	return ret0
+}
+
+// DriftPolicy indicates an expected call of DriftPolicy.
+func (mr *MockBastionScopeMockRecorder) DriftPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DriftPolicy", reflect.TypeOf((*MockBastionScope)(nil).DriftPolicy))
+}
+
 // FailureDomains mocks base method.
 func (m *MockBastionScope) FailureDomains() []string {
 	m.ctrl.T.Helper()
@@ -572,3 +586,17 @@ func (mr *MockBastionScopeMockRecorder) Vnet() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Vnet", reflect.TypeOf((*MockBastionScope)(nil).Vnet))
 }
+
+// VnetSubscriptionID mocks base method.
+func (m *MockBastionScope) VnetSubscriptionID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VnetSubscriptionID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// VnetSubscriptionID indicates an expected call of VnetSubscriptionID.
+func (mr *MockBastionScopeMockRecorder) VnetSubscriptionID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VnetSubscriptionID", reflect.TypeOf((*MockBastionScope)(nil).VnetSubscriptionID))
+}
@@ -33,6 +33,7 @@ type DiskScope interface {
 	azure.ClusterDescriber
 	azure.AsyncStatusUpdater
 	DiskSpecs() []azure.ResourceSpecGetter
+	DataDiskSpecs() []azure.ResourceSpecGetter
 }
 
 // Service provides operations on Azure resources.
@@ -46,7 +47,7 @@ func New(scope DiskScope) *Service {
 	client := newClient(scope)
 	return &Service{
 		Scope:      scope,
-		Reconciler: async.New(scope, nil, client),
+		Reconciler: async.New(scope, client, client),
 	}
 }
 
@@ -55,16 +56,40 @@ func (s *Service) Name() string {
 	return serviceName
 }
 
-// Reconcile on disk is currently no-op. OS disks should only be deleted and will create with the VM automatically.
+// Reconcile creates the data disks which must exist before the VM they are attached to, i.e. the disks
+// with provisioned performance settings, and grows existing data disks. OS disks and all other data disks
+// are created with the VM.
 func (s *Service) Reconcile(ctx context.Context) error {
-	_, _, done := tele.StartSpanWithLogger(ctx, "disks.Service.Reconcile")
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "disks.Service.Reconcile")
 	defer done()
 
-	// DisksReadyCondition is set in the VM service.
-	return nil
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	specs := s.Scope.DataDiskSpecs()
+	if len(specs) == 0 {
+		// DisksReadyCondition is set in the VM service.
+		return nil
+	}
+
+	// We go through the list of DataDiskSpecs to reconcile each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
+	var result error
+	for _, diskSpec := range specs {
+		if _, err := s.CreateOrUpdateResource(ctx, diskSpec, serviceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || result == nil {
+				result = err
+			}
+		}
+	}
+
+	s.Scope.UpdatePutStatus(infrav1.DisksReadyCondition, serviceName, result)
+	return result
 }
 
-// Delete deletes the disk associated with a VM.
+// Delete deletes the disks associated with a VM. Data disks with a Detach delete policy are kept, and data disks
+// with a Retain delete policy are kept and released from the cluster.
 func (s *Service) Delete(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "disks.Service.Delete")
 	defer done()
@@ -82,7 +107,16 @@ func (s *Service) Delete(ctx context.Context) error {
 	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
 	var result error
 	for _, diskSpec := range specs {
-		if err := s.DeleteResource(ctx, diskSpec, serviceName); err != nil {
+		var err error
+		switch deletePolicy(diskSpec) {
+		case infrav1.DiskDeletePolicyDetach:
+			continue
+		case infrav1.DiskDeletePolicyRetain:
+			_, err = s.CreateOrUpdateResource(ctx, &releasedDiskSpec{diskSpec.(*DiskSpec)}, serviceName)
+		default:
+			err = s.DeleteResource(ctx, diskSpec, serviceName)
+		}
+		if err != nil {
 			if !azure.IsOperationNotDoneError(err) || result == nil {
 				result = err
 			}
@@ -92,6 +126,14 @@ func (s *Service) Delete(ctx context.Context) error {
 	return result
 }
 
+// deletePolicy returns the delete policy of a disk spec. Disks are deleted unless their spec says otherwise.
+func deletePolicy(spec azure.ResourceSpecGetter) infrav1.DiskDeletePolicy {
+	if diskSpec, ok := spec.(*DiskSpec); ok && diskSpec.DeletePolicy != "" {
+		return diskSpec.DeletePolicy
+	}
+	return infrav1.DiskDeletePolicyDelete
+}
+
 // IsManaged returns always returns true as CAPZ does not support BYO disk.
 func (s *Service) IsManaged(ctx context.Context) (bool, error) {
 	return true, nil
@@ -42,6 +42,20 @@ var (
 		ResourceGroup: "my-group",
 	}
 
+	detachedDiskSpec = DiskSpec{
+		Name:          "my-disk-3",
+		ResourceGroup: "my-group",
+		ClusterName:   "my-cluster",
+		DeletePolicy:  infrav1.DiskDeletePolicyDetach,
+	}
+
+	retainedDiskSpec = DiskSpec{
+		Name:          "my-disk-4",
+		ResourceGroup: "my-group",
+		ClusterName:   "my-cluster",
+		DeletePolicy:  infrav1.DiskDeletePolicyRetain,
+	}
+
 	fakeDiskSpecs = []azure.ResourceSpecGetter{
 		&diskSpec1,
 		&diskSpec2,
@@ -50,6 +64,74 @@ var (
 	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error")
 )
 
+func TestReconcileDisks(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_disks.MockDiskScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if no data disk specs are found",
+			expectedError: "",
+			expect: func(s *mock_disks.MockDiskScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DataDiskSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "create the disks",
+			expectedError: "",
+			expect: func(s *mock_disks.MockDiskScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DataDiskSpecs().Return(fakeDiskSpecs)
+				gomock.InOrder(
+					r.CreateOrUpdateResource(gomockinternal.AContext(), &diskSpec1, serviceName).Return(nil, nil),
+					r.CreateOrUpdateResource(gomockinternal.AContext(), &diskSpec2, serviceName).Return(nil, nil),
+					s.UpdatePutStatus(infrav1.DisksReadyCondition, serviceName, nil),
+				)
+			},
+		},
+		{
+			name:          "error while trying to create the disk",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_disks.MockDiskScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DataDiskSpecs().Return(fakeDiskSpecs)
+				gomock.InOrder(
+					r.CreateOrUpdateResource(gomockinternal.AContext(), &diskSpec1, serviceName).Return(nil, internalError),
+					r.CreateOrUpdateResource(gomockinternal.AContext(), &diskSpec2, serviceName).Return(nil, nil),
+					s.UpdatePutStatus(infrav1.DisksReadyCondition, serviceName, internalError),
+				)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_disks.NewMockDiskScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestDeleteDisk(t *testing.T) {
 	testcases := []struct {
 		name          string
@@ -87,6 +169,30 @@ func TestDeleteDisk(t *testing.T) {
 				)
 			},
 		},
+		{
+			name:          "keep the disks with a detach or retain delete policy",
+			expectedError: "",
+			expect: func(s *mock_disks.MockDiskScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DiskSpecs().Return([]azure.ResourceSpecGetter{&diskSpec1, &detachedDiskSpec, &retainedDiskSpec})
+				gomock.InOrder(
+					r.DeleteResource(gomockinternal.AContext(), &diskSpec1, serviceName).Return(nil),
+					r.CreateOrUpdateResource(gomockinternal.AContext(), &releasedDiskSpec{&retainedDiskSpec}, serviceName).Return(nil, nil),
+					s.UpdateDeleteStatus(infrav1.DisksReadyCondition, serviceName, nil),
+				)
+			},
+		},
+		{
+			name:          "error while trying to release a retained disk",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_disks.MockDiskScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DiskSpecs().Return([]azure.ResourceSpecGetter{&retainedDiskSpec, &diskSpec2})
+				gomock.InOrder(
+					r.CreateOrUpdateResource(gomockinternal.AContext(), &releasedDiskSpec{&retainedDiskSpec}, serviceName).Return(nil, internalError),
+					r.DeleteResource(gomockinternal.AContext(), &diskSpec2, serviceName).Return(nil),
+					s.UpdateDeleteStatus(infrav1.DisksReadyCondition, serviceName, internalError),
+				)
+			},
+		},
 		{
 			name:          "error while trying to delete the disk",
 			expectedError: "#: Internal Server Error: StatusCode=500",
@@ -179,6 +179,20 @@ func (mr *MockDiskScopeMockRecorder) ClusterName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterName", reflect.TypeOf((*MockDiskScope)(nil).ClusterName))
 }
 
+// DataDiskSpecs mocks base method.
+func (m *MockDiskScope) DataDiskSpecs() []azure.ResourceSpecGetter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DataDiskSpecs")
+	ret0, _ := ret[0].([]azure.ResourceSpecGetter)
+	return ret0
+}
+
+// DataDiskSpecs indicates an expected call of DataDiskSpecs.
+func (mr *MockDiskScopeMockRecorder) DataDiskSpecs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DataDiskSpecs", reflect.TypeOf((*MockDiskScope)(nil).DataDiskSpecs))
+}
+
 // DeleteLongRunningOperationState mocks base method.
 func (m *MockDiskScope) DeleteLongRunningOperationState(arg0, arg1, arg2 string) {
 	m.ctrl.T.Helper()
@@ -205,6 +219,20 @@ func (mr *MockDiskScopeMockRecorder) DiskSpecs() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiskSpecs", reflect.TypeOf((*MockDiskScope)(nil).DiskSpecs))
 }
 
+// DriftPolicy mocks base method.
+func (m *MockDiskScope) DriftPolicy() v1beta1.DriftPolicy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DriftPolicy")
+	ret0, _ := ret[0].(v1beta1.DriftPolicy)
+	return ret0
+}
+
+// DriftPolicy indicates an expected call of DriftPolicy.
+func (mr *MockDiskScopeMockRecorder) DriftPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DriftPolicy", reflect.TypeOf((*MockDiskScope)(nil).DriftPolicy))
+}
+
 // FailureDomains mocks base method.
 func (m *MockDiskScope) FailureDomains() []string {
 	m.ctrl.T.Helper()
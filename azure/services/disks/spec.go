@@ -16,10 +16,32 @@ limitations under the License.
 
 package disks
 
+import (
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
 // DiskSpec defines the specification for a disk.
+// Only disks with a size which are not created together with the VM are created by the disks service.
+// Existing disks with a size are grown to that size.
 type DiskSpec struct {
-	Name          string
-	ResourceGroup string
+	Name                string
+	ResourceGroup       string
+	Location            string
+	Zone                string
+	ClusterName         string
+	AdditionalTags      infrav1.Tags
+	SizeGB              int32
+	CreateWithVM        bool
+	StorageAccountType  string
+	DiskEncryptionSetID string
+	DiskIOPSReadWrite   *int64
+	DiskMBpsReadWrite   *int64
+	LogicalSectorSize   *int32
+	DeletePolicy        infrav1.DiskDeletePolicy
 }
 
 // ResourceName returns the name of the disk.
@@ -37,7 +59,96 @@ func (s *DiskSpec) OwnerResourceName() string {
 	return ""
 }
 
-// Parameters is a no-op for disks.
+// Parameters returns the parameters for the disk.
 func (s *DiskSpec) Parameters(existing interface{}) (params interface{}, err error) {
-	return nil, nil
+	if existing != nil {
+		existingDisk, ok := existing.(compute.Disk)
+		if !ok {
+			return nil, errors.Errorf("%T is not a compute.Disk", existing)
+		}
+
+		// disks can only be grown
+		if existingDisk.DiskProperties == nil || existingDisk.DiskSizeGB == nil || s.SizeGB <= *existingDisk.DiskSizeGB {
+			// disk already exists with the desired size
+			return nil, nil
+		}
+
+		existingDisk.DiskSizeGB = to.Int32Ptr(s.SizeGB)
+		return existingDisk, nil
+	}
+
+	if s.SizeGB == 0 || s.CreateWithVM {
+		// the disk is created together with the VM
+		return nil, nil
+	}
+
+	disk := compute.Disk{
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        to.StringPtr(s.Name),
+			Additional:  s.AdditionalTags,
+		})),
+		Name:     to.StringPtr(s.Name),
+		Location: to.StringPtr(s.Location),
+		Sku: &compute.DiskSku{
+			Name: compute.DiskStorageAccountTypes(s.StorageAccountType),
+		},
+		DiskProperties: &compute.DiskProperties{
+			CreationData: &compute.CreationData{
+				CreateOption:      compute.DiskCreateOptionEmpty,
+				LogicalSectorSize: s.LogicalSectorSize,
+			},
+			DiskSizeGB:        to.Int32Ptr(s.SizeGB),
+			DiskIOPSReadWrite: s.DiskIOPSReadWrite,
+			DiskMBpsReadWrite: s.DiskMBpsReadWrite,
+		},
+	}
+
+	if s.Zone != "" {
+		disk.Zones = &[]string{s.Zone}
+	}
+
+	if s.DiskEncryptionSetID != "" {
+		disk.DiskProperties.Encryption = &compute.Encryption{
+			DiskEncryptionSetID: to.StringPtr(s.DiskEncryptionSetID),
+			Type:                compute.EncryptionTypeEncryptionAtRestWithCustomerKey,
+		}
+	}
+
+	return disk, nil
+}
+
+// releasedDiskSpec is the specification of a disk which is kept after its machine is deleted and
+// no longer belongs to the cluster.
+type releasedDiskSpec struct {
+	*DiskSpec
+}
+
+// Parameters returns the existing disk without the cluster ownership tag, or nil if the disk
+// does not exist or is not owned by the cluster.
+func (s *releasedDiskSpec) Parameters(existing interface{}) (params interface{}, err error) {
+	if existing == nil {
+		return nil, nil
+	}
+
+	existingDisk, ok := existing.(compute.Disk)
+	if !ok {
+		return nil, errors.Errorf("%T is not a compute.Disk", existing)
+	}
+
+	ownedTag := infrav1.ClusterTagKey(s.ClusterName)
+	if _, ok := existingDisk.Tags[ownedTag]; !ok {
+		// disk is already released
+		return nil, nil
+	}
+
+	tags := make(map[string]*string, len(existingDisk.Tags))
+	for k, v := range existingDisk.Tags {
+		if k != ownedTag {
+			tags[k] = v
+		}
+	}
+	existingDisk.Tags = tags
+	return existingDisk, nil
 }
@@ -0,0 +1,265 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package disks
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+var (
+	fakeUltraDiskSpec = DiskSpec{
+		Name:               "my-vm_etcddisk",
+		ResourceGroup:      "my-rg",
+		Location:           "westus3",
+		Zone:               "1",
+		ClusterName:        "my-cluster",
+		AdditionalTags:     infrav1.Tags{"foo": "bar"},
+		SizeGB:             256,
+		StorageAccountType: "UltraSSD_LRS",
+		DiskIOPSReadWrite:  to.Int64Ptr(10000),
+		DiskMBpsReadWrite:  to.Int64Ptr(400),
+		LogicalSectorSize:  to.Int32Ptr(4096),
+	}
+
+	fakeUltraDiskSpecWithDiskEncryptionSet = DiskSpec{
+		Name:                "my-vm_etcddisk",
+		ResourceGroup:       "my-rg",
+		Location:            "westus3",
+		ClusterName:         "my-cluster",
+		SizeGB:              256,
+		StorageAccountType:  "UltraSSD_LRS",
+		DiskEncryptionSetID: "my-des-id",
+		DiskIOPSReadWrite:   to.Int64Ptr(10000),
+	}
+)
+
+func TestParameters(t *testing.T) {
+	testCases := []struct {
+		name          string
+		spec          DiskSpec
+		existing      interface{}
+		expected      interface{}
+		expectedError string
+	}{
+		{
+			name:          "disk already exists",
+			spec:          fakeUltraDiskSpec,
+			existing:      compute.Disk{Name: to.StringPtr("my-vm_etcddisk")},
+			expected:      nil,
+			expectedError: "",
+		},
+		{
+			name: "grow an existing disk",
+			spec: fakeUltraDiskSpec,
+			existing: compute.Disk{
+				Name:           to.StringPtr("my-vm_etcddisk"),
+				DiskProperties: &compute.DiskProperties{DiskSizeGB: to.Int32Ptr(128)},
+			},
+			expected: compute.Disk{
+				Name:           to.StringPtr("my-vm_etcddisk"),
+				DiskProperties: &compute.DiskProperties{DiskSizeGB: to.Int32Ptr(256)},
+			},
+			expectedError: "",
+		},
+		{
+			name: "do not shrink an existing disk",
+			spec: fakeUltraDiskSpec,
+			existing: compute.Disk{
+				Name:           to.StringPtr("my-vm_etcddisk"),
+				DiskProperties: &compute.DiskProperties{DiskSizeGB: to.Int32Ptr(512)},
+			},
+			expected:      nil,
+			expectedError: "",
+		},
+		{
+			name:          "existing is not a disk",
+			spec:          fakeUltraDiskSpec,
+			existing:      struct{}{},
+			expected:      nil,
+			expectedError: "struct {} is not a compute.Disk",
+		},
+		{
+			name:          "disk created together with the VM",
+			spec:          diskSpec1,
+			existing:      nil,
+			expected:      nil,
+			expectedError: "",
+		},
+		{
+			name: "data disk created together with the VM",
+			spec: DiskSpec{
+				Name:          "my-vm_datadisk",
+				ResourceGroup: "my-rg",
+				SizeGB:        128,
+				CreateWithVM:  true,
+			},
+			existing:      nil,
+			expected:      nil,
+			expectedError: "",
+		},
+		{
+			name:     "ultra disk with provisioned performance",
+			spec:     fakeUltraDiskSpec,
+			existing: nil,
+			expected: compute.Disk{
+				Name:     to.StringPtr("my-vm_etcddisk"),
+				Location: to.StringPtr("westus3"),
+				Zones:    &[]string{"1"},
+				Tags: map[string]*string{
+					"Name": to.StringPtr("my-vm_etcddisk"),
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+					"foo": to.StringPtr("bar"),
+				},
+				Sku: &compute.DiskSku{Name: compute.DiskStorageAccountTypesUltraSSDLRS},
+				DiskProperties: &compute.DiskProperties{
+					CreationData: &compute.CreationData{
+						CreateOption:      compute.DiskCreateOptionEmpty,
+						LogicalSectorSize: to.Int32Ptr(4096),
+					},
+					DiskSizeGB:        to.Int32Ptr(256),
+					DiskIOPSReadWrite: to.Int64Ptr(10000),
+					DiskMBpsReadWrite: to.Int64Ptr(400),
+				},
+			},
+			expectedError: "",
+		},
+		{
+			name:     "ultra disk with disk encryption set",
+			spec:     fakeUltraDiskSpecWithDiskEncryptionSet,
+			existing: nil,
+			expected: compute.Disk{
+				Name:     to.StringPtr("my-vm_etcddisk"),
+				Location: to.StringPtr("westus3"),
+				Tags: map[string]*string{
+					"Name": to.StringPtr("my-vm_etcddisk"),
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+				},
+				Sku: &compute.DiskSku{Name: compute.DiskStorageAccountTypesUltraSSDLRS},
+				DiskProperties: &compute.DiskProperties{
+					CreationData: &compute.CreationData{
+						CreateOption: compute.DiskCreateOptionEmpty,
+					},
+					DiskSizeGB:        to.Int32Ptr(256),
+					DiskIOPSReadWrite: to.Int64Ptr(10000),
+					Encryption: &compute.Encryption{
+						DiskEncryptionSetID: to.StringPtr("my-des-id"),
+						Type:                compute.EncryptionTypeEncryptionAtRestWithCustomerKey,
+					},
+				},
+			},
+			expectedError: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Diff between expected result and actual result:\n%s", cmp.Diff(tc.expected, result))
+			}
+		})
+	}
+}
+
+func TestReleasedDiskParameters(t *testing.T) {
+	testCases := []struct {
+		name          string
+		spec          releasedDiskSpec
+		existing      interface{}
+		expected      interface{}
+		expectedError string
+	}{
+		{
+			name:          "disk does not exist",
+			spec:          releasedDiskSpec{&fakeUltraDiskSpec},
+			existing:      nil,
+			expected:      nil,
+			expectedError: "",
+		},
+		{
+			name:          "existing is not a disk",
+			spec:          releasedDiskSpec{&fakeUltraDiskSpec},
+			existing:      struct{}{},
+			expected:      nil,
+			expectedError: "struct {} is not a compute.Disk",
+		},
+		{
+			name: "disk is already released",
+			spec: releasedDiskSpec{&fakeUltraDiskSpec},
+			existing: compute.Disk{
+				Name: to.StringPtr("my-vm_etcddisk"),
+				Tags: map[string]*string{"foo": to.StringPtr("bar")},
+			},
+			expected:      nil,
+			expectedError: "",
+		},
+		{
+			name: "remove the cluster ownership tag",
+			spec: releasedDiskSpec{&fakeUltraDiskSpec},
+			existing: compute.Disk{
+				Name: to.StringPtr("my-vm_etcddisk"),
+				Tags: map[string]*string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+					"foo": to.StringPtr("bar"),
+				},
+			},
+			expected: compute.Disk{
+				Name: to.StringPtr("my-vm_etcddisk"),
+				Tags: map[string]*string{"foo": to.StringPtr("bar")},
+			},
+			expectedError: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Diff between expected result and actual result:\n%s", cmp.Diff(tc.expected, result))
+			}
+		})
+	}
+}
@@ -62,6 +62,11 @@ func (s *Service) Name() string {
 	return ServiceName
 }
 
+// DependsOn returns nil as the resource group does not depend on any other service.
+func (s *Service) DependsOn() []string {
+	return nil
+}
+
 // Reconcile idempotently creates or updates a resource group.
 func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "groups.Service.Reconcile")
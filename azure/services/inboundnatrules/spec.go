@@ -20,6 +20,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/util/diff"
 )
 
 // InboundNatSpec defines the specification for an inbound NAT rule.
@@ -48,19 +49,46 @@ func (s *InboundNatSpec) OwnerResourceName() string {
 
 // Parameters returns the parameters for the inbound NAT rule.
 func (s *InboundNatSpec) Parameters(existing interface{}) (parameters interface{}, err error) {
+	frontendPort := s.SSHFrontendPort
 	if existing != nil {
-		if _, ok := existing.(network.InboundNatRule); !ok {
+		existingRule, ok := existing.(network.InboundNatRule)
+		if !ok {
 			return nil, errors.Errorf("%T is not a network.InboundNatRule", existing)
 		}
 
-		return nil, nil
+		drift, err := s.Drift(existingRule)
+		if err != nil {
+			return nil, err
+		}
+		if len(drift) == 0 {
+			return nil, nil
+		}
+		// Keep the frontend port of the existing rule, it was already chosen among the available ones.
+		if existingRule.InboundNatRulePropertiesFormat != nil {
+			frontendPort = existingRule.FrontendPort
+		}
 	}
 
 	if s.FrontendIPConfigurationID == nil {
 		return nil, errors.Errorf("FrontendIPConfigurationID is not set")
 	}
 
-	rule := network.InboundNatRule{
+	return s.rule(frontendPort), nil
+}
+
+// Drift returns the fields of the existing inbound NAT rule which were modified outside of CAPZ. The frontend port is
+// chosen among the available ones when the rule is created and is not owned by the spec.
+func (s *InboundNatSpec) Drift(existing interface{}) ([]string, error) {
+	existingRule, ok := existing.(network.InboundNatRule)
+	if !ok {
+		return nil, errors.Errorf("%T is not a network.InboundNatRule", existing)
+	}
+	return diff.ChangedFields(existingRule, s.rule(nil))
+}
+
+// rule returns the inbound NAT rule forwarding the given frontend port to the SSH port of the machine.
+func (s *InboundNatSpec) rule(frontendPort *int32) network.InboundNatRule {
+	return network.InboundNatRule{
 		Name: to.StringPtr(s.ResourceName()),
 		InboundNatRulePropertiesFormat: &network.InboundNatRulePropertiesFormat{
 			BackendPort:          to.Int32Ptr(22),
@@ -70,11 +98,9 @@ func (s *InboundNatSpec) Parameters(existing interface{}) (parameters interface{
 				ID: s.FrontendIPConfigurationID,
 			},
 			Protocol:     network.TransportProtocolTCP,
-			FrontendPort: s.SSHFrontendPort,
+			FrontendPort: frontendPort,
 		},
 	}
-
-	return rule, nil
 }
 
 func getAvailableSSHFrontendPort(portsInUse map[int32]struct{}) (int32, error) {
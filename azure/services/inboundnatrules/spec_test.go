@@ -19,6 +19,8 @@ package inboundnatrules
 import (
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
 	. "github.com/onsi/gomega"
 )
 
@@ -88,3 +90,68 @@ func getFullPortsMap() map[int32]struct{} {
 	}
 	return res
 }
+
+func TestParameters(t *testing.T) {
+	spec := &InboundNatSpec{
+		Name:                      "my-machine-nat-rule",
+		LoadBalancerName:          "my-lb",
+		ResourceGroup:             "my-rg",
+		FrontendIPConfigurationID: to.StringPtr("frontend-ip-config-id"),
+		SSHFrontendPort:           to.Int32Ptr(2202),
+	}
+	rule := func(frontendPort int32, backendPort int32) network.InboundNatRule {
+		return network.InboundNatRule{
+			Name: to.StringPtr("my-machine-nat-rule"),
+			InboundNatRulePropertiesFormat: &network.InboundNatRulePropertiesFormat{
+				BackendPort:             to.Int32Ptr(backendPort),
+				EnableFloatingIP:        to.BoolPtr(false),
+				IdleTimeoutInMinutes:    to.Int32Ptr(4),
+				FrontendIPConfiguration: &network.SubResource{ID: to.StringPtr("frontend-ip-config-id")},
+				Protocol:                network.TransportProtocolTCP,
+				FrontendPort:            to.Int32Ptr(frontendPort),
+			},
+		}
+	}
+	testcases := []struct {
+		name          string
+		existing      interface{}
+		expected      interface{}
+		expectedDrift []string
+	}{
+		{
+			name:     "new rule uses the available frontend port",
+			expected: rule(2202, 22),
+		},
+		{
+			name:     "existing rule with another frontend port is up to date",
+			existing: rule(2201, 22),
+			expected: nil,
+		},
+		{
+			name:          "existing rule modified outside of CAPZ keeps its frontend port",
+			existing:      rule(2201, 2222),
+			expected:      rule(2201, 22),
+			expectedDrift: []string{"properties.backendPort"},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := spec.Parameters(tc.existing)
+			g.Expect(err).NotTo(HaveOccurred())
+			if tc.expected == nil {
+				g.Expect(result).To(BeNil())
+			} else {
+				g.Expect(result).To(Equal(tc.expected))
+			}
+			if tc.existing != nil {
+				drift, err := spec.Drift(tc.existing)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(drift).To(Equal(tc.expectedDrift))
+			}
+		})
+	}
+}
@@ -22,12 +22,15 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
 const (
-	serviceName = "loadbalancers"
+	// ServiceName is the name of this service.
+	ServiceName = "loadbalancers"
 	tcpProbe    = "TCPProbe"
 	lbRuleHTTPS = "LBRuleHTTPS"
 	outboundNAT = "OutboundNATAllProtocols"
@@ -57,7 +60,15 @@ func New(scope LBScope) *Service {
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return ServiceName
+}
+
+// DependsOn returns the names of the services that must be reconciled before this one.
+func (s *Service) DependsOn() []string {
+	return []string{
+		subnets.ServiceName,
+		publicips.ServiceName,
+	}
 }
 
 // Reconcile idempotently creates or updates a load balancer.
@@ -78,14 +89,14 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
 	var result error
 	for _, lbSpec := range specs {
-		if _, err := s.CreateOrUpdateResource(ctx, lbSpec, serviceName); err != nil {
+		if _, err := s.CreateOrUpdateResource(ctx, lbSpec, ServiceName); err != nil {
 			if !azure.IsOperationNotDoneError(err) || result == nil {
 				result = err
 			}
 		}
 	}
 
-	s.Scope.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, result)
+	s.Scope.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, ServiceName, result)
 	return result
 }
 
@@ -107,14 +118,14 @@ func (s *Service) Delete(ctx context.Context) error {
 	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
 	var result error
 	for _, lbSpec := range specs {
-		if err := s.DeleteResource(ctx, lbSpec, serviceName); err != nil {
+		if err := s.DeleteResource(ctx, lbSpec, ServiceName); err != nil {
 			if !azure.IsOperationNotDoneError(err) || result == nil {
 				result = err
 			}
 		}
 	}
 
-	s.Scope.UpdateDeleteStatus(infrav1.LoadBalancersReadyCondition, serviceName, result)
+	s.Scope.UpdateDeleteStatus(infrav1.LoadBalancersReadyCondition, ServiceName, result)
 	return result
 }
 
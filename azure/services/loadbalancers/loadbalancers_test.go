@@ -66,6 +66,9 @@ var (
 		Role:                 infrav1.APIServerRole,
 		Type:                 infrav1.Internal,
 		SKU:                  infrav1.SKUStandard,
+		VNetSubscriptionID:   "123",
+		VNetName:             "my-vnet",
+		VNetResourceGroup:    "my-rg",
 		SubnetName:           "my-cp-subnet",
 		BackendPoolName:      "my-private-lb-backendPool",
 		IdleTimeoutInMinutes: to.Int32Ptr(4),
@@ -122,8 +125,8 @@ func TestReconcileLoadBalancer(t *testing.T) {
 			expectedError: "#: Internal Server Error: StatusCode=500",
 			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(nil, internalError)
-				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicAPILBSpec, ServiceName).Return(nil, internalError)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, ServiceName, internalError)
 			},
 		},
 		{
@@ -131,8 +134,8 @@ func TestReconcileLoadBalancer(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicAPILBSpec, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -140,8 +143,8 @@ func TestReconcileLoadBalancer(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakeInternalAPILBSpec})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeInternalAPILBSpec, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeInternalAPILBSpec, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -149,8 +152,8 @@ func TestReconcileLoadBalancer(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakeNodeOutboundLBSpec})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNodeOutboundLBSpec, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNodeOutboundLBSpec, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -158,10 +161,10 @@ func TestReconcileLoadBalancer(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec, &fakeInternalAPILBSpec, &fakeNodeOutboundLBSpec})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeInternalAPILBSpec, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNodeOutboundLBSpec, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicAPILBSpec, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeInternalAPILBSpec, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNodeOutboundLBSpec, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, ServiceName, nil)
 			},
 		},
 	}
@@ -213,8 +216,8 @@ func TestDeleteLoadBalancer(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec})
-				r.DeleteResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePublicAPILBSpec, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.LoadBalancersReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -222,10 +225,10 @@ func TestDeleteLoadBalancer(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec, &fakeInternalAPILBSpec, &fakeNodeOutboundLBSpec})
-				r.DeleteResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakeInternalAPILBSpec, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakeNodeOutboundLBSpec, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePublicAPILBSpec, ServiceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeInternalAPILBSpec, ServiceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeNodeOutboundLBSpec, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.LoadBalancersReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -233,8 +236,8 @@ func TestDeleteLoadBalancer(t *testing.T) {
 			expectedError: "#: Internal Server Error: StatusCode=500",
 			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec})
-				r.DeleteResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(internalError)
-				s.UpdateDeleteStatus(infrav1.LoadBalancersReadyCondition, serviceName, internalError)
+				r.DeleteResource(gomockinternal.AContext(), &fakePublicAPILBSpec, ServiceName).Return(internalError)
+				s.UpdateDeleteStatus(infrav1.LoadBalancersReadyCondition, ServiceName, internalError)
 			},
 		},
 	}
@@ -261,6 +261,20 @@ func (mr *MockLBScopeMockRecorder) DeleteLongRunningOperationState(arg0, arg1, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLongRunningOperationState", reflect.TypeOf((*MockLBScope)(nil).DeleteLongRunningOperationState), arg0, arg1, arg2)
 }
 
+// DriftPolicy mocks base method.
+func (m *MockLBScope) DriftPolicy() v1beta1.DriftPolicy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DriftPolicy")
+	ret0, _ := ret[0].(v1beta1.DriftPolicy)
+	return ret0
+}
+
+// DriftPolicy indicates an expected call of DriftPolicy.
+func (mr *MockLBScopeMockRecorder) DriftPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DriftPolicy", reflect.TypeOf((*MockLBScope)(nil).DriftPolicy))
+}
+
 // FailureDomains mocks base method.
 func (m *MockLBScope) FailureDomains() []string {
 	m.ctrl.T.Helper()
@@ -572,3 +586,17 @@ func (mr *MockLBScopeMockRecorder) Vnet() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Vnet", reflect.TypeOf((*MockLBScope)(nil).Vnet))
 }
+
+// VnetSubscriptionID mocks base method.
+func (m *MockLBScope) VnetSubscriptionID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VnetSubscriptionID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// VnetSubscriptionID indicates an expected call of VnetSubscriptionID.
+func (mr *MockLBScopeMockRecorder) VnetSubscriptionID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VnetSubscriptionID", reflect.TypeOf((*MockLBScope)(nil).VnetSubscriptionID))
+}
@@ -23,6 +23,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/util/diff"
 )
 
 // LBSpec defines the specification for a Load Balancer.
@@ -35,6 +36,7 @@ type LBSpec struct {
 	Role                 string
 	Type                 infrav1.LBType
 	SKU                  infrav1.SKU
+	VNetSubscriptionID   string
 	VNetName             string
 	VNetResourceGroup    string
 	SubnetName           string
@@ -80,51 +82,17 @@ func (s *LBSpec) Parameters(existing interface{}) (parameters interface{}, err e
 		// LB already exists
 		// We append the existing LB etag to the header to ensure we only apply the updates if the LB has not been modified.
 		etag = existingLB.Etag
-		update := false
-
-		// merge existing LB properties with desired properties
-		frontendIPConfigs = *existingLB.FrontendIPConfigurations
-		wantedIPs, wantedFrontendIDs := getFrontendIPConfigs(*s)
-		for _, ip := range wantedIPs {
-			if !ipExists(frontendIPConfigs, ip) {
-				update = true
-				frontendIPConfigs = append(frontendIPConfigs, ip)
-			}
-		}
-
-		loadBalancingRules = *existingLB.LoadBalancingRules
-		for _, rule := range getLoadBalancingRules(*s, wantedFrontendIDs) {
-			if !lbRuleExists(loadBalancingRules, rule) {
-				update = true
-				loadBalancingRules = append(loadBalancingRules, rule)
-			}
-		}
-
-		backendAddressPools = *existingLB.BackendAddressPools
-		for _, pool := range getBackendAddressPools(*s) {
-			if !poolExists(backendAddressPools, pool) {
-				update = true
-				backendAddressPools = append(backendAddressPools, pool)
-			}
-		}
-
-		outboundRules = *existingLB.OutboundRules
-		for _, rule := range getOutboundRules(*s, wantedFrontendIDs) {
-			if !outboundRuleExists(outboundRules, rule) {
-				update = true
-				outboundRules = append(outboundRules, rule)
-			}
+		merged, changes, err := s.mergeProperties(existingLB)
+		if err != nil {
+			return nil, err
 		}
+		frontendIPConfigs = *merged.FrontendIPConfigurations
+		loadBalancingRules = *merged.LoadBalancingRules
+		backendAddressPools = *merged.BackendAddressPools
+		outboundRules = *merged.OutboundRules
+		probes = *merged.Probes
 
-		probes = *existingLB.Probes
-		for _, probe := range getProbes(*s) {
-			if !probeExists(probes, probe) {
-				update = true
-				probes = append(probes, probe)
-			}
-		}
-
-		if !update {
+		if len(changes) == 0 {
 			// load balancer already exists with all required defaults
 			return nil, nil
 		}
@@ -158,6 +126,50 @@ func (s *LBSpec) Parameters(existing interface{}) (parameters interface{}, err e
 	return lb, nil
 }
 
+// Drift returns the sub-resources of the spec, such as load balancing rules or probes, which were modified or deleted
+// outside of CAPZ in the existing load balancer. Sub-resources added outside of CAPZ are not owned by the spec and are
+// ignored.
+func (s *LBSpec) Drift(existing interface{}) ([]string, error) {
+	existingLB, ok := existing.(network.LoadBalancer)
+	if !ok {
+		return nil, errors.Errorf("%T is not a network.LoadBalancer", existing)
+	}
+	_, changes, err := s.mergeProperties(existingLB)
+	return changes, err
+}
+
+// mergeProperties merges the sub-resources of the spec with the ones of the existing load balancer, keeping the ones
+// added outside of CAPZ, and returns the changes made to the existing sub-resources.
+func (s *LBSpec) mergeProperties(existingLB network.LoadBalancer) (*network.LoadBalancerPropertiesFormat, []string, error) {
+	props := existingLB.LoadBalancerPropertiesFormat
+	if props == nil {
+		props = &network.LoadBalancerPropertiesFormat{}
+	}
+
+	var (
+		merged  network.LoadBalancerPropertiesFormat
+		changes []string
+		err     error
+	)
+	wantedIPs, wantedFrontendIDs := getFrontendIPConfigs(*s)
+	if merged.FrontendIPConfigurations, err = mergeByName("frontendIPConfigurations", props.FrontendIPConfigurations, wantedIPs, func(ip network.FrontendIPConfiguration) string { return to.String(ip.Name) }, &changes); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to merge frontend IP configurations")
+	}
+	if merged.LoadBalancingRules, err = mergeByName("loadBalancingRules", props.LoadBalancingRules, getLoadBalancingRules(*s, wantedFrontendIDs), func(rule network.LoadBalancingRule) string { return to.String(rule.Name) }, &changes); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to merge load balancing rules")
+	}
+	if merged.BackendAddressPools, err = mergeByName("backendAddressPools", props.BackendAddressPools, getBackendAddressPools(*s), func(pool network.BackendAddressPool) string { return to.String(pool.Name) }, &changes); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to merge backend address pools")
+	}
+	if merged.OutboundRules, err = mergeByName("outboundRules", props.OutboundRules, getOutboundRules(*s, wantedFrontendIDs), func(rule network.OutboundRule) string { return to.String(rule.Name) }, &changes); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to merge outbound rules")
+	}
+	if merged.Probes, err = mergeByName("probes", props.Probes, getProbes(*s), func(probe network.Probe) string { return to.String(probe.Name) }, &changes); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to merge probes")
+	}
+	return &merged, changes, nil
+}
+
 func getFrontendIPConfigs(lbSpec LBSpec) ([]network.FrontendIPConfiguration, []network.SubResource) {
 	frontendIPConfigurations := make([]network.FrontendIPConfiguration, 0)
 	frontendIDs := make([]network.SubResource, 0)
@@ -167,7 +179,7 @@ func getFrontendIPConfigs(lbSpec LBSpec) ([]network.FrontendIPConfiguration, []n
 			properties = network.FrontendIPConfigurationPropertiesFormat{
 				PrivateIPAllocationMethod: network.IPAllocationMethodStatic,
 				Subnet: &network.Subnet{
-					ID: to.StringPtr(azure.SubnetID(lbSpec.SubscriptionID, lbSpec.VNetResourceGroup, lbSpec.VNetName, lbSpec.SubnetName)),
+					ID: to.StringPtr(azure.SubnetID(lbSpec.VNetSubscriptionID, lbSpec.VNetResourceGroup, lbSpec.VNetName, lbSpec.SubnetName)),
 				},
 				PrivateIPAddress: to.StringPtr(ipConfig.PrivateIPAddress),
 			}
@@ -266,47 +278,19 @@ func getProbes(lbSpec LBSpec) []network.Probe {
 	return []network.Probe{}
 }
 
-func probeExists(probes []network.Probe, probe network.Probe) bool {
-	for _, p := range probes {
-		if to.String(p.Name) == to.String(probe.Name) {
-			return true
-		}
-	}
-	return false
-}
-
-func outboundRuleExists(rules []network.OutboundRule, rule network.OutboundRule) bool {
-	for _, r := range rules {
-		if to.String(r.Name) == to.String(rule.Name) {
-			return true
-		}
-	}
-	return false
-}
-
-func poolExists(pools []network.BackendAddressPool, pool network.BackendAddressPool) bool {
-	for _, p := range pools {
-		if to.String(p.Name) == to.String(pool.Name) {
-			return true
-		}
+// mergeByName merges the desired sub-resources of the load balancer into the existing ones and appends the changes
+// made to the existing sub-resources to changes, prefixed with the name of the list of sub-resources.
+func mergeByName[T any](list string, existing *[]T, desired []T, name func(T) string, changes *[]string) (*[]T, error) {
+	var current []T
+	if existing != nil {
+		current = *existing
 	}
-	return false
-}
-
-func lbRuleExists(rules []network.LoadBalancingRule, rule network.LoadBalancingRule) bool {
-	for _, r := range rules {
-		if to.String(r.Name) == to.String(rule.Name) {
-			return true
-		}
+	merged, c, err := diff.MergeByName(current, desired, name)
+	if err != nil {
+		return nil, err
 	}
-	return false
-}
-
-func ipExists(configs []network.FrontendIPConfiguration, config network.FrontendIPConfiguration) bool {
-	for _, ip := range configs {
-		if to.String(ip.Name) == to.String(config.Name) {
-			return true
-		}
+	for _, change := range c {
+		*changes = append(*changes, list+"."+change)
 	}
-	return false
+	return &merged, nil
 }
@@ -60,6 +60,35 @@ func getExistingLBWithMissingOutboundRules() network.LoadBalancer {
 	return existingLB
 }
 
+func getExistingLBWithDriftedRules() network.LoadBalancer {
+	existingLB := newSamplePublicAPIServerLB(true, true, true, true, true)
+	(*existingLB.LoadBalancingRules)[0].EnableFloatingIP = to.BoolPtr(true)
+	(*existingLB.Probes)[0].NumberOfProbes = to.Int32Ptr(999)
+
+	return existingLB
+}
+
+func getExistingLBWithRulesAddedOutOfBand() network.LoadBalancer {
+	existingLB := newSamplePublicAPIServerLB(true, true, true, true, true)
+	*existingLB.LoadBalancingRules = append(*existingLB.LoadBalancingRules, network.LoadBalancingRule{
+		Name: to.StringPtr("custom-rule"),
+		LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
+			Protocol:     network.TransportProtocolUDP,
+			FrontendPort: to.Int32Ptr(53),
+			BackendPort:  to.Int32Ptr(53),
+		},
+	})
+	*existingLB.Probes = append(*existingLB.Probes, network.Probe{
+		Name: to.StringPtr("custom-probe"),
+		ProbePropertiesFormat: &network.ProbePropertiesFormat{
+			Protocol: network.ProbeProtocolTCP,
+			Port:     to.Int32Ptr(53),
+		},
+	})
+
+	return existingLB
+}
+
 func TestParameters(t *testing.T) {
 	testcases := []struct {
 		name          string
@@ -145,6 +174,30 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name:     "load balancer exists with drifted load balancing rules and probes",
+			spec:     &fakePublicAPILBSpec,
+			existing: getExistingLBWithDriftedRules(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				lb := result.(network.LoadBalancer)
+				expectedLB := newSamplePublicAPIServerLB(true, true, true, true, true)
+				// drifted rules are replaced with the desired ones, the other rules are kept as they are.
+				(*expectedLB.LoadBalancingRules)[0] = getLoadBalancingRules(fakePublicAPILBSpec, []network.SubResource{{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-publiclb/frontendIPConfigurations/my-publiclb-frontEnd")}})[0]
+				(*expectedLB.Probes)[0] = getProbes(fakePublicAPILBSpec)[0]
+				g.Expect(lb).To(Equal(expectedLB))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "load balancer exists with rules added outside of CAPZ",
+			spec:     &fakePublicAPILBSpec,
+			existing: getExistingLBWithRulesAddedOutOfBand(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
 	}
 	for _, tc := range testcases {
 		tc := tc
@@ -210,9 +263,9 @@ func newDefaultNodeOutboundLB() network.LoadBalancer {
 func newSamplePublicAPIServerLB(verifyFrontendIP bool, verifyBackendAddressPools bool, verifyLBRules bool, verifyProbes bool, verifyOutboundRules bool) network.LoadBalancer {
 	var subnet *network.Subnet
 	var backendAddressPoolProps *network.BackendAddressPoolPropertiesFormat
-	enableFloatingIP := to.BoolPtr(false)
-	numProbes := to.Int32Ptr(4)
-	idleTimeout := to.Int32Ptr(4)
+	var enableTCPReset *bool
+	var requestPath *string
+	var allocatedOutboundPorts *int32
 
 	if verifyFrontendIP {
 		subnet = &network.Subnet{
@@ -225,13 +278,13 @@ func newSamplePublicAPIServerLB(verifyFrontendIP bool, verifyBackendAddressPools
 		}
 	}
 	if verifyLBRules {
-		enableFloatingIP = to.BoolPtr(true)
+		enableTCPReset = to.BoolPtr(true)
 	}
 	if verifyProbes {
-		numProbes = to.Int32Ptr(999)
+		requestPath = to.StringPtr("/healthz")
 	}
 	if verifyOutboundRules {
-		idleTimeout = to.Int32Ptr(1000)
+		allocatedOutboundPorts = to.Int32Ptr(1000)
 	}
 
 	return network.LoadBalancer{
@@ -266,7 +319,8 @@ func newSamplePublicAPIServerLB(verifyFrontendIP bool, verifyBackendAddressPools
 						FrontendPort:         to.Int32Ptr(6443),
 						BackendPort:          to.Int32Ptr(6443),
 						IdleTimeoutInMinutes: to.Int32Ptr(4),
-						EnableFloatingIP:     enableFloatingIP, // Add to verify that LoadBalancingRules aren't overwritten on update
+						EnableFloatingIP:     to.BoolPtr(false),
+						EnableTCPReset:       enableTCPReset, // Add to verify that LoadBalancingRules aren't overwritten on update
 						LoadDistribution:     network.LoadDistributionDefault,
 						FrontendIPConfiguration: &network.SubResource{
 							ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-publiclb/frontendIPConfigurations/my-publiclb-frontEnd"),
@@ -287,7 +341,8 @@ func newSamplePublicAPIServerLB(verifyFrontendIP bool, verifyBackendAddressPools
 						Protocol:          network.ProbeProtocolTCP,
 						Port:              to.Int32Ptr(6443),
 						IntervalInSeconds: to.Int32Ptr(15),
-						NumberOfProbes:    numProbes, // Add to verify that Probes aren't overwritten on update
+						NumberOfProbes:    to.Int32Ptr(4),
+						RequestPath:       requestPath, // Add to verify that Probes aren't overwritten on update
 					},
 				},
 			},
@@ -301,8 +356,9 @@ func newSamplePublicAPIServerLB(verifyFrontendIP bool, verifyBackendAddressPools
 						BackendAddressPool: &network.SubResource{
 							ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-publiclb/backendAddressPools/my-publiclb-backendPool"),
 						},
-						Protocol:             network.LoadBalancerOutboundRuleProtocolAll,
-						IdleTimeoutInMinutes: idleTimeout, // Add to verify that OutboundRules aren't overwritten on update
+						Protocol:               network.LoadBalancerOutboundRuleProtocolAll,
+						IdleTimeoutInMinutes:   to.Int32Ptr(4),
+						AllocatedOutboundPorts: allocatedOutboundPorts, // Add to verify that OutboundRules aren't overwritten on update
 					},
 				},
 			},
@@ -374,3 +430,41 @@ func newDefaultInternalAPIServerLB() network.LoadBalancer {
 		},
 	}
 }
+
+func TestDrift(t *testing.T) {
+	testcases := []struct {
+		name     string
+		existing interface{}
+		expected []string
+	}{
+		{
+			name:     "no drift, sub-resources added outside of CAPZ are ignored",
+			existing: getExistingLBWithRulesAddedOutOfBand(),
+			expected: nil,
+		},
+		{
+			name:     "modified rules and probes",
+			existing: getExistingLBWithDriftedRules(),
+			expected: []string{
+				"loadBalancingRules.LBRuleHTTPS.properties.enableFloatingIP",
+				"probes.TCPProbe.properties.numberOfProbes",
+			},
+		},
+		{
+			name:     "deleted outbound rule",
+			existing: getExistingLBWithMissingOutboundRules(),
+			expected: []string{"outboundRules.OutboundNATAllProtocols"},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			drift, err := fakePublicAPILBSpec.Drift(tc.existing)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(drift).To(Equal(tc.expected))
+		})
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenanceconfigurations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// ServiceName is the name of this service.
+const ServiceName = "maintenanceconfigurations"
+
+// MaintenanceConfigurationScope defines the scope interface for a maintenance configurations service.
+type MaintenanceConfigurationScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	ResourceGroup() string
+	ManagedClusterName() string
+	MaintenanceConfigurationSpecs() []azure.ResourceSpecGetter
+	AnnotationJSON(string) (map[string]interface{}, error)
+	UpdateAnnotationJSON(string, map[string]interface{}) error
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope MaintenanceConfigurationScope
+	async.Reconciler
+}
+
+// New creates a new service.
+func New(scope MaintenanceConfigurationScope) *Service {
+	client := newClient(scope)
+	return &Service{
+		Scope:      scope,
+		Reconciler: async.New(scope, client, client),
+	}
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return ServiceName
+}
+
+// Reconcile idempotently creates or updates the maintenance configurations of an AKS cluster, and deletes the ones
+// previously created by CAPZ that are no longer in the spec.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "maintenanceconfigurations.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	lastApplied, err := s.Scope.AnnotationJSON(azure.MaintenanceConfigurationsLastAppliedAnnotation)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the last applied maintenance configurations")
+	}
+
+	specs := s.Scope.MaintenanceConfigurationSpecs()
+	if len(specs) == 0 && len(lastApplied) == 0 {
+		return nil
+	}
+
+	// We go through the list of MaintenanceConfigurationSpecs to reconcile each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
+	var resultErr error
+	applied := make(map[string]interface{}, len(specs))
+	for _, spec := range specs {
+		// The configuration is recorded even if it fails to be created, in case it is removed from the spec before it is.
+		applied[spec.ResourceName()] = true
+		if _, err := s.CreateOrUpdateResource(ctx, spec, ServiceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || resultErr == nil {
+				resultErr = err
+			}
+		}
+	}
+
+	for name := range lastApplied {
+		if _, ok := applied[name]; ok {
+			continue
+		}
+		log.V(2).Info("deleting maintenance configuration removed from the spec", "maintenanceConfiguration", name)
+		spec := &MaintenanceConfigurationSpec{
+			Name:          name,
+			ResourceGroup: s.Scope.ResourceGroup(),
+			ClusterName:   s.Scope.ManagedClusterName(),
+		}
+		if err := s.DeleteResource(ctx, spec, ServiceName); err != nil {
+			// Keep track of the configuration to retry deleting it on the next reconciliation.
+			applied[name] = true
+			if !azure.IsOperationNotDoneError(err) || resultErr == nil {
+				resultErr = err
+			}
+		}
+	}
+
+	if err := s.Scope.UpdateAnnotationJSON(azure.MaintenanceConfigurationsLastAppliedAnnotation, applied); err != nil {
+		return errors.Wrap(err, "failed to update the last applied maintenance configurations")
+	}
+
+	s.Scope.UpdatePutStatus(infrav1.MaintenanceConfigurationsReadyCondition, ServiceName, resultErr)
+	return resultErr
+}
+
+// Delete is a no-op as the maintenance configurations are deleted with the AKS cluster.
+func (s *Service) Delete(ctx context.Context) error {
+	_, _, done := tele.StartSpanWithLogger(ctx, "maintenanceconfigurations.Service.Delete")
+	defer done()
+
+	return nil
+}
+
+// IsManaged returns always returns true as CAPZ does not support BYO maintenance configurations.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenanceconfigurations
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/maintenanceconfigurations/mock_maintenanceconfigurations"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	staleMaintenanceConfigurationSpec = MaintenanceConfigurationSpec{
+		Name:          "stale",
+		ResourceGroup: "my-rg",
+		ClusterName:   "my-cluster",
+	}
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+)
+
+func TestReconcileMaintenanceConfigurations(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_maintenanceconfigurations.MockMaintenanceConfigurationScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if no maintenance configurations are specified or were applied",
+			expectedError: "",
+			expect: func(s *mock_maintenanceconfigurations.MockMaintenanceConfigurationScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.MaintenanceConfigurationsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.MaintenanceConfigurationSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "create maintenance configuration and delete the one removed from the spec",
+			expectedError: "",
+			expect: func(s *mock_maintenanceconfigurations.MockMaintenanceConfigurationScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.MaintenanceConfigurationsLastAppliedAnnotation).Return(map[string]interface{}{"default": true, "stale": true}, nil)
+				s.MaintenanceConfigurationSpecs().Return([]azure.ResourceSpecGetter{&fakeMaintenanceConfigurationSpec})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeMaintenanceConfigurationSpec, ServiceName).Return(nil, nil)
+				s.ResourceGroup().Return("my-rg")
+				s.ManagedClusterName().Return("my-cluster")
+				r.DeleteResource(gomockinternal.AContext(), &staleMaintenanceConfigurationSpec, ServiceName).Return(nil)
+				s.UpdateAnnotationJSON(azure.MaintenanceConfigurationsLastAppliedAnnotation, map[string]interface{}{"default": true}).Return(nil)
+				s.UpdatePutStatus(infrav1.MaintenanceConfigurationsReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "fail to create a maintenance configuration",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_maintenanceconfigurations.MockMaintenanceConfigurationScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.MaintenanceConfigurationsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.MaintenanceConfigurationSpecs().Return([]azure.ResourceSpecGetter{&fakeMaintenanceConfigurationSpec})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeMaintenanceConfigurationSpec, ServiceName).Return(nil, internalError)
+				s.UpdateAnnotationJSON(azure.MaintenanceConfigurationsLastAppliedAnnotation, map[string]interface{}{"default": true}).Return(nil)
+				s.UpdatePutStatus(infrav1.MaintenanceConfigurationsReadyCondition, ServiceName, internalError)
+			},
+		},
+		{
+			name:          "maintenance configuration that fails to be deleted is kept track of",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_maintenanceconfigurations.MockMaintenanceConfigurationScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.MaintenanceConfigurationsLastAppliedAnnotation).Return(map[string]interface{}{"stale": true}, nil)
+				s.MaintenanceConfigurationSpecs().Return([]azure.ResourceSpecGetter{})
+				s.ResourceGroup().Return("my-rg")
+				s.ManagedClusterName().Return("my-cluster")
+				r.DeleteResource(gomockinternal.AContext(), &staleMaintenanceConfigurationSpec, ServiceName).Return(internalError)
+				s.UpdateAnnotationJSON(azure.MaintenanceConfigurationsLastAppliedAnnotation, map[string]interface{}{"stale": true}).Return(nil)
+				s.UpdatePutStatus(infrav1.MaintenanceConfigurationsReadyCondition, ServiceName, internalError)
+			},
+		},
+		{
+			name:          "fail to get the last applied maintenance configurations",
+			expectedError: "failed to get the last applied maintenance configurations: invalid annotation",
+			expect: func(s *mock_maintenanceconfigurations.MockMaintenanceConfigurationScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AnnotationJSON(azure.MaintenanceConfigurationsLastAppliedAnnotation).Return(nil, errors.New("invalid annotation"))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_maintenanceconfigurations.NewMockMaintenanceConfigurationScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
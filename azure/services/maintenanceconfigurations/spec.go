@@ -0,0 +1,132 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenanceconfigurations
+
+import (
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2021-05-01/containerservice"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+// MaintenanceConfigurationSpec defines the specification for a planned maintenance configuration of an AKS cluster.
+type MaintenanceConfigurationSpec struct {
+	Name           string
+	ResourceGroup  string
+	ClusterName    string
+	TimeInWeek     []TimeInWeek
+	NotAllowedTime []TimeSpan
+}
+
+// TimeInWeek is a day of the week and the hours of that day in which maintenance is allowed.
+type TimeInWeek struct {
+	Day       string
+	HourSlots []int32
+}
+
+// TimeSpan is a time span in which maintenance is not allowed.
+type TimeSpan struct {
+	Start time.Time
+	End   time.Time
+}
+
+var _ azure.ResourceSpecGetter = (*MaintenanceConfigurationSpec)(nil)
+
+// ResourceName returns the name of the maintenance configuration.
+func (s *MaintenanceConfigurationSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group of the AKS cluster.
+func (s *MaintenanceConfigurationSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName returns the name of the AKS cluster.
+func (s *MaintenanceConfigurationSpec) OwnerResourceName() string {
+	return s.ClusterName
+}
+
+// Parameters returns the parameters for the maintenance configuration.
+func (s *MaintenanceConfigurationSpec) Parameters(existing interface{}) (interface{}, error) {
+	properties := &containerservice.MaintenanceConfigurationProperties{
+		TimeInWeek:     &[]containerservice.TimeInWeek{},
+		NotAllowedTime: &[]containerservice.TimeSpan{},
+	}
+	for _, timeInWeek := range s.TimeInWeek {
+		hourSlots := append([]int32{}, timeInWeek.HourSlots...)
+		*properties.TimeInWeek = append(*properties.TimeInWeek, containerservice.TimeInWeek{
+			Day:       containerservice.WeekDay(timeInWeek.Day),
+			HourSlots: &hourSlots,
+		})
+	}
+	for _, timeSpan := range s.NotAllowedTime {
+		*properties.NotAllowedTime = append(*properties.NotAllowedTime, containerservice.TimeSpan{
+			Start: &date.Time{Time: timeSpan.Start.UTC()},
+			End:   &date.Time{Time: timeSpan.End.UTC()},
+		})
+	}
+
+	if existing != nil {
+		existingConfig, ok := existing.(containerservice.MaintenanceConfiguration)
+		if !ok {
+			return nil, errors.Errorf("%T is not a containerservice.MaintenanceConfiguration", existing)
+		}
+		if cmp.Equal(normalize(properties), normalize(existingConfig.MaintenanceConfigurationProperties)) {
+			// maintenance configuration is up to date, nothing to do
+			return nil, nil
+		}
+	}
+
+	return containerservice.MaintenanceConfiguration{
+		MaintenanceConfigurationProperties: properties,
+	}, nil
+}
+
+// normalize returns the time slots of a maintenance configuration in a form that can be compared: unset lists are
+// empty and times are in UTC.
+func normalize(properties *containerservice.MaintenanceConfigurationProperties) MaintenanceConfigurationSpec {
+	timeInWeek, notAllowedTime := []TimeInWeek{}, []TimeSpan{}
+	if properties == nil {
+		return MaintenanceConfigurationSpec{TimeInWeek: timeInWeek, NotAllowedTime: notAllowedTime}
+	}
+	if properties.TimeInWeek != nil {
+		for _, t := range *properties.TimeInWeek {
+			hourSlots := []int32{}
+			if t.HourSlots != nil {
+				hourSlots = append(hourSlots, *t.HourSlots...)
+			}
+			timeInWeek = append(timeInWeek, TimeInWeek{Day: string(t.Day), HourSlots: hourSlots})
+		}
+	}
+	if properties.NotAllowedTime != nil {
+		for _, t := range *properties.NotAllowedTime {
+			var span TimeSpan
+			if t.Start != nil {
+				span.Start = t.Start.UTC()
+			}
+			if t.End != nil {
+				span.End = t.End.UTC()
+			}
+			notAllowedTime = append(notAllowedTime, span)
+		}
+	}
+	return MaintenanceConfigurationSpec{TimeInWeek: timeInWeek, NotAllowedTime: notAllowedTime}
+}
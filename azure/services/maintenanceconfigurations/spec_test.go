@@ -0,0 +1,124 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenanceconfigurations
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2021-05-01/containerservice"
+	"github.com/Azure/go-autorest/autorest/date"
+	. "github.com/onsi/gomega"
+)
+
+var (
+	christmas = time.Date(2022, time.December, 24, 0, 0, 0, 0, time.UTC)
+	boxingDay = time.Date(2022, time.December, 26, 0, 0, 0, 0, time.UTC)
+
+	fakeMaintenanceConfigurationSpec = MaintenanceConfigurationSpec{
+		Name:           "default",
+		ResourceGroup:  "my-rg",
+		ClusterName:    "my-cluster",
+		TimeInWeek:     []TimeInWeek{{Day: "Saturday", HourSlots: []int32{1, 2}}, {Day: "Sunday"}},
+		NotAllowedTime: []TimeSpan{{Start: christmas, End: boxingDay}},
+	}
+)
+
+func fakeMaintenanceConfiguration() containerservice.MaintenanceConfiguration {
+	return containerservice.MaintenanceConfiguration{
+		MaintenanceConfigurationProperties: &containerservice.MaintenanceConfigurationProperties{
+			TimeInWeek: &[]containerservice.TimeInWeek{
+				{Day: containerservice.WeekDaySaturday, HourSlots: &[]int32{1, 2}},
+				{Day: containerservice.WeekDaySunday, HourSlots: &[]int32{}},
+			},
+			NotAllowedTime: &[]containerservice.TimeSpan{
+				{Start: &date.Time{Time: christmas}, End: &date.Time{Time: boxingDay}},
+			},
+		},
+	}
+}
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          MaintenanceConfigurationSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "maintenance configuration does not exist",
+			spec:     fakeMaintenanceConfigurationSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(fakeMaintenanceConfiguration()))
+			},
+		},
+		{
+			name: "maintenance configuration is up to date",
+			spec: fakeMaintenanceConfigurationSpec,
+			existing: func() containerservice.MaintenanceConfiguration {
+				config := fakeMaintenanceConfiguration()
+				// Azure returns the times in the location of the cluster.
+				(*config.NotAllowedTime)[0].Start = &date.Time{Time: christmas.In(time.FixedZone("PST", -8*60*60))}
+				(*config.TimeInWeek)[1].HourSlots = nil
+				return config
+			}(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "maintenance configuration needs an update",
+			spec: fakeMaintenanceConfigurationSpec,
+			existing: containerservice.MaintenanceConfiguration{
+				MaintenanceConfigurationProperties: &containerservice.MaintenanceConfigurationProperties{
+					TimeInWeek: &[]containerservice.TimeInWeek{
+						{Day: containerservice.WeekDayMonday, HourSlots: &[]int32{1, 2}},
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(fakeMaintenanceConfiguration()))
+			},
+		},
+		{
+			name:          "existing is not a maintenance configuration",
+			spec:          fakeMaintenanceConfigurationSpec,
+			existing:      "not a maintenance configuration",
+			expectedError: "string is not a containerservice.MaintenanceConfiguration",
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
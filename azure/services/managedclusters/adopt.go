@@ -0,0 +1,226 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package managedclusters
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2021-05-01/containerservice"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	"golang.org/x/mod/semver"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// adopt brings an existing managed cluster under the management of CAPZ. Once the AzureManagedControlPlane and its
+// agent pools match the managed cluster, the properties the AzureManagedControlPlane leaves unset are set from the
+// managed cluster, and the managed cluster is tagged as owned by the cluster.
+func (s *Service) adopt(ctx context.Context, spec *ManagedClusterSpec) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "managedclusters.Service.adopt")
+	defer done()
+
+	result, err := s.Adopter.Get(ctx, spec)
+	if err != nil {
+		if azure.ResourceNotFound(err) {
+			return azure.WithTerminalError(errors.Errorf("managed cluster %s to adopt does not exist in resource group %s", spec.Name, spec.ResourceGroup))
+		}
+		return errors.Wrapf(err, "failed to get managed cluster %s", spec.Name)
+	}
+	existing, _, err := managedClusterFrom(result)
+	if err != nil {
+		return err
+	}
+
+	if converters.MapToTags(existing.Tags).HasOwned(spec.ClusterName) {
+		// The managed cluster is already adopted, but the properties set from it may not have been saved.
+		properties := adoptedProperties(existing)
+		if !missingAdoptedProperties(spec, properties) {
+			return nil
+		}
+		if dr, ok := s.Scope.(async.DryRunner); ok && dr.IsDryRun() {
+			return nil
+		}
+		log.V(2).Info("setting the properties of the adopted managed cluster", "managedCluster", spec.Name)
+		s.Scope.SetAdoptedProperties(properties)
+		return nil
+	}
+
+	mismatches, err := adoptionMismatches(spec, existing)
+	if err != nil {
+		return err
+	}
+	if len(mismatches) > 0 {
+		return azure.WithTerminalError(errors.Errorf("managed cluster %s does not match the AzureManagedControlPlane: %s", spec.Name, strings.Join(mismatches, "; ")))
+	}
+
+	if dr, ok := s.Scope.(async.DryRunner); ok && dr.IsDryRun() {
+		log.V(2).Info("not adopting managed cluster in dry-run mode", "managedCluster", spec.Name)
+		return nil
+	}
+
+	s.Scope.SetAdoptedProperties(adoptedProperties(existing))
+
+	log.V(2).Info("adopting managed cluster", "managedCluster", spec.Name)
+	if err := s.Adopter.MergeTags(ctx, to.String(existing.ID), map[string]*string{
+		infrav1.ClusterTagKey(spec.ClusterName): to.StringPtr(string(infrav1.ResourceLifecycleOwned)),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to tag managed cluster %s as owned", spec.Name)
+	}
+	log.V(2).Info("successfully adopted managed cluster", "managedCluster", spec.Name)
+	return nil
+}
+
+// adoptionMismatches returns the differences between a managed cluster spec and an existing managed cluster that
+// prevent the managed cluster from being adopted, because the properties that differ cannot be updated.
+func adoptionMismatches(spec *ManagedClusterSpec, existing containerservice.ManagedCluster) ([]string, error) {
+	if existing.ManagedClusterProperties == nil {
+		return nil, errors.Errorf("managed cluster %s has no properties", spec.Name)
+	}
+
+	var mismatches []string
+	compare := func(property, desired, actual string) {
+		if desired != "" && !strings.EqualFold(desired, actual) {
+			mismatches = append(mismatches, fmt.Sprintf("%s is %q instead of %q", property, actual, desired))
+		}
+	}
+
+	compare("location", spec.Location, to.String(existing.Location))
+	compare("node resource group", spec.NodeResourceGroup, to.String(existing.NodeResourceGroup))
+	version := to.String(existing.KubernetesVersion)
+	if spec.Version != "" && semver.Compare("v"+strings.TrimPrefix(spec.Version, "v"), "v"+strings.TrimPrefix(version, "v")) < 0 {
+		mismatches = append(mismatches, fmt.Sprintf("version is %q, which is newer than %q", version, spec.Version))
+	}
+
+	if networkProfile := existing.NetworkProfile; networkProfile != nil {
+		compare("network plugin", spec.NetworkPlugin, string(networkProfile.NetworkPlugin))
+		compare("network policy", spec.NetworkPolicy, string(networkProfile.NetworkPolicy))
+		compare("load balancer SKU", spec.LoadBalancerSKU, string(networkProfile.LoadBalancerSku))
+		compare("DNS service IP", to.String(spec.DNSServiceIP), to.String(networkProfile.DNSServiceIP))
+		compare("pod CIDR", spec.PodCIDR, to.String(networkProfile.PodCidr))
+		compare("service CIDR", spec.ServiceCIDR, to.String(networkProfile.ServiceCidr))
+	}
+
+	if existing.ServicePrincipalProfile != nil && to.String(existing.ServicePrincipalProfile.ClientID) != "msi" {
+		mismatches = append(mismatches, "managed cluster uses a service principal instead of a managed identity")
+	} else if existing.Identity != nil {
+		identityType := string(containerservice.ResourceIdentityTypeSystemAssigned)
+		if spec.Identity != nil {
+			identityType = spec.Identity.Type
+		}
+		compare("identity type", identityType, string(existing.Identity.Type))
+	}
+
+	if spec.GetAllAgentPools == nil {
+		return mismatches, nil
+	}
+	agentPoolSpecs, err := spec.GetAllAgentPools()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get agent pool specs for managed cluster %s", spec.Name)
+	}
+	existingAgentPools := make(map[string]containerservice.ManagedClusterAgentPoolProfile)
+	if existing.AgentPoolProfiles != nil {
+		for _, profile := range *existing.AgentPoolProfiles {
+			existingAgentPools[to.String(profile.Name)] = profile
+		}
+	}
+	for _, agentPoolSpec := range agentPoolSpecs {
+		existingAgentPool, ok := existingAgentPools[agentPoolSpec.ResourceName()]
+		if !ok {
+			// The agent pool is created once the managed cluster is adopted.
+			continue
+		}
+		params, err := agentPoolSpec.Parameters(nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get agent pool parameters for managed cluster %s", spec.Name)
+		}
+		agentPool, ok := params.(containerservice.AgentPool)
+		if !ok {
+			return nil, errors.Errorf("%T is not a containerservice.AgentPool", params)
+		}
+		desired := converters.AgentPoolToManagedClusterAgentPoolProfile(agentPool)
+		prefix := fmt.Sprintf("agent pool %s ", agentPoolSpec.ResourceName())
+		compare(prefix+"VM size", to.String(desired.VMSize), to.String(existingAgentPool.VMSize))
+		compare(prefix+"mode", string(desired.Mode), string(existingAgentPool.Mode))
+		compare(prefix+"OS type", string(desired.OsType), string(existingAgentPool.OsType))
+		compare(prefix+"subnet", to.String(desired.VnetSubnetID), to.String(existingAgentPool.VnetSubnetID))
+		if to.Int32(desired.OsDiskSizeGB) != 0 && to.Int32(desired.OsDiskSizeGB) != to.Int32(existingAgentPool.OsDiskSizeGB) {
+			mismatches = append(mismatches, fmt.Sprintf("%sOS disk size is %d GB instead of %d GB", prefix, to.Int32(existingAgentPool.OsDiskSizeGB), to.Int32(desired.OsDiskSizeGB)))
+		}
+	}
+
+	return mismatches, nil
+}
+
+// adoptedProperties returns the properties of an existing managed cluster set in the AzureManagedControlPlane that
+// adopts it.
+func adoptedProperties(existing containerservice.ManagedCluster) azure.AdoptedManagedClusterProperties {
+	properties := azure.AdoptedManagedClusterProperties{
+		Tags: make(map[string]string, len(existing.Tags)),
+	}
+	if linuxProfile := existing.LinuxProfile; linuxProfile != nil && linuxProfile.SSH != nil &&
+		linuxProfile.SSH.PublicKeys != nil && len(*linuxProfile.SSH.PublicKeys) > 0 {
+		properties.SSHPublicKey = base64.StdEncoding.EncodeToString([]byte(to.String((*linuxProfile.SSH.PublicKeys)[0].KeyData)))
+	}
+	if networkProfile := existing.NetworkProfile; networkProfile != nil {
+		properties.NetworkPlugin = string(networkProfile.NetworkPlugin)
+		properties.NetworkPolicy = string(networkProfile.NetworkPolicy)
+		properties.LoadBalancerSKU = string(networkProfile.LoadBalancerSku)
+		properties.DNSServiceIP = to.String(networkProfile.DNSServiceIP)
+	}
+	for key, value := range existing.Tags {
+		properties.Tags[key] = to.String(value)
+	}
+	if existing.AgentPoolProfiles != nil {
+		properties.AgentPools = make(map[string]azure.AdoptedAgentPoolProperties, len(*existing.AgentPoolProfiles))
+		for _, profile := range *existing.AgentPoolProfiles {
+			agentPool := azure.AdoptedAgentPoolProperties{
+				OSDiskSizeGB: profile.OsDiskSizeGB,
+				OSDiskType:   string(profile.OsDiskType),
+				MaxPods:      profile.MaxPods,
+			}
+			if len(profile.NodeLabels) > 0 {
+				agentPool.NodeLabels = make(map[string]string, len(profile.NodeLabels))
+				for key, value := range profile.NodeLabels {
+					agentPool.NodeLabels[key] = to.String(value)
+				}
+			}
+			if to.Bool(profile.EnableAutoScaling) {
+				agentPool.MinCount = profile.MinCount
+				agentPool.MaxCount = profile.MaxCount
+			}
+			properties.AgentPools[to.String(profile.Name)] = agentPool
+		}
+	}
+	return properties
+}
+
+// missingAdoptedProperties returns true if the managed cluster spec leaves unset a property that is set from the
+// adopted managed cluster.
+func missingAdoptedProperties(spec *ManagedClusterSpec, properties azure.AdoptedManagedClusterProperties) bool {
+	return (spec.SSHPublicKey == "" && properties.SSHPublicKey != "") ||
+		(spec.NetworkPlugin == "" && properties.NetworkPlugin != "") ||
+		(spec.NetworkPolicy == "" && properties.NetworkPolicy != "") ||
+		(spec.LoadBalancerSKU == "" && properties.LoadBalancerSKU != "") ||
+		(spec.DNSServiceIP == nil && properties.DNSServiceIP != "")
+}
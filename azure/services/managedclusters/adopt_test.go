@@ -0,0 +1,188 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package managedclusters
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2021-05-01/containerservice"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/agentpools"
+)
+
+func TestAdoptionMismatches(t *testing.T) {
+	testcases := []struct {
+		name               string
+		spec               *ManagedClusterSpec
+		existing           func() containerservice.ManagedCluster
+		expectedMismatches []string
+	}{
+		{
+			name: "managed cluster matches the spec",
+			spec: &ManagedClusterSpec{
+				Name:              "test-managedcluster",
+				Location:          "test-location",
+				NodeResourceGroup: "test-node-rg",
+				Version:           "1.22.0",
+				LoadBalancerSKU:   "Standard",
+				GetAllAgentPools: func() ([]azure.ResourceSpecGetter, error) {
+					return []azure.ResourceSpecGetter{
+						&agentpools.AgentPoolSpec{Name: "test-agentpool-1", SKU: "test_SKU", Mode: "User", Replicas: 4, VnetSubnetID: "fake/subnet/id"},
+						&agentpools.AgentPoolSpec{Name: "test-agentpool-2", SKU: "Standard_D2s_v3", Mode: "User", Replicas: 1},
+					}, nil
+				},
+			},
+			existing:           getExistingCluster,
+			expectedMismatches: nil,
+		},
+		{
+			name: "managed cluster does not match the spec",
+			spec: &ManagedClusterSpec{
+				Name:              "test-managedcluster",
+				Location:          "other-location",
+				NodeResourceGroup: "test-node-rg",
+				Version:           "1.21.2",
+				NetworkPlugin:     "kubenet",
+				LoadBalancerSKU:   "Standard",
+				GetAllAgentPools: func() ([]azure.ResourceSpecGetter, error) {
+					return []azure.ResourceSpecGetter{
+						&agentpools.AgentPoolSpec{Name: "test-agentpool-1", SKU: "Standard_D2s_v3", Mode: "User", Replicas: 4, VnetSubnetID: "fake/subnet/id"},
+					}, nil
+				},
+			},
+			existing: getExistingCluster,
+			expectedMismatches: []string{
+				`location is "test-location" instead of "other-location"`,
+				`version is "v1.22.0", which is newer than "1.21.2"`,
+				`network plugin is "" instead of "kubenet"`,
+				`agent pool test-agentpool-1 VM size is "test_SKU" instead of "Standard_D2s_v3"`,
+			},
+		},
+		{
+			name: "managed cluster uses a service principal",
+			spec: &ManagedClusterSpec{
+				Name: "test-managedcluster",
+			},
+			existing: func() containerservice.ManagedCluster {
+				mc := getExistingCluster()
+				mc.ServicePrincipalProfile.ClientID = to.StringPtr("00000000-0000-0000-0000-000000000000")
+				return mc
+			},
+			expectedMismatches: []string{
+				"managed cluster uses a service principal instead of a managed identity",
+			},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			mismatches, err := adoptionMismatches(tc.spec, tc.existing())
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(mismatches).To(Equal(tc.expectedMismatches))
+		})
+	}
+}
+
+func TestAdoptedProperties(t *testing.T) {
+	g := NewWithT(t)
+
+	existing := getExistingCluster()
+	(*existing.LinuxProfile.SSH.PublicKeys)[0].KeyData = to.StringPtr("ssh-rsa AAAA")
+	existing.NetworkProfile.NetworkPlugin = containerservice.NetworkPluginKubenet
+	existing.NetworkProfile.DNSServiceIP = to.StringPtr("10.0.0.10")
+	agentPool := &(*existing.AgentPoolProfiles)[1]
+	agentPool.OsDiskType = containerservice.OSDiskTypeManaged
+	agentPool.NodeLabels = map[string]*string{"workload": to.StringPtr("batch")}
+	agentPool.EnableAutoScaling = to.BoolPtr(true)
+	agentPool.MinCount = to.Int32Ptr(1)
+	agentPool.MaxCount = to.Int32Ptr(5)
+
+	g.Expect(adoptedProperties(existing)).To(Equal(azure.AdoptedManagedClusterProperties{
+		SSHPublicKey:    base64.StdEncoding.EncodeToString([]byte("ssh-rsa AAAA")),
+		NetworkPlugin:   "kubenet",
+		LoadBalancerSKU: "Standard",
+		DNSServiceIP:    "10.0.0.10",
+		Tags:            map[string]string{"test-tag": "test-value"},
+		AgentPools: map[string]azure.AdoptedAgentPoolProperties{
+			"test-agentpool-0": {
+				OSDiskSizeGB: to.Int32Ptr(0),
+			},
+			"test-agentpool-1": {
+				OSDiskSizeGB: to.Int32Ptr(0),
+				OSDiskType:   "Managed",
+				MaxPods:      to.Int32Ptr(32),
+				NodeLabels:   map[string]string{"workload": "batch"},
+				MinCount:     to.Int32Ptr(1),
+				MaxCount:     to.Int32Ptr(5),
+			},
+		},
+	}))
+}
+
+func TestMissingAdoptedProperties(t *testing.T) {
+	properties := azure.AdoptedManagedClusterProperties{
+		SSHPublicKey:    "c3NoLXJzYSBBQUFB",
+		NetworkPlugin:   "kubenet",
+		LoadBalancerSKU: "Standard",
+	}
+	testcases := []struct {
+		name     string
+		spec     *ManagedClusterSpec
+		expected bool
+	}{
+		{
+			name: "spec sets the adopted properties",
+			spec: &ManagedClusterSpec{
+				SSHPublicKey:    "c3NoLXJzYSBCQkJC",
+				NetworkPlugin:   "kubenet",
+				LoadBalancerSKU: "Standard",
+			},
+			expected: false,
+		},
+		{
+			name: "spec leaves the network plugin unset",
+			spec: &ManagedClusterSpec{
+				SSHPublicKey:    "c3NoLXJzYSBCQkJC",
+				LoadBalancerSKU: "Standard",
+			},
+			expected: true,
+		},
+		{
+			name: "spec leaves unset a property the managed cluster doesn't have",
+			spec: &ManagedClusterSpec{
+				SSHPublicKey:    "c3NoLXJzYSBCQkJC",
+				NetworkPlugin:   "kubenet",
+				LoadBalancerSKU: "Standard",
+				NetworkPolicy:   "",
+			},
+			expected: false,
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(missingAdoptedProperties(tc.spec, properties)).To(Equal(tc.expected))
+		})
+	}
+}
@@ -21,11 +21,14 @@ import (
 	"encoding/json"
 
 	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2021-05-01/containerservice"
+	previewcontainerservice "github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
 	"github.com/Azure/go-autorest/autorest"
 	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/pkg/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/feature"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
@@ -35,16 +38,32 @@ type CredentialGetter interface {
 	GetCredentials(context.Context, string, string) ([]byte, error)
 }
 
+// Adopter is a helper interface for adopting existing managed clusters.
+type Adopter interface {
+	Get(context.Context, azure.ResourceSpecGetter) (interface{}, error)
+	MergeTags(ctx context.Context, resourceID string, tags map[string]*string) error
+}
+
 // azureClient contains the Azure go-sdk Client.
 type azureClient struct {
 	managedclusters containerservice.ManagedClustersClient
+	// previewManagedClusters is the client of the preview AKS API, which is only set when the AKSPreview feature gate
+	// is enabled. Managed clusters are then read and written with the preview API, and deleted with the GA API.
+	previewManagedClusters *previewcontainerservice.ManagedClustersClient
+	tags                   resources.TagsClient
 }
 
 // newClient creates a new managed cluster client from an authorizer.
 func newClient(auth azure.Authorizer) *azureClient {
-	return &azureClient{
+	client := &azureClient{
 		managedclusters: newManagedClustersClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		tags:            newTagsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+	}
+	if feature.Gates.Enabled(feature.AKSPreview) {
+		previewClient := newPreviewManagedClustersClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
+		client.previewManagedClusters = &previewClient
 	}
+	return client
 }
 
 // newManagedClustersClient creates a new managed clusters client from subscription ID.
@@ -54,14 +73,44 @@ func newManagedClustersClient(subscriptionID string, baseURI string, authorizer
 	return managedClustersClient
 }
 
+// newPreviewManagedClustersClient creates a new managed clusters client of the preview AKS API from subscription ID.
+func newPreviewManagedClustersClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) previewcontainerservice.ManagedClustersClient {
+	managedClustersClient := previewcontainerservice.NewManagedClustersClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&managedClustersClient.Client, authorizer)
+	return managedClustersClient
+}
+
+// newTagsClient creates a new tags client from subscription ID.
+func newTagsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) resources.TagsClient {
+	tagsClient := resources.NewTagsClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&tagsClient.Client, authorizer)
+	return tagsClient
+}
+
 // Get gets a managed cluster.
 func (ac *azureClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (result interface{}, err error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "managedclusters.azureClient.Get")
 	defer done()
 
+	if ac.previewManagedClusters != nil {
+		return ac.getPreview(ctx, spec.ResourceGroupName(), spec.ResourceName())
+	}
 	return ac.managedclusters.Get(ctx, spec.ResourceGroupName(), spec.ResourceName())
 }
 
+// getPreview gets a managed cluster with the preview API.
+func (ac *azureClient) getPreview(ctx context.Context, resourceGroupName, name string) (previewManagedCluster, error) {
+	req, err := ac.previewManagedClusters.GetPreparer(ctx, resourceGroupName, name)
+	if err != nil {
+		return previewManagedCluster{}, autorest.NewErrorWithError(err, "containerservice.ManagedClustersClient", "Get", nil, "Failure preparing request")
+	}
+	resp, err := ac.previewManagedClusters.GetSender(req)
+	if err != nil {
+		return previewManagedCluster{}, autorest.NewErrorWithError(err, "containerservice.ManagedClustersClient", "Get", resp, "Failure sending request")
+	}
+	return respondPreview(resp, "Get")
+}
+
 // GetCredentials fetches the admin kubeconfig for a managed cluster.
 func (ac *azureClient) GetCredentials(ctx context.Context, resourceGroupName, name string) ([]byte, error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "managedclusters.azureClient.GetCredentials")
@@ -79,6 +128,18 @@ func (ac *azureClient) GetCredentials(ctx context.Context, resourceGroupName, na
 	return *(*credentialList.Kubeconfigs)[0].Value, nil
 }
 
+// MergeTags adds tags to a managed cluster, keeping its other tags.
+func (ac *azureClient) MergeTags(ctx context.Context, resourceID string, tags map[string]*string) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "managedclusters.azureClient.MergeTags")
+	defer done()
+
+	_, err := ac.tags.UpdateAtScope(ctx, resourceID, resources.TagsPatchResource{
+		Operation:  resources.TagsPatchOperationMerge,
+		Properties: &resources.Tags{Tags: tags},
+	})
+	return err
+}
+
 // CreateOrUpdateAsync creates or updates a managed cluster.
 // It sends a PUT request to Azure and if accepted without error, the func will return a Future which can be used to track the ongoing
 // progress of the operation.
@@ -86,8 +147,20 @@ func (ac *azureClient) CreateOrUpdateAsync(ctx context.Context, spec azure.Resou
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "managedclusters.azureClient.CreateOrUpdate")
 	defer done()
 
+	headerSpec, ok := spec.(azure.ResourceSpecGetterWithHeaders)
+	if !ok {
+		return nil, nil, errors.Errorf("%T is not a azure.ResourceSpecGetterWithHeaders", spec)
+	}
+
+	if ac.previewManagedClusters != nil {
+		return ac.createOrUpdatePreview(ctx, spec, headerSpec.CustomHeaders(), parameters)
+	}
+
 	managedcluster, ok := parameters.(containerservice.ManagedCluster)
 	if !ok {
+		if _, ok := parameters.(previewManagedCluster); ok {
+			return nil, nil, errors.New("the OIDC issuer and workload identity require the AKSPreview feature gate to be enabled")
+		}
 		return nil, nil, errors.Errorf("%T is not a containerservice.ManagedCluster", parameters)
 	}
 
@@ -96,11 +169,6 @@ func (ac *azureClient) CreateOrUpdateAsync(ctx context.Context, spec azure.Resou
 		return nil, nil, errors.Wrap(err, "failed to prepare operation")
 	}
 
-	headerSpec, ok := spec.(azure.ResourceSpecGetterWithHeaders)
-	if !ok {
-		return nil, nil, errors.Errorf("%T is not a azure.ResourceSpecGetterWithHeaders", spec)
-	}
-
 	for key, value := range headerSpec.CustomHeaders() {
 		preparer.Header.Add(key, value)
 	}
@@ -125,6 +193,73 @@ func (ac *azureClient) CreateOrUpdateAsync(ctx context.Context, spec azure.Resou
 	return result, nil, err
 }
 
+// createOrUpdatePreview creates or updates a managed cluster with the preview API.
+func (ac *azureClient) createOrUpdatePreview(ctx context.Context, spec azure.ResourceSpecGetter, headers map[string]string, parameters interface{}) (result interface{}, future azureautorest.FutureAPI, err error) {
+	var managedcluster previewManagedCluster
+	switch params := parameters.(type) {
+	case previewManagedCluster:
+		managedcluster = params
+	case containerservice.ManagedCluster:
+		managedcluster = previewManagedCluster{ManagedCluster: params}
+	default:
+		return nil, nil, errors.Errorf("%T is not a containerservice.ManagedCluster", parameters)
+	}
+	previewParams, err := toPreviewParameters(managedcluster)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	preparer, err := ac.previewManagedClusters.CreateOrUpdatePreparer(ctx, spec.ResourceGroupName(), spec.ResourceName(), previewParams)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to prepare operation")
+	}
+
+	for key, value := range headers {
+		preparer.Header.Add(key, value)
+	}
+
+	createFuture, err := ac.previewManagedClusters.CreateOrUpdateSender(preparer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
+	defer cancel()
+
+	err = createFuture.WaitForCompletionRef(ctx, ac.previewManagedClusters.Client)
+	if err != nil {
+		// if an error occurs, return the future.
+		// this means the long-running operation didn't finish in the specified timeout.
+		return nil, &createFuture, err
+	}
+
+	result, err = ac.previewResult(&createFuture)
+	// if the operation completed, return a nil future
+	return result, nil, err
+}
+
+// previewResult fetches the managed cluster resulting from a completed long-running operation of the preview API.
+func (ac *azureClient) previewResult(future azureautorest.FutureAPI) (previewManagedCluster, error) {
+	// Marshal and Unmarshal the future to get the generic azureautorest.Future, whose result can be read without the
+	// preview SDK types.
+	var createFuture azureautorest.Future
+	jsonData, err := future.MarshalJSON()
+	if err != nil {
+		return previewManagedCluster{}, errors.Wrap(err, "failed to marshal future")
+	}
+	if err := json.Unmarshal(jsonData, &createFuture); err != nil {
+		return previewManagedCluster{}, errors.Wrap(err, "failed to unmarshal future data")
+	}
+
+	client := ac.previewManagedClusters
+	sender := autorest.DecorateSender(client, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+	resp, err := createFuture.GetResult(sender)
+	if err != nil {
+		return previewManagedCluster{}, autorest.NewErrorWithError(err, "containerservice.ManagedClustersCreateOrUpdateFuture", "Result", resp, "Failure sending request")
+	}
+	return respondPreview(resp, "CreateOrUpdate")
+}
+
 // DeleteAsync deletes a managed cluster asynchronously. DeleteAsync sends a DELETE
 // request to Azure and if accepted without error, the func will return a Future which can be used to track the ongoing
 // progress of the operation.
@@ -170,6 +305,9 @@ func (ac *azureClient) Result(ctx context.Context, future azureautorest.FutureAP
 
 	switch futureType {
 	case infrav1.PutFuture:
+		if ac.previewManagedClusters != nil {
+			return ac.previewResult(future)
+		}
 		// Marshal and Unmarshal the future to put it into the correct future type so we can access the Result function.
 		// Unfortunately the FutureAPI can't be casted directly to ManagedClustersCreateOrUpdateFuture because it is a azureautorest.Future, which doesn't implement the Result function. See PR #1686 for discussion on alternatives.
 		// It was converted back to a generic azureautorest.Future from the CAPZ infrav1.Future type stored in Status: https://github.com/kubernetes-sigs/cluster-api-provider-azure/blob/main/azure/converters/futures.go#L49.
@@ -19,7 +19,6 @@ package managedclusters
 import (
 	"context"
 
-	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2021-05-01/containerservice"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -39,9 +38,14 @@ type ManagedClusterScope interface {
 	azure.AsyncStatusUpdater
 	ManagedClusterSpec(context.Context) azure.ResourceSpecGetter
 	SetControlPlaneEndpoint(clusterv1.APIEndpoint)
+	SetControlPlaneVersion(string)
 	MakeEmptyKubeConfigSecret() corev1.Secret
 	GetKubeConfigData() []byte
 	SetKubeConfigData([]byte)
+	SetOIDCIssuerURL(string)
+	IsAdopting() bool
+	SetAdoptedProperties(azure.AdoptedManagedClusterProperties)
+	UpdateAdoptionStatus(error)
 }
 
 // Service provides operations on azure resources.
@@ -49,6 +53,7 @@ type Service struct {
 	Scope ManagedClusterScope
 	async.Reconciler
 	CredentialGetter
+	Adopter
 }
 
 // New creates a new service.
@@ -58,6 +63,7 @@ func New(scope ManagedClusterScope) *Service {
 		Scope:            scope,
 		Reconciler:       async.New(scope, client, client),
 		CredentialGetter: client,
+		Adopter:          client,
 	}
 }
 
@@ -79,11 +85,26 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return nil
 	}
 
+	// An existing managed cluster is only reconciled once it is adopted.
+	if s.Scope.IsAdopting() {
+		spec, ok := managedClusterSpec.(*ManagedClusterSpec)
+		if !ok {
+			return errors.Errorf("%T is not a managedclusters.ManagedClusterSpec", managedClusterSpec)
+		}
+		err := s.adopt(ctx, spec)
+		s.Scope.UpdateAdoptionStatus(err)
+		if err != nil {
+			return err
+		}
+		// The spec includes the properties set from the adopted managed cluster.
+		managedClusterSpec = s.Scope.ManagedClusterSpec(ctx)
+	}
+
 	result, resultErr := s.CreateOrUpdateResource(ctx, managedClusterSpec, serviceName)
 	if resultErr == nil {
-		managedCluster, ok := result.(containerservice.ManagedCluster)
-		if !ok {
-			return errors.Errorf("%T is not a containerservice.ManagedCluster", result)
+		managedCluster, preview, err := managedClusterFrom(result)
+		if err != nil {
+			return err
 		}
 		// Update control plane endpoint.
 		endpoint := clusterv1.APIEndpoint{
@@ -92,6 +113,22 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		}
 		s.Scope.SetControlPlaneEndpoint(endpoint)
 
+		// Update the Kubernetes version the control plane runs, which lags behind the desired version during an upgrade.
+		// Only the preview API returns the current version. The GA API returns the desired version, which the control
+		// plane runs once the managed cluster is provisioned.
+		if preview != nil && to.String(preview.CurrentKubernetesVersion) != "" {
+			s.Scope.SetControlPlaneVersion(to.String(preview.CurrentKubernetesVersion))
+		} else if to.String(managedCluster.ProvisioningState) == string(infrav1.Succeeded) {
+			s.Scope.SetControlPlaneVersion(to.String(managedCluster.KubernetesVersion))
+		}
+
+		// Update the OIDC issuer URL, which AKS only returns when the OIDC issuer is enabled.
+		var oidcIssuerURL string
+		if preview != nil && preview.OidcIssuerProfile != nil {
+			oidcIssuerURL = to.String(preview.OidcIssuerProfile.IssuerURL)
+		}
+		s.Scope.SetOIDCIssuerURL(oidcIssuerURL)
+
 		// Update kubeconfig data
 		// Always fetch credentials in case of rotation
 		kubeConfigData, err := s.GetCredentials(ctx, managedClusterSpec.ResourceGroupName(), managedClusterSpec.ResourceName())
@@ -19,13 +19,17 @@ package managedclusters
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2021-05-01/containerservice"
+	previewcontainerservice "github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/managedclusters/mock_managedclusters"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
@@ -52,6 +56,7 @@ func TestReconcile(t *testing.T) {
 			expectedError: "some unexpected error occurred",
 			expect: func(m *mock_managedclusters.MockCredentialGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.ManagedClusterSpec(gomockinternal.AContext()).Return(fakeManagedClusterSpec)
+				s.IsAdopting().Return(false)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeManagedClusterSpec, serviceName).Return(nil, errors.New("some unexpected error occurred"))
 				s.UpdatePutStatus(infrav1.ManagedClusterRunningCondition, serviceName, errors.New("some unexpected error occurred"))
 			},
@@ -61,16 +66,103 @@ func TestReconcile(t *testing.T) {
 			expectedError: "",
 			expect: func(m *mock_managedclusters.MockCredentialGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.ManagedClusterSpec(gomockinternal.AContext()).Return(fakeManagedClusterSpec)
+				s.IsAdopting().Return(false)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeManagedClusterSpec, serviceName).Return(containerservice.ManagedCluster{
 					ManagedClusterProperties: &containerservice.ManagedClusterProperties{
 						Fqdn:              pointer.String("my-managedcluster-fqdn"),
 						ProvisioningState: pointer.String("Succeeded"),
+						KubernetesVersion: pointer.String("1.24.3"),
 					},
 				}, nil)
 				s.SetControlPlaneEndpoint(clusterv1.APIEndpoint{
 					Host: "my-managedcluster-fqdn",
 					Port: 443,
 				})
+				s.SetControlPlaneVersion("1.24.3")
+				s.SetOIDCIssuerURL("")
+				m.GetCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return([]byte("credentials"), nil)
+				s.SetKubeConfigData([]byte("credentials"))
+				s.UpdatePutStatus(infrav1.ManagedClusterRunningCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "failed managed cluster doesn't update the control plane version",
+			expectedError: "",
+			expect: func(m *mock_managedclusters.MockCredentialGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ManagedClusterSpec(gomockinternal.AContext()).Return(fakeManagedClusterSpec)
+				s.IsAdopting().Return(false)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeManagedClusterSpec, serviceName).Return(containerservice.ManagedCluster{
+					ManagedClusterProperties: &containerservice.ManagedClusterProperties{
+						Fqdn:              pointer.String("my-managedcluster-fqdn"),
+						ProvisioningState: pointer.String("Failed"),
+						KubernetesVersion: pointer.String("1.24.3"),
+					},
+				}, nil)
+				s.SetControlPlaneEndpoint(clusterv1.APIEndpoint{
+					Host: "my-managedcluster-fqdn",
+					Port: 443,
+				})
+				s.SetOIDCIssuerURL("")
+				m.GetCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return([]byte("credentials"), nil)
+				s.SetKubeConfigData([]byte("credentials"))
+				s.UpdatePutStatus(infrav1.ManagedClusterRunningCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "create managed cluster with the preview API succeeds",
+			expectedError: "",
+			expect: func(m *mock_managedclusters.MockCredentialGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ManagedClusterSpec(gomockinternal.AContext()).Return(fakeManagedClusterSpec)
+				s.IsAdopting().Return(false)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeManagedClusterSpec, serviceName).Return(previewManagedCluster{
+					ManagedCluster: containerservice.ManagedCluster{
+						ManagedClusterProperties: &containerservice.ManagedClusterProperties{
+							Fqdn:              pointer.String("my-managedcluster-fqdn"),
+							ProvisioningState: pointer.String("Succeeded"),
+							KubernetesVersion: pointer.String("1.24.3"),
+						},
+					},
+					preview: previewProperties{
+						CurrentKubernetesVersion: pointer.String("1.23.8"),
+					},
+				}, nil)
+				s.SetControlPlaneEndpoint(clusterv1.APIEndpoint{
+					Host: "my-managedcluster-fqdn",
+					Port: 443,
+				})
+				s.SetControlPlaneVersion("1.23.8")
+				s.SetOIDCIssuerURL("")
+				m.GetCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return([]byte("credentials"), nil)
+				s.SetKubeConfigData([]byte("credentials"))
+				s.UpdatePutStatus(infrav1.ManagedClusterRunningCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "create managed cluster with the OIDC issuer enabled succeeds",
+			expectedError: "",
+			expect: func(m *mock_managedclusters.MockCredentialGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ManagedClusterSpec(gomockinternal.AContext()).Return(fakeManagedClusterSpec)
+				s.IsAdopting().Return(false)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeManagedClusterSpec, serviceName).Return(previewManagedCluster{
+					ManagedCluster: containerservice.ManagedCluster{
+						ManagedClusterProperties: &containerservice.ManagedClusterProperties{
+							Fqdn:              pointer.String("my-managedcluster-fqdn"),
+							ProvisioningState: pointer.String("Succeeded"),
+						},
+					},
+					preview: previewProperties{
+						OidcIssuerProfile: &previewcontainerservice.ManagedClusterOIDCIssuerProfile{
+							Enabled:   pointer.Bool(true),
+							IssuerURL: pointer.String("https://oidc.prod-aks.azure.com/my-issuer/"),
+						},
+					},
+				}, nil)
+				s.SetControlPlaneEndpoint(clusterv1.APIEndpoint{
+					Host: "my-managedcluster-fqdn",
+					Port: 443,
+				})
+				s.SetControlPlaneVersion("")
+				s.SetOIDCIssuerURL("https://oidc.prod-aks.azure.com/my-issuer/")
 				m.GetCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return([]byte("credentials"), nil)
 				s.SetKubeConfigData([]byte("credentials"))
 				s.UpdatePutStatus(infrav1.ManagedClusterRunningCondition, serviceName, nil)
@@ -81,6 +173,7 @@ func TestReconcile(t *testing.T) {
 			expectedError: "failed to get credentials for managed cluster: internal server error",
 			expect: func(m *mock_managedclusters.MockCredentialGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.ManagedClusterSpec(gomockinternal.AContext()).Return(fakeManagedClusterSpec)
+				s.IsAdopting().Return(false)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeManagedClusterSpec, serviceName).Return(containerservice.ManagedCluster{
 					ManagedClusterProperties: &containerservice.ManagedClusterProperties{
 						Fqdn:              pointer.String("my-managedcluster-fqdn"),
@@ -91,6 +184,8 @@ func TestReconcile(t *testing.T) {
 					Host: "my-managedcluster-fqdn",
 					Port: 443,
 				})
+				s.SetControlPlaneVersion("")
+				s.SetOIDCIssuerURL("")
 				m.GetCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return([]byte(""), errors.New("internal server error"))
 			},
 		},
@@ -126,6 +221,142 @@ func TestReconcile(t *testing.T) {
 	}
 }
 
+func TestReconcileAdopt(t *testing.T) {
+	adoptSpec := &ManagedClusterSpec{
+		Name:          "my-managedcluster",
+		ResourceGroup: "my-rg",
+		ClusterName:   "my-cluster",
+		Location:      "westus2",
+		NetworkPlugin: "azure",
+	}
+	existingManagedCluster := func(tags map[string]*string, networkPlugin containerservice.NetworkPlugin) containerservice.ManagedCluster {
+		return containerservice.ManagedCluster{
+			ID:       pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.ContainerService/managedClusters/my-managedcluster"),
+			Location: pointer.String("westus2"),
+			Tags:     tags,
+			ManagedClusterProperties: &containerservice.ManagedClusterProperties{
+				Fqdn:              pointer.String("my-managedcluster-fqdn"),
+				ProvisioningState: pointer.String("Succeeded"),
+				NetworkProfile: &containerservice.NetworkProfile{
+					NetworkPlugin:   networkPlugin,
+					LoadBalancerSku: containerservice.LoadBalancerSkuStandard,
+				},
+			},
+		}
+	}
+	expectReconcile := func(m *mock_managedclusters.MockCredentialGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+		s.ManagedClusterSpec(gomockinternal.AContext()).Return(adoptSpec)
+		r.CreateOrUpdateResource(gomockinternal.AContext(), adoptSpec, serviceName).Return(existingManagedCluster(nil, containerservice.NetworkPluginAzure), nil)
+		s.SetControlPlaneEndpoint(clusterv1.APIEndpoint{
+			Host: "my-managedcluster-fqdn",
+			Port: 443,
+		})
+		s.SetControlPlaneVersion("")
+		s.SetOIDCIssuerURL("")
+		m.GetCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return([]byte("credentials"), nil)
+		s.SetKubeConfigData([]byte("credentials"))
+		s.UpdatePutStatus(infrav1.ManagedClusterRunningCondition, serviceName, nil)
+	}
+
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(m *mock_managedclusters.MockCredentialGetterMockRecorder, a *mock_managedclusters.MockAdopterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "managed cluster to adopt does not exist",
+			expectedError: "managed cluster my-managedcluster to adopt does not exist in resource group my-rg",
+			expect: func(m *mock_managedclusters.MockCredentialGetterMockRecorder, a *mock_managedclusters.MockAdopterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ManagedClusterSpec(gomockinternal.AContext()).Return(adoptSpec)
+				s.IsAdopting().Return(true)
+				a.Get(gomockinternal.AContext(), adoptSpec).Return(nil, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not Found"))
+				s.UpdateAdoptionStatus(gomock.Any())
+			},
+		},
+		{
+			name:          "managed cluster to adopt does not match the spec",
+			expectedError: `managed cluster my-managedcluster does not match the AzureManagedControlPlane: network plugin is "kubenet" instead of "azure"`,
+			expect: func(m *mock_managedclusters.MockCredentialGetterMockRecorder, a *mock_managedclusters.MockAdopterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ManagedClusterSpec(gomockinternal.AContext()).Return(adoptSpec)
+				s.IsAdopting().Return(true)
+				a.Get(gomockinternal.AContext(), adoptSpec).Return(existingManagedCluster(nil, containerservice.NetworkPluginKubenet), nil)
+				s.UpdateAdoptionStatus(gomock.Any())
+			},
+		},
+		{
+			name:          "managed cluster is adopted",
+			expectedError: "",
+			expect: func(m *mock_managedclusters.MockCredentialGetterMockRecorder, a *mock_managedclusters.MockAdopterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ManagedClusterSpec(gomockinternal.AContext()).Return(adoptSpec)
+				s.IsAdopting().Return(true)
+				a.Get(gomockinternal.AContext(), adoptSpec).Return(existingManagedCluster(map[string]*string{"team": pointer.String("a")}, containerservice.NetworkPluginAzure), nil)
+				s.SetAdoptedProperties(azure.AdoptedManagedClusterProperties{
+					NetworkPlugin:   "azure",
+					LoadBalancerSKU: "standard",
+					Tags:            map[string]string{"team": "a"},
+				})
+				a.MergeTags(gomockinternal.AContext(), "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.ContainerService/managedClusters/my-managedcluster", map[string]*string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": pointer.String("owned"),
+				}).Return(nil)
+				s.UpdateAdoptionStatus(nil)
+				expectReconcile(m, s, r)
+			},
+		},
+		{
+			name:          "managed cluster is already adopted",
+			expectedError: "",
+			expect: func(m *mock_managedclusters.MockCredentialGetterMockRecorder, a *mock_managedclusters.MockAdopterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ManagedClusterSpec(gomockinternal.AContext()).Return(adoptSpec)
+				s.IsAdopting().Return(true)
+				a.Get(gomockinternal.AContext(), adoptSpec).Return(existingManagedCluster(map[string]*string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": pointer.String("owned"),
+				}, containerservice.NetworkPluginKubenet), nil)
+				// The load balancer SKU left unset in the spec is set again from the managed cluster.
+				s.SetAdoptedProperties(azure.AdoptedManagedClusterProperties{
+					NetworkPlugin:   "kubenet",
+					LoadBalancerSKU: "standard",
+					Tags: map[string]string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": "owned",
+					},
+				})
+				s.UpdateAdoptionStatus(nil)
+				expectReconcile(m, s, r)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_managedclusters.NewMockManagedClusterScope(mockCtrl)
+			credsGetterMock := mock_managedclusters.NewMockCredentialGetter(mockCtrl)
+			adopterMock := mock_managedclusters.NewMockAdopter(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(credsGetterMock.EXPECT(), adopterMock.EXPECT(), scopeMock.EXPECT(), reconcilerMock.EXPECT())
+
+			s := &Service{
+				Scope:            scopeMock,
+				CredentialGetter: credsGetterMock,
+				Adopter:          adopterMock,
+				Reconciler:       reconcilerMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestDelete(t *testing.T) {
 	testcases := []struct {
 		name          string
@@ -25,6 +25,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	azure "sigs.k8s.io/cluster-api-provider-azure/azure"
 )
 
 // MockCredentialGetter is a mock of CredentialGetter interface.
@@ -64,3 +65,55 @@ func (mr *MockCredentialGetterMockRecorder) GetCredentials(arg0, arg1, arg2 inte
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredentials", reflect.TypeOf((*MockCredentialGetter)(nil).GetCredentials), arg0, arg1, arg2)
 }
+
+// MockAdopter is a mock of Adopter interface.
+type MockAdopter struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdopterMockRecorder
+}
+
+// MockAdopterMockRecorder is the mock recorder for MockAdopter.
+type MockAdopterMockRecorder struct {
+	mock *MockAdopter
+}
+
+// NewMockAdopter creates a new mock instance.
+func NewMockAdopter(ctrl *gomock.Controller) *MockAdopter {
+	mock := &MockAdopter{ctrl: ctrl}
+	mock.recorder = &MockAdopterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdopter) EXPECT() *MockAdopterMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockAdopter) Get(arg0 context.Context, arg1 azure.ResourceSpecGetter) (interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAdopterMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAdopter)(nil).Get), arg0, arg1)
+}
+
+// MergeTags mocks base method.
+func (m *MockAdopter) MergeTags(ctx context.Context, resourceID string, tags map[string]*string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeTags", ctx, resourceID, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeTags indicates an expected call of MergeTags.
+func (mr *MockAdopterMockRecorder) MergeTags(ctx, resourceID, tags interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeTags", reflect.TypeOf((*MockAdopter)(nil).MergeTags), ctx, resourceID, tags)
+}
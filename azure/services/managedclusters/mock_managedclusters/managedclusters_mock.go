@@ -179,6 +179,20 @@ func (mr *MockManagedClusterScopeMockRecorder) HashKey() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashKey", reflect.TypeOf((*MockManagedClusterScope)(nil).HashKey))
 }
 
+// IsAdopting mocks base method.
+func (m *MockManagedClusterScope) IsAdopting() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAdopting")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsAdopting indicates an expected call of IsAdopting.
+func (mr *MockManagedClusterScopeMockRecorder) IsAdopting() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAdopting", reflect.TypeOf((*MockManagedClusterScope)(nil).IsAdopting))
+}
+
 // MakeEmptyKubeConfigSecret mocks base method.
 func (m *MockManagedClusterScope) MakeEmptyKubeConfigSecret() v1.Secret {
 	m.ctrl.T.Helper()
@@ -207,6 +221,18 @@ func (mr *MockManagedClusterScopeMockRecorder) ManagedClusterSpec(arg0 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ManagedClusterSpec", reflect.TypeOf((*MockManagedClusterScope)(nil).ManagedClusterSpec), arg0)
 }
 
+// SetAdoptedProperties mocks base method.
+func (m *MockManagedClusterScope) SetAdoptedProperties(arg0 azure.AdoptedManagedClusterProperties) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAdoptedProperties", arg0)
+}
+
+// SetAdoptedProperties indicates an expected call of SetAdoptedProperties.
+func (mr *MockManagedClusterScopeMockRecorder) SetAdoptedProperties(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAdoptedProperties", reflect.TypeOf((*MockManagedClusterScope)(nil).SetAdoptedProperties), arg0)
+}
+
 // SetControlPlaneEndpoint mocks base method.
 func (m *MockManagedClusterScope) SetControlPlaneEndpoint(arg0 v1beta10.APIEndpoint) {
 	m.ctrl.T.Helper()
@@ -219,6 +245,18 @@ func (mr *MockManagedClusterScopeMockRecorder) SetControlPlaneEndpoint(arg0 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetControlPlaneEndpoint", reflect.TypeOf((*MockManagedClusterScope)(nil).SetControlPlaneEndpoint), arg0)
 }
 
+// SetControlPlaneVersion mocks base method.
+func (m *MockManagedClusterScope) SetControlPlaneVersion(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetControlPlaneVersion", arg0)
+}
+
+// SetControlPlaneVersion indicates an expected call of SetControlPlaneVersion.
+func (mr *MockManagedClusterScopeMockRecorder) SetControlPlaneVersion(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetControlPlaneVersion", reflect.TypeOf((*MockManagedClusterScope)(nil).SetControlPlaneVersion), arg0)
+}
+
 // SetKubeConfigData mocks base method.
 func (m *MockManagedClusterScope) SetKubeConfigData(arg0 []byte) {
 	m.ctrl.T.Helper()
@@ -243,6 +281,18 @@ func (mr *MockManagedClusterScopeMockRecorder) SetLongRunningOperationState(arg0
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLongRunningOperationState", reflect.TypeOf((*MockManagedClusterScope)(nil).SetLongRunningOperationState), arg0)
 }
 
+// SetOIDCIssuerURL mocks base method.
+func (m *MockManagedClusterScope) SetOIDCIssuerURL(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetOIDCIssuerURL", arg0)
+}
+
+// SetOIDCIssuerURL indicates an expected call of SetOIDCIssuerURL.
+func (mr *MockManagedClusterScopeMockRecorder) SetOIDCIssuerURL(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOIDCIssuerURL", reflect.TypeOf((*MockManagedClusterScope)(nil).SetOIDCIssuerURL), arg0)
+}
+
 // SubscriptionID mocks base method.
 func (m *MockManagedClusterScope) SubscriptionID() string {
 	m.ctrl.T.Helper()
@@ -271,6 +321,18 @@ func (mr *MockManagedClusterScopeMockRecorder) TenantID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockManagedClusterScope)(nil).TenantID))
 }
 
+// UpdateAdoptionStatus mocks base method.
+func (m *MockManagedClusterScope) UpdateAdoptionStatus(arg0 error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateAdoptionStatus", arg0)
+}
+
+// UpdateAdoptionStatus indicates an expected call of UpdateAdoptionStatus.
+func (mr *MockManagedClusterScopeMockRecorder) UpdateAdoptionStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAdoptionStatus", reflect.TypeOf((*MockManagedClusterScope)(nil).UpdateAdoptionStatus), arg0)
+}
+
 // UpdateDeleteStatus mocks base method.
 func (m *MockManagedClusterScope) UpdateDeleteStatus(arg0 v1beta10.ConditionType, arg1 string, arg2 error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package managedclusters
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2021-05-01/containerservice"
+	previewcontainerservice "github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
+	"github.com/Azure/go-autorest/autorest"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/pkg/errors"
+)
+
+// previewManagedCluster is a managed cluster read or written with the preview AKS API, which is only used when the
+// AKSPreview feature gate is enabled. It keeps the GA representation of the managed cluster, so that the rest of the
+// service doesn't depend on the preview API, next to the properties only supported by the preview API.
+type previewManagedCluster struct {
+	containerservice.ManagedCluster
+	preview previewProperties
+}
+
+// previewProperties are the properties of a managed cluster which are only supported by the preview AKS API.
+type previewProperties struct {
+	// CurrentKubernetesVersion is the version of Kubernetes the control plane runs, which lags behind the desired
+	// version during an upgrade.
+	CurrentKubernetesVersion *string
+	// OidcIssuerProfile is the OIDC issuer profile of the managed cluster.
+	OidcIssuerProfile *previewcontainerservice.ManagedClusterOIDCIssuerProfile
+	// SecurityProfile is the security profile of the managed cluster.
+	SecurityProfile *previewcontainerservice.ManagedClusterSecurityProfile
+}
+
+// managedClusterFrom returns the GA representation of a managed cluster and its preview properties, which are nil if it
+// was read with the GA API.
+func managedClusterFrom(v interface{}) (containerservice.ManagedCluster, *previewProperties, error) {
+	switch mc := v.(type) {
+	case containerservice.ManagedCluster:
+		return mc, nil, nil
+	case previewManagedCluster:
+		return mc.ManagedCluster, &mc.preview, nil
+	default:
+		return containerservice.ManagedCluster{}, nil, errors.Errorf("%T is not a containerservice.ManagedCluster", v)
+	}
+}
+
+// toPreviewParameters converts the GA parameters of a managed cluster to the parameters of the preview API, and sets the
+// properties only supported by the preview API.
+func toPreviewParameters(mc previewManagedCluster) (previewcontainerservice.ManagedCluster, error) {
+	var params previewcontainerservice.ManagedCluster
+	data, err := json.Marshal(mc.ManagedCluster)
+	if err != nil {
+		return params, errors.Wrap(err, "failed to marshal managed cluster")
+	}
+	if err := json.Unmarshal(data, &params); err != nil {
+		return params, errors.Wrap(err, "failed to unmarshal managed cluster")
+	}
+	if params.ManagedClusterProperties == nil {
+		params.ManagedClusterProperties = &previewcontainerservice.ManagedClusterProperties{}
+	}
+	params.OidcIssuerProfile = mc.preview.OidcIssuerProfile
+	params.SecurityProfile = mc.preview.SecurityProfile
+	return params, nil
+}
+
+// respondPreview reads a managed cluster returned by the preview API. The response body is read into both the GA and the
+// preview representations, as the preview SDK types don't marshal their read-only properties.
+func respondPreview(resp *http.Response, method string) (previewManagedCluster, error) {
+	var result previewManagedCluster
+	var body bytes.Buffer
+	err := autorest.Respond(
+		resp,
+		azureautorest.WithErrorUnlessStatusCode(http.StatusOK, http.StatusCreated),
+		autorest.ByCopying(&body),
+		autorest.ByUnmarshallingJSON(&result.ManagedCluster),
+		autorest.ByClosing())
+	result.Response = autorest.Response{Response: resp}
+	if err != nil {
+		return result, autorest.NewErrorWithError(err, "containerservice.ManagedClustersClient", method, resp, "Failure responding to request")
+	}
+
+	var preview previewcontainerservice.ManagedCluster
+	if err := json.Unmarshal(body.Bytes(), &preview); err != nil {
+		return result, errors.Wrap(err, "failed to unmarshal preview managed cluster")
+	}
+	if preview.ManagedClusterProperties != nil {
+		result.preview = previewProperties{
+			CurrentKubernetesVersion: preview.CurrentKubernetesVersion,
+			OidcIssuerProfile:        preview.OidcIssuerProfile,
+			SecurityProfile:          preview.SecurityProfile,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package managedclusters
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2021-05-01/containerservice"
+	previewcontainerservice "github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+func TestRespondPreview(t *testing.T) {
+	g := NewWithT(t)
+
+	body := `{
+		"name": "test-managedcluster",
+		"properties": {
+			"provisioningState": "Succeeded",
+			"fqdn": "test-managedcluster.hcp.eastus.azmk8s.io",
+			"kubernetesVersion": "1.24.3",
+			"currentKubernetesVersion": "1.23.8",
+			"oidcIssuerProfile": {"enabled": true, "issuerURL": "https://oidc.prod-aks.azure.com/my-issuer/"},
+			"securityProfile": {"workloadIdentity": {"enabled": true}}
+		}
+	}`
+	resp := &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}
+
+	result, err := respondPreview(resp, "Get")
+	g.Expect(err).NotTo(HaveOccurred())
+	// The read-only properties are kept in the GA representation.
+	g.Expect(result.Name).To(Equal(to.StringPtr("test-managedcluster")))
+	g.Expect(result.ProvisioningState).To(Equal(to.StringPtr("Succeeded")))
+	g.Expect(result.Fqdn).To(Equal(to.StringPtr("test-managedcluster.hcp.eastus.azmk8s.io")))
+	g.Expect(result.KubernetesVersion).To(Equal(to.StringPtr("1.24.3")))
+	g.Expect(result.preview.CurrentKubernetesVersion).To(Equal(to.StringPtr("1.23.8")))
+	g.Expect(result.preview.OidcIssuerProfile.IssuerURL).To(Equal(to.StringPtr("https://oidc.prod-aks.azure.com/my-issuer/")))
+	g.Expect(result.preview.SecurityProfile.WorkloadIdentity.Enabled).To(Equal(to.BoolPtr(true)))
+}
+
+func TestRespondPreviewNotFound(t *testing.T) {
+	g := NewWithT(t)
+
+	resp := &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(`{"error": {"code": "ResourceNotFound"}}`))}
+	_, err := respondPreview(resp, "Get")
+	g.Expect(azure.ResourceNotFound(err)).To(BeTrue())
+}
+
+func TestToPreviewParameters(t *testing.T) {
+	g := NewWithT(t)
+
+	params, err := toPreviewParameters(previewManagedCluster{
+		ManagedCluster: containerservice.ManagedCluster{
+			Location: to.StringPtr("eastus"),
+			ManagedClusterProperties: &containerservice.ManagedClusterProperties{
+				KubernetesVersion: to.StringPtr("1.24.3"),
+				DNSPrefix:         to.StringPtr("test-managedcluster"),
+			},
+		},
+		preview: previewProperties{
+			OidcIssuerProfile: &previewcontainerservice.ManagedClusterOIDCIssuerProfile{Enabled: to.BoolPtr(true)},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(params.Location).To(Equal(to.StringPtr("eastus")))
+	g.Expect(params.KubernetesVersion).To(Equal(to.StringPtr("1.24.3")))
+	g.Expect(params.DNSPrefix).To(Equal(to.StringPtr("test-managedcluster")))
+	g.Expect(params.OidcIssuerProfile.Enabled).To(Equal(to.BoolPtr(true)))
+	g.Expect(params.SecurityProfile).To(BeNil())
+}
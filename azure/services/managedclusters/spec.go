@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2021-05-01/containerservice"
+	previewcontainerservice "github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
@@ -31,6 +32,9 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 )
 
+// kubeletIdentityKey is the key of the kubelet identity in the identity profile of a managed cluster.
+const kubeletIdentityKey = "kubeletidentity"
+
 // ManagedClusterSpec contains properties to create a managed cluster.
 type ManagedClusterSpec struct {
 	// Name is the name of this AKS Cluster.
@@ -39,6 +43,9 @@ type ManagedClusterSpec struct {
 	// ResourceGroup is the name of the Azure resource group for this AKS Cluster.
 	ResourceGroup string
 
+	// ClusterName is the name of the Cluster this AKS Cluster belongs to.
+	ClusterName string
+
 	// NodeResourceGroup is the name of the Azure resource group containing IaaS VMs.
 	NodeResourceGroup string
 
@@ -93,6 +100,24 @@ type ManagedClusterSpec struct {
 	// APIServerAccessProfile is the access profile for AKS API server.
 	APIServerAccessProfile *APIServerAccessProfile
 
+	// AutoScalerProfile is the parameters to be applied to the cluster-autoscaler when enabled.
+	AutoScalerProfile *AutoScalerProfile
+
+	// AutoUpgradeProfile is the auto upgrade configuration of the cluster.
+	AutoUpgradeProfile *AutoUpgradeProfile
+
+	// Identity is the identity of the cluster control plane. Defaults to a system-assigned identity.
+	Identity *Identity
+
+	// KubeletUserAssignedIdentity is the resource ID of the user-assigned identity used by the kubelets.
+	KubeletUserAssignedIdentity string
+
+	// OIDCIssuerProfile is the OIDC issuer profile of the cluster.
+	OIDCIssuerProfile *OIDCIssuerProfile
+
+	// SecurityProfile is the security profile of the cluster.
+	SecurityProfile *SecurityProfile
+
 	// Headers is the list of headers to add to the HTTP requests to update this resource.
 	Headers map[string]string
 }
@@ -157,6 +182,61 @@ type APIServerAccessProfile struct {
 	EnablePrivateClusterPublicFQDN *bool
 }
 
+// AutoScalerProfile is the parameters to be applied to the cluster-autoscaler.
+// Parameters left unset keep the value they have in Azure, which defaults to the AKS default.
+type AutoScalerProfile struct {
+	BalanceSimilarNodeGroups      *string
+	Expander                      *string
+	MaxEmptyBulkDelete            *string
+	MaxGracefulTerminationSec     *string
+	MaxNodeProvisionTime          *string
+	MaxTotalUnreadyPercentage     *string
+	NewPodScaleUpDelay            *string
+	OkTotalUnreadyCount           *string
+	ScanInterval                  *string
+	ScaleDownDelayAfterAdd        *string
+	ScaleDownDelayAfterDelete     *string
+	ScaleDownDelayAfterFailure    *string
+	ScaleDownUnneededTime         *string
+	ScaleDownUnreadyTime          *string
+	ScaleDownUtilizationThreshold *string
+	SkipNodesWithLocalStorage     *string
+	SkipNodesWithSystemPods       *string
+}
+
+// AutoUpgradeProfile is the auto upgrade configuration of an AKS cluster.
+type AutoUpgradeProfile struct {
+	// UpgradeChannel is the channel used to automatically upgrade the cluster.
+	UpgradeChannel string
+}
+
+// Identity is the identity of an AKS control plane.
+type Identity struct {
+	// Type is the type of identity, SystemAssigned or UserAssigned.
+	Type string
+
+	// UserAssignedIdentityResourceID is the resource ID of the user-assigned identity, when Type is UserAssigned.
+	UserAssignedIdentityResourceID string
+}
+
+// OIDCIssuerProfile is the OIDC issuer profile of an AKS cluster.
+type OIDCIssuerProfile struct {
+	// Enabled defines whether the OIDC issuer is enabled.
+	Enabled *bool
+}
+
+// SecurityProfile is the security profile of an AKS cluster.
+type SecurityProfile struct {
+	// WorkloadIdentity are the workload identity settings.
+	WorkloadIdentity *WorkloadIdentity
+}
+
+// WorkloadIdentity are the workload identity settings of an AKS cluster.
+type WorkloadIdentity struct {
+	// Enabled defines whether workload identity is enabled.
+	Enabled *bool
+}
+
 var _ azure.ResourceSpecGetterWithHeaders = (*ManagedClusterSpec)(nil)
 
 // ResourceName returns the name of the AKS cluster.
@@ -303,10 +383,59 @@ func (s *ManagedClusterSpec) Parameters(existing interface{}) (params interface{
 		}
 	}
 
+	if s.Identity != nil && s.Identity.Type == string(containerservice.ResourceIdentityTypeUserAssigned) {
+		managedCluster.Identity = &containerservice.ManagedClusterIdentity{
+			Type: containerservice.ResourceIdentityTypeUserAssigned,
+			UserAssignedIdentities: map[string]*containerservice.ManagedClusterIdentityUserAssignedIdentitiesValue{
+				s.Identity.UserAssignedIdentityResourceID: {},
+			},
+		}
+	}
+
+	if s.KubeletUserAssignedIdentity != "" {
+		managedCluster.IdentityProfile = map[string]*containerservice.ManagedClusterPropertiesIdentityProfileValue{
+			kubeletIdentityKey: {
+				ResourceID: to.StringPtr(s.KubeletUserAssignedIdentity),
+			},
+		}
+	}
+
+	preview := s.previewProperties()
+
+	if s.AutoScalerProfile != nil {
+		managedCluster.AutoScalerProfile = &containerservice.ManagedClusterPropertiesAutoScalerProfile{
+			BalanceSimilarNodeGroups:      s.AutoScalerProfile.BalanceSimilarNodeGroups,
+			MaxEmptyBulkDelete:            s.AutoScalerProfile.MaxEmptyBulkDelete,
+			MaxGracefulTerminationSec:     s.AutoScalerProfile.MaxGracefulTerminationSec,
+			MaxNodeProvisionTime:          s.AutoScalerProfile.MaxNodeProvisionTime,
+			MaxTotalUnreadyPercentage:     s.AutoScalerProfile.MaxTotalUnreadyPercentage,
+			NewPodScaleUpDelay:            s.AutoScalerProfile.NewPodScaleUpDelay,
+			OkTotalUnreadyCount:           s.AutoScalerProfile.OkTotalUnreadyCount,
+			ScanInterval:                  s.AutoScalerProfile.ScanInterval,
+			ScaleDownDelayAfterAdd:        s.AutoScalerProfile.ScaleDownDelayAfterAdd,
+			ScaleDownDelayAfterDelete:     s.AutoScalerProfile.ScaleDownDelayAfterDelete,
+			ScaleDownDelayAfterFailure:    s.AutoScalerProfile.ScaleDownDelayAfterFailure,
+			ScaleDownUnneededTime:         s.AutoScalerProfile.ScaleDownUnneededTime,
+			ScaleDownUnreadyTime:          s.AutoScalerProfile.ScaleDownUnreadyTime,
+			ScaleDownUtilizationThreshold: s.AutoScalerProfile.ScaleDownUtilizationThreshold,
+			SkipNodesWithLocalStorage:     s.AutoScalerProfile.SkipNodesWithLocalStorage,
+			SkipNodesWithSystemPods:       s.AutoScalerProfile.SkipNodesWithSystemPods,
+		}
+		if s.AutoScalerProfile.Expander != nil {
+			managedCluster.AutoScalerProfile.Expander = containerservice.Expander(*s.AutoScalerProfile.Expander)
+		}
+	}
+
+	if s.AutoUpgradeProfile != nil {
+		managedCluster.AutoUpgradeProfile = &containerservice.ManagedClusterAutoUpgradeProfile{
+			UpgradeChannel: containerservice.UpgradeChannel(s.AutoUpgradeProfile.UpgradeChannel),
+		}
+	}
+
 	if existing != nil {
-		existingMC, ok := existing.(containerservice.ManagedCluster)
-		if !ok {
-			return nil, fmt.Errorf("%T is not a containerservice.ManagedCluster", existing)
+		existingMC, existingPreview, err := managedClusterFrom(existing)
+		if err != nil {
+			return nil, err
 		}
 		ps := *existingMC.ManagedClusterProperties.ProvisioningState
 		if ps != string(infrav1.Canceled) && ps != string(infrav1.Failed) && ps != string(infrav1.Succeeded) {
@@ -323,11 +452,26 @@ func (s *ManagedClusterSpec) Parameters(existing interface{}) (params interface{
 			existingMC.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs = nil
 		}
 
+		// Keep the autoscaler parameters that are not set in the spec at their existing value, so that they are neither
+		// reported as a diff below nor reset to their defaults by the update.
+		if managedCluster.AutoScalerProfile != nil && existingMC.AutoScalerProfile != nil {
+			mergeAutoScalerProfile(managedCluster.AutoScalerProfile, existingMC.AutoScalerProfile)
+		}
+
+		// Keep the tag marking an adopted managed cluster as owned by the cluster, so that it is neither reported as a
+		// diff below nor removed by the update.
+		if ownedTag, ok := existingMC.Tags[infrav1.ClusterTagKey(s.ClusterName)]; ok && s.ClusterName != "" {
+			if managedCluster.Tags == nil {
+				managedCluster.Tags = map[string]*string{}
+			}
+			managedCluster.Tags[infrav1.ClusterTagKey(s.ClusterName)] = ownedTag
+		}
+
 		// Avoid changing agent pool profiles through AMCP and just use the existing agent pool profiles
 		// AgentPool changes are managed through AMMP.
 		managedCluster.AgentPoolProfiles = existingMC.AgentPoolProfiles
 
-		diff := computeDiffOfNormalizedClusters(managedCluster, existingMC)
+		diff := computeDiffOfNormalizedClusters(managedCluster, existingMC) + computeDiffOfPreviewProperties(preview, existingPreview)
 		if diff == "" {
 			return nil, nil
 		}
@@ -353,9 +497,35 @@ func (s *ManagedClusterSpec) Parameters(existing interface{}) (params interface{
 		}
 	}
 
+	if preview != nil {
+		return previewManagedCluster{ManagedCluster: managedCluster, preview: *preview}, nil
+	}
 	return managedCluster, nil
 }
 
+// previewProperties returns the properties of the managed cluster which are only supported by the preview AKS API, or
+// nil if none are set.
+func (s *ManagedClusterSpec) previewProperties() *previewProperties {
+	if s.OIDCIssuerProfile == nil && (s.SecurityProfile == nil || s.SecurityProfile.WorkloadIdentity == nil) {
+		return nil
+	}
+
+	preview := &previewProperties{}
+	if s.OIDCIssuerProfile != nil {
+		preview.OidcIssuerProfile = &previewcontainerservice.ManagedClusterOIDCIssuerProfile{
+			Enabled: s.OIDCIssuerProfile.Enabled,
+		}
+	}
+	if s.SecurityProfile != nil && s.SecurityProfile.WorkloadIdentity != nil {
+		preview.SecurityProfile = &previewcontainerservice.ManagedClusterSecurityProfile{
+			WorkloadIdentity: &previewcontainerservice.ManagedClusterSecurityProfileWorkloadIdentity{
+				Enabled: s.SecurityProfile.WorkloadIdentity.Enabled,
+			},
+		}
+	}
+	return preview
+}
+
 func convertToResourceReferences(resources []string) *[]containerservice.ResourceReference {
 	resourceReferences := make([]containerservice.ResourceReference, len(resources))
 	for i := range resources {
@@ -364,6 +534,39 @@ func convertToResourceReferences(resources []string) *[]containerservice.Resourc
 	return &resourceReferences
 }
 
+// mergeAutoScalerProfile sets the parameters of the desired autoscaler profile that are unset to their existing value.
+func mergeAutoScalerProfile(desired, existing *containerservice.ManagedClusterPropertiesAutoScalerProfile) {
+	params := []struct {
+		desired  **string
+		existing *string
+	}{
+		{&desired.BalanceSimilarNodeGroups, existing.BalanceSimilarNodeGroups},
+		{&desired.MaxEmptyBulkDelete, existing.MaxEmptyBulkDelete},
+		{&desired.MaxGracefulTerminationSec, existing.MaxGracefulTerminationSec},
+		{&desired.MaxNodeProvisionTime, existing.MaxNodeProvisionTime},
+		{&desired.MaxTotalUnreadyPercentage, existing.MaxTotalUnreadyPercentage},
+		{&desired.NewPodScaleUpDelay, existing.NewPodScaleUpDelay},
+		{&desired.OkTotalUnreadyCount, existing.OkTotalUnreadyCount},
+		{&desired.ScanInterval, existing.ScanInterval},
+		{&desired.ScaleDownDelayAfterAdd, existing.ScaleDownDelayAfterAdd},
+		{&desired.ScaleDownDelayAfterDelete, existing.ScaleDownDelayAfterDelete},
+		{&desired.ScaleDownDelayAfterFailure, existing.ScaleDownDelayAfterFailure},
+		{&desired.ScaleDownUnneededTime, existing.ScaleDownUnneededTime},
+		{&desired.ScaleDownUnreadyTime, existing.ScaleDownUnreadyTime},
+		{&desired.ScaleDownUtilizationThreshold, existing.ScaleDownUtilizationThreshold},
+		{&desired.SkipNodesWithLocalStorage, existing.SkipNodesWithLocalStorage},
+		{&desired.SkipNodesWithSystemPods, existing.SkipNodesWithSystemPods},
+	}
+	for _, param := range params {
+		if *param.desired == nil {
+			*param.desired = param.existing
+		}
+	}
+	if desired.Expander == "" {
+		desired.Expander = existing.Expander
+	}
+}
+
 func computeDiffOfNormalizedClusters(managedCluster containerservice.ManagedCluster, existingMC containerservice.ManagedCluster) string {
 	// Normalize properties for the desired (CR spec) and existing managed
 	// cluster, so that we check only those fields that were specified in
@@ -417,6 +620,18 @@ func computeDiffOfNormalizedClusters(managedCluster containerservice.ManagedClus
 		}
 	}
 
+	// The autoscaler and auto upgrade profiles are only compared when they are set in the spec, as AKS returns its
+	// defaults otherwise.
+	if managedCluster.AutoScalerProfile != nil {
+		propertiesNormalized.AutoScalerProfile = managedCluster.AutoScalerProfile
+		existingMCPropertiesNormalized.AutoScalerProfile = existingMC.AutoScalerProfile
+	}
+
+	if managedCluster.AutoUpgradeProfile != nil {
+		propertiesNormalized.AutoUpgradeProfile = managedCluster.AutoUpgradeProfile
+		existingMCPropertiesNormalized.AutoUpgradeProfile = existingMC.AutoUpgradeProfile
+	}
+
 	clusterNormalized := &containerservice.ManagedCluster{
 		ManagedClusterProperties: propertiesNormalized,
 		Tags:                     managedCluster.Tags,
@@ -436,3 +651,32 @@ func computeDiffOfNormalizedClusters(managedCluster containerservice.ManagedClus
 	diff := cmp.Diff(clusterNormalized, existingMCClusterNormalized)
 	return diff
 }
+
+// computeDiffOfPreviewProperties returns the difference between the desired and existing properties only supported by
+// the preview AKS API. Whether the OIDC issuer and workload identity are enabled are only compared when they are set in
+// the spec. They are disabled when AKS does not return them.
+func computeDiffOfPreviewProperties(desired, existing *previewProperties) string {
+	if desired == nil {
+		return ""
+	}
+	if existing == nil {
+		existing = &previewProperties{}
+	}
+
+	type normalizedPreviewProperties struct {
+		OIDCIssuerEnabled       *bool
+		WorkloadIdentityEnabled *bool
+	}
+	var desiredNormalized, existingNormalized normalizedPreviewProperties
+	if desired.OidcIssuerProfile != nil {
+		desiredNormalized.OIDCIssuerEnabled = to.BoolPtr(to.Bool(desired.OidcIssuerProfile.Enabled))
+		existingNormalized.OIDCIssuerEnabled = to.BoolPtr(existing.OidcIssuerProfile != nil && to.Bool(existing.OidcIssuerProfile.Enabled))
+	}
+	if desired.SecurityProfile != nil && desired.SecurityProfile.WorkloadIdentity != nil {
+		desiredNormalized.WorkloadIdentityEnabled = to.BoolPtr(to.Bool(desired.SecurityProfile.WorkloadIdentity.Enabled))
+		existingNormalized.WorkloadIdentityEnabled = to.BoolPtr(existing.SecurityProfile != nil && existing.SecurityProfile.WorkloadIdentity != nil &&
+			to.Bool(existing.SecurityProfile.WorkloadIdentity.Enabled))
+	}
+
+	return cmp.Diff(desiredNormalized, existingNormalized)
+}
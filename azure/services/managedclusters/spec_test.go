@@ -110,6 +110,28 @@ func TestParameters(t *testing.T) {
 				g.Expect(result).To(BeNil())
 			},
 		},
+		{
+			name: "managedcluster exists, the owned tag of an adopted cluster is kept",
+			existing: func() containerservice.ManagedCluster {
+				mc := getExistingCluster()
+				mc.Tags["sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster"] = to.StringPtr("owned")
+				return mc
+			}(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				ClusterName:   "test-cluster",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "Standard",
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
 		{
 			name:     "managedcluster exists and an update is needed",
 			existing: getExistingCluster(),
@@ -128,6 +150,148 @@ func TestParameters(t *testing.T) {
 				g.Expect(result.(containerservice.ManagedCluster).KubernetesVersion).To(Equal(to.StringPtr("v1.22.99")))
 			},
 		},
+		{
+			name:     "managedcluster exists, autoscaler parameters not set in the spec are ignored",
+			existing: getExistingClusterWithAutoScalerProfile(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "Standard",
+				AutoScalerProfile: &AutoScalerProfile{
+					ScanInterval: to.StringPtr("10s"),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:     "managedcluster exists and the autoscaler profile needs an update",
+			existing: getExistingClusterWithAutoScalerProfile(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "Standard",
+				AutoScalerProfile: &AutoScalerProfile{
+					Expander:     to.StringPtr("least-waste"),
+					ScanInterval: to.StringPtr("20s"),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(containerservice.ManagedCluster{}))
+				g.Expect(result.(containerservice.ManagedCluster).AutoScalerProfile).To(Equal(&containerservice.ManagedClusterPropertiesAutoScalerProfile{
+					Expander:              containerservice.ExpanderLeastWaste,
+					ScanInterval:          to.StringPtr("20s"),
+					ScaleDownUnneededTime: to.StringPtr("10m"),
+				}))
+			},
+		},
+		{
+			name:     "managedcluster does not exist, with user-assigned identities and workload identity",
+			existing: nil,
+			spec: &ManagedClusterSpec{
+				Name:              "test-managedcluster",
+				ResourceGroup:     "test-rg",
+				NodeResourceGroup: "test-node-rg",
+				Location:          "test-location",
+				Version:           "v1.22.0",
+				Identity: &Identity{
+					Type:                           "UserAssigned",
+					UserAssignedIdentityResourceID: "/subscriptions/123/resourceGroups/test-rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/control-plane",
+				},
+				KubeletUserAssignedIdentity: "/subscriptions/123/resourceGroups/test-rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/kubelet",
+				OIDCIssuerProfile:           &OIDCIssuerProfile{Enabled: to.BoolPtr(true)},
+				SecurityProfile:             &SecurityProfile{WorkloadIdentity: &WorkloadIdentity{Enabled: to.BoolPtr(true)}},
+				GetAllAgentPools: func() ([]azure.ResourceSpecGetter, error) {
+					return []azure.ResourceSpecGetter{}, nil
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(previewManagedCluster{}))
+				mc := result.(previewManagedCluster)
+				g.Expect(mc.Identity).To(Equal(&containerservice.ManagedClusterIdentity{
+					Type: containerservice.ResourceIdentityTypeUserAssigned,
+					UserAssignedIdentities: map[string]*containerservice.ManagedClusterIdentityUserAssignedIdentitiesValue{
+						"/subscriptions/123/resourceGroups/test-rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/control-plane": {},
+					},
+				}))
+				g.Expect(mc.IdentityProfile).To(Equal(map[string]*containerservice.ManagedClusterPropertiesIdentityProfileValue{
+					"kubeletidentity": {ResourceID: to.StringPtr("/subscriptions/123/resourceGroups/test-rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/kubelet")},
+				}))
+				g.Expect(mc.preview.OidcIssuerProfile.Enabled).To(Equal(to.BoolPtr(true)))
+				g.Expect(mc.preview.SecurityProfile.WorkloadIdentity.Enabled).To(Equal(to.BoolPtr(true)))
+			},
+		},
+		{
+			name:     "managedcluster exists and the OIDC issuer needs to be enabled",
+			existing: getExistingCluster(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:           "v1.22.0",
+				LoadBalancerSKU:   "Standard",
+				OIDCIssuerProfile: &OIDCIssuerProfile{Enabled: to.BoolPtr(true)},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(previewManagedCluster{}))
+				g.Expect(result.(previewManagedCluster).preview.OidcIssuerProfile.Enabled).To(Equal(to.BoolPtr(true)))
+			},
+		},
+		{
+			name:     "managedcluster exists, disabled workload identity is not returned by AKS",
+			existing: getExistingCluster(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "Standard",
+				SecurityProfile: &SecurityProfile{WorkloadIdentity: &WorkloadIdentity{Enabled: to.BoolPtr(false)}},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:     "managedcluster exists and the upgrade channel needs an update",
+			existing: getExistingCluster(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "Standard",
+				AutoUpgradeProfile: &AutoUpgradeProfile{
+					UpgradeChannel: "patch",
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(containerservice.ManagedCluster{}))
+				g.Expect(result.(containerservice.ManagedCluster).AutoUpgradeProfile).To(Equal(&containerservice.ManagedClusterAutoUpgradeProfile{
+					UpgradeChannel: containerservice.UpgradeChannelPatch,
+				}))
+			},
+		},
 	}
 	for _, tc := range testcases {
 		tc := tc
@@ -154,6 +318,16 @@ func getExistingCluster() containerservice.ManagedCluster {
 	return mc
 }
 
+func getExistingClusterWithAutoScalerProfile() containerservice.ManagedCluster {
+	mc := getExistingCluster()
+	mc.AutoScalerProfile = &containerservice.ManagedClusterPropertiesAutoScalerProfile{
+		Expander:              containerservice.ExpanderRandom,
+		ScanInterval:          to.StringPtr("10s"),
+		ScaleDownUnneededTime: to.StringPtr("10m"),
+	}
+	return mc
+}
+
 func getSampleManagedCluster() containerservice.ManagedCluster {
 	return containerservice.ManagedCluster{
 		ManagedClusterProperties: &containerservice.ManagedClusterProperties{
@@ -261,6 +261,20 @@ func (mr *MockNatGatewayScopeMockRecorder) DeleteLongRunningOperationState(arg0,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLongRunningOperationState", reflect.TypeOf((*MockNatGatewayScope)(nil).DeleteLongRunningOperationState), arg0, arg1, arg2)
 }
 
+// DriftPolicy mocks base method.
+func (m *MockNatGatewayScope) DriftPolicy() v1beta1.DriftPolicy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DriftPolicy")
+	ret0, _ := ret[0].(v1beta1.DriftPolicy)
+	return ret0
+}
+
+// DriftPolicy indicates an expected call of DriftPolicy.
+func (mr *MockNatGatewayScopeMockRecorder) DriftPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DriftPolicy", reflect.TypeOf((*MockNatGatewayScope)(nil).DriftPolicy))
+}
+
 // FailureDomains mocks base method.
 func (m *MockNatGatewayScope) FailureDomains() []string {
 	m.ctrl.T.Helper()
@@ -584,3 +598,17 @@ func (mr *MockNatGatewayScopeMockRecorder) Vnet() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Vnet", reflect.TypeOf((*MockNatGatewayScope)(nil).Vnet))
 }
+
+// VnetSubscriptionID mocks base method.
+func (m *MockNatGatewayScope) VnetSubscriptionID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VnetSubscriptionID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// VnetSubscriptionID indicates an expected call of VnetSubscriptionID.
+func (mr *MockNatGatewayScopeMockRecorder) VnetSubscriptionID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VnetSubscriptionID", reflect.TypeOf((*MockNatGatewayScope)(nil).VnetSubscriptionID))
+}
@@ -24,11 +24,14 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-const serviceName = "natgateways"
+// ServiceName is the name of this service.
+const ServiceName = "natgateways"
 
 // NatGatewayScope defines the scope interface for NAT gateway service.
 type NatGatewayScope interface {
@@ -55,7 +58,15 @@ func New(scope NatGatewayScope) *Service {
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return ServiceName
+}
+
+// DependsOn returns the names of the services that must be reconciled before this one.
+func (s *Service) DependsOn() []string {
+	return []string{
+		virtualnetworks.ServiceName,
+		publicips.ServiceName,
+	}
 }
 
 // Reconcile idempotently creates or updates a NAT gateway.
@@ -84,7 +95,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (ie. error creating) -> operationNotDoneError (ie. creating in progress) -> no error (ie. created)
 	var resultingErr error
 	for _, natGatewaySpec := range specs {
-		result, err := s.CreateOrUpdateResource(ctx, natGatewaySpec, serviceName)
+		result, err := s.CreateOrUpdateResource(ctx, natGatewaySpec, ServiceName)
 		if err != nil {
 			if !azure.IsOperationNotDoneError(err) || resultingErr == nil {
 				resultingErr = err
@@ -103,7 +114,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		}
 	}
 
-	s.Scope.UpdatePutStatus(infrav1.NATGatewaysReadyCondition, serviceName, resultingErr)
+	s.Scope.UpdatePutStatus(infrav1.NATGatewaysReadyCondition, ServiceName, resultingErr)
 	return resultingErr
 }
 
@@ -132,13 +143,13 @@ func (s *Service) Delete(ctx context.Context) error {
 	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (ie. error creating) -> operationNotDoneError (ie. creating in progress) -> no error (ie. created)
 	var resultingErr error
 	for _, natGatewaySpec := range specs {
-		if err := s.DeleteResource(ctx, natGatewaySpec, serviceName); err != nil {
+		if err := s.DeleteResource(ctx, natGatewaySpec, ServiceName); err != nil {
 			if !azure.IsOperationNotDoneError(err) || resultingErr == nil {
 				resultingErr = err
 			}
 		}
 	}
-	s.Scope.UpdateDeleteStatus(infrav1.NATGatewaysReadyCondition, serviceName, resultingErr)
+	s.Scope.UpdateDeleteStatus(infrav1.NATGatewaysReadyCondition, ServiceName, resultingErr)
 	return resultingErr
 }
 
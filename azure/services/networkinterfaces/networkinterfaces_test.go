@@ -41,6 +41,7 @@ var (
 		SubscriptionID:        "123",
 		MachineName:           "azure-test1",
 		SubnetName:            "my-subnet",
+		VNetSubscriptionID:    "123",
 		VNetName:              "my-vnet",
 		VNetResourceGroup:     "my-rg",
 		AcceleratedNetworking: nil,
@@ -53,6 +54,7 @@ var (
 		SubscriptionID:        "123",
 		MachineName:           "azure-test1",
 		SubnetName:            "my-subnet",
+		VNetSubscriptionID:    "123",
 		VNetName:              "my-vnet",
 		VNetResourceGroup:     "my-rg",
 		AcceleratedNetworking: nil,
@@ -34,6 +34,7 @@ type NICSpec struct {
 	SubscriptionID            string
 	MachineName               string
 	SubnetName                string
+	VNetSubscriptionID        string
 	VNetName                  string
 	VNetResourceGroup         string
 	StaticIPAddress           string
@@ -80,7 +81,7 @@ func (s *NICSpec) Parameters(existing interface{}) (parameters interface{}, err
 	nicConfig := &network.InterfaceIPConfigurationPropertiesFormat{}
 
 	subnet := &network.Subnet{
-		ID: to.StringPtr(azure.SubnetID(s.SubscriptionID, s.VNetResourceGroup, s.VNetName, s.SubnetName)),
+		ID: to.StringPtr(azure.SubnetID(s.VNetSubscriptionID, s.VNetResourceGroup, s.VNetName, s.SubnetName)),
 	}
 	nicConfig.Subnet = subnet
 
@@ -34,6 +34,7 @@ var (
 		SubscriptionID:        "123",
 		MachineName:           "azure-test1",
 		SubnetName:            "my-subnet",
+		VNetSubscriptionID:    "123",
 		VNetName:              "my-vnet",
 		VNetResourceGroup:     "my-rg",
 		PublicLBName:          "my-public-lb",
@@ -69,6 +70,7 @@ var (
 		SubscriptionID:          "123",
 		MachineName:             "azure-test1",
 		SubnetName:              "my-subnet",
+		VNetSubscriptionID:      "123",
 		VNetName:                "my-vnet",
 		VNetResourceGroup:       "my-rg",
 		PublicLBName:            "my-public-lb",
@@ -86,6 +88,7 @@ var (
 		SubscriptionID:          "123",
 		MachineName:             "azure-test1",
 		SubnetName:              "my-subnet",
+		VNetSubscriptionID:      "123",
 		VNetName:                "my-vnet",
 		VNetResourceGroup:       "my-rg",
 		PublicLBName:            "my-public-lb",
@@ -102,6 +105,7 @@ var (
 		SubscriptionID:            "123",
 		MachineName:               "azure-test1",
 		SubnetName:                "my-subnet",
+		VNetSubscriptionID:        "123",
 		VNetName:                  "my-vnet",
 		VNetResourceGroup:         "my-rg",
 		PublicLBName:              "my-public-lb",
@@ -121,6 +125,7 @@ var (
 		SubscriptionID:        "123",
 		MachineName:           "azure-test1",
 		SubnetName:            "my-subnet",
+		VNetSubscriptionID:    "123",
 		VNetName:              "my-vnet",
 		VNetResourceGroup:     "my-rg",
 		PublicLBName:          "my-public-lb",
@@ -136,6 +141,7 @@ var (
 		SubscriptionID:        "123",
 		MachineName:           "azure-test1",
 		SubnetName:            "my-subnet",
+		VNetSubscriptionID:    "123",
 		VNetName:              "my-vnet",
 		VNetResourceGroup:     "my-rg",
 		PublicLBName:          "my-public-lb",
@@ -150,6 +156,7 @@ var (
 		SubscriptionID:        "123",
 		MachineName:           "azure-test1",
 		SubnetName:            "my-subnet",
+		VNetSubscriptionID:    "123",
 		VNetName:              "my-vnet",
 		IPv6Enabled:           true,
 		VNetResourceGroup:     "my-rg",
@@ -167,6 +174,7 @@ var (
 		SubscriptionID:            "123",
 		MachineName:               "azure-test1",
 		SubnetName:                "my-subnet",
+		VNetSubscriptionID:        "123",
 		VNetName:                  "my-vnet",
 		VNetResourceGroup:         "my-rg",
 		PublicLBName:              "my-public-lb",
@@ -81,6 +81,20 @@ func (mr *MockScopeMockRecorder) Authorizer() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorizer", reflect.TypeOf((*MockScope)(nil).Authorizer))
 }
 
+// AuthorizerFor mocks base method.
+func (m *MockScope) AuthorizerFor(subscriptionID string) azure.Authorizer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizerFor", subscriptionID)
+	ret0, _ := ret[0].(azure.Authorizer)
+	return ret0
+}
+
+// AuthorizerFor indicates an expected call of AuthorizerFor.
+func (mr *MockScopeMockRecorder) AuthorizerFor(subscriptionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizerFor", reflect.TypeOf((*MockScope)(nil).AuthorizerFor), subscriptionID)
+}
+
 // AvailabilitySetEnabled mocks base method.
 func (m *MockScope) AvailabilitySetEnabled() bool {
 	m.ctrl.T.Helper()
@@ -191,6 +205,20 @@ func (mr *MockScopeMockRecorder) DeleteLongRunningOperationState(arg0, arg1, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLongRunningOperationState", reflect.TypeOf((*MockScope)(nil).DeleteLongRunningOperationState), arg0, arg1, arg2)
 }
 
+// DriftPolicy mocks base method.
+func (m *MockScope) DriftPolicy() v1beta1.DriftPolicy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DriftPolicy")
+	ret0, _ := ret[0].(v1beta1.DriftPolicy)
+	return ret0
+}
+
+// DriftPolicy indicates an expected call of DriftPolicy.
+func (mr *MockScopeMockRecorder) DriftPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DriftPolicy", reflect.TypeOf((*MockScope)(nil).DriftPolicy))
+}
+
 // FailureDomains mocks base method.
 func (m *MockScope) FailureDomains() []string {
 	m.ctrl.T.Helper()
@@ -263,6 +291,20 @@ func (mr *MockScopeMockRecorder) PrivateDNSSpec() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrivateDNSSpec", reflect.TypeOf((*MockScope)(nil).PrivateDNSSpec))
 }
 
+// PrivateDNSZoneSubscriptionID mocks base method.
+func (m *MockScope) PrivateDNSZoneSubscriptionID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrivateDNSZoneSubscriptionID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// PrivateDNSZoneSubscriptionID indicates an expected call of PrivateDNSZoneSubscriptionID.
+func (mr *MockScopeMockRecorder) PrivateDNSZoneSubscriptionID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrivateDNSZoneSubscriptionID", reflect.TypeOf((*MockScope)(nil).PrivateDNSZoneSubscriptionID))
+}
+
 // ResourceGroup mocks base method.
 func (m *MockScope) ResourceGroup() string {
 	m.ctrl.T.Helper()
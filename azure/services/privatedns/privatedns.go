@@ -25,17 +25,21 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-const serviceName = "privatedns"
+// ServiceName is the name of this service.
+const ServiceName = "privatedns"
 
 // Scope defines the scope interface for a private dns service.
 type Scope interface {
 	azure.ClusterDescriber
 	azure.Authorizer
+	azure.SubscriptionAuthorizer
 	azure.AsyncStatusUpdater
+	PrivateDNSZoneSubscriptionID() string
 	PrivateDNSSpec() (zoneSpec azure.ResourceSpecGetter, linksSpec, recordsSpec []azure.ResourceSpecGetter)
 }
 
@@ -50,10 +54,12 @@ type Service struct {
 }
 
 // New creates a new private dns service.
+// The clients are created in the subscription of the private DNS zone, which can differ from the cluster's.
 func New(scope Scope) *Service {
-	zoneClient := newPrivateZonesClient(scope)
-	vnetLinkClient := newVirtualNetworkLinksClient(scope)
-	recordSetsClient := newRecordSetsClient(scope)
+	auth := scope.AuthorizerFor(scope.PrivateDNSZoneSubscriptionID())
+	zoneClient := newPrivateZonesClient(auth)
+	vnetLinkClient := newVirtualNetworkLinksClient(auth)
+	recordSetsClient := newRecordSetsClient(auth)
 	return &Service{
 		Scope:              scope,
 		zoneGetter:         zoneClient,
@@ -66,7 +72,14 @@ func New(scope Scope) *Service {
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return ServiceName
+}
+
+// DependsOn returns the names of the services that must be reconciled before this one.
+func (s *Service) DependsOn() []string {
+	return []string{
+		virtualnetworks.ServiceName,
+	}
 }
 
 // Reconcile creates or updates the private zone, links it to the vnet, and creates DNS records.
@@ -84,7 +97,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 
 	managed, err := s.reconcileZone(ctx, zoneSpec)
 	if managed {
-		s.Scope.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, err)
+		s.Scope.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, err)
 	}
 	if err != nil {
 		return err
@@ -92,14 +105,14 @@ func (s *Service) Reconcile(ctx context.Context) error {
 
 	managed, err = s.reconcileLinks(ctx, links)
 	if managed {
-		s.Scope.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, err)
+		s.Scope.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, err)
 	}
 	if err != nil {
 		return err
 	}
 
 	err = s.reconcileRecords(ctx, records)
-	s.Scope.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, err)
+	s.Scope.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, err)
 	return err
 }
 
@@ -118,7 +131,7 @@ func (s *Service) Delete(ctx context.Context) error {
 
 	managed, err := s.deleteLinks(ctx, links)
 	if managed {
-		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, err)
+		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, err)
 	}
 	if err != nil {
 		return err
@@ -126,8 +139,8 @@ func (s *Service) Delete(ctx context.Context) error {
 
 	managed, err = s.deleteZone(ctx, zoneSpec)
 	if managed {
-		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, err)
-		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, err)
+		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, err)
+		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, err)
 	}
 
 	return err
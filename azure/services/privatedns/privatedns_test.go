@@ -119,13 +119,13 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				zg.Get(gomockinternal.AContext(), fakeZone).Return(nil, notFoundError)
 				lg.Get(gomockinternal.AContext(), fakeLink1).Return(nil, notFoundError)
 				lg.Get(gomockinternal.AContext(), fakeLink2).Return(nil, notFoundError)
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, nil)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -134,8 +134,8 @@ func TestReconcilePrivateDNS(t *testing.T) {
 			expect: func(s *mock_privatedns.MockScopeMockRecorder, z, l, r *mock_async.MockReconcilerMockRecorder, zg, lg *mock_async.MockGetterMockRecorder) {
 				s.PrivateDNSSpec().Return(fakeZone, []azure.ResourceSpecGetter{fakeLink1, fakeLink2}, []azure.ResourceSpecGetter{fakeRecord1}).Times(2)
 				zg.Get(gomockinternal.AContext(), fakeZone).Return(nil, notFoundError)
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, notDoneError)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, notDoneError)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, notDoneError)
 			},
 		},
 		{
@@ -144,8 +144,8 @@ func TestReconcilePrivateDNS(t *testing.T) {
 			expect: func(s *mock_privatedns.MockScopeMockRecorder, z, l, r *mock_async.MockReconcilerMockRecorder, zg, lg *mock_async.MockGetterMockRecorder) {
 				s.PrivateDNSSpec().Return(fakeZone, []azure.ResourceSpecGetter{fakeLink1, fakeLink2}, []azure.ResourceSpecGetter{fakeRecord1}).Times(2)
 				zg.Get(gomockinternal.AContext(), fakeZone).Return(nil, notFoundError)
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, errFake)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, errFake)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, errFake)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -156,11 +156,11 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.ClusterName()
 				lg.Get(gomockinternal.AContext(), fakeLink1).Return(false, notFoundError)
 				lg.Get(gomockinternal.AContext(), fakeLink2).Return(false, notFoundError)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -171,11 +171,11 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				zg.Get(gomockinternal.AContext(), fakeZone).Return(nil, notFoundError)
 				lg.Get(gomockinternal.AContext(), fakeLink1).Return(nil, notFoundError)
 				lg.Get(gomockinternal.AContext(), fakeLink2).Return(nil, notFoundError)
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil, errFake)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, errFake)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil, errFake)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -186,11 +186,11 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				zg.Get(gomockinternal.AContext(), fakeZone).Return(nil, notFoundError)
 				lg.Get(gomockinternal.AContext(), fakeLink1).Return(nil, notFoundError)
 				lg.Get(gomockinternal.AContext(), fakeLink2).Return(nil, notFoundError)
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil, errFake)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, errFake)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil, errFake)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -201,11 +201,11 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				zg.Get(gomockinternal.AContext(), fakeZone).Return(nil, notFoundError)
 				lg.Get(gomockinternal.AContext(), fakeLink1).Return(nil, notFoundError)
 				lg.Get(gomockinternal.AContext(), fakeLink2).Return(nil, notFoundError)
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil, notDoneError)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil, errFake)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, errFake)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil, notDoneError)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil, errFake)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -217,10 +217,10 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.ClusterName()
 				lg.Get(gomockinternal.AContext(), fakeLink2).Return(fakeAzureVnetLinkUnmanaged, nil)
 				s.ClusterName()
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, nil)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -231,12 +231,12 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				lg.Get(gomockinternal.AContext(), fakeLink1).Return(fakeAzureVnetLinkUnmanaged, nil)
 				s.ClusterName()
 				lg.Get(gomockinternal.AContext(), fakeLink2).Return(nil, notFoundError)
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, nil)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -247,13 +247,13 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				zg.Get(gomockinternal.AContext(), fakeZone).Return(nil, notFoundError)
 				lg.Get(gomockinternal.AContext(), fakeLink1).Return(nil, notFoundError)
 				lg.Get(gomockinternal.AContext(), fakeLink2).Return(nil, notFoundError)
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, serviceName).Return(nil, errFake)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, errFake)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, ServiceName).Return(nil, errFake)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, errFake)
 			},
 		},
 	}
@@ -318,17 +318,17 @@ func TestDeletePrivateDNS(t *testing.T) {
 
 				lg.Get(gomockinternal.AContext(), fakeLink1).Return(fakeAzureVnetLinkManaged, nil)
 				s.ClusterName().Return(clusterName)
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil)
 				lg.Get(gomockinternal.AContext(), fakeLink2).Return(fakeAzureVnetLinkManaged, nil)
 				s.ClusterName().Return(clusterName)
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil)
 
 				zg.Get(gomockinternal.AContext(), fakeZone).Return(fakeAzurePrivateZoneManaged, nil)
 				s.ClusterName().Return(clusterName)
-				zr.DeleteResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, nil)
+				zr.DeleteResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -356,14 +356,14 @@ func TestDeletePrivateDNS(t *testing.T) {
 				s.ClusterName().Return(clusterName)
 				lg.Get(gomockinternal.AContext(), fakeLink2).Return(fakeAzureVnetLinkManaged, nil)
 				s.ClusterName().Return(clusterName)
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil)
 
 				zg.Get(gomockinternal.AContext(), fakeZone).Return(fakeAzurePrivateZoneManaged, nil)
 				s.ClusterName().Return(clusterName)
-				zr.DeleteResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, nil)
+				zr.DeleteResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -374,11 +374,11 @@ func TestDeletePrivateDNS(t *testing.T) {
 
 				lg.Get(gomockinternal.AContext(), fakeLink1).Return(fakeAzureVnetLinkManaged, nil)
 				s.ClusterName().Return(clusterName)
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(notDoneError)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(notDoneError)
 				lg.Get(gomockinternal.AContext(), fakeLink2).Return(fakeAzureVnetLinkManaged, nil)
 				s.ClusterName().Return(clusterName)
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, notDoneError)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, notDoneError)
 			},
 		},
 		{
@@ -389,11 +389,11 @@ func TestDeletePrivateDNS(t *testing.T) {
 
 				lg.Get(gomockinternal.AContext(), fakeLink1).Return(fakeAzureVnetLinkManaged, nil)
 				s.ClusterName().Return(clusterName)
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(errFake)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(errFake)
 				lg.Get(gomockinternal.AContext(), fakeLink2).Return(fakeAzureVnetLinkManaged, nil)
 				s.ClusterName().Return(clusterName)
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(notDoneError)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, errFake)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(notDoneError)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -404,18 +404,18 @@ func TestDeletePrivateDNS(t *testing.T) {
 
 				lg.Get(gomockinternal.AContext(), fakeLink1).Return(fakeAzureVnetLinkManaged, nil)
 				s.ClusterName().Return(clusterName)
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil)
 				lg.Get(gomockinternal.AContext(), fakeLink2).Return(fakeAzureVnetLinkManaged, nil)
 				s.ClusterName().Return(clusterName)
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil)
 
 				zg.Get(gomockinternal.AContext(), fakeZone).Return(fakeAzurePrivateZoneManaged, nil)
 				s.ClusterName().Return(clusterName)
-				zr.DeleteResource(gomockinternal.AContext(), fakeZone, serviceName).Return(notDoneError)
+				zr.DeleteResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(notDoneError)
 
-				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, notDoneError)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, notDoneError)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, notDoneError)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, notDoneError)
 			},
 		},
 		{
@@ -426,18 +426,18 @@ func TestDeletePrivateDNS(t *testing.T) {
 
 				lg.Get(gomockinternal.AContext(), fakeLink1).Return(fakeAzureVnetLinkManaged, nil)
 				s.ClusterName().Return(clusterName)
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil)
 				lg.Get(gomockinternal.AContext(), fakeLink2).Return(fakeAzureVnetLinkManaged, nil)
 				s.ClusterName().Return(clusterName)
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil)
 
 				zg.Get(gomockinternal.AContext(), fakeZone).Return(fakeAzurePrivateZoneManaged, nil)
 				s.ClusterName().Return(clusterName)
-				zr.DeleteResource(gomockinternal.AContext(), fakeZone, serviceName).Return(errFake)
+				zr.DeleteResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(errFake)
 
-				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, errFake)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, errFake)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, errFake)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, errFake)
 			},
 		},
 	}
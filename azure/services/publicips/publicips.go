@@ -25,10 +25,12 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-const serviceName = "publicips"
+// ServiceName is the name of this service.
+const ServiceName = "publicips"
 
 // PublicIPScope defines the scope interface for a public IP service.
 type PublicIPScope interface {
@@ -57,7 +59,14 @@ func New(scope PublicIPScope) *Service {
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return ServiceName
+}
+
+// DependsOn returns the names of the services that must be reconciled before this one.
+func (s *Service) DependsOn() []string {
+	return []string{
+		groups.ServiceName,
+	}
 }
 
 // Reconcile idempotently creates or updates a public IP.
@@ -75,14 +84,14 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
 	var result error
 	for _, publicIPSpec := range specs {
-		if _, err := s.CreateOrUpdateResource(ctx, publicIPSpec, serviceName); err != nil {
+		if _, err := s.CreateOrUpdateResource(ctx, publicIPSpec, ServiceName); err != nil {
 			if !azure.IsOperationNotDoneError(err) || result == nil {
 				result = err
 			}
 		}
 	}
 
-	s.Scope.UpdatePutStatus(infrav1.PublicIPsReadyCondition, serviceName, result)
+	s.Scope.UpdatePutStatus(infrav1.PublicIPsReadyCondition, ServiceName, result)
 	return result
 }
 
@@ -115,7 +124,7 @@ func (s *Service) Delete(ctx context.Context) error {
 
 		log.V(2).Info("deleting public IP", "public ip", publicIPSpec.ResourceName())
 		hasManagedPublicIPs = true
-		if err := s.DeleteResource(ctx, publicIPSpec, serviceName); err != nil {
+		if err := s.DeleteResource(ctx, publicIPSpec, ServiceName); err != nil {
 			if !azure.IsOperationNotDoneError(err) || result == nil {
 				result = err
 			}
@@ -125,7 +134,7 @@ func (s *Service) Delete(ctx context.Context) error {
 	}
 
 	if hasManagedPublicIPs {
-		s.Scope.UpdateDeleteStatus(infrav1.PublicIPsReadyCondition, serviceName, result)
+		s.Scope.UpdateDeleteStatus(infrav1.PublicIPsReadyCondition, ServiceName, result)
 	}
 
 	return result
@@ -129,11 +129,11 @@ func TestReconcilePublicIP(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.PublicIPSpecs().Return([]azure.ResourceSpecGetter{&fakePublicIPSpec1, &fakePublicIPSpec2, &fakePublicIPSpec3, &fakePublicIPSpecIpv6})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpec1, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpec2, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpec3, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpecIpv6, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.PublicIPsReadyCondition, serviceName, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpec1, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpec2, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpec3, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpecIpv6, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PublicIPsReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -141,11 +141,11 @@ func TestReconcilePublicIP(t *testing.T) {
 			expectedError: internalError.Error(),
 			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.PublicIPSpecs().Return([]azure.ResourceSpecGetter{&fakePublicIPSpec1, &fakePublicIPSpec2, &fakePublicIPSpec3, &fakePublicIPSpecIpv6})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpec1, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpec2, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpec3, serviceName).Return(nil, internalError)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpecIpv6, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.PublicIPsReadyCondition, serviceName, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpec1, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpec2, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpec3, ServiceName).Return(nil, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpecIpv6, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PublicIPsReadyCondition, ServiceName, internalError)
 			},
 		},
 	}
@@ -203,20 +203,20 @@ func TestDeletePublicIP(t *testing.T) {
 
 				m.Get(gomockinternal.AContext(), &fakePublicIPSpec1).Return(fakeManagedPublicIP, nil)
 				s.ClusterName().Return("my-cluster")
-				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPSpec1, serviceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPSpec1, ServiceName).Return(nil)
 
 				m.Get(gomockinternal.AContext(), &fakePublicIPSpec2).Return(fakeManagedPublicIP, nil)
 				s.ClusterName().Return("my-cluster")
-				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPSpec2, serviceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPSpec2, ServiceName).Return(nil)
 
 				m.Get(gomockinternal.AContext(), &fakePublicIPSpec3).Return(fakeUnmanagedPublicIP, nil)
 				s.ClusterName().Return("my-cluster")
 
 				m.Get(gomockinternal.AContext(), &fakePublicIPSpecIpv6).Return(fakeManagedPublicIP, nil)
 				s.ClusterName().Return("my-cluster")
-				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPSpecIpv6, serviceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPSpecIpv6, ServiceName).Return(nil)
 
-				s.UpdateDeleteStatus(infrav1.PublicIPsReadyCondition, serviceName, nil)
+				s.UpdateDeleteStatus(infrav1.PublicIPsReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -246,21 +246,21 @@ func TestDeletePublicIP(t *testing.T) {
 
 				m.Get(gomockinternal.AContext(), &fakePublicIPSpec1).Return(fakeManagedPublicIP, nil)
 				s.ClusterName().Return("my-cluster")
-				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPSpec1, serviceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPSpec1, ServiceName).Return(nil)
 
 				m.Get(gomockinternal.AContext(), &fakePublicIPSpec2).Return(fakeManagedPublicIP, nil)
 				s.ClusterName().Return("my-cluster")
-				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPSpec2, serviceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPSpec2, ServiceName).Return(nil)
 
 				m.Get(gomockinternal.AContext(), &fakePublicIPSpec3).Return(fakeManagedPublicIP, nil)
 				s.ClusterName().Return("my-cluster")
-				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPSpec3, serviceName).Return(internalError)
+				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPSpec3, ServiceName).Return(internalError)
 
 				m.Get(gomockinternal.AContext(), &fakePublicIPSpecIpv6).Return(fakeManagedPublicIP, nil)
 				s.ClusterName().Return("my-cluster")
-				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPSpecIpv6, serviceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPSpecIpv6, ServiceName).Return(nil)
 
-				s.UpdateDeleteStatus(infrav1.PublicIPsReadyCondition, serviceName, internalError)
+				s.UpdateDeleteStatus(infrav1.PublicIPsReadyCondition, ServiceName, internalError)
 			},
 		},
 	}
@@ -27,69 +27,114 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/pkg/errors"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
-	"sigs.k8s.io/cluster-api-provider-azure/util/cache/ttllru"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/ot"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-// Cache loads resource SKUs at the beginning of reconcile to expose
+// Cache loads resource SKUs of a subscription and location to expose
 // features available on compute resources. It exposes convenience
-// functionality for trawling Azure SKU capabilities. It may be adapted
-// to periodically refresh data in the background.
+// functionality for trawling Azure SKU capabilities. The caches returned
+// by GetCache are shared by all the controllers of the manager and
+// refreshed in the background by a Refresher.
 type Cache struct {
 	client Client
+	// clientKey is the HashKey of the authorizer of the client. It is guarded by cachesMu.
+	clientKey string
+	// used is the last time the cache was returned by GetCache. It is guarded by cachesMu.
+	used time.Time
+
+	// subscriptionID is the Azure subscription for which this cache stores sku info.
+	subscriptionID string
 
 	// location is the Azure location for which this cache stores sku info.
-	// we do lookup once per reconcile for the given cluster/location.
 	location string
 
+	// loadMu serializes the loads and refreshes of the cache.
+	loadMu sync.Mutex
+
+	// mu guards data, refreshed and client.
+	mu sync.RWMutex
+
 	// data is the cached sku information from Azure.
-	// synchronization required if data is cached across reconcile calls, (i.e., refreshed in background as Runnable via mgr.Add(...))
 	data []compute.ResourceSku
+
+	// refreshed is the time data was listed from Azure.
+	refreshed time.Time
 }
 
-// Cacher describes the ability to get and to add items to cache.
-type Cacher interface {
-	Get(key interface{}) (value interface{}, ok bool)
-	Add(key interface{}, value interface{}) bool
+// CacheOptions configures the caches returned by GetCache.
+type CacheOptions struct {
+	// RefreshInterval is the maximum age of the SKUs of a cache before they are refreshed in the background.
+	RefreshInterval time.Duration
+	// Store persists the SKUs across restarts. nil disables persistence.
+	Store Store
 }
 
 // NewCacheFunc allows for mocking out the underlying client.
 type NewCacheFunc func(azure.Authorizer, string) *Cache
 
+// cacheKey identifies the shared cache of a subscription and location.
+type cacheKey struct {
+	subscriptionID string
+	location       string
+}
+
 var (
-	_           Client = &AzureClient{}
-	doOnce      sync.Once
-	clientCache Cacher
+	_        Client = &AzureClient{}
+	cachesMu sync.Mutex
+	caches   = map[cacheKey]*Cache{}
+	options  = CacheOptions{RefreshInterval: 24 * time.Hour}
 )
 
+// Configure sets the options of the shared caches. It should be called before the caches are used.
+func Configure(opts CacheOptions) {
+	cachesMu.Lock()
+	defer cachesMu.Unlock()
+	options = opts
+}
+
+// currentOptions returns the options of the shared caches.
+func currentOptions() CacheOptions {
+	cachesMu.Lock()
+	defer cachesMu.Unlock()
+	return options
+}
+
 // newCache instantiates a cache and initializes its contents.
 func newCache(auth azure.Authorizer, location string) *Cache {
 	return &Cache{
-		client:   NewClient(auth),
-		location: location,
+		client:         NewClient(auth),
+		clientKey:      auth.HashKey(),
+		subscriptionID: auth.SubscriptionID(),
+		location:       location,
 	}
 }
 
-// GetCache either creates a new SKUs cache or returns an existing one based on the location + Authorizer HashKey().
+// GetCache either creates a new SKUs cache or returns the existing one of the subscription and location. The SKUs
+// of a subscription are the same for all the identities using it, so the cache is shared by all of them and
+// refreshed with the last one. Caches which are not used for a refresh interval are dropped by the Refresher, so that
+// the identity of a deleted cluster is not used to refresh them.
 func GetCache(auth azure.Authorizer, location string) (*Cache, error) {
-	var err error
-	doOnce.Do(func() {
-		clientCache, err = ttllru.New(128, 24*time.Hour)
-	})
-
-	if err != nil {
-		return nil, errors.Wrap(err, "failed creating LRU cache for resourceSKUs cache")
+	cachesMu.Lock()
+	defer cachesMu.Unlock()
+
+	key := cacheKey{subscriptionID: auth.SubscriptionID(), location: location}
+	c, ok := caches[key]
+	if !ok {
+		c = newCache(auth, location)
+		c.used = time.Now()
+		caches[key] = c
+		return c, nil
 	}
 
-	key := location + "_" + auth.HashKey()
-	c, ok := clientCache.Get(key)
-	if ok {
-		return c.(*Cache), nil
-	}
+	c.used = time.Now()
 
-	c = newCache(auth, location)
-	_ = clientCache.Add(key, c)
-	return c.(*Cache), nil
+	if hashKey := auth.HashKey(); hashKey != c.clientKey {
+		c.mu.Lock()
+		c.client, c.clientKey = NewClient(auth), hashKey
+		c.mu.Unlock()
+	}
+	return c, nil
 }
 
 // NewStaticCache initializes a cache with data and no ability to refresh. Used for testing.
@@ -100,16 +145,90 @@ func NewStaticCache(data []compute.ResourceSku, location string) *Cache {
 	}
 }
 
+// skus returns the cached SKUs. They are loaded from the store or listed from Azure on first use.
+func (c *Cache) skus(ctx context.Context) ([]compute.ResourceSku, error) {
+	if data, _ := c.snapshot(); data != nil {
+		return data, nil
+	}
+
+	c.loadMu.Lock()
+	defer c.loadMu.Unlock()
+	// Another caller may have loaded the SKUs while waiting for the lock.
+	if data, _ := c.snapshot(); data != nil {
+		return data, nil
+	}
+
+	if c.load(ctx) {
+		data, _ := c.snapshot()
+		return data, nil
+	}
+	if err := c.refresh(ctx, c.location); err != nil {
+		return nil, err
+	}
+	data, _ := c.snapshot()
+	return data, nil
+}
+
+// snapshot returns the cached SKUs and the time they were listed from Azure.
+func (c *Cache) snapshot() ([]compute.ResourceSku, time.Time) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.data, c.refreshed
+}
+
+// load loads the SKUs persisted by a previous manager and returns whether it found any.
+func (c *Cache) load(ctx context.Context) bool {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "resourceskus.Cache.load")
+	defer done()
+
+	store := currentOptions().Store
+	if store == nil {
+		return false
+	}
+
+	data, refreshed, err := store.Load(ctx, c.subscriptionID, c.location)
+	if err != nil {
+		log.Error(err, "failed to load persisted resource skus, listing them from Azure", "location", c.location)
+		return false
+	}
+	if data == nil {
+		return false
+	}
+
+	c.mu.Lock()
+	c.data, c.refreshed = data, refreshed
+	c.mu.Unlock()
+	ot.SetResourceSKUCacheRefreshed(c.subscriptionID, c.location, refreshed)
+	return true
+}
+
+// refresh lists the SKUs of the location from Azure and persists them.
 func (c *Cache) refresh(ctx context.Context, location string) error {
-	ctx, _, done := tele.StartSpanWithLogger(ctx, "resourceskus.Cache.refresh")
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "resourceskus.Cache.refresh")
 	defer done()
 
-	data, err := c.client.List(ctx, fmt.Sprintf("location eq '%s'", location))
+	c.mu.RLock()
+	client := c.client
+	c.mu.RUnlock()
+
+	data, err := client.List(ctx, fmt.Sprintf("location eq '%s'", location))
 	if err != nil {
+		ot.RecordResourceSKUCacheRefresh(ctx, c.subscriptionID, location, ot.ResourceSKUCacheRefreshFailure)
 		return errors.Wrap(err, "failed to refresh resource sku cache")
 	}
+	ot.RecordResourceSKUCacheRefresh(ctx, c.subscriptionID, location, ot.ResourceSKUCacheRefreshSuccess)
 
-	c.data = data
+	refreshed := time.Now()
+	c.mu.Lock()
+	c.data, c.refreshed = data, refreshed
+	c.mu.Unlock()
+	ot.SetResourceSKUCacheRefreshed(c.subscriptionID, location, refreshed)
+
+	if store := currentOptions().Store; store != nil {
+		if err := store.Save(ctx, c.subscriptionID, location, data, refreshed); err != nil {
+			log.Error(err, "failed to persist resource skus", "location", location)
+		}
+	}
 
 	return nil
 }
@@ -123,13 +242,12 @@ func (c *Cache) Get(ctx context.Context, name string, kind ResourceType) (SKU, e
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "resourceskus.Cache.Get")
 	defer done()
 
-	if c.data == nil {
-		if err := c.refresh(ctx, c.location); err != nil {
-			return SKU{}, err
-		}
+	data, err := c.skus(ctx)
+	if err != nil {
+		return SKU{}, err
 	}
 
-	for _, sku := range c.data {
+	for _, sku := range data {
 		if sku.Name != nil && *sku.Name == name {
 			return SKU(sku), nil
 		}
@@ -142,14 +260,13 @@ func (c *Cache) Map(ctx context.Context, mapFn func(sku SKU)) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "resourceskus.Cache.Map")
 	defer done()
 
-	if c.data == nil {
-		if err := c.refresh(ctx, c.location); err != nil {
-			return err
-		}
+	data, err := c.skus(ctx)
+	if err != nil {
+		return err
 	}
 
-	for i := range c.data {
-		val := SKU(c.data[i])
+	for i := range data {
+		val := SKU(data[i])
 		mapFn(val)
 	}
 
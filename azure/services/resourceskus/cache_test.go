@@ -18,12 +18,19 @@ package resourceskus
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus/mock_resourceskus"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
 )
 
 func TestCacheGet(t *testing.T) {
@@ -394,3 +401,165 @@ func TestCacheGetZonesWithVMSize(t *testing.T) {
 		})
 	}
 }
+
+// fakeStore is an in-memory Store.
+type fakeStore struct {
+	skus      []compute.ResourceSku
+	refreshed time.Time
+	loadErr   error
+	saved     int
+}
+
+func (s *fakeStore) Load(_ context.Context, _, _ string) ([]compute.ResourceSku, time.Time, error) {
+	return s.skus, s.refreshed, s.loadErr
+}
+
+func (s *fakeStore) Save(_ context.Context, _, _ string, skus []compute.ResourceSku, refreshed time.Time) error {
+	s.skus, s.refreshed = skus, refreshed
+	s.saved++
+	return nil
+}
+
+// resetCaches clears the shared caches and restores the default options.
+func resetCaches() {
+	cachesMu.Lock()
+	defer cachesMu.Unlock()
+	caches = map[cacheKey]*Cache{}
+	options = CacheOptions{RefreshInterval: 24 * time.Hour}
+}
+
+func newTestAuthorizer(mockCtrl *gomock.Controller, subscriptionID, hashKey string) *mock_azure.MockAuthorizer {
+	auth := mock_azure.NewMockAuthorizer(mockCtrl)
+	auth.EXPECT().SubscriptionID().Return(subscriptionID).AnyTimes()
+	auth.EXPECT().HashKey().Return(hashKey).AnyTimes()
+	auth.EXPECT().BaseURI().Return("https://management.azure.com/").AnyTimes()
+	auth.EXPECT().Authorizer().Return(nil).AnyTimes()
+	return auth
+}
+
+func TestGetCache(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	defer resetCaches()
+	resetCaches()
+
+	c, err := GetCache(newTestAuthorizer(mockCtrl, "sub1", "key1"), "westus")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(c.clientKey).To(Equal("key1"))
+
+	// Another identity of the same subscription shares the cache and refreshes it.
+	other, err := GetCache(newTestAuthorizer(mockCtrl, "sub1", "key2"), "westus")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(other).To(BeIdenticalTo(c))
+	g.Expect(c.clientKey).To(Equal("key2"))
+	g.Expect(c.used).To(BeTemporally("~", time.Now(), time.Minute))
+
+	other, err = GetCache(newTestAuthorizer(mockCtrl, "sub1", "key1"), "eastus")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(other).NotTo(BeIdenticalTo(c))
+
+	other, err = GetCache(newTestAuthorizer(mockCtrl, "sub2", "key1"), "westus")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(other).NotTo(BeIdenticalTo(c))
+}
+
+func TestCacheLoad(t *testing.T) {
+	skus := []compute.ResourceSku{{Name: to.StringPtr("Standard_D2s_v3")}}
+	persisted := []compute.ResourceSku{{Name: to.StringPtr("Standard_B2s")}}
+	refreshed := time.Now().Add(-time.Hour)
+
+	cases := []struct {
+		name       string
+		store      *fakeStore
+		expect     func(c *mock_resourceskus.MockClientMockRecorder)
+		want       []compute.ResourceSku
+		wantSaved  int
+		wantErr    string
+		wantRecent bool
+	}{
+		{
+			name:  "lists skus without store",
+			store: nil,
+			expect: func(c *mock_resourceskus.MockClientMockRecorder) {
+				c.List(gomockinternal.AContext(), "location eq 'westus'").Return(skus, nil)
+			},
+			want:       skus,
+			wantRecent: true,
+		},
+		{
+			name:   "loads persisted skus",
+			store:  &fakeStore{skus: persisted, refreshed: refreshed},
+			expect: func(c *mock_resourceskus.MockClientMockRecorder) {},
+			want:   persisted,
+		},
+		{
+			name:  "lists and saves skus when none are persisted",
+			store: &fakeStore{},
+			expect: func(c *mock_resourceskus.MockClientMockRecorder) {
+				c.List(gomockinternal.AContext(), "location eq 'westus'").Return(skus, nil)
+			},
+			want:       skus,
+			wantSaved:  1,
+			wantRecent: true,
+		},
+		{
+			name:  "lists skus when loading fails",
+			store: &fakeStore{loadErr: errors.New("boom")},
+			expect: func(c *mock_resourceskus.MockClientMockRecorder) {
+				c.List(gomockinternal.AContext(), "location eq 'westus'").Return(skus, nil)
+			},
+			want:       skus,
+			wantSaved:  1,
+			wantRecent: true,
+		},
+		{
+			name:  "returns listing errors",
+			store: &fakeStore{},
+			expect: func(c *mock_resourceskus.MockClientMockRecorder) {
+				c.List(gomockinternal.AContext(), "location eq 'westus'").Return(nil, errors.New("boom"))
+			},
+			wantErr: "failed to refresh resource sku cache: boom",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			defer resetCaches()
+			if tc.store != nil {
+				Configure(CacheOptions{Store: tc.store})
+			}
+
+			client := mock_resourceskus.NewMockClient(mockCtrl)
+			tc.expect(client.EXPECT())
+			c := &Cache{client: client, subscriptionID: "sub", location: "westus"}
+
+			got, err := c.skus(context.Background())
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(tc.wantErr))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tc.want))
+
+			// The skus are only loaded once.
+			got, err = c.skus(context.Background())
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tc.want))
+
+			_, gotRefreshed := c.snapshot()
+			if tc.wantRecent {
+				g.Expect(gotRefreshed).To(BeTemporally("~", time.Now(), time.Minute))
+			} else {
+				g.Expect(gotRefreshed).To(Equal(refreshed))
+			}
+			if tc.store != nil {
+				g.Expect(tc.store.saved).To(Equal(tc.wantSaved))
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceskus
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// refreshCheckPeriod is the interval between two checks of the age of the shared caches.
+const refreshCheckPeriod = time.Minute
+
+// Refresher is a manager.Runnable which refreshes the shared caches in the background once their SKUs are older than
+// the refresh interval. Caches are only refreshed once they have been used, and dropped once they have not been used
+// for the refresh interval.
+type Refresher struct{}
+
+// Start refreshes the caches until ctx is done.
+func (r *Refresher) Start(ctx context.Context) error {
+	if currentOptions().RefreshInterval <= 0 {
+		return nil
+	}
+	wait.UntilWithContext(ctx, refreshStaleCaches, refreshCheckPeriod)
+	return nil
+}
+
+// refreshStaleCaches drops the caches unused for the refresh interval and refreshes the caches whose SKUs are older
+// than the refresh interval.
+func refreshStaleCaches(ctx context.Context) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "resourceskus.refreshStaleCaches")
+	defer done()
+
+	interval := currentOptions().RefreshInterval
+	for _, c := range dropUnusedCaches(interval) {
+		log.V(4).Info("dropped unused resource sku cache", "subscriptionID", c.subscriptionID, "location", c.location)
+	}
+	for _, c := range sharedCaches() {
+		if data, refreshed := c.snapshot(); data == nil || time.Since(refreshed) < interval {
+			continue
+		}
+		c.loadMu.Lock()
+		err := c.refresh(ctx, c.location)
+		c.loadMu.Unlock()
+		if err != nil {
+			log.Error(err, "failed to refresh resource skus, using the cached ones", "subscriptionID", c.subscriptionID, "location", c.location)
+		}
+	}
+}
+
+// sharedCaches returns the caches returned by GetCache.
+func sharedCaches() []*Cache {
+	cachesMu.Lock()
+	defer cachesMu.Unlock()
+	all := make([]*Cache, 0, len(caches))
+	for _, c := range caches {
+		all = append(all, c)
+	}
+	return all
+}
+
+// dropUnusedCaches removes the caches which GetCache has not returned for the interval from the shared caches, and
+// returns them.
+func dropUnusedCaches(interval time.Duration) []*Cache {
+	cachesMu.Lock()
+	defer cachesMu.Unlock()
+	var dropped []*Cache
+	for key, c := range caches {
+		if time.Since(c.used) >= interval {
+			delete(caches, key)
+			dropped = append(dropped, c)
+		}
+	}
+	return dropped
+}
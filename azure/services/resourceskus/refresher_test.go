@@ -0,0 +1,85 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceskus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus/mock_resourceskus"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+func TestRefreshStaleCaches(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	defer resetCaches()
+	resetCaches()
+	Configure(CacheOptions{RefreshInterval: time.Hour})
+
+	old := []compute.ResourceSku{{Name: to.StringPtr("old")}}
+	skus := []compute.ResourceSku{{Name: to.StringPtr("new")}}
+
+	staleClient := mock_resourceskus.NewMockClient(mockCtrl)
+	staleClient.EXPECT().List(gomockinternal.AContext(), "location eq 'westus'").Return(skus, nil)
+	stale := &Cache{used: time.Now(), client: staleClient, location: "westus", data: old, refreshed: time.Now().Add(-2 * time.Hour)}
+
+	failingClient := mock_resourceskus.NewMockClient(mockCtrl)
+	failingClient.EXPECT().List(gomockinternal.AContext(), "location eq 'northeurope'").Return(nil, errors.New("boom"))
+	failing := &Cache{used: time.Now(), client: failingClient, location: "northeurope", data: old, refreshed: time.Now().Add(-2 * time.Hour)}
+
+	// Fresh and unused caches are not refreshed.
+	fresh := &Cache{used: time.Now(), client: mock_resourceskus.NewMockClient(mockCtrl), location: "eastus", data: old, refreshed: time.Now()}
+	unused := &Cache{used: time.Now(), client: mock_resourceskus.NewMockClient(mockCtrl), location: "centralus"}
+
+	// Caches not used for the refresh interval are dropped without being refreshed.
+	dropped := &Cache{client: mock_resourceskus.NewMockClient(mockCtrl), location: "eastus2", data: old, refreshed: time.Now().Add(-2 * time.Hour), used: time.Now().Add(-time.Hour)}
+
+	caches = map[cacheKey]*Cache{
+		{subscriptionID: "sub", location: "westus"}:      stale,
+		{subscriptionID: "sub", location: "northeurope"}: failing,
+		{subscriptionID: "sub", location: "eastus"}:      fresh,
+		{subscriptionID: "sub", location: "centralus"}:   unused,
+		{subscriptionID: "sub", location: "eastus2"}:     dropped,
+	}
+
+	refreshStaleCaches(context.Background())
+
+	data, refreshed := stale.snapshot()
+	g.Expect(data).To(Equal(skus))
+	g.Expect(refreshed).To(BeTemporally("~", time.Now(), time.Minute))
+
+	// Caches which fail to refresh keep their skus.
+	data, _ = failing.snapshot()
+	g.Expect(data).To(Equal(old))
+
+	data, _ = fresh.snapshot()
+	g.Expect(data).To(Equal(old))
+	data, _ = unused.snapshot()
+	g.Expect(data).To(BeNil())
+
+	g.Expect(sharedCaches()).To(ConsistOf(stale, failing, fresh, unused))
+	data, _ = dropped.snapshot()
+	g.Expect(data).To(Equal(old))
+}
@@ -21,7 +21,10 @@ import (
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 )
 
 // SKU is a thin layer over the Azure resource SKU API to better introspect capabilities.
@@ -68,6 +71,16 @@ const (
 	MaximumPlatformFaultDomainCount = "MaximumPlatformFaultDomainCount"
 	// UltraSSDAvailable identifies the capability for the support of UltraSSD data disks.
 	UltraSSDAvailable = "UltraSSDAvailable"
+	// TrustedLaunchDisabled identifies the absence of the trusted launch capability.
+	TrustedLaunchDisabled = "TrustedLaunchDisabled"
+	// ConfidentialComputingType identifies the capability for confidential computing, e.g. "SNP".
+	ConfidentialComputingType = "ConfidentialComputingType"
+	// HyperVGenerations identifies the Hyper-V generations supported by a VM size, e.g. "V1,V2".
+	HyperVGenerations = "HyperVGenerations"
+	// CachedDiskBytes identifies the size in bytes of the cache disk of a VM size.
+	CachedDiskBytes = "CachedDiskBytes"
+	// MaxResourceVolumeMB identifies the size in MB of the resource (temp) disk of a VM size.
+	MaxResourceVolumeMB = "MaxResourceVolumeMB"
 )
 
 // HasCapability return true for a capability which can be either
@@ -87,6 +100,59 @@ func (s SKU) HasCapability(name string) bool {
 	return false
 }
 
+// ValidateSecurityType returns a terminal error if the VM size does not support the security type.
+func (s SKU) ValidateSecurityType(securityType infrav1.SecurityTypes) error {
+	size := to.String(s.Name)
+	if generations, ok := s.GetCapability(HyperVGenerations); !ok || !strings.Contains(generations, "V2") {
+		return azure.WithTerminalError(errors.Errorf("security type %s requires a VM type supporting Hyper-V generation V2, %s does not", securityType, size))
+	}
+
+	switch securityType {
+	case infrav1.SecurityTypesTrustedLaunch:
+		if s.HasCapability(TrustedLaunchDisabled) {
+			return azure.WithTerminalError(errors.Errorf("trusted launch is not supported for VM type %s", size))
+		}
+	case infrav1.SecurityTypesConfidentialVM:
+		if _, ok := s.GetCapability(ConfidentialComputingType); !ok {
+			return azure.WithTerminalError(errors.Errorf("confidential computing is not supported for VM type %s", size))
+		}
+	}
+
+	return nil
+}
+
+// ValidateDiffDiskPlacement returns a terminal error if the cache or resource disk of the VM size is too small to
+// hold the ephemeral OS disk.
+func (s SKU) ValidateDiffDiskPlacement(osDisk infrav1.OSDisk) error {
+	if osDisk.DiskSizeGB == nil || osDisk.DiffDiskSettings == nil {
+		return nil
+	}
+
+	var (
+		capability string
+		required   int64
+	)
+	switch osDisk.DiffDiskSettings.Placement {
+	case infrav1.DiffDiskPlacementCacheDisk:
+		capability, required = CachedDiskBytes, int64(*osDisk.DiskSizeGB)*1024*1024*1024
+	case infrav1.DiffDiskPlacementResourceDisk:
+		capability, required = MaxResourceVolumeMB, int64(*osDisk.DiskSizeGB)*1024
+	default:
+		return nil
+	}
+
+	size := to.String(s.Name)
+	ok, err := s.HasCapabilityWithCapacity(capability, required)
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate the %s capability of vm size %s", capability, size)
+	}
+	if !ok {
+		return azure.WithTerminalError(errors.Errorf("the %s of vm size %s is too small for an ephemeral os disk of %d GB. select a different vm size, placement or os disk size", osDisk.DiffDiskSettings.Placement, size, *osDisk.DiskSizeGB))
+	}
+
+	return nil
+}
+
 // HasCapabilityWithCapacity returns true when the provided resource
 // exposes a numeric capability and the maximum value exposed by that
 // capability exceeds the value requested by the user. Examples include
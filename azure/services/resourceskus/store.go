@@ -0,0 +1,159 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceskus
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// configMapPrefix is the prefix of the names of the ConfigMaps persisting resource SKUs.
+	configMapPrefix = "capz-resource-skus-"
+	// refreshedAnnotation is the time the SKUs persisted in a ConfigMap were listed from Azure.
+	refreshedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-resource-skus-refreshed"
+	// skusKey is the key of the gzipped gob encoded SKUs in a ConfigMap. The SKUs are not encoded in JSON because the
+	// SDK only marshals the fields which can be sent to Azure, and all the fields of a SKU are read-only.
+	skusKey = "skus.gob.gz"
+)
+
+// Store persists resource SKUs across restarts of the manager.
+type Store interface {
+	// Load returns the SKUs of the subscription and location and the time they were listed from Azure, or nil SKUs if
+	// none were saved.
+	Load(ctx context.Context, subscriptionID, location string) ([]compute.ResourceSku, time.Time, error)
+	// Save saves the SKUs of the subscription and location and the time they were listed from Azure.
+	Save(ctx context.Context, subscriptionID, location string, skus []compute.ResourceSku, refreshed time.Time) error
+}
+
+// +kubebuilder:rbac:groups="",namespace=system,resources=configmaps,verbs=get;create;update
+
+// configMapStore persists the SKUs of each subscription and location in a ConfigMap of the namespace of the manager,
+// which is the only namespace the manager is allowed to write ConfigMaps to.
+type configMapStore struct {
+	reader    client.Reader
+	writer    client.Writer
+	namespace string
+}
+
+// NewConfigMapStore returns a Store which persists the SKUs of each subscription and location in a ConfigMap of the
+// given namespace. The reader should not be backed by a cache, so that the manager doesn't watch all ConfigMaps.
+func NewConfigMapStore(reader client.Reader, writer client.Writer, namespace string) Store {
+	return &configMapStore{
+		reader:    reader,
+		writer:    writer,
+		namespace: namespace,
+	}
+}
+
+// configMapName returns the name of the ConfigMap persisting the SKUs of the subscription and location.
+func configMapName(subscriptionID, location string) string {
+	hash := sha256.Sum256([]byte(subscriptionID + "/" + location))
+	return configMapPrefix + hex.EncodeToString(hash[:10])
+}
+
+// Load returns the SKUs persisted in the ConfigMap of the subscription and location.
+func (s *configMapStore) Load(ctx context.Context, subscriptionID, location string) ([]compute.ResourceSku, time.Time, error) {
+	cm := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: s.namespace, Name: configMapName(subscriptionID, location)}
+	if err := s.reader.Get(ctx, key, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, time.Time{}, nil
+		}
+		return nil, time.Time{}, errors.Wrapf(err, "failed to get ConfigMap %s", key)
+	}
+
+	refreshed, err := time.Parse(time.RFC3339, cm.Annotations[refreshedAnnotation])
+	if err != nil {
+		return nil, time.Time{}, errors.Wrapf(err, "failed to parse the %s annotation of ConfigMap %s", refreshedAnnotation, key)
+	}
+	skus, err := decodeSKUs(cm.BinaryData[skusKey])
+	if err != nil {
+		return nil, time.Time{}, errors.Wrapf(err, "failed to decode the resource skus of ConfigMap %s", key)
+	}
+	return skus, refreshed, nil
+}
+
+// Save creates or updates the ConfigMap of the subscription and location.
+func (s *configMapStore) Save(ctx context.Context, subscriptionID, location string, skus []compute.ResourceSku, refreshed time.Time) error {
+	data, err := encodeSKUs(skus)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode resource skus")
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   s.namespace,
+			Name:        configMapName(subscriptionID, location),
+			Annotations: map[string]string{refreshedAnnotation: refreshed.UTC().Format(time.RFC3339)},
+		},
+		Data: map[string]string{
+			"subscriptionID": subscriptionID,
+			"location":       location,
+		},
+		BinaryData: map[string][]byte{skusKey: data},
+	}
+
+	existing := &corev1.ConfigMap{}
+	err = s.reader.Get(ctx, client.ObjectKeyFromObject(cm), existing)
+	switch {
+	case apierrors.IsNotFound(err):
+		err = s.writer.Create(ctx, cm)
+	case err == nil:
+		cm.ResourceVersion = existing.ResourceVersion
+		err = s.writer.Update(ctx, cm)
+	}
+	return errors.Wrapf(err, "failed to save ConfigMap %s", client.ObjectKeyFromObject(cm))
+}
+
+func encodeSKUs(skus []compute.ResourceSku) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := gob.NewEncoder(zw).Encode(skus); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeSKUs(data []byte) ([]compute.ResourceSku, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	var skus []compute.ResourceSku
+	if err := gob.NewDecoder(zr).Decode(&skus); err != nil {
+		return nil, err
+	}
+	return skus, nil
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceskus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestConfigMapStore(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	fakeClient := fake.NewClientBuilder().Build()
+	store := NewConfigMapStore(fakeClient, fakeClient, "capz-system")
+
+	skus, _, err := store.Load(ctx, "sub", "westus")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(skus).To(BeNil())
+
+	// All the fields of the SKUs are read-only, they must be persisted nonetheless.
+	want := []compute.ResourceSku{
+		{
+			Name:         to.StringPtr("Standard_D2s_v3"),
+			ResourceType: to.StringPtr(string(VirtualMachines)),
+			LocationInfo: &[]compute.ResourceSkuLocationInfo{
+				{
+					Location: to.StringPtr("westus"),
+					Zones:    &[]string{"1", "2", "3"},
+				},
+			},
+			Capabilities: &[]compute.ResourceSkuCapabilities{
+				{
+					Name:  to.StringPtr(VCPUs),
+					Value: to.StringPtr("2"),
+				},
+			},
+			Restrictions: &[]compute.ResourceSkuRestrictions{
+				{
+					Type:            compute.ResourceSkuRestrictionsTypeZone,
+					RestrictionInfo: &compute.ResourceSkuRestrictionInfo{Zones: &[]string{"3"}},
+				},
+			},
+		},
+	}
+	refreshed := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	g.Expect(store.Save(ctx, "sub", "westus", want, refreshed)).To(Succeed())
+
+	skus, gotRefreshed, err := store.Load(ctx, "sub", "westus")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(skus).To(Equal(want))
+	g.Expect(gotRefreshed).To(Equal(refreshed))
+
+	// Saving again updates the ConfigMap.
+	want = []compute.ResourceSku{{Name: to.StringPtr("Standard_B2s")}}
+	refreshed = refreshed.Add(24 * time.Hour)
+	g.Expect(store.Save(ctx, "sub", "westus", want, refreshed)).To(Succeed())
+
+	skus, gotRefreshed, err = store.Load(ctx, "sub", "westus")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(skus).To(Equal(want))
+	g.Expect(gotRefreshed).To(Equal(refreshed))
+
+	// Other locations are persisted separately.
+	skus, _, err = store.Load(ctx, "sub", "eastus")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(skus).To(BeNil())
+
+	cm := &corev1.ConfigMap{}
+	g.Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: "capz-system", Name: configMapName("sub", "westus")}, cm)).To(Succeed())
+	g.Expect(cm.Data).To(Equal(map[string]string{"subscriptionID": "sub", "location": "westus"}))
+}
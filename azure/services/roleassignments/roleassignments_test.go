@@ -30,7 +30,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/roleassignments/mock_roleassignments"
-	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesets/mock_scalesets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesets"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachines"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
 )
@@ -155,7 +155,7 @@ func TestReconcileRoleAssignmentsVMSS(t *testing.T) {
 	testcases := []struct {
 		name   string
 		expect func(s *mock_roleassignments.MockRoleAssignmentScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder,
-			mvmss *mock_scalesets.MockClientMockRecorder)
+			mvmss *mock_async.MockGetterMockRecorder)
 		expectedError string
 	}{
 		{
@@ -163,13 +163,13 @@ func TestReconcileRoleAssignmentsVMSS(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_roleassignments.MockRoleAssignmentScopeMockRecorder,
 				r *mock_async.MockReconcilerMockRecorder,
-				mvmss *mock_scalesets.MockClientMockRecorder) {
+				mvmss *mock_async.MockGetterMockRecorder) {
 				s.HasSystemAssignedIdentity().Return(true)
 				s.RoleAssignmentSpecs(&fakePrincipalID).Return(fakeRoleAssignmentSpecs[1:2])
 				s.RoleAssignmentResourceType().Return(azure.VirtualMachineScaleSet)
 				s.ResourceGroup().Return("my-rg")
 				s.Name().Return("test-vmss")
-				mvmss.Get(gomockinternal.AContext(), "my-rg", "test-vmss").Return(compute.VirtualMachineScaleSet{
+				mvmss.Get(gomockinternal.AContext(), &scalesets.ScaleSetSpec{Name: "test-vmss", ResourceGroup: "my-rg"}).Return(compute.VirtualMachineScaleSet{
 					Identity: &compute.VirtualMachineScaleSetIdentity{
 						PrincipalID: &fakePrincipalID,
 					},
@@ -182,12 +182,12 @@ func TestReconcileRoleAssignmentsVMSS(t *testing.T) {
 			expectedError: "failed to assign role to system assigned identity: failed to get principal ID for VMSS: #: Internal Server Error: StatusCode=500",
 			expect: func(s *mock_roleassignments.MockRoleAssignmentScopeMockRecorder,
 				r *mock_async.MockReconcilerMockRecorder,
-				mvmss *mock_scalesets.MockClientMockRecorder) {
+				mvmss *mock_async.MockGetterMockRecorder) {
 				s.RoleAssignmentResourceType().Return(azure.VirtualMachineScaleSet)
 				s.ResourceGroup().Return("my-rg")
 				s.Name().Return("test-vmss")
 				s.HasSystemAssignedIdentity().Return(true)
-				mvmss.Get(gomockinternal.AContext(), "my-rg", "test-vmss").Return(compute.VirtualMachineScaleSet{},
+				mvmss.Get(gomockinternal.AContext(), &scalesets.ScaleSetSpec{Name: "test-vmss", ResourceGroup: "my-rg"}).Return(compute.VirtualMachineScaleSet{},
 					autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
 			},
 		},
@@ -196,13 +196,13 @@ func TestReconcileRoleAssignmentsVMSS(t *testing.T) {
 			expectedError: fmt.Sprintf("cannot assign role to %s system assigned identity: #: Internal Server Error: StatusCode=500", azure.VirtualMachineScaleSet),
 			expect: func(s *mock_roleassignments.MockRoleAssignmentScopeMockRecorder,
 				r *mock_async.MockReconcilerMockRecorder,
-				mvmss *mock_scalesets.MockClientMockRecorder) {
+				mvmss *mock_async.MockGetterMockRecorder) {
 				s.HasSystemAssignedIdentity().Return(true)
 				s.RoleAssignmentSpecs(&fakePrincipalID).Return(fakeRoleAssignmentSpecs[1:2])
 				s.RoleAssignmentResourceType().Return(azure.VirtualMachineScaleSet)
 				s.ResourceGroup().Return("my-rg")
 				s.Name().Return("test-vmss")
-				mvmss.Get(gomockinternal.AContext(), "my-rg", "test-vmss").Return(compute.VirtualMachineScaleSet{
+				mvmss.Get(gomockinternal.AContext(), &scalesets.ScaleSetSpec{Name: "test-vmss", ResourceGroup: "my-rg"}).Return(compute.VirtualMachineScaleSet{
 					Identity: &compute.VirtualMachineScaleSetIdentity{
 						PrincipalID: &fakePrincipalID,
 					},
@@ -222,14 +222,14 @@ func TestReconcileRoleAssignmentsVMSS(t *testing.T) {
 			defer mockCtrl.Finish()
 			scopeMock := mock_roleassignments.NewMockRoleAssignmentScope(mockCtrl)
 			asyncMock := mock_async.NewMockReconciler(mockCtrl)
-			vmMock := mock_scalesets.NewMockClient(mockCtrl)
+			vmMock := mock_async.NewMockGetter(mockCtrl)
 
 			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT(), vmMock.EXPECT())
 
 			s := &Service{
 				Scope:                        scopeMock,
 				Reconciler:                   asyncMock,
-				virtualMachineScaleSetClient: vmMock,
+				virtualMachineScaleSetGetter: vmMock,
 			}
 
 			err := s.Reconcile(context.TODO())
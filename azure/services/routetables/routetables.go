@@ -23,16 +23,20 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-const serviceName = "routetables"
+// ServiceName is the name of this service.
+const ServiceName = "routetables"
 
 // RouteTableScope defines the scope interface for route table service.
 type RouteTableScope interface {
 	azure.Authorizer
+	azure.SubscriptionAuthorizer
 	azure.AsyncStatusUpdater
+	VnetSubscriptionID() string
 	RouteTableSpecs() []azure.ResourceSpecGetter
 	IsVnetManaged() bool
 }
@@ -45,7 +49,8 @@ type Service struct {
 
 // New creates a new service.
 func New(scope RouteTableScope) *Service {
-	client := newClient(scope)
+	// The resources associated with the subnets must be in the subscription of the Vnet.
+	client := newClient(scope.AuthorizerFor(scope.VnetSubscriptionID()))
 	return &Service{
 		Scope:      scope,
 		Reconciler: async.New(scope, client, client),
@@ -54,7 +59,14 @@ func New(scope RouteTableScope) *Service {
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return ServiceName
+}
+
+// DependsOn returns the names of the services that must be reconciled before this one.
+func (s *Service) DependsOn() []string {
+	return []string{
+		virtualnetworks.ServiceName,
+	}
 }
 
 // Reconcile idempotently creates or updates a set of route tables.
@@ -83,14 +95,14 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	// If multiple errors occur, we return the most pressing one.
 	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
 	for _, rtSpec := range specs {
-		if _, err := s.CreateOrUpdateResource(ctx, rtSpec, serviceName); err != nil {
+		if _, err := s.CreateOrUpdateResource(ctx, rtSpec, ServiceName); err != nil {
 			if !azure.IsOperationNotDoneError(err) || resErr == nil {
 				resErr = err
 			}
 		}
 	}
 
-	s.Scope.UpdatePutStatus(infrav1.RouteTablesReadyCondition, serviceName, resErr)
+	s.Scope.UpdatePutStatus(infrav1.RouteTablesReadyCondition, ServiceName, resErr)
 	return resErr
 }
 
@@ -121,13 +133,13 @@ func (s *Service) Delete(ctx context.Context) error {
 	// order of precedence is: error deleting -> deleting in progress -> deleted (no error)
 	var result error
 	for _, rtSpec := range specs {
-		if err := s.DeleteResource(ctx, rtSpec, serviceName); err != nil {
+		if err := s.DeleteResource(ctx, rtSpec, ServiceName); err != nil {
 			if !azure.IsOperationNotDoneError(err) || result == nil {
 				result = err
 			}
 		}
 	}
-	s.Scope.UpdateDeleteStatus(infrav1.RouteTablesReadyCondition, serviceName, result)
+	s.Scope.UpdateDeleteStatus(infrav1.RouteTablesReadyCondition, ServiceName, result)
 	return result
 }
 
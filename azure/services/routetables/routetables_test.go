@@ -21,10 +21,13 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routetables/mock_routetables"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
@@ -71,9 +74,9 @@ func TestReconcileRouteTables(t *testing.T) {
 			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT, &fakeRT2})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT2, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.RouteTablesReadyCondition, serviceName, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT2, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.RouteTablesReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -82,9 +85,9 @@ func TestReconcileRouteTables(t *testing.T) {
 			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT, &fakeRT2})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT, serviceName).Return(nil, errFake)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT2, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.RouteTablesReadyCondition, serviceName, errFake)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT, ServiceName).Return(nil, errFake)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT2, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.RouteTablesReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -93,9 +96,9 @@ func TestReconcileRouteTables(t *testing.T) {
 			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT, &fakeRT2})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT, serviceName).Return(nil, errFake)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT2, serviceName).Return(nil, notDoneError)
-				s.UpdatePutStatus(infrav1.RouteTablesReadyCondition, serviceName, errFake)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT, ServiceName).Return(nil, errFake)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT2, ServiceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.RouteTablesReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -156,9 +159,9 @@ func TestDeleteRouteTable(t *testing.T) {
 			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT, &fakeRT2})
-				r.DeleteResource(gomockinternal.AContext(), &fakeRT, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakeRT2, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.RouteTablesReadyCondition, serviceName, nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeRT, ServiceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeRT2, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.RouteTablesReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -167,9 +170,9 @@ func TestDeleteRouteTable(t *testing.T) {
 			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT, &fakeRT2})
-				r.DeleteResource(gomockinternal.AContext(), &fakeRT, serviceName).Return(errFake)
-				r.DeleteResource(gomockinternal.AContext(), &fakeRT2, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.RouteTablesReadyCondition, serviceName, errFake)
+				r.DeleteResource(gomockinternal.AContext(), &fakeRT, ServiceName).Return(errFake)
+				r.DeleteResource(gomockinternal.AContext(), &fakeRT2, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.RouteTablesReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -178,9 +181,9 @@ func TestDeleteRouteTable(t *testing.T) {
 			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT, &fakeRT2})
-				r.DeleteResource(gomockinternal.AContext(), &fakeRT, serviceName).Return(errFake)
-				r.DeleteResource(gomockinternal.AContext(), &fakeRT2, serviceName).Return(notDoneError)
-				s.UpdateDeleteStatus(infrav1.RouteTablesReadyCondition, serviceName, errFake)
+				r.DeleteResource(gomockinternal.AContext(), &fakeRT, ServiceName).Return(errFake)
+				r.DeleteResource(gomockinternal.AContext(), &fakeRT2, ServiceName).Return(notDoneError)
+				s.UpdateDeleteStatus(infrav1.RouteTablesReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -219,3 +222,22 @@ func TestDeleteRouteTable(t *testing.T) {
 		})
 	}
 }
+
+func TestNewUsesVnetSubscription(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scopeMock := mock_routetables.NewMockRouteTableScope(mockCtrl)
+	authorizerMock := mock_azure.NewMockAuthorizer(mockCtrl)
+
+	// The route tables associated with the subnets of a vnet in another subscription are created in that subscription.
+	scopeMock.EXPECT().VnetSubscriptionID().Return("spoke-sub")
+	scopeMock.EXPECT().AuthorizerFor("spoke-sub").Return(authorizerMock)
+	authorizerMock.EXPECT().SubscriptionID().Return("spoke-sub")
+	authorizerMock.EXPECT().BaseURI().Return("https://management.azure.com/")
+	authorizerMock.EXPECT().Authorizer().Return(autorest.NullAuthorizer{})
+
+	s := New(scopeMock)
+	client := s.Reconciler.(*async.Service).Creator.(*azureClient)
+	g.Expect(client.routetables.SubscriptionID).To(Equal("spoke-sub"))
+}
@@ -192,6 +192,20 @@ func (mr *MockScaleSetScopeMockRecorder) DeleteLongRunningOperationState(arg0, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLongRunningOperationState", reflect.TypeOf((*MockScaleSetScope)(nil).DeleteLongRunningOperationState), arg0, arg1, arg2)
 }
 
+// DriftPolicy mocks base method.
+func (m *MockScaleSetScope) DriftPolicy() v1beta1.DriftPolicy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DriftPolicy")
+	ret0, _ := ret[0].(v1beta1.DriftPolicy)
+	return ret0
+}
+
+// DriftPolicy indicates an expected call of DriftPolicy.
+func (mr *MockScaleSetScopeMockRecorder) DriftPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DriftPolicy", reflect.TypeOf((*MockScaleSetScope)(nil).DriftPolicy))
+}
+
 // FailureDomains mocks base method.
 func (m *MockScaleSetScope) FailureDomains() []string {
 	m.ctrl.T.Helper()
@@ -320,10 +334,10 @@ func (mr *MockScaleSetScopeMockRecorder) SaveVMImageToStatus(arg0 interface{}) *
 }
 
 // ScaleSetSpec mocks base method.
-func (m *MockScaleSetScope) ScaleSetSpec() azure.ScaleSetSpec {
+func (m *MockScaleSetScope) ScaleSetSpec() azure.ResourceSpecGetter {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ScaleSetSpec")
-	ret0, _ := ret[0].(azure.ScaleSetSpec)
+	ret0, _ := ret[0].(azure.ResourceSpecGetter)
 	return ret0
 }
 
@@ -444,17 +458,3 @@ func (mr *MockScaleSetScopeMockRecorder) UpdatePutStatus(arg0, arg1, arg2 interf
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePutStatus", reflect.TypeOf((*MockScaleSetScope)(nil).UpdatePutStatus), arg0, arg1, arg2)
 }
-
-// VMSSExtensionSpecs mocks base method.
-func (m *MockScaleSetScope) VMSSExtensionSpecs() []azure.ResourceSpecGetter {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "VMSSExtensionSpecs")
-	ret0, _ := ret[0].([]azure.ResourceSpecGetter)
-	return ret0
-}
-
-// VMSSExtensionSpecs indicates an expected call of VMSSExtensionSpecs.
-func (mr *MockScaleSetScopeMockRecorder) VMSSExtensionSpecs() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VMSSExtensionSpecs", reflect.TypeOf((*MockScaleSetScope)(nil).VMSSExtensionSpecs))
-}
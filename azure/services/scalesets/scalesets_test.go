@@ -20,17 +20,18 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
-	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesets/mock_scalesets"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
@@ -44,6 +45,22 @@ const (
 	vmSizeEPH             = "VM_SIZE_EPH"
 )
 
+var (
+	defaultVMImage = &infrav1.Image{
+		Marketplace: &infrav1.AzureMarketplaceImage{
+			ImagePlan: infrav1.ImagePlan{
+				Publisher: "fake-publisher",
+				Offer:     "my-offer",
+				SKU:       "sku-id",
+			},
+			Version: "1.0",
+		},
+	}
+
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+	notFoundError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not found")
+)
+
 func init() {
 	_ = clusterv1.AddToScheme(scheme.Scheme)
 }
@@ -51,24 +68,23 @@ func init() {
 func TestGetExistingVMSS(t *testing.T) {
 	testcases := []struct {
 		name          string
-		vmssName      string
+		spec          *ScaleSetSpec
 		result        *azure.VMSS
 		expectedError string
-		expect        func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder)
+		expect        func(m *mock_scalesets.MockClientMockRecorder)
 	}{
 		{
 			name:          "scale set not found",
-			vmssName:      "my-vmss",
+			spec:          &ScaleSetSpec{Name: defaultVMSSName, ResourceGroup: defaultResourceGroup},
 			result:        &azure.VMSS{},
 			expectedError: "failed to get existing vmss: #: Not found: StatusCode=404",
-			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				s.ResourceGroup().AnyTimes().Return("my-rg")
-				m.Get(gomockinternal.AContext(), "my-rg", "my-vmss").Return(compute.VirtualMachineScaleSet{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+			expect: func(m *mock_scalesets.MockClientMockRecorder) {
+				m.Get(gomockinternal.AContext(), &ScaleSetSpec{Name: defaultVMSSName, ResourceGroup: defaultResourceGroup}).Return(nil, notFoundError)
 			},
 		},
 		{
-			name:     "get existing vmss",
-			vmssName: "my-vmss",
+			name: "get existing vmss",
+			spec: &ScaleSetSpec{Name: defaultVMSSName, ResourceGroup: defaultResourceGroup},
 			result: &azure.VMSS{
 				ID:       "my-id",
 				Name:     "my-vmss",
@@ -88,9 +104,8 @@ func TestGetExistingVMSS(t *testing.T) {
 				},
 			},
 			expectedError: "",
-			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				s.ResourceGroup().AnyTimes().Return("my-rg")
-				m.Get(gomockinternal.AContext(), "my-rg", "my-vmss").Return(compute.VirtualMachineScaleSet{
+			expect: func(m *mock_scalesets.MockClientMockRecorder) {
+				m.Get(gomockinternal.AContext(), &ScaleSetSpec{Name: defaultVMSSName, ResourceGroup: defaultResourceGroup}).Return(compute.VirtualMachineScaleSet{
 					ID:   to.StringPtr("my-id"),
 					Name: to.StringPtr("my-vmss"),
 					Sku: &compute.Sku{
@@ -103,7 +118,7 @@ func TestGetExistingVMSS(t *testing.T) {
 					},
 					Zones: &[]string{"1", "3"},
 				}, nil)
-				m.ListInstances(gomock.Any(), "my-rg", "my-vmss").Return([]compute.VirtualMachineScaleSetVM{
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return([]compute.VirtualMachineScaleSetVM{
 					{
 						ID:         to.StringPtr("my-vm-id"),
 						InstanceID: to.StringPtr("my-vm-1"),
@@ -120,12 +135,11 @@ func TestGetExistingVMSS(t *testing.T) {
 		},
 		{
 			name:          "list instances fails",
-			vmssName:      "my-vmss",
+			spec:          &ScaleSetSpec{Name: defaultVMSSName, ResourceGroup: defaultResourceGroup},
 			result:        &azure.VMSS{},
 			expectedError: "failed to list instances: #: Not found: StatusCode=404",
-			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				s.ResourceGroup().AnyTimes().Return("my-rg")
-				m.Get(gomockinternal.AContext(), "my-rg", "my-vmss").Return(compute.VirtualMachineScaleSet{
+			expect: func(m *mock_scalesets.MockClientMockRecorder) {
+				m.Get(gomockinternal.AContext(), &ScaleSetSpec{Name: defaultVMSSName, ResourceGroup: defaultResourceGroup}).Return(compute.VirtualMachineScaleSet{
 					ID:   to.StringPtr("my-id"),
 					Name: to.StringPtr("my-vmss"),
 					VirtualMachineScaleSetProperties: &compute.VirtualMachineScaleSetProperties{
@@ -133,7 +147,7 @@ func TestGetExistingVMSS(t *testing.T) {
 						ProvisioningState:    to.StringPtr("Succeeded"),
 					},
 				}, nil)
-				m.ListInstances(gomockinternal.AContext(), "my-rg", "my-vmss").Return([]compute.VirtualMachineScaleSetVM{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return([]compute.VirtualMachineScaleSetVM{}, notFoundError)
 			},
 		},
 	}
@@ -149,17 +163,16 @@ func TestGetExistingVMSS(t *testing.T) {
 			scopeMock := mock_scalesets.NewMockScaleSetScope(mockCtrl)
 			clientMock := mock_scalesets.NewMockClient(mockCtrl)
 
-			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+			tc.expect(clientMock.EXPECT())
 
 			s := &Service{
 				Scope:  scopeMock,
 				Client: clientMock,
 			}
 
-			result, err := s.getVirtualMachineScaleSet(context.TODO(), tc.vmssName)
+			result, err := s.getVirtualMachineScaleSet(context.TODO(), tc.spec)
 			if tc.expectedError != "" {
 				g.Expect(err).To(HaveOccurred())
-				t.Log(err.Error())
 				g.Expect(err).To(MatchError(tc.expectedError))
 			} else {
 				g.Expect(err).NotTo(HaveOccurred())
@@ -170,457 +183,179 @@ func TestGetExistingVMSS(t *testing.T) {
 }
 
 func TestReconcileVMSS(t *testing.T) {
-	var (
-		putFuture = &infrav1.Future{
-			Type:          infrav1.PutFuture,
-			ResourceGroup: defaultResourceGroup,
-			Name:          defaultVMSSName,
-		}
-
-		patchFuture = &infrav1.Future{
-			Type:          infrav1.PatchFuture,
-			ResourceGroup: defaultResourceGroup,
-			Name:          defaultVMSSName,
-		}
-	)
-
 	testcases := []struct {
 		name          string
-		expect        func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder)
+		spec          func() ScaleSetSpec
+		expect        func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder, spec *ScaleSetSpec)
 		expectedError string
 	}{
 		{
-			name:          "should start creating a vmss",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				defaultSpec := newDefaultVMSSSpec()
-				defaultSpec.DataDisks = append(defaultSpec.DataDisks, infrav1.DataDisk{
-					NameSuffix: "my_disk_with_ultra_disks",
-					DiskSizeGB: 128,
-					Lun:        to.Int32Ptr(3),
-					ManagedDisk: &infrav1.ManagedDiskParameters{
-						StorageAccountType: "UltraSSD_LRS",
-					},
-				})
-				s.ScaleSetSpec().Return(defaultSpec).AnyTimes()
-				setupDefaultVMSSStartCreatingExpectations(s, m)
-				vmss := newDefaultVMSS("VM_SIZE")
-				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
-				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
-					Return(putFuture, nil)
-				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE"), putFuture)
-			},
-		},
-		{
-			name:          "should finish creating a vmss when long running operation is done",
+			name:          "create vmss succeeds",
+			spec:          newDefaultVMSSSpec,
 			expectedError: "",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				defaultSpec := newDefaultVMSSSpec()
-				s.ScaleSetSpec().Return(defaultSpec).AnyTimes()
-				createdVMSS := newDefaultVMSS("VM_SIZE")
-				instances := newDefaultInstances()
-
-				setupDefaultVMSSInProgressOperationDoneExpectations(s, m, createdVMSS, instances)
-				s.DeleteLongRunningOperationState(defaultSpec.Name, serviceName, infrav1.PutFuture)
-				s.DeleteLongRunningOperationState(defaultSpec.Name, serviceName, infrav1.PatchFuture)
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder, spec *ScaleSetSpec) {
+				setupDefaultVMSSResolveExpectations(s, m)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), spec, serviceName).Return(newDefaultExistingVMSS("VM_SIZE"), nil)
 				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
+				s.SetProviderID(azure.ProviderIDPrefix + "subscriptions/1234/resourceGroups/my_resource_group/providers/Microsoft.Compute/virtualMachines/my-vm")
+				s.SetVMSSState(gomock.AssignableToTypeOf(&azure.VMSS{}))
 			},
 		},
 		{
-			name:          "Windows VMSS should not get patched",
-			expectedError: "",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				defaultSpec := newWindowsVMSSSpec()
-				s.ScaleSetSpec().Return(defaultSpec).AnyTimes()
-				createdVMSS := newDefaultWindowsVMSS()
-				instances := newDefaultInstances()
-
-				setupDefaultVMSSInProgressOperationDoneExpectations(s, m, createdVMSS, instances)
-				s.DeleteLongRunningOperationState(defaultSpec.Name, serviceName, infrav1.PutFuture)
-				s.DeleteLongRunningOperationState(defaultSpec.Name, serviceName, infrav1.PatchFuture)
-				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
+			name:          "vmss create or update operation in progress",
+			spec:          newDefaultVMSSSpec,
+			expectedError: "operation type PUT on Azure resource my-rg/my-vmss is not done. Object will be requeued after 15s",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder, spec *ScaleSetSpec) {
+				future := &infrav1.Future{
+					Type:          infrav1.PutFuture,
+					ResourceGroup: defaultResourceGroup,
+					Name:          defaultVMSSName,
+				}
+				s.DeleteLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PatchFuture)
+				s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PutFuture).Return(future)
+				s.GetVMImage(gomockinternal.AContext()).Return(defaultVMImage, nil)
+				s.SaveVMImageToStatus(defaultVMImage)
+				s.GetBootstrapData(gomockinternal.AContext()).Return("fake-bootstrap-data", nil)
+				s.MaxSurge().Return(1, nil)
+				notDoneErr := azure.WithTransientError(azure.NewOperationNotDoneError(future), 15*time.Second)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), spec, serviceName).Return(nil, notDoneErr)
+				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, notDoneErr)
+				// The instances are only listed once, by the deferred update of the VMSS state.
+				m.Get(gomockinternal.AContext(), spec).Return(newDefaultExistingVMSS("VM_SIZE"), nil)
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(newDefaultInstances(), nil)
+				s.SetProviderID(azure.ProviderIDPrefix + "subscriptions/1234/resourceGroups/my_resource_group/providers/Microsoft.Compute/virtualMachines/my-vm")
+				s.SetVMSSState(gomock.AssignableToTypeOf(&azure.VMSS{}))
 			},
 		},
 		{
-			name:          "should start creating vmss with defaulted accelerated networking when size allows",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				spec := newDefaultVMSSSpec()
-				spec.Size = "VM_SIZE_AN"
-				s.ScaleSetSpec().Return(spec).AnyTimes()
-				setupDefaultVMSSStartCreatingExpectations(s, m)
-				vmss := newDefaultVMSS("VM_SIZE_AN")
-				netConfigs := vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.NetworkProfile.NetworkInterfaceConfigurations
-				(*netConfigs)[0].EnableAcceleratedNetworking = to.BoolPtr(true)
-				vmss.Sku.Name = to.StringPtr(spec.Size)
-				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
-					Return(putFuture, nil)
-				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE_AN"), putFuture)
+			name:          "vmss create or update fails",
+			spec:          newDefaultVMSSSpec,
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder, spec *ScaleSetSpec) {
+				setupDefaultVMSSResolveExpectations(s, m)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), spec, serviceName).Return(nil, internalError)
+				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, internalError)
+				m.Get(gomockinternal.AContext(), spec).Return(nil, notFoundError)
 			},
 		},
 		{
-			name:          "should start creating a vmss with spot vm",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				spec := newDefaultVMSSSpec()
-				spec.SpotVMOptions = &infrav1.SpotVMOptions{}
-				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
-					NameSuffix: "my_disk_with_ultra_disks",
-					DiskSizeGB: 128,
-					Lun:        to.Int32Ptr(3),
-					ManagedDisk: &infrav1.ManagedDiskParameters{
-						StorageAccountType: "UltraSSD_LRS",
-					},
-				})
-				s.ScaleSetSpec().Return(spec).AnyTimes()
-				setupDefaultVMSSStartCreatingExpectations(s, m)
-				vmss := newDefaultVMSS("VM_SIZE")
-				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
-				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.Priority = compute.VirtualMachinePriorityTypesSpot
-				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
-					Return(putFuture, nil)
-				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE"), putFuture)
+			name:          "fails to get VM image",
+			spec:          newDefaultVMSSSpec,
+			expectedError: "failed to get VM image: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder, spec *ScaleSetSpec) {
+				s.DeleteLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PatchFuture)
+				s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PutFuture).Return(nil)
+				s.GetVMImage(gomockinternal.AContext()).Return(nil, internalError)
+				m.Get(gomockinternal.AContext(), spec).Return(nil, notFoundError)
 			},
 		},
 		{
-			name:          "should start creating a vmss with spot vm and ephemeral disk",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			name: "less than 2 vCPUs",
+			spec: func() ScaleSetSpec {
 				spec := newDefaultVMSSSpec()
-				spec.Size = vmSizeEPH
-				spec.SpotVMOptions = &infrav1.SpotVMOptions{}
-				spec.OSDisk.DiffDiskSettings = &infrav1.DiffDiskSettings{
-					Option: string(compute.DiffDiskOptionsLocal),
-				}
-				s.ScaleSetSpec().Return(spec).AnyTimes()
-				setupDefaultVMSSStartCreatingExpectations(s, m)
-				vmss := newDefaultVMSS(vmSizeEPH)
-				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.StorageProfile.OsDisk.DiffDiskSettings = &compute.DiffDiskSettings{
-					Option: compute.DiffDiskOptionsLocal,
-				}
-				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.Priority = compute.VirtualMachinePriorityTypesSpot
-				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
-					Return(putFuture, nil)
-				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS(vmSizeEPH), putFuture)
+				spec.Size = "VM_SIZE_1_CPU"
+				return spec
 			},
-		},
-		{
-			name:          "should start creating a vmss with spot vm and a defined delete evictionPolicy",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				spec := newDefaultVMSSSpec()
-				spec.Size = vmSizeEPH
-				deletePolicy := infrav1.SpotEvictionPolicyDelete
-				spec.SpotVMOptions = &infrav1.SpotVMOptions{EvictionPolicy: &deletePolicy}
-				s.ScaleSetSpec().Return(spec).AnyTimes()
-				setupDefaultVMSSStartCreatingExpectations(s, m)
-				vmss := newDefaultVMSS(vmSizeEPH)
-				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.Priority = compute.VirtualMachinePriorityTypesSpot
-				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.EvictionPolicy = compute.VirtualMachineEvictionPolicyTypesDelete
-				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
-					Return(putFuture, nil)
-				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS(vmSizeEPH), putFuture)
+			expectedError: "reconcile error that cannot be recovered occurred: vm size should be bigger or equal to at least 2 vCPUs. Object will not be requeued",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder, spec *ScaleSetSpec) {
 			},
 		},
 		{
-			name:          "should start creating a vmss with spot vm and a maximum price",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			name: "Memory is less than 2Gi",
+			spec: func() ScaleSetSpec {
 				spec := newDefaultVMSSSpec()
-				maxPrice := resource.MustParse("0.001")
-				spec.SpotVMOptions = &infrav1.SpotVMOptions{
-					MaxPrice: &maxPrice,
-				}
-				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
-					NameSuffix: "my_disk_with_ultra_disks",
-					DiskSizeGB: 128,
-					Lun:        to.Int32Ptr(3),
-					ManagedDisk: &infrav1.ManagedDiskParameters{
-						StorageAccountType: "UltraSSD_LRS",
-					},
-				})
-				s.ScaleSetSpec().Return(spec).AnyTimes()
-				setupDefaultVMSSStartCreatingExpectations(s, m)
-				vmss := newDefaultVMSS("VM_SIZE")
-				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.Priority = compute.VirtualMachinePriorityTypesSpot
-				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.BillingProfile = &compute.BillingProfile{
-					MaxPrice: to.Float64Ptr(0.001),
-				}
-				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
-				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
-					Return(putFuture, nil)
-				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE"), putFuture)
+				spec.Size = "VM_SIZE_1_MEM"
+				return spec
 			},
-		},
-		{
-			name:          "should start creating a vmss with encryption",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				spec := newDefaultVMSSSpec()
-				spec.OSDisk.ManagedDisk.DiskEncryptionSet = &infrav1.DiskEncryptionSetParameters{
-					ID: "my-diskencryptionset-id",
-				}
-				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
-					NameSuffix: "my_disk_with_ultra_disks",
-					DiskSizeGB: 128,
-					Lun:        to.Int32Ptr(3),
-					ManagedDisk: &infrav1.ManagedDiskParameters{
-						StorageAccountType: "UltraSSD_LRS",
-					},
-				})
-				s.ScaleSetSpec().Return(spec).AnyTimes()
-				setupDefaultVMSSStartCreatingExpectations(s, m)
-				vmss := newDefaultVMSS("VM_SIZE")
-				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
-				osdisk := vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.StorageProfile.OsDisk
-				osdisk.ManagedDisk = &compute.VirtualMachineScaleSetManagedDiskParameters{
-					StorageAccountType: "Premium_LRS",
-					DiskEncryptionSet: &compute.DiskEncryptionSetParameters{
-						ID: to.StringPtr("my-diskencryptionset-id"),
-					},
-				}
-				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
-					Return(putFuture, nil)
-				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE"), putFuture)
+			expectedError: "reconcile error that cannot be recovered occurred: vm memory should be bigger or equal to at least 2Gi. Object will not be requeued",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder, spec *ScaleSetSpec) {
 			},
 		},
 		{
-			name:          "can start creating a vmss with user assigned identity",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			name: "failed to get SKU",
+			spec: func() ScaleSetSpec {
 				spec := newDefaultVMSSSpec()
-				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
-					NameSuffix: "my_disk_with_ultra_disks",
-					DiskSizeGB: 128,
-					Lun:        to.Int32Ptr(3),
-					ManagedDisk: &infrav1.ManagedDiskParameters{
-						StorageAccountType: "UltraSSD_LRS",
-					},
-				})
-				spec.Identity = infrav1.VMIdentityUserAssigned
-				spec.UserAssignedIdentities = []infrav1.UserAssignedIdentity{
-					{
-						ProviderID: "azure:///subscriptions/123/resourcegroups/456/providers/Microsoft.ManagedIdentity/userAssignedIdentities/id1",
-					},
-				}
-				s.ScaleSetSpec().Return(spec).AnyTimes()
-				setupDefaultVMSSStartCreatingExpectations(s, m)
-				vmss := newDefaultVMSS("VM_SIZE")
-				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
-				vmss.Identity = &compute.VirtualMachineScaleSetIdentity{
-					Type: compute.ResourceIdentityTypeUserAssigned,
-					UserAssignedIdentities: map[string]*compute.VirtualMachineScaleSetIdentityUserAssignedIdentitiesValue{
-						"/subscriptions/123/resourcegroups/456/providers/Microsoft.ManagedIdentity/userAssignedIdentities/id1": {},
-					},
-				}
-				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
-					Return(putFuture, nil)
-				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE"), putFuture)
+				spec.Size = "INVALID_VM_SIZE"
+				return spec
+			},
+			expectedError: "failed to get SKU INVALID_VM_SIZE in compute api: reconcile error that cannot be recovered occurred: resource sku with name 'INVALID_VM_SIZE' and category 'virtualMachines' not found in location 'test-location'. Object will not be requeued",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder, spec *ScaleSetSpec) {
 			},
 		},
 		{
-			name:          "should start creating a vmss with encryption at host enabled",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			name: "creating a vmss with encryption at host enabled for unsupported VM type fails",
+			spec: func() ScaleSetSpec {
 				spec := newDefaultVMSSSpec()
-				spec.Size = "VM_SIZE_EAH"
 				spec.SecurityProfile = &infrav1.SecurityProfile{EncryptionAtHost: to.BoolPtr(true)}
-				s.ScaleSetSpec().Return(spec).AnyTimes()
-				setupDefaultVMSSStartCreatingExpectations(s, m)
-				vmss := newDefaultVMSS("VM_SIZE_EAH")
-				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.SecurityProfile = &compute.SecurityProfile{
-					EncryptionAtHost: to.BoolPtr(true),
-				}
-				vmss.Sku.Name = to.StringPtr(spec.Size)
-				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
-					Return(putFuture, nil)
-				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE_EAH"), putFuture)
+				return spec
 			},
-		},
-		{
-			name:          "creating a vmss with encryption at host enabled for unsupported VM type fails",
 			expectedError: "reconcile error that cannot be recovered occurred: encryption at host is not supported for VM type VM_SIZE. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
-					Name:            defaultVMSSName,
-					Size:            "VM_SIZE",
-					Capacity:        2,
-					SSHKeyData:      "ZmFrZXNzaGtleQo=",
-					SecurityProfile: &infrav1.SecurityProfile{EncryptionAtHost: to.BoolPtr(true)},
-				})
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder, spec *ScaleSetSpec) {
 			},
 		},
 		{
-			name:          "should start creating a vmss with ephemeral osdisk",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				defaultSpec := newDefaultVMSSSpec()
-				defaultSpec.Size = "VM_SIZE_EPH"
-				defaultSpec.OSDisk.DiffDiskSettings = &infrav1.DiffDiskSettings{
-					Option: "Local",
-				}
-				defaultSpec.OSDisk.CachingType = "ReadOnly"
-
-				s.ScaleSetSpec().Return(defaultSpec).AnyTimes()
-				setupDefaultVMSSStartCreatingExpectations(s, m)
-				vmss := newDefaultVMSS("VM_SIZE_EPH")
-				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.StorageProfile.OsDisk.DiffDiskSettings = &compute.DiffDiskSettings{
-					Option: compute.DiffDiskOptionsLocal,
-				}
-				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.StorageProfile.OsDisk.Caching = compute.CachingTypesReadOnly
-
-				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
-					Return(putFuture, nil)
-				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE_EPH"), putFuture)
-			},
-		},
-		{
-			name:          "should start updating when scale set already exists and not currently in a long running operation",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PATCH on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			name: "creating a trusted launch vmss for a VM type without Hyper-V generation V2 fails",
+			spec: func() ScaleSetSpec {
 				spec := newDefaultVMSSSpec()
-				spec.Capacity = 2
-				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
-					NameSuffix: "my_disk_with_ultra_disks",
-					DiskSizeGB: 128,
-					Lun:        to.Int32Ptr(3),
-					ManagedDisk: &infrav1.ManagedDiskParameters{
-						StorageAccountType: "UltraSSD_LRS",
-					},
-				})
-				s.ScaleSetSpec().Return(spec).AnyTimes()
-
-				setupDefaultVMSSUpdateExpectations(s)
-				existingVMSS := newDefaultExistingVMSS("VM_SIZE")
-				existingVMSS.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
-				existingVMSS.Sku.Capacity = to.Int64Ptr(2)
-				existingVMSS.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
-				instances := newDefaultInstances()
-				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(existingVMSS, nil)
-				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(instances, nil)
-
-				clone := newDefaultExistingVMSS("VM_SIZE")
-				clone.Sku.Capacity = to.Int64Ptr(3)
-				clone.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
-
-				patchVMSS, err := getVMSSUpdateFromVMSS(clone)
-				g.Expect(err).NotTo(HaveOccurred())
-				patchVMSS.VirtualMachineProfile.StorageProfile.ImageReference.Version = to.StringPtr("2.0")
-				patchVMSS.VirtualMachineProfile.NetworkProfile = nil
-				m.UpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(patchVMSS)).
-					Return(patchFuture, nil)
-				s.SetLongRunningOperationState(patchFuture)
-				m.GetResultIfDone(gomockinternal.AContext(), patchFuture).Return(compute.VirtualMachineScaleSet{}, azure.NewOperationNotDoneError(patchFuture))
-				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(clone, nil)
-				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(instances, nil)
+				spec.SecurityProfile = &infrav1.SecurityProfile{SecurityType: infrav1.SecurityTypesTrustedLaunch}
+				return spec
 			},
-		},
-		{
-			name:          "less than 2 vCPUs",
-			expectedError: "reconcile error that cannot be recovered occurred: vm size should be bigger or equal to at least 2 vCPUs. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
-					Name:       defaultVMSSName,
-					Size:       "VM_SIZE_1_CPU",
-					Capacity:   2,
-					SSHKeyData: "ZmFrZXNzaGtleQo=",
-				})
+			expectedError: "reconcile error that cannot be recovered occurred: security type TrustedLaunch requires a VM type supporting Hyper-V generation V2, VM_SIZE does not. Object will not be requeued",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder, spec *ScaleSetSpec) {
 			},
 		},
 		{
-			name:          "Memory is less than 2Gi",
-			expectedError: "reconcile error that cannot be recovered occurred: vm memory should be bigger or equal to at least 2Gi. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
-					Name:       defaultVMSSName,
-					Size:       "VM_SIZE_1_MEM",
-					Capacity:   2,
-					SSHKeyData: "ZmFrZXNzaGtleQo=",
-				})
+			name: "fail to create a vm with ultra disk implicitly enabled by data disk, when location not supported",
+			spec: func() ScaleSetSpec {
+				spec := newDefaultVMSSSpec()
+				spec.Size = "VM_SIZE_USSD"
+				spec.DataDisks = []infrav1.DataDisk{
+					{
+						ManagedDisk: &infrav1.ManagedDiskParameters{
+							StorageAccountType: "UltraSSD_LRS",
+						},
+					},
+				}
+				return spec
 			},
-		},
-		{
-			name:          "failed to get SKU",
-			expectedError: "failed to get SKU INVALID_VM_SIZE in compute api: reconcile error that cannot be recovered occurred: resource sku with name 'INVALID_VM_SIZE' and category 'virtualMachines' not found in location 'test-location'. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
-					Name:       defaultVMSSName,
-					Size:       "INVALID_VM_SIZE",
-					Capacity:   2,
-					SSHKeyData: "ZmFrZXNzaGtleQo=",
-				})
+			expectedError: "reconcile error that cannot be recovered occurred: vm size VM_SIZE_USSD does not support ultra disks in location test-location. select a different vm size or disable ultra disks. Object will not be requeued",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder, spec *ScaleSetSpec) {
 			},
 		},
 		{
-			name:          "fails with internal error",
-			expectedError: "failed to start creating VMSS: cannot create VMSS: #: Internal error: StatusCode=500",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			name: "fail to create a vm with ultra disk explicitly enabled via additional capabilities, when location not supported",
+			spec: func() ScaleSetSpec {
 				spec := newDefaultVMSSSpec()
-				s.ScaleSetSpec().Return(spec).AnyTimes()
-				setupDefaultVMSSStartCreatingExpectations(s, m)
-				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomock.AssignableToTypeOf(compute.VirtualMachineScaleSet{})).
-					Return(nil, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal error"))
-				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).
-					Return(compute.VirtualMachineScaleSet{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				spec.Size = "VM_SIZE_USSD"
+				spec.DataDisks = nil
+				spec.AdditionalCapabilities = &infrav1.AdditionalCapabilities{
+					UltraSSDEnabled: to.BoolPtr(true),
+				}
+				return spec
 			},
-		},
-		{
-			name:          "fail to create a vm with ultra disk implicitly enabled by data disk, when location not supported",
 			expectedError: "reconcile error that cannot be recovered occurred: vm size VM_SIZE_USSD does not support ultra disks in location test-location. select a different vm size or disable ultra disks. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
-					Name:       defaultVMSSName,
-					Size:       "VM_SIZE_USSD",
-					Capacity:   2,
-					SSHKeyData: "ZmFrZXNzaGtleQo=",
-					DataDisks: []infrav1.DataDisk{
-						{
-							ManagedDisk: &infrav1.ManagedDiskParameters{
-								StorageAccountType: "UltraSSD_LRS",
-							},
-						},
-					},
-				})
-				s.Location().AnyTimes().Return("test-location")
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder, spec *ScaleSetSpec) {
 			},
 		},
 		{
-			name:          "fail to create a vm with ultra disk explicitly enabled via additional capabilities, when location not supported",
-			expectedError: "reconcile error that cannot be recovered occurred: vm size VM_SIZE_USSD does not support ultra disks in location test-location. select a different vm size or disable ultra disks. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
-					Name:       defaultVMSSName,
-					Size:       "VM_SIZE_USSD",
-					Capacity:   2,
-					SSHKeyData: "ZmFrZXNzaGtleQo=",
-					AdditionalCapabilities: &infrav1.AdditionalCapabilities{
-						UltraSSDEnabled: to.BoolPtr(true),
+			name: "fail to create a vm with ultra disk data disk when additional capabilities disable ultra disks, when location not supported",
+			spec: func() ScaleSetSpec {
+				spec := newDefaultVMSSSpec()
+				spec.Size = "VM_SIZE_USSD"
+				spec.DataDisks = []infrav1.DataDisk{
+					{
+						ManagedDisk: &infrav1.ManagedDiskParameters{
+							StorageAccountType: "UltraSSD_LRS",
+						},
 					},
-				})
-				s.Location().AnyTimes().Return("test-location")
+				}
+				spec.AdditionalCapabilities = &infrav1.AdditionalCapabilities{
+					UltraSSDEnabled: to.BoolPtr(false),
+				}
+				return spec
 			},
-		},
-		{
-			name:          "fail to create a vm with ultra disk explicitly enabled via additional capabilities, when location not supported",
 			expectedError: "reconcile error that cannot be recovered occurred: vm size VM_SIZE_USSD does not support ultra disks in location test-location. select a different vm size or disable ultra disks. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
-					Name:       defaultVMSSName,
-					Size:       "VM_SIZE_USSD",
-					Capacity:   2,
-					SSHKeyData: "ZmFrZXNzaGtleQo=",
-					DataDisks: []infrav1.DataDisk{
-						{
-							ManagedDisk: &infrav1.ManagedDiskParameters{
-								StorageAccountType: "UltraSSD_LRS",
-							},
-						},
-					},
-					AdditionalCapabilities: &infrav1.AdditionalCapabilities{
-						UltraSSDEnabled: to.BoolPtr(false),
-					},
-				})
-				s.Location().AnyTimes().Return("test-location")
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder, spec *ScaleSetSpec) {
 			},
 		},
 	}
@@ -635,12 +370,16 @@ func TestReconcileVMSS(t *testing.T) {
 
 			scopeMock := mock_scalesets.NewMockScaleSetScope(mockCtrl)
 			clientMock := mock_scalesets.NewMockClient(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
 
-			tc.expect(g, scopeMock.EXPECT(), clientMock.EXPECT())
+			spec := tc.spec()
+			scopeMock.EXPECT().ScaleSetSpec().Return(&spec).AnyTimes()
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT(), asyncMock.EXPECT(), &spec)
 
 			s := &Service{
 				Scope:            scopeMock,
 				Client:           clientMock,
+				Reconciler:       asyncMock,
 				resourceSKUCache: resourceskus.NewStaticCache(getFakeSkus(), "test-location"),
 			}
 
@@ -650,74 +389,38 @@ func TestReconcileVMSS(t *testing.T) {
 				g.Expect(err).To(MatchError(tc.expectedError), err.Error())
 			} else {
 				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(spec.VMImage).To(Equal(defaultVMImage))
+				g.Expect(spec.BootstrapData).To(Equal("fake-bootstrap-data"))
+				g.Expect(spec.MaxSurge).To(Equal(1))
+				g.Expect(spec.VMSSInstances).To(Equal(newDefaultInstances()))
 			}
 		})
 	}
 }
 
 func TestDeleteVMSS(t *testing.T) {
-	const (
-		resourceGroup = "my-rg"
-		name          = "my-vmss"
-	)
-
 	testcases := []struct {
 		name          string
 		expectedError string
-		expect        func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder)
+		expect        func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder, spec *ScaleSetSpec)
 	}{
 		{
 			name:          "successfully delete an existing vmss",
 			expectedError: "",
-			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
-					Name:     "my-existing-vmss",
-					Size:     "VM_SIZE",
-					Capacity: 3,
-				}).AnyTimes()
-				s.ResourceGroup().AnyTimes().Return("my-existing-rg")
-				future := &infrav1.Future{}
-				s.GetLongRunningOperationState("my-existing-vmss", serviceName, infrav1.DeleteFuture).Return(future)
-				m.GetResultIfDone(gomockinternal.AContext(), future).Return(compute.VirtualMachineScaleSet{}, nil)
-				m.Get(gomockinternal.AContext(), "my-existing-rg", "my-existing-vmss").
-					Return(compute.VirtualMachineScaleSet{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
-				s.DeleteLongRunningOperationState("my-existing-vmss", serviceName, infrav1.DeleteFuture)
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder, spec *ScaleSetSpec) {
+				r.DeleteResource(gomockinternal.AContext(), spec, serviceName).Return(nil)
 				s.UpdateDeleteStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
-			},
-		},
-		{
-			name:          "vmss already deleted",
-			expectedError: "",
-			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
-					Name:     name,
-					Size:     "VM_SIZE",
-					Capacity: 3,
-				}).AnyTimes()
-				s.ResourceGroup().AnyTimes().Return(resourceGroup)
-				s.GetLongRunningOperationState(name, serviceName, infrav1.DeleteFuture).Return(nil)
-				m.DeleteAsync(gomockinternal.AContext(), resourceGroup, name).
-					Return(nil, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
-				m.Get(gomockinternal.AContext(), resourceGroup, name).
-					Return(compute.VirtualMachineScaleSet{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				m.Get(gomockinternal.AContext(), spec).Return(nil, notFoundError)
 			},
 		},
 		{
 			name:          "vmss deletion fails",
-			expectedError: "failed to delete VMSS my-vmss in resource group my-rg: #: Internal Server Error: StatusCode=500",
-			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
-					Name:     name,
-					Size:     "VM_SIZE",
-					Capacity: 3,
-				}).AnyTimes()
-				s.ResourceGroup().AnyTimes().Return(resourceGroup)
-				s.GetLongRunningOperationState(name, serviceName, infrav1.DeleteFuture).Return(nil)
-				m.DeleteAsync(gomockinternal.AContext(), resourceGroup, name).
-					Return(nil, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
-				m.Get(gomockinternal.AContext(), resourceGroup, name).
-					Return(newDefaultVMSS("VM_SIZE"), nil)
-				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(newDefaultInstances(), nil).AnyTimes()
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder, spec *ScaleSetSpec) {
+				r.DeleteResource(gomockinternal.AContext(), spec, serviceName).Return(internalError)
+				s.UpdateDeleteStatus(infrav1.BootstrapSucceededCondition, serviceName, internalError)
+				m.Get(gomockinternal.AContext(), spec).Return(newDefaultVMSS("VM_SIZE"), nil)
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(newDefaultInstances(), nil)
 				s.SetVMSSState(gomock.AssignableToTypeOf(&azure.VMSS{}))
 			},
 		},
@@ -732,12 +435,16 @@ func TestDeleteVMSS(t *testing.T) {
 			defer mockCtrl.Finish()
 			scopeMock := mock_scalesets.NewMockScaleSetScope(mockCtrl)
 			clientMock := mock_scalesets.NewMockClient(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
 
-			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+			spec := newDefaultVMSSSpec()
+			scopeMock.EXPECT().ScaleSetSpec().Return(&spec).AnyTimes()
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT(), asyncMock.EXPECT(), &spec)
 
 			s := &Service{
-				Scope:  scopeMock,
-				Client: clientMock,
+				Scope:      scopeMock,
+				Client:     clientMock,
+				Reconciler: asyncMock,
 			}
 
 			err := s.Delete(context.TODO())
@@ -924,6 +631,38 @@ func getFakeSkus() []compute.ResourceSku {
 				},
 			},
 		},
+		{
+			Name:         to.StringPtr("VM_SIZE_CVM"),
+			ResourceType: to.StringPtr(string(resourceskus.VirtualMachines)),
+			Kind:         to.StringPtr(string(resourceskus.VirtualMachines)),
+			Locations: &[]string{
+				"test-location",
+			},
+			LocationInfo: &[]compute.ResourceSkuLocationInfo{
+				{
+					Location: to.StringPtr("test-location"),
+					Zones:    &[]string{"1", "3"},
+				},
+			},
+			Capabilities: &[]compute.ResourceSkuCapabilities{
+				{
+					Name:  to.StringPtr(resourceskus.VCPUs),
+					Value: to.StringPtr("4"),
+				},
+				{
+					Name:  to.StringPtr(resourceskus.MemoryGB),
+					Value: to.StringPtr("8"),
+				},
+				{
+					Name:  to.StringPtr(resourceskus.HyperVGenerations),
+					Value: to.StringPtr("V2"),
+				},
+				{
+					Name:  to.StringPtr(resourceskus.ConfidentialComputingType),
+					Value: to.StringPtr("SNP"),
+				},
+			},
+		},
 		{
 			Name:         to.StringPtr("VM_SIZE_USSD"),
 			ResourceType: to.StringPtr(string(resourceskus.VirtualMachines)),
@@ -993,17 +732,22 @@ func getFakeSkus() []compute.ResourceSku {
 					Name:  to.StringPtr(resourceskus.EphemeralOSDisk),
 					Value: to.StringPtr("True"),
 				},
+				{
+					Name:  to.StringPtr(resourceskus.MaxResourceVolumeMB),
+					Value: to.StringPtr("153600"),
+				},
 			},
 		},
 	}
 }
 
-func newDefaultVMSSSpec() azure.ScaleSetSpec {
-	return azure.ScaleSetSpec{
-		Name:       defaultVMSSName,
-		Size:       "VM_SIZE",
-		Capacity:   2,
-		SSHKeyData: "ZmFrZXNzaGtleQo=",
+func newDefaultVMSSSpec() ScaleSetSpec {
+	return ScaleSetSpec{
+		Name:          defaultVMSSName,
+		ResourceGroup: defaultResourceGroup,
+		Size:          "VM_SIZE",
+		Capacity:      2,
+		SSHKeyData:    "ZmFrZXNzaGtleQo=",
 		OSDisk: infrav1.OSDisk{
 			OSType:     "Linux",
 			DiskSizeGB: to.Int32Ptr(120),
@@ -1038,6 +782,7 @@ func newDefaultVMSSSpec() azure.ScaleSetSpec {
 			},
 		},
 		SubnetName:                   "my-subnet",
+		VNetSubscriptionID:           defaultSubscriptionID,
 		VNetName:                     "my-vnet",
 		VNetResourceGroup:            defaultResourceGroup,
 		PublicLBName:                 "capz-lb",
@@ -1045,10 +790,30 @@ func newDefaultVMSSSpec() azure.ScaleSetSpec {
 		AcceleratedNetworking:        nil,
 		TerminateNotificationTimeout: to.IntPtr(7),
 		FailureDomains:               []string{"1", "3"},
+		Location:                     "test-location",
+		SubscriptionID:               defaultSubscriptionID,
+		ClusterName:                  "my-cluster",
+		VMSSExtensionSpecs: []azure.ResourceSpecGetter{
+			&VMSSExtensionSpec{
+				ExtensionSpec: azure.ExtensionSpec{
+					Name:      "someExtension",
+					VMName:    "my-vmss",
+					Publisher: "somePublisher",
+					Version:   "someVersion",
+					Settings: map[string]string{
+						"someSetting": "someValue",
+					},
+					ProtectedSettings: map[string]string{
+						"commandToExecute": "echo hello",
+					},
+				},
+				ResourceGroup: "my-rg",
+			},
+		},
 	}
 }
 
-func newWindowsVMSSSpec() azure.ScaleSetSpec {
+func newWindowsVMSSSpec() ScaleSetSpec {
 	vmss := newDefaultVMSSSpec()
 	vmss.OSDisk.OSType = azure.WindowsOS
 	return vmss
@@ -1301,105 +1066,12 @@ func newDefaultInstances() []compute.VirtualMachineScaleSetVM {
 		},
 	}
 }
-
-func setupDefaultVMSSInProgressOperationDoneExpectations(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, createdVMSS compute.VirtualMachineScaleSet, instances []compute.VirtualMachineScaleSetVM) {
-	createdVMSS.ID = to.StringPtr("subscriptions/1234/resourceGroups/my_resource_group/providers/Microsoft.Compute/virtualMachines/my-vm")
-	createdVMSS.ProvisioningState = to.StringPtr(string(infrav1.Succeeded))
-	setupDefaultVMSSExpectations(s)
-	future := &infrav1.Future{
-		Type:          infrav1.PutFuture,
-		ResourceGroup: defaultResourceGroup,
-		Name:          defaultVMSSName,
-		Data:          "",
-	}
-	s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PutFuture).Return(future)
-	m.GetResultIfDone(gomockinternal.AContext(), future).Return(createdVMSS, nil).AnyTimes()
-	m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(instances, nil).AnyTimes()
-	s.MaxSurge().Return(1, nil)
-	s.SetVMSSState(gomock.Any())
-	s.SetProviderID(azure.ProviderIDPrefix + *createdVMSS.ID)
-}
-
-func setupDefaultVMSSStartCreatingExpectations(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-	setupDefaultVMSSExpectations(s)
+func setupDefaultVMSSResolveExpectations(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+	s.DeleteLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PatchFuture)
 	s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PutFuture).Return(nil)
-	s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PatchFuture).Return(nil)
-	m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).
-		Return(compute.VirtualMachineScaleSet{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
-}
-
-func setupCreatingSucceededExpectations(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, vmss compute.VirtualMachineScaleSet, future *infrav1.Future) {
-	s.SetLongRunningOperationState(future)
-	m.GetResultIfDone(gomockinternal.AContext(), future).Return(compute.VirtualMachineScaleSet{}, azure.NewOperationNotDoneError(future))
-	m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(vmss, nil)
-	m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(newDefaultInstances(), nil).AnyTimes()
-	s.SetVMSSState(gomock.Any())
-	s.SetProviderID(azure.ProviderIDPrefix + *vmss.ID)
-}
-
-func setupDefaultVMSSExpectations(s *mock_scalesets.MockScaleSetScopeMockRecorder) {
-	setupVMSSExpectationsWithoutVMImage(s)
-	image := &infrav1.Image{
-		Marketplace: &infrav1.AzureMarketplaceImage{
-			ImagePlan: infrav1.ImagePlan{
-				Publisher: "fake-publisher",
-				Offer:     "my-offer",
-				SKU:       "sku-id",
-			},
-			Version: "1.0",
-		},
-	}
-	s.GetVMImage(gomockinternal.AContext()).Return(image, nil).AnyTimes()
-	s.SaveVMImageToStatus(image)
-}
-
-func setupUpdateVMSSExpectations(s *mock_scalesets.MockScaleSetScopeMockRecorder) {
-	setupVMSSExpectationsWithoutVMImage(s)
-	image := &infrav1.Image{
-		Marketplace: &infrav1.AzureMarketplaceImage{
-			ImagePlan: infrav1.ImagePlan{
-				Publisher: "fake-publisher",
-				Offer:     "my-offer",
-				SKU:       "sku-id",
-			},
-			Version: "2.0",
-		},
-	}
-	s.GetVMImage(gomockinternal.AContext()).Return(image, nil).AnyTimes()
-	s.SaveVMImageToStatus(image)
-}
-
-func setupVMSSExpectationsWithoutVMImage(s *mock_scalesets.MockScaleSetScopeMockRecorder) {
-	s.SubscriptionID().AnyTimes().Return(defaultSubscriptionID)
-	s.ResourceGroup().AnyTimes().Return(defaultResourceGroup)
-	s.AdditionalTags()
-	s.Location().AnyTimes().Return("test-location")
-	s.ClusterName().Return("my-cluster")
+	s.GetVMImage(gomockinternal.AContext()).Return(defaultVMImage, nil)
+	s.SaveVMImageToStatus(defaultVMImage)
 	s.GetBootstrapData(gomockinternal.AContext()).Return("fake-bootstrap-data", nil)
-	s.VMSSExtensionSpecs().Return([]azure.ResourceSpecGetter{
-		&VMSSExtensionSpec{
-			ExtensionSpec: azure.ExtensionSpec{
-				Name:      "someExtension",
-				VMName:    "my-vmss",
-				Publisher: "somePublisher",
-				Version:   "someVersion",
-				Settings: map[string]string{
-					"someSetting": "someValue",
-				},
-				ProtectedSettings: map[string]string{
-					"commandToExecute": "echo hello",
-				},
-			},
-			ResourceGroup: "my-rg",
-		},
-	}).AnyTimes()
-}
-
-func setupDefaultVMSSUpdateExpectations(s *mock_scalesets.MockScaleSetScopeMockRecorder) {
-	setupUpdateVMSSExpectations(s)
-	s.SetProviderID(azure.ProviderIDPrefix + "subscriptions/1234/resourceGroups/my_resource_group/providers/Microsoft.Compute/virtualMachines/my-vm")
-	s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PutFuture).Return(nil)
-	s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PatchFuture).Return(nil)
 	s.MaxSurge().Return(1, nil)
-	s.SetVMSSState(gomock.Any())
+	m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(newDefaultInstances(), nil).AnyTimes()
 }
@@ -0,0 +1,509 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalesets
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
+	"sigs.k8s.io/cluster-api-provider-azure/util/generators"
+)
+
+// ScaleSetSpec defines the specification for a Scale Set.
+type ScaleSetSpec struct {
+	Name                         string
+	ResourceGroup                string
+	Size                         string
+	Capacity                     int64
+	SSHKeyData                   string
+	OSDisk                       infrav1.OSDisk
+	DataDisks                    []infrav1.DataDisk
+	SubnetName                   string
+	VNetSubscriptionID           string
+	VNetName                     string
+	VNetResourceGroup            string
+	PublicLBName                 string
+	PublicLBAddressPoolName      string
+	AcceleratedNetworking        *bool
+	TerminateNotificationTimeout *int
+	Identity                     infrav1.VMIdentity
+	UserAssignedIdentities       []infrav1.UserAssignedIdentity
+	SecurityProfile              *infrav1.SecurityProfile
+	SpotVMOptions                *infrav1.SpotVMOptions
+	AdditionalCapabilities       *infrav1.AdditionalCapabilities
+	FailureDomains               []string
+	Location                     string
+	SubscriptionID               string
+	ClusterName                  string
+	AdditionalTags               infrav1.Tags
+	VMSSExtensionSpecs           []azure.ResourceSpecGetter
+
+	// The following fields are resolved by the scale sets service before the spec is reconciled.
+	SKU           resourceskus.SKU
+	VMImage       *infrav1.Image
+	BootstrapData string
+	MaxSurge      int
+	VMSSInstances []compute.VirtualMachineScaleSetVM
+}
+
+// ResourceName returns the name of the scale set.
+func (s *ScaleSetSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *ScaleSetSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for scale sets.
+func (s *ScaleSetSpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns the parameters for the scale set. A compute.VirtualMachineScaleSet is returned to create a new
+// scale set, and a compute.VirtualMachineScaleSetUpdate is returned to patch an existing one.
+func (s *ScaleSetSpec) Parameters(existing interface{}) (params interface{}, err error) {
+	vmss, err := s.buildVMSS()
+	if err != nil {
+		return nil, err
+	}
+
+	if existing == nil {
+		return vmss, nil
+	}
+
+	existingVMSS, ok := existing.(compute.VirtualMachineScaleSet)
+	if !ok {
+		return nil, errors.Errorf("%T is not a compute.VirtualMachineScaleSet", existing)
+	}
+
+	// VMSS already exists and may have changes; update it with a PATCH
+	// we do this to avoid overwriting fields in networkProfile modified by cloud-provider
+	patch, err := getVMSSUpdateFromVMSS(vmss)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to generate vmss patch for %s", s.Name)
+	}
+
+	infraVMSS := converters.SDKToVMSS(existingVMSS, s.VMSSInstances)
+	hasModelChanges := hasModelModifyingDifferences(infraVMSS, vmss)
+	if s.MaxSurge > 0 && (hasModelChanges || !infraVMSS.HasEnoughLatestModelOrNotMixedModel()) {
+		// surge capacity with the intention of lowering during instance reconciliation
+		patch.Sku.Capacity = to.Int64Ptr(s.Capacity + int64(s.MaxSurge))
+	}
+
+	// If there are no model changes and no increase in the replica count, do not update the VMSS.
+	// Decreases in replica count is handled by deleting AzureMachinePoolMachine instances in the MachinePoolScope
+	if *patch.Sku.Capacity <= infraVMSS.Capacity && !hasModelChanges {
+		return nil, nil
+	}
+
+	return patch, nil
+}
+
+func (s *ScaleSetSpec) buildVMSS() (compute.VirtualMachineScaleSet, error) {
+	accelNet := s.AcceleratedNetworking
+	if accelNet == nil {
+		// set accelerated networking to the capability of the VMSize
+		accelNet = to.BoolPtr(s.SKU.HasCapability(resourceskus.AcceleratedNetworking))
+	}
+
+	extensions, err := s.generateExtensions()
+	if err != nil {
+		return compute.VirtualMachineScaleSet{}, err
+	}
+
+	storageProfile, err := s.generateStorageProfile()
+	if err != nil {
+		return compute.VirtualMachineScaleSet{}, err
+	}
+
+	securityProfile, err := s.getSecurityProfile()
+	if err != nil {
+		return compute.VirtualMachineScaleSet{}, err
+	}
+
+	priority, evictionPolicy, billingProfile, err := converters.GetSpotVMOptions(s.SpotVMOptions, s.OSDisk.DiffDiskSettings)
+	if err != nil {
+		return compute.VirtualMachineScaleSet{}, errors.Wrapf(err, "failed to get Spot VM options")
+	}
+
+	// Get the node outbound LB backend pool ID
+	var backendAddressPools []compute.SubResource
+	if s.PublicLBName != "" {
+		if s.PublicLBAddressPoolName != "" {
+			backendAddressPools = append(backendAddressPools,
+				compute.SubResource{
+					ID: to.StringPtr(azure.AddressPoolID(s.SubscriptionID, s.ResourceGroup, s.PublicLBName, s.PublicLBAddressPoolName)),
+				})
+		}
+	}
+
+	osProfile, err := s.generateOSProfile()
+	if err != nil {
+		return compute.VirtualMachineScaleSet{}, err
+	}
+
+	vmss := compute.VirtualMachineScaleSet{
+		Location: to.StringPtr(s.Location),
+		Sku: &compute.Sku{
+			Name:     to.StringPtr(s.Size),
+			Tier:     to.StringPtr("Standard"),
+			Capacity: to.Int64Ptr(s.Capacity),
+		},
+		Zones: to.StringSlicePtr(s.FailureDomains),
+		Plan:  s.generateImagePlan(),
+		VirtualMachineScaleSetProperties: &compute.VirtualMachineScaleSetProperties{
+			SinglePlacementGroup: to.BoolPtr(false),
+			UpgradePolicy: &compute.UpgradePolicy{
+				Mode: compute.UpgradeModeManual,
+			},
+			Overprovision: to.BoolPtr(false),
+			VirtualMachineProfile: &compute.VirtualMachineScaleSetVMProfile{
+				OsProfile:       osProfile,
+				StorageProfile:  storageProfile,
+				SecurityProfile: securityProfile,
+				DiagnosticsProfile: &compute.DiagnosticsProfile{
+					BootDiagnostics: &compute.BootDiagnostics{
+						Enabled: to.BoolPtr(true),
+					},
+				},
+				NetworkProfile: &compute.VirtualMachineScaleSetNetworkProfile{
+					NetworkInterfaceConfigurations: &[]compute.VirtualMachineScaleSetNetworkConfiguration{
+						{
+							Name: to.StringPtr(s.Name),
+							VirtualMachineScaleSetNetworkConfigurationProperties: &compute.VirtualMachineScaleSetNetworkConfigurationProperties{
+								Primary:            to.BoolPtr(true),
+								EnableIPForwarding: to.BoolPtr(true),
+								IPConfigurations: &[]compute.VirtualMachineScaleSetIPConfiguration{
+									{
+										Name: to.StringPtr(s.Name),
+										VirtualMachineScaleSetIPConfigurationProperties: &compute.VirtualMachineScaleSetIPConfigurationProperties{
+											Subnet: &compute.APIEntityReference{
+												ID: to.StringPtr(azure.SubnetID(s.VNetSubscriptionID, s.VNetResourceGroup, s.VNetName, s.SubnetName)),
+											},
+											Primary:                         to.BoolPtr(true),
+											PrivateIPAddressVersion:         compute.IPVersionIPv4,
+											LoadBalancerBackendAddressPools: &backendAddressPools,
+										},
+									},
+								},
+								EnableAcceleratedNetworking: accelNet,
+							},
+						},
+					},
+				},
+				Priority:       priority,
+				EvictionPolicy: evictionPolicy,
+				BillingProfile: billingProfile,
+				ExtensionProfile: &compute.VirtualMachineScaleSetExtensionProfile{
+					Extensions: &extensions,
+				},
+			},
+		},
+	}
+
+	// Assign Identity to VMSS
+	if s.Identity == infrav1.VMIdentitySystemAssigned {
+		vmss.Identity = &compute.VirtualMachineScaleSetIdentity{
+			Type: compute.ResourceIdentityTypeSystemAssigned,
+		}
+	} else if s.Identity == infrav1.VMIdentityUserAssigned {
+		userIdentitiesMap, err := converters.UserAssignedIdentitiesToVMSSSDK(s.UserAssignedIdentities)
+		if err != nil {
+			return vmss, errors.Wrapf(err, "failed to assign identity %q", s.Name)
+		}
+		vmss.Identity = &compute.VirtualMachineScaleSetIdentity{
+			Type:                   compute.ResourceIdentityTypeUserAssigned,
+			UserAssignedIdentities: userIdentitiesMap,
+		}
+	}
+
+	// Provisionally detect whether there is any Data Disk defined which uses UltraSSDs.
+	// If that's the case, enable the UltraSSD capability.
+	for _, dataDisk := range s.DataDisks {
+		if dataDisk.ManagedDisk != nil && dataDisk.ManagedDisk.StorageAccountType == string(compute.StorageAccountTypesUltraSSDLRS) {
+			vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{
+				UltraSSDEnabled: to.BoolPtr(true),
+			}
+		}
+	}
+
+	// Set Additional Capabilities if any is present on the spec.
+	if s.AdditionalCapabilities != nil {
+		// Set UltraSSDEnabled if a specific value is set on the spec for it.
+		if s.AdditionalCapabilities.UltraSSDEnabled != nil {
+			if vmss.AdditionalCapabilities == nil {
+				vmss.AdditionalCapabilities = &compute.AdditionalCapabilities{}
+			}
+			vmss.AdditionalCapabilities.UltraSSDEnabled = s.AdditionalCapabilities.UltraSSDEnabled
+		}
+	}
+
+	if s.TerminateNotificationTimeout != nil {
+		vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.ScheduledEventsProfile = &compute.ScheduledEventsProfile{
+			TerminateNotificationProfile: &compute.TerminateNotificationProfile{
+				NotBeforeTimeout: to.StringPtr(fmt.Sprintf("PT%dM", *s.TerminateNotificationTimeout)),
+				Enable:           to.BoolPtr(true),
+			},
+		}
+	}
+
+	tags := infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.ClusterName,
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        to.StringPtr(s.Name),
+		Role:        to.StringPtr(infrav1.Node),
+		Additional:  s.AdditionalTags,
+	})
+
+	vmss.Tags = converters.TagsToMap(tags)
+	return vmss, nil
+}
+
+func hasModelModifyingDifferences(infraVMSS *azure.VMSS, vmss compute.VirtualMachineScaleSet) bool {
+	other := converters.SDKToVMSS(vmss, []compute.VirtualMachineScaleSetVM{})
+	return infraVMSS.HasModelChanges(*other)
+}
+
+func (s *ScaleSetSpec) generateExtensions() ([]compute.VirtualMachineScaleSetExtension, error) {
+	extensions := make([]compute.VirtualMachineScaleSetExtension, len(s.VMSSExtensionSpecs))
+	for i, extensionSpec := range s.VMSSExtensionSpecs {
+		extensionSpec := extensionSpec
+		parameters, err := extensionSpec.Parameters(nil)
+		if err != nil {
+			return nil, err
+		}
+		vmssextension, ok := parameters.(compute.VirtualMachineScaleSetExtension)
+		if !ok {
+			return nil, errors.Errorf("%T is not a compute.VirtualMachineScaleSetExtension", parameters)
+		}
+		extensions[i] = vmssextension
+	}
+
+	return extensions, nil
+}
+
+// generateStorageProfile generates a pointer to a compute.VirtualMachineScaleSetStorageProfile which can utilized for VM creation.
+func (s *ScaleSetSpec) generateStorageProfile() (*compute.VirtualMachineScaleSetStorageProfile, error) {
+	storageProfile := &compute.VirtualMachineScaleSetStorageProfile{
+		OsDisk: &compute.VirtualMachineScaleSetOSDisk{
+			OsType:       compute.OperatingSystemTypes(s.OSDisk.OSType),
+			CreateOption: compute.DiskCreateOptionTypesFromImage,
+			DiskSizeGB:   s.OSDisk.DiskSizeGB,
+		},
+	}
+
+	// enable ephemeral OS
+	if s.OSDisk.DiffDiskSettings != nil {
+		if !s.SKU.HasCapability(resourceskus.EphemeralOSDisk) {
+			return nil, fmt.Errorf("vm size %s does not support ephemeral os. select a different vm size or disable ephemeral os", s.Size)
+		}
+
+		storageProfile.OsDisk.DiffDiskSettings = &compute.DiffDiskSettings{
+			Option: compute.DiffDiskOptions(s.OSDisk.DiffDiskSettings.Option),
+		}
+
+		if s.OSDisk.DiffDiskSettings.Placement != "" {
+			if err := s.SKU.ValidateDiffDiskPlacement(s.OSDisk); err != nil {
+				return nil, err
+			}
+			storageProfile.OsDisk.DiffDiskSettings.Placement = compute.DiffDiskPlacement(s.OSDisk.DiffDiskSettings.Placement)
+		}
+	}
+
+	if s.OSDisk.ManagedDisk != nil {
+		storageProfile.OsDisk.ManagedDisk = &compute.VirtualMachineScaleSetManagedDiskParameters{}
+		if s.OSDisk.ManagedDisk.StorageAccountType != "" {
+			storageProfile.OsDisk.ManagedDisk.StorageAccountType = compute.StorageAccountTypes(s.OSDisk.ManagedDisk.StorageAccountType)
+		}
+		if s.OSDisk.ManagedDisk.DiskEncryptionSet != nil {
+			storageProfile.OsDisk.ManagedDisk.DiskEncryptionSet = &compute.DiskEncryptionSetParameters{ID: to.StringPtr(s.OSDisk.ManagedDisk.DiskEncryptionSet.ID)}
+		}
+		if s.OSDisk.ManagedDisk.SecurityProfile != nil {
+			storageProfile.OsDisk.ManagedDisk.SecurityProfile = &compute.VMDiskSecurityProfile{
+				SecurityEncryptionType: compute.SecurityEncryptionTypes(s.OSDisk.ManagedDisk.SecurityProfile.SecurityEncryptionType),
+			}
+			if s.OSDisk.ManagedDisk.SecurityProfile.DiskEncryptionSet != nil {
+				storageProfile.OsDisk.ManagedDisk.SecurityProfile.DiskEncryptionSet = &compute.DiskEncryptionSetParameters{ID: to.StringPtr(s.OSDisk.ManagedDisk.SecurityProfile.DiskEncryptionSet.ID)}
+			}
+		}
+	}
+
+	if s.OSDisk.CachingType != "" {
+		storageProfile.OsDisk.Caching = compute.CachingTypes(s.OSDisk.CachingType)
+	}
+
+	dataDisks := make([]compute.VirtualMachineScaleSetDataDisk, len(s.DataDisks))
+	for i, disk := range s.DataDisks {
+		dataDisks[i] = compute.VirtualMachineScaleSetDataDisk{
+			CreateOption:      compute.DiskCreateOptionTypesEmpty,
+			DiskSizeGB:        to.Int32Ptr(disk.DiskSizeGB),
+			Lun:               disk.Lun,
+			Name:              to.StringPtr(azure.GenerateDataDiskName(s.Name, disk.NameSuffix)),
+			DiskIOPSReadWrite: disk.DiskIOPSReadWrite,
+			DiskMBpsReadWrite: disk.DiskMBpsReadWrite,
+		}
+
+		if disk.ManagedDisk != nil {
+			dataDisks[i].ManagedDisk = &compute.VirtualMachineScaleSetManagedDiskParameters{
+				StorageAccountType: compute.StorageAccountTypes(disk.ManagedDisk.StorageAccountType),
+			}
+
+			if disk.ManagedDisk.DiskEncryptionSet != nil {
+				dataDisks[i].ManagedDisk.DiskEncryptionSet = &compute.DiskEncryptionSetParameters{ID: to.StringPtr(disk.ManagedDisk.DiskEncryptionSet.ID)}
+			}
+		}
+	}
+	storageProfile.DataDisks = &dataDisks
+
+	if s.VMImage == nil {
+		return nil, errors.Errorf("vm image is nil")
+	}
+	imageRef, err := converters.ImageToSDK(s.VMImage)
+	if err != nil {
+		return nil, err
+	}
+
+	storageProfile.ImageReference = imageRef
+
+	return storageProfile, nil
+}
+
+func (s *ScaleSetSpec) generateOSProfile() (*compute.VirtualMachineScaleSetOSProfile, error) {
+	sshKey, err := base64.StdEncoding.DecodeString(s.SSHKeyData)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode ssh public key")
+	}
+
+	osProfile := &compute.VirtualMachineScaleSetOSProfile{
+		ComputerNamePrefix: to.StringPtr(s.Name),
+		AdminUsername:      to.StringPtr(azure.DefaultUserName),
+		CustomData:         to.StringPtr(s.BootstrapData),
+	}
+
+	switch s.OSDisk.OSType {
+	case string(compute.OperatingSystemTypesWindows):
+		// Cloudbase-init is used to generate a password.
+		// https://cloudbase-init.readthedocs.io/en/latest/plugins.html#setting-password-main
+		//
+		// We generate a random password here in case of failure
+		// but the password on the VM will NOT be the same as created here.
+		// Access is provided via SSH public key that is set during deployment
+		// Azure also provides a way to reset user passwords in the case of need.
+		osProfile.AdminPassword = to.StringPtr(generators.SudoRandomPassword(123))
+		osProfile.WindowsConfiguration = &compute.WindowsConfiguration{
+			EnableAutomaticUpdates: to.BoolPtr(false),
+		}
+	default:
+		osProfile.LinuxConfiguration = &compute.LinuxConfiguration{
+			DisablePasswordAuthentication: to.BoolPtr(true),
+			SSH: &compute.SSHConfiguration{
+				PublicKeys: &[]compute.SSHPublicKey{
+					{
+						Path:    to.StringPtr(fmt.Sprintf("/home/%s/.ssh/authorized_keys", azure.DefaultUserName)),
+						KeyData: to.StringPtr(string(sshKey)),
+					},
+				},
+			},
+		}
+	}
+
+	return osProfile, nil
+}
+
+func (s *ScaleSetSpec) generateImagePlan() *compute.Plan {
+	image := s.VMImage
+	if image == nil {
+		return nil
+	}
+
+	if image.SharedGallery != nil && image.SharedGallery.Publisher != nil && image.SharedGallery.SKU != nil && image.SharedGallery.Offer != nil {
+		return &compute.Plan{
+			Publisher: image.SharedGallery.Publisher,
+			Name:      image.SharedGallery.SKU,
+			Product:   image.SharedGallery.Offer,
+		}
+	}
+
+	if image.Marketplace == nil || !image.Marketplace.ThirdPartyImage {
+		return nil
+	}
+
+	if image.Marketplace.Publisher == "" || image.Marketplace.SKU == "" || image.Marketplace.Offer == "" {
+		return nil
+	}
+
+	return &compute.Plan{
+		Publisher: to.StringPtr(image.Marketplace.Publisher),
+		Name:      to.StringPtr(image.Marketplace.SKU),
+		Product:   to.StringPtr(image.Marketplace.Offer),
+	}
+}
+
+func getVMSSUpdateFromVMSS(vmss compute.VirtualMachineScaleSet) (compute.VirtualMachineScaleSetUpdate, error) {
+	jsonData, err := vmss.MarshalJSON()
+	if err != nil {
+		return compute.VirtualMachineScaleSetUpdate{}, err
+	}
+
+	var update compute.VirtualMachineScaleSetUpdate
+	if err := update.UnmarshalJSON(jsonData); err != nil {
+		return update, err
+	}
+
+	// wipe out network profile, so updates won't conflict with Cloud Provider updates
+	update.VirtualMachineProfile.NetworkProfile = nil
+	return update, nil
+}
+
+func (s *ScaleSetSpec) getSecurityProfile() (*compute.SecurityProfile, error) {
+	if s.SecurityProfile == nil {
+		return nil, nil
+	}
+
+	if to.Bool(s.SecurityProfile.EncryptionAtHost) && !s.SKU.HasCapability(resourceskus.EncryptionAtHost) {
+		return nil, azure.WithTerminalError(errors.Errorf("encryption at host is not supported for VM type %s", s.Size))
+	}
+
+	securityProfile := &compute.SecurityProfile{
+		EncryptionAtHost: s.SecurityProfile.EncryptionAtHost,
+	}
+
+	if s.SecurityProfile.SecurityType != "" {
+		if err := s.SKU.ValidateSecurityType(s.SecurityProfile.SecurityType); err != nil {
+			return nil, err
+		}
+		securityProfile.SecurityType = compute.SecurityTypes(s.SecurityProfile.SecurityType)
+	}
+
+	if s.SecurityProfile.UefiSettings != nil {
+		securityProfile.UefiSettings = &compute.UefiSettings{
+			SecureBootEnabled: s.SecurityProfile.UefiSettings.SecureBootEnabled,
+			VTpmEnabled:       s.SecurityProfile.UefiSettings.VTpmEnabled,
+		}
+	}
+
+	return securityProfile, nil
+}
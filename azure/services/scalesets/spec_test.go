@@ -0,0 +1,456 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalesets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
+)
+
+func TestScaleSetParameters(t *testing.T) {
+	ultraDataDisk := infrav1.DataDisk{
+		NameSuffix: "my_disk_with_ultra_disks",
+		DiskSizeGB: 128,
+		Lun:        to.Int32Ptr(3),
+		ManagedDisk: &infrav1.ManagedDiskParameters{
+			StorageAccountType: "UltraSSD_LRS",
+		},
+	}
+
+	testcases := []struct {
+		name          string
+		spec          func(g *WithT) ScaleSetSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name: "vmss with ultra disk",
+			spec: func(g *WithT) ScaleSetSpec {
+				spec := newResolvedVMSSSpec(g, "VM_SIZE")
+				spec.DataDisks = append(spec.DataDisks, ultraDataDisk)
+				return spec
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS("VM_SIZE")
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "windows vmss",
+			spec: func(g *WithT) ScaleSetSpec {
+				spec := newWindowsVMSSSpec()
+				resolveVMSSSpec(g, &spec)
+				spec.DataDisks = append(spec.DataDisks, ultraDataDisk)
+				return spec
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachineScaleSet{}))
+				// the admin password of a Windows scale set is randomly generated
+				adminPassword := result.(compute.VirtualMachineScaleSet).VirtualMachineProfile.OsProfile.AdminPassword
+				g.Expect(adminPassword).NotTo(BeNil())
+				vmss := newDefaultWindowsVMSS()
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				vmss.VirtualMachineProfile.OsProfile.AdminPassword = adminPassword
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "vmss with defaulted accelerated networking when size allows",
+			spec: func(g *WithT) ScaleSetSpec {
+				return newResolvedVMSSSpec(g, "VM_SIZE_AN")
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS("VM_SIZE_AN")
+				netConfigs := vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.NetworkProfile.NetworkInterfaceConfigurations
+				(*netConfigs)[0].EnableAcceleratedNetworking = to.BoolPtr(true)
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "vmss with spot vm",
+			spec: func(g *WithT) ScaleSetSpec {
+				spec := newResolvedVMSSSpec(g, "VM_SIZE")
+				spec.SpotVMOptions = &infrav1.SpotVMOptions{}
+				spec.DataDisks = append(spec.DataDisks, ultraDataDisk)
+				return spec
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS("VM_SIZE")
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.Priority = compute.VirtualMachinePriorityTypesSpot
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "vmss with spot vm and ephemeral disk",
+			spec: func(g *WithT) ScaleSetSpec {
+				spec := newResolvedVMSSSpec(g, vmSizeEPH)
+				spec.SpotVMOptions = &infrav1.SpotVMOptions{}
+				spec.OSDisk.DiffDiskSettings = &infrav1.DiffDiskSettings{
+					Option: string(compute.DiffDiskOptionsLocal),
+				}
+				return spec
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS(vmSizeEPH)
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.StorageProfile.OsDisk.DiffDiskSettings = &compute.DiffDiskSettings{
+					Option: compute.DiffDiskOptionsLocal,
+				}
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.Priority = compute.VirtualMachinePriorityTypesSpot
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "vmss with spot vm and a defined delete evictionPolicy",
+			spec: func(g *WithT) ScaleSetSpec {
+				spec := newResolvedVMSSSpec(g, vmSizeEPH)
+				deletePolicy := infrav1.SpotEvictionPolicyDelete
+				spec.SpotVMOptions = &infrav1.SpotVMOptions{EvictionPolicy: &deletePolicy}
+				return spec
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS(vmSizeEPH)
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.Priority = compute.VirtualMachinePriorityTypesSpot
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.EvictionPolicy = compute.VirtualMachineEvictionPolicyTypesDelete
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "vmss with spot vm and a maximum price",
+			spec: func(g *WithT) ScaleSetSpec {
+				spec := newResolvedVMSSSpec(g, "VM_SIZE")
+				maxPrice := resource.MustParse("0.001")
+				spec.SpotVMOptions = &infrav1.SpotVMOptions{
+					MaxPrice: &maxPrice,
+				}
+				spec.DataDisks = append(spec.DataDisks, ultraDataDisk)
+				return spec
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS("VM_SIZE")
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.Priority = compute.VirtualMachinePriorityTypesSpot
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.BillingProfile = &compute.BillingProfile{
+					MaxPrice: to.Float64Ptr(0.001),
+				}
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "vmss with encryption",
+			spec: func(g *WithT) ScaleSetSpec {
+				spec := newResolvedVMSSSpec(g, "VM_SIZE")
+				spec.OSDisk.ManagedDisk.DiskEncryptionSet = &infrav1.DiskEncryptionSetParameters{
+					ID: "my-diskencryptionset-id",
+				}
+				spec.DataDisks = append(spec.DataDisks, ultraDataDisk)
+				return spec
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS("VM_SIZE")
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				osdisk := vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.StorageProfile.OsDisk
+				osdisk.ManagedDisk = &compute.VirtualMachineScaleSetManagedDiskParameters{
+					StorageAccountType: "Premium_LRS",
+					DiskEncryptionSet: &compute.DiskEncryptionSetParameters{
+						ID: to.StringPtr("my-diskencryptionset-id"),
+					},
+				}
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "vmss with user assigned identity",
+			spec: func(g *WithT) ScaleSetSpec {
+				spec := newResolvedVMSSSpec(g, "VM_SIZE")
+				spec.DataDisks = append(spec.DataDisks, ultraDataDisk)
+				spec.Identity = infrav1.VMIdentityUserAssigned
+				spec.UserAssignedIdentities = []infrav1.UserAssignedIdentity{
+					{
+						ProviderID: "azure:///subscriptions/123/resourcegroups/456/providers/Microsoft.ManagedIdentity/userAssignedIdentities/id1",
+					},
+				}
+				return spec
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS("VM_SIZE")
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				vmss.Identity = &compute.VirtualMachineScaleSetIdentity{
+					Type: compute.ResourceIdentityTypeUserAssigned,
+					UserAssignedIdentities: map[string]*compute.VirtualMachineScaleSetIdentityUserAssignedIdentitiesValue{
+						"/subscriptions/123/resourcegroups/456/providers/Microsoft.ManagedIdentity/userAssignedIdentities/id1": {},
+					},
+				}
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "vmss with encryption at host enabled",
+			spec: func(g *WithT) ScaleSetSpec {
+				spec := newResolvedVMSSSpec(g, "VM_SIZE_EAH")
+				spec.SecurityProfile = &infrav1.SecurityProfile{EncryptionAtHost: to.BoolPtr(true)}
+				return spec
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS("VM_SIZE_EAH")
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.SecurityProfile = &compute.SecurityProfile{
+					EncryptionAtHost: to.BoolPtr(true),
+				}
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "confidential vmss with an encrypted OS disk",
+			spec: func(g *WithT) ScaleSetSpec {
+				spec := newResolvedVMSSSpec(g, "VM_SIZE_CVM")
+				spec.SecurityProfile = &infrav1.SecurityProfile{
+					SecurityType: infrav1.SecurityTypesConfidentialVM,
+					UefiSettings: &infrav1.UefiSettings{
+						SecureBootEnabled: to.BoolPtr(true),
+						VTpmEnabled:       to.BoolPtr(true),
+					},
+				}
+				spec.OSDisk.ManagedDisk.SecurityProfile = &infrav1.VMDiskSecurityProfile{
+					SecurityEncryptionType: infrav1.SecurityEncryptionTypeVMGuestStateOnly,
+				}
+				return spec
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS("VM_SIZE_CVM")
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.SecurityProfile = &compute.SecurityProfile{
+					SecurityType: compute.SecurityTypesConfidentialVM,
+					UefiSettings: &compute.UefiSettings{
+						SecureBootEnabled: to.BoolPtr(true),
+						VTpmEnabled:       to.BoolPtr(true),
+					},
+				}
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.StorageProfile.OsDisk.ManagedDisk.SecurityProfile = &compute.VMDiskSecurityProfile{
+					SecurityEncryptionType: compute.SecurityEncryptionTypesVMGuestStateOnly,
+				}
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "vmss with ephemeral osdisk",
+			spec: func(g *WithT) ScaleSetSpec {
+				spec := newResolvedVMSSSpec(g, vmSizeEPH)
+				spec.OSDisk.DiffDiskSettings = &infrav1.DiffDiskSettings{
+					Option: "Local",
+				}
+				spec.OSDisk.CachingType = "ReadOnly"
+				return spec
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS(vmSizeEPH)
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.StorageProfile.OsDisk.DiffDiskSettings = &compute.DiffDiskSettings{
+					Option: compute.DiffDiskOptionsLocal,
+				}
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.StorageProfile.OsDisk.Caching = compute.CachingTypesReadOnly
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "vmss with ephemeral osdisk placed on the resource disk",
+			spec: func(g *WithT) ScaleSetSpec {
+				spec := newResolvedVMSSSpec(g, vmSizeEPH)
+				spec.OSDisk.DiffDiskSettings = &infrav1.DiffDiskSettings{
+					Option:    "Local",
+					Placement: infrav1.DiffDiskPlacementResourceDisk,
+				}
+				spec.OSDisk.CachingType = "ReadOnly"
+				return spec
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS(vmSizeEPH)
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.StorageProfile.OsDisk.DiffDiskSettings = &compute.DiffDiskSettings{
+					Option:    compute.DiffDiskOptionsLocal,
+					Placement: compute.DiffDiskPlacementResourceDisk,
+				}
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.StorageProfile.OsDisk.Caching = compute.CachingTypesReadOnly
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "vmss with ephemeral osdisk placed on the cache disk of a size without one",
+			spec: func(g *WithT) ScaleSetSpec {
+				spec := newResolvedVMSSSpec(g, vmSizeEPH)
+				spec.OSDisk.DiffDiskSettings = &infrav1.DiffDiskSettings{
+					Option:    "Local",
+					Placement: infrav1.DiffDiskPlacementCacheDisk,
+				}
+				return spec
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "reconcile error that cannot be recovered occurred: the CacheDisk of vm size VM_SIZE_EPH is too small for an ephemeral os disk of 120 GB. select a different vm size, placement or os disk size. Object will not be requeued",
+		},
+		{
+			name: "vmss with ultra disk with provisioned performance",
+			spec: func(g *WithT) ScaleSetSpec {
+				spec := newResolvedVMSSSpec(g, "VM_SIZE")
+				disk := ultraDataDisk
+				disk.DiskIOPSReadWrite = to.Int64Ptr(10000)
+				disk.DiskMBpsReadWrite = to.Int64Ptr(400)
+				spec.DataDisks = append(spec.DataDisks, disk)
+				return spec
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS("VM_SIZE")
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				dataDisks := *vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.StorageProfile.DataDisks
+				dataDisks[3].DiskIOPSReadWrite = to.Int64Ptr(10000)
+				dataDisks[3].DiskMBpsReadWrite = to.Int64Ptr(400)
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "fails without a VM image",
+			spec: func(g *WithT) ScaleSetSpec {
+				spec := newResolvedVMSSSpec(g, "VM_SIZE")
+				spec.VMImage = nil
+				return spec
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "vm image is nil",
+		},
+		{
+			name: "existing vmss with an updated image is patched with surged capacity",
+			spec: func(g *WithT) ScaleSetSpec {
+				spec := newResolvedVMSSSpec(g, "VM_SIZE")
+				spec.DataDisks = append(spec.DataDisks, ultraDataDisk)
+				spec.VMImage = &infrav1.Image{
+					Marketplace: &infrav1.AzureMarketplaceImage{
+						ImagePlan: infrav1.ImagePlan{
+							Publisher: "fake-publisher",
+							Offer:     "my-offer",
+							SKU:       "sku-id",
+						},
+						Version: "2.0",
+					},
+				}
+				return spec
+			},
+			existing: func() compute.VirtualMachineScaleSet {
+				vmss := newDefaultExistingVMSS("VM_SIZE")
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				return vmss
+			}(),
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS("VM_SIZE")
+				vmss.Sku.Capacity = to.Int64Ptr(3)
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.StorageProfile.ImageReference.Version = to.StringPtr("2.0")
+				patch, err := getVMSSUpdateFromVMSS(vmss)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(cmp.Diff(patch, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "existing vmss with no changes is not updated",
+			spec: func(g *WithT) ScaleSetSpec {
+				spec := newResolvedVMSSSpec(g, "VM_SIZE")
+				spec.DataDisks = append(spec.DataDisks, ultraDataDisk)
+				return spec
+			},
+			existing: func() compute.VirtualMachineScaleSet {
+				vmss := newDefaultExistingVMSS("VM_SIZE")
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				return vmss
+			}(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "existing is not a vmss",
+			spec: func(g *WithT) ScaleSetSpec {
+				return newResolvedVMSSSpec(g, "VM_SIZE")
+			},
+			existing: "not a vmss",
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "string is not a compute.VirtualMachineScaleSet",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			spec := tc.spec(g)
+			result, err := spec.Parameters(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
+
+// newResolvedVMSSSpec returns the default scale set spec for the given VM size, with the fields that are resolved
+// during reconciliation already filled in.
+func newResolvedVMSSSpec(g *WithT, vmSize string) ScaleSetSpec {
+	spec := newDefaultVMSSSpec()
+	spec.Size = vmSize
+	resolveVMSSSpec(g, &spec)
+	return spec
+}
+
+func resolveVMSSSpec(g *WithT, spec *ScaleSetSpec) {
+	sku, err := resourceskus.NewStaticCache(getFakeSkus(), "test-location").Get(context.TODO(), spec.Size, resourceskus.VirtualMachines)
+	g.Expect(err).NotTo(HaveOccurred())
+	spec.SKU = sku
+	spec.VMImage = defaultVMImage
+	spec.BootstrapData = "fake-bootstrap-data"
+	spec.MaxSurge = 1
+	spec.VMSSInstances = newDefaultInstances()
+}
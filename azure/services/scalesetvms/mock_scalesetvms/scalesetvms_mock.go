@@ -191,6 +191,20 @@ func (mr *MockScaleSetVMScopeMockRecorder) DeleteLongRunningOperationState(arg0,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLongRunningOperationState", reflect.TypeOf((*MockScaleSetVMScope)(nil).DeleteLongRunningOperationState), arg0, arg1, arg2)
 }
 
+// DriftPolicy mocks base method.
+func (m *MockScaleSetVMScope) DriftPolicy() v1beta1.DriftPolicy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DriftPolicy")
+	ret0, _ := ret[0].(v1beta1.DriftPolicy)
+	return ret0
+}
+
+// DriftPolicy indicates an expected call of DriftPolicy.
+func (mr *MockScaleSetVMScopeMockRecorder) DriftPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DriftPolicy", reflect.TypeOf((*MockScaleSetVMScope)(nil).DriftPolicy))
+}
+
 // FailureDomains mocks base method.
 func (m *MockScaleSetVMScope) FailureDomains() []string {
 	m.ctrl.T.Helper()
@@ -233,20 +247,6 @@ func (mr *MockScaleSetVMScopeMockRecorder) HashKey() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashKey", reflect.TypeOf((*MockScaleSetVMScope)(nil).HashKey))
 }
 
-// InstanceID mocks base method.
-func (m *MockScaleSetVMScope) InstanceID() string {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InstanceID")
-	ret0, _ := ret[0].(string)
-	return ret0
-}
-
-// InstanceID indicates an expected call of InstanceID.
-func (mr *MockScaleSetVMScopeMockRecorder) InstanceID() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstanceID", reflect.TypeOf((*MockScaleSetVMScope)(nil).InstanceID))
-}
-
 // Location mocks base method.
 func (m *MockScaleSetVMScope) Location() string {
 	m.ctrl.T.Helper()
@@ -275,18 +275,18 @@ func (mr *MockScaleSetVMScopeMockRecorder) ResourceGroup() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceGroup", reflect.TypeOf((*MockScaleSetVMScope)(nil).ResourceGroup))
 }
 
-// ScaleSetName mocks base method.
-func (m *MockScaleSetVMScope) ScaleSetName() string {
+// ScaleSetVMSpec mocks base method.
+func (m *MockScaleSetVMScope) ScaleSetVMSpec() azure.ResourceSpecGetter {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ScaleSetName")
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "ScaleSetVMSpec")
+	ret0, _ := ret[0].(azure.ResourceSpecGetter)
 	return ret0
 }
 
-// ScaleSetName indicates an expected call of ScaleSetName.
-func (mr *MockScaleSetVMScopeMockRecorder) ScaleSetName() *gomock.Call {
+// ScaleSetVMSpec indicates an expected call of ScaleSetVMSpec.
+func (mr *MockScaleSetVMScopeMockRecorder) ScaleSetVMSpec() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScaleSetName", reflect.TypeOf((*MockScaleSetVMScope)(nil).ScaleSetName))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScaleSetVMSpec", reflect.TypeOf((*MockScaleSetVMScope)(nil).ScaleSetVMSpec))
 }
 
 // SetLongRunningOperationState mocks base method.
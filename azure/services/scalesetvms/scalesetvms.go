@@ -20,10 +20,11 @@ import (
 	"context"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/pkg/errors"
-	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
@@ -34,8 +35,7 @@ type (
 	ScaleSetVMScope interface {
 		azure.ClusterDescriber
 		azure.AsyncStatusUpdater
-		InstanceID() string
-		ScaleSetName() string
+		ScaleSetVMSpec() azure.ResourceSpecGetter
 		SetVMSSVM(vmssvm *azure.VMSSVM)
 	}
 
@@ -43,14 +43,17 @@ type (
 	Service struct {
 		Client client
 		Scope  ScaleSetVMScope
+		async.Reconciler
 	}
 )
 
 // NewService creates a new service.
 func NewService(scope ScaleSetVMScope) *Service {
+	client := newClient(scope)
 	return &Service{
-		Client: newClient(scope),
-		Scope:  scope,
+		Client:     client,
+		Scope:      scope,
+		Reconciler: async.New(scope, nil, client),
 	}
 }
 
@@ -64,14 +67,10 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesetvms.Service.Reconcile")
 	defer done()
 
-	var (
-		resourceGroup = s.Scope.ResourceGroup()
-		vmssName      = s.Scope.ScaleSetName()
-		instanceID    = s.Scope.InstanceID()
-	)
+	spec := s.Scope.ScaleSetVMSpec()
 
 	// fetch the latest data about the instance -- model mutations are handled by the AzureMachinePoolReconciler
-	instance, err := s.Client.Get(ctx, resourceGroup, vmssName, instanceID)
+	result, err := s.Client.Get(ctx, spec)
 	if err != nil {
 		if azure.ResourceNotFound(err) {
 			return azure.WithTransientError(errors.New("instance does not exist yet"), 30*time.Second)
@@ -79,67 +78,43 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return errors.Wrap(err, "failed getting instance")
 	}
 
+	instance, ok := result.(compute.VirtualMachineScaleSetVM)
+	if !ok {
+		return errors.Errorf("%T is not a compute.VirtualMachineScaleSetVM", result)
+	}
 	s.Scope.SetVMSSVM(converters.SDKToVMSSVM(instance))
 	return nil
 }
 
-// Delete deletes a scaleset instance asynchronously returning a future which encapsulates the long-running operation.
+// Delete deletes a scaleset instance asynchronously, storing the future which encapsulates the long-running operation
+// in the scope status until it is done.
 func (s *Service) Delete(ctx context.Context) error {
-	var (
-		resourceGroup = s.Scope.ResourceGroup()
-		vmssName      = s.Scope.ScaleSetName()
-		instanceID    = s.Scope.InstanceID()
-	)
+	spec := s.Scope.ScaleSetVMSpec()
 
 	ctx, log, done := tele.StartSpanWithLogger(
 		ctx,
 		"scalesetvms.Service.Delete",
-		tele.KVP("resourceGroup", resourceGroup),
-		tele.KVP("scaleset", vmssName),
-		tele.KVP("instanceID", instanceID),
+		tele.KVP("resourceGroup", spec.ResourceGroupName()),
+		tele.KVP("scaleset", spec.OwnerResourceName()),
+		tele.KVP("instanceID", spec.ResourceName()),
 	)
 	defer done()
 
 	defer func() {
-		if instance, err := s.Client.Get(ctx, resourceGroup, vmssName, instanceID); err == nil && instance.VirtualMachineScaleSetVMProperties != nil {
-			log.V(4).Info("updating vmss vm state", "state", instance.ProvisioningState)
-			s.Scope.SetVMSSVM(converters.SDKToVMSSVM(instance))
+		// fetch instance to update status
+		if result, err := s.Client.Get(ctx, spec); err == nil {
+			if instance, ok := result.(compute.VirtualMachineScaleSetVM); ok && instance.VirtualMachineScaleSetVMProperties != nil {
+				log.V(4).Info("updating vmss vm state", "state", instance.ProvisioningState)
+				s.Scope.SetVMSSVM(converters.SDKToVMSSVM(instance))
+			}
 		}
 	}()
 
 	log.V(4).Info("entering delete")
-	future := s.Scope.GetLongRunningOperationState(instanceID, serviceName, infrav1.DeleteFuture)
-	if future != nil {
-		log.V(4).Info("checking if the instance is done deleting")
-		if _, err := s.Client.GetResultIfDone(ctx, future); err != nil {
-			// fetch instance to update status
-			return errors.Wrap(err, "failed to get result of long running operation")
-		}
-
-		// there was no error in fetching the result, the future has been completed
-		log.V(4).Info("successfully deleted the instance")
-		s.Scope.DeleteLongRunningOperationState(instanceID, serviceName, infrav1.DeleteFuture)
-		return nil
-	}
-
-	// since the future was nil, there is no ongoing activity; start deleting the instance
-	future, err := s.Client.DeleteAsync(ctx, resourceGroup, vmssName, instanceID)
-	if err != nil {
-		if azure.ResourceNotFound(err) {
-			// already deleted
-			return nil
-		}
-		return errors.Wrapf(err, "failed to delete instance %s/%s", vmssName, instanceID)
-	}
-
-	s.Scope.SetLongRunningOperationState(future)
-
-	log.V(4).Info("checking if the instance is done deleting")
-	if _, err := s.Client.GetResultIfDone(ctx, future); err != nil {
-		// fetch instance to update status
-		return errors.Wrap(err, "failed to get result of long running operation")
+	if err := s.DeleteResource(ctx, spec, serviceName); err != nil {
+		return errors.Wrapf(err, "failed to delete instance %s/%s", spec.OwnerResourceName(), spec.ResourceName())
 	}
 
-	s.Scope.DeleteLongRunningOperationState(instanceID, serviceName, infrav1.DeleteFuture)
+	log.V(4).Info("successfully deleted the instance")
 	return nil
 }
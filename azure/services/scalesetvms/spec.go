@@ -0,0 +1,44 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalesetvms
+
+// ScaleSetVMSpec defines the specification for a VMSS VM instance.
+type ScaleSetVMSpec struct {
+	InstanceID    string
+	ScaleSetName  string
+	ResourceGroup string
+}
+
+// ResourceName returns the instance ID of the VMSS VM, which is used as its name.
+func (s *ScaleSetVMSpec) ResourceName() string {
+	return s.InstanceID
+}
+
+// ResourceGroupName returns the name of the resource group the VMSS is in.
+func (s *ScaleSetVMSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName returns the name of the VMSS that owns the instance.
+func (s *ScaleSetVMSpec) OwnerResourceName() string {
+	return s.ScaleSetName
+}
+
+// Parameters is a no-op for VMSS VMs as instances are created and updated through the VMSS model.
+func (s *ScaleSetVMSpec) Parameters(existing interface{}) (params interface{}, err error) {
+	return nil, nil
+}
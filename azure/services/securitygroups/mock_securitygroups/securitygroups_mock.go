@@ -67,6 +67,20 @@ func (mr *MockNSGScopeMockRecorder) Authorizer() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorizer", reflect.TypeOf((*MockNSGScope)(nil).Authorizer))
 }
 
+// AuthorizerFor mocks base method.
+func (m *MockNSGScope) AuthorizerFor(subscriptionID string) azure.Authorizer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizerFor", subscriptionID)
+	ret0, _ := ret[0].(azure.Authorizer)
+	return ret0
+}
+
+// AuthorizerFor indicates an expected call of AuthorizerFor.
+func (mr *MockNSGScopeMockRecorder) AuthorizerFor(subscriptionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizerFor", reflect.TypeOf((*MockNSGScope)(nil).AuthorizerFor), subscriptionID)
+}
+
 // BaseURI mocks base method.
 func (m *MockNSGScope) BaseURI() string {
 	m.ctrl.T.Helper()
@@ -266,3 +280,17 @@ func (mr *MockNSGScopeMockRecorder) UpdatePutStatus(arg0, arg1, arg2 interface{}
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePutStatus", reflect.TypeOf((*MockNSGScope)(nil).UpdatePutStatus), arg0, arg1, arg2)
 }
+
+// VnetSubscriptionID mocks base method.
+func (m *MockNSGScope) VnetSubscriptionID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VnetSubscriptionID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// VnetSubscriptionID indicates an expected call of VnetSubscriptionID.
+func (mr *MockNSGScopeMockRecorder) VnetSubscriptionID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VnetSubscriptionID", reflect.TypeOf((*MockNSGScope)(nil).VnetSubscriptionID))
+}
@@ -21,12 +21,15 @@ import (
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups/mock_securitygroups"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
@@ -86,9 +89,9 @@ func TestReconcileSecurityGroups(t *testing.T) {
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&fakeNSG, &fakeNSG2})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG2, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.SecurityGroupsReadyCondition, serviceName, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG2, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.SecurityGroupsReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -97,9 +100,9 @@ func TestReconcileSecurityGroups(t *testing.T) {
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&fakeNSG, &fakeNSG2})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG, serviceName).Return(nil, errFake)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG2, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.SecurityGroupsReadyCondition, serviceName, errFake)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG, ServiceName).Return(nil, errFake)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG2, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.SecurityGroupsReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -108,9 +111,9 @@ func TestReconcileSecurityGroups(t *testing.T) {
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&fakeNSG, &fakeNSG2})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG, serviceName).Return(nil, errFake)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG2, serviceName).Return(nil, notDoneError)
-				s.UpdatePutStatus(infrav1.SecurityGroupsReadyCondition, serviceName, errFake)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG, ServiceName).Return(nil, errFake)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG2, ServiceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.SecurityGroupsReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -119,8 +122,8 @@ func TestReconcileSecurityGroups(t *testing.T) {
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&fakeNSG})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG, serviceName).Return(nil, notDoneError)
-				s.UpdatePutStatus(infrav1.SecurityGroupsReadyCondition, serviceName, notDoneError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG, ServiceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.SecurityGroupsReadyCondition, ServiceName, notDoneError)
 			},
 		},
 		{
@@ -172,9 +175,9 @@ func TestDeleteSecurityGroups(t *testing.T) {
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&fakeNSG, &fakeNSG2})
-				r.DeleteResource(gomockinternal.AContext(), &fakeNSG, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakeNSG2, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.SecurityGroupsReadyCondition, serviceName, nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeNSG, ServiceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeNSG2, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.SecurityGroupsReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -183,9 +186,9 @@ func TestDeleteSecurityGroups(t *testing.T) {
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&fakeNSG, &fakeNSG2})
-				r.DeleteResource(gomockinternal.AContext(), &fakeNSG, serviceName).Return(errFake)
-				r.DeleteResource(gomockinternal.AContext(), &fakeNSG2, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.SecurityGroupsReadyCondition, serviceName, errFake)
+				r.DeleteResource(gomockinternal.AContext(), &fakeNSG, ServiceName).Return(errFake)
+				r.DeleteResource(gomockinternal.AContext(), &fakeNSG2, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.SecurityGroupsReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -194,9 +197,9 @@ func TestDeleteSecurityGroups(t *testing.T) {
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&fakeNSG, &fakeNSG2})
-				r.DeleteResource(gomockinternal.AContext(), &fakeNSG, serviceName).Return(errFake)
-				r.DeleteResource(gomockinternal.AContext(), &fakeNSG2, serviceName).Return(notDoneError)
-				s.UpdateDeleteStatus(infrav1.SecurityGroupsReadyCondition, serviceName, errFake)
+				r.DeleteResource(gomockinternal.AContext(), &fakeNSG, ServiceName).Return(errFake)
+				r.DeleteResource(gomockinternal.AContext(), &fakeNSG2, ServiceName).Return(notDoneError)
+				s.UpdateDeleteStatus(infrav1.SecurityGroupsReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -205,8 +208,8 @@ func TestDeleteSecurityGroups(t *testing.T) {
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&fakeNSG})
-				r.DeleteResource(gomockinternal.AContext(), &fakeNSG, serviceName).Return(notDoneError)
-				s.UpdateDeleteStatus(infrav1.SecurityGroupsReadyCondition, serviceName, notDoneError)
+				r.DeleteResource(gomockinternal.AContext(), &fakeNSG, ServiceName).Return(notDoneError)
+				s.UpdateDeleteStatus(infrav1.SecurityGroupsReadyCondition, ServiceName, notDoneError)
 			},
 		},
 		{
@@ -246,44 +249,35 @@ func TestDeleteSecurityGroups(t *testing.T) {
 	}
 }
 
-var (
-	ruleA = network.SecurityRule{
-		Name: to.StringPtr("A"),
-		SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
-			Description:              to.StringPtr("this is rule A"),
-			Protocol:                 network.SecurityRuleProtocolTCP,
-			DestinationPortRange:     to.StringPtr("*"),
-			SourcePortRange:          to.StringPtr("*"),
-			DestinationAddressPrefix: to.StringPtr("*"),
-			SourceAddressPrefix:      to.StringPtr("*"),
-			Priority:                 to.Int32Ptr(100),
-			Direction:                network.SecurityRuleDirectionInbound,
-		},
-	}
-	ruleB = network.SecurityRule{
-		Name: to.StringPtr("B"),
-		SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
-			Description:              to.StringPtr("this is rule B"),
-			Protocol:                 network.SecurityRuleProtocolTCP,
-			DestinationPortRange:     to.StringPtr("*"),
-			SourcePortRange:          to.StringPtr("*"),
-			DestinationAddressPrefix: to.StringPtr("*"),
-			SourceAddressPrefix:      to.StringPtr("*"),
-			Priority:                 to.Int32Ptr(100),
-			Direction:                network.SecurityRuleDirectionOutbound,
-		},
-	}
-	ruleBModified = network.SecurityRule{
-		Name: to.StringPtr("B"),
-		SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
-			Description:              to.StringPtr("this is rule B"),
-			Protocol:                 network.SecurityRuleProtocolTCP,
-			DestinationPortRange:     to.StringPtr("80"),
-			SourcePortRange:          to.StringPtr("*"),
-			DestinationAddressPrefix: to.StringPtr("*"),
-			SourceAddressPrefix:      to.StringPtr("*"),
-			Priority:                 to.Int32Ptr(100),
-			Direction:                network.SecurityRuleDirectionOutbound,
-		},
-	}
-)
+var ruleA = network.SecurityRule{
+	Name: to.StringPtr("A"),
+	SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
+		Description:              to.StringPtr("this is rule A"),
+		Protocol:                 network.SecurityRuleProtocolTCP,
+		DestinationPortRange:     to.StringPtr("*"),
+		SourcePortRange:          to.StringPtr("*"),
+		DestinationAddressPrefix: to.StringPtr("*"),
+		SourceAddressPrefix:      to.StringPtr("*"),
+		Priority:                 to.Int32Ptr(100),
+		Direction:                network.SecurityRuleDirectionInbound,
+	},
+}
+
+func TestNewUsesVnetSubscription(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scopeMock := mock_securitygroups.NewMockNSGScope(mockCtrl)
+	authorizerMock := mock_azure.NewMockAuthorizer(mockCtrl)
+
+	// The security groups associated with the subnets of a vnet in another subscription are created in that subscription.
+	scopeMock.EXPECT().VnetSubscriptionID().Return("spoke-sub")
+	scopeMock.EXPECT().AuthorizerFor("spoke-sub").Return(authorizerMock)
+	authorizerMock.EXPECT().SubscriptionID().Return("spoke-sub")
+	authorizerMock.EXPECT().BaseURI().Return("https://management.azure.com/")
+	authorizerMock.EXPECT().Authorizer().Return(autorest.NullAuthorizer{})
+
+	s := New(scopeMock)
+	client := s.Reconciler.(*async.Service).Creator.(*azureClient)
+	g.Expect(client.securitygroups.SubscriptionID).To(Equal("spoke-sub"))
+}
@@ -17,13 +17,12 @@ limitations under the License.
 package securitygroups
 
 import (
-	"strings"
-
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/util/diff"
 )
 
 // NSGSpec defines the specification for a security group.
@@ -64,17 +63,13 @@ func (s *NSGSpec) Parameters(existing interface{}) (interface{}, error) {
 		// security group already exists
 		// We append the existing NSG etag to the header to ensure we only apply the updates if the NSG has not been modified.
 		etag = existingNSG.Etag
-		// Check if the expected rules are present
-		update := false
-		securityRules = *existingNSG.SecurityRules
-		for _, rule := range s.SecurityRules {
-			sdkRule := converters.SecurityRuleToSDK(rule)
-			if !ruleExists(securityRules, sdkRule) {
-				update = true
-				securityRules = append(securityRules, sdkRule)
-			}
+		var changes []string
+		var err error
+		securityRules, changes, err = s.mergeSecurityRules(existingNSG)
+		if err != nil {
+			return nil, err
 		}
-		if !update {
+		if len(changes) == 0 {
 			// Skip update for NSG as the required default rules are present
 			return nil, nil
 		}
@@ -100,26 +95,38 @@ func (s *NSGSpec) Parameters(existing interface{}) (interface{}, error) {
 	}, nil
 }
 
-// TODO: review this logic and make sure it is what we want. It seems incorrect to skip rules that don't have a certain protocol, etc.
-func ruleExists(rules []network.SecurityRule, rule network.SecurityRule) bool {
-	for _, existingRule := range rules {
-		if !strings.EqualFold(to.String(existingRule.Name), to.String(rule.Name)) {
-			continue
-		}
-		if !strings.EqualFold(to.String(existingRule.DestinationPortRange), to.String(rule.DestinationPortRange)) {
-			continue
-		}
-		if existingRule.Protocol != network.SecurityRuleProtocolTCP &&
-			existingRule.Access != network.SecurityRuleAccessAllow &&
-			existingRule.Direction != network.SecurityRuleDirectionInbound {
-			continue
-		}
-		if !strings.EqualFold(to.String(existingRule.SourcePortRange), "*") &&
-			!strings.EqualFold(to.String(existingRule.SourceAddressPrefix), "*") &&
-			!strings.EqualFold(to.String(existingRule.DestinationAddressPrefix), "*") {
-			continue
-		}
-		return true
+// Drift returns the security rules of the spec which were modified or deleted outside of CAPZ in the existing security
+// group. Security rules added outside of CAPZ are not owned by the spec and are ignored.
+func (s *NSGSpec) Drift(existing interface{}) ([]string, error) {
+	existingNSG, ok := existing.(network.SecurityGroup)
+	if !ok {
+		return nil, errors.Errorf("%T is not a network.SecurityGroup", existing)
+	}
+	_, changes, err := s.mergeSecurityRules(existingNSG)
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]string, 0, len(changes))
+	for _, change := range changes {
+		fields = append(fields, "securityRules."+change)
+	}
+	return fields, nil
+}
+
+// mergeSecurityRules merges the security rules of the spec with the ones of the existing security group, keeping the
+// rules added outside of CAPZ, and returns the changes made to the existing rules.
+func (s *NSGSpec) mergeSecurityRules(existingNSG network.SecurityGroup) ([]network.SecurityRule, []string, error) {
+	var existingRules []network.SecurityRule
+	if existingNSG.SecurityGroupPropertiesFormat != nil && existingNSG.SecurityRules != nil {
+		existingRules = *existingNSG.SecurityRules
+	}
+	wantedRules := make([]network.SecurityRule, 0, len(s.SecurityRules))
+	for _, rule := range s.SecurityRules {
+		wantedRules = append(wantedRules, converters.SecurityRuleToSDK(rule))
+	}
+	rules, changes, err := diff.MergeByName(existingRules, wantedRules, func(rule network.SecurityRule) string { return to.String(rule.Name) })
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to merge security rules")
 	}
-	return false
+	return rules, changes, nil
 }
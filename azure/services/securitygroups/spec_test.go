@@ -38,6 +38,17 @@ var (
 		Destination:      to.StringPtr("*"),
 		DestinationPorts: to.StringPtr("22"),
 	}
+	modifiedSSHRule = infrav1.SecurityRule{
+		Name:             "allow_ssh",
+		Description:      "Allow SSH",
+		Priority:         2200,
+		Protocol:         infrav1.SecurityGroupProtocolTCP,
+		Direction:        infrav1.SecurityRuleDirectionInbound,
+		Source:           to.StringPtr("10.0.0.0/8"),
+		SourcePorts:      to.StringPtr("*"),
+		Destination:      to.StringPtr("*"),
+		DestinationPorts: to.StringPtr("2222"),
+	}
 	otherRule = infrav1.SecurityRule{
 		Name:             "other_rule",
 		Description:      "Test Rule",
@@ -137,6 +148,73 @@ func TestParameters(t *testing.T) {
 				}))
 			},
 		},
+		{
+			name: "NSG already exists with a modified rule and a rule added outside of CAPZ",
+			spec: &NSGSpec{
+				Name:     "test-nsg",
+				Location: "test-location",
+				SecurityRules: infrav1.SecurityRules{
+					sshRule,
+					otherRule,
+				},
+				ResourceGroup: "test-group",
+				ClusterName:   "my-cluster",
+			},
+			existing: network.SecurityGroup{
+				Name:     to.StringPtr("test-nsg"),
+				Location: to.StringPtr("test-location"),
+				Etag:     to.StringPtr("fake-etag"),
+				SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+					SecurityRules: &[]network.SecurityRule{
+						ruleA,
+						converters.SecurityRuleToSDK(modifiedSSHRule),
+						converters.SecurityRuleToSDK(otherRule),
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.SecurityGroup{}))
+				g.Expect(result).To(Equal(network.SecurityGroup{
+					Location: to.StringPtr("test-location"),
+					Etag:     to.StringPtr("fake-etag"),
+					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+						SecurityRules: &[]network.SecurityRule{
+							ruleA,
+							converters.SecurityRuleToSDK(sshRule),
+							converters.SecurityRuleToSDK(otherRule),
+						},
+					},
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						"Name": to.StringPtr("test-nsg"),
+					},
+				}))
+			},
+		},
+		{
+			name: "NSG already exists with all rules present and a rule added outside of CAPZ",
+			spec: &NSGSpec{
+				Name:     "test-nsg",
+				Location: "test-location",
+				SecurityRules: infrav1.SecurityRules{
+					sshRule,
+				},
+				ResourceGroup: "test-group",
+				ClusterName:   "my-cluster",
+			},
+			existing: network.SecurityGroup{
+				Name: to.StringPtr("test-nsg"),
+				SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+					SecurityRules: &[]network.SecurityRule{
+						converters.SecurityRuleToSDK(sshRule),
+						ruleA,
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
 		{
 			name: "NSG does not exist",
 			spec: &NSGSpec{
@@ -187,30 +265,49 @@ func TestParameters(t *testing.T) {
 	}
 }
 
-func TestRuleExists(t *testing.T) {
+func TestDrift(t *testing.T) {
+	spec := &NSGSpec{
+		Name:     "test-nsg",
+		Location: "test-location",
+		SecurityRules: infrav1.SecurityRules{
+			sshRule,
+			otherRule,
+		},
+		ResourceGroup: "test-group",
+		ClusterName:   "my-cluster",
+	}
 	testcases := []struct {
 		name     string
-		rules    []network.SecurityRule
-		rule     network.SecurityRule
-		expected bool
+		existing interface{}
+		expected []string
 	}{
 		{
-			name:     "rule doesn't exitst",
-			rules:    []network.SecurityRule{ruleA},
-			rule:     ruleB,
-			expected: false,
-		},
-		{
-			name:     "rule exists",
-			rules:    []network.SecurityRule{ruleA, ruleB},
-			rule:     ruleB,
-			expected: true,
+			name: "no drift, rules added outside of CAPZ are ignored",
+			existing: network.SecurityGroup{
+				SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+					SecurityRules: &[]network.SecurityRule{
+						converters.SecurityRuleToSDK(sshRule),
+						converters.SecurityRuleToSDK(otherRule),
+						ruleA,
+					},
+				},
+			},
+			expected: []string{},
 		},
 		{
-			name:     "rule exists but has been modified",
-			rules:    []network.SecurityRule{ruleA, ruleB},
-			rule:     ruleBModified,
-			expected: false,
+			name: "modified and deleted rules",
+			existing: network.SecurityGroup{
+				SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+					SecurityRules: &[]network.SecurityRule{
+						converters.SecurityRuleToSDK(modifiedSSHRule),
+					},
+				},
+			},
+			expected: []string{
+				"securityRules.allow_ssh.properties.destinationPortRange",
+				"securityRules.allow_ssh.properties.sourceAddressPrefix",
+				"securityRules.other_rule",
+			},
 		},
 	}
 	for _, tc := range testcases {
@@ -218,8 +315,10 @@ func TestRuleExists(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			g := NewWithT(t)
 			t.Parallel()
-			result := ruleExists(tc.rules, tc.rule)
-			g.Expect(result).To(Equal(tc.expected))
+
+			drift, err := spec.Drift(tc.existing)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(drift).To(Equal(tc.expected))
 		})
 	}
 }
@@ -67,6 +67,20 @@ func (mr *MockSubnetScopeMockRecorder) Authorizer() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorizer", reflect.TypeOf((*MockSubnetScope)(nil).Authorizer))
 }
 
+// AuthorizerFor mocks base method.
+func (m *MockSubnetScope) AuthorizerFor(subscriptionID string) azure.Authorizer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizerFor", subscriptionID)
+	ret0, _ := ret[0].(azure.Authorizer)
+	return ret0
+}
+
+// AuthorizerFor indicates an expected call of AuthorizerFor.
+func (mr *MockSubnetScopeMockRecorder) AuthorizerFor(subscriptionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizerFor", reflect.TypeOf((*MockSubnetScope)(nil).AuthorizerFor), subscriptionID)
+}
+
 // BaseURI mocks base method.
 func (m *MockSubnetScope) BaseURI() string {
 	m.ctrl.T.Helper()
@@ -290,3 +304,17 @@ func (mr *MockSubnetScopeMockRecorder) UpdateSubnetID(arg0, arg1 interface{}) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubnetID", reflect.TypeOf((*MockSubnetScope)(nil).UpdateSubnetID), arg0, arg1)
 }
+
+// VnetSubscriptionID mocks base method.
+func (m *MockSubnetScope) VnetSubscriptionID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VnetSubscriptionID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// VnetSubscriptionID indicates an expected call of VnetSubscriptionID.
+func (mr *MockSubnetScopeMockRecorder) VnetSubscriptionID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VnetSubscriptionID", reflect.TypeOf((*MockSubnetScope)(nil).VnetSubscriptionID))
+}
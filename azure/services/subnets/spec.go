@@ -23,6 +23,7 @@ import (
 	"github.com/pkg/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/diff"
 )
 
 // SubnetSpec defines the specification for a Subnet.
@@ -80,9 +81,12 @@ func (s *SubnetSpec) Parameters(existing interface{}) (parameters interface{}, e
 			newServiceEndpoints = append(newServiceEndpoints, network.ServiceEndpointPropertiesFormat{Service: to.StringPtr(se.Service), Locations: to.StringSlicePtr(se.Locations)})
 		}
 
-		// Right now only serviceEndpoints are allowed to be updated. More to come later
-		diff := cmp.Diff(newServiceEndpoints, existingServiceEndpoints)
-		if diff == "" {
+		// Right now only serviceEndpoints and the associations of the subnet are allowed to be updated. More to come later
+		drift, err := s.Drift(existingSubnet)
+		if err != nil {
+			return nil, err
+		}
+		if cmp.Diff(newServiceEndpoints, existingServiceEndpoints) == "" && len(drift) == 0 {
 			// up to date, nothing to do
 			return nil, nil
 		}
@@ -103,31 +107,57 @@ func (s *SubnetSpec) Parameters(existing interface{}) (parameters interface{}, e
 		}
 	}
 
+	associations := s.associations()
+	subnetProperties.RouteTable = associations.RouteTable
+	subnetProperties.NatGateway = associations.NatGateway
+	subnetProperties.NetworkSecurityGroup = associations.NetworkSecurityGroup
+
+	serviceEndpoints := make([]network.ServiceEndpointPropertiesFormat, 0, len(s.ServiceEndpoints))
+	for _, se := range s.ServiceEndpoints {
+		serviceEndpoints = append(serviceEndpoints, network.ServiceEndpointPropertiesFormat{Service: to.StringPtr(se.Service), Locations: to.StringSlicePtr(se.Locations)})
+	}
+	subnetProperties.ServiceEndpoints = &serviceEndpoints
+
+	return network.Subnet{
+		SubnetPropertiesFormat: &subnetProperties,
+	}, nil
+}
+
+// Drift returns the associations of the subnet with a route table, a NAT gateway or a security group which were
+// modified outside of CAPZ in the existing subnet. The subnets of a VNet not managed by CAPZ are not owned by the spec.
+func (s *SubnetSpec) Drift(existing interface{}) ([]string, error) {
+	existingSubnet, ok := existing.(network.Subnet)
+	if !ok {
+		return nil, errors.Errorf("%T is not a network.Subnet", existing)
+	}
+	if !s.IsVNetManaged {
+		return nil, nil
+	}
+	return diff.ChangedFields(existingSubnet, network.Subnet{
+		SubnetPropertiesFormat: s.associations(),
+	})
+}
+
+// associations returns the properties of the subnet associating it with a route table, a NAT gateway or a security
+// group.
+func (s *SubnetSpec) associations() *network.SubnetPropertiesFormat {
+	associations := &network.SubnetPropertiesFormat{}
 	if s.RouteTableName != "" {
-		subnetProperties.RouteTable = &network.RouteTable{
+		associations.RouteTable = &network.RouteTable{
 			ID: to.StringPtr(azure.RouteTableID(s.SubscriptionID, s.ResourceGroup, s.RouteTableName)),
 		}
 	}
 
 	if s.NatGatewayName != "" {
-		subnetProperties.NatGateway = &network.SubResource{
+		associations.NatGateway = &network.SubResource{
 			ID: to.StringPtr(azure.NatGatewayID(s.SubscriptionID, s.ResourceGroup, s.NatGatewayName)),
 		}
 	}
 
 	if s.SecurityGroupName != "" {
-		subnetProperties.NetworkSecurityGroup = &network.SecurityGroup{
+		associations.NetworkSecurityGroup = &network.SecurityGroup{
 			ID: to.StringPtr(azure.SecurityGroupID(s.SubscriptionID, s.ResourceGroup, s.SecurityGroupName)),
 		}
 	}
-
-	serviceEndpoints := make([]network.ServiceEndpointPropertiesFormat, 0, len(s.ServiceEndpoints))
-	for _, se := range s.ServiceEndpoints {
-		serviceEndpoints = append(serviceEndpoints, network.ServiceEndpointPropertiesFormat{Service: to.StringPtr(se.Service), Locations: to.StringSlicePtr(se.Locations)})
-	}
-	subnetProperties.ServiceEndpoints = &serviceEndpoints
-
-	return network.Subnet{
-		SubnetPropertiesFormat: &subnetProperties,
-	}, nil
+	return associations
 }
@@ -185,3 +185,49 @@ func TestParameters(t *testing.T) {
 		})
 	}
 }
+
+func TestDrift(t *testing.T) {
+	testcases := []struct {
+		name     string
+		spec     *SubnetSpec
+		existing interface{}
+		expected []string
+	}{
+		{
+			name:     "no drift",
+			spec:     &fakeSubnetOneCidrSpec,
+			existing: fakeSubnetOneCidrParams,
+			expected: nil,
+		},
+		{
+			name: "security group and route table associations modified outside of CAPZ",
+			spec: &fakeSubnetOneCidrSpec,
+			existing: network.Subnet{
+				SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+					AddressPrefix:        to.StringPtr("10.0.0.0/16"),
+					RouteTable:           &network.RouteTable{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/routeTables/other-route-table")},
+					NetworkSecurityGroup: nil,
+					NatGateway:           &network.SubResource{ID: to.StringPtr("/subscriptions/123/resourceGroups/MY-RG/providers/Microsoft.Network/natGateways/my-nat-gateway")},
+				},
+			},
+			expected: []string{"properties.networkSecurityGroup.id", "properties.routeTable.id"},
+		},
+		{
+			name:     "subnets of a VNet not managed by CAPZ are not owned",
+			spec:     &fakeIpv6SubnetSpecNotManaged,
+			existing: network.Subnet{SubnetPropertiesFormat: &network.SubnetPropertiesFormat{}},
+			expected: nil,
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			drift, err := tc.spec.Drift(tc.existing)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(drift).To(Equal(tc.expected))
+		})
+	}
+}
@@ -26,16 +26,23 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/natgateways"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routetables"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-const serviceName = "subnets"
+// ServiceName is the name of this service.
+const ServiceName = "subnets"
 
 // SubnetScope defines the scope interface for a subnet service.
 type SubnetScope interface {
 	azure.Authorizer
+	azure.SubscriptionAuthorizer
 	azure.AsyncStatusUpdater
+	VnetSubscriptionID() string
 	UpdateSubnetID(string, string)
 	UpdateSubnetCIDRs(string, []string)
 	IsVnetManaged() bool
@@ -50,7 +57,7 @@ type Service struct {
 
 // New creates a new service.
 func New(scope SubnetScope) *Service {
-	Client := NewClient(scope)
+	Client := NewClient(scope.AuthorizerFor(scope.VnetSubscriptionID()))
 	return &Service{
 		Scope:      scope,
 		Reconciler: async.New(scope, Client, Client),
@@ -59,7 +66,17 @@ func New(scope SubnetScope) *Service {
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return ServiceName
+}
+
+// DependsOn returns the names of the services that must be reconciled before this one.
+func (s *Service) DependsOn() []string {
+	return []string{
+		virtualnetworks.ServiceName,
+		securitygroups.ServiceName,
+		routetables.ServiceName,
+		natgateways.ServiceName,
+	}
 }
 
 // Reconcile idempotently creates or updates a subnet.
@@ -80,7 +97,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
 	var resultErr error
 	for _, subnetSpec := range specs {
-		result, err := s.CreateOrUpdateResource(ctx, subnetSpec, serviceName)
+		result, err := s.CreateOrUpdateResource(ctx, subnetSpec, ServiceName)
 		if err != nil {
 			if !azure.IsOperationNotDoneError(err) || resultErr == nil {
 				resultErr = err
@@ -96,7 +113,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	}
 
 	if s.Scope.IsVnetManaged() {
-		s.Scope.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, resultErr)
+		s.Scope.UpdatePutStatus(infrav1.SubnetsReadyCondition, ServiceName, resultErr)
 	}
 
 	return resultErr
@@ -127,14 +144,14 @@ func (s *Service) Delete(ctx context.Context) error {
 	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
 	var result error
 	for _, subnetSpec := range specs {
-		if err := s.DeleteResource(ctx, subnetSpec, serviceName); err != nil {
+		if err := s.DeleteResource(ctx, subnetSpec, ServiceName); err != nil {
 			if !azure.IsOperationNotDoneError(err) || result == nil {
 				result = err
 			}
 		}
 	}
 
-	s.Scope.UpdateDeleteStatus(infrav1.SubnetsReadyCondition, serviceName, result)
+	s.Scope.UpdateDeleteStatus(infrav1.SubnetsReadyCondition, ServiceName, result)
 	return result
 }
 
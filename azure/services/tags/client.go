@@ -21,23 +21,17 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
 	"github.com/Azure/go-autorest/autorest"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/pkg/errors"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-// client wraps go-sdk.
-type client interface {
-	GetAtScope(context.Context, string) (resources.TagsResource, error)
-	UpdateAtScope(context.Context, string, resources.TagsPatchResource) (resources.TagsResource, error)
-}
-
 // azureClient contains the Azure go-sdk Client.
 type azureClient struct {
 	tags resources.TagsClient
 }
 
-var _ client = (*azureClient)(nil)
-
 // newClient creates a new tags client from subscription ID.
 func newClient(auth azure.Authorizer) *azureClient {
 	c := newTagsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
@@ -51,19 +45,41 @@ func newTagsClient(subscriptionID string, baseURI string, authorizer autorest.Au
 	return tagsClient
 }
 
-// GetAtScope sends the get at scope request.
-func (ac *azureClient) GetAtScope(ctx context.Context, scope string) (resources.TagsResource, error) {
-	ctx, _, done := tele.StartSpanWithLogger(ctx, "tags.AzureClient.GetAtScope")
+// Get gets the tags at the scope of the spec.
+func (ac *azureClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (result interface{}, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "tags.AzureClient.Get")
 	defer done()
 
-	return ac.tags.GetAtScope(ctx, scope)
+	return ac.tags.GetAtScope(ctx, spec.ResourceName())
 }
 
-// UpdateAtScope this operation allows replacing, merging or selectively deleting tags on the specified resource or
-// subscription.
-func (ac *azureClient) UpdateAtScope(ctx context.Context, scope string, parameters resources.TagsPatchResource) (resources.TagsResource, error) {
-	ctx, _, done := tele.StartSpanWithLogger(ctx, "tags.AzureClient.UpdateAtScope")
+// CreateOrUpdateAsync applies the tags patches at the scope of the spec. Tags patches are synchronous operations, so a
+// nil future is always returned along with the tags resulting from the last patch.
+func (ac *azureClient) CreateOrUpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, parameters interface{}) (result interface{}, future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "tags.AzureClient.CreateOrUpdateAsync")
 	defer done()
 
-	return ac.tags.UpdateAtScope(ctx, scope, parameters)
+	patches, ok := parameters.([]resources.TagsPatchResource)
+	if !ok {
+		return nil, nil, errors.Errorf("%T is not a []resources.TagsPatchResource", parameters)
+	}
+
+	var tags resources.TagsResource
+	for _, patch := range patches {
+		tags, err = ac.tags.UpdateAtScope(ctx, spec.ResourceName(), patch)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "cannot update tags")
+		}
+	}
+	return tags, nil, nil
+}
+
+// IsDone always returns true as tags are updated synchronously.
+func (ac *azureClient) IsDone(ctx context.Context, future azureautorest.FutureAPI) (isDone bool, err error) {
+	return true, nil
+}
+
+// Result always returns nil as tags are updated synchronously.
+func (ac *azureClient) Result(ctx context.Context, future azureautorest.FutureAPI, futureType string) (result interface{}, err error) {
+	return nil, nil
 }
@@ -0,0 +1,125 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tags
+
+import (
+	"sort"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+// TagsSpec defines the specification for a set of tags.
+type TagsSpec struct {
+	Scope       string
+	Tags        infrav1.Tags
+	ClusterName string
+	// Annotation is the key which stores the last applied tags as value in JSON format.
+	// The last applied tags are used to find out which tags are being managed by CAPZ
+	// and if any has to be deleted by comparing it with the new desired tags
+	Annotation string
+	// LastAppliedTags are the tags stored in the annotation during the last reconciliation.
+	LastAppliedTags map[string]interface{}
+}
+
+// ResourceName returns the scope the tags are applied to, which uniquely identifies the tags resource.
+func (s *TagsSpec) ResourceName() string {
+	return s.Scope
+}
+
+// ResourceGroupName is a no-op for tags as they are addressed by their scope.
+func (s *TagsSpec) ResourceGroupName() string {
+	return ""
+}
+
+// OwnerResourceName is a no-op for tags.
+func (s *TagsSpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns the patches needed to bring the existing tags in line with the desired tags, or nil if the
+// resource does not exist, is not managed by CAPZ or its tags are already up to date.
+func (s *TagsSpec) Parameters(existing interface{}) (params interface{}, err error) {
+	if existing == nil {
+		// Tags can only be applied to a resource that exists.
+		return nil, nil
+	}
+	existingTags, ok := existing.(resources.TagsResource)
+	if !ok {
+		return nil, errors.Errorf("%T is not a resources.TagsResource", existing)
+	}
+
+	tags := make(map[string]*string)
+	if existingTags.Properties != nil && existingTags.Properties.Tags != nil {
+		tags = existingTags.Properties.Tags
+	}
+	if !isResourceManaged(tags, s.ClusterName) {
+		return nil, nil
+	}
+
+	changed, createdOrUpdated, deleted, _ := tagsChanged(s.LastAppliedTags, s.Tags, tags)
+	if !changed {
+		return nil, nil
+	}
+
+	var patches []resources.TagsPatchResource
+	if len(createdOrUpdated) > 0 {
+		createdOrUpdatedTags := make(map[string]*string)
+		for k, v := range createdOrUpdated {
+			createdOrUpdatedTags[k] = to.StringPtr(v)
+		}
+		patches = append(patches, resources.TagsPatchResource{Operation: resources.TagsPatchOperationMerge, Properties: &resources.Tags{Tags: createdOrUpdatedTags}})
+	}
+
+	if len(deleted) > 0 {
+		deletedTags := make(map[string]*string)
+		for k, v := range deleted {
+			deletedTags[k] = to.StringPtr(v)
+		}
+		patches = append(patches, resources.TagsPatchResource{Operation: resources.TagsPatchOperationDelete, Properties: &resources.Tags{Tags: deletedTags}})
+	}
+
+	return patches, nil
+}
+
+// ChangedFields returns the paths of the tags the patches create, update or delete, sorted alphabetically.
+func (s *TagsSpec) ChangedFields(existing, parameters interface{}) ([]string, error) {
+	patches, ok := parameters.([]resources.TagsPatchResource)
+	if !ok {
+		return nil, errors.Errorf("%T is not a []resources.TagsPatchResource", parameters)
+	}
+
+	var fields []string
+	for _, patch := range patches {
+		if patch.Properties == nil {
+			continue
+		}
+		for k := range patch.Properties.Tags {
+			fields = append(fields, "properties.tags."+k)
+		}
+	}
+	sort.Strings(fields)
+	return fields, nil
+}
+
+// isResourceManaged returns true if the tags include the owned tag of the cluster.
+func isResourceManaged(tags map[string]*string, clusterName string) bool {
+	return converters.MapToTags(tags).HasOwned(clusterName)
+}
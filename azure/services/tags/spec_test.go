@@ -0,0 +1,224 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tags
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+)
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          TagsSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name: "resource does not exist",
+			spec: TagsSpec{
+				Scope:       "/sub/123/fake/scope",
+				ClusterName: "test-cluster",
+				Tags:        map[string]string{"foo": "bar"},
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "existing is not a tags resource",
+			spec: TagsSpec{
+				Scope:       "/sub/123/fake/scope",
+				ClusterName: "test-cluster",
+			},
+			existing: struct{}{},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "struct {} is not a resources.TagsResource",
+		},
+		{
+			name: "resource is not managed",
+			spec: TagsSpec{
+				Scope:       "/sub/123/fake/scope",
+				ClusterName: "test-cluster",
+				Tags:        map[string]string{"foo": "bar"},
+			},
+			existing: resources.TagsResource{},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "tags are created",
+			spec: TagsSpec{
+				Scope:       "/sub/123/fake/scope",
+				ClusterName: "test-cluster",
+				Tags: map[string]string{
+					"foo":   "bar",
+					"thing": "stuff",
+				},
+			},
+			existing: resources.TagsResource{Properties: &resources.Tags{
+				Tags: map[string]*string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": to.StringPtr("owned"),
+					"externalSystemTag": to.StringPtr("randomValue"),
+				},
+			}},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal([]resources.TagsPatchResource{
+					{
+						Operation: "Merge",
+						Properties: &resources.Tags{
+							Tags: map[string]*string{
+								"foo":   to.StringPtr("bar"),
+								"thing": to.StringPtr("stuff"),
+							},
+						},
+					},
+				}))
+			},
+		},
+		{
+			name: "removed tags are deleted",
+			spec: TagsSpec{
+				Scope:           "/sub/123/fake/scope",
+				ClusterName:     "test-cluster",
+				Tags:            map[string]string{"foo": "bar"},
+				LastAppliedTags: map[string]interface{}{"foo": "bar", "thing": "stuff"},
+			},
+			existing: resources.TagsResource{Properties: &resources.Tags{
+				Tags: map[string]*string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": to.StringPtr("owned"),
+					"foo":   to.StringPtr("bar"),
+					"thing": to.StringPtr("stuff"),
+				},
+			}},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal([]resources.TagsPatchResource{
+					{
+						Operation: "Delete",
+						Properties: &resources.Tags{
+							Tags: map[string]*string{
+								"thing": to.StringPtr("stuff"),
+							},
+						},
+					},
+				}))
+			},
+		},
+		{
+			name: "tags are updated and deleted",
+			spec: TagsSpec{
+				Scope:           "/sub/123/fake/scope",
+				ClusterName:     "test-cluster",
+				Tags:            map[string]string{"foo": "baz"},
+				LastAppliedTags: map[string]interface{}{"foo": "bar", "thing": "stuff"},
+			},
+			existing: resources.TagsResource{Properties: &resources.Tags{
+				Tags: map[string]*string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": to.StringPtr("owned"),
+					"foo":   to.StringPtr("bar"),
+					"thing": to.StringPtr("stuff"),
+				},
+			}},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal([]resources.TagsPatchResource{
+					{
+						Operation: "Merge",
+						Properties: &resources.Tags{
+							Tags: map[string]*string{
+								"foo": to.StringPtr("baz"),
+							},
+						},
+					},
+					{
+						Operation: "Delete",
+						Properties: &resources.Tags{
+							Tags: map[string]*string{
+								"thing": to.StringPtr("stuff"),
+							},
+						},
+					},
+				}))
+			},
+		},
+		{
+			name: "tags unchanged",
+			spec: TagsSpec{
+				Scope:           "/sub/123/fake/scope",
+				ClusterName:     "test-cluster",
+				Tags:            map[string]string{"key": "value"},
+				LastAppliedTags: map[string]interface{}{"key": "value"},
+			},
+			existing: resources.TagsResource{Properties: &resources.Tags{
+				Tags: map[string]*string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": to.StringPtr("owned"),
+					"key": to.StringPtr("value"),
+				},
+			}},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
+
+func TestChangedFields(t *testing.T) {
+	g := NewWithT(t)
+	spec := TagsSpec{
+		Scope:           "/sub/123/fake/scope",
+		ClusterName:     "test-cluster",
+		Tags:            map[string]string{"foo": "bar", "key": "new-value"},
+		LastAppliedTags: map[string]interface{}{"key": "value", "old": "value"},
+	}
+	existing := resources.TagsResource{Properties: &resources.Tags{Tags: map[string]*string{
+		"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": to.StringPtr("owned"),
+		"key": to.StringPtr("value"),
+		"old": to.StringPtr("value"),
+	}}}
+
+	params, err := spec.Parameters(existing)
+	g.Expect(err).NotTo(HaveOccurred())
+	fields, err := spec.ChangedFields(existing, params)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(fields).To(Equal([]string{"properties.tags.foo", "properties.tags.key", "properties.tags.old"}))
+
+	_, err = spec.ChangedFields(existing, existing)
+	g.Expect(err).To(HaveOccurred())
+}
@@ -18,43 +18,55 @@ package tags
 
 import (
 	"context"
+	"reflect"
 
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
-	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
-	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-const serviceName = "tags"
+// ServiceName is the name of this service.
+const ServiceName = "tags"
 
 // TagScope defines the scope interface for a tags service.
 type TagScope interface {
 	azure.Authorizer
+	azure.AsyncStatusUpdater
 	ClusterName() string
-	TagsSpecs() []azure.TagsSpec
+	TagsSpecs() []azure.ResourceSpecGetter
 	AnnotationJSON(string) (map[string]interface{}, error)
 	UpdateAnnotationJSON(string, map[string]interface{}) error
+	IsDryRun() bool
 }
 
 // Service provides operations on Azure resources.
 type Service struct {
 	Scope TagScope
-	client
+	async.Reconciler
 }
 
 // New creates a new service.
 func New(scope TagScope) *Service {
+	client := newClient(scope)
 	return &Service{
-		Scope:  scope,
-		client: newClient(scope),
+		Scope:      scope,
+		Reconciler: async.New(scope, client, nil),
 	}
 }
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return ServiceName
+}
+
+// DependsOn returns the names of the services that must be reconciled before this one.
+func (s *Service) DependsOn() []string {
+	return []string{
+		groups.ServiceName,
+	}
 }
 
 // Reconcile ensures tags are correct.
@@ -62,64 +74,48 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "tags.Service.Reconcile")
 	defer done()
 
-	for _, tagsSpec := range s.Scope.TagsSpecs() {
-		existingTags, err := s.client.GetAtScope(ctx, tagsSpec.Scope)
-		if err != nil {
-			return errors.Wrap(err, "failed to get existing tags")
-		}
-		tags := make(map[string]*string)
-		if existingTags.Properties != nil && existingTags.Properties.Tags != nil {
-			tags = existingTags.Properties.Tags
+	for _, spec := range s.Scope.TagsSpecs() {
+		tagsSpec, ok := spec.(*TagsSpec)
+		if !ok {
+			return errors.Errorf("%T is not a tags.TagsSpec", spec)
 		}
 
-		if !s.isResourceManaged(tags) {
-			log.V(4).Info("Skipping tags reconcile for not managed resource")
-			continue
+		lastAppliedTags, err := s.Scope.AnnotationJSON(tagsSpec.Annotation)
+		if err != nil {
+			return err
 		}
+		tagsSpec.LastAppliedTags = lastAppliedTags
 
-		lastAppliedTags, err := s.Scope.AnnotationJSON(tagsSpec.Annotation)
+		result, err := s.CreateOrUpdateResource(ctx, tagsSpec, ServiceName)
 		if err != nil {
 			return err
 		}
-		changed, createdOrUpdated, deleted, newAnnotation := tagsChanged(lastAppliedTags, tagsSpec.Tags, tags)
-		if changed {
-			log.V(2).Info("Updating tags")
-			if len(createdOrUpdated) > 0 {
-				createdOrUpdatedTags := make(map[string]*string)
-				for k, v := range createdOrUpdated {
-					createdOrUpdatedTags[k] = to.StringPtr(v)
-				}
-
-				if _, err := s.client.UpdateAtScope(ctx, tagsSpec.Scope, resources.TagsPatchResource{Operation: "Merge", Properties: &resources.Tags{Tags: createdOrUpdatedTags}}); err != nil {
-					return errors.Wrap(err, "cannot update tags")
-				}
-			}
 
-			if len(deleted) > 0 {
-				deletedTags := make(map[string]*string)
-				for k, v := range deleted {
-					deletedTags[k] = to.StringPtr(v)
-				}
+		tags, ok := result.(resources.TagsResource)
+		if !ok || tags.Properties == nil || !isResourceManaged(tags.Properties.Tags, tagsSpec.ClusterName) {
+			log.V(4).Info("Skipping tags annotation update for not managed resource", "scope", tagsSpec.Scope)
+			continue
+		}
 
-				if _, err := s.client.UpdateAtScope(ctx, tagsSpec.Scope, resources.TagsPatchResource{Operation: "Delete", Properties: &resources.Tags{Tags: deletedTags}}); err != nil {
-					return errors.Wrap(err, "cannot update tags")
-				}
-			}
+		// In dry-run mode the tags were not changed, so the last applied tags must stay as they are.
+		if s.Scope.IsDryRun() {
+			continue
+		}
 
-			// We also need to update the annotation if anything changed.
+		// We also need to update the annotation if anything changed.
+		newAnnotation := map[string]interface{}{}
+		for k, v := range tagsSpec.Tags {
+			newAnnotation[k] = v
+		}
+		if (len(lastAppliedTags) != 0 || len(newAnnotation) != 0) && !reflect.DeepEqual(lastAppliedTags, newAnnotation) {
 			if err := s.Scope.UpdateAnnotationJSON(tagsSpec.Annotation, newAnnotation); err != nil {
 				return err
 			}
-			log.V(2).Info("successfully updated tags")
 		}
 	}
 	return nil
 }
 
-func (s *Service) isResourceManaged(tags map[string]*string) bool {
-	return converters.MapToTags(tags).HasOwned(s.Scope.ClusterName())
-}
-
 // Delete is a no-op as the tags get deleted as part of VM deletion.
 func (s *Service) Delete(ctx context.Context) error {
 	_, _, done := tele.StartSpanWithLogger(ctx, "tags.Service.Delete")
@@ -27,197 +27,170 @@ import (
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags/mock_tags"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
 )
 
+var (
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error")
+
+	ownedTags = resources.TagsResource{Properties: &resources.Tags{
+		Tags: map[string]*string{
+			"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": to.StringPtr("owned"),
+			"key": to.StringPtr("value"),
+		},
+	}}
+)
+
 func TestReconcileTags(t *testing.T) {
 	testcases := []struct {
 		name          string
-		expect        func(s *mock_tags.MockTagScopeMockRecorder, m *mock_tags.MockclientMockRecorder)
+		specs         []TagsSpec
+		expect        func(s *mock_tags.MockTagScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, specs []azure.ResourceSpecGetter)
 		expectedError string
 	}{
 		{
-			name:          "create tags for managed resources",
+			name: "create tags for managed resources",
+			specs: []TagsSpec{
+				{
+					Scope:       "/sub/123/fake/scope",
+					ClusterName: "test-cluster",
+					Tags: map[string]string{
+						"foo":   "bar",
+						"thing": "stuff",
+					},
+					Annotation: "my-annotation",
+				},
+				{
+					Scope:       "/sub/123/other/scope",
+					ClusterName: "test-cluster",
+					Tags: map[string]string{
+						"tag1": "value1",
+					},
+					Annotation: "my-annotation-2",
+				},
+			},
 			expectedError: "",
-			expect: func(s *mock_tags.MockTagScopeMockRecorder, m *mock_tags.MockclientMockRecorder) {
-				s.ClusterName().AnyTimes().Return("test-cluster")
+			expect: func(s *mock_tags.MockTagScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, specs []azure.ResourceSpecGetter) {
 				gomock.InOrder(
-					s.TagsSpecs().Return([]azure.TagsSpec{
-						{
-							Scope: "/sub/123/fake/scope",
-							Tags: map[string]string{
-								"foo":   "bar",
-								"thing": "stuff",
-							},
-							Annotation: "my-annotation",
-						},
-						{
-							Scope: "/sub/123/other/scope",
-							Tags: map[string]string{
-								"tag1": "value1",
-							},
-							Annotation: "my-annotation-2",
-						},
-					}),
-					m.GetAtScope(gomockinternal.AContext(), "/sub/123/fake/scope").Return(resources.TagsResource{Properties: &resources.Tags{
-						Tags: map[string]*string{
-							"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": to.StringPtr("owned"),
-							"externalSystemTag": to.StringPtr("randomValue"),
-						},
-					}}, nil),
+					s.TagsSpecs().Return(specs),
 					s.AnnotationJSON("my-annotation"),
-					m.UpdateAtScope(gomockinternal.AContext(), "/sub/123/fake/scope", resources.TagsPatchResource{
-						Operation: "Merge",
-						Properties: &resources.Tags{
-							Tags: map[string]*string{
-								"foo":   to.StringPtr("bar"),
-								"thing": to.StringPtr("stuff"),
-							},
-						},
-					}),
+					r.CreateOrUpdateResource(gomockinternal.AContext(), specs[0], ServiceName).Return(ownedTags, nil),
+					s.IsDryRun().Return(false),
 					s.UpdateAnnotationJSON("my-annotation", map[string]interface{}{"foo": "bar", "thing": "stuff"}),
-					m.GetAtScope(gomockinternal.AContext(), "/sub/123/other/scope").Return(resources.TagsResource{Properties: &resources.Tags{
-						Tags: map[string]*string{
-							"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": to.StringPtr("owned"),
-							"externalSystem2Tag": to.StringPtr("randomValue2"),
-						},
-					}}, nil),
 					s.AnnotationJSON("my-annotation-2"),
-					m.UpdateAtScope(gomockinternal.AContext(), "/sub/123/other/scope", resources.TagsPatchResource{
-						Operation: "Merge",
-						Properties: &resources.Tags{
-							Tags: map[string]*string{
-								"tag1": to.StringPtr("value1"),
-							},
-						},
-					}),
+					r.CreateOrUpdateResource(gomockinternal.AContext(), specs[1], ServiceName).Return(ownedTags, nil),
+					s.IsDryRun().Return(false),
 					s.UpdateAnnotationJSON("my-annotation-2", map[string]interface{}{"tag1": "value1"}),
 				)
 			},
 		},
 		{
-			name:          "do not create tags for unmanaged resources",
-			expectedError: "",
-			expect: func(s *mock_tags.MockTagScopeMockRecorder, m *mock_tags.MockclientMockRecorder) {
-				s.ClusterName().AnyTimes().Return("test-cluster")
-				s.TagsSpecs().Return([]azure.TagsSpec{
-					{
-						Scope: "/sub/123/fake/scope",
-						Tags: map[string]string{
-							"foo":   "bar",
-							"thing": "stuff",
-						},
-						Annotation: "my-annotation",
+			name: "do not update annotation for unmanaged resources",
+			specs: []TagsSpec{
+				{
+					Scope:       "/sub/123/fake/scope",
+					ClusterName: "test-cluster",
+					Tags: map[string]string{
+						"foo": "bar",
 					},
-				})
-				m.GetAtScope(gomockinternal.AContext(), "/sub/123/fake/scope").Return(resources.TagsResource{}, nil)
+					Annotation: "my-annotation",
+				},
+			},
+			expectedError: "",
+			expect: func(s *mock_tags.MockTagScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, specs []azure.ResourceSpecGetter) {
+				gomock.InOrder(
+					s.TagsSpecs().Return(specs),
+					s.AnnotationJSON("my-annotation"),
+					r.CreateOrUpdateResource(gomockinternal.AContext(), specs[0], ServiceName).Return(resources.TagsResource{}, nil),
+				)
 			},
 		},
 		{
-			name:          "delete removed tags",
+			name: "do not update annotation for resources that do not exist",
+			specs: []TagsSpec{
+				{
+					Scope:       "/sub/123/fake/scope",
+					ClusterName: "test-cluster",
+					Tags: map[string]string{
+						"foo": "bar",
+					},
+					Annotation: "my-annotation",
+				},
+			},
 			expectedError: "",
-			expect: func(s *mock_tags.MockTagScopeMockRecorder, m *mock_tags.MockclientMockRecorder) {
-				s.ClusterName().AnyTimes().Return("test-cluster")
+			expect: func(s *mock_tags.MockTagScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, specs []azure.ResourceSpecGetter) {
 				gomock.InOrder(
-					s.TagsSpecs().Return([]azure.TagsSpec{
-						{
-							Scope: "/sub/123/fake/scope",
-							Tags: map[string]string{
-								"foo": "bar",
-							},
-							Annotation: "my-annotation",
-						},
-					}),
-					m.GetAtScope(gomockinternal.AContext(), "/sub/123/fake/scope").Return(resources.TagsResource{Properties: &resources.Tags{
-						Tags: map[string]*string{
-							"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": to.StringPtr("owned"),
-							"foo":   to.StringPtr("bar"),
-							"thing": to.StringPtr("stuff"),
-						},
-					}}, nil),
-					s.AnnotationJSON("my-annotation").Return(map[string]interface{}{"foo": "bar", "thing": "stuff"}, nil),
-					m.UpdateAtScope(gomockinternal.AContext(), "/sub/123/fake/scope", resources.TagsPatchResource{
-						Operation: "Delete",
-						Properties: &resources.Tags{
-							Tags: map[string]*string{
-								"thing": to.StringPtr("stuff"),
-							},
-						},
-					}),
-					s.UpdateAnnotationJSON("my-annotation", map[string]interface{}{"foo": "bar"}),
+					s.TagsSpecs().Return(specs),
+					s.AnnotationJSON("my-annotation"),
+					r.CreateOrUpdateResource(gomockinternal.AContext(), specs[0], ServiceName).Return(nil, nil),
 				)
 			},
 		},
 		{
-			name:          "error getting existing tags",
-			expectedError: "failed to get existing tags: #: Internal Server Error: StatusCode=500",
-			expect: func(s *mock_tags.MockTagScopeMockRecorder, m *mock_tags.MockclientMockRecorder) {
-				s.ClusterName().AnyTimes().Return("test-cluster")
-				s.TagsSpecs().Return([]azure.TagsSpec{
-					{
-						Scope: "/sub/123/fake/scope",
-						Tags: map[string]string{
-							"foo":   "bar",
-							"thing": "stuff",
-						},
-						Annotation: "my-annotation",
+			name: "tags unchanged",
+			specs: []TagsSpec{
+				{
+					Scope:       "/sub/123/fake/scope",
+					ClusterName: "test-cluster",
+					Tags: map[string]string{
+						"key": "value",
 					},
-				})
-				m.GetAtScope(gomockinternal.AContext(), "/sub/123/fake/scope").Return(resources.TagsResource{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+					Annotation: "my-annotation",
+				},
+			},
+			expectedError: "",
+			expect: func(s *mock_tags.MockTagScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, specs []azure.ResourceSpecGetter) {
+				gomock.InOrder(
+					s.TagsSpecs().Return(specs),
+					s.AnnotationJSON("my-annotation").Return(map[string]interface{}{"key": "value"}, nil),
+					r.CreateOrUpdateResource(gomockinternal.AContext(), specs[0], ServiceName).Return(ownedTags, nil),
+					s.IsDryRun().Return(false),
+				)
 			},
 		},
 		{
-			name:          "error updating tags",
-			expectedError: "cannot update tags: #: Internal Server Error: StatusCode=500",
-			expect: func(s *mock_tags.MockTagScopeMockRecorder, m *mock_tags.MockclientMockRecorder) {
-				s.ClusterName().AnyTimes().Return("test-cluster")
-				s.TagsSpecs().Return([]azure.TagsSpec{
-					{
-						Scope: "/sub/123/fake/scope",
-						Tags: map[string]string{
-							"key": "value",
-						},
-						Annotation: "my-annotation",
-					},
-				})
-				m.GetAtScope(gomockinternal.AContext(), "/sub/123/fake/scope").Return(resources.TagsResource{Properties: &resources.Tags{
-					Tags: map[string]*string{
-						"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": to.StringPtr("owned"),
-					},
-				}}, nil)
-				s.AnnotationJSON("my-annotation")
-				m.UpdateAtScope(gomockinternal.AContext(), "/sub/123/fake/scope", resources.TagsPatchResource{
-					Operation: "Merge",
-					Properties: &resources.Tags{
-						Tags: map[string]*string{
-							"key": to.StringPtr("value"),
-						},
+			name: "do not update annotation in dry-run mode",
+			specs: []TagsSpec{
+				{
+					Scope:       "/sub/123/fake/scope",
+					ClusterName: "test-cluster",
+					Tags: map[string]string{
+						"foo": "bar",
 					},
-				}).Return(resources.TagsResource{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+					Annotation: "my-annotation",
+				},
+			},
+			expectedError: "",
+			expect: func(s *mock_tags.MockTagScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, specs []azure.ResourceSpecGetter) {
+				gomock.InOrder(
+					s.TagsSpecs().Return(specs),
+					s.AnnotationJSON("my-annotation"),
+					r.CreateOrUpdateResource(gomockinternal.AContext(), specs[0], ServiceName).Return(ownedTags, nil),
+					s.IsDryRun().Return(true),
+				)
 			},
 		},
 		{
-			name:          "tags unchanged",
-			expectedError: "",
-			expect: func(s *mock_tags.MockTagScopeMockRecorder, m *mock_tags.MockclientMockRecorder) {
-				s.ClusterName().AnyTimes().Return("test-cluster")
-				s.TagsSpecs().Return([]azure.TagsSpec{
-					{
-						Scope: "/sub/123/fake/scope",
-						Tags: map[string]string{
-							"key": "value",
-						},
-						Annotation: "my-annotation",
+			name: "error updating tags",
+			specs: []TagsSpec{
+				{
+					Scope:       "/sub/123/fake/scope",
+					ClusterName: "test-cluster",
+					Tags: map[string]string{
+						"key": "value",
 					},
-				})
-				m.GetAtScope(gomockinternal.AContext(), "/sub/123/fake/scope").Return(resources.TagsResource{Properties: &resources.Tags{
-					Tags: map[string]*string{
-						"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": to.StringPtr("owned"),
-						"key": to.StringPtr("value"),
-					},
-				}}, nil)
-				s.AnnotationJSON("my-annotation").Return(map[string]interface{}{"key": "value"}, nil)
+					Annotation: "my-annotation",
+				},
+			},
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_tags.MockTagScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, specs []azure.ResourceSpecGetter) {
+				gomock.InOrder(
+					s.TagsSpecs().Return(specs),
+					s.AnnotationJSON("my-annotation"),
+					r.CreateOrUpdateResource(gomockinternal.AContext(), specs[0], ServiceName).Return(nil, internalError),
+				)
 			},
 		},
 	}
@@ -230,13 +203,17 @@ func TestReconcileTags(t *testing.T) {
 			mockCtrl := gomock.NewController(t)
 			defer mockCtrl.Finish()
 			scopeMock := mock_tags.NewMockTagScope(mockCtrl)
-			clientMock := mock_tags.NewMockclient(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
 
-			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+			specs := make([]azure.ResourceSpecGetter, len(tc.specs))
+			for i := range tc.specs {
+				specs[i] = &tc.specs[i]
+			}
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT(), specs)
 
 			s := &Service{
-				Scope:  scopeMock,
-				client: clientMock,
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
 			}
 
 			err := s.Reconcile(context.TODO())
@@ -137,10 +137,10 @@ func (s *Service) getSKUAndVersion(ctx context.Context, location, publisher, off
 	sku := fmt.Sprintf("%s-gen1", osAndVersion)
 
 	imageCache, err := GetCache(s.Authorizer)
-	imageCache.client = s.Client
 	if err != nil {
 		return "", "", errors.Wrap(err, "failed to get image cache")
 	}
+	imageCache.client = s.Client
 
 	listVMImagesResource, err := imageCache.Get(ctx, location, publisher, offer, sku)
 	if err != nil {
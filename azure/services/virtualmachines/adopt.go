@@ -0,0 +1,318 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package virtualmachines
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/networkinterfaces"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// Adopt brings the existing VM an AzureMachine adopts under the management of CAPZ, with its network interfaces,
+// disks and public IPs. Once the AzureMachine matches the VM, the resources of the VM are tagged as owned by the
+// cluster, and the AzureMachine tracks their names to manage them in place of the ones named after it.
+func (s *Service) Adopt(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.Service.Adopt")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	if !s.Scope.IsAdopting() {
+		return nil
+	}
+	if _, ok := s.Scope.AdoptedResources(); ok {
+		// The VM is already adopted.
+		return nil
+	}
+
+	vmSpec, ok := s.Scope.VMSpec().(*VMSpec)
+	if !ok {
+		return errors.Errorf("%T is not a *VMSpec", s.Scope.VMSpec())
+	}
+
+	err := s.adopt(ctx, vmSpec)
+	s.Scope.UpdateAdoptionStatus(err)
+	return err
+}
+
+func (s *Service) adopt(ctx context.Context, spec *VMSpec) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "virtualmachines.Service.adopt")
+	defer done()
+
+	// The VM is looked up, and its resources managed, in the resource group and subscription of the cluster.
+	if id := s.Scope.AdoptedVMID(); id != "" {
+		parsed, err := azureautorest.ParseResourceID(id)
+		if err != nil {
+			return azure.WithTerminalError(errors.Wrapf(err, "failed to parse the resource ID %s of the virtual machine to adopt", id))
+		}
+		if !strings.EqualFold(parsed.ResourceGroup, spec.ResourceGroup) || !strings.EqualFold(parsed.SubscriptionID, s.Scope.SubscriptionID()) {
+			return azure.WithTerminalError(errors.Errorf("virtual machine %s to adopt is in resource group %s of subscription %s instead of resource group %s of subscription %s",
+				spec.Name, parsed.ResourceGroup, parsed.SubscriptionID, spec.ResourceGroup, s.Scope.SubscriptionID()))
+		}
+	}
+
+	result, err := s.adopter.Get(ctx, spec)
+	if err != nil {
+		if azure.ResourceNotFound(err) {
+			return azure.WithTerminalError(errors.Errorf("virtual machine %s to adopt does not exist in resource group %s", spec.Name, spec.ResourceGroup))
+		}
+		return errors.Wrapf(err, "failed to get virtual machine %s", spec.Name)
+	}
+	existing, ok := result.(compute.VirtualMachine)
+	if !ok {
+		return errors.Errorf("%T is not a compute.VirtualMachine", result)
+	}
+
+	if owner := owningCluster(existing.Tags, spec.ClusterName); owner != "" {
+		return azure.WithTerminalError(errors.Errorf("virtual machine %s is owned by cluster %s", spec.Name, owner))
+	}
+
+	if mismatches := adoptionMismatches(spec, existing); len(mismatches) > 0 {
+		return azure.WithTerminalError(errors.Errorf("virtual machine %s does not match the AzureMachine: %s", spec.Name, strings.Join(mismatches, "; ")))
+	}
+
+	resources, resourceIDs, err := s.adoptedResources(ctx, spec, existing)
+	if err != nil {
+		return err
+	}
+
+	if dr, ok := s.Scope.(async.DryRunner); ok && dr.IsDryRun() {
+		log.V(2).Info("not adopting virtual machine in dry-run mode", "vm", spec.Name)
+		return nil
+	}
+
+	log.V(2).Info("adopting virtual machine", "vm", spec.Name)
+	owned := map[string]*string{
+		infrav1.ClusterTagKey(spec.ClusterName): to.StringPtr(string(infrav1.ResourceLifecycleOwned)),
+	}
+	// The VM is tagged last, once all of its resources are owned.
+	for _, id := range append(resourceIDs, to.String(existing.ID)) {
+		if err := s.adopter.MergeTags(ctx, id, owned); err != nil {
+			return errors.Wrapf(err, "failed to tag %s as owned", id)
+		}
+	}
+	s.Scope.SetAdoptedResources(resources)
+	log.V(2).Info("successfully adopted virtual machine", "vm", spec.Name)
+	return nil
+}
+
+// adoptedResources returns the names and the resource IDs of the network interfaces, public IPs and disks of an
+// existing VM, which must all be in the resource group of the VM spec.
+func (s *Service) adoptedResources(ctx context.Context, spec *VMSpec, existing compute.VirtualMachine) (azure.AdoptedVMResources, []string, error) {
+	var resources azure.AdoptedVMResources
+	var resourceIDs []string
+
+	nameInResourceGroup := func(kind, id string) (string, error) {
+		parsed, err := azureautorest.ParseResourceID(id)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to parse %s ID %s", kind, id)
+		}
+		if !strings.EqualFold(parsed.ResourceGroup, spec.ResourceGroup) {
+			return "", azure.WithTerminalError(errors.Errorf("%s %s of virtual machine %s is in resource group %s instead of %s",
+				kind, parsed.ResourceName, spec.Name, parsed.ResourceGroup, spec.ResourceGroup))
+		}
+		resourceIDs = append(resourceIDs, id)
+		return parsed.ResourceName, nil
+	}
+
+	var nicRefs []compute.NetworkInterfaceReference
+	if existing.NetworkProfile != nil && existing.NetworkProfile.NetworkInterfaces != nil {
+		nicRefs = append(nicRefs, *existing.NetworkProfile.NetworkInterfaces...)
+	}
+	// The primary network interface is the first one of the VM spec.
+	sort.SliceStable(nicRefs, func(i, j int) bool {
+		return isPrimary(nicRefs[i]) && !isPrimary(nicRefs[j])
+	})
+	for _, nicRef := range nicRefs {
+		nicName, err := nameInResourceGroup("network interface", to.String(nicRef.ID))
+		if err != nil {
+			return resources, nil, err
+		}
+		resources.NetworkInterfaces = append(resources.NetworkInterfaces, nicName)
+
+		result, err := s.interfacesGetter.Get(ctx, &networkinterfaces.NICSpec{
+			Name:          nicName,
+			ResourceGroup: spec.ResourceGroup,
+		})
+		if err != nil {
+			return resources, nil, errors.Wrapf(err, "failed to get network interface %s", nicName)
+		}
+		nic, ok := result.(network.Interface)
+		if !ok {
+			return resources, nil, errors.Errorf("%T is not a network.Interface", result)
+		}
+		if nic.InterfacePropertiesFormat == nil || nic.IPConfigurations == nil {
+			continue
+		}
+		for _, ipConfig := range *nic.IPConfigurations {
+			if ipConfig.InterfaceIPConfigurationPropertiesFormat == nil || ipConfig.PublicIPAddress == nil {
+				continue
+			}
+			publicIPName, err := nameInResourceGroup("public IP", to.String(ipConfig.PublicIPAddress.ID))
+			if err != nil {
+				return resources, nil, err
+			}
+			resources.PublicIPs = append(resources.PublicIPs, publicIPName)
+		}
+	}
+
+	if storageProfile := existing.StorageProfile; storageProfile != nil {
+		if storageProfile.OsDisk != nil && storageProfile.OsDisk.ManagedDisk != nil {
+			diskName, err := nameInResourceGroup("OS disk", to.String(storageProfile.OsDisk.ManagedDisk.ID))
+			if err != nil {
+				return resources, nil, err
+			}
+			resources.OSDisk = diskName
+		}
+		if storageProfile.DataDisks != nil {
+			for _, disk := range *storageProfile.DataDisks {
+				if disk.ManagedDisk == nil {
+					continue
+				}
+				diskName, err := nameInResourceGroup("data disk", to.String(disk.ManagedDisk.ID))
+				if err != nil {
+					return resources, nil, err
+				}
+				resources.DataDisks = append(resources.DataDisks, diskName)
+				if disk.Lun != nil {
+					if resources.DataDiskLUNs == nil {
+						resources.DataDiskLUNs = make(map[string]int32)
+					}
+					resources.DataDiskLUNs[diskName] = *disk.Lun
+				}
+			}
+		}
+	}
+
+	return resources, resourceIDs, nil
+}
+
+// adoptionMismatches returns the differences between a VM spec and an existing VM that prevent the VM from being
+// adopted, because the properties that differ cannot be updated.
+func adoptionMismatches(spec *VMSpec, existing compute.VirtualMachine) []string {
+	if existing.VirtualMachineProperties == nil {
+		return []string{"virtual machine has no properties"}
+	}
+
+	var mismatches []string
+	compare := func(property, desired, actual string) {
+		if desired != "" && !strings.EqualFold(desired, actual) {
+			mismatches = append(mismatches, fmt.Sprintf("%s is %q instead of %q", property, actual, desired))
+		}
+	}
+
+	compare("location", spec.Location, to.String(existing.Location))
+	if existing.HardwareProfile != nil {
+		compare("VM size", spec.Size, string(existing.HardwareProfile.VMSize))
+	}
+	zone := ""
+	if existing.Zones != nil && len(*existing.Zones) > 0 {
+		zone = (*existing.Zones)[0]
+	}
+	compare("availability zone", spec.Zone, zone)
+	availabilitySetID := ""
+	if existing.AvailabilitySet != nil {
+		availabilitySetID = to.String(existing.AvailabilitySet.ID)
+	}
+	compare("availability set", spec.AvailabilitySetID, availabilitySetID)
+
+	priority := string(compute.VirtualMachinePriorityTypesRegular)
+	if existing.Priority != "" {
+		priority = string(existing.Priority)
+	}
+	if spec.SpotVMOptions != nil {
+		compare("priority", string(compute.VirtualMachinePriorityTypesSpot), priority)
+	} else {
+		compare("priority", string(compute.VirtualMachinePriorityTypesRegular), priority)
+	}
+
+	identityType := string(compute.ResourceIdentityTypeNone)
+	if existing.Identity != nil && existing.Identity.Type != "" {
+		identityType = string(existing.Identity.Type)
+	}
+	switch spec.Identity {
+	case infrav1.VMIdentitySystemAssigned, infrav1.VMIdentityUserAssigned:
+		if !strings.Contains(identityType, string(spec.Identity)) {
+			mismatches = append(mismatches, fmt.Sprintf("identity type is %q instead of %q", identityType, spec.Identity))
+		}
+	}
+
+	storageProfile := existing.StorageProfile
+	if storageProfile == nil || storageProfile.OsDisk == nil {
+		return append(mismatches, "virtual machine has no OS disk")
+	}
+	compare("OS type", spec.OSDisk.OSType, string(storageProfile.OsDisk.OsType))
+	if storageProfile.OsDisk.ManagedDisk == nil {
+		mismatches = append(mismatches, "OS disk is not a managed disk")
+	}
+	if spec.OSDisk.DiskSizeGB != nil && to.Int32(spec.OSDisk.DiskSizeGB) != to.Int32(storageProfile.OsDisk.DiskSizeGB) {
+		mismatches = append(mismatches, fmt.Sprintf("OS disk size is %d GB instead of %d GB", to.Int32(storageProfile.OsDisk.DiskSizeGB), to.Int32(spec.OSDisk.DiskSizeGB)))
+	}
+
+	existingDataDisks := make(map[int32]compute.DataDisk)
+	if storageProfile.DataDisks != nil {
+		for _, disk := range *storageProfile.DataDisks {
+			if disk.ManagedDisk == nil {
+				mismatches = append(mismatches, fmt.Sprintf("data disk with LUN %d is not a managed disk", to.Int32(disk.Lun)))
+			}
+			existingDataDisks[to.Int32(disk.Lun)] = disk
+		}
+	}
+	for _, disk := range spec.DataDisks {
+		lun := to.Int32(disk.Lun)
+		existingDisk, ok := existingDataDisks[lun]
+		if !ok {
+			mismatches = append(mismatches, fmt.Sprintf("data disk with LUN %d does not exist", lun))
+			continue
+		}
+		if disk.DiskSizeGB != to.Int32(existingDisk.DiskSizeGB) {
+			mismatches = append(mismatches, fmt.Sprintf("data disk with LUN %d is %d GB instead of %d GB", lun, to.Int32(existingDisk.DiskSizeGB), disk.DiskSizeGB))
+		}
+	}
+
+	return mismatches
+}
+
+// owningCluster returns the name of the cluster other than clusterName which owns a resource with the given tags, if
+// any.
+func owningCluster(tags map[string]*string, clusterName string) string {
+	for key, value := range tags {
+		if strings.HasPrefix(key, infrav1.NameAzureProviderOwned) && key != infrav1.ClusterTagKey(clusterName) &&
+			to.String(value) == string(infrav1.ResourceLifecycleOwned) {
+			return strings.TrimPrefix(key, infrav1.NameAzureProviderOwned)
+		}
+	}
+	return ""
+}
+
+func isPrimary(nicRef compute.NetworkInterfaceReference) bool {
+	return nicRef.NetworkInterfaceReferenceProperties != nil && to.Bool(nicRef.Primary)
+}
@@ -0,0 +1,367 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package virtualmachines
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/networkinterfaces"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachines/mock_virtualmachines"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+const adoptResourceGroupID = "/subscriptions/123/resourceGroups/test-group/providers/"
+
+var (
+	adoptVMSpec = VMSpec{
+		Name:          "legacy-node-1",
+		ResourceGroup: "test-group",
+		Location:      "test-location",
+		ClusterName:   "test-cluster",
+		Size:          "Standard_D2s_v3",
+		OSDisk: infrav1.OSDisk{
+			OSType: "Linux",
+		},
+		DataDisks: []infrav1.DataDisk{
+			{
+				NameSuffix: "etcddisk",
+				DiskSizeGB: 64,
+				Lun:        to.Int32Ptr(0),
+			},
+		},
+	}
+	ownedTag = map[string]*string{
+		"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": to.StringPtr("owned"),
+	}
+)
+
+func getAdoptableVM() compute.VirtualMachine {
+	return compute.VirtualMachine{
+		ID:       to.StringPtr(adoptResourceGroupID + "Microsoft.Compute/virtualMachines/legacy-node-1"),
+		Name:     to.StringPtr("legacy-node-1"),
+		Location: to.StringPtr("test-location"),
+		Tags:     map[string]*string{"env": to.StringPtr("legacy")},
+		VirtualMachineProperties: &compute.VirtualMachineProperties{
+			HardwareProfile: &compute.HardwareProfile{
+				VMSize: "Standard_D2s_v3",
+			},
+			StorageProfile: &compute.StorageProfile{
+				OsDisk: &compute.OSDisk{
+					OsType:     compute.OperatingSystemTypesLinux,
+					DiskSizeGB: to.Int32Ptr(30),
+					ManagedDisk: &compute.ManagedDiskParameters{
+						ID: to.StringPtr(adoptResourceGroupID + "Microsoft.Compute/disks/legacy-node-1-os"),
+					},
+				},
+				DataDisks: &[]compute.DataDisk{
+					{
+						Lun:        to.Int32Ptr(0),
+						DiskSizeGB: to.Int32Ptr(64),
+						ManagedDisk: &compute.ManagedDiskParameters{
+							ID: to.StringPtr(adoptResourceGroupID + "Microsoft.Compute/disks/legacy-node-1-data"),
+						},
+					},
+				},
+			},
+			NetworkProfile: &compute.NetworkProfile{
+				NetworkInterfaces: &[]compute.NetworkInterfaceReference{
+					{
+						ID: to.StringPtr(adoptResourceGroupID + "Microsoft.Network/networkInterfaces/legacy-node-1-nic-2"),
+						NetworkInterfaceReferenceProperties: &compute.NetworkInterfaceReferenceProperties{
+							Primary: to.BoolPtr(false),
+						},
+					},
+					{
+						ID: to.StringPtr(adoptResourceGroupID + "Microsoft.Network/networkInterfaces/legacy-node-1-nic-1"),
+						NetworkInterfaceReferenceProperties: &compute.NetworkInterfaceReferenceProperties{
+							Primary: to.BoolPtr(true),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getAdoptableNIC(publicIPID string) network.Interface {
+	ipConfig := network.InterfaceIPConfiguration{
+		InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+			PrivateIPAddress: to.StringPtr("10.0.0.4"),
+		},
+	}
+	if publicIPID != "" {
+		ipConfig.PublicIPAddress = &network.PublicIPAddress{ID: to.StringPtr(publicIPID)}
+	}
+	return network.Interface{
+		InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+			IPConfigurations: &[]network.InterfaceIPConfiguration{ipConfig},
+		},
+	}
+}
+
+func TestAdopt(t *testing.T) {
+	notFound := autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not Found")
+
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_virtualmachines.MockVMScopeMockRecorder, a *mock_virtualmachines.MockAdopterMockRecorder, mnic *mock_async.MockGetterMockRecorder)
+	}{
+		{
+			name:          "noop if the AzureMachine does not adopt a VM",
+			expectedError: "",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, a *mock_virtualmachines.MockAdopterMockRecorder, mnic *mock_async.MockGetterMockRecorder) {
+				s.IsAdopting().Return(false)
+			},
+		},
+		{
+			name:          "noop if the VM is already adopted",
+			expectedError: "",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, a *mock_virtualmachines.MockAdopterMockRecorder, mnic *mock_async.MockGetterMockRecorder) {
+				s.IsAdopting().Return(true)
+				s.AdoptedResources().Return(azure.AdoptedVMResources{OSDisk: "legacy-node-1-os"}, true)
+			},
+		},
+		{
+			name:          "VM to adopt does not exist",
+			expectedError: "virtual machine legacy-node-1 to adopt does not exist in resource group test-group",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, a *mock_virtualmachines.MockAdopterMockRecorder, mnic *mock_async.MockGetterMockRecorder) {
+				s.IsAdopting().Return(true)
+				s.AdoptedResources().Return(azure.AdoptedVMResources{}, false)
+				s.VMSpec().Return(&adoptVMSpec)
+				s.AdoptedVMID().Return("")
+				a.Get(gomockinternal.AContext(), &adoptVMSpec).Return(nil, notFound)
+				s.UpdateAdoptionStatus(gomock.Any())
+			},
+		},
+		{
+			name:          "VM to adopt is in another resource group",
+			expectedError: "virtual machine legacy-node-1 to adopt is in resource group other-group of subscription 123 instead of resource group test-group of subscription 123",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, a *mock_virtualmachines.MockAdopterMockRecorder, mnic *mock_async.MockGetterMockRecorder) {
+				s.IsAdopting().Return(true)
+				s.AdoptedResources().Return(azure.AdoptedVMResources{}, false)
+				s.VMSpec().Return(&adoptVMSpec)
+				s.AdoptedVMID().Return("/subscriptions/123/resourceGroups/other-group/providers/Microsoft.Compute/virtualMachines/legacy-node-1")
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.UpdateAdoptionStatus(gomock.Any())
+			},
+		},
+		{
+			name:          "VM to adopt is in another subscription",
+			expectedError: "virtual machine legacy-node-1 to adopt is in resource group test-group of subscription 456 instead of resource group test-group of subscription 123",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, a *mock_virtualmachines.MockAdopterMockRecorder, mnic *mock_async.MockGetterMockRecorder) {
+				s.IsAdopting().Return(true)
+				s.AdoptedResources().Return(azure.AdoptedVMResources{}, false)
+				s.VMSpec().Return(&adoptVMSpec)
+				s.AdoptedVMID().Return("/subscriptions/456/resourceGroups/test-group/providers/Microsoft.Compute/virtualMachines/legacy-node-1")
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.UpdateAdoptionStatus(gomock.Any())
+			},
+		},
+		{
+			name:          "VM to adopt by resource ID is looked up in the resource group of the cluster",
+			expectedError: "virtual machine legacy-node-1 to adopt does not exist in resource group test-group",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, a *mock_virtualmachines.MockAdopterMockRecorder, mnic *mock_async.MockGetterMockRecorder) {
+				s.IsAdopting().Return(true)
+				s.AdoptedResources().Return(azure.AdoptedVMResources{}, false)
+				s.VMSpec().Return(&adoptVMSpec)
+				s.AdoptedVMID().Return("/subscriptions/123/resourceGroups/TEST-GROUP/providers/Microsoft.Compute/virtualMachines/legacy-node-1")
+				s.SubscriptionID().AnyTimes().Return("123")
+				a.Get(gomockinternal.AContext(), &adoptVMSpec).Return(nil, notFound)
+				s.UpdateAdoptionStatus(gomock.Any())
+			},
+		},
+		{
+			name:          "VM to adopt is owned by another cluster",
+			expectedError: "virtual machine legacy-node-1 is owned by cluster other-cluster",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, a *mock_virtualmachines.MockAdopterMockRecorder, mnic *mock_async.MockGetterMockRecorder) {
+				s.IsAdopting().Return(true)
+				s.AdoptedResources().Return(azure.AdoptedVMResources{}, false)
+				s.VMSpec().Return(&adoptVMSpec)
+				s.AdoptedVMID().Return("")
+				vm := getAdoptableVM()
+				vm.Tags["sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster"] = to.StringPtr("owned")
+				a.Get(gomockinternal.AContext(), &adoptVMSpec).Return(vm, nil)
+				s.UpdateAdoptionStatus(gomock.Any())
+			},
+		},
+		{
+			name:          "VM to adopt does not match the AzureMachine",
+			expectedError: `virtual machine legacy-node-1 does not match the AzureMachine: VM size is "Standard_B2s" instead of "Standard_D2s_v3"`,
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, a *mock_virtualmachines.MockAdopterMockRecorder, mnic *mock_async.MockGetterMockRecorder) {
+				s.IsAdopting().Return(true)
+				s.AdoptedResources().Return(azure.AdoptedVMResources{}, false)
+				s.VMSpec().Return(&adoptVMSpec)
+				s.AdoptedVMID().Return("")
+				vm := getAdoptableVM()
+				vm.HardwareProfile.VMSize = "Standard_B2s"
+				a.Get(gomockinternal.AContext(), &adoptVMSpec).Return(vm, nil)
+				s.UpdateAdoptionStatus(gomock.Any())
+			},
+		},
+		{
+			name:          "public IP of the VM to adopt is in another resource group",
+			expectedError: "public IP legacy-node-1-ip of virtual machine legacy-node-1 is in resource group other-group instead of test-group",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, a *mock_virtualmachines.MockAdopterMockRecorder, mnic *mock_async.MockGetterMockRecorder) {
+				s.IsAdopting().Return(true)
+				s.AdoptedResources().Return(azure.AdoptedVMResources{}, false)
+				s.VMSpec().Return(&adoptVMSpec)
+				s.AdoptedVMID().Return("")
+				a.Get(gomockinternal.AContext(), &adoptVMSpec).Return(getAdoptableVM(), nil)
+				mnic.Get(gomockinternal.AContext(), &networkinterfaces.NICSpec{Name: "legacy-node-1-nic-1", ResourceGroup: "test-group"}).
+					Return(getAdoptableNIC("/subscriptions/123/resourceGroups/other-group/providers/Microsoft.Network/publicIPAddresses/legacy-node-1-ip"), nil)
+				s.UpdateAdoptionStatus(gomock.Any())
+			},
+		},
+		{
+			name:          "VM is adopted with its network interfaces, public IP and disks",
+			expectedError: "",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, a *mock_virtualmachines.MockAdopterMockRecorder, mnic *mock_async.MockGetterMockRecorder) {
+				s.IsAdopting().Return(true)
+				s.AdoptedResources().Return(azure.AdoptedVMResources{}, false)
+				s.VMSpec().Return(&adoptVMSpec)
+				s.AdoptedVMID().Return("")
+				a.Get(gomockinternal.AContext(), &adoptVMSpec).Return(getAdoptableVM(), nil)
+				mnic.Get(gomockinternal.AContext(), &networkinterfaces.NICSpec{Name: "legacy-node-1-nic-1", ResourceGroup: "test-group"}).
+					Return(getAdoptableNIC(adoptResourceGroupID+"Microsoft.Network/publicIPAddresses/legacy-node-1-ip"), nil)
+				mnic.Get(gomockinternal.AContext(), &networkinterfaces.NICSpec{Name: "legacy-node-1-nic-2", ResourceGroup: "test-group"}).
+					Return(getAdoptableNIC(""), nil)
+				gomock.InOrder(
+					a.MergeTags(gomockinternal.AContext(), adoptResourceGroupID+"Microsoft.Network/networkInterfaces/legacy-node-1-nic-1", ownedTag),
+					a.MergeTags(gomockinternal.AContext(), adoptResourceGroupID+"Microsoft.Network/publicIPAddresses/legacy-node-1-ip", ownedTag),
+					a.MergeTags(gomockinternal.AContext(), adoptResourceGroupID+"Microsoft.Network/networkInterfaces/legacy-node-1-nic-2", ownedTag),
+					a.MergeTags(gomockinternal.AContext(), adoptResourceGroupID+"Microsoft.Compute/disks/legacy-node-1-os", ownedTag),
+					a.MergeTags(gomockinternal.AContext(), adoptResourceGroupID+"Microsoft.Compute/disks/legacy-node-1-data", ownedTag),
+					a.MergeTags(gomockinternal.AContext(), adoptResourceGroupID+"Microsoft.Compute/virtualMachines/legacy-node-1", ownedTag),
+				)
+				s.SetAdoptedResources(azure.AdoptedVMResources{
+					NetworkInterfaces: []string{"legacy-node-1-nic-1", "legacy-node-1-nic-2"},
+					PublicIPs:         []string{"legacy-node-1-ip"},
+					OSDisk:            "legacy-node-1-os",
+					DataDisks:         []string{"legacy-node-1-data"},
+					DataDiskLUNs:      map[string]int32{"legacy-node-1-data": 0},
+				})
+				s.UpdateAdoptionStatus(nil)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_virtualmachines.NewMockVMScope(mockCtrl)
+			adopterMock := mock_virtualmachines.NewMockAdopter(mockCtrl)
+			interfaceMock := mock_async.NewMockGetter(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), adopterMock.EXPECT(), interfaceMock.EXPECT())
+
+			s := &Service{
+				Scope:            scopeMock,
+				adopter:          adopterMock,
+				interfacesGetter: interfaceMock,
+			}
+
+			err := s.Adopt(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestAdoptionMismatches(t *testing.T) {
+	testcases := []struct {
+		name               string
+		spec               VMSpec
+		existing           func() compute.VirtualMachine
+		expectedMismatches []string
+	}{
+		{
+			name:               "VM matches the spec",
+			spec:               adoptVMSpec,
+			existing:           getAdoptableVM,
+			expectedMismatches: nil,
+		},
+		{
+			name: "VM does not match the spec",
+			spec: func() VMSpec {
+				spec := adoptVMSpec
+				spec.Zone = "2"
+				spec.SpotVMOptions = &infrav1.SpotVMOptions{}
+				spec.Identity = infrav1.VMIdentitySystemAssigned
+				spec.OSDisk.DiskSizeGB = to.Int32Ptr(128)
+				spec.DataDisks = append([]infrav1.DataDisk{{NameSuffix: "etcddisk", DiskSizeGB: 128, Lun: to.Int32Ptr(0)}}, infrav1.DataDisk{NameSuffix: "other", DiskSizeGB: 64, Lun: to.Int32Ptr(1)})
+				return spec
+			}(),
+			existing: func() compute.VirtualMachine {
+				vm := getAdoptableVM()
+				vm.Zones = &[]string{"1"}
+				return vm
+			},
+			expectedMismatches: []string{
+				`availability zone is "1" instead of "2"`,
+				`priority is "Regular" instead of "Spot"`,
+				`identity type is "None" instead of "SystemAssigned"`,
+				"OS disk size is 30 GB instead of 128 GB",
+				"data disk with LUN 0 is 64 GB instead of 128 GB",
+				"data disk with LUN 1 does not exist",
+			},
+		},
+		{
+			name: "VM has unmanaged disks",
+			spec: adoptVMSpec,
+			existing: func() compute.VirtualMachine {
+				vm := getAdoptableVM()
+				vm.StorageProfile.OsDisk.ManagedDisk = nil
+				(*vm.StorageProfile.DataDisks)[0].ManagedDisk = nil
+				return vm
+			},
+			expectedMismatches: []string{
+				"OS disk is not a managed disk",
+				"data disk with LUN 0 is not a managed disk",
+			},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			g.Expect(adoptionMismatches(&tc.spec, tc.existing())).To(Equal(tc.expectedMismatches))
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
 	"github.com/Azure/go-autorest/autorest"
 	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/to"
@@ -31,15 +32,24 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
+// Adopter is a helper interface for adopting existing VMs.
+type Adopter interface {
+	Get(context.Context, azure.ResourceSpecGetter) (interface{}, error)
+	MergeTags(ctx context.Context, resourceID string, tags map[string]*string) error
+}
+
 // AzureClient contains the Azure go-sdk Client.
 type AzureClient struct {
 	virtualmachines compute.VirtualMachinesClient
+	tags            resources.TagsClient
 }
 
 // NewClient creates a new VM client from subscription ID.
 func NewClient(auth azure.Authorizer) *AzureClient {
-	c := newVirtualMachinesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
-	return &AzureClient{c}
+	return &AzureClient{
+		virtualmachines: newVirtualMachinesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		tags:            newTagsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+	}
 }
 
 // newVirtualMachinesClient creates a new VM client from subscription ID.
@@ -49,6 +59,13 @@ func newVirtualMachinesClient(subscriptionID string, baseURI string, authorizer
 	return vmClient
 }
 
+// newTagsClient creates a new tags client from subscription ID.
+func newTagsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) resources.TagsClient {
+	tagsClient := resources.NewTagsClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&tagsClient.Client, authorizer)
+	return tagsClient
+}
+
 // Get retrieves information about the model view or the instance view of a virtual machine.
 func (ac *AzureClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (result interface{}, err error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.AzureClient.Get")
@@ -57,35 +74,65 @@ func (ac *AzureClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (
 	return ac.virtualmachines.Get(ctx, spec.ResourceGroupName(), spec.ResourceName(), "")
 }
 
+// MergeTags adds tags to a VM or to one of its resources, keeping their other tags.
+func (ac *AzureClient) MergeTags(ctx context.Context, resourceID string, tags map[string]*string) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.AzureClient.MergeTags")
+	defer done()
+
+	_, err := ac.tags.UpdateAtScope(ctx, resourceID, resources.TagsPatchResource{
+		Operation:  resources.TagsPatchOperationMerge,
+		Properties: &resources.Tags{Tags: tags},
+	})
+	return err
+}
+
 // CreateOrUpdateAsync creates or updates a virtual machine asynchronously.
-// It sends a PUT request to Azure and if accepted without error, the func will return a Future which can be used to track the ongoing
+// It sends a PUT request to Azure, or a PATCH request when attaching data disks to an existing VM, and if accepted without error, the func will return a Future which can be used to track the ongoing
 // progress of the operation.
 func (ac *AzureClient) CreateOrUpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, parameters interface{}) (result interface{}, future azureautorest.FutureAPI, err error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.AzureClient.CreateOrUpdate")
 	defer done()
 
-	vm, ok := parameters.(compute.VirtualMachine)
-	if !ok {
-		return nil, nil, errors.Errorf("%T is not a compute.VirtualMachine", parameters)
-	}
+	switch vm := parameters.(type) {
+	case compute.VirtualMachine:
+		createFuture, err := ac.virtualmachines.CreateOrUpdate(ctx, spec.ResourceGroupName(), spec.ResourceName(), vm)
+		if err != nil {
+			return nil, nil, err
+		}
 
-	createFuture, err := ac.virtualmachines.CreateOrUpdate(ctx, spec.ResourceGroupName(), spec.ResourceName(), vm)
-	if err != nil {
-		return nil, nil, err
-	}
+		ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
+		defer cancel()
 
-	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
-	defer cancel()
+		err = createFuture.WaitForCompletionRef(ctx, ac.virtualmachines.Client)
+		if err != nil {
+			// if an error occurs, return the future.
+			// this means the long-running operation didn't finish in the specified timeout.
+			return nil, &createFuture, err
+		}
+		result, err = createFuture.Result(ac.virtualmachines)
+		// if the operation completed, return a nil future
+		return result, nil, err
+	case compute.VirtualMachineUpdate:
+		updateFuture, err := ac.virtualmachines.Update(ctx, spec.ResourceGroupName(), spec.ResourceName(), vm)
+		if err != nil {
+			return nil, nil, err
+		}
 
-	err = createFuture.WaitForCompletionRef(ctx, ac.virtualmachines.Client)
-	if err != nil {
-		// if an error occurs, return the future.
-		// this means the long-running operation didn't finish in the specified timeout.
-		return nil, &createFuture, err
+		ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
+		defer cancel()
+
+		err = updateFuture.WaitForCompletionRef(ctx, ac.virtualmachines.Client)
+		if err != nil {
+			// if an error occurs, return the future.
+			// this means the long-running operation didn't finish in the specified timeout.
+			return nil, &updateFuture, err
+		}
+		result, err = updateFuture.Result(ac.virtualmachines)
+		// if the operation completed, return a nil future
+		return result, nil, err
+	default:
+		return nil, nil, errors.Errorf("%T is not a compute.VirtualMachine or compute.VirtualMachineUpdate", parameters)
 	}
-	result, err = createFuture.Result(ac.virtualmachines)
-	// if the operation completed, return a nil future
-	return result, nil, err
 }
 
 // DeleteAsync deletes a virtual machine asynchronously. DeleteAsync sends a DELETE
@@ -151,6 +198,7 @@ func (ac *AzureClient) Result(ctx context.Context, future azureautorest.FutureAP
 		// Marshal and Unmarshal the future to put it into the correct future type so we can access the Result function.
 		// Unfortunately the FutureAPI can't be casted directly to VirtualMachinesCreateOrUpdateFuture because it is a azureautorest.Future, which doesn't implement the Result function. See PR #1686 for discussion on alternatives.
 		// It was converted back to a generic azureautorest.Future from the CAPZ infrav1.Future type stored in Status: https://github.com/kubernetes-sigs/cluster-api-provider-azure/blob/main/azure/converters/futures.go#L49.
+		// Both PUT and PATCH operations are stored as PUT futures, and they both result in a VM.
 		var createFuture *compute.VirtualMachinesCreateOrUpdateFuture
 		jsonData, err := future.MarshalJSON()
 		if err != nil {
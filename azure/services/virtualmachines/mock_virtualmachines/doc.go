@@ -16,7 +16,7 @@ limitations under the License.
 
 // Run go generate to regenerate this mock.
 //
-//go:generate ../../../../hack/tools/bin/mockgen -destination client_mock.go -package mock_virtualmachines -source ../client.go Client
+//go:generate ../../../../hack/tools/bin/mockgen -destination client_mock.go -package mock_virtualmachines -source ../client.go Adopter
 //go:generate ../../../../hack/tools/bin/mockgen -destination virtualmachines_mock.go -package mock_virtualmachines -source ../virtualmachines.go VMScope
 //go:generate /usr/bin/env bash -c "cat ../../../../hack/boilerplate/boilerplate.generatego.txt client_mock.go > _client_mock.go && mv _client_mock.go client_mock.go"
 //go:generate /usr/bin/env bash -c "cat ../../../../hack/boilerplate/boilerplate.generatego.txt virtualmachines_mock.go > _virtualmachines_mock.go && mv _virtualmachines_mock.go virtualmachines_mock.go"
@@ -54,6 +54,35 @@ func (m *MockVMScope) EXPECT() *MockVMScopeMockRecorder {
 	return m.recorder
 }
 
+// AdoptedResources mocks base method.
+func (m *MockVMScope) AdoptedResources() (azure.AdoptedVMResources, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdoptedResources")
+	ret0, _ := ret[0].(azure.AdoptedVMResources)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// AdoptedResources indicates an expected call of AdoptedResources.
+func (mr *MockVMScopeMockRecorder) AdoptedResources() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdoptedResources", reflect.TypeOf((*MockVMScope)(nil).AdoptedResources))
+}
+
+// AdoptedVMID mocks base method.
+func (m *MockVMScope) AdoptedVMID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdoptedVMID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// AdoptedVMID indicates an expected call of AdoptedVMID.
+func (mr *MockVMScopeMockRecorder) AdoptedVMID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdoptedVMID", reflect.TypeOf((*MockVMScope)(nil).AdoptedVMID))
+}
+
 // Authorizer mocks base method.
 func (m *MockVMScope) Authorizer() autorest.Authorizer {
 	m.ctrl.T.Helper()
@@ -164,6 +193,20 @@ func (mr *MockVMScopeMockRecorder) HashKey() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashKey", reflect.TypeOf((*MockVMScope)(nil).HashKey))
 }
 
+// IsAdopting mocks base method.
+func (m *MockVMScope) IsAdopting() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAdopting")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsAdopting indicates an expected call of IsAdopting.
+func (mr *MockVMScopeMockRecorder) IsAdopting() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAdopting", reflect.TypeOf((*MockVMScope)(nil).IsAdopting))
+}
+
 // SetAddresses mocks base method.
 func (m *MockVMScope) SetAddresses(arg0 []v1.NodeAddress) {
 	m.ctrl.T.Helper()
@@ -176,6 +219,18 @@ func (mr *MockVMScopeMockRecorder) SetAddresses(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAddresses", reflect.TypeOf((*MockVMScope)(nil).SetAddresses), arg0)
 }
 
+// SetAdoptedResources mocks base method.
+func (m *MockVMScope) SetAdoptedResources(arg0 azure.AdoptedVMResources) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAdoptedResources", arg0)
+}
+
+// SetAdoptedResources indicates an expected call of SetAdoptedResources.
+func (mr *MockVMScopeMockRecorder) SetAdoptedResources(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAdoptedResources", reflect.TypeOf((*MockVMScope)(nil).SetAdoptedResources), arg0)
+}
+
 // SetAnnotation mocks base method.
 func (m *MockVMScope) SetAnnotation(arg0, arg1 string) {
 	m.ctrl.T.Helper()
@@ -252,6 +307,18 @@ func (mr *MockVMScopeMockRecorder) TenantID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockVMScope)(nil).TenantID))
 }
 
+// UpdateAdoptionStatus mocks base method.
+func (m *MockVMScope) UpdateAdoptionStatus(arg0 error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateAdoptionStatus", arg0)
+}
+
+// UpdateAdoptionStatus indicates an expected call of UpdateAdoptionStatus.
+func (mr *MockVMScopeMockRecorder) UpdateAdoptionStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAdoptionStatus", reflect.TypeOf((*MockVMScope)(nil).UpdateAdoptionStatus), arg0)
+}
+
 // UpdateDeleteStatus mocks base method.
 func (m *MockVMScope) UpdateDeleteStatus(arg0 v1beta10.ConditionType, arg1 string, arg2 error) {
 	m.ctrl.T.Helper()
@@ -34,6 +34,7 @@ import (
 type VMSpec struct {
 	Name                   string
 	ResourceGroup          string
+	SubscriptionID         string
 	Location               string
 	ClusterName            string
 	Role                   string
@@ -74,11 +75,12 @@ func (s *VMSpec) OwnerResourceName() string {
 // Parameters returns the parameters for the virtual machine.
 func (s *VMSpec) Parameters(existing interface{}) (params interface{}, err error) {
 	if existing != nil {
-		if _, ok := existing.(compute.VirtualMachine); !ok {
+		existingVM, ok := existing.(compute.VirtualMachine)
+		if !ok {
 			return nil, errors.Errorf("%T is not a compute.VirtualMachine", existing)
 		}
-		// vm already exists
-		return nil, nil
+		// vm already exists, attach the data disks which were added after its creation.
+		return s.dataDisksUpdate(existingVM)
 	}
 
 	// VM got deleted outside of capz, do not recreate it as Machines are immutable.
@@ -186,6 +188,13 @@ func (s *VMSpec) generateStorageProfile() (*compute.StorageProfile, error) {
 		storageProfile.OsDisk.DiffDiskSettings = &compute.DiffDiskSettings{
 			Option: compute.DiffDiskOptions(s.OSDisk.DiffDiskSettings.Option),
 		}
+
+		if s.OSDisk.DiffDiskSettings.Placement != "" {
+			if err := s.SKU.ValidateDiffDiskPlacement(s.OSDisk); err != nil {
+				return nil, err
+			}
+			storageProfile.OsDisk.DiffDiskSettings.Placement = compute.DiffDiskPlacement(s.OSDisk.DiffDiskSettings.Placement)
+		}
 	}
 
 	if s.OSDisk.ManagedDisk != nil {
@@ -196,32 +205,18 @@ func (s *VMSpec) generateStorageProfile() (*compute.StorageProfile, error) {
 		if s.OSDisk.ManagedDisk.DiskEncryptionSet != nil {
 			storageProfile.OsDisk.ManagedDisk.DiskEncryptionSet = &compute.DiskEncryptionSetParameters{ID: to.StringPtr(s.OSDisk.ManagedDisk.DiskEncryptionSet.ID)}
 		}
+		if s.OSDisk.ManagedDisk.SecurityProfile != nil {
+			storageProfile.OsDisk.ManagedDisk.SecurityProfile = generateDiskSecurityProfile(s.OSDisk.ManagedDisk.SecurityProfile)
+		}
 	}
 
 	dataDisks := make([]compute.DataDisk, len(s.DataDisks))
 	for i, disk := range s.DataDisks {
-		dataDisks[i] = compute.DataDisk{
-			CreateOption: compute.DiskCreateOptionTypesEmpty,
-			DiskSizeGB:   to.Int32Ptr(disk.DiskSizeGB),
-			Lun:          disk.Lun,
-			Name:         to.StringPtr(azure.GenerateDataDiskName(s.Name, disk.NameSuffix)),
-			Caching:      compute.CachingTypes(disk.CachingType),
-		}
-
-		if disk.ManagedDisk != nil {
-			dataDisks[i].ManagedDisk = &compute.ManagedDiskParameters{
-				StorageAccountType: compute.StorageAccountTypes(disk.ManagedDisk.StorageAccountType),
-			}
-
-			if disk.ManagedDisk.DiskEncryptionSet != nil {
-				dataDisks[i].ManagedDisk.DiskEncryptionSet = &compute.DiskEncryptionSetParameters{ID: to.StringPtr(disk.ManagedDisk.DiskEncryptionSet.ID)}
-			}
-
-			// check the support for ultra disks based on location and vm size
-			if disk.ManagedDisk.StorageAccountType == string(compute.StorageAccountTypesUltraSSDLRS) && !s.SKU.HasLocationCapability(resourceskus.UltraSSDAvailable, s.Location, s.Zone) {
-				return nil, azure.WithTerminalError(fmt.Errorf("vm size %s does not support ultra disks in location %s. select a different vm size or disable ultra disks", s.Size, s.Location))
-			}
+		dataDisk, err := s.generateDataDisk(disk)
+		if err != nil {
+			return nil, err
 		}
+		dataDisks[i] = dataDisk
 	}
 	storageProfile.DataDisks = &dataDisks
 
@@ -235,6 +230,105 @@ func (s *VMSpec) generateStorageProfile() (*compute.StorageProfile, error) {
 	return storageProfile, nil
 }
 
+// dataDisksUpdate returns the update which attaches the data disks of the spec missing from an existing VM,
+// or nil if all of them are attached. Data disks are matched by name or logical unit number.
+func (s *VMSpec) dataDisksUpdate(existing compute.VirtualMachine) (interface{}, error) {
+	var attached []compute.DataDisk
+	if existing.VirtualMachineProperties != nil && existing.StorageProfile != nil && existing.StorageProfile.DataDisks != nil {
+		attached = *existing.StorageProfile.DataDisks
+	}
+
+	names := make(map[string]struct{}, len(attached))
+	luns := make(map[int32]struct{}, len(attached))
+	for _, disk := range attached {
+		if disk.Name != nil {
+			names[*disk.Name] = struct{}{}
+		}
+		if disk.Lun != nil {
+			luns[*disk.Lun] = struct{}{}
+		}
+	}
+
+	dataDisks := append([]compute.DataDisk{}, attached...)
+	for _, disk := range s.DataDisks {
+		if _, ok := names[azure.GenerateDataDiskName(s.Name, disk.NameSuffix)]; ok {
+			continue
+		}
+		if disk.Lun != nil {
+			if _, ok := luns[*disk.Lun]; ok {
+				continue
+			}
+		}
+		dataDisk, err := s.generateDataDisk(disk)
+		if err != nil {
+			return nil, err
+		}
+		dataDisks = append(dataDisks, dataDisk)
+	}
+
+	if len(dataDisks) == len(attached) {
+		return nil, nil
+	}
+
+	return compute.VirtualMachineUpdate{
+		VirtualMachineProperties: &compute.VirtualMachineProperties{
+			StorageProfile: &compute.StorageProfile{
+				DataDisks: &dataDisks,
+			},
+		},
+	}, nil
+}
+
+// generateDataDisk generates the VM data disk for a data disk of the AzureMachine.
+func (s *VMSpec) generateDataDisk(disk infrav1.DataDisk) (compute.DataDisk, error) {
+	diskName := azure.GenerateDataDiskName(s.Name, disk.NameSuffix)
+	dataDisk := compute.DataDisk{
+		CreateOption: compute.DiskCreateOptionTypesEmpty,
+		DiskSizeGB:   to.Int32Ptr(disk.DiskSizeGB),
+		Lun:          disk.Lun,
+		Name:         to.StringPtr(diskName),
+		Caching:      compute.CachingTypes(disk.CachingType),
+	}
+
+	// disks with provisioned performance settings are created by the disks service and attached here.
+	if disk.HasProvisionedPerformance() {
+		dataDisk.CreateOption = compute.DiskCreateOptionTypesAttach
+		dataDisk.DiskSizeGB = nil
+		dataDisk.ManagedDisk = &compute.ManagedDiskParameters{
+			ID: to.StringPtr(azure.DiskID(s.SubscriptionID, s.ResourceGroup, diskName)),
+		}
+	}
+
+	if disk.ManagedDisk != nil {
+		if dataDisk.ManagedDisk == nil {
+			dataDisk.ManagedDisk = &compute.ManagedDiskParameters{}
+		}
+		dataDisk.ManagedDisk.StorageAccountType = compute.StorageAccountTypes(disk.ManagedDisk.StorageAccountType)
+
+		if disk.ManagedDisk.DiskEncryptionSet != nil {
+			dataDisk.ManagedDisk.DiskEncryptionSet = &compute.DiskEncryptionSetParameters{ID: to.StringPtr(disk.ManagedDisk.DiskEncryptionSet.ID)}
+		}
+
+		// check the support for ultra disks based on location and vm size
+		if disk.ManagedDisk.StorageAccountType == string(compute.StorageAccountTypesUltraSSDLRS) && !s.SKU.HasLocationCapability(resourceskus.UltraSSDAvailable, s.Location, s.Zone) {
+			return compute.DataDisk{}, azure.WithTerminalError(fmt.Errorf("vm size %s does not support ultra disks in location %s. select a different vm size or disable ultra disks", s.Size, s.Location))
+		}
+	}
+
+	return dataDisk, nil
+}
+
+// generateDiskSecurityProfile translates the confidential encryption settings of a managed disk.
+func generateDiskSecurityProfile(profile *infrav1.VMDiskSecurityProfile) *compute.VMDiskSecurityProfile {
+	diskSecurityProfile := &compute.VMDiskSecurityProfile{
+		SecurityEncryptionType: compute.SecurityEncryptionTypes(profile.SecurityEncryptionType),
+	}
+	if profile.DiskEncryptionSet != nil {
+		diskSecurityProfile.DiskEncryptionSet = &compute.DiskEncryptionSetParameters{ID: to.StringPtr(profile.DiskEncryptionSet.ID)}
+	}
+	return diskSecurityProfile
+}
+
 func (s *VMSpec) generateOSProfile() (*compute.OSProfile, error) {
 	sshKey, err := base64.StdEncoding.DecodeString(s.SSHKeyData)
 	if err != nil {
@@ -282,13 +376,29 @@ func (s *VMSpec) generateSecurityProfile() (*compute.SecurityProfile, error) {
 		return nil, nil
 	}
 
-	if !s.SKU.HasCapability(resourceskus.EncryptionAtHost) {
+	if to.Bool(s.SecurityProfile.EncryptionAtHost) && !s.SKU.HasCapability(resourceskus.EncryptionAtHost) {
 		return nil, azure.WithTerminalError(errors.Errorf("encryption at host is not supported for VM type %s", s.Size))
 	}
 
-	return &compute.SecurityProfile{
+	securityProfile := &compute.SecurityProfile{
 		EncryptionAtHost: s.SecurityProfile.EncryptionAtHost,
-	}, nil
+	}
+
+	if s.SecurityProfile.SecurityType != "" {
+		if err := s.SKU.ValidateSecurityType(s.SecurityProfile.SecurityType); err != nil {
+			return nil, err
+		}
+		securityProfile.SecurityType = compute.SecurityTypes(s.SecurityProfile.SecurityType)
+	}
+
+	if s.SecurityProfile.UefiSettings != nil {
+		securityProfile.UefiSettings = &compute.UefiSettings{
+			SecureBootEnabled: s.SecurityProfile.UefiSettings.SecureBootEnabled,
+			VTpmEnabled:       s.SecurityProfile.UefiSettings.VTpmEnabled,
+		}
+	}
+
+	return securityProfile, nil
 }
 
 func (s *VMSpec) generateNICRefs() *[]compute.NetworkInterfaceReference {
@@ -71,6 +71,80 @@ var (
 		},
 	}
 
+	validSKUWithTrustedLaunch = resourceskus.SKU{
+		Name: to.StringPtr("Standard_D2v3"),
+		Kind: to.StringPtr(string(resourceskus.VirtualMachines)),
+		Locations: &[]string{
+			"test-location",
+		},
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{
+				Name:  to.StringPtr(resourceskus.VCPUs),
+				Value: to.StringPtr("2"),
+			},
+			{
+				Name:  to.StringPtr(resourceskus.MemoryGB),
+				Value: to.StringPtr("4"),
+			},
+			{
+				Name:  to.StringPtr(resourceskus.HyperVGenerations),
+				Value: to.StringPtr("V1,V2"),
+			},
+		},
+	}
+
+	validSKUWithTrustedLaunchDisabled = resourceskus.SKU{
+		Name: to.StringPtr("Standard_D2v3"),
+		Kind: to.StringPtr(string(resourceskus.VirtualMachines)),
+		Locations: &[]string{
+			"test-location",
+		},
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{
+				Name:  to.StringPtr(resourceskus.VCPUs),
+				Value: to.StringPtr("2"),
+			},
+			{
+				Name:  to.StringPtr(resourceskus.MemoryGB),
+				Value: to.StringPtr("4"),
+			},
+			{
+				Name:  to.StringPtr(resourceskus.HyperVGenerations),
+				Value: to.StringPtr("V1,V2"),
+			},
+			{
+				Name:  to.StringPtr(resourceskus.TrustedLaunchDisabled),
+				Value: to.StringPtr(string(resourceskus.CapabilitySupported)),
+			},
+		},
+	}
+
+	validSKUWithConfidentialComputing = resourceskus.SKU{
+		Name: to.StringPtr("Standard_D2v3"),
+		Kind: to.StringPtr(string(resourceskus.VirtualMachines)),
+		Locations: &[]string{
+			"test-location",
+		},
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{
+				Name:  to.StringPtr(resourceskus.VCPUs),
+				Value: to.StringPtr("2"),
+			},
+			{
+				Name:  to.StringPtr(resourceskus.MemoryGB),
+				Value: to.StringPtr("4"),
+			},
+			{
+				Name:  to.StringPtr(resourceskus.HyperVGenerations),
+				Value: to.StringPtr("V2"),
+			},
+			{
+				Name:  to.StringPtr(resourceskus.ConfidentialComputingType),
+				Value: to.StringPtr("SNP"),
+			},
+		},
+	}
+
 	validSKUWithEphemeralOS = resourceskus.SKU{
 		Name: to.StringPtr("Standard_D2v3"),
 		Kind: to.StringPtr(string(resourceskus.VirtualMachines)),
@@ -93,6 +167,36 @@ var (
 		},
 	}
 
+	validSKUWithEphemeralOSPlacement = resourceskus.SKU{
+		Name: to.StringPtr("Standard_D2v3"),
+		Kind: to.StringPtr(string(resourceskus.VirtualMachines)),
+		Locations: &[]string{
+			"test-location",
+		},
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{
+				Name:  to.StringPtr(resourceskus.VCPUs),
+				Value: to.StringPtr("2"),
+			},
+			{
+				Name:  to.StringPtr(resourceskus.MemoryGB),
+				Value: to.StringPtr("4"),
+			},
+			{
+				Name:  to.StringPtr(resourceskus.EphemeralOSDisk),
+				Value: to.StringPtr("True"),
+			},
+			{
+				Name:  to.StringPtr(resourceskus.CachedDiskBytes),
+				Value: to.StringPtr("53687091200"),
+			},
+			{
+				Name:  to.StringPtr(resourceskus.MaxResourceVolumeMB),
+				Value: to.StringPtr("16384"),
+			},
+		},
+	}
+
 	validSKUWithUltraSSD = resourceskus.SKU{
 		Name: to.StringPtr("Standard_D2v3"),
 		Kind: to.StringPtr(string(resourceskus.VirtualMachines)),
@@ -193,6 +297,87 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name: "returns nil if all data disks are attached to the existing vm",
+			spec: &VMSpec{
+				Name: "my-vm",
+				DataDisks: []infrav1.DataDisk{
+					{
+						NameSuffix: "mydisk",
+						DiskSizeGB: 64,
+						Lun:        to.Int32Ptr(0),
+					},
+				},
+			},
+			existing: compute.VirtualMachine{
+				VirtualMachineProperties: &compute.VirtualMachineProperties{
+					StorageProfile: &compute.StorageProfile{
+						DataDisks: &[]compute.DataDisk{
+							{
+								Name: to.StringPtr("my-vm_mydisk"),
+								Lun:  to.Int32Ptr(0),
+							},
+						},
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name: "attaches the data disks added after the creation of the vm",
+			spec: &VMSpec{
+				Name:           "my-vm",
+				ResourceGroup:  "my-rg",
+				SubscriptionID: "123",
+				DataDisks: []infrav1.DataDisk{
+					{
+						NameSuffix: "mydisk",
+						DiskSizeGB: 64,
+						Lun:        to.Int32Ptr(0),
+					},
+					{
+						NameSuffix: "myotherdisk",
+						DiskSizeGB: 128,
+						Lun:        to.Int32Ptr(1),
+					},
+				},
+			},
+			existing: compute.VirtualMachine{
+				VirtualMachineProperties: &compute.VirtualMachineProperties{
+					StorageProfile: &compute.StorageProfile{
+						DataDisks: &[]compute.DataDisk{
+							{
+								Name:         to.StringPtr("my-vm_mydisk"),
+								Lun:          to.Int32Ptr(0),
+								CreateOption: compute.DiskCreateOptionTypesEmpty,
+								DiskSizeGB:   to.Int32Ptr(64),
+							},
+						},
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachineUpdate{}))
+				g.Expect(result.(compute.VirtualMachineUpdate).StorageProfile.DataDisks).To(Equal(&[]compute.DataDisk{
+					{
+						Name:         to.StringPtr("my-vm_mydisk"),
+						Lun:          to.Int32Ptr(0),
+						CreateOption: compute.DiskCreateOptionTypesEmpty,
+						DiskSizeGB:   to.Int32Ptr(64),
+					},
+					{
+						Name:         to.StringPtr("my-vm_myotherdisk"),
+						Lun:          to.Int32Ptr(1),
+						CreateOption: compute.DiskCreateOptionTypesEmpty,
+						DiskSizeGB:   to.Int32Ptr(128),
+					},
+				}))
+			},
+			expectedError: "",
+		},
 		{
 			name: "fails if vm deleted out of band, should not recreate",
 			spec: &VMSpec{
@@ -367,6 +552,134 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name: "can create a trusted launch vm",
+			spec: &VMSpec{
+				Name:       "my-vm",
+				Role:       infrav1.Node,
+				NICIDs:     []string{"my-nic"},
+				SSHKeyData: "fakesshpublickey",
+				Size:       "Standard_D2v3",
+				Zone:       "1",
+				Image:      &infrav1.Image{ID: to.StringPtr("fake-image-id")},
+				SecurityProfile: &infrav1.SecurityProfile{
+					SecurityType: infrav1.SecurityTypesTrustedLaunch,
+					UefiSettings: &infrav1.UefiSettings{
+						SecureBootEnabled: to.BoolPtr(true),
+						VTpmEnabled:       to.BoolPtr(true),
+					},
+				},
+				SKU: validSKUWithTrustedLaunch,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachine{}))
+				g.Expect(result.(compute.VirtualMachine).SecurityProfile).To(Equal(&compute.SecurityProfile{
+					SecurityType: compute.SecurityTypesTrustedLaunch,
+					UefiSettings: &compute.UefiSettings{
+						SecureBootEnabled: to.BoolPtr(true),
+						VTpmEnabled:       to.BoolPtr(true),
+					},
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "can create a confidential vm with an encrypted OS disk",
+			spec: &VMSpec{
+				Name:       "my-vm",
+				Role:       infrav1.Node,
+				NICIDs:     []string{"my-nic"},
+				SSHKeyData: "fakesshpublickey",
+				Size:       "Standard_D2v3",
+				Zone:       "1",
+				Image:      &infrav1.Image{ID: to.StringPtr("fake-image-id")},
+				OSDisk: infrav1.OSDisk{
+					OSType:     "Linux",
+					DiskSizeGB: to.Int32Ptr(128),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "Premium_LRS",
+						SecurityProfile: &infrav1.VMDiskSecurityProfile{
+							SecurityEncryptionType: infrav1.SecurityEncryptionTypeDiskWithVMGuestState,
+							DiskEncryptionSet:      &infrav1.DiskEncryptionSetParameters{ID: "my-diskencryptionset-id"},
+						},
+					},
+				},
+				SecurityProfile: &infrav1.SecurityProfile{
+					SecurityType: infrav1.SecurityTypesConfidentialVM,
+					UefiSettings: &infrav1.UefiSettings{
+						SecureBootEnabled: to.BoolPtr(true),
+						VTpmEnabled:       to.BoolPtr(true),
+					},
+				},
+				SKU: validSKUWithConfidentialComputing,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachine{}))
+				vm := result.(compute.VirtualMachine)
+				g.Expect(vm.SecurityProfile.SecurityType).To(Equal(compute.SecurityTypesConfidentialVM))
+				g.Expect(vm.StorageProfile.OsDisk.ManagedDisk.SecurityProfile).To(Equal(&compute.VMDiskSecurityProfile{
+					SecurityEncryptionType: compute.SecurityEncryptionTypesDiskWithVMGuestState,
+					DiskEncryptionSet:      &compute.DiskEncryptionSetParameters{ID: to.StringPtr("my-diskencryptionset-id")},
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "creating a trusted launch vm for a VM type with trusted launch disabled fails",
+			spec: &VMSpec{
+				Name:            "my-vm",
+				Role:            infrav1.Node,
+				NICIDs:          []string{"my-nic"},
+				SSHKeyData:      "fakesshpublickey",
+				Size:            "Standard_D2v3",
+				Image:           &infrav1.Image{ID: to.StringPtr("fake-image-id")},
+				SecurityProfile: &infrav1.SecurityProfile{SecurityType: infrav1.SecurityTypesTrustedLaunch},
+				SKU:             validSKUWithTrustedLaunchDisabled,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "reconcile error that cannot be recovered occurred: trusted launch is not supported for VM type Standard_D2v3. Object will not be requeued",
+		},
+		{
+			name: "creating a confidential vm for a VM type without confidential computing fails",
+			spec: &VMSpec{
+				Name:            "my-vm",
+				Role:            infrav1.Node,
+				NICIDs:          []string{"my-nic"},
+				SSHKeyData:      "fakesshpublickey",
+				Size:            "Standard_D2v3",
+				Image:           &infrav1.Image{ID: to.StringPtr("fake-image-id")},
+				SecurityProfile: &infrav1.SecurityProfile{SecurityType: infrav1.SecurityTypesConfidentialVM},
+				SKU:             validSKUWithTrustedLaunch,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "reconcile error that cannot be recovered occurred: confidential computing is not supported for VM type Standard_D2v3. Object will not be requeued",
+		},
+		{
+			name: "creating a trusted launch vm for a VM type without Hyper-V generation V2 fails",
+			spec: &VMSpec{
+				Name:            "my-vm",
+				Role:            infrav1.Node,
+				NICIDs:          []string{"my-nic"},
+				SSHKeyData:      "fakesshpublickey",
+				Size:            "Standard_D2v3",
+				Image:           &infrav1.Image{ID: to.StringPtr("fake-image-id")},
+				SecurityProfile: &infrav1.SecurityProfile{SecurityType: infrav1.SecurityTypesTrustedLaunch},
+				SKU:             validSKU,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "reconcile error that cannot be recovered occurred: security type TrustedLaunch requires a VM type supporting Hyper-V generation V2, Standard_D2v3 does not. Object will not be requeued",
+		},
 		{
 			name: "can create a vm and assign it to an availability set",
 			spec: &VMSpec{
@@ -463,6 +776,113 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "reconcile error that cannot be recovered occurred: vm size Standard_D2v3 does not support ephemeral os. select a different vm size or disable ephemeral os. Object will not be requeued",
 		},
+		{
+			name: "can create a vm with EphemeralOSDisk placed on the cache disk",
+			spec: &VMSpec{
+				Name:       "my-vm",
+				Role:       infrav1.Node,
+				NICIDs:     []string{"my-nic"},
+				SSHKeyData: "fakesshpublickey",
+				Size:       "Standard_D2v3",
+				OSDisk: infrav1.OSDisk{
+					OSType:     "Linux",
+					DiskSizeGB: to.Int32Ptr(30),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "Standard_LRS",
+					},
+					DiffDiskSettings: &infrav1.DiffDiskSettings{
+						Option:    string(compute.DiffDiskOptionsLocal),
+						Placement: infrav1.DiffDiskPlacementCacheDisk,
+					},
+				},
+				Image: &infrav1.Image{ID: to.StringPtr("fake-image-id")},
+				SKU:   validSKUWithEphemeralOSPlacement,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachine{}))
+				g.Expect(result.(compute.VirtualMachine).StorageProfile.OsDisk.DiffDiskSettings).To(Equal(&compute.DiffDiskSettings{
+					Option:    compute.DiffDiskOptionsLocal,
+					Placement: compute.DiffDiskPlacementCacheDisk,
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "cannot create vm with EphemeralOSDisk placed on a resource disk that is too small",
+			spec: &VMSpec{
+				Name:       "my-vm",
+				Role:       infrav1.Node,
+				NICIDs:     []string{"my-nic"},
+				SSHKeyData: "fakesshpublickey",
+				Size:       "Standard_D2v3",
+				OSDisk: infrav1.OSDisk{
+					OSType:     "Linux",
+					DiskSizeGB: to.Int32Ptr(30),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "Standard_LRS",
+					},
+					DiffDiskSettings: &infrav1.DiffDiskSettings{
+						Option:    string(compute.DiffDiskOptionsLocal),
+						Placement: infrav1.DiffDiskPlacementResourceDisk,
+					},
+				},
+				Image: &infrav1.Image{ID: to.StringPtr("fake-image-id")},
+				SKU:   validSKUWithEphemeralOSPlacement,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "reconcile error that cannot be recovered occurred: the ResourceDisk of vm size Standard_D2v3 is too small for an ephemeral os disk of 30 GB. select a different vm size, placement or os disk size. Object will not be requeued",
+		},
+		{
+			name: "can create a vm with an ultra disk with provisioned performance",
+			spec: &VMSpec{
+				Name:           "my-ultra-ssd-vm",
+				ResourceGroup:  "my-rg",
+				SubscriptionID: "123",
+				Role:           infrav1.Node,
+				NICIDs:         []string{"my-nic"},
+				SSHKeyData:     "fakesshpublickey",
+				Size:           "Standard_D2v3",
+				Location:       "test-location",
+				Zone:           "1",
+				Image:          &infrav1.Image{ID: to.StringPtr("fake-image-id")},
+				DataDisks: []infrav1.DataDisk{
+					{
+						NameSuffix:        "etcddisk",
+						DiskSizeGB:        256,
+						Lun:               to.Int32Ptr(0),
+						CachingType:       "None",
+						DiskIOPSReadWrite: to.Int64Ptr(10000),
+						DiskMBpsReadWrite: to.Int64Ptr(400),
+						ManagedDisk: &infrav1.ManagedDiskParameters{
+							StorageAccountType: "UltraSSD_LRS",
+						},
+					},
+				},
+				SKU: validSKUWithUltraSSD,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachine{}))
+				g.Expect(result.(compute.VirtualMachine).AdditionalCapabilities.UltraSSDEnabled).To(Equal(to.BoolPtr(true)))
+				g.Expect(result.(compute.VirtualMachine).StorageProfile.DataDisks).To(Equal(&[]compute.DataDisk{
+					{
+						Lun:          to.Int32Ptr(0),
+						Name:         to.StringPtr("my-ultra-ssd-vm_etcddisk"),
+						CreateOption: compute.DiskCreateOptionTypesAttach,
+						Caching:      compute.CachingTypesNone,
+						ManagedDisk: &compute.ManagedDiskParameters{
+							ID:                 to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/disks/my-ultra-ssd-vm_etcddisk"),
+							StorageAccountType: compute.StorageAccountTypesUltraSSDLRS,
+						},
+					},
+				}))
+			},
+			expectedError: "",
+		},
 		{
 			name: "cannot create vm if vCPU is less than 2",
 			spec: &VMSpec{
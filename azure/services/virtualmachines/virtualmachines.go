@@ -47,12 +47,18 @@ type VMScope interface {
 	SetProviderID(string)
 	SetAddresses([]corev1.NodeAddress)
 	SetVMState(infrav1.ProvisioningState)
+	IsAdopting() bool
+	AdoptedVMID() string
+	AdoptedResources() (azure.AdoptedVMResources, bool)
+	SetAdoptedResources(azure.AdoptedVMResources)
+	UpdateAdoptionStatus(error)
 }
 
 // Service provides operations on Azure resources.
 type Service struct {
 	Scope VMScope
 	async.Reconciler
+	adopter          Adopter
 	interfacesGetter async.Getter
 	publicIPsGetter  async.Getter
 }
@@ -62,6 +68,7 @@ func New(scope VMScope) *Service {
 	Client := NewClient(scope)
 	return &Service{
 		Scope:            scope,
+		adopter:          Client,
 		interfacesGetter: networkinterfaces.NewClient(scope),
 		publicIPsGetter:  publicips.NewClient(scope),
 		Reconciler:       async.New(scope, Client, Client),
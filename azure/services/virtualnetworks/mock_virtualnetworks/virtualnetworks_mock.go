@@ -67,6 +67,20 @@ func (mr *MockVNetScopeMockRecorder) Authorizer() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorizer", reflect.TypeOf((*MockVNetScope)(nil).Authorizer))
 }
 
+// AuthorizerFor mocks base method.
+func (m *MockVNetScope) AuthorizerFor(subscriptionID string) azure.Authorizer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizerFor", subscriptionID)
+	ret0, _ := ret[0].(azure.Authorizer)
+	return ret0
+}
+
+// AuthorizerFor indicates an expected call of AuthorizerFor.
+func (mr *MockVNetScopeMockRecorder) AuthorizerFor(subscriptionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizerFor", reflect.TypeOf((*MockVNetScope)(nil).AuthorizerFor), subscriptionID)
+}
+
 // BaseURI mocks base method.
 func (m *MockVNetScope) BaseURI() string {
 	m.ctrl.T.Helper()
@@ -306,3 +320,17 @@ func (mr *MockVNetScopeMockRecorder) Vnet() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Vnet", reflect.TypeOf((*MockVNetScope)(nil).Vnet))
 }
+
+// VnetSubscriptionID mocks base method.
+func (m *MockVNetScope) VnetSubscriptionID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VnetSubscriptionID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// VnetSubscriptionID indicates an expected call of VnetSubscriptionID.
+func (mr *MockVNetScopeMockRecorder) VnetSubscriptionID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VnetSubscriptionID", reflect.TypeOf((*MockVNetScope)(nil).VnetSubscriptionID))
+}
@@ -26,17 +26,21 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-const serviceName = "virtualnetworks"
+// ServiceName is the name of this service.
+const ServiceName = "virtualnetworks"
 
 // VNetScope defines the scope interface for a virtual network service.
 type VNetScope interface {
 	azure.Authorizer
+	azure.SubscriptionAuthorizer
 	azure.AsyncStatusUpdater
 	Vnet() *infrav1.VnetSpec
+	VnetSubscriptionID() string
 	VNetSpec() azure.ResourceSpecGetter
 	ClusterName() string
 	IsVnetManaged() bool
@@ -52,7 +56,7 @@ type Service struct {
 
 // New creates a new service.
 func New(scope VNetScope) *Service {
-	client := newClient(scope)
+	client := newClient(scope.AuthorizerFor(scope.VnetSubscriptionID()))
 	return &Service{
 		Scope:      scope,
 		Getter:     client,
@@ -62,7 +66,14 @@ func New(scope VNetScope) *Service {
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return ServiceName
+}
+
+// DependsOn returns the names of the services that must be reconciled before this one.
+func (s *Service) DependsOn() []string {
+	return []string{
+		groups.ServiceName,
+	}
 }
 
 // Reconcile idempotently creates or updates a virtual network.
@@ -78,7 +89,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return nil
 	}
 
-	result, err := s.CreateOrUpdateResource(ctx, vnetSpec, serviceName)
+	result, err := s.CreateOrUpdateResource(ctx, vnetSpec, ServiceName)
 	if err == nil && result != nil {
 		existingVnet, ok := result.(network.VirtualNetwork)
 		if !ok {
@@ -105,7 +116,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	}
 
 	if s.Scope.IsVnetManaged() {
-		s.Scope.UpdatePutStatus(infrav1.VNetReadyCondition, serviceName, err)
+		s.Scope.UpdatePutStatus(infrav1.VNetReadyCondition, ServiceName, err)
 	}
 
 	return err
@@ -129,8 +140,8 @@ func (s *Service) Delete(ctx context.Context) error {
 	if err != nil {
 		if azure.ResourceNotFound(err) {
 			// already deleted or doesn't exist, cleanup status and return.
-			s.Scope.DeleteLongRunningOperationState(vnetSpec.ResourceName(), serviceName, infrav1.DeleteFuture)
-			s.Scope.UpdateDeleteStatus(infrav1.VNetReadyCondition, serviceName, nil)
+			s.Scope.DeleteLongRunningOperationState(vnetSpec.ResourceName(), ServiceName, infrav1.DeleteFuture)
+			s.Scope.UpdateDeleteStatus(infrav1.VNetReadyCondition, ServiceName, nil)
 			return nil
 		}
 		return errors.Wrap(err, "could not get VNet management state")
@@ -140,8 +151,8 @@ func (s *Service) Delete(ctx context.Context) error {
 		return nil
 	}
 
-	err = s.DeleteResource(ctx, vnetSpec, serviceName)
-	s.Scope.UpdateDeleteStatus(infrav1.VNetReadyCondition, serviceName, err)
+	err = s.DeleteResource(ctx, vnetSpec, ServiceName)
+	s.Scope.UpdateDeleteStatus(infrav1.VNetReadyCondition, ServiceName, err)
 	return err
 }
 
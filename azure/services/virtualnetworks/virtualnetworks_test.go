@@ -100,7 +100,7 @@ func TestReconcileVnet(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.VNetSpec().Return(&fakeVNetSpec)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, serviceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, ServiceName).Return(nil, nil)
 				s.IsVnetManaged().Return(false)
 			},
 		},
@@ -109,9 +109,9 @@ func TestReconcileVnet(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.VNetSpec().Return(&fakeVNetSpec)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, serviceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, ServiceName).Return(nil, nil)
 				s.IsVnetManaged().Return(true)
-				s.UpdatePutStatus(infrav1.VNetReadyCondition, serviceName, nil)
+				s.UpdatePutStatus(infrav1.VNetReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -119,9 +119,9 @@ func TestReconcileVnet(t *testing.T) {
 			expectedError: internalError.Error(),
 			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.VNetSpec().Return(&fakeVNetSpec)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, serviceName).Return(nil, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, ServiceName).Return(nil, internalError)
 				s.IsVnetManaged().Return(true)
-				s.UpdatePutStatus(infrav1.VNetReadyCondition, serviceName, internalError)
+				s.UpdatePutStatus(infrav1.VNetReadyCondition, ServiceName, internalError)
 			},
 		},
 		{
@@ -129,7 +129,7 @@ func TestReconcileVnet(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.VNetSpec().Return(&fakeVNetSpec)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, serviceName).Return(customVnet, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, ServiceName).Return(customVnet, nil)
 				s.Vnet().Return(&infrav1.VnetSpec{})
 				s.UpdateSubnetCIDRs("test-subnet", []string{"subnet-cidr"})
 				s.UpdateSubnetCIDRs("test-subnet-2", []string{"subnet-cidr-1", "subnet-cidr-2"})
@@ -189,8 +189,8 @@ func TestDeleteVnet(t *testing.T) {
 				s.VNetSpec().Times(2).Return(&fakeVNetSpec)
 				m.Get(gomockinternal.AContext(), &fakeVNetSpec).Return(managedVnet, nil)
 				s.ClusterName().Return("test-cluster")
-				r.DeleteResource(gomockinternal.AContext(), &fakeVNetSpec, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.VNetReadyCondition, serviceName, nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeVNetSpec, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.VNetReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -200,8 +200,8 @@ func TestDeleteVnet(t *testing.T) {
 				s.VNetSpec().Times(2).Return(&fakeVNetSpec)
 				m.Get(gomockinternal.AContext(), &fakeVNetSpec).Return(managedVnet, nil)
 				s.ClusterName().Return("test-cluster")
-				r.DeleteResource(gomockinternal.AContext(), &fakeVNetSpec, serviceName).Return(internalError)
-				s.UpdateDeleteStatus(infrav1.VNetReadyCondition, serviceName, internalError)
+				r.DeleteResource(gomockinternal.AContext(), &fakeVNetSpec, ServiceName).Return(internalError)
+				s.UpdateDeleteStatus(infrav1.VNetReadyCondition, ServiceName, internalError)
 			},
 		},
 		{
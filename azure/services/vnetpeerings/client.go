@@ -19,6 +19,7 @@ package vnetpeerings
 import (
 	"context"
 	"encoding/json"
+	"sync"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
 	"github.com/Azure/go-autorest/autorest"
@@ -31,14 +32,22 @@ import (
 )
 
 // AzureClient contains the Azure go-sdk Client.
+// Peerings are created in the subscription of their source virtual network, so the client keeps a virtual network
+// peerings client for each subscription it is used with.
 type AzureClient struct {
-	peerings network.VirtualNetworkPeeringsClient
+	auth azure.SubscriptionAuthorizer
+
+	mu       sync.Mutex
+	peerings map[string]network.VirtualNetworkPeeringsClient
 }
 
-// NewClient creates a new virtual network peerings client from subscription ID.
-func NewClient(auth azure.Authorizer) *AzureClient {
-	c := newPeeringsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
-	return &AzureClient{c}
+// NewClient creates a new virtual network peerings client which authorizes requests for each subscription
+// with the Authorizer returned for it.
+func NewClient(auth azure.SubscriptionAuthorizer) *AzureClient {
+	return &AzureClient{
+		auth:     auth,
+		peerings: make(map[string]network.VirtualNetworkPeeringsClient),
+	}
 }
 
 // newPeeringsClient creates a new virtual network peerings client from subscription ID.
@@ -48,12 +57,42 @@ func newPeeringsClient(subscriptionID string, baseURI string, authorizer autores
 	return peeringsClient
 }
 
+// peeringsClient returns the virtual network peerings client for a subscription. An empty subscription ID
+// returns the client for the cluster's subscription.
+func (ac *AzureClient) peeringsClient(subscriptionID string) network.VirtualNetworkPeeringsClient {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	if c, ok := ac.peerings[subscriptionID]; ok {
+		return c
+	}
+	auth := ac.auth.AuthorizerFor(subscriptionID)
+	c := newPeeringsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
+	ac.peerings[subscriptionID] = c
+	return c
+}
+
+// specPeeringsClient returns the virtual network peerings client for the subscription of the source virtual network of a spec.
+func (ac *AzureClient) specPeeringsClient(spec azure.ResourceSpecGetter) network.VirtualNetworkPeeringsClient {
+	var subscriptionID string
+	if peeringSpec, ok := spec.(*VnetPeeringSpec); ok {
+		subscriptionID = peeringSpec.SourceSubscriptionID
+	}
+	return ac.peeringsClient(subscriptionID)
+}
+
+// futurePeeringsClient returns the virtual network peerings client for the subscription a long-running operation
+// was started in, which is part of the URL the operation is polled at.
+func (ac *AzureClient) futurePeeringsClient(future azureautorest.FutureAPI) network.VirtualNetworkPeeringsClient {
+	return ac.peeringsClient(azure.SubscriptionIDFromURL(future.PollingURL()))
+}
+
 // Get gets the specified virtual network peering by the peering name, virtual network, and resource group.
 func (ac *AzureClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (result interface{}, err error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "vnetpeerings.AzureClient.Get")
 	defer done()
 
-	return ac.peerings.Get(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName())
+	return ac.specPeeringsClient(spec).Get(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName())
 }
 
 // CreateOrUpdateAsync creates or updates a virtual network peering asynchronously.
@@ -68,7 +107,8 @@ func (ac *AzureClient) CreateOrUpdateAsync(ctx context.Context, spec azure.Resou
 		return nil, nil, errors.Errorf("%T is not a network.VirtualNetworkPeering", parameters)
 	}
 
-	createFuture, err := ac.peerings.CreateOrUpdate(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName(), peering, network.SyncRemoteAddressSpaceTrue)
+	peerings := ac.specPeeringsClient(spec)
+	createFuture, err := peerings.CreateOrUpdate(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName(), peering, network.SyncRemoteAddressSpaceTrue)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -76,14 +116,14 @@ func (ac *AzureClient) CreateOrUpdateAsync(ctx context.Context, spec azure.Resou
 	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
 	defer cancel()
 
-	err = createFuture.WaitForCompletionRef(ctx, ac.peerings.Client)
+	err = createFuture.WaitForCompletionRef(ctx, peerings.Client)
 	if err != nil {
 		// if an error occurs, return the future.
 		// this means the long-running operation didn't finish in the specified timeout.
 		return nil, &createFuture, err
 	}
 
-	result, err = createFuture.Result(ac.peerings)
+	result, err = createFuture.Result(peerings)
 	// if the operation completed, return a nil future
 	return result, nil, err
 }
@@ -95,7 +135,8 @@ func (ac *AzureClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecG
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "vnetpeerings.AzureClient.Delete")
 	defer done()
 
-	deleteFuture, err := ac.peerings.Delete(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName())
+	peerings := ac.specPeeringsClient(spec)
+	deleteFuture, err := peerings.Delete(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName())
 	if err != nil {
 		return nil, err
 	}
@@ -103,13 +144,13 @@ func (ac *AzureClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecG
 	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
 	defer cancel()
 
-	err = deleteFuture.WaitForCompletionRef(ctx, ac.peerings.Client)
+	err = deleteFuture.WaitForCompletionRef(ctx, peerings.Client)
 	if err != nil {
 		// if an error occurs, return the future.
 		// this means the long-running operation didn't finish in the specified timeout.
 		return &deleteFuture, err
 	}
-	_, err = deleteFuture.Result(ac.peerings)
+	_, err = deleteFuture.Result(peerings)
 	// if the operation completed, return a nil future.
 	return nil, err
 }
@@ -119,7 +160,7 @@ func (ac *AzureClient) IsDone(ctx context.Context, future azureautorest.FutureAP
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "vnetpeerings.AzureClient.IsDone")
 	defer done()
 
-	return future.DoneWithContext(ctx, ac.peerings)
+	return future.DoneWithContext(ctx, ac.futurePeeringsClient(future))
 }
 
 // Result fetches the result of a long-running operation future.
@@ -144,7 +185,7 @@ func (ac *AzureClient) Result(ctx context.Context, future azureautorest.FutureAP
 		if err := json.Unmarshal(jsonData, &createFuture); err != nil {
 			return nil, errors.Wrap(err, "failed to unmarshal future data")
 		}
-		return createFuture.Result(ac.peerings)
+		return createFuture.Result(ac.futurePeeringsClient(future))
 
 	case infrav1.DeleteFuture:
 		// Delete does not return a result virtual network peering
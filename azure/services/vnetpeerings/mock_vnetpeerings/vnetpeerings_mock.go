@@ -67,6 +67,20 @@ func (mr *MockVnetPeeringScopeMockRecorder) Authorizer() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorizer", reflect.TypeOf((*MockVnetPeeringScope)(nil).Authorizer))
 }
 
+// AuthorizerFor mocks base method.
+func (m *MockVnetPeeringScope) AuthorizerFor(subscriptionID string) azure.Authorizer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizerFor", subscriptionID)
+	ret0, _ := ret[0].(azure.Authorizer)
+	return ret0
+}
+
+// AuthorizerFor indicates an expected call of AuthorizerFor.
+func (mr *MockVnetPeeringScopeMockRecorder) AuthorizerFor(subscriptionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizerFor", reflect.TypeOf((*MockVnetPeeringScope)(nil).AuthorizerFor), subscriptionID)
+}
+
 // BaseURI mocks base method.
 func (m *MockVnetPeeringScope) BaseURI() string {
 	m.ctrl.T.Helper()
@@ -25,12 +25,13 @@ import (
 
 // VnetPeeringSpec defines the specification for a virtual network peering.
 type VnetPeeringSpec struct {
-	SourceResourceGroup string
-	SourceVnetName      string
-	RemoteResourceGroup string
-	RemoteVnetName      string
-	PeeringName         string
-	SubscriptionID      string
+	SourceSubscriptionID string
+	SourceResourceGroup  string
+	SourceVnetName       string
+	RemoteSubscriptionID string
+	RemoteResourceGroup  string
+	RemoteVnetName       string
+	PeeringName          string
 }
 
 // ResourceName returns the name of the virtual network peering.
@@ -57,7 +58,7 @@ func (s *VnetPeeringSpec) Parameters(existing interface{}) (params interface{},
 		// virtual network peering already exists
 		return nil, nil
 	}
-	vnetID := azure.VNetID(s.SubscriptionID, s.RemoteResourceGroup, s.RemoteVnetName)
+	vnetID := azure.VNetID(s.RemoteSubscriptionID, s.RemoteResourceGroup, s.RemoteVnetName)
 	peeringProperties := network.VirtualNetworkPeeringPropertiesFormat{
 		RemoteVirtualNetwork: &network.SubResource{
 			ID: to.StringPtr(vnetID),
@@ -34,44 +34,49 @@ import (
 
 var (
 	fakePeering1To2 = VnetPeeringSpec{
-		PeeringName:         "vnet1-to-vnet2",
-		SourceVnetName:      "vnet1",
-		SourceResourceGroup: "group1",
-		RemoteVnetName:      "vnet2",
-		RemoteResourceGroup: "group2",
-		SubscriptionID:      "sub1",
+		PeeringName:          "vnet1-to-vnet2",
+		SourceVnetName:       "vnet1",
+		SourceResourceGroup:  "group1",
+		RemoteVnetName:       "vnet2",
+		RemoteResourceGroup:  "group2",
+		SourceSubscriptionID: "sub1",
+		RemoteSubscriptionID: "sub1",
 	}
 	fakePeering2To1 = VnetPeeringSpec{
-		PeeringName:         "vnet2-to-vnet1",
-		SourceVnetName:      "vnet2",
-		SourceResourceGroup: "group2",
-		RemoteVnetName:      "vnet1",
-		RemoteResourceGroup: "group1",
-		SubscriptionID:      "sub1",
+		PeeringName:          "vnet2-to-vnet1",
+		SourceVnetName:       "vnet2",
+		SourceResourceGroup:  "group2",
+		RemoteVnetName:       "vnet1",
+		RemoteResourceGroup:  "group1",
+		SourceSubscriptionID: "sub1",
+		RemoteSubscriptionID: "sub1",
 	}
 	fakePeering1To3 = VnetPeeringSpec{
-		PeeringName:         "vnet1-to-vnet3",
-		SourceVnetName:      "vnet1",
-		SourceResourceGroup: "group1",
-		RemoteVnetName:      "vnet3",
-		RemoteResourceGroup: "group3",
-		SubscriptionID:      "sub1",
+		PeeringName:          "vnet1-to-vnet3",
+		SourceVnetName:       "vnet1",
+		SourceResourceGroup:  "group1",
+		RemoteVnetName:       "vnet3",
+		RemoteResourceGroup:  "group3",
+		SourceSubscriptionID: "sub1",
+		RemoteSubscriptionID: "sub1",
 	}
 	fakePeering3To1 = VnetPeeringSpec{
-		PeeringName:         "vnet3-to-vnet1",
-		SourceVnetName:      "vnet3",
-		SourceResourceGroup: "group3",
-		RemoteVnetName:      "vnet1",
-		RemoteResourceGroup: "group1",
-		SubscriptionID:      "sub1",
+		PeeringName:          "vnet3-to-vnet1",
+		SourceVnetName:       "vnet3",
+		SourceResourceGroup:  "group3",
+		RemoteVnetName:       "vnet1",
+		RemoteResourceGroup:  "group1",
+		SourceSubscriptionID: "sub1",
+		RemoteSubscriptionID: "sub1",
 	}
 	fakePeeringExtra = VnetPeeringSpec{
-		PeeringName:         "extra-peering",
-		SourceVnetName:      "vnet3",
-		SourceResourceGroup: "group3",
-		RemoteVnetName:      "vnet4",
-		RemoteResourceGroup: "group4",
-		SubscriptionID:      "sub1",
+		PeeringName:          "extra-peering",
+		SourceVnetName:       "vnet3",
+		SourceResourceGroup:  "group3",
+		RemoteVnetName:       "vnet4",
+		RemoteResourceGroup:  "group4",
+		SourceSubscriptionID: "sub1",
+		RemoteSubscriptionID: "sub1",
 	}
 	fakePeeringSpecs      = []azure.ResourceSpecGetter{&fakePeering1To2, &fakePeering2To1, &fakePeering1To3, &fakePeering3To1}
 	fakePeeringExtraSpecs = []azure.ResourceSpecGetter{&fakePeering1To2, &fakePeering2To1, &fakePeeringExtra}
@@ -90,8 +95,8 @@ func TestReconcileVnetPeerings(t *testing.T) {
 			expectedError: "",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs[:1])
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To2, serviceName).Return(&fakePeering1To2, nil)
-				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, serviceName, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(&fakePeering1To2, nil)
+				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -106,9 +111,9 @@ func TestReconcileVnetPeerings(t *testing.T) {
 			expectedError: "",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs[:2])
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To2, serviceName).Return(&fakePeering1To2, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering2To1, serviceName).Return(&fakePeering2To1, nil)
-				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, serviceName, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(&fakePeering1To2, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(&fakePeering2To1, nil)
+				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -116,10 +121,10 @@ func TestReconcileVnetPeerings(t *testing.T) {
 			expectedError: "",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringExtraSpecs)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To2, serviceName).Return(&fakePeering1To2, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering2To1, serviceName).Return(&fakePeering2To1, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeeringExtra, serviceName).Return(&fakePeeringExtra, nil)
-				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, serviceName, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(&fakePeering1To2, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(&fakePeering2To1, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeeringExtra, ServiceName).Return(&fakePeeringExtra, nil)
+				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -127,11 +132,11 @@ func TestReconcileVnetPeerings(t *testing.T) {
 			expectedError: "",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To2, serviceName).Return(&fakePeering1To2, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering2To1, serviceName).Return(&fakePeering2To1, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To3, serviceName).Return(&fakePeering1To3, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering3To1, serviceName).Return(&fakePeering3To1, nil)
-				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, serviceName, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(&fakePeering1To2, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(&fakePeering2To1, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To3, ServiceName).Return(&fakePeering1To3, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering3To1, ServiceName).Return(&fakePeering3To1, nil)
+				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -139,11 +144,11 @@ func TestReconcileVnetPeerings(t *testing.T) {
 			expectedError: "#: Internal Server Error: StatusCode=500",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To2, serviceName).Return(&fakePeering1To2, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering2To1, serviceName).Return(&fakePeering2To1, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To3, serviceName).Return(nil, internalError)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering3To1, serviceName).Return(&fakePeering3To1, nil)
-				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, serviceName, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(&fakePeering1To2, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(&fakePeering2To1, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To3, ServiceName).Return(nil, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering3To1, ServiceName).Return(&fakePeering3To1, nil)
+				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, internalError)
 			},
 		},
 		{
@@ -151,11 +156,11 @@ func TestReconcileVnetPeerings(t *testing.T) {
 			expectedError: "#: Internal Server Error: StatusCode=500",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To2, serviceName).Return(&fakePeering1To2, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering2To1, serviceName).Return(&fakePeering2To1, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To3, serviceName).Return(nil, internalError)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering3To1, serviceName).Return(&fakePeering3To1, nil)
-				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, serviceName, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(&fakePeering1To2, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(&fakePeering2To1, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To3, ServiceName).Return(nil, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering3To1, ServiceName).Return(&fakePeering3To1, nil)
+				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, internalError)
 			},
 		},
 		{
@@ -163,11 +168,11 @@ func TestReconcileVnetPeerings(t *testing.T) {
 			expectedError: "#: Internal Server Error: StatusCode=500",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To2, serviceName).Return(&fakePeering1To2, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering2To1, serviceName).Return(nil, internalError)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To3, serviceName).Return(nil, notDoneError)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering3To1, serviceName).Return(&fakePeering3To1, nil)
-				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, serviceName, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(&fakePeering1To2, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(nil, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To3, ServiceName).Return(nil, notDoneError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering3To1, ServiceName).Return(&fakePeering3To1, nil)
+				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, internalError)
 			},
 		},
 		{
@@ -175,11 +180,11 @@ func TestReconcileVnetPeerings(t *testing.T) {
 			expectedError: "#: Internal Server Error: StatusCode=500",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To2, serviceName).Return(&fakePeering1To2, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering2To1, serviceName).Return(&fakePeering2To1, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To3, serviceName).Return(nil, notDoneError)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering3To1, serviceName).Return(nil, internalError)
-				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, serviceName, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(&fakePeering1To2, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(&fakePeering2To1, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To3, ServiceName).Return(nil, notDoneError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering3To1, ServiceName).Return(nil, internalError)
+				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, internalError)
 			},
 		},
 		{
@@ -187,11 +192,11 @@ func TestReconcileVnetPeerings(t *testing.T) {
 			expectedError: "operation type  on Azure resource / is not done",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To2, serviceName).Return(&fakePeering1To2, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering2To1, serviceName).Return(&fakePeering2To1, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To3, serviceName).Return(nil, notDoneError)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering3To1, serviceName).Return(&fakePeering3To1, nil)
-				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, serviceName, notDoneError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(&fakePeering1To2, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(&fakePeering2To1, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To3, ServiceName).Return(nil, notDoneError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering3To1, ServiceName).Return(&fakePeering3To1, nil)
+				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, notDoneError)
 			},
 		},
 	}
@@ -236,8 +241,8 @@ func TestDeleteVnetPeerings(t *testing.T) {
 			expectedError: "",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs[:1])
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To2, serviceName).Return(nil)
-				p.UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, serviceName, nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(nil)
+				p.UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -252,9 +257,9 @@ func TestDeleteVnetPeerings(t *testing.T) {
 			expectedError: "",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs[:2])
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To2, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering2To1, serviceName).Return(nil)
-				p.UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, serviceName, nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(nil)
+				p.UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -262,10 +267,10 @@ func TestDeleteVnetPeerings(t *testing.T) {
 			expectedError: "",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringExtraSpecs)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To2, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering2To1, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeeringExtra, serviceName).Return(nil)
-				p.UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, serviceName, nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePeeringExtra, ServiceName).Return(nil)
+				p.UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -273,11 +278,11 @@ func TestDeleteVnetPeerings(t *testing.T) {
 			expectedError: "",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To2, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering2To1, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To3, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering3To1, serviceName).Return(nil)
-				p.UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, serviceName, nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To3, ServiceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePeering3To1, ServiceName).Return(nil)
+				p.UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -285,11 +290,11 @@ func TestDeleteVnetPeerings(t *testing.T) {
 			expectedError: "#: Internal Server Error: StatusCode=500",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To2, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering2To1, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To3, serviceName).Return(internalError)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering3To1, serviceName).Return(nil)
-				p.UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, serviceName, internalError)
+				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To3, ServiceName).Return(internalError)
+				r.DeleteResource(gomockinternal.AContext(), &fakePeering3To1, ServiceName).Return(nil)
+				p.UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, ServiceName, internalError)
 			},
 		},
 		{
@@ -297,11 +302,11 @@ func TestDeleteVnetPeerings(t *testing.T) {
 			expectedError: "#: Internal Server Error: StatusCode=500",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To2, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering2To1, serviceName).Return(internalError)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To3, serviceName).Return(notDoneError)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering3To1, serviceName).Return(nil)
-				p.UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, serviceName, internalError)
+				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(internalError)
+				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To3, ServiceName).Return(notDoneError)
+				r.DeleteResource(gomockinternal.AContext(), &fakePeering3To1, ServiceName).Return(nil)
+				p.UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, ServiceName, internalError)
 			},
 		},
 		{
@@ -309,11 +314,11 @@ func TestDeleteVnetPeerings(t *testing.T) {
 			expectedError: "#: Internal Server Error: StatusCode=500",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To2, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering2To1, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To3, serviceName).Return(notDoneError)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering3To1, serviceName).Return(internalError)
-				p.UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, serviceName, internalError)
+				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To3, ServiceName).Return(notDoneError)
+				r.DeleteResource(gomockinternal.AContext(), &fakePeering3To1, ServiceName).Return(internalError)
+				p.UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, ServiceName, internalError)
 			},
 		},
 		{
@@ -321,11 +326,11 @@ func TestDeleteVnetPeerings(t *testing.T) {
 			expectedError: "operation type  on Azure resource / is not done",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To2, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering2To1, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To3, serviceName).Return(notDoneError)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering3To1, serviceName).Return(nil)
-				p.UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, serviceName, notDoneError)
+				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To3, ServiceName).Return(notDoneError)
+				r.DeleteResource(gomockinternal.AContext(), &fakePeering3To1, ServiceName).Return(nil)
+				p.UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, ServiceName, notDoneError)
 			},
 		},
 	}
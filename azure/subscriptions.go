@@ -0,0 +1,46 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"net/url"
+	"strings"
+)
+
+// SubscriptionAuthorizer is an interface which can get an Authorizer for a subscription other than the cluster's.
+// It is declared outside of interfaces.go so that the generated mocks of the azure package do not import it.
+type SubscriptionAuthorizer interface {
+	// AuthorizerFor returns an Authorizer for the given subscription, or for the cluster's subscription if
+	// subscriptionID is empty.
+	AuthorizerFor(subscriptionID string) Authorizer
+}
+
+// SubscriptionIDFromURL returns the ID of the subscription in an Azure Resource Manager URL, such as the URL a
+// long-running operation is polled at, or an empty string if the URL does not contain one.
+func SubscriptionIDFromURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 0; i < len(segments)-1; i++ {
+		if strings.EqualFold(segments[i], "subscriptions") {
+			return segments[i+1]
+		}
+	}
+	return ""
+}
@@ -43,40 +43,6 @@ const (
 	VirtualMachineScaleSet = "VirtualMachineScaleSet"
 )
 
-// ScaleSetSpec defines the specification for a Scale Set.
-type ScaleSetSpec struct {
-	Name                         string
-	Size                         string
-	Capacity                     int64
-	SSHKeyData                   string
-	OSDisk                       infrav1.OSDisk
-	DataDisks                    []infrav1.DataDisk
-	SubnetName                   string
-	VNetName                     string
-	VNetResourceGroup            string
-	PublicLBName                 string
-	PublicLBAddressPoolName      string
-	AcceleratedNetworking        *bool
-	TerminateNotificationTimeout *int
-	Identity                     infrav1.VMIdentity
-	UserAssignedIdentities       []infrav1.UserAssignedIdentity
-	SecurityProfile              *infrav1.SecurityProfile
-	SpotVMOptions                *infrav1.SpotVMOptions
-	AdditionalCapabilities       *infrav1.AdditionalCapabilities
-	FailureDomains               []string
-	VMExtensions                 []infrav1.VMExtension
-}
-
-// TagsSpec defines the specification for a set of tags.
-type TagsSpec struct {
-	Scope string
-	Tags  infrav1.Tags
-	// Annotation is the key which stores the last applied tags as value in JSON format.
-	// The last applied tags are used to find out which tags are being managed by CAPZ
-	// and if any has to be deleted by comparing it with the new desired tags
-	Annotation string
-}
-
 // ExtensionSpec defines the specification for a VM or VMSS extension.
 type ExtensionSpec struct {
 	Name              string
@@ -170,3 +136,107 @@ func (vmss VMSS) HasLatestModelApplied(vm VMSSVM) bool {
 	// if the images match, then the VM is of the same model
 	return reflect.DeepEqual(vm.Image, vmss.Image)
 }
+
+// PlannedAction is the action that would be taken on an Azure resource.
+type PlannedAction string
+
+const (
+	// PlannedCreate means the resource would be created.
+	PlannedCreate PlannedAction = "create"
+	// PlannedUpdate means the resource would be updated.
+	PlannedUpdate PlannedAction = "update"
+	// PlannedDelete means the resource would be deleted.
+	PlannedDelete PlannedAction = "delete"
+)
+
+// PlannedChange is a change to an Azure resource that was computed but not applied in dry-run mode.
+type PlannedChange struct {
+	Service       string        `json:"service"`
+	ResourceGroup string        `json:"resourceGroup,omitempty"`
+	Resource      string        `json:"resource"`
+	Action        PlannedAction `json:"action"`
+	// ChangedFields are the JSON paths of the fields that would be changed by an update.
+	ChangedFields []string `json:"changedFields,omitempty"`
+}
+
+// ResourceDrift is a change made outside of CAPZ to the fields CAPZ owns of an Azure resource.
+type ResourceDrift struct {
+	Service       string `json:"service"`
+	ResourceGroup string `json:"resourceGroup,omitempty"`
+	Resource      string `json:"resource"`
+	// Fields are the paths of the fields which differ from the spec.
+	Fields []string `json:"fields"`
+	// Remediated is true if the fields were reverted to the spec.
+	Remediated bool `json:"remediated"`
+}
+
+// AdoptedManagedClusterProperties are the properties of an adopted AKS cluster that are set in the AzureManagedControlPlane
+// when it leaves them unset.
+type AdoptedManagedClusterProperties struct {
+	// SSHPublicKey is the base64 encoded SSH public key of the nodes.
+	SSHPublicKey string
+
+	// NetworkPlugin is the network plugin of the AKS cluster.
+	NetworkPlugin string
+
+	// NetworkPolicy is the network policy of the AKS cluster.
+	NetworkPolicy string
+
+	// LoadBalancerSKU is the SKU of the load balancer of the AKS cluster.
+	LoadBalancerSKU string
+
+	// DNSServiceIP is the IP address of the Kubernetes DNS service of the AKS cluster.
+	DNSServiceIP string
+
+	// Tags are the tags of the AKS cluster.
+	Tags map[string]string
+
+	// AgentPools are the properties of the agent pools of the AKS cluster, by agent pool name.
+	AgentPools map[string]AdoptedAgentPoolProperties
+}
+
+// AdoptedAgentPoolProperties are the properties of an agent pool of an adopted AKS cluster that are set in the
+// AzureManagedMachinePool when it leaves them unset.
+type AdoptedAgentPoolProperties struct {
+	// OSDiskSizeGB is the OS disk size of the nodes in GB.
+	OSDiskSizeGB *int32
+
+	// OSDiskType is the OS disk type of the nodes.
+	OSDiskType string
+
+	// MaxPods is the maximum number of pods per node.
+	MaxPods *int32
+
+	// NodeLabels are the labels of the nodes.
+	NodeLabels map[string]string
+
+	// MinCount is the minimum number of nodes if the agent pool is autoscaled.
+	MinCount *int32
+
+	// MaxCount is the maximum number of nodes if the agent pool is autoscaled.
+	MaxCount *int32
+}
+
+// AdoptedVMResources are the names of the resources of a VM adopted by an AzureMachine, which are managed in place
+// of the ones named after the AzureMachine.
+type AdoptedVMResources struct {
+	// NetworkInterfaces are the names of the network interfaces of the VM, the primary one first.
+	NetworkInterfaces []string `json:"networkInterfaces,omitempty"`
+
+	// PublicIPs are the names of the public IPs of the network interfaces of the VM.
+	PublicIPs []string `json:"publicIPs,omitempty"`
+
+	// OSDisk is the name of the OS disk of the VM.
+	OSDisk string `json:"osDisk,omitempty"`
+
+	// DataDisks are the names of the data disks of the VM.
+	DataDisks []string `json:"dataDisks,omitempty"`
+
+	// DataDiskLUNs are the LUNs of the data disks of the VM, by disk name.
+	DataDiskLUNs map[string]int32 `json:"dataDiskLUNs,omitempty"`
+}
+
+// IsDryRunEnabled returns true if the given annotations of an object enable dry-run mode.
+func IsDryRunEnabled(annotations map[string]string) bool {
+	return annotations[DryRunAnnotation] == "true"
+}
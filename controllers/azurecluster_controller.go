@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/ratelimit"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -51,6 +52,7 @@ type AzureClusterReconciler struct {
 	ReconcileTimeout          time.Duration
 	WatchFilterValue          string
 	createAzureClusterService azureClusterServiceCreator
+	driftScanInterval         time.Duration
 }
 
 type azureClusterServiceCreator func(clusterScope *scope.ClusterScope) (*azureClusterService, error)
@@ -77,9 +79,11 @@ func (acr *AzureClusterReconciler) SetupWithManager(ctx context.Context, mgr ctr
 	)
 	defer done()
 
+	acr.driftScanInterval = options.DriftScanInterval
+
 	var r reconcile.Reconciler = acr
 	if options.Cache != nil {
-		r = coalescing.NewReconciler(acr, options.Cache, log)
+		r = coalescing.NewReconciler(acr, acr.Client, &infrav1.AzureCluster{}, options.Cache, log)
 	}
 
 	c, err := ctrl.NewControllerManagedBy(mgr).
@@ -168,6 +172,15 @@ func (acr *AzureClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		acr.Recorder.Eventf(azureCluster, corev1.EventTypeWarning, "AzureClusterIdentity", deprecatedManagerCredsWarning)
 	}
 
+	for _, ref := range scope.SubscriptionReferences(azureCluster) {
+		if ref.IdentityRef == nil {
+			continue
+		}
+		if err := EnsureClusterIdentity(ctx, acr.Client, azureCluster, ref.IdentityRef, infrav1.ClusterFinalizer); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
 	// Create the scope.
 	clusterScope, err := scope.NewClusterScope(ctx, scope.ClusterScopeParams{
 		Client:       acr.Client,
@@ -215,7 +228,10 @@ func (acr *AzureClusterReconciler) reconcileNormal(ctx context.Context, clusterS
 		return reconcile.Result{}, errors.Wrap(err, "failed to create a new AzureClusterReconciler")
 	}
 
-	if err := acs.Reconcile(ctx); err != nil {
+	err = acs.Reconcile(ctx)
+	ReportDryRun(acr.Recorder, azureCluster, clusterScope)
+	ReportDrift(acr.Recorder, azureCluster, clusterScope)
+	if err != nil {
 		// Handle terminal & transient errors
 		var reconcileError azure.ReconcileError
 		if errors.As(err, &reconcileError) {
@@ -253,12 +269,14 @@ func (acr *AzureClusterReconciler) reconcileNormal(ctx context.Context, clusterS
 	azureCluster.Status.Ready = true
 	conditions.MarkTrue(azureCluster, infrav1.NetworkInfrastructureReadyCondition)
 
-	return reconcile.Result{}, nil
+	// Reconcile again to check the Azure resources for changes made outside of CAPZ.
+	return reconcile.Result{RequeueAfter: acr.driftScanInterval}, nil
 }
 
 func (acr *AzureClusterReconciler) reconcileDelete(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.AzureClusterReconciler.reconcileDelete")
 	defer done()
+	ctx = ratelimit.WithPriority(ctx)
 
 	log.Info("Reconciling AzureCluster delete")
 
@@ -269,7 +287,9 @@ func (acr *AzureClusterReconciler) reconcileDelete(ctx context.Context, clusterS
 		return reconcile.Result{}, errors.Wrap(err, "failed to create a new AzureClusterReconciler")
 	}
 
-	if err := acs.Delete(ctx); err != nil {
+	err = acs.Delete(ctx)
+	ReportDryRun(acr.Recorder, azureCluster, clusterScope)
+	if err != nil {
 		// Handle transient errors
 		var reconcileError azure.ReconcileError
 		if errors.As(err, &reconcileError) {
@@ -300,5 +320,14 @@ func (acr *AzureClusterReconciler) reconcileDelete(ctx context.Context, clusterS
 		}
 	}
 
+	for _, ref := range scope.SubscriptionReferences(azureCluster) {
+		if ref.IdentityRef == nil {
+			continue
+		}
+		if err := RemoveClusterIdentityFinalizer(ctx, acr.Client, azureCluster, ref.IdentityRef, infrav1.ClusterFinalizer); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
 	return reconcile.Result{}, nil
 }
@@ -43,7 +43,9 @@ import (
 type azureClusterService struct {
 	scope *scope.ClusterScope
 	// services is the list of services that are reconciled by this controller.
-	// The order of the services is important as it determines the order in which the services are reconciled.
+	// Services are reconciled once the services they depend on are reconciled, and services that do not depend on
+	// each other are reconciled concurrently. A service that does not declare its dependencies is reconciled after
+	// all the services listed before it.
 	services []azure.ServiceReconciler
 	skuCache *resourceskus.Cache
 }
@@ -74,7 +76,7 @@ func newAzureClusterService(scope *scope.ClusterScope) (*azureClusterService, er
 	}, nil
 }
 
-// Reconcile reconciles all the services in a predetermined order.
+// Reconcile reconciles all the services in the order given by their dependencies.
 func (s *azureClusterService) Reconcile(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureClusterService.Reconcile")
 	defer done()
@@ -86,16 +88,20 @@ func (s *azureClusterService) Reconcile(ctx context.Context) error {
 	s.scope.SetDNSName()
 	s.scope.SetControlPlaneSecurityRules()
 
-	for _, service := range s.services {
+	graph, err := newServiceGraph(s.services)
+	if err != nil {
+		return errors.Wrap(err, "failed to order AzureCluster services")
+	}
+
+	return graph.Reconcile(ctx, func(ctx context.Context, service azure.ServiceReconciler) error {
 		if err := service.Reconcile(ctx); err != nil {
 			return errors.Wrapf(err, "failed to reconcile AzureCluster service %s", service.Name())
 		}
-	}
-
-	return nil
+		return nil
+	})
 }
 
-// Delete reconciles all the services in a predetermined order.
+// Delete deletes all the services in the reverse order given by their dependencies.
 func (s *azureClusterService) Delete(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureClusterService.Delete")
 	defer done()
@@ -121,10 +127,18 @@ func (s *azureClusterService) Delete(ctx context.Context) error {
 	} else {
 		// If the resource group is not managed we need to delete resources inside the group one by one.
 		// services are deleted in reverse order from the order in which they are reconciled.
-		for i := len(s.services) - 1; i >= 0; i-- {
-			if err := s.services[i].Delete(ctx); err != nil {
-				return errors.Wrapf(err, "failed to delete AzureCluster service %s", s.services[i].Name())
+		graph, err := newServiceGraph(s.services)
+		if err != nil {
+			return errors.Wrap(err, "failed to order AzureCluster services")
+		}
+		err = graph.Delete(ctx, func(ctx context.Context, service azure.ServiceReconciler) error {
+			if err := service.Delete(ctx); err != nil {
+				return errors.Wrapf(err, "failed to delete AzureCluster service %s", service.Name())
 			}
+			return nil
+		})
+		if err != nil {
+			return err
 		}
 	}
 
@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/ratelimit"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -52,6 +53,7 @@ type AzureMachineReconciler struct {
 	ReconcileTimeout          time.Duration
 	WatchFilterValue          string
 	createAzureMachineService azureMachineServiceCreator
+	driftScanInterval         time.Duration
 }
 
 type azureMachineServiceCreator func(machineScope *scope.MachineScope) (*azureMachineService, error)
@@ -78,9 +80,11 @@ func (amr *AzureMachineReconciler) SetupWithManager(ctx context.Context, mgr ctr
 	)
 	defer done()
 
+	amr.driftScanInterval = options.DriftScanInterval
+
 	var r reconcile.Reconciler = amr
 	if options.Cache != nil {
-		r = coalescing.NewReconciler(amr, options.Cache, log)
+		r = coalescing.NewReconciler(amr, amr.Client, &infrav1.AzureMachine{}, options.Cache, log)
 	}
 
 	// create mapper to transform incoming AzureClusters into AzureMachine requests
@@ -289,7 +293,10 @@ func (amr *AzureMachineReconciler) reconcileNormal(ctx context.Context, machineS
 		return reconcile.Result{}, errors.Wrap(err, "failed to create azure machine service")
 	}
 
-	if err := ams.Reconcile(ctx); err != nil {
+	err = ams.Reconcile(ctx)
+	ReportDryRun(amr.Recorder, machineScope.AzureMachine, machineScope)
+	ReportDrift(amr.Recorder, machineScope.AzureMachine, machineScope)
+	if err != nil {
 		// This means that a VM was created and managed by this controller, but is not present anymore.
 		// In this case, we mark it as failed and leave it to MHC for remediation
 		if errors.As(err, &azure.VMDeletedError{}) {
@@ -302,11 +309,18 @@ func (amr *AzureMachineReconciler) reconcileNormal(ctx context.Context, machineS
 		}
 
 		// Handle transient and terminal errors
+		category := ReportAzureError(machineScope.AzureMachine, err)
 		if errors.As(err, &reconcileError) {
 			if reconcileError.IsTerminal() {
 				amr.Recorder.Eventf(machineScope.AzureMachine, corev1.EventTypeWarning, "ReconcileError", errors.Wrapf(err, "failed to reconcile AzureMachine").Error())
 				log.Error(err, "failed to reconcile AzureMachine", "name", machineScope.Name())
-				machineScope.SetFailureReason(capierrors.CreateMachineError)
+				if category == azure.TerminalErrorCategory {
+					// Azure rejected the spec of the AzureMachine, e.g. because of an invalid parameter or a VM size
+					// which is not available in the location.
+					machineScope.SetFailureReason(capierrors.InvalidConfigurationMachineError)
+				} else {
+					machineScope.SetFailureReason(capierrors.CreateMachineError)
+				}
 				machineScope.SetFailureMessage(err)
 				machineScope.SetNotReady()
 				machineScope.SetVMState(infrav1.Failed)
@@ -328,12 +342,14 @@ func (amr *AzureMachineReconciler) reconcileNormal(ctx context.Context, machineS
 
 	machineScope.SetReady()
 
-	return reconcile.Result{}, nil
+	// Reconcile again to check the Azure resources for changes made outside of CAPZ.
+	return reconcile.Result{RequeueAfter: amr.driftScanInterval}, nil
 }
 
 func (amr *AzureMachineReconciler) reconcileDelete(ctx context.Context, machineScope *scope.MachineScope, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.AzureMachineReconciler.reconcileDelete")
 	defer done()
+	ctx = ratelimit.WithPriority(ctx)
 
 	log.Info("Handling deleted AzureMachine")
 	conditions.MarkFalse(machineScope.AzureMachine, infrav1.VMRunningCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
@@ -348,7 +364,9 @@ func (amr *AzureMachineReconciler) reconcileDelete(ctx context.Context, machineS
 			return reconcile.Result{}, errors.Wrap(err, "failed to create azure machine service")
 		}
 
-		if err := ams.Delete(ctx); err != nil {
+		err = ams.Delete(ctx)
+		ReportDryRun(amr.Recorder, machineScope.AzureMachine, machineScope)
+		if err != nil {
 			// Handle transient errors
 			var reconcileError azure.ReconcileError
 			if errors.As(err, &reconcileError) {
@@ -41,6 +41,8 @@ type azureMachineService struct {
 	// services is the list of services to be reconciled.
 	// The order of the services is important as it determines the order in which the services are reconciled.
 	services []azure.ServiceReconciler
+	// adopter brings the existing VM the AzureMachine adopts under the management of CAPZ before the services are reconciled.
+	adopter  azure.Adopter
 	skuCache *resourceskus.Cache
 }
 
@@ -51,6 +53,7 @@ func newAzureMachineService(machineScope *scope.MachineScope) (*azureMachineServ
 		return nil, errors.Wrap(err, "failed creating a NewCache")
 	}
 
+	vmSvc := virtualmachines.New(machineScope)
 	return &azureMachineService{
 		scope: machineScope,
 		services: []azure.ServiceReconciler{
@@ -59,11 +62,12 @@ func newAzureMachineService(machineScope *scope.MachineScope) (*azureMachineServ
 			networkinterfaces.New(machineScope, cache),
 			availabilitysets.New(machineScope, cache),
 			disks.New(machineScope),
-			virtualmachines.New(machineScope),
+			vmSvc,
 			roleassignments.New(machineScope),
 			vmextensions.New(machineScope),
 			tags.New(machineScope),
 		},
+		adopter:  vmSvc,
 		skuCache: cache,
 	}, nil
 }
@@ -77,6 +81,12 @@ func (s *azureMachineService) Reconcile(ctx context.Context) error {
 		return errors.Wrap(err, "failed defaulting subnet name")
 	}
 
+	if s.scope.IsAdopting() {
+		if err := s.adopter.Adopt(ctx); err != nil {
+			return errors.Wrap(err, "failed to adopt virtual machine")
+		}
+	}
+
 	for _, service := range s.services {
 		if err := service.Reconcile(ctx); err != nil {
 			return errors.Wrapf(err, "failed to reconcile AzureMachine service %s", service.Name())
@@ -24,6 +24,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
@@ -104,6 +105,76 @@ func TestAzureMachineServiceReconcile(t *testing.T) {
 	}
 }
 
+func TestAzureMachineServiceReconcileAdopt(t *testing.T) {
+	cases := map[string]struct {
+		expectedError string
+		expect        func(adopter *mock_azure.MockAdopterMockRecorder, svc *mock_azure.MockServiceReconcilerMockRecorder)
+	}{
+		"the VM is adopted before the services are reconciled": {
+			expectedError: "",
+			expect: func(adopter *mock_azure.MockAdopterMockRecorder, svc *mock_azure.MockServiceReconcilerMockRecorder) {
+				gomock.InOrder(
+					adopter.Adopt(gomockinternal.AContext()).Return(nil),
+					svc.Reconcile(gomockinternal.AContext()).Return(nil))
+			},
+		},
+		"the services are not reconciled when the VM cannot be adopted": {
+			expectedError: "failed to adopt virtual machine: some error happened",
+			expect: func(adopter *mock_azure.MockAdopterMockRecorder, svc *mock_azure.MockServiceReconcilerMockRecorder) {
+				adopter.Adopt(gomockinternal.AContext()).Return(errors.New("some error happened"))
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			adopterMock := mock_azure.NewMockAdopter(mockCtrl)
+			svcMock := mock_azure.NewMockServiceReconciler(mockCtrl)
+
+			tc.expect(adopterMock.EXPECT(), svcMock.EXPECT())
+
+			s := &azureMachineService{
+				scope: &scope.MachineScope{
+					ClusterScoper: &scope.ClusterScope{
+						AzureCluster: &infrav1.AzureCluster{},
+						Cluster:      &clusterv1.Cluster{},
+					},
+					Machine: &clusterv1.Machine{},
+					AzureMachine: &infrav1.AzureMachine{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{
+								infrav1.AdoptAnnotation: "true",
+							},
+						},
+						Spec: infrav1.AzureMachineSpec{
+							SubnetName: "test-subnet",
+						},
+					},
+				},
+				services: []azure.ServiceReconciler{
+					svcMock,
+				},
+				adopter:  adopterMock,
+				skuCache: resourceskus.NewStaticCache([]compute.ResourceSku{}, ""),
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestAzureMachineServiceDelete(t *testing.T) {
 	cases := map[string]struct {
 		expectedError string
@@ -22,6 +22,8 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -33,12 +35,15 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
+	azurerecord "sigs.k8s.io/cluster-api-provider-azure/pkg/record"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -68,6 +73,9 @@ type (
 	Options struct {
 		controller.Options
 		Cache *coalescing.ReconcileCache
+		// DriftScanInterval is the interval at which the Azure resources of reconciled objects are checked for changes
+		// made outside of CAPZ. 0 only checks them when the objects are reconciled, at least once per sync period.
+		DriftScanInterval time.Duration
 	}
 )
 
@@ -661,3 +669,78 @@ func RemoveClusterIdentityFinalizer(ctx context.Context, c client.Client, object
 	}
 	return nil
 }
+
+// ReportDryRun reports the changes planned by the services of a scope in dry-run mode in the ChangesApplied condition
+// and in an event listing each of them. The condition is removed when the object is not in dry-run mode.
+func ReportDryRun(recorder record.EventRecorder, obj conditions.Setter, dryRunner async.DryRunner) {
+	if !dryRunner.IsDryRun() {
+		conditions.Delete(obj, infrav1.ChangesAppliedCondition)
+		return
+	}
+
+	changes := dryRunner.PlannedChanges()
+	if len(changes) == 0 {
+		conditions.MarkTrue(obj, infrav1.ChangesAppliedCondition)
+		return
+	}
+
+	conditions.MarkFalse(obj, infrav1.ChangesAppliedCondition, infrav1.DryRunReason, clusterv1.ConditionSeverityInfo, "%d changes were planned but not applied in dry-run mode", len(changes))
+	plan, err := json.Marshal(struct {
+		Changes []azure.PlannedChange `json:"changes"`
+	}{changes})
+	if err != nil {
+		recorder.Eventf(obj, corev1.EventTypeWarning, "DryRunFailed", "failed to marshal the planned changes: %v", err)
+		return
+	}
+	recorder.Event(obj, corev1.EventTypeNormal, "DryRun", string(plan))
+}
+
+// ReportDrift reports the changes made outside of CAPZ to the Azure resources of a scope in the DriftDetected
+// condition and in an event listing each of them. The condition is removed when no change was detected.
+func ReportDrift(recorder record.EventRecorder, obj conditions.Setter, reporter async.DriftReporter) {
+	drifts := reporter.Drifts()
+	if len(drifts) == 0 {
+		conditions.Delete(obj, infrav1.DriftDetectedCondition)
+		return
+	}
+
+	reason, eventType := infrav1.DriftRemediatedReason, corev1.EventTypeNormal
+	details := make([]string, 0, len(drifts))
+	for _, drift := range drifts {
+		state := "reverted"
+		if !drift.Remediated {
+			reason, eventType = infrav1.DriftReportedReason, corev1.EventTypeWarning
+			state = "not reverted"
+		}
+		details = append(details, fmt.Sprintf("%s %s/%s (%s): %s", drift.Service, drift.ResourceGroup, drift.Resource, state, strings.Join(drift.Fields, ", ")))
+	}
+	conditions.Set(obj, &clusterv1.Condition{
+		Type:    infrav1.DriftDetectedCondition,
+		Status:  corev1.ConditionTrue,
+		Reason:  reason,
+		Message: fmt.Sprintf("%d Azure resources were modified outside of CAPZ: %s", len(drifts), strings.Join(details, "; ")),
+	})
+
+	report, err := json.Marshal(struct {
+		Drifts []azure.ResourceDrift `json:"drifts"`
+	}{drifts})
+	if err != nil {
+		recorder.Eventf(obj, corev1.EventTypeWarning, "DriftReportFailed", "failed to marshal the detected drift: %v", err)
+		return
+	}
+	recorder.Event(obj, eventType, reason, string(report))
+}
+
+// ReportAzureError emits a warning event for an error returned by Azure which cannot be recovered from or needs an
+// action from the user, such as a policy denial or an exhausted quota, so that it is visible without reading the
+// controller logs. It returns the category of the error.
+func ReportAzureError(obj runtime.Object, err error) azure.ErrorCategory {
+	category := azure.ClassifyError(err)
+	switch category {
+	case azure.TerminalErrorCategory:
+		azurerecord.Warnf(obj, "Failed", "Azure rejected the request with error code %s, the spec needs to be fixed: %v", azure.ErrorCode(err), err)
+	case azure.UserActionableErrorCategory:
+		azurerecord.Warnf(obj, "Blocked", "Azure rejected the request with error code %s, it will be retried after %s once the cause is addressed: %v", azure.ErrorCode(err), reconciler.DefaultUserActionableErrorRequeue, err)
+	}
+	return category
+}
@@ -35,10 +35,14 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/internal/test/mock_log"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -761,3 +765,140 @@ func Test_deprecatedClusterIdentityFinalizer(t *testing.T) {
 		})
 	}
 }
+
+func TestReportDryRun(t *testing.T) {
+	tests := []struct {
+		name           string
+		expect         func(d *mock_async.MockDryRunnerMockRecorder)
+		existing       *clusterv1.Condition
+		expectedStatus corev1.ConditionStatus
+		expectedEvent  string
+	}{
+		{
+			name: "condition is removed when not in dry-run mode",
+			expect: func(d *mock_async.MockDryRunnerMockRecorder) {
+				d.IsDryRun().Return(false)
+			},
+			existing: conditions.TrueCondition(infrav1.ChangesAppliedCondition),
+		},
+		{
+			name: "no planned changes",
+			expect: func(d *mock_async.MockDryRunnerMockRecorder) {
+				d.IsDryRun().Return(true)
+				d.PlannedChanges().Return(nil)
+			},
+			expectedStatus: corev1.ConditionTrue,
+		},
+		{
+			name: "planned changes are reported",
+			expect: func(d *mock_async.MockDryRunnerMockRecorder) {
+				d.IsDryRun().Return(true)
+				d.PlannedChanges().Return([]azure.PlannedChange{
+					{Service: "virtualnetworks", ResourceGroup: "my-rg", Resource: "my-vnet", Action: azure.PlannedUpdate, ChangedFields: []string{"tags.foo"}},
+					{Service: "subnets", ResourceGroup: "my-rg", Resource: "my-subnet", Action: azure.PlannedCreate},
+				})
+			},
+			expectedStatus: corev1.ConditionFalse,
+			expectedEvent: `Normal DryRun {"changes":[` +
+				`{"service":"virtualnetworks","resourceGroup":"my-rg","resource":"my-vnet","action":"update","changedFields":["tags.foo"]},` +
+				`{"service":"subnets","resourceGroup":"my-rg","resource":"my-subnet","action":"create"}]}`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			dryRunner := mock_async.NewMockDryRunner(mockCtrl)
+			tt.expect(dryRunner.EXPECT())
+
+			azureCluster := &infrav1.AzureCluster{}
+			if tt.existing != nil {
+				conditions.Set(azureCluster, tt.existing)
+			}
+			recorder := record.NewFakeRecorder(1)
+
+			ReportDryRun(recorder, azureCluster, dryRunner)
+
+			if tt.expectedStatus == "" {
+				g.Expect(conditions.Has(azureCluster, infrav1.ChangesAppliedCondition)).To(BeFalse())
+			} else {
+				g.Expect(conditions.Get(azureCluster, infrav1.ChangesAppliedCondition).Status).To(Equal(tt.expectedStatus))
+			}
+			if tt.expectedEvent == "" {
+				g.Expect(recorder.Events).To(BeEmpty())
+			} else {
+				g.Expect(recorder.Events).To(Receive(Equal(tt.expectedEvent)))
+				g.Expect(conditions.GetReason(azureCluster, infrav1.ChangesAppliedCondition)).To(Equal(infrav1.DryRunReason))
+			}
+		})
+	}
+}
+
+func TestReportDrift(t *testing.T) {
+	tests := []struct {
+		name            string
+		drifts          []azure.ResourceDrift
+		existing        *clusterv1.Condition
+		expectedReason  string
+		expectedMessage string
+		expectedEvent   string
+	}{
+		{
+			name:     "condition is removed when no drift was detected",
+			existing: conditions.TrueCondition(infrav1.DriftDetectedCondition),
+		},
+		{
+			name: "remediated drift is reported",
+			drifts: []azure.ResourceDrift{
+				{Service: "securitygroups", ResourceGroup: "my-rg", Resource: "my-nsg", Fields: []string{"securityRules.allow_ssh.properties.sourceAddressPrefix"}, Remediated: true},
+			},
+			expectedReason:  infrav1.DriftRemediatedReason,
+			expectedMessage: "1 Azure resources were modified outside of CAPZ: securitygroups my-rg/my-nsg (reverted): securityRules.allow_ssh.properties.sourceAddressPrefix",
+			expectedEvent: `Normal DriftRemediated {"drifts":[` +
+				`{"service":"securitygroups","resourceGroup":"my-rg","resource":"my-nsg","fields":["securityRules.allow_ssh.properties.sourceAddressPrefix"],"remediated":true}]}`,
+		},
+		{
+			name: "drift which is not remediated is reported as a warning",
+			drifts: []azure.ResourceDrift{
+				{Service: "securitygroups", ResourceGroup: "my-rg", Resource: "my-nsg", Fields: []string{"securityRules.allow_ssh"}, Remediated: true},
+				{Service: "subnets", ResourceGroup: "my-rg", Resource: "my-subnet", Fields: []string{"properties.networkSecurityGroup.id", "properties.routeTable.id"}},
+			},
+			expectedReason: infrav1.DriftReportedReason,
+			expectedMessage: "2 Azure resources were modified outside of CAPZ: securitygroups my-rg/my-nsg (reverted): securityRules.allow_ssh; " +
+				"subnets my-rg/my-subnet (not reverted): properties.networkSecurityGroup.id, properties.routeTable.id",
+			expectedEvent: `Warning DriftReported {"drifts":[` +
+				`{"service":"securitygroups","resourceGroup":"my-rg","resource":"my-nsg","fields":["securityRules.allow_ssh"],"remediated":true},` +
+				`{"service":"subnets","resourceGroup":"my-rg","resource":"my-subnet","fields":["properties.networkSecurityGroup.id","properties.routeTable.id"],"remediated":false}]}`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			reporter := mock_async.NewMockDriftReporter(mockCtrl)
+			reporter.EXPECT().Drifts().Return(tt.drifts)
+
+			azureCluster := &infrav1.AzureCluster{}
+			if tt.existing != nil {
+				conditions.Set(azureCluster, tt.existing)
+			}
+			recorder := record.NewFakeRecorder(1)
+
+			ReportDrift(recorder, azureCluster, reporter)
+
+			if tt.expectedReason == "" {
+				g.Expect(conditions.Has(azureCluster, infrav1.DriftDetectedCondition)).To(BeFalse())
+				g.Expect(recorder.Events).To(BeEmpty())
+				return
+			}
+			g.Expect(conditions.IsTrue(azureCluster, infrav1.DriftDetectedCondition)).To(BeTrue())
+			g.Expect(conditions.GetReason(azureCluster, infrav1.DriftDetectedCondition)).To(Equal(tt.expectedReason))
+			g.Expect(conditions.GetMessage(azureCluster, infrav1.DriftDetectedCondition)).To(Equal(tt.expectedMessage))
+			g.Expect(recorder.Events).To(Receive(Equal(tt.expectedEvent)))
+		})
+	}
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+// serviceGraph runs the services of a controller in the order given by the dependencies they declare, running
+// services that do not depend on each other concurrently.
+type serviceGraph struct {
+	services []azure.ServiceReconciler
+	names    []string
+	// dependencies holds, for each service, the indices of the services it depends on.
+	dependencies [][]int
+	// dependents holds, for each service, the indices of the services that depend on it.
+	dependents [][]int
+}
+
+// newServiceGraph builds the dependency graph of the given services. A service that implements
+// azure.DependentServiceReconciler depends on the services it names which are part of the graph. Any other service
+// depends on all of the services listed before it, so it is only run once they are done.
+func newServiceGraph(services []azure.ServiceReconciler) (*serviceGraph, error) {
+	g := &serviceGraph{
+		services:     services,
+		names:        make([]string, len(services)),
+		dependencies: make([][]int, len(services)),
+		dependents:   make([][]int, len(services)),
+	}
+
+	index := make(map[string]int, len(services))
+	for i, service := range services {
+		g.names[i] = service.Name()
+		if _, ok := index[g.names[i]]; ok {
+			return nil, errors.Errorf("service %s is listed more than once", g.names[i])
+		}
+		index[g.names[i]] = i
+	}
+
+	for i, service := range services {
+		dependent, ok := service.(azure.DependentServiceReconciler)
+		if !ok {
+			for j := 0; j < i; j++ {
+				g.addDependency(i, j)
+			}
+			continue
+		}
+		for _, name := range dependent.DependsOn() {
+			if j, ok := index[name]; ok {
+				g.addDependency(i, j)
+			}
+		}
+	}
+
+	if err := g.checkAcyclic(); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// addDependency records that the service at index i depends on the service at index j.
+func (g *serviceGraph) addDependency(i, j int) {
+	g.dependencies[i] = append(g.dependencies[i], j)
+	g.dependents[j] = append(g.dependents[j], i)
+}
+
+// checkAcyclic returns an error if the services have a circular dependency.
+func (g *serviceGraph) checkAcyclic() error {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(g.services))
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case visiting:
+			return errors.Errorf("service %s has a circular dependency", g.names[i])
+		case visited:
+			return nil
+		}
+		state[i] = visiting
+		for _, j := range g.dependencies[i] {
+			if err := visit(j); err != nil {
+				return err
+			}
+		}
+		state[i] = visited
+		return nil
+	}
+
+	for i := range g.services {
+		if err := visit(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reconcile calls fn for every service once all the services it depends on have succeeded. Services depending on a
+// service that failed are skipped.
+func (g *serviceGraph) Reconcile(ctx context.Context, fn func(context.Context, azure.ServiceReconciler) error) error {
+	return g.run(ctx, g.dependencies, fn)
+}
+
+// Delete calls fn for every service once all the services depending on it have succeeded, which is the reverse of
+// the order used by Reconcile. Services that a failed service depends on are skipped.
+func (g *serviceGraph) Delete(ctx context.Context, fn func(context.Context, azure.ServiceReconciler) error) error {
+	return g.run(ctx, g.dependents, fn)
+}
+
+// run calls fn for every service in its own goroutine, once all the services in waitFor for that service have
+// succeeded, and returns the combined errors.
+func (g *serviceGraph) run(ctx context.Context, waitFor [][]int, fn func(context.Context, azure.ServiceReconciler) error) error {
+	done := make([]chan struct{}, len(g.services))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+	// succeeded is written by each goroutine before it closes its done channel, and only read by others after it is
+	// closed.
+	succeeded := make([]bool, len(g.services))
+	errs := make([]error, len(g.services))
+
+	var wg sync.WaitGroup
+	for i := range g.services {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer close(done[i])
+
+			for _, j := range waitFor[i] {
+				<-done[j]
+				if !succeeded[j] {
+					return
+				}
+			}
+
+			if err := fn(ctx, g.services[i]); err != nil {
+				errs[i] = err
+				return
+			}
+			succeeded[i] = true
+		}(i)
+	}
+	wg.Wait()
+
+	return aggregateServiceErrors(errs)
+}
+
+// aggregateServiceErrors combines the errors of services run concurrently into one error so that the controller
+// handles it the same way it would handle the errors one at a time. The result is:
+//   - the error itself if there is only one,
+//   - an aggregate of all errors if any of them is not an azure.ReconcileError, as it needs to be retried with backoff,
+//   - a terminal azure.ReconcileError if any of them is terminal, or
+//   - a transient azure.ReconcileError requeued after the shortest requeue time of the transient errors.
+func aggregateServiceErrors(errs []error) error {
+	var all []error
+	for _, err := range errs {
+		if err != nil {
+			all = append(all, err)
+		}
+	}
+	switch len(all) {
+	case 0:
+		return nil
+	case 1:
+		return all[0]
+	}
+
+	var (
+		terminal     bool
+		transient    bool
+		requeueAfter time.Duration
+	)
+	for _, err := range all {
+		var reconcileError azure.ReconcileError
+		if !errors.As(err, &reconcileError) || (!reconcileError.IsTerminal() && !reconcileError.IsTransient()) {
+			return kerrors.NewAggregate(all)
+		}
+		if reconcileError.IsTerminal() {
+			terminal = true
+		} else if !transient || reconcileError.RequeueAfter() < requeueAfter {
+			transient = true
+			requeueAfter = reconcileError.RequeueAfter()
+		}
+	}
+
+	if terminal {
+		return azure.WithTerminalError(kerrors.NewAggregate(all))
+	}
+	return azure.WithTransientError(kerrors.NewAggregate(all), requeueAfter)
+}
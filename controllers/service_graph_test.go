@@ -0,0 +1,266 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+func newDependentServiceMock(mockCtrl *gomock.Controller, name string, dependsOn ...string) *mock_azure.MockDependentServiceReconciler {
+	svc := mock_azure.NewMockDependentServiceReconciler(mockCtrl)
+	svc.EXPECT().Name().Return(name).AnyTimes()
+	svc.EXPECT().DependsOn().Return(dependsOn).AnyTimes()
+	return svc
+}
+
+func TestNewServiceGraph(t *testing.T) {
+	cases := map[string]struct {
+		services      func(mockCtrl *gomock.Controller) []azure.ServiceReconciler
+		expectedError string
+	}{
+		"dependencies outside of the graph are ignored": {
+			services: func(mockCtrl *gomock.Controller) []azure.ServiceReconciler {
+				return []azure.ServiceReconciler{
+					newDependentServiceMock(mockCtrl, "one", "unknown"),
+					newDependentServiceMock(mockCtrl, "two", "one"),
+				}
+			},
+			expectedError: "",
+		},
+		"circular dependency": {
+			services: func(mockCtrl *gomock.Controller) []azure.ServiceReconciler {
+				return []azure.ServiceReconciler{
+					newDependentServiceMock(mockCtrl, "one", "three"),
+					newDependentServiceMock(mockCtrl, "two", "one"),
+					newDependentServiceMock(mockCtrl, "three", "two"),
+				}
+			},
+			expectedError: "service one has a circular dependency",
+		},
+		"duplicate service": {
+			services: func(mockCtrl *gomock.Controller) []azure.ServiceReconciler {
+				return []azure.ServiceReconciler{
+					newDependentServiceMock(mockCtrl, "one"),
+					newDependentServiceMock(mockCtrl, "one"),
+				}
+			},
+			expectedError: "service one is listed more than once",
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			_, err := newServiceGraph(tc.services(mockCtrl))
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestServiceGraphReconcile(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	// one and two do not depend on each other, so each of them can only finish once the other has started.
+	var started sync.WaitGroup
+	started.Add(2)
+	waitForBoth := func(context.Context) error {
+		started.Done()
+		waited := make(chan struct{})
+		go func() {
+			started.Wait()
+			close(waited)
+		}()
+		select {
+		case <-waited:
+			return nil
+		case <-time.After(10 * time.Second):
+			return errors.New("services were not reconciled concurrently")
+		}
+	}
+
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+	record := func(name string) {
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, name)
+	}
+
+	one := newDependentServiceMock(mockCtrl, "one")
+	two := newDependentServiceMock(mockCtrl, "two")
+	three := newDependentServiceMock(mockCtrl, "three", "one", "two")
+	four := newDependentServiceMock(mockCtrl, "four", "three", "failing")
+	failing := newDependentServiceMock(mockCtrl, "failing", "one")
+	one.EXPECT().Reconcile(gomockinternal.AContext()).DoAndReturn(func(ctx context.Context) error {
+		defer record("one")
+		return waitForBoth(ctx)
+	})
+	two.EXPECT().Reconcile(gomockinternal.AContext()).DoAndReturn(func(ctx context.Context) error {
+		defer record("two")
+		return waitForBoth(ctx)
+	})
+	three.EXPECT().Reconcile(gomockinternal.AContext()).DoAndReturn(func(ctx context.Context) error {
+		record("three")
+		return nil
+	})
+	failing.EXPECT().Reconcile(gomockinternal.AContext()).Return(errors.New("some error happened"))
+	// four depends on a failed service, so it is never reconciled.
+
+	graph, err := newServiceGraph([]azure.ServiceReconciler{four, three, two, one, failing})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	err = graph.Reconcile(context.TODO(), func(ctx context.Context, service azure.ServiceReconciler) error {
+		return service.Reconcile(ctx)
+	})
+	g.Expect(err).To(MatchError("some error happened"))
+	g.Expect(order).To(HaveLen(3))
+	g.Expect(order[:2]).To(ConsistOf("one", "two"))
+	g.Expect(order[2]).To(Equal("three"))
+}
+
+func TestServiceGraphReconcileInOrderWithoutDependencies(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	one := mock_azure.NewMockServiceReconciler(mockCtrl)
+	two := mock_azure.NewMockServiceReconciler(mockCtrl)
+	three := newDependentServiceMock(mockCtrl, "three")
+	one.EXPECT().Name().Return("one").AnyTimes()
+	two.EXPECT().Name().Return("two").AnyTimes()
+	gomock.InOrder(
+		one.EXPECT().Reconcile(gomockinternal.AContext()).Return(nil),
+		two.EXPECT().Reconcile(gomockinternal.AContext()).Return(nil))
+	three.EXPECT().Reconcile(gomockinternal.AContext()).Return(nil)
+
+	graph, err := newServiceGraph([]azure.ServiceReconciler{one, two, three})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	err = graph.Reconcile(context.TODO(), func(ctx context.Context, service azure.ServiceReconciler) error {
+		return service.Reconcile(ctx)
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestServiceGraphDelete(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	one := newDependentServiceMock(mockCtrl, "one")
+	two := newDependentServiceMock(mockCtrl, "two", "one")
+	three := newDependentServiceMock(mockCtrl, "three", "two")
+	four := newDependentServiceMock(mockCtrl, "four", "one")
+	notDone := azure.WithTransientError(errors.New("deleting"), 15*time.Second)
+	gomock.InOrder(
+		three.EXPECT().Delete(gomockinternal.AContext()).Return(nil),
+		two.EXPECT().Delete(gomockinternal.AContext()).Return(nil))
+	four.EXPECT().Delete(gomockinternal.AContext()).Return(notDone)
+	// one is still depended on by four, which is not deleted yet, so it is not deleted.
+
+	graph, err := newServiceGraph([]azure.ServiceReconciler{one, two, three, four})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	err = graph.Delete(context.TODO(), func(ctx context.Context, service azure.ServiceReconciler) error {
+		return service.Delete(ctx)
+	})
+	g.Expect(err).To(MatchError(notDone))
+}
+
+func TestAggregateServiceErrors(t *testing.T) {
+	cases := map[string]struct {
+		errs          []error
+		expectedError string
+		expect        func(g *WithT, err error)
+	}{
+		"no errors": {
+			errs:          []error{nil, nil},
+			expectedError: "",
+		},
+		"single error is returned as is": {
+			errs:          []error{nil, azure.WithTransientError(errors.New("one"), 15*time.Second)},
+			expectedError: "one. Object will be requeued after 15s",
+		},
+		"errors that are not reconcile errors are aggregated": {
+			errs:          []error{azure.WithTransientError(errors.New("one"), 15*time.Second), errors.New("two")},
+			expectedError: "[one. Object will be requeued after 15s, two]",
+			expect: func(g *WithT, err error) {
+				g.Expect(errors.As(err, &azure.ReconcileError{})).To(BeFalse())
+			},
+		},
+		"a terminal error makes the aggregate terminal": {
+			errs:          []error{azure.WithTransientError(errors.New("one"), 15*time.Second), azure.WithTerminalError(errors.New("two"))},
+			expectedError: "reconcile error that cannot be recovered occurred: [one. Object will be requeued after 15s, reconcile error that cannot be recovered occurred: two. Object will not be requeued]. Object will not be requeued",
+			expect: func(g *WithT, err error) {
+				var reconcileError azure.ReconcileError
+				g.Expect(errors.As(err, &reconcileError)).To(BeTrue())
+				g.Expect(reconcileError.IsTerminal()).To(BeTrue())
+			},
+		},
+		"transient errors are requeued after the shortest time": {
+			errs:          []error{azure.WithTransientError(errors.New("one"), 30*time.Second), azure.WithTransientError(errors.New("two"), 15*time.Second)},
+			expectedError: "[one. Object will be requeued after 30s, two. Object will be requeued after 15s]. Object will be requeued after 15s",
+			expect: func(g *WithT, err error) {
+				var reconcileError azure.ReconcileError
+				g.Expect(errors.As(err, &reconcileError)).To(BeTrue())
+				g.Expect(reconcileError.IsTransient()).To(BeTrue())
+				g.Expect(reconcileError.RequeueAfter()).To(Equal(15 * time.Second))
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			err := aggregateServiceErrors(tc.errs)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			if tc.expect != nil {
+				tc.expect(g, err)
+			}
+		})
+	}
+}
@@ -96,6 +96,9 @@ func (src *AzureMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.Template.SpotVMOptions.EvictionPolicy = restored.Spec.Template.SpotVMOptions.EvictionPolicy
 	}
 
+	restoreAzureMachinePoolSecuritySettings(&restored.Spec.Template, &dst.Spec.Template)
+	restoreAzureMachinePoolDiskSettings(&restored.Spec.Template, &dst.Spec.Template)
+
 	return nil
 }
 
@@ -168,3 +171,38 @@ func (dst *AzureMachinePoolList) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*infrav1exp.AzureMachinePoolList)
 	return Convert_v1beta1_AzureMachinePoolList_To_v1alpha3_AzureMachinePoolList(src, dst, nil)
 }
+
+// restoreAzureMachinePoolSecuritySettings restores the security type, UEFI settings and managed disk
+// security profiles which do not exist in v1alpha3.
+func restoreAzureMachinePoolSecuritySettings(restored, dst *infrav1exp.AzureMachinePoolMachineTemplate) {
+	if restored.SecurityProfile != nil && dst.SecurityProfile != nil {
+		dst.SecurityProfile.SecurityType = restored.SecurityProfile.SecurityType
+		dst.SecurityProfile.UefiSettings = restored.SecurityProfile.UefiSettings
+	}
+
+	if restored.OSDisk.ManagedDisk != nil && dst.OSDisk.ManagedDisk != nil {
+		dst.OSDisk.ManagedDisk.SecurityProfile = restored.OSDisk.ManagedDisk.SecurityProfile
+	}
+	for i := range dst.DataDisks {
+		if i < len(restored.DataDisks) && restored.DataDisks[i].ManagedDisk != nil && dst.DataDisks[i].ManagedDisk != nil {
+			dst.DataDisks[i].ManagedDisk.SecurityProfile = restored.DataDisks[i].ManagedDisk.SecurityProfile
+		}
+	}
+}
+
+// restoreAzureMachinePoolDiskSettings restores the ephemeral OS disk placement and the data disk
+// performance and delete settings which do not exist in v1alpha3.
+func restoreAzureMachinePoolDiskSettings(restored, dst *infrav1exp.AzureMachinePoolMachineTemplate) {
+	if restored.OSDisk.DiffDiskSettings != nil && dst.OSDisk.DiffDiskSettings != nil {
+		dst.OSDisk.DiffDiskSettings.Placement = restored.OSDisk.DiffDiskSettings.Placement
+	}
+
+	for i := range dst.DataDisks {
+		if i < len(restored.DataDisks) {
+			dst.DataDisks[i].DiskIOPSReadWrite = restored.DataDisks[i].DiskIOPSReadWrite
+			dst.DataDisks[i].DiskMBpsReadWrite = restored.DataDisks[i].DiskMBpsReadWrite
+			dst.DataDisks[i].LogicalSectorSize = restored.DataDisks[i].LogicalSectorSize
+			dst.DataDisks[i].DeletePolicy = restored.DataDisks[i].DeletePolicy
+		}
+	}
+}
@@ -48,9 +48,20 @@ func (src *AzureManagedMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.EnableNodePublicIP = restored.Spec.EnableNodePublicIP
 	dst.Spec.NodePublicIPPrefixID = restored.Spec.NodePublicIPPrefixID
 	dst.Spec.ScaleSetPriority = restored.Spec.ScaleSetPriority
+	dst.Spec.NodeSubnetName = restored.Spec.NodeSubnetName
+	dst.Spec.PodSubnetName = restored.Spec.PodSubnetName
+	dst.Spec.KubeletConfig = restored.Spec.KubeletConfig
+	dst.Spec.LinuxOSConfig = restored.Spec.LinuxOSConfig
+	dst.Spec.UpgradeSettings = restored.Spec.UpgradeSettings
+	dst.Spec.SpotMaxPrice = restored.Spec.SpotMaxPrice
+	dst.Spec.OSSKU = restored.Spec.OSSKU
+	dst.Spec.EnableEncryptionAtHost = restored.Spec.EnableEncryptionAtHost
+	dst.Spec.ProximityPlacementGroupID = restored.Spec.ProximityPlacementGroupID
+	dst.Spec.EnableFIPS = restored.Spec.EnableFIPS
 
 	dst.Status.LongRunningOperationStates = restored.Status.LongRunningOperationStates
 	dst.Status.Conditions = restored.Status.Conditions
+	dst.Status.Version = restored.Status.Version
 
 	return nil
 }
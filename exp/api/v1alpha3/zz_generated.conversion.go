@@ -211,6 +211,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*clusterapiproviderazureapiv1alpha3.DataDisk)(nil), (*clusterapiproviderazureapiv1beta1.DataDisk)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_DataDisk_To_v1beta1_DataDisk(a.(*clusterapiproviderazureapiv1alpha3.DataDisk), b.(*clusterapiproviderazureapiv1beta1.DataDisk), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*clusterapiproviderazureapiv1alpha3.Image)(nil), (*clusterapiproviderazureapiv1beta1.Image)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_Image_To_v1beta1_Image(a.(*clusterapiproviderazureapiv1alpha3.Image), b.(*clusterapiproviderazureapiv1beta1.Image), scope)
 	}); err != nil {
@@ -221,6 +226,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*clusterapiproviderazureapiv1alpha3.SecurityProfile)(nil), (*clusterapiproviderazureapiv1beta1.SecurityProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_SecurityProfile_To_v1beta1_SecurityProfile(a.(*clusterapiproviderazureapiv1alpha3.SecurityProfile), b.(*clusterapiproviderazureapiv1beta1.SecurityProfile), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*clusterapiproviderazureapiv1alpha3.SpotVMOptions)(nil), (*clusterapiproviderazureapiv1beta1.SpotVMOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_SpotVMOptions_To_v1beta1_SpotVMOptions(a.(*clusterapiproviderazureapiv1alpha3.SpotVMOptions), b.(*clusterapiproviderazureapiv1beta1.SpotVMOptions), scope)
 	}); err != nil {
@@ -266,6 +276,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*clusterapiproviderazureapiv1beta1.DataDisk)(nil), (*clusterapiproviderazureapiv1alpha3.DataDisk)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DataDisk_To_v1alpha3_DataDisk(a.(*clusterapiproviderazureapiv1beta1.DataDisk), b.(*clusterapiproviderazureapiv1alpha3.DataDisk), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*clusterapiproviderazureapiv1beta1.Image)(nil), (*clusterapiproviderazureapiv1alpha3.Image)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_Image_To_v1alpha3_Image(a.(*clusterapiproviderazureapiv1beta1.Image), b.(*clusterapiproviderazureapiv1alpha3.Image), scope)
 	}); err != nil {
@@ -286,6 +301,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*clusterapiproviderazureapiv1beta1.SecurityProfile)(nil), (*clusterapiproviderazureapiv1alpha3.SecurityProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_SecurityProfile_To_v1alpha3_SecurityProfile(a.(*clusterapiproviderazureapiv1beta1.SecurityProfile), b.(*clusterapiproviderazureapiv1alpha3.SecurityProfile), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*clusterapiproviderazureapiv1beta1.SpotVMOptions)(nil), (*clusterapiproviderazureapiv1alpha3.SpotVMOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_SpotVMOptions_To_v1alpha3_SpotVMOptions(a.(*clusterapiproviderazureapiv1beta1.SpotVMOptions), b.(*clusterapiproviderazureapiv1alpha3.SpotVMOptions), scope)
 	}); err != nil {
@@ -434,11 +454,29 @@ func autoConvert_v1alpha3_AzureMachinePoolMachineTemplate_To_v1beta1_AzureMachin
 	if err := Convert_v1alpha3_OSDisk_To_v1beta1_OSDisk(&in.OSDisk, &out.OSDisk, s); err != nil {
 		return err
 	}
-	out.DataDisks = *(*[]clusterapiproviderazureapiv1beta1.DataDisk)(unsafe.Pointer(&in.DataDisks))
+	if in.DataDisks != nil {
+		in, out := &in.DataDisks, &out.DataDisks
+		*out = make([]clusterapiproviderazureapiv1beta1.DataDisk, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_DataDisk_To_v1beta1_DataDisk(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.DataDisks = nil
+	}
 	out.SSHPublicKey = in.SSHPublicKey
 	out.AcceleratedNetworking = (*bool)(unsafe.Pointer(in.AcceleratedNetworking))
 	out.TerminateNotificationTimeout = (*int)(unsafe.Pointer(in.TerminateNotificationTimeout))
-	out.SecurityProfile = (*clusterapiproviderazureapiv1beta1.SecurityProfile)(unsafe.Pointer(in.SecurityProfile))
+	if in.SecurityProfile != nil {
+		in, out := &in.SecurityProfile, &out.SecurityProfile
+		*out = new(clusterapiproviderazureapiv1beta1.SecurityProfile)
+		if err := Convert_v1alpha3_SecurityProfile_To_v1beta1_SecurityProfile(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SecurityProfile = nil
+	}
 	if in.SpotVMOptions != nil {
 		in, out := &in.SpotVMOptions, &out.SpotVMOptions
 		*out = new(clusterapiproviderazureapiv1beta1.SpotVMOptions)
@@ -470,11 +508,29 @@ func autoConvert_v1beta1_AzureMachinePoolMachineTemplate_To_v1alpha3_AzureMachin
 	if err := Convert_v1beta1_OSDisk_To_v1alpha3_OSDisk(&in.OSDisk, &out.OSDisk, s); err != nil {
 		return err
 	}
-	out.DataDisks = *(*[]clusterapiproviderazureapiv1alpha3.DataDisk)(unsafe.Pointer(&in.DataDisks))
+	if in.DataDisks != nil {
+		in, out := &in.DataDisks, &out.DataDisks
+		*out = make([]clusterapiproviderazureapiv1alpha3.DataDisk, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_DataDisk_To_v1alpha3_DataDisk(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.DataDisks = nil
+	}
 	out.SSHPublicKey = in.SSHPublicKey
 	out.AcceleratedNetworking = (*bool)(unsafe.Pointer(in.AcceleratedNetworking))
 	out.TerminateNotificationTimeout = (*int)(unsafe.Pointer(in.TerminateNotificationTimeout))
-	out.SecurityProfile = (*clusterapiproviderazureapiv1alpha3.SecurityProfile)(unsafe.Pointer(in.SecurityProfile))
+	if in.SecurityProfile != nil {
+		in, out := &in.SecurityProfile, &out.SecurityProfile
+		*out = new(clusterapiproviderazureapiv1alpha3.SecurityProfile)
+		if err := Convert_v1beta1_SecurityProfile_To_v1alpha3_SecurityProfile(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SecurityProfile = nil
+	}
 	if in.SpotVMOptions != nil {
 		in, out := &in.SpotVMOptions, &out.SpotVMOptions
 		*out = new(clusterapiproviderazureapiv1alpha3.SpotVMOptions)
@@ -794,6 +850,14 @@ func autoConvert_v1beta1_AzureManagedControlPlaneSpec_To_v1alpha3_AzureManagedCo
 	// WARNING: in.SKU requires manual conversion: does not exist in peer-type
 	// WARNING: in.LoadBalancerProfile requires manual conversion: does not exist in peer-type
 	// WARNING: in.APIServerAccessProfile requires manual conversion: does not exist in peer-type
+	// WARNING: in.AutoScalerProfile requires manual conversion: does not exist in peer-type
+	// WARNING: in.AutoUpgradeProfile requires manual conversion: does not exist in peer-type
+	// WARNING: in.MaintenanceConfigurations requires manual conversion: does not exist in peer-type
+	// WARNING: in.Identity requires manual conversion: does not exist in peer-type
+	// WARNING: in.KubeletUserAssignedIdentity requires manual conversion: does not exist in peer-type
+	// WARNING: in.OIDCIssuerProfile requires manual conversion: does not exist in peer-type
+	// WARNING: in.SecurityProfile requires manual conversion: does not exist in peer-type
+	// WARNING: in.NodePoolUpgradeSettings requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -813,6 +877,9 @@ func autoConvert_v1beta1_AzureManagedControlPlaneStatus_To_v1alpha3_AzureManaged
 	out.Initialized = in.Initialized
 	// WARNING: in.Conditions requires manual conversion: does not exist in peer-type
 	// WARNING: in.LongRunningOperationStates requires manual conversion: does not exist in peer-type
+	// WARNING: in.OIDCIssuerURL requires manual conversion: does not exist in peer-type
+	// WARNING: in.Version requires manual conversion: does not exist in peer-type
+	// WARNING: in.UpgradingNodePools requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -920,6 +987,16 @@ func autoConvert_v1beta1_AzureManagedMachinePoolSpec_To_v1alpha3_AzureManagedMac
 	// WARNING: in.EnableNodePublicIP requires manual conversion: does not exist in peer-type
 	// WARNING: in.NodePublicIPPrefixID requires manual conversion: does not exist in peer-type
 	// WARNING: in.ScaleSetPriority requires manual conversion: does not exist in peer-type
+	// WARNING: in.NodeSubnetName requires manual conversion: does not exist in peer-type
+	// WARNING: in.PodSubnetName requires manual conversion: does not exist in peer-type
+	// WARNING: in.KubeletConfig requires manual conversion: does not exist in peer-type
+	// WARNING: in.LinuxOSConfig requires manual conversion: does not exist in peer-type
+	// WARNING: in.UpgradeSettings requires manual conversion: does not exist in peer-type
+	// WARNING: in.SpotMaxPrice requires manual conversion: does not exist in peer-type
+	// WARNING: in.OSSKU requires manual conversion: does not exist in peer-type
+	// WARNING: in.EnableEncryptionAtHost requires manual conversion: does not exist in peer-type
+	// WARNING: in.ProximityPlacementGroupID requires manual conversion: does not exist in peer-type
+	// WARNING: in.EnableFIPS requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -943,6 +1020,7 @@ func autoConvert_v1beta1_AzureManagedMachinePoolStatus_To_v1alpha3_AzureManagedM
 	out.ErrorMessage = (*string)(unsafe.Pointer(in.ErrorMessage))
 	// WARNING: in.Conditions requires manual conversion: does not exist in peer-type
 	// WARNING: in.LongRunningOperationStates requires manual conversion: does not exist in peer-type
+	// WARNING: in.Version requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -961,6 +1039,7 @@ func autoConvert_v1beta1_ManagedControlPlaneSubnet_To_v1alpha3_ManagedControlPla
 	out.Name = in.Name
 	out.CIDRBlock = in.CIDRBlock
 	// WARNING: in.ServiceEndpoints requires manual conversion: does not exist in peer-type
+	// WARNING: in.RouteTable requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -984,6 +1063,7 @@ func autoConvert_v1beta1_ManagedControlPlaneVirtualNetwork_To_v1alpha3_ManagedCo
 	if err := Convert_v1beta1_ManagedControlPlaneSubnet_To_v1alpha3_ManagedControlPlaneSubnet(&in.Subnet, &out.Subnet, s); err != nil {
 		return err
 	}
+	// WARNING: in.Subnets requires manual conversion: does not exist in peer-type
 	// WARNING: in.ResourceGroup requires manual conversion: does not exist in peer-type
 	return nil
 }
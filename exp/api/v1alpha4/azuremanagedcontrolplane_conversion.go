@@ -40,6 +40,19 @@ func (src *AzureManagedControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Status.Conditions = restored.Status.Conditions
 	dst.Spec.VirtualNetwork.ResourceGroup = restored.Spec.VirtualNetwork.ResourceGroup
 	dst.Spec.VirtualNetwork.Subnet.ServiceEndpoints = restored.Spec.VirtualNetwork.Subnet.ServiceEndpoints
+	dst.Spec.VirtualNetwork.Subnet.RouteTable = restored.Spec.VirtualNetwork.Subnet.RouteTable
+	dst.Spec.VirtualNetwork.Subnets = restored.Spec.VirtualNetwork.Subnets
+	dst.Spec.AutoScalerProfile = restored.Spec.AutoScalerProfile
+	dst.Spec.AutoUpgradeProfile = restored.Spec.AutoUpgradeProfile
+	dst.Spec.MaintenanceConfigurations = restored.Spec.MaintenanceConfigurations
+	dst.Spec.Identity = restored.Spec.Identity
+	dst.Spec.KubeletUserAssignedIdentity = restored.Spec.KubeletUserAssignedIdentity
+	dst.Spec.OIDCIssuerProfile = restored.Spec.OIDCIssuerProfile
+	dst.Spec.SecurityProfile = restored.Spec.SecurityProfile
+	dst.Spec.NodePoolUpgradeSettings = restored.Spec.NodePoolUpgradeSettings
+	dst.Status.OIDCIssuerURL = restored.Status.OIDCIssuerURL
+	dst.Status.Version = restored.Status.Version
+	dst.Status.UpgradingNodePools = restored.Status.UpgradingNodePools
 
 	return nil
 }
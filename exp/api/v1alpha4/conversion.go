@@ -40,6 +40,26 @@ func Convert_v1beta1_OSDisk_To_v1alpha4_OSDisk(in *infrav1.OSDisk, out *infrav1a
 	return infrav1alpha4.Convert_v1beta1_OSDisk_To_v1alpha4_OSDisk(in, out, s)
 }
 
+// Convert_v1alpha4_DataDisk_To_v1beta1_DataDisk is a conversion function.
+func Convert_v1alpha4_DataDisk_To_v1beta1_DataDisk(in *infrav1alpha4.DataDisk, out *infrav1.DataDisk, s conversion.Scope) error {
+	return infrav1alpha4.Convert_v1alpha4_DataDisk_To_v1beta1_DataDisk(in, out, s)
+}
+
+// Convert_v1beta1_DataDisk_To_v1alpha4_DataDisk is a conversion function.
+func Convert_v1beta1_DataDisk_To_v1alpha4_DataDisk(in *infrav1.DataDisk, out *infrav1alpha4.DataDisk, s conversion.Scope) error {
+	return infrav1alpha4.Convert_v1beta1_DataDisk_To_v1alpha4_DataDisk(in, out, s)
+}
+
+// Convert_v1alpha4_SecurityProfile_To_v1beta1_SecurityProfile is a conversion function.
+func Convert_v1alpha4_SecurityProfile_To_v1beta1_SecurityProfile(in *infrav1alpha4.SecurityProfile, out *infrav1.SecurityProfile, s conversion.Scope) error {
+	return infrav1alpha4.Convert_v1alpha4_SecurityProfile_To_v1beta1_SecurityProfile(in, out, s)
+}
+
+// Convert_v1beta1_SecurityProfile_To_v1alpha4_SecurityProfile is a conversion function.
+func Convert_v1beta1_SecurityProfile_To_v1alpha4_SecurityProfile(in *infrav1.SecurityProfile, out *infrav1alpha4.SecurityProfile, s conversion.Scope) error {
+	return infrav1alpha4.Convert_v1beta1_SecurityProfile_To_v1alpha4_SecurityProfile(in, out, s)
+}
+
 // Convert_v1alpha4_Image_To_v1beta1_Image is a conversion function.
 func Convert_v1alpha4_Image_To_v1beta1_Image(in *infrav1alpha4.Image, out *infrav1.Image, s conversion.Scope) error {
 	return infrav1alpha4.Convert_v1alpha4_Image_To_v1beta1_Image(in, out, s)
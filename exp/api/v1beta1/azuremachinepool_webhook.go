@@ -87,6 +87,8 @@ func (amp *AzureMachinePool) Validate(old runtime.Object) error {
 		amp.ValidateUserAssignedIdentity,
 		amp.ValidateStrategy(),
 		amp.ValidateSystemAssignedIdentity(old),
+		amp.ValidateSecurityProfile,
+		amp.ValidateDataDisks,
 	}
 
 	var errs []error
@@ -151,6 +153,37 @@ func (amp *AzureMachinePool) ValidateUserAssignedIdentity() error {
 	return nil
 }
 
+// ValidateSecurityProfile validates the security type, UEFI settings and confidential disk encryption settings.
+func (amp *AzureMachinePool) ValidateSecurityProfile() error {
+	template := amp.Spec.Template
+	if errs := infrav1.ValidateSecurityProfile(template.SecurityProfile, template.Image, template.OSDisk, template.DataDisks, field.NewPath("securityProfile")); len(errs) > 0 {
+		return kerrors.NewAggregate(errs.ToAggregate().Errors())
+	}
+
+	return nil
+}
+
+// ValidateDataDisks validates the provisioned performance of the data disks.
+// Scale sets cannot set the logical sector size or the delete policy of their data disks.
+func (amp *AzureMachinePool) ValidateDataDisks() error {
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("dataDisks")
+	for i, disk := range amp.Spec.Template.DataDisks {
+		allErrs = append(allErrs, infrav1.ValidateDataDiskPerformance(disk, fldPath.Index(i))...)
+		if disk.LogicalSectorSize != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("logicalSectorSize"), "logicalSectorSize is not supported on AzureMachinePools"))
+		}
+		if disk.DeletePolicy != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("deletePolicy"), "deletePolicy is not supported on AzureMachinePools"))
+		}
+	}
+	if len(allErrs) > 0 {
+		return kerrors.NewAggregate(allErrs.ToAggregate().Errors())
+	}
+
+	return nil
+}
+
 // ValidateStrategy validates the strategy.
 func (amp *AzureMachinePool) ValidateStrategy() func() error {
 	return func() error {
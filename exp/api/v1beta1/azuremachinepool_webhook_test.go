@@ -144,6 +144,45 @@ func TestAzureMachinePool_ValidateCreate(t *testing.T) {
 			}),
 			wantErr: false,
 		},
+		{
+			name: "azuremachinepool with an ultra disk with provisioned performance",
+			amp: createMachinePoolWithDataDisks([]infrav1.DataDisk{
+				{
+					NameSuffix:        "etcddisk",
+					DiskSizeGB:        256,
+					Lun:               to.Int32Ptr(0),
+					ManagedDisk:       &infrav1.ManagedDiskParameters{StorageAccountType: "UltraSSD_LRS"},
+					DiskIOPSReadWrite: to.Int64Ptr(10000),
+					DiskMBpsReadWrite: to.Int64Ptr(400),
+				},
+			}),
+			wantErr: false,
+		},
+		{
+			name: "azuremachinepool with an ultra disk with a logical sector size",
+			amp: createMachinePoolWithDataDisks([]infrav1.DataDisk{
+				{
+					NameSuffix:        "etcddisk",
+					DiskSizeGB:        256,
+					Lun:               to.Int32Ptr(0),
+					ManagedDisk:       &infrav1.ManagedDiskParameters{StorageAccountType: "UltraSSD_LRS"},
+					LogicalSectorSize: to.Int32Ptr(4096),
+				},
+			}),
+			wantErr: true,
+		},
+		{
+			name: "azuremachinepool with a data disk delete policy",
+			amp: createMachinePoolWithDataDisks([]infrav1.DataDisk{
+				{
+					NameSuffix:   "datadisk",
+					DiskSizeGB:   128,
+					Lun:          to.Int32Ptr(0),
+					DeletePolicy: infrav1.DiskDeletePolicyRetain,
+				},
+			}),
+			wantErr: true,
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -381,6 +420,16 @@ func createMachinePoolWithStrategy(strategy AzureMachinePoolDeploymentStrategy)
 	}
 }
 
+func createMachinePoolWithDataDisks(dataDisks []infrav1.DataDisk) *AzureMachinePool {
+	return &AzureMachinePool{
+		Spec: AzureMachinePoolSpec{
+			Template: AzureMachinePoolMachineTemplate{
+				DataDisks: dataDisks,
+			},
+		},
+	}
+}
+
 func TestAzureMachinePool_ValidateCreateFailure(t *testing.T) {
 	g := NewWithT(t)
 
@@ -33,6 +33,13 @@ const (
 
 	// PrivateDNSZoneModeNone represents mode None for azuremanagedcontrolplane.
 	PrivateDNSZoneModeNone string = "None"
+
+	// AdoptAnnotation is set to "true" on an AzureManagedControlPlane to bring an existing AKS cluster under the
+	// management of CAPZ instead of creating it.
+	AdoptAnnotation = infrav1.AdoptAnnotation
+
+	// MaxNodePoolMinorVersionSkew is the number of minor versions an AKS node pool may be older than its control plane.
+	MaxNodePoolMinorVersionSkew = 2
 )
 
 // AzureManagedControlPlaneSpec defines the desired state of AzureManagedControlPlane.
@@ -116,6 +123,42 @@ type AzureManagedControlPlaneSpec struct {
 	// APIServerAccessProfile is the access profile for AKS API server.
 	// +optional
 	APIServerAccessProfile *APIServerAccessProfile `json:"apiServerAccessProfile,omitempty"`
+
+	// AutoScalerProfile is the parameters to be applied to the cluster-autoscaler when enabled.
+	// +optional
+	AutoScalerProfile *AutoScalerProfile `json:"autoScalerProfile,omitempty"`
+
+	// AutoUpgradeProfile is the auto upgrade configuration of the AKS cluster.
+	// +optional
+	AutoUpgradeProfile *ManagedClusterAutoUpgradeProfile `json:"autoUpgradeProfile,omitempty"`
+
+	// MaintenanceConfigurations are the planned maintenance configurations of the AKS cluster.
+	// Maintenance configurations created by CAPZ are deleted when they are removed from this list.
+	// +optional
+	MaintenanceConfigurations []MaintenanceConfiguration `json:"maintenanceConfigurations,omitempty"`
+
+	// Identity is the identity of the AKS control plane. Defaults to a system-assigned identity. Immutable.
+	// +optional
+	Identity *Identity `json:"identity,omitempty"`
+
+	// KubeletUserAssignedIdentity is the ARM resource ID of the user-assigned identity used by the kubelets of the AKS
+	// cluster, e.g. to pull images from an Azure Container Registry. Requires a user-assigned control plane identity.
+	// Defaults to an identity created by AKS in the node resource group. Immutable.
+	// +optional
+	KubeletUserAssignedIdentity string `json:"kubeletUserAssignedIdentity,omitempty"`
+
+	// OIDCIssuerProfile is the OIDC issuer profile of the AKS cluster.
+	// +optional
+	OIDCIssuerProfile *OIDCIssuerProfile `json:"oidcIssuerProfile,omitempty"`
+
+	// SecurityProfile is the security profile of the AKS cluster.
+	// +optional
+	SecurityProfile *ManagedControlPlaneSecurityProfile `json:"securityProfile,omitempty"`
+
+	// NodePoolUpgradeSettings specifies how the node pools are upgraded once the control plane runs a new Kubernetes
+	// version. The node pools are upgraded one at a time, after the control plane.
+	// +optional
+	NodePoolUpgradeSettings *ManagedControlPlaneNodePoolUpgradeSettings `json:"nodePoolUpgradeSettings,omitempty"`
 }
 
 // AADProfile - AAD integration managed by AKS.
@@ -204,12 +247,221 @@ type APIServerAccessProfile struct {
 	EnablePrivateClusterPublicFQDN *bool `json:"enablePrivateClusterPublicFQDN,omitempty"`
 }
 
+// Expander is the strategy the cluster-autoscaler uses to choose the node pool to scale up.
+// +kubebuilder:validation:Enum=least-waste;most-pods;priority;random
+type Expander string
+
+const (
+	// ExpanderLeastWaste selects the node group that will have the least idle CPU (if tied, unused memory) after scale-up.
+	ExpanderLeastWaste Expander = "least-waste"
+	// ExpanderMostPods selects the node group that would be able to schedule the most pods when scaling up.
+	ExpanderMostPods Expander = "most-pods"
+	// ExpanderPriority selects the node group that has the highest priority assigned by the user.
+	ExpanderPriority Expander = "priority"
+	// ExpanderRandom selects a random node group.
+	ExpanderRandom Expander = "random"
+)
+
+// AutoScalerProfile - parameters to be applied to the cluster-autoscaler.
+// See https://docs.microsoft.com/en-us/azure/aks/cluster-autoscaler#using-the-autoscaler-profile for the meaning and
+// default value of each parameter.
+type AutoScalerProfile struct {
+	// BalanceSimilarNodeGroups - Whether to detect similar node pools and balance the number of nodes between them.
+	// +kubebuilder:validation:Enum="true";"false"
+	// +optional
+	BalanceSimilarNodeGroups *string `json:"balanceSimilarNodeGroups,omitempty"`
+	// Expander - The expander to use when scaling up.
+	// +optional
+	Expander *Expander `json:"expander,omitempty"`
+	// MaxEmptyBulkDelete - The maximum number of empty nodes that can be deleted at the same time.
+	// +kubebuilder:validation:Pattern=`^(\d+)$`
+	// +optional
+	MaxEmptyBulkDelete *string `json:"maxEmptyBulkDelete,omitempty"`
+	// MaxGracefulTerminationSec - The maximum number of seconds the cluster-autoscaler waits for pod termination when trying to scale down a node.
+	// +kubebuilder:validation:Pattern=`^(\d+)$`
+	// +optional
+	MaxGracefulTerminationSec *string `json:"maxGracefulTerminationSec,omitempty"`
+	// MaxNodeProvisionTime - The maximum time the cluster-autoscaler waits for a node to be provisioned, e.g. 15m.
+	// +kubebuilder:validation:Pattern=`^(\d+)m$`
+	// +optional
+	MaxNodeProvisionTime *string `json:"maxNodeProvisionTime,omitempty"`
+	// MaxTotalUnreadyPercentage - The maximum percentage of unready nodes in the cluster, between 0 and 100.
+	// +kubebuilder:validation:Pattern=`^(\d+)$`
+	// +optional
+	MaxTotalUnreadyPercentage *string `json:"maxTotalUnreadyPercentage,omitempty"`
+	// NewPodScaleUpDelay - The duration for which the cluster-autoscaler ignores new pods before scaling up, e.g. 0s or 1m.
+	// +kubebuilder:validation:Pattern=`^(\d+)(s|m|h)$`
+	// +optional
+	NewPodScaleUpDelay *string `json:"newPodScaleUpDelay,omitempty"`
+	// OkTotalUnreadyCount - The number of allowed unready nodes, irrespective of max-total-unready-percentage.
+	// +kubebuilder:validation:Pattern=`^(\d+)$`
+	// +optional
+	OkTotalUnreadyCount *string `json:"okTotalUnreadyCount,omitempty"`
+	// ScanInterval - How often the cluster is reevaluated for scale up or down, e.g. 10s.
+	// +kubebuilder:validation:Pattern=`^(\d+)s$`
+	// +optional
+	ScanInterval *string `json:"scanInterval,omitempty"`
+	// ScaleDownDelayAfterAdd - How long after scale up that scale down evaluation resumes, e.g. 10m.
+	// +kubebuilder:validation:Pattern=`^(\d+)m$`
+	// +optional
+	ScaleDownDelayAfterAdd *string `json:"scaleDownDelayAfterAdd,omitempty"`
+	// ScaleDownDelayAfterDelete - How long after node deletion that scale down evaluation resumes, e.g. 10s.
+	// +kubebuilder:validation:Pattern=`^(\d+)s$`
+	// +optional
+	ScaleDownDelayAfterDelete *string `json:"scaleDownDelayAfterDelete,omitempty"`
+	// ScaleDownDelayAfterFailure - How long after scale down failure that scale down evaluation resumes, e.g. 3m.
+	// +kubebuilder:validation:Pattern=`^(\d+)m$`
+	// +optional
+	ScaleDownDelayAfterFailure *string `json:"scaleDownDelayAfterFailure,omitempty"`
+	// ScaleDownUnneededTime - How long a node should be unneeded before it is eligible for scale down, e.g. 10m.
+	// +kubebuilder:validation:Pattern=`^(\d+)m$`
+	// +optional
+	ScaleDownUnneededTime *string `json:"scaleDownUnneededTime,omitempty"`
+	// ScaleDownUnreadyTime - How long an unready node should be unneeded before it is eligible for scale down, e.g. 20m.
+	// +kubebuilder:validation:Pattern=`^(\d+)m$`
+	// +optional
+	ScaleDownUnreadyTime *string `json:"scaleDownUnreadyTime,omitempty"`
+	// ScaleDownUtilizationThreshold - Node utilization level, defined as sum of requested resources divided by capacity, below which a node can be considered for scale down, e.g. 0.5.
+	// +kubebuilder:validation:Pattern=`^(0|0\.\d+|1)$`
+	// +optional
+	ScaleDownUtilizationThreshold *string `json:"scaleDownUtilizationThreshold,omitempty"`
+	// SkipNodesWithLocalStorage - Whether the cluster-autoscaler skips deleting nodes with pods with local storage, for example, EmptyDir or HostPath.
+	// +kubebuilder:validation:Enum="true";"false"
+	// +optional
+	SkipNodesWithLocalStorage *string `json:"skipNodesWithLocalStorage,omitempty"`
+	// SkipNodesWithSystemPods - Whether the cluster-autoscaler skips deleting nodes with pods from kube-system (except for DaemonSet or mirror pods).
+	// +kubebuilder:validation:Enum="true";"false"
+	// +optional
+	SkipNodesWithSystemPods *string `json:"skipNodesWithSystemPods,omitempty"`
+}
+
+// UpgradeChannel is the channel used to automatically upgrade an AKS cluster.
+// +kubebuilder:validation:Enum=rapid;stable;patch;node-image;none
+type UpgradeChannel string
+
+const (
+	// UpgradeChannelRapid automatically upgrades the cluster to the latest supported patch release on the latest supported minor version.
+	UpgradeChannelRapid UpgradeChannel = "rapid"
+	// UpgradeChannelStable automatically upgrades the cluster to the latest supported patch release on minor version N-1.
+	UpgradeChannelStable UpgradeChannel = "stable"
+	// UpgradeChannelPatch automatically upgrades the cluster to the latest supported patch version of its minor version.
+	UpgradeChannelPatch UpgradeChannel = "patch"
+	// UpgradeChannelNodeImage automatically upgrades the node image to the latest version available.
+	UpgradeChannelNodeImage UpgradeChannel = "node-image"
+	// UpgradeChannelNone disables auto-upgrades.
+	UpgradeChannelNone UpgradeChannel = "none"
+)
+
+// ManagedClusterAutoUpgradeProfile - auto upgrade profile for an AKS cluster.
+type ManagedClusterAutoUpgradeProfile struct {
+	// UpgradeChannel - The channel used to automatically upgrade the cluster.
+	// Note that the Kubernetes version in the spec must be updated to follow automatic upgrades of the minor version.
+	// +optional
+	UpgradeChannel *UpgradeChannel `json:"upgradeChannel,omitempty"`
+}
+
+// WeekDay is a day of the week.
+// +kubebuilder:validation:Enum=Sunday;Monday;Tuesday;Wednesday;Thursday;Friday;Saturday
+type WeekDay string
+
+// MaintenanceConfiguration - planned maintenance configuration of an AKS cluster.
+// See https://docs.microsoft.com/en-us/azure/aks/planned-maintenance.
+type MaintenanceConfiguration struct {
+	// Name - The name of the maintenance configuration.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// TimeInWeek - The weekday time slots in which maintenance is allowed.
+	// +optional
+	TimeInWeek []TimeInWeek `json:"timeInWeek,omitempty"`
+	// NotAllowedTime - The time spans in which maintenance is not allowed.
+	// +optional
+	NotAllowedTime []TimeSpan `json:"notAllowedTime,omitempty"`
+}
+
+// TimeInWeek - hour slots of a day of the week.
+type TimeInWeek struct {
+	// Day - The day of the week.
+	Day WeekDay `json:"day"`
+	// HourSlots - The hours of the day in which maintenance is allowed, from 0 to 23. Each slot covers one hour, e.g. 1
+	// covers 1:00 to 2:00 in UTC.
+	// +optional
+	HourSlots []int32 `json:"hourSlots,omitempty"`
+}
+
+// TimeSpan - a time span with a start and an end.
+type TimeSpan struct {
+	// Start - The start of the time span.
+	Start metav1.Time `json:"start"`
+	// End - The end of the time span.
+	End metav1.Time `json:"end"`
+}
+
+// ManagedControlPlaneIdentityType is the type of identity of an AKS control plane.
+type ManagedControlPlaneIdentityType string
+
+const (
+	// ManagedControlPlaneIdentityTypeSystemAssigned uses an identity created and managed by AKS.
+	ManagedControlPlaneIdentityTypeSystemAssigned ManagedControlPlaneIdentityType = "SystemAssigned"
+	// ManagedControlPlaneIdentityTypeUserAssigned uses an existing user-assigned identity.
+	ManagedControlPlaneIdentityTypeUserAssigned ManagedControlPlaneIdentityType = "UserAssigned"
+)
+
+// Identity represents the identity of an AKS control plane.
+type Identity struct {
+	// Type - The type of identity used by the AKS control plane.
+	// +kubebuilder:validation:Enum=SystemAssigned;UserAssigned
+	Type ManagedControlPlaneIdentityType `json:"type"`
+
+	// UserAssignedIdentityResourceID - The ARM resource ID of the user-assigned identity, when Type is UserAssigned.
+	// +optional
+	UserAssignedIdentityResourceID string `json:"userAssignedIdentityResourceID,omitempty"`
+}
+
+// ManagedControlPlaneNodePoolUpgradeSettings specifies how the node pools of an AKS cluster are upgraded.
+type ManagedControlPlaneNodePoolUpgradeSettings struct {
+	// Order lists the names of the AzureManagedMachinePools in the order in which they are upgraded. The node pools
+	// which are not listed are upgraded afterwards, System node pools first, then by name.
+	// +optional
+	Order []string `json:"order,omitempty"`
+
+	// MaxSurge is the maximum number or percentage of nodes surged while upgrading the node pools which do not set
+	// upgradeSettings.maxSurge, e.g. 5 or 33%.
+	// +optional
+	MaxSurge *string `json:"maxSurge,omitempty"`
+}
+
+// OIDCIssuerProfile is the OIDC issuer profile of an AKS cluster.
+type OIDCIssuerProfile struct {
+	// Enabled - Whether the OIDC issuer is enabled. Once enabled, the OIDC issuer cannot be disabled.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// ManagedControlPlaneSecurityProfile is the security profile of an AKS cluster.
+type ManagedControlPlaneSecurityProfile struct {
+	// WorkloadIdentity - Workload identity settings of the AKS cluster.
+	// See https://azure.github.io/azure-workload-identity/docs/.
+	// +optional
+	WorkloadIdentity *ManagedControlPlaneSecurityProfileWorkloadIdentity `json:"workloadIdentity,omitempty"`
+}
+
+// ManagedControlPlaneSecurityProfileWorkloadIdentity is the workload identity settings of an AKS cluster.
+type ManagedControlPlaneSecurityProfileWorkloadIdentity struct {
+	// Enabled - Whether workload identity is enabled. Requires the OIDC issuer to be enabled.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
 // ManagedControlPlaneVirtualNetwork describes a virtual network required to provision AKS clusters.
 type ManagedControlPlaneVirtualNetwork struct {
 	Name      string `json:"name"`
 	CIDRBlock string `json:"cidrBlock"`
 	// +optional
 	Subnet ManagedControlPlaneSubnet `json:"subnet,omitempty"`
+	// Subnets are additional subnets of the virtual network. They can be referenced by AzureManagedMachinePools to
+	// place their nodes or pods in a subnet other than the default one.
+	// +optional
+	Subnets []ManagedControlPlaneSubnet `json:"subnets,omitempty"`
 	// ResourceGroup is the name of the Azure resource group for the VNet and Subnet.
 	// +optional
 	ResourceGroup string `json:"resourceGroup,omitempty"`
@@ -223,6 +475,11 @@ type ManagedControlPlaneSubnet struct {
 	// ServiceEndpoints is a slice of Virtual Network service endpoints to enable for the subnets.
 	// +optional
 	ServiceEndpoints infrav1.ServiceEndpoints `json:"serviceEndpoints,omitempty"`
+
+	// RouteTable is the route table associated with the subnet. It is created if the vnet is managed.
+	// Only supported with the kubenet network plugin.
+	// +optional
+	RouteTable *infrav1.RouteTable `json:"routeTable,omitempty"`
 }
 
 // AzureManagedControlPlaneStatus defines the observed state of AzureManagedControlPlane.
@@ -245,6 +502,20 @@ type AzureManagedControlPlaneStatus struct {
 	// next reconciliation loop.
 	// +optional
 	LongRunningOperationStates infrav1.Futures `json:"longRunningOperationStates,omitempty"`
+
+	// OIDCIssuerURL is the URL of the OIDC issuer of the AKS cluster, when the OIDC issuer is enabled.
+	// +optional
+	OIDCIssuerURL string `json:"oidcIssuerURL,omitempty"`
+
+	// Version is the Kubernetes version the AKS control plane runs.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// UpgradingNodePools lists the names of the AzureManagedMachinePools which are allowed to upgrade to the version
+	// of their MachinePool. Node pools are only upgraded after the control plane, in the order set in
+	// NodePoolUpgradeSettings.
+	// +optional
+	UpgradingNodePools []string `json:"upgradingNodePools,omitempty"`
 }
 
 // +kubebuilder:object:root=true
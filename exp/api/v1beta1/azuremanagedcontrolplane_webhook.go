@@ -23,12 +23,16 @@ import (
 	"net"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"golang.org/x/mod/semver"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/feature"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -49,15 +53,18 @@ func (m *AzureManagedControlPlane) SetupWebhookWithManager(mgr ctrl.Manager) err
 
 // Default implements webhook.Defaulter so a webhook will be registered for the type.
 func (m *AzureManagedControlPlane) Default(_ client.Client) {
-	if m.Spec.NetworkPlugin == nil {
+	// The network settings and SSH public key of an adopted AKS cluster are set from the existing cluster.
+	adopting := m.Annotations[AdoptAnnotation] == "true"
+
+	if m.Spec.NetworkPlugin == nil && !adopting {
 		networkPlugin := "azure"
 		m.Spec.NetworkPlugin = &networkPlugin
 	}
-	if m.Spec.LoadBalancerSKU == nil {
+	if m.Spec.LoadBalancerSKU == nil && !adopting {
 		loadBalancerSKU := "Standard"
 		m.Spec.LoadBalancerSKU = &loadBalancerSKU
 	}
-	if m.Spec.NetworkPolicy == nil {
+	if m.Spec.NetworkPolicy == nil && !adopting {
 		NetworkPolicy := "calico"
 		m.Spec.NetworkPolicy = &NetworkPolicy
 	}
@@ -67,8 +74,10 @@ func (m *AzureManagedControlPlane) Default(_ client.Client) {
 		m.Spec.Version = normalizedVersion
 	}
 
-	if err := m.setDefaultSSHPublicKey(); err != nil {
-		ctrl.Log.WithName("AzureManagedControlPlaneWebHookLogger").Error(err, "setDefaultSSHPublicKey failed")
+	if !adopting {
+		if err := m.setDefaultSSHPublicKey(); err != nil {
+			ctrl.Log.WithName("AzureManagedControlPlaneWebHookLogger").Error(err, "setDefaultSSHPublicKey failed")
+		}
 	}
 
 	m.setDefaultNodeResourceGroupName()
@@ -257,6 +266,14 @@ func (m *AzureManagedControlPlane) ValidateUpdate(oldRaw runtime.Object, client
 		allErrs = append(allErrs, errs...)
 	}
 
+	if errs := m.validateIdentityUpdate(old); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := m.validateVersionUpdate(old, client); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
 	if len(allErrs) == 0 {
 		return m.Validate(client)
 	}
@@ -279,6 +296,11 @@ func (m *AzureManagedControlPlane) Validate(cli client.Client) error {
 		m.validateLoadBalancerProfile,
 		m.validateAPIServerAccessProfile,
 		m.validateManagedClusterNetwork,
+		m.validateAutoScalerProfile,
+		m.validateMaintenanceConfigurations,
+		m.validateNodePoolUpgradeSettings,
+		m.validateIdentity,
+		m.validateSubnets,
 	}
 
 	var errs []error
@@ -388,6 +410,147 @@ func (m *AzureManagedControlPlane) validateAPIServerAccessProfile(_ client.Clien
 	return nil
 }
 
+// validateAutoScalerProfile validates an AutoScalerProfile.
+func (m *AzureManagedControlPlane) validateAutoScalerProfile(_ client.Client) error {
+	if m.Spec.AutoScalerProfile == nil || m.Spec.AutoScalerProfile.MaxTotalUnreadyPercentage == nil {
+		return nil
+	}
+
+	maxTotalUnreadyPercentage := *m.Spec.AutoScalerProfile.MaxTotalUnreadyPercentage
+	if percentage, err := strconv.Atoi(maxTotalUnreadyPercentage); err != nil || percentage < 0 || percentage > 100 {
+		return field.Invalid(field.NewPath("Spec", "AutoScalerProfile", "MaxTotalUnreadyPercentage"), maxTotalUnreadyPercentage, "value should be in between 0 and 100")
+	}
+
+	return nil
+}
+
+// validateNodePoolUpgradeSettings validates the NodePoolUpgradeSettings.
+func (m *AzureManagedControlPlane) validateNodePoolUpgradeSettings(_ client.Client) error {
+	if m.Spec.NodePoolUpgradeSettings == nil {
+		return nil
+	}
+	if err := validateMaxSurge(field.NewPath("Spec", "NodePoolUpgradeSettings", "MaxSurge"), m.Spec.NodePoolUpgradeSettings.MaxSurge); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validateMaintenanceConfigurations validates the MaintenanceConfigurations.
+func (m *AzureManagedControlPlane) validateMaintenanceConfigurations(_ client.Client) error {
+	var allErrs field.ErrorList
+	names := make(map[string]struct{}, len(m.Spec.MaintenanceConfigurations))
+	for i, config := range m.Spec.MaintenanceConfigurations {
+		fldPath := field.NewPath("Spec", "MaintenanceConfigurations").Index(i)
+		if _, ok := names[config.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("Name"), config.Name))
+		}
+		names[config.Name] = struct{}{}
+
+		for j, timeInWeek := range config.TimeInWeek {
+			for k, hourSlot := range timeInWeek.HourSlots {
+				if hourSlot < 0 || hourSlot > 23 {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("TimeInWeek").Index(j).Child("HourSlots").Index(k), hourSlot, "value should be in between 0 and 23"))
+				}
+			}
+		}
+
+		for j, timeSpan := range config.NotAllowedTime {
+			if !timeSpan.Start.Before(&timeSpan.End) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("NotAllowedTime").Index(j).Child("End"), timeSpan.End, "end of the time span must be after its start"))
+			}
+		}
+	}
+
+	if len(allErrs) > 0 {
+		return kerrors.NewAggregate(allErrs.ToAggregate().Errors())
+	}
+	return nil
+}
+
+// validateIdentity validates the control plane and kubelet identities, and the workload identity settings.
+func (m *AzureManagedControlPlane) validateIdentity(_ client.Client) error {
+	var allErrs field.ErrorList
+
+	userAssigned := false
+	if m.Spec.Identity != nil {
+		fldPath := field.NewPath("Spec", "Identity", "UserAssignedIdentityResourceID")
+		switch m.Spec.Identity.Type {
+		case ManagedControlPlaneIdentityTypeUserAssigned:
+			userAssigned = true
+			if m.Spec.Identity.UserAssignedIdentityResourceID == "" {
+				allErrs = append(allErrs, field.Required(fldPath, "a user-assigned identity must be specified when the identity type is UserAssigned"))
+			} else if _, err := azureautorest.ParseResourceID(m.Spec.Identity.UserAssignedIdentityResourceID); err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath, m.Spec.Identity.UserAssignedIdentityResourceID, "invalid resource ID"))
+			}
+		default:
+			if m.Spec.Identity.UserAssignedIdentityResourceID != "" {
+				allErrs = append(allErrs, field.Forbidden(fldPath, "a user-assigned identity can only be specified when the identity type is UserAssigned"))
+			}
+		}
+	}
+
+	if m.Spec.KubeletUserAssignedIdentity != "" {
+		fldPath := field.NewPath("Spec", "KubeletUserAssignedIdentity")
+		if !userAssigned {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "a kubelet identity can only be specified when the control plane identity type is UserAssigned"))
+		}
+		if _, err := azureautorest.ParseResourceID(m.Spec.KubeletUserAssignedIdentity); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath, m.Spec.KubeletUserAssignedIdentity, "invalid resource ID"))
+		}
+	}
+
+	// The OIDC issuer and workload identity are only supported by the preview AKS API.
+	if !feature.Gates.Enabled(feature.AKSPreview) {
+		if m.Spec.OIDCIssuerProfile != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("Spec", "OIDCIssuerProfile"), "the OIDC issuer can only be set when the AKSPreview feature gate is enabled"))
+		}
+		if m.Spec.SecurityProfile != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("Spec", "SecurityProfile"), "the security profile can only be set when the AKSPreview feature gate is enabled"))
+		}
+	}
+
+	if m.Spec.SecurityProfile != nil && m.Spec.SecurityProfile.WorkloadIdentity != nil && pointer.BoolDeref(m.Spec.SecurityProfile.WorkloadIdentity.Enabled, false) &&
+		(m.Spec.OIDCIssuerProfile == nil || !pointer.BoolDeref(m.Spec.OIDCIssuerProfile.Enabled, false)) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("Spec", "SecurityProfile", "WorkloadIdentity", "Enabled"), "workload identity requires the OIDC issuer to be enabled"))
+	}
+
+	if len(allErrs) > 0 {
+		return kerrors.NewAggregate(allErrs.ToAggregate().Errors())
+	}
+	return nil
+}
+
+// validateSubnets validates the additional subnets of the virtual network and the route tables of the subnets.
+func (m *AzureManagedControlPlane) validateSubnets(_ client.Client) error {
+	var allErrs field.ErrorList
+
+	names := map[string]bool{m.Spec.VirtualNetwork.Subnet.Name: true}
+	for i, subnet := range m.Spec.VirtualNetwork.Subnets {
+		fldPath := field.NewPath("Spec", "VirtualNetwork", "Subnets").Index(i)
+		if subnet.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("Name"), "subnet name must be specified"))
+		} else if names[subnet.Name] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("Name"), subnet.Name))
+		}
+		names[subnet.Name] = true
+		if _, _, err := net.ParseCIDR(subnet.CIDRBlock); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("CIDRBlock"), subnet.CIDRBlock, "must be a valid CIDR"))
+		}
+	}
+
+	subnets := append([]ManagedControlPlaneSubnet{m.Spec.VirtualNetwork.Subnet}, m.Spec.VirtualNetwork.Subnets...)
+	for _, subnet := range subnets {
+		if subnet.RouteTable != nil && pointer.StringDeref(m.Spec.NetworkPlugin, "") != "kubenet" {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("Spec", "VirtualNetwork", "Subnets", subnet.Name, "RouteTable"), "route tables are only supported with the kubenet network plugin"))
+		}
+	}
+
+	if len(allErrs) > 0 {
+		return kerrors.NewAggregate(allErrs.ToAggregate().Errors())
+	}
+	return nil
+}
+
 // validateManagedClusterNetwork validates the Cluster network values.
 func (m *AzureManagedControlPlane) validateManagedClusterNetwork(cli client.Client) error {
 	ctx := context.Background()
@@ -453,6 +616,53 @@ func (m *AzureManagedControlPlane) validateManagedClusterNetwork(cli client.Clie
 	return nil
 }
 
+// validateVersionUpdate validates update to Version. AKS upgrades one minor version at a time, and node pools may not
+// fall more than MaxNodePoolMinorVersionSkew minor versions behind the control plane.
+func (m *AzureManagedControlPlane) validateVersionUpdate(old *AzureManagedControlPlane, cli client.Client) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if m.Spec.Version == old.Spec.Version || !kubeSemver.MatchString(m.Spec.Version) || !kubeSemver.MatchString(old.Spec.Version) {
+		return nil
+	}
+
+	versionPath := field.NewPath("Spec", "Version")
+	if semver.Compare(m.Spec.Version, old.Spec.Version) < 0 {
+		return append(allErrs, field.Invalid(versionPath, m.Spec.Version, fmt.Sprintf("cannot downgrade from %s", old.Spec.Version)))
+	}
+
+	newMinor := minorVersion(m.Spec.Version)
+	if semver.Major(m.Spec.Version) != semver.Major(old.Spec.Version) || newMinor-minorVersion(old.Spec.Version) > 1 {
+		return append(allErrs, field.Invalid(versionPath, m.Spec.Version, fmt.Sprintf("cannot skip minor versions when upgrading from %s", old.Spec.Version)))
+	}
+
+	clusterName, ok := m.Labels[clusterv1.ClusterLabelName]
+	if !ok || cli == nil {
+		return allErrs
+	}
+
+	pools := &AzureManagedMachinePoolList{}
+	if err := cli.List(context.Background(), pools, client.InNamespace(m.Namespace), client.MatchingLabels{clusterv1.ClusterLabelName: clusterName}); err != nil {
+		return append(allErrs, field.InternalError(versionPath, err))
+	}
+	for _, pool := range pools.Items {
+		if !kubeSemver.MatchString(pool.Status.Version) {
+			continue
+		}
+		if newMinor-minorVersion(pool.Status.Version) > MaxNodePoolMinorVersionSkew {
+			allErrs = append(allErrs, field.Invalid(versionPath, m.Spec.Version,
+				fmt.Sprintf("node pool %s runs %s, which would be more than %d minor versions older than the control plane", pool.Name, pool.Status.Version, MaxNodePoolMinorVersionSkew)))
+		}
+	}
+
+	return allErrs
+}
+
+// minorVersion returns the minor version of a Kubernetes version that matches kubeSemver.
+func minorVersion(version string) int {
+	minor, _ := strconv.Atoi(strings.Split(version, ".")[1])
+	return minor
+}
+
 // validateAPIServerAccessProfileUpdate validates update to APIServerAccessProfile.
 func (m *AzureManagedControlPlane) validateAPIServerAccessProfileUpdate(old *AzureManagedControlPlane) field.ErrorList {
 	var allErrs field.ErrorList
@@ -530,6 +740,79 @@ func (m *AzureManagedControlPlane) validateVirtualNetworkUpdate(old *AzureManage
 				m.Spec.VirtualNetwork.ResourceGroup,
 				"Virtual Network Resource Group is immutable"))
 	}
+
+	if !reflect.DeepEqual(old.Spec.VirtualNetwork.Subnet.RouteTable, m.Spec.VirtualNetwork.Subnet.RouteTable) {
+		allErrs = append(allErrs,
+			field.Invalid(
+				field.NewPath("Spec", "VirtualNetwork.Subnet.RouteTable"),
+				m.Spec.VirtualNetwork.Subnet.RouteTable,
+				"Subnet RouteTable is immutable"))
+	}
+
+	// Additional subnets can be added, but the existing ones can't be modified or removed.
+	subnets := make(map[string]ManagedControlPlaneSubnet, len(m.Spec.VirtualNetwork.Subnets))
+	for _, subnet := range m.Spec.VirtualNetwork.Subnets {
+		subnets[subnet.Name] = subnet
+	}
+	for _, oldSubnet := range old.Spec.VirtualNetwork.Subnets {
+		subnet, ok := subnets[oldSubnet.Name]
+		if !ok {
+			allErrs = append(allErrs,
+				field.Invalid(
+					field.NewPath("Spec", "VirtualNetwork.Subnets"),
+					m.Spec.VirtualNetwork.Subnets,
+					fmt.Sprintf("Subnet %s cannot be removed", oldSubnet.Name)))
+			continue
+		}
+		if subnet.CIDRBlock != oldSubnet.CIDRBlock || !reflect.DeepEqual(subnet.RouteTable, oldSubnet.RouteTable) {
+			allErrs = append(allErrs,
+				field.Invalid(
+					field.NewPath("Spec", "VirtualNetwork.Subnets"),
+					m.Spec.VirtualNetwork.Subnets,
+					fmt.Sprintf("CIDRBlock and RouteTable of subnet %s are immutable", oldSubnet.Name)))
+		}
+	}
+	return allErrs
+}
+
+// validateIdentityUpdate validates that the control plane and kubelet identities are not modified and that the OIDC
+// issuer is not disabled.
+func (m *AzureManagedControlPlane) validateIdentityUpdate(old *AzureManagedControlPlane) field.ErrorList {
+	var allErrs field.ErrorList
+
+	// An unset identity is a system-assigned identity.
+	identity, oldIdentity := Identity{Type: ManagedControlPlaneIdentityTypeSystemAssigned}, Identity{Type: ManagedControlPlaneIdentityTypeSystemAssigned}
+	if m.Spec.Identity != nil {
+		identity = *m.Spec.Identity
+	}
+	if old.Spec.Identity != nil {
+		oldIdentity = *old.Spec.Identity
+	}
+	if !reflect.DeepEqual(identity, oldIdentity) {
+		allErrs = append(allErrs,
+			field.Invalid(
+				field.NewPath("Spec", "Identity"),
+				m.Spec.Identity,
+				"field is immutable"))
+	}
+
+	if m.Spec.KubeletUserAssignedIdentity != old.Spec.KubeletUserAssignedIdentity {
+		allErrs = append(allErrs,
+			field.Invalid(
+				field.NewPath("Spec", "KubeletUserAssignedIdentity"),
+				m.Spec.KubeletUserAssignedIdentity,
+				"field is immutable"))
+	}
+
+	if old.Spec.OIDCIssuerProfile != nil && pointer.BoolDeref(old.Spec.OIDCIssuerProfile.Enabled, false) &&
+		(m.Spec.OIDCIssuerProfile == nil || !pointer.BoolDeref(m.Spec.OIDCIssuerProfile.Enabled, false)) {
+		allErrs = append(allErrs,
+			field.Invalid(
+				field.NewPath("Spec", "OIDCIssuerProfile", "Enabled"),
+				m.Spec.OIDCIssuerProfile,
+				"the OIDC issuer cannot be disabled once enabled"))
+	}
+
 	return allErrs
 }
 
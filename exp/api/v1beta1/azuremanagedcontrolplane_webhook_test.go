@@ -18,13 +18,18 @@ package v1beta1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest/to"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	utilfeature "k8s.io/component-base/featuregate/testing"
 	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/feature"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestDefaultingWebhook(t *testing.T) {
@@ -78,10 +83,34 @@ func TestDefaultingWebhook(t *testing.T) {
 	g.Expect(amcp.Spec.SKU.Tier).To(Equal(PaidManagedControlPlaneTier))
 }
 
+func TestDefaultingWebhookAdopt(t *testing.T) {
+	g := NewWithT(t)
+
+	amcp := &AzureManagedControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "fooName",
+			Annotations: map[string]string{AdoptAnnotation: "true"},
+		},
+		Spec: AzureManagedControlPlaneSpec{
+			ResourceGroupName: "fooRg",
+			Location:          "fooLocation",
+			Version:           "1.17.5",
+		},
+	}
+	amcp.Default(nil)
+	g.Expect(amcp.Spec.NetworkPlugin).To(BeNil())
+	g.Expect(amcp.Spec.NetworkPolicy).To(BeNil())
+	g.Expect(amcp.Spec.LoadBalancerSKU).To(BeNil())
+	g.Expect(amcp.Spec.SSHPublicKey).To(BeEmpty())
+	g.Expect(amcp.Spec.Version).To(Equal("v1.17.5"))
+	g.Expect(amcp.Spec.NodeResourceGroupName).To(Equal("MC_fooRg_fooName_fooLocation"))
+}
+
 func TestValidatingWebhook(t *testing.T) {
 	// NOTE: AzureManageControlPlane is behind AKS feature gate flag; the web hook
 	// must prevent creating new objects in case the feature flag is disabled.
 	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.AKS, true)()
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.AKSPreview, true)()
 	g := NewWithT(t)
 	tests := []struct {
 		name      string
@@ -240,6 +269,238 @@ func TestValidatingWebhook(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		{
+			name: "Valid AutoScalerProfile",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.21.2",
+					AutoScalerProfile: &AutoScalerProfile{
+						MaxTotalUnreadyPercentage: to.StringPtr("45"),
+						ScanInterval:              to.StringPtr("20s"),
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Invalid AutoScalerProfile.MaxTotalUnreadyPercentage",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.21.2",
+					AutoScalerProfile: &AutoScalerProfile{
+						MaxTotalUnreadyPercentage: to.StringPtr("101"),
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Negative AutoScalerProfile.MaxTotalUnreadyPercentage",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.21.2",
+					AutoScalerProfile: &AutoScalerProfile{
+						MaxTotalUnreadyPercentage: to.StringPtr("-1"),
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Valid MaintenanceConfigurations",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.21.2",
+					MaintenanceConfigurations: []MaintenanceConfiguration{
+						{
+							Name:       "default",
+							TimeInWeek: []TimeInWeek{{Day: "Saturday", HourSlots: []int32{0, 1, 23}}},
+							NotAllowedTime: []TimeSpan{{
+								Start: metav1.Date(2022, time.December, 24, 0, 0, 0, 0, time.UTC),
+								End:   metav1.Date(2022, time.December, 27, 0, 0, 0, 0, time.UTC),
+							}},
+						},
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Valid user-assigned control plane and kubelet identities",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.21.2",
+					Identity: &Identity{
+						Type:                           ManagedControlPlaneIdentityTypeUserAssigned,
+						UserAssignedIdentityResourceID: controlPlaneIdentityID,
+					},
+					KubeletUserAssignedIdentity: kubeletIdentityID,
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "User-assigned control plane identity without resource ID",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:  "v1.21.2",
+					Identity: &Identity{Type: ManagedControlPlaneIdentityTypeUserAssigned},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Kubelet identity requires a user-assigned control plane identity",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:                     "v1.21.2",
+					KubeletUserAssignedIdentity: kubeletIdentityID,
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Workload identity with the OIDC issuer enabled",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:           "v1.21.2",
+					OIDCIssuerProfile: &OIDCIssuerProfile{Enabled: pointer.Bool(true)},
+					SecurityProfile: &ManagedControlPlaneSecurityProfile{
+						WorkloadIdentity: &ManagedControlPlaneSecurityProfileWorkloadIdentity{Enabled: pointer.Bool(true)},
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Workload identity requires the OIDC issuer",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.21.2",
+					SecurityProfile: &ManagedControlPlaneSecurityProfile{
+						WorkloadIdentity: &ManagedControlPlaneSecurityProfileWorkloadIdentity{Enabled: pointer.Bool(true)},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Valid additional subnets with a route table",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:       "v1.21.2",
+					NetworkPlugin: to.StringPtr("kubenet"),
+					VirtualNetwork: ManagedControlPlaneVirtualNetwork{
+						Subnet: ManagedControlPlaneSubnet{Name: "nodes", CIDRBlock: "10.240.0.0/16", RouteTable: &infrav1.RouteTable{Name: "rt"}},
+						Subnets: []ManagedControlPlaneSubnet{
+							{Name: "pool1-nodes", CIDRBlock: "10.241.0.0/16", RouteTable: &infrav1.RouteTable{Name: "rt"}},
+						},
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Additional subnet with the name of the default subnet",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.21.2",
+					VirtualNetwork: ManagedControlPlaneVirtualNetwork{
+						Subnet:  ManagedControlPlaneSubnet{Name: "nodes", CIDRBlock: "10.240.0.0/16"},
+						Subnets: []ManagedControlPlaneSubnet{{Name: "nodes", CIDRBlock: "10.241.0.0/16"}},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Additional subnet with an invalid CIDR",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.21.2",
+					VirtualNetwork: ManagedControlPlaneVirtualNetwork{
+						Subnets: []ManagedControlPlaneSubnet{{Name: "pods", CIDRBlock: "10.241.0.0"}},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Route table requires the kubenet network plugin",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:       "v1.21.2",
+					NetworkPlugin: to.StringPtr("azure"),
+					VirtualNetwork: ManagedControlPlaneVirtualNetwork{
+						Subnet: ManagedControlPlaneSubnet{Name: "nodes", CIDRBlock: "10.240.0.0/16", RouteTable: &infrav1.RouteTable{Name: "rt"}},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Duplicate MaintenanceConfigurations names",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.21.2",
+					MaintenanceConfigurations: []MaintenanceConfiguration{
+						{Name: "default", TimeInWeek: []TimeInWeek{{Day: "Saturday"}}},
+						{Name: "default", TimeInWeek: []TimeInWeek{{Day: "Sunday"}}},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Invalid MaintenanceConfigurations.TimeInWeek.HourSlots",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.21.2",
+					MaintenanceConfigurations: []MaintenanceConfiguration{
+						{Name: "default", TimeInWeek: []TimeInWeek{{Day: "Saturday", HourSlots: []int32{24}}}},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "MaintenanceConfigurations.NotAllowedTime must end after it starts",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.21.2",
+					MaintenanceConfigurations: []MaintenanceConfiguration{
+						{
+							Name: "default",
+							NotAllowedTime: []TimeSpan{{
+								Start: metav1.Date(2022, time.December, 27, 0, 0, 0, 0, time.UTC),
+								End:   metav1.Date(2022, time.December, 24, 0, 0, 0, 0, time.UTC),
+							}},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Valid NodePoolUpgradeSettings.MaxSurge",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:                 "v1.21.2",
+					NodePoolUpgradeSettings: &ManagedControlPlaneNodePoolUpgradeSettings{MaxSurge: pointer.StringPtr("5")},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Invalid NodePoolUpgradeSettings.MaxSurge",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:                 "v1.21.2",
+					NodePoolUpgradeSettings: &ManagedControlPlaneNodePoolUpgradeSettings{MaxSurge: pointer.StringPtr("-33%")},
+				},
+			},
+			expectErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -254,6 +515,28 @@ func TestValidatingWebhook(t *testing.T) {
 	}
 }
 
+func TestValidatingWebhookAKSPreview(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.AKS, true)()
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.AKSPreview, false)()
+	g := NewWithT(t)
+
+	amcp := AzureManagedControlPlane{
+		Spec: AzureManagedControlPlaneSpec{
+			Version: "v1.21.2",
+		},
+	}
+	g.Expect(amcp.ValidateCreate(nil)).To(Succeed())
+
+	amcp.Spec.OIDCIssuerProfile = &OIDCIssuerProfile{Enabled: pointer.Bool(true)}
+	g.Expect(amcp.ValidateCreate(nil)).NotTo(Succeed())
+
+	amcp.Spec.OIDCIssuerProfile = nil
+	amcp.Spec.SecurityProfile = &ManagedControlPlaneSecurityProfile{
+		WorkloadIdentity: &ManagedControlPlaneSecurityProfileWorkloadIdentity{Enabled: pointer.Bool(false)},
+	}
+	g.Expect(amcp.ValidateCreate(nil)).NotTo(Succeed())
+}
+
 func TestAzureManagedControlPlane_ValidateCreate(t *testing.T) {
 	// NOTE: AzureManageControlPlane is behind AKS feature gate flag; the web hook
 	// must prevent creating new objects in case the feature flag is disabled.
@@ -368,6 +651,7 @@ func TestAzureManagedControlPlane_ValidateCreateFailure(t *testing.T) {
 }
 
 func TestAzureManagedControlPlane_ValidateUpdate(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.AKSPreview, true)()
 	g := NewWithT(t)
 
 	tests := []struct {
@@ -902,6 +1186,107 @@ func TestAzureManagedControlPlane_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:    "AzureManagedControlPlane additional subnets can be added",
+			oldAMCP: createAzureManagedControlPlane("192.168.0.0", "v1.18.0", ""),
+			amcp: func() *AzureManagedControlPlane {
+				amcp := createAzureManagedControlPlane("192.168.0.0", "v1.18.0", generateSSHPublicKey(true))
+				amcp.Spec.VirtualNetwork.Subnets = []ManagedControlPlaneSubnet{{Name: "pods", CIDRBlock: "10.241.0.0/16"}}
+				return amcp
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "AzureManagedControlPlane additional subnets cannot be removed",
+			oldAMCP: func() *AzureManagedControlPlane {
+				amcp := createAzureManagedControlPlane("192.168.0.0", "v1.18.0", "")
+				amcp.Spec.VirtualNetwork.Subnets = []ManagedControlPlaneSubnet{{Name: "pods", CIDRBlock: "10.241.0.0/16"}}
+				return amcp
+			}(),
+			amcp:    createAzureManagedControlPlane("192.168.0.0", "v1.18.0", generateSSHPublicKey(true)),
+			wantErr: true,
+		},
+		{
+			name: "AzureManagedControlPlane additional subnet CIDRBlock is immutable",
+			oldAMCP: func() *AzureManagedControlPlane {
+				amcp := createAzureManagedControlPlane("192.168.0.0", "v1.18.0", "")
+				amcp.Spec.VirtualNetwork.Subnets = []ManagedControlPlaneSubnet{{Name: "pods", CIDRBlock: "10.241.0.0/16"}}
+				return amcp
+			}(),
+			amcp: func() *AzureManagedControlPlane {
+				amcp := createAzureManagedControlPlane("192.168.0.0", "v1.18.0", generateSSHPublicKey(true))
+				amcp.Spec.VirtualNetwork.Subnets = []ManagedControlPlaneSubnet{{Name: "pods", CIDRBlock: "10.242.0.0/16"}}
+				return amcp
+			}(),
+			wantErr: true,
+		},
+		{
+			name:    "AzureManagedControlPlane Identity is immutable",
+			oldAMCP: createAzureManagedControlPlane("192.168.0.0", "v1.18.0", ""),
+			amcp: func() *AzureManagedControlPlane {
+				amcp := createAzureManagedControlPlane("192.168.0.0", "v1.18.0", generateSSHPublicKey(true))
+				amcp.Spec.Identity = &Identity{
+					Type:                           ManagedControlPlaneIdentityTypeUserAssigned,
+					UserAssignedIdentityResourceID: controlPlaneIdentityID,
+				}
+				return amcp
+			}(),
+			wantErr: true,
+		},
+		{
+			name:    "AzureManagedControlPlane Identity can be set to the default system-assigned identity",
+			oldAMCP: createAzureManagedControlPlane("192.168.0.0", "v1.18.0", ""),
+			amcp: func() *AzureManagedControlPlane {
+				amcp := createAzureManagedControlPlane("192.168.0.0", "v1.18.0", generateSSHPublicKey(true))
+				amcp.Spec.Identity = &Identity{Type: ManagedControlPlaneIdentityTypeSystemAssigned}
+				return amcp
+			}(),
+			wantErr: false,
+		},
+		{
+			name:    "AzureManagedControlPlane OIDC issuer can be enabled",
+			oldAMCP: createAzureManagedControlPlane("192.168.0.0", "v1.18.0", ""),
+			amcp: func() *AzureManagedControlPlane {
+				amcp := createAzureManagedControlPlane("192.168.0.0", "v1.18.0", generateSSHPublicKey(true))
+				amcp.Spec.OIDCIssuerProfile = &OIDCIssuerProfile{Enabled: pointer.Bool(true)}
+				return amcp
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "AzureManagedControlPlane OIDC issuer cannot be disabled",
+			oldAMCP: func() *AzureManagedControlPlane {
+				amcp := createAzureManagedControlPlane("192.168.0.0", "v1.18.0", "")
+				amcp.Spec.OIDCIssuerProfile = &OIDCIssuerProfile{Enabled: pointer.Bool(true)}
+				return amcp
+			}(),
+			amcp:    createAzureManagedControlPlane("192.168.0.0", "v1.18.0", generateSSHPublicKey(true)),
+			wantErr: true,
+		},
+		{
+			name:    "AzureManagedControlPlane Version patch upgrade is allowed",
+			oldAMCP: createAzureManagedControlPlane("192.168.0.0", "v1.18.0", ""),
+			amcp:    createAzureManagedControlPlane("192.168.0.0", "v1.18.8", generateSSHPublicKey(true)),
+			wantErr: false,
+		},
+		{
+			name:    "AzureManagedControlPlane Version minor upgrade is allowed",
+			oldAMCP: createAzureManagedControlPlane("192.168.0.0", "v1.18.8", ""),
+			amcp:    createAzureManagedControlPlane("192.168.0.0", "v1.19.0", generateSSHPublicKey(true)),
+			wantErr: false,
+		},
+		{
+			name:    "AzureManagedControlPlane Version cannot skip a minor version",
+			oldAMCP: createAzureManagedControlPlane("192.168.0.0", "v1.18.8", ""),
+			amcp:    createAzureManagedControlPlane("192.168.0.0", "v1.20.0", generateSSHPublicKey(true)),
+			wantErr: true,
+		},
+		{
+			name:    "AzureManagedControlPlane Version cannot be downgraded",
+			oldAMCP: createAzureManagedControlPlane("192.168.0.0", "v1.19.0", ""),
+			amcp:    createAzureManagedControlPlane("192.168.0.0", "v1.18.8", generateSSHPublicKey(true)),
+			wantErr: true,
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -915,6 +1300,71 @@ func TestAzureManagedControlPlane_ValidateUpdate(t *testing.T) {
 	}
 }
 
+func TestAzureManagedControlPlane_ValidateVersionUpdateNodePoolSkew(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(AddToScheme(scheme)).To(Succeed())
+
+	tests := []struct {
+		name        string
+		poolVersion string
+		version     string
+		wantErr     bool
+	}{
+		{
+			name:        "node pool within the supported skew",
+			poolVersion: "v1.22.4",
+			version:     "v1.24.0",
+			wantErr:     false,
+		},
+		{
+			name:        "node pool outside the supported skew",
+			poolVersion: "v1.21.2",
+			version:     "v1.24.0",
+			wantErr:     true,
+		},
+		{
+			name:        "node pool without a version",
+			poolVersion: "",
+			version:     "v1.24.0",
+			wantErr:     false,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			pool := &AzureManagedMachinePool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pool0",
+					Namespace: "default",
+					Labels:    map[string]string{clusterv1.ClusterLabelName: "my-cluster"},
+				},
+				Status: AzureManagedMachinePoolStatus{Version: tc.poolVersion},
+			}
+			cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pool).Build()
+
+			oldAMCP := createAzureManagedControlPlane("192.168.0.0", "v1.23.5", "")
+			oldAMCP.Namespace = "default"
+			oldAMCP.Labels = map[string]string{clusterv1.ClusterLabelName: "my-cluster"}
+			amcp := oldAMCP.DeepCopy()
+			amcp.Spec.Version = tc.version
+
+			errs := amcp.validateVersionUpdate(oldAMCP, cli)
+			if tc.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+const (
+	controlPlaneIdentityID = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/my-rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/control-plane"
+	kubeletIdentityID      = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/my-rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/kubelet"
+)
+
 func createAzureManagedControlPlane(serviceIP, version, sshKey string) *AzureManagedControlPlane {
 	return &AzureManagedControlPlane{
 		Spec: AzureManagedControlPlaneSpec{
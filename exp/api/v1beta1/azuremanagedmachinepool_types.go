@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
@@ -111,6 +112,50 @@ type AzureManagedMachinePoolSpec struct {
 	// +kubebuilder:validation:Enum=Regular;Spot
 	// +optional
 	ScaleSetPriority *string `json:"scaleSetPriority,omitempty"`
+
+	// NodeSubnetName is the name of the subnet of the AzureManagedControlPlane virtual network which contains the nodes
+	// of the pool. Defaults to the subnet of the virtual network.
+	// +optional
+	NodeSubnetName *string `json:"nodeSubnetName,omitempty"`
+
+	// PodSubnetName is the name of the subnet of the AzureManagedControlPlane virtual network from which the pods of
+	// the pool are dynamically allocated IPs. Only supported with the azure network plugin.
+	// +optional
+	PodSubnetName *string `json:"podSubnetName,omitempty"`
+
+	// KubeletConfig specifies the kubelet configuration of the nodes in the pool. Immutable.
+	// +optional
+	KubeletConfig *KubeletConfig `json:"kubeletConfig,omitempty"`
+
+	// LinuxOSConfig specifies the custom Linux OS settings and configurations of the nodes in the pool. Immutable.
+	// +optional
+	LinuxOSConfig *LinuxOSConfig `json:"linuxOSConfig,omitempty"`
+
+	// UpgradeSettings specifies the settings used when upgrading the pool.
+	// +optional
+	UpgradeSettings *ManagedMachinePoolUpgradeSettings `json:"upgradeSettings,omitempty"`
+
+	// SpotMaxPrice is the maximum price per hour you are willing to pay for the Spot VMs of the pool, or -1 to pay up to
+	// the on-demand price. Only allowed when ScaleSetPriority is Spot. Immutable.
+	// +optional
+	SpotMaxPrice *resource.Quantity `json:"spotMaxPrice,omitempty"`
+
+	// OSSKU specifies the OS SKU used by the nodes of a Linux pool. Immutable.
+	// +kubebuilder:validation:Enum=Ubuntu;CBLMariner
+	// +optional
+	OSSKU *string `json:"osSKU,omitempty"`
+
+	// EnableEncryptionAtHost enables host-based encryption of the disks of the nodes in the pool. Immutable.
+	// +optional
+	EnableEncryptionAtHost *bool `json:"enableEncryptionAtHost,omitempty"`
+
+	// ProximityPlacementGroupID is the resource ID of the proximity placement group of the nodes in the pool. Immutable.
+	// +optional
+	ProximityPlacementGroupID *string `json:"proximityPlacementGroupID,omitempty"`
+
+	// EnableFIPS enables the use of a FIPS-enabled OS for the nodes in the pool. Immutable.
+	// +optional
+	EnableFIPS *bool `json:"enableFIPS,omitempty"`
 }
 
 // ManagedMachinePoolScaling specifies scaling options.
@@ -119,6 +164,184 @@ type ManagedMachinePoolScaling struct {
 	MaxSize *int32 `json:"maxSize,omitempty"`
 }
 
+// ManagedMachinePoolUpgradeSettings specifies the settings used when upgrading a node pool.
+type ManagedMachinePoolUpgradeSettings struct {
+	// MaxSurge is the maximum number or percentage of nodes that are surged during an upgrade, e.g. 5 or 33%.
+	// Defaults to 1.
+	// +optional
+	MaxSurge *string `json:"maxSurge,omitempty"`
+}
+
+// KubeletConfig specifies the kubelet configuration of the nodes of an agent pool.
+// See https://docs.microsoft.com/en-us/azure/aks/custom-node-configuration.
+type KubeletConfig struct {
+	// CPUManagerPolicy is the CPU manager policy to use.
+	// +kubebuilder:validation:Enum=none;static
+	// +optional
+	CPUManagerPolicy *string `json:"cpuManagerPolicy,omitempty"`
+
+	// CPUCfsQuota enables CPU CFS quota enforcement for containers that specify CPU limits.
+	// +optional
+	CPUCfsQuota *bool `json:"cpuCfsQuota,omitempty"`
+
+	// CPUCfsQuotaPeriod is the CPU CFS quota period value, in milliseconds with an "ms" suffix, e.g. 100ms.
+	// +optional
+	CPUCfsQuotaPeriod *string `json:"cpuCfsQuotaPeriod,omitempty"`
+
+	// ImageGcHighThreshold is the percent of disk usage after which image garbage collection is always run.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	ImageGcHighThreshold *int32 `json:"imageGcHighThreshold,omitempty"`
+
+	// ImageGcLowThreshold is the percent of disk usage before which image garbage collection is never run.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	ImageGcLowThreshold *int32 `json:"imageGcLowThreshold,omitempty"`
+
+	// TopologyManagerPolicy is the topology manager policy to use.
+	// +kubebuilder:validation:Enum=none;best-effort;restricted;single-numa-node
+	// +optional
+	TopologyManagerPolicy *string `json:"topologyManagerPolicy,omitempty"`
+
+	// AllowedUnsafeSysctls is the list of allowed unsafe sysctls or sysctl patterns, among kernel.shm*, kernel.msg*,
+	// kernel.sem, fs.mqueue.* and net.*.
+	// +optional
+	AllowedUnsafeSysctls []string `json:"allowedUnsafeSysctls,omitempty"`
+
+	// FailSwapOn makes the kubelet fail to start if swap is enabled on the node.
+	// +optional
+	FailSwapOn *bool `json:"failSwapOn,omitempty"`
+
+	// ContainerLogMaxSizeMB is the maximum size in MB of a container log file before it is rotated.
+	// +optional
+	ContainerLogMaxSizeMB *int32 `json:"containerLogMaxSizeMB,omitempty"`
+
+	// ContainerLogMaxFiles is the maximum number of container log files that can be present for a container.
+	// +kubebuilder:validation:Minimum=2
+	// +optional
+	ContainerLogMaxFiles *int32 `json:"containerLogMaxFiles,omitempty"`
+
+	// PodMaxPids is the maximum number of processes per pod.
+	// +kubebuilder:validation:Minimum=-1
+	// +optional
+	PodMaxPids *int32 `json:"podMaxPids,omitempty"`
+}
+
+// LinuxOSConfig specifies the custom Linux OS settings and configurations of the nodes of an agent pool.
+// See https://docs.microsoft.com/en-us/azure/aks/custom-node-configuration.
+type LinuxOSConfig struct {
+	// SwapFileSizeMB is the size in MB of a swap file created on each node. Requires FailSwapOn to be false in the
+	// kubelet configuration.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SwapFileSizeMB *int32 `json:"swapFileSizeMB,omitempty"`
+
+	// Sysctls specifies the sysctl settings of the nodes.
+	// +optional
+	Sysctls *SysctlConfig `json:"sysctls,omitempty"`
+
+	// TransparentHugePageDefrag specifies whether the kernel should make aggressive use of memory compaction to make
+	// more transparent huge pages available.
+	// +kubebuilder:validation:Enum=always;defer;defer+madvise;madvise;never
+	// +optional
+	TransparentHugePageDefrag *string `json:"transparentHugePageDefrag,omitempty"`
+
+	// TransparentHugePageEnabled specifies whether transparent huge pages are enabled.
+	// +kubebuilder:validation:Enum=always;madvise;never
+	// +optional
+	TransparentHugePageEnabled *string `json:"transparentHugePageEnabled,omitempty"`
+}
+
+// SysctlConfig specifies the sysctl settings of the nodes of an agent pool.
+type SysctlConfig struct {
+	// NetCoreSomaxconn - Sysctl setting net.core.somaxconn.
+	// +optional
+	NetCoreSomaxconn *int32 `json:"netCoreSomaxconn,omitempty"`
+	// NetCoreNetdevMaxBacklog - Sysctl setting net.core.netdev_max_backlog.
+	// +optional
+	NetCoreNetdevMaxBacklog *int32 `json:"netCoreNetdevMaxBacklog,omitempty"`
+	// NetCoreRmemDefault - Sysctl setting net.core.rmem_default.
+	// +optional
+	NetCoreRmemDefault *int32 `json:"netCoreRmemDefault,omitempty"`
+	// NetCoreRmemMax - Sysctl setting net.core.rmem_max.
+	// +optional
+	NetCoreRmemMax *int32 `json:"netCoreRmemMax,omitempty"`
+	// NetCoreWmemDefault - Sysctl setting net.core.wmem_default.
+	// +optional
+	NetCoreWmemDefault *int32 `json:"netCoreWmemDefault,omitempty"`
+	// NetCoreWmemMax - Sysctl setting net.core.wmem_max.
+	// +optional
+	NetCoreWmemMax *int32 `json:"netCoreWmemMax,omitempty"`
+	// NetCoreOptmemMax - Sysctl setting net.core.optmem_max.
+	// +optional
+	NetCoreOptmemMax *int32 `json:"netCoreOptmemMax,omitempty"`
+	// NetIpv4TCPMaxSynBacklog - Sysctl setting net.ipv4.tcp_max_syn_backlog.
+	// +optional
+	NetIpv4TCPMaxSynBacklog *int32 `json:"netIpv4TcpMaxSynBacklog,omitempty"`
+	// NetIpv4TCPMaxTwBuckets - Sysctl setting net.ipv4.tcp_max_tw_buckets.
+	// +optional
+	NetIpv4TCPMaxTwBuckets *int32 `json:"netIpv4TcpMaxTwBuckets,omitempty"`
+	// NetIpv4TCPFinTimeout - Sysctl setting net.ipv4.tcp_fin_timeout.
+	// +optional
+	NetIpv4TCPFinTimeout *int32 `json:"netIpv4TcpFinTimeout,omitempty"`
+	// NetIpv4TCPKeepaliveTime - Sysctl setting net.ipv4.tcp_keepalive_time.
+	// +optional
+	NetIpv4TCPKeepaliveTime *int32 `json:"netIpv4TcpKeepaliveTime,omitempty"`
+	// NetIpv4TCPKeepaliveProbes - Sysctl setting net.ipv4.tcp_keepalive_probes.
+	// +optional
+	NetIpv4TCPKeepaliveProbes *int32 `json:"netIpv4TcpKeepaliveProbes,omitempty"`
+	// NetIpv4TcpkeepaliveIntvl - Sysctl setting net.ipv4.tcp_keepalive_intvl.
+	// +optional
+	NetIpv4TcpkeepaliveIntvl *int32 `json:"netIpv4TcpkeepaliveIntvl,omitempty"`
+	// NetIpv4TCPTwReuse - Sysctl setting net.ipv4.tcp_tw_reuse.
+	// +optional
+	NetIpv4TCPTwReuse *bool `json:"netIpv4TcpTwReuse,omitempty"`
+	// NetIpv4IPLocalPortRange - Sysctl setting net.ipv4.ip_local_port_range, e.g. "32768 60999".
+	// +optional
+	NetIpv4IPLocalPortRange *string `json:"netIpv4IpLocalPortRange,omitempty"`
+	// NetIpv4NeighDefaultGcThresh1 - Sysctl setting net.ipv4.neigh.default.gc_thresh1.
+	// +optional
+	NetIpv4NeighDefaultGcThresh1 *int32 `json:"netIpv4NeighDefaultGcThresh1,omitempty"`
+	// NetIpv4NeighDefaultGcThresh2 - Sysctl setting net.ipv4.neigh.default.gc_thresh2.
+	// +optional
+	NetIpv4NeighDefaultGcThresh2 *int32 `json:"netIpv4NeighDefaultGcThresh2,omitempty"`
+	// NetIpv4NeighDefaultGcThresh3 - Sysctl setting net.ipv4.neigh.default.gc_thresh3.
+	// +optional
+	NetIpv4NeighDefaultGcThresh3 *int32 `json:"netIpv4NeighDefaultGcThresh3,omitempty"`
+	// NetNetfilterNfConntrackMax - Sysctl setting net.netfilter.nf_conntrack_max.
+	// +optional
+	NetNetfilterNfConntrackMax *int32 `json:"netNetfilterNfConntrackMax,omitempty"`
+	// NetNetfilterNfConntrackBuckets - Sysctl setting net.netfilter.nf_conntrack_buckets.
+	// +optional
+	NetNetfilterNfConntrackBuckets *int32 `json:"netNetfilterNfConntrackBuckets,omitempty"`
+	// FsInotifyMaxUserWatches - Sysctl setting fs.inotify.max_user_watches.
+	// +optional
+	FsInotifyMaxUserWatches *int32 `json:"fsInotifyMaxUserWatches,omitempty"`
+	// FsFileMax - Sysctl setting fs.file-max.
+	// +optional
+	FsFileMax *int32 `json:"fsFileMax,omitempty"`
+	// FsAioMaxNr - Sysctl setting fs.aio-max-nr.
+	// +optional
+	FsAioMaxNr *int32 `json:"fsAioMaxNr,omitempty"`
+	// FsNrOpen - Sysctl setting fs.nr_open.
+	// +optional
+	FsNrOpen *int32 `json:"fsNrOpen,omitempty"`
+	// KernelThreadsMax - Sysctl setting kernel.threads-max.
+	// +optional
+	KernelThreadsMax *int32 `json:"kernelThreadsMax,omitempty"`
+	// VMMaxMapCount - Sysctl setting vm.max_map_count.
+	// +optional
+	VMMaxMapCount *int32 `json:"vmMaxMapCount,omitempty"`
+	// VMSwappiness - Sysctl setting vm.swappiness.
+	// +optional
+	VMSwappiness *int32 `json:"vmSwappiness,omitempty"`
+	// VMVfsCachePressure - Sysctl setting vm.vfs_cache_pressure.
+	// +optional
+	VMVfsCachePressure *int32 `json:"vmVfsCachePressure,omitempty"`
+}
+
 // TaintEffect is the effect for a Kubernetes taint.
 type TaintEffect string
 
@@ -166,6 +389,10 @@ type AzureManagedMachinePoolStatus struct {
 	// next reconciliation loop.
 	// +optional
 	LongRunningOperationStates infrav1.Futures `json:"longRunningOperationStates,omitempty"`
+
+	// Version is the Kubernetes version the nodes of the agent pool run.
+	// +optional
+	Version string `json:"version,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -21,10 +21,12 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"strings"
 
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -36,7 +38,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-var validNodePublicPrefixID = regexp.MustCompile(`(?i)^/?subscriptions/[0-9a-f]{8}-([0-9a-f]{4}-){3}[0-9a-f]{12}/resourcegroups/[^/]+/providers/microsoft\.network/publicipprefixes/[^/]+$`)
+var (
+	validNodePublicPrefixID          = regexp.MustCompile(`(?i)^/?subscriptions/[0-9a-f]{8}-([0-9a-f]{4}-){3}[0-9a-f]{12}/resourcegroups/[^/]+/providers/microsoft\.network/publicipprefixes/[^/]+$`)
+	validProximityPlacementGroupID   = regexp.MustCompile(`(?i)^/?subscriptions/[0-9a-f]{8}-([0-9a-f]{4}-){3}[0-9a-f]{12}/resourcegroups/[^/]+/providers/microsoft\.compute/proximityplacementgroups/[^/]+$`)
+	validCPUCfsQuotaPeriod           = regexp.MustCompile(`^[0-9]+ms$`)
+	validMaxSurge                    = regexp.MustCompile(`^[1-9][0-9]*%?$`)
+	validAllowedUnsafeSysctlPatterns = []string{"kernel.shm*", "kernel.msg*", "kernel.sem", "fs.mqueue.*", "net.*"}
+)
 
 //+kubebuilder:webhook:path=/mutate-infrastructure-cluster-x-k8s-io-v1beta1-azuremanagedmachinepool,mutating=true,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=azuremanagedmachinepools,verbs=create;update,versions=v1beta1,name=default.azuremanagedmachinepools.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
 
@@ -56,7 +64,7 @@ func (m *AzureManagedMachinePool) Default(client client.Client) {
 	}
 }
 
-//+kubebuilder:webhook:verbs=update;delete,path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-azuremanagedmachinepool,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=azuremanagedmachinepools,versions=v1beta1,name=validation.azuremanagedmachinepools.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+//+kubebuilder:webhook:verbs=create;update;delete,path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-azuremanagedmachinepool,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=azuremanagedmachinepools,versions=v1beta1,name=validation.azuremanagedmachinepools.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
 func (m *AzureManagedMachinePool) ValidateCreate(client client.Client) error {
@@ -75,6 +83,12 @@ func (m *AzureManagedMachinePool) ValidateCreate(client client.Client) error {
 		m.validateNodeLabels,
 		m.validateNodePublicIPPrefixID,
 		m.validateEnableNodePublicIP,
+		m.validateKubeletConfig,
+		m.validateLinuxOSConfig,
+		m.validateSpotMaxPrice,
+		m.validateOSSKU,
+		m.validateProximityPlacementGroupID,
+		m.validateUpgradeSettings,
 	}
 
 	var errs []error
@@ -83,6 +97,7 @@ func (m *AzureManagedMachinePool) ValidateCreate(client client.Client) error {
 			errs = append(errs, err)
 		}
 	}
+	errs = append(errs, m.validateSubnets(client)...)
 
 	return kerrors.NewAggregate(errs)
 }
@@ -100,6 +115,12 @@ func (m *AzureManagedMachinePool) ValidateUpdate(oldRaw runtime.Object, client c
 				err.Error()))
 	}
 
+	if m.Spec.UpgradeSettings != nil {
+		if err := validateMaxSurge(field.NewPath("Spec", "UpgradeSettings", "MaxSurge"), m.Spec.UpgradeSettings.MaxSurge); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+
 	if err := validateStringPtrImmutable(
 		field.NewPath("Spec", "OSType"),
 		old.Spec.OSType,
@@ -225,6 +246,62 @@ func (m *AzureManagedMachinePool) ValidateUpdate(oldRaw runtime.Object, client c
 		m.Spec.NodePublicIPPrefixID); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if err := validateStringPtrImmutable(
+		field.NewPath("Spec", "NodeSubnetName"),
+		old.Spec.NodeSubnetName,
+		m.Spec.NodeSubnetName); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if err := validateStringPtrImmutable(
+		field.NewPath("Spec", "PodSubnetName"),
+		old.Spec.PodSubnetName,
+		m.Spec.PodSubnetName); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if !reflect.DeepEqual(m.Spec.KubeletConfig, old.Spec.KubeletConfig) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("Spec", "KubeletConfig"),
+				m.Spec.KubeletConfig, "field is immutable"),
+		)
+	}
+	if !reflect.DeepEqual(m.Spec.LinuxOSConfig, old.Spec.LinuxOSConfig) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("Spec", "LinuxOSConfig"),
+				m.Spec.LinuxOSConfig, "field is immutable"),
+		)
+	}
+	if (m.Spec.SpotMaxPrice == nil) != (old.Spec.SpotMaxPrice == nil) ||
+		(m.Spec.SpotMaxPrice != nil && m.Spec.SpotMaxPrice.Cmp(*old.Spec.SpotMaxPrice) != 0) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("Spec", "SpotMaxPrice"),
+				m.Spec.SpotMaxPrice, "field is immutable"),
+		)
+	}
+	if err := validateStringPtrImmutable(
+		field.NewPath("Spec", "OSSKU"),
+		old.Spec.OSSKU,
+		m.Spec.OSSKU); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if err := validateBoolPtrImmutable(
+		field.NewPath("Spec", "EnableEncryptionAtHost"),
+		old.Spec.EnableEncryptionAtHost,
+		m.Spec.EnableEncryptionAtHost); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if err := validateStringPtrImmutable(
+		field.NewPath("Spec", "ProximityPlacementGroupID"),
+		old.Spec.ProximityPlacementGroupID,
+		m.Spec.ProximityPlacementGroupID); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if err := validateBoolPtrImmutable(
+		field.NewPath("Spec", "EnableFIPS"),
+		old.Spec.EnableFIPS,
+		m.Spec.EnableFIPS); err != nil {
+		allErrs = append(allErrs, err)
+	}
 
 	if len(allErrs) != 0 {
 		return apierrors.NewInvalid(GroupVersion.WithKind("AzureManagedMachinePool").GroupKind(), m.Name, allErrs)
@@ -242,6 +319,96 @@ func (m *AzureManagedMachinePool) ValidateDelete(client client.Client) error {
 	return errors.Wrapf(m.validateLastSystemNodePool(client), "if the delete is triggered via owner MachinePool please refer to trouble shooting section in https://capz.sigs.k8s.io/topics/managedcluster.html")
 }
 
+// validateSubnets validates that the node and pod subnets of the agent pool are subnets of the virtual network of its
+// AzureManagedControlPlane, and that pod subnets are only used with the azure network plugin. The subnets are not
+// validated when the AzureManagedControlPlane can't be found yet, e.g. when it is created at the same time.
+func (m *AzureManagedMachinePool) validateSubnets(cli client.Client) []error {
+	if m.Spec.NodeSubnetName == nil && m.Spec.PodSubnetName == nil {
+		return nil
+	}
+
+	controlPlane, err := m.getManagedControlPlane(cli)
+	if err != nil {
+		return []error{err}
+	}
+	if controlPlane == nil {
+		return nil
+	}
+
+	subnetNames := map[string]struct{}{controlPlane.Spec.VirtualNetwork.Subnet.Name: {}}
+	for _, subnet := range controlPlane.Spec.VirtualNetwork.Subnets {
+		subnetNames[subnet.Name] = struct{}{}
+	}
+
+	var errs []error
+	for _, subnet := range []struct {
+		fldPath *field.Path
+		name    *string
+	}{
+		{field.NewPath("Spec", "NodeSubnetName"), m.Spec.NodeSubnetName},
+		{field.NewPath("Spec", "PodSubnetName"), m.Spec.PodSubnetName},
+	} {
+		if subnet.name == nil {
+			continue
+		}
+		if _, ok := subnetNames[*subnet.name]; !ok {
+			errs = append(errs, field.Invalid(subnet.fldPath, *subnet.name,
+				fmt.Sprintf("subnet is not a subnet of the virtual network of AzureManagedControlPlane %s", controlPlane.Name)))
+		}
+	}
+
+	// Pod subnets are only supported by Azure CNI. The network plugin is unknown until an adopted cluster is adopted.
+	if m.Spec.PodSubnetName != nil && controlPlane.Spec.NetworkPlugin != nil && *controlPlane.Spec.NetworkPlugin != "azure" {
+		errs = append(errs, field.Forbidden(field.NewPath("Spec", "PodSubnetName"),
+			fmt.Sprintf("pod subnets require the azure network plugin, AzureManagedControlPlane %s uses %s", controlPlane.Name, *controlPlane.Spec.NetworkPlugin)))
+	}
+	return errs
+}
+
+// getManagedControlPlane returns the AzureManagedControlPlane of the cluster of the agent pool, or nil if the cluster or
+// its control plane can't be found.
+func (m *AzureManagedMachinePool) getManagedControlPlane(cli client.Client) (*AzureManagedControlPlane, error) {
+	ctx := context.Background()
+
+	clusterName, ok := m.Labels[clusterv1.ClusterLabelName]
+	if !ok {
+		return nil, nil
+	}
+
+	ownerCluster := &clusterv1.Cluster{}
+	key := client.ObjectKey{
+		Namespace: m.Namespace,
+		Name:      clusterName,
+	}
+	if err := cli.Get(ctx, key, ownerCluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	ref := ownerCluster.Spec.ControlPlaneRef
+	if ref == nil || ref.Kind != "AzureManagedControlPlane" {
+		return nil, nil
+	}
+
+	controlPlane := &AzureManagedControlPlane{}
+	key = client.ObjectKey{
+		Namespace: ref.Namespace,
+		Name:      ref.Name,
+	}
+	if key.Namespace == "" {
+		key.Namespace = m.Namespace
+	}
+	if err := cli.Get(ctx, key, controlPlane); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return controlPlane, nil
+}
+
 // validateLastSystemNodePool is used to check if the existing system node pool is the last system node pool.
 // If it is a last system node pool it cannot be deleted or mutated to user node pool as AKS expects min 1 system node pool.
 func (m *AzureManagedMachinePool) validateLastSystemNodePool(cli client.Client) error {
@@ -356,6 +523,132 @@ func (m *AzureManagedMachinePool) validateEnableNodePublicIP() error {
 	return nil
 }
 
+func (m *AzureManagedMachinePool) validateUpgradeSettings() error {
+	if m.Spec.UpgradeSettings == nil {
+		return nil
+	}
+	if err := validateMaxSurge(field.NewPath("Spec", "UpgradeSettings", "MaxSurge"), m.Spec.UpgradeSettings.MaxSurge); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validateMaxSurge validates the max surge of the upgrades of node pools, which AKS accepts as a positive number or
+// percentage of nodes.
+func validateMaxSurge(fldPath *field.Path, maxSurge *string) *field.Error {
+	if maxSurge != nil && !validMaxSurge.MatchString(*maxSurge) {
+		return field.Invalid(fldPath, *maxSurge, "must be a positive integer or percentage, e.g. 5 or 33%")
+	}
+	return nil
+}
+
+func (m *AzureManagedMachinePool) validateKubeletConfig() error {
+	kubeletConfig := m.Spec.KubeletConfig
+	if kubeletConfig == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("Spec", "KubeletConfig")
+	if kubeletConfig.ImageGcHighThreshold != nil && kubeletConfig.ImageGcLowThreshold != nil &&
+		*kubeletConfig.ImageGcLowThreshold > *kubeletConfig.ImageGcHighThreshold {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ImageGcLowThreshold"), *kubeletConfig.ImageGcLowThreshold,
+			"must not be greater than ImageGcHighThreshold"))
+	}
+	if kubeletConfig.CPUCfsQuotaPeriod != nil && !validCPUCfsQuotaPeriod.MatchString(*kubeletConfig.CPUCfsQuotaPeriod) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("CPUCfsQuotaPeriod"), *kubeletConfig.CPUCfsQuotaPeriod,
+			"must be a number of milliseconds with an 'ms' suffix"))
+	}
+	for i, sysctl := range kubeletConfig.AllowedUnsafeSysctls {
+		if !isAllowedUnsafeSysctl(sysctl) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("AllowedUnsafeSysctls").Index(i), sysctl, validAllowedUnsafeSysctlPatterns))
+		}
+	}
+
+	if len(allErrs) > 0 {
+		return kerrors.NewAggregate(allErrs.ToAggregate().Errors())
+	}
+	return nil
+}
+
+// isAllowedUnsafeSysctl returns true if the sysctl or sysctl pattern belongs to one of the groups of unsafe sysctls
+// allowed by AKS.
+func isAllowedUnsafeSysctl(sysctl string) bool {
+	for _, pattern := range validAllowedUnsafeSysctlPatterns {
+		if prefix := strings.TrimSuffix(pattern, "*"); prefix != pattern {
+			if strings.HasPrefix(sysctl, prefix) {
+				return true
+			}
+		} else if sysctl == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *AzureManagedMachinePool) validateLinuxOSConfig() error {
+	if m.Spec.LinuxOSConfig == nil {
+		return nil
+	}
+
+	if m.Spec.OSType != nil && *m.Spec.OSType == azure.WindowsOS {
+		return field.Forbidden(
+			field.NewPath("Spec", "LinuxOSConfig"),
+			"LinuxOSConfig is not supported by Windows node pools")
+	}
+
+	if m.Spec.LinuxOSConfig.SwapFileSizeMB != nil &&
+		(m.Spec.KubeletConfig == nil || m.Spec.KubeletConfig.FailSwapOn == nil || *m.Spec.KubeletConfig.FailSwapOn) {
+		return field.Invalid(
+			field.NewPath("Spec", "LinuxOSConfig", "SwapFileSizeMB"),
+			m.Spec.LinuxOSConfig.SwapFileSizeMB,
+			"KubeletConfig.FailSwapOn must be set to false to enable swap files")
+	}
+
+	return nil
+}
+
+func (m *AzureManagedMachinePool) validateSpotMaxPrice() error {
+	if m.Spec.SpotMaxPrice == nil {
+		return nil
+	}
+
+	if to.String(m.Spec.ScaleSetPriority) != "Spot" {
+		return field.Forbidden(
+			field.NewPath("Spec", "SpotMaxPrice"),
+			"SpotMaxPrice can only be set when ScaleSetPriority is Spot")
+	}
+
+	if m.Spec.SpotMaxPrice.Sign() <= 0 && m.Spec.SpotMaxPrice.Cmp(resource.MustParse("-1")) != 0 {
+		return field.Invalid(
+			field.NewPath("Spec", "SpotMaxPrice"),
+			m.Spec.SpotMaxPrice.String(),
+			"must be greater than zero or -1")
+	}
+
+	return nil
+}
+
+func (m *AzureManagedMachinePool) validateOSSKU() error {
+	if m.Spec.OSSKU != nil && m.Spec.OSType != nil && *m.Spec.OSType == azure.WindowsOS {
+		return field.Forbidden(
+			field.NewPath("Spec", "OSSKU"),
+			"OSSKU is not supported by Windows node pools")
+	}
+
+	return nil
+}
+
+func (m *AzureManagedMachinePool) validateProximityPlacementGroupID() error {
+	if m.Spec.ProximityPlacementGroupID != nil && !validProximityPlacementGroupID.MatchString(*m.Spec.ProximityPlacementGroupID) {
+		return field.Invalid(
+			field.NewPath("Spec", "ProximityPlacementGroupID"),
+			m.Spec.ProximityPlacementGroupID,
+			fmt.Sprintf("resource ID must match %q", validProximityPlacementGroupID.String()))
+	}
+	return nil
+}
+
 func ensureStringSlicesAreEqual(a []string, b []string) bool {
 	if len(a) != len(b) {
 		return false
@@ -22,12 +22,17 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2021-05-01/containerservice"
 	"github.com/Azure/go-autorest/autorest/to"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	utilfeature "k8s.io/component-base/featuregate/testing"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/feature"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestAzureManagedMachinePoolDefaultingWebhook(t *testing.T) {
@@ -438,6 +443,32 @@ func TestAzureManagedMachinePoolUpdatingWebhook(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Cannot change NodeSubnetName of the agentpool",
+			new: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					NodeSubnetName: to.StringPtr("pool1-nodes"),
+				},
+			},
+			old: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Cannot change PodSubnetName of the agentpool",
+			new: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					PodSubnetName: to.StringPtr("pool1-pods-2"),
+				},
+			},
+			old: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					PodSubnetName: to.StringPtr("pool1-pods"),
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "NodeTaints are mutable",
 			new: &AzureManagedMachinePool{
@@ -464,6 +495,142 @@ func TestAzureManagedMachinePoolUpdatingWebhook(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Cannot change KubeletConfig of the agentpool",
+			new: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					KubeletConfig: &KubeletConfig{CPUManagerPolicy: to.StringPtr("static")},
+				},
+			},
+			old: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					KubeletConfig: &KubeletConfig{CPUManagerPolicy: to.StringPtr("none")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Cannot add LinuxOSConfig to the agentpool",
+			new: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					LinuxOSConfig: &LinuxOSConfig{TransparentHugePageEnabled: to.StringPtr("never")},
+				},
+			},
+			old: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Cannot change SpotMaxPrice of the agentpool",
+			new: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					SpotMaxPrice: resourceQuantityPtr("0.6"),
+				},
+			},
+			old: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					SpotMaxPrice: resourceQuantityPtr("0.5"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unchanged SpotMaxPrice in a different format should not result in an error",
+			new: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					SpotMaxPrice: resourceQuantityPtr("500m"),
+				},
+			},
+			old: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					SpotMaxPrice: resourceQuantityPtr("0.5"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Cannot change OSSKU of the agentpool",
+			new: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					OSSKU: to.StringPtr("CBLMariner"),
+				},
+			},
+			old: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					OSSKU: to.StringPtr("Ubuntu"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Cannot enable EnableEncryptionAtHost on the agentpool",
+			new: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					EnableEncryptionAtHost: to.BoolPtr(true),
+				},
+			},
+			old: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Cannot change ProximityPlacementGroupID of the agentpool",
+			new: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					ProximityPlacementGroupID: to.StringPtr("ppg-2"),
+				},
+			},
+			old: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					ProximityPlacementGroupID: to.StringPtr("ppg-1"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Cannot disable EnableFIPS on the agentpool",
+			new: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					EnableFIPS: to.BoolPtr(false),
+				},
+			},
+			old: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					EnableFIPS: to.BoolPtr(true),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "UpgradeSettings are mutable",
+			new: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					UpgradeSettings: &ManagedMachinePoolUpgradeSettings{MaxSurge: to.StringPtr("33%")},
+				},
+			},
+			old: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					UpgradeSettings: &ManagedMachinePoolUpgradeSettings{MaxSurge: to.StringPtr("1")},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Cannot set an invalid max surge",
+			new: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					UpgradeSettings: &ManagedMachinePoolUpgradeSettings{MaxSurge: to.StringPtr("33 %")},
+				},
+			},
+			old: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					UpgradeSettings: &ManagedMachinePoolUpgradeSettings{MaxSurge: to.StringPtr("1")},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "Can't add a node label that begins with kubernetes.azure.com",
 			new: &AzureManagedMachinePool{
@@ -776,6 +943,173 @@ func TestAzureManagedMachinePool_ValidateCreate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid kubelet and Linux OS configuration",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					KubeletConfig: &KubeletConfig{
+						CPUCfsQuotaPeriod:    to.StringPtr("100ms"),
+						ImageGcHighThreshold: to.Int32Ptr(85),
+						ImageGcLowThreshold:  to.Int32Ptr(80),
+						AllowedUnsafeSysctls: []string{"kernel.shm*", "kernel.sem", "net.ipv4.route.min_pmtu"},
+						FailSwapOn:           to.BoolPtr(false),
+					},
+					LinuxOSConfig: &LinuxOSConfig{
+						SwapFileSizeMB: to.Int32Ptr(1500),
+						Sysctls:        &SysctlConfig{NetCoreSomaxconn: to.Int32Ptr(4096)},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "kubelet image GC low threshold greater than high threshold",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					KubeletConfig: &KubeletConfig{
+						ImageGcHighThreshold: to.Int32Ptr(80),
+						ImageGcLowThreshold:  to.Int32Ptr(85),
+					},
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
+		{
+			name: "kubelet CPU CFS quota period without ms suffix",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					KubeletConfig: &KubeletConfig{
+						CPUCfsQuotaPeriod: to.StringPtr("100"),
+					},
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
+		{
+			name: "valid max surge percentage",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					UpgradeSettings: &ManagedMachinePoolUpgradeSettings{MaxSurge: to.StringPtr("33%")},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "max surge which is not a positive integer or percentage",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					UpgradeSettings: &ManagedMachinePoolUpgradeSettings{MaxSurge: to.StringPtr("0")},
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
+		{
+			name: "kubelet unsafe sysctl not allowed by AKS",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					KubeletConfig: &KubeletConfig{
+						AllowedUnsafeSysctls: []string{"kernel.sem*"},
+					},
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
+		{
+			name: "Linux OS configuration not allowed on Windows pools",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					OSType:        to.StringPtr(azure.WindowsOS),
+					LinuxOSConfig: &LinuxOSConfig{TransparentHugePageEnabled: to.StringPtr("never")},
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
+		{
+			name: "swap file requires kubelet FailSwapOn disabled",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					LinuxOSConfig: &LinuxOSConfig{SwapFileSizeMB: to.Int32Ptr(1500)},
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
+		{
+			name: "OSSKU not allowed on Windows pools",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					OSType: to.StringPtr(azure.WindowsOS),
+					OSSKU:  to.StringPtr("Ubuntu"),
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
+		{
+			name: "spot max price with Spot priority",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					ScaleSetPriority: to.StringPtr("Spot"),
+					SpotMaxPrice:     resourceQuantityPtr("0.5"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "spot max price of -1 with Spot priority",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					ScaleSetPriority: to.StringPtr("Spot"),
+					SpotMaxPrice:     resourceQuantityPtr("-1"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "spot max price without Spot priority",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					SpotMaxPrice: resourceQuantityPtr("0.5"),
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
+		{
+			name: "negative spot max price",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					ScaleSetPriority: to.StringPtr("Spot"),
+					SpotMaxPrice:     resourceQuantityPtr("-0.5"),
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
+		{
+			name: "valid proximity placement group",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					ProximityPlacementGroupID: to.StringPtr("/subscriptions/11111111-2222-aaaa-bbbb-cccccccccccc/resourceGroups/ppg-test/providers/Microsoft.Compute/proximityPlacementGroups/ppg"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid proximity placement group",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					ProximityPlacementGroupID: to.StringPtr("not a valid resource ID"),
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
 	}
 	var client client.Client
 	for _, tc := range tests {
@@ -792,6 +1126,103 @@ func TestAzureManagedMachinePool_ValidateCreate(t *testing.T) {
 	}
 }
 
+func TestAzureManagedMachinePool_ValidateSubnets(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.AKS, true)()
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(AddToScheme(scheme)).To(Succeed())
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+
+	tests := []struct {
+		name           string
+		networkPlugin  string
+		nodeSubnetName *string
+		podSubnetName  *string
+		noControlPlane bool
+		errorLen       int
+	}{
+		{
+			name:           "node and pod subnets of the virtual network",
+			networkPlugin:  "azure",
+			nodeSubnetName: to.StringPtr("pool1-nodes"),
+			podSubnetName:  to.StringPtr("pool1-pods"),
+		},
+		{
+			name:           "default node subnet of the virtual network",
+			networkPlugin:  "kubenet",
+			nodeSubnetName: to.StringPtr("nodes"),
+		},
+		{
+			name:           "node subnet not in the virtual network",
+			networkPlugin:  "azure",
+			nodeSubnetName: to.StringPtr("unknown"),
+			errorLen:       1,
+		},
+		{
+			name:          "pod subnet not in the virtual network",
+			networkPlugin: "azure",
+			podSubnetName: to.StringPtr("unknown"),
+			errorLen:      1,
+		},
+		{
+			name:          "pod subnet with the kubenet network plugin",
+			networkPlugin: "kubenet",
+			podSubnetName: to.StringPtr("pool1-pods"),
+			errorLen:      1,
+		},
+		{
+			name:           "control plane not created yet",
+			nodeSubnetName: to.StringPtr("unknown"),
+			noControlPlane: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+				Spec: clusterv1.ClusterSpec{
+					ControlPlaneRef: &corev1.ObjectReference{Kind: "AzureManagedControlPlane", Name: "my-cluster-control-plane"},
+				},
+			}
+			amcp := &AzureManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-control-plane", Namespace: "default"},
+				Spec: AzureManagedControlPlaneSpec{
+					NetworkPlugin: to.StringPtr(tc.networkPlugin),
+					VirtualNetwork: ManagedControlPlaneVirtualNetwork{
+						Subnet: ManagedControlPlaneSubnet{Name: "nodes"},
+						Subnets: []ManagedControlPlaneSubnet{
+							{Name: "pool1-nodes"},
+							{Name: "pool1-pods"},
+						},
+					},
+				},
+			}
+			objs := []client.Object{cluster}
+			if !tc.noControlPlane {
+				objs = append(objs, amcp)
+			}
+			cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+
+			ammp := getKnownValidAzureManagedMachinePool()
+			ammp.Namespace = "default"
+			ammp.Labels = map[string]string{clusterv1.ClusterLabelName: "my-cluster"}
+			ammp.Spec.NodeSubnetName = tc.nodeSubnetName
+			ammp.Spec.PodSubnetName = tc.podSubnetName
+
+			err := ammp.ValidateCreate(cli)
+			if tc.errorLen > 0 {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(HaveLen(tc.errorLen))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestAzureManagedMachinePool_ValidateCreateFailure(t *testing.T) {
 	g := NewWithT(t)
 
@@ -828,3 +1259,8 @@ func getKnownValidAzureManagedMachinePool() *AzureManagedMachinePool {
 		},
 	}
 }
+
+func resourceQuantityPtr(value string) *resource.Quantity {
+	quantity := resource.MustParse(value)
+	return &quantity
+}
@@ -108,6 +108,106 @@ func (in *AddonProfile) DeepCopy() *AddonProfile {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoScalerProfile) DeepCopyInto(out *AutoScalerProfile) {
+	*out = *in
+	if in.BalanceSimilarNodeGroups != nil {
+		in, out := &in.BalanceSimilarNodeGroups, &out.BalanceSimilarNodeGroups
+		*out = new(string)
+		**out = **in
+	}
+	if in.Expander != nil {
+		in, out := &in.Expander, &out.Expander
+		*out = new(Expander)
+		**out = **in
+	}
+	if in.MaxEmptyBulkDelete != nil {
+		in, out := &in.MaxEmptyBulkDelete, &out.MaxEmptyBulkDelete
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxGracefulTerminationSec != nil {
+		in, out := &in.MaxGracefulTerminationSec, &out.MaxGracefulTerminationSec
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxNodeProvisionTime != nil {
+		in, out := &in.MaxNodeProvisionTime, &out.MaxNodeProvisionTime
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxTotalUnreadyPercentage != nil {
+		in, out := &in.MaxTotalUnreadyPercentage, &out.MaxTotalUnreadyPercentage
+		*out = new(string)
+		**out = **in
+	}
+	if in.NewPodScaleUpDelay != nil {
+		in, out := &in.NewPodScaleUpDelay, &out.NewPodScaleUpDelay
+		*out = new(string)
+		**out = **in
+	}
+	if in.OkTotalUnreadyCount != nil {
+		in, out := &in.OkTotalUnreadyCount, &out.OkTotalUnreadyCount
+		*out = new(string)
+		**out = **in
+	}
+	if in.ScanInterval != nil {
+		in, out := &in.ScanInterval, &out.ScanInterval
+		*out = new(string)
+		**out = **in
+	}
+	if in.ScaleDownDelayAfterAdd != nil {
+		in, out := &in.ScaleDownDelayAfterAdd, &out.ScaleDownDelayAfterAdd
+		*out = new(string)
+		**out = **in
+	}
+	if in.ScaleDownDelayAfterDelete != nil {
+		in, out := &in.ScaleDownDelayAfterDelete, &out.ScaleDownDelayAfterDelete
+		*out = new(string)
+		**out = **in
+	}
+	if in.ScaleDownDelayAfterFailure != nil {
+		in, out := &in.ScaleDownDelayAfterFailure, &out.ScaleDownDelayAfterFailure
+		*out = new(string)
+		**out = **in
+	}
+	if in.ScaleDownUnneededTime != nil {
+		in, out := &in.ScaleDownUnneededTime, &out.ScaleDownUnneededTime
+		*out = new(string)
+		**out = **in
+	}
+	if in.ScaleDownUnreadyTime != nil {
+		in, out := &in.ScaleDownUnreadyTime, &out.ScaleDownUnreadyTime
+		*out = new(string)
+		**out = **in
+	}
+	if in.ScaleDownUtilizationThreshold != nil {
+		in, out := &in.ScaleDownUtilizationThreshold, &out.ScaleDownUtilizationThreshold
+		*out = new(string)
+		**out = **in
+	}
+	if in.SkipNodesWithLocalStorage != nil {
+		in, out := &in.SkipNodesWithLocalStorage, &out.SkipNodesWithLocalStorage
+		*out = new(string)
+		**out = **in
+	}
+	if in.SkipNodesWithSystemPods != nil {
+		in, out := &in.SkipNodesWithSystemPods, &out.SkipNodesWithSystemPods
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoScalerProfile.
+func (in *AutoScalerProfile) DeepCopy() *AutoScalerProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoScalerProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureMachinePool) DeepCopyInto(out *AzureMachinePool) {
 	*out = *in
@@ -693,6 +793,44 @@ func (in *AzureManagedControlPlaneSpec) DeepCopyInto(out *AzureManagedControlPla
 		*out = new(APIServerAccessProfile)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AutoScalerProfile != nil {
+		in, out := &in.AutoScalerProfile, &out.AutoScalerProfile
+		*out = new(AutoScalerProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AutoUpgradeProfile != nil {
+		in, out := &in.AutoUpgradeProfile, &out.AutoUpgradeProfile
+		*out = new(ManagedClusterAutoUpgradeProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceConfigurations != nil {
+		in, out := &in.MaintenanceConfigurations, &out.MaintenanceConfigurations
+		*out = make([]MaintenanceConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Identity != nil {
+		in, out := &in.Identity, &out.Identity
+		*out = new(Identity)
+		**out = **in
+	}
+	if in.OIDCIssuerProfile != nil {
+		in, out := &in.OIDCIssuerProfile, &out.OIDCIssuerProfile
+		*out = new(OIDCIssuerProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityProfile != nil {
+		in, out := &in.SecurityProfile, &out.SecurityProfile
+		*out = new(ManagedControlPlaneSecurityProfile)
+		(*in).DeepCopyInto(*out)
+	}
+
+	if in.NodePoolUpgradeSettings != nil {
+		in, out := &in.NodePoolUpgradeSettings, &out.NodePoolUpgradeSettings
+		*out = new(ManagedControlPlaneNodePoolUpgradeSettings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedControlPlaneSpec.
@@ -720,6 +858,11 @@ func (in *AzureManagedControlPlaneStatus) DeepCopyInto(out *AzureManagedControlP
 		*out = make(apiv1beta1.Futures, len(*in))
 		copy(*out, *in)
 	}
+	if in.UpgradingNodePools != nil {
+		in, out := &in.UpgradingNodePools, &out.UpgradingNodePools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedControlPlaneStatus.
@@ -866,6 +1009,56 @@ func (in *AzureManagedMachinePoolSpec) DeepCopyInto(out *AzureManagedMachinePool
 		*out = new(string)
 		**out = **in
 	}
+	if in.NodeSubnetName != nil {
+		in, out := &in.NodeSubnetName, &out.NodeSubnetName
+		*out = new(string)
+		**out = **in
+	}
+	if in.PodSubnetName != nil {
+		in, out := &in.PodSubnetName, &out.PodSubnetName
+		*out = new(string)
+		**out = **in
+	}
+	if in.KubeletConfig != nil {
+		in, out := &in.KubeletConfig, &out.KubeletConfig
+		*out = new(KubeletConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LinuxOSConfig != nil {
+		in, out := &in.LinuxOSConfig, &out.LinuxOSConfig
+		*out = new(LinuxOSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradeSettings != nil {
+		in, out := &in.UpgradeSettings, &out.UpgradeSettings
+		*out = new(ManagedMachinePoolUpgradeSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SpotMaxPrice != nil {
+		in, out := &in.SpotMaxPrice, &out.SpotMaxPrice
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.OSSKU != nil {
+		in, out := &in.OSSKU, &out.OSSKU
+		*out = new(string)
+		**out = **in
+	}
+	if in.EnableEncryptionAtHost != nil {
+		in, out := &in.EnableEncryptionAtHost, &out.EnableEncryptionAtHost
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ProximityPlacementGroupID != nil {
+		in, out := &in.ProximityPlacementGroupID, &out.ProximityPlacementGroupID
+		*out = new(string)
+		**out = **in
+	}
+	if in.EnableFIPS != nil {
+		in, out := &in.EnableFIPS, &out.EnableFIPS
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedMachinePoolSpec.
@@ -915,6 +1108,126 @@ func (in *AzureManagedMachinePoolStatus) DeepCopy() *AzureManagedMachinePoolStat
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Identity) DeepCopyInto(out *Identity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Identity.
+func (in *Identity) DeepCopy() *Identity {
+	if in == nil {
+		return nil
+	}
+	out := new(Identity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletConfig) DeepCopyInto(out *KubeletConfig) {
+	*out = *in
+	if in.CPUManagerPolicy != nil {
+		in, out := &in.CPUManagerPolicy, &out.CPUManagerPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.CPUCfsQuota != nil {
+		in, out := &in.CPUCfsQuota, &out.CPUCfsQuota
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CPUCfsQuotaPeriod != nil {
+		in, out := &in.CPUCfsQuotaPeriod, &out.CPUCfsQuotaPeriod
+		*out = new(string)
+		**out = **in
+	}
+	if in.ImageGcHighThreshold != nil {
+		in, out := &in.ImageGcHighThreshold, &out.ImageGcHighThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ImageGcLowThreshold != nil {
+		in, out := &in.ImageGcLowThreshold, &out.ImageGcLowThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TopologyManagerPolicy != nil {
+		in, out := &in.TopologyManagerPolicy, &out.TopologyManagerPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.AllowedUnsafeSysctls != nil {
+		in, out := &in.AllowedUnsafeSysctls, &out.AllowedUnsafeSysctls
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailSwapOn != nil {
+		in, out := &in.FailSwapOn, &out.FailSwapOn
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ContainerLogMaxSizeMB != nil {
+		in, out := &in.ContainerLogMaxSizeMB, &out.ContainerLogMaxSizeMB
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ContainerLogMaxFiles != nil {
+		in, out := &in.ContainerLogMaxFiles, &out.ContainerLogMaxFiles
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PodMaxPids != nil {
+		in, out := &in.PodMaxPids, &out.PodMaxPids
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletConfig.
+func (in *KubeletConfig) DeepCopy() *KubeletConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinuxOSConfig) DeepCopyInto(out *LinuxOSConfig) {
+	*out = *in
+	if in.SwapFileSizeMB != nil {
+		in, out := &in.SwapFileSizeMB, &out.SwapFileSizeMB
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Sysctls != nil {
+		in, out := &in.Sysctls, &out.Sysctls
+		*out = new(SysctlConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TransparentHugePageDefrag != nil {
+		in, out := &in.TransparentHugePageDefrag, &out.TransparentHugePageDefrag
+		*out = new(string)
+		**out = **in
+	}
+	if in.TransparentHugePageEnabled != nil {
+		in, out := &in.TransparentHugePageEnabled, &out.TransparentHugePageEnabled
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinuxOSConfig.
+func (in *LinuxOSConfig) DeepCopy() *LinuxOSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LinuxOSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoadBalancerProfile) DeepCopyInto(out *LoadBalancerProfile) {
 	*out = *in
@@ -980,6 +1293,120 @@ func (in *MachineRollingUpdateDeployment) DeepCopy() *MachineRollingUpdateDeploy
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceConfiguration) DeepCopyInto(out *MaintenanceConfiguration) {
+	*out = *in
+	if in.TimeInWeek != nil {
+		in, out := &in.TimeInWeek, &out.TimeInWeek
+		*out = make([]TimeInWeek, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NotAllowedTime != nil {
+		in, out := &in.NotAllowedTime, &out.NotAllowedTime
+		*out = make([]TimeSpan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceConfiguration.
+func (in *MaintenanceConfiguration) DeepCopy() *MaintenanceConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedClusterAutoUpgradeProfile) DeepCopyInto(out *ManagedClusterAutoUpgradeProfile) {
+	*out = *in
+	if in.UpgradeChannel != nil {
+		in, out := &in.UpgradeChannel, &out.UpgradeChannel
+		*out = new(UpgradeChannel)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedClusterAutoUpgradeProfile.
+func (in *ManagedClusterAutoUpgradeProfile) DeepCopy() *ManagedClusterAutoUpgradeProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedClusterAutoUpgradeProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedControlPlaneNodePoolUpgradeSettings) DeepCopyInto(out *ManagedControlPlaneNodePoolUpgradeSettings) {
+	*out = *in
+	if in.Order != nil {
+		in, out := &in.Order, &out.Order
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedControlPlaneNodePoolUpgradeSettings.
+func (in *ManagedControlPlaneNodePoolUpgradeSettings) DeepCopy() *ManagedControlPlaneNodePoolUpgradeSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedControlPlaneNodePoolUpgradeSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedControlPlaneSecurityProfile) DeepCopyInto(out *ManagedControlPlaneSecurityProfile) {
+	*out = *in
+	if in.WorkloadIdentity != nil {
+		in, out := &in.WorkloadIdentity, &out.WorkloadIdentity
+		*out = new(ManagedControlPlaneSecurityProfileWorkloadIdentity)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedControlPlaneSecurityProfile.
+func (in *ManagedControlPlaneSecurityProfile) DeepCopy() *ManagedControlPlaneSecurityProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedControlPlaneSecurityProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedControlPlaneSecurityProfileWorkloadIdentity) DeepCopyInto(out *ManagedControlPlaneSecurityProfileWorkloadIdentity) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedControlPlaneSecurityProfileWorkloadIdentity.
+func (in *ManagedControlPlaneSecurityProfileWorkloadIdentity) DeepCopy() *ManagedControlPlaneSecurityProfileWorkloadIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedControlPlaneSecurityProfileWorkloadIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagedControlPlaneSubnet) DeepCopyInto(out *ManagedControlPlaneSubnet) {
 	*out = *in
@@ -990,6 +1417,11 @@ func (in *ManagedControlPlaneSubnet) DeepCopyInto(out *ManagedControlPlaneSubnet
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RouteTable != nil {
+		in, out := &in.RouteTable, &out.RouteTable
+		*out = new(apiv1beta1.RouteTable)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedControlPlaneSubnet.
@@ -1006,6 +1438,13 @@ func (in *ManagedControlPlaneSubnet) DeepCopy() *ManagedControlPlaneSubnet {
 func (in *ManagedControlPlaneVirtualNetwork) DeepCopyInto(out *ManagedControlPlaneVirtualNetwork) {
 	*out = *in
 	in.Subnet.DeepCopyInto(&out.Subnet)
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]ManagedControlPlaneSubnet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedControlPlaneVirtualNetwork.
@@ -1043,6 +1482,46 @@ func (in *ManagedMachinePoolScaling) DeepCopy() *ManagedMachinePoolScaling {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedMachinePoolUpgradeSettings) DeepCopyInto(out *ManagedMachinePoolUpgradeSettings) {
+	*out = *in
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedMachinePoolUpgradeSettings.
+func (in *ManagedMachinePoolUpgradeSettings) DeepCopy() *ManagedMachinePoolUpgradeSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedMachinePoolUpgradeSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCIssuerProfile) DeepCopyInto(out *OIDCIssuerProfile) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCIssuerProfile.
+func (in *OIDCIssuerProfile) DeepCopy() *OIDCIssuerProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCIssuerProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SKU) DeepCopyInto(out *SKU) {
 	*out = *in
@@ -1058,6 +1537,161 @@ func (in *SKU) DeepCopy() *SKU {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SysctlConfig) DeepCopyInto(out *SysctlConfig) {
+	*out = *in
+	if in.NetCoreSomaxconn != nil {
+		in, out := &in.NetCoreSomaxconn, &out.NetCoreSomaxconn
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetCoreNetdevMaxBacklog != nil {
+		in, out := &in.NetCoreNetdevMaxBacklog, &out.NetCoreNetdevMaxBacklog
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetCoreRmemDefault != nil {
+		in, out := &in.NetCoreRmemDefault, &out.NetCoreRmemDefault
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetCoreRmemMax != nil {
+		in, out := &in.NetCoreRmemMax, &out.NetCoreRmemMax
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetCoreWmemDefault != nil {
+		in, out := &in.NetCoreWmemDefault, &out.NetCoreWmemDefault
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetCoreWmemMax != nil {
+		in, out := &in.NetCoreWmemMax, &out.NetCoreWmemMax
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetCoreOptmemMax != nil {
+		in, out := &in.NetCoreOptmemMax, &out.NetCoreOptmemMax
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetIpv4TCPMaxSynBacklog != nil {
+		in, out := &in.NetIpv4TCPMaxSynBacklog, &out.NetIpv4TCPMaxSynBacklog
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetIpv4TCPMaxTwBuckets != nil {
+		in, out := &in.NetIpv4TCPMaxTwBuckets, &out.NetIpv4TCPMaxTwBuckets
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetIpv4TCPFinTimeout != nil {
+		in, out := &in.NetIpv4TCPFinTimeout, &out.NetIpv4TCPFinTimeout
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetIpv4TCPKeepaliveTime != nil {
+		in, out := &in.NetIpv4TCPKeepaliveTime, &out.NetIpv4TCPKeepaliveTime
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetIpv4TCPKeepaliveProbes != nil {
+		in, out := &in.NetIpv4TCPKeepaliveProbes, &out.NetIpv4TCPKeepaliveProbes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetIpv4TcpkeepaliveIntvl != nil {
+		in, out := &in.NetIpv4TcpkeepaliveIntvl, &out.NetIpv4TcpkeepaliveIntvl
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetIpv4TCPTwReuse != nil {
+		in, out := &in.NetIpv4TCPTwReuse, &out.NetIpv4TCPTwReuse
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NetIpv4IPLocalPortRange != nil {
+		in, out := &in.NetIpv4IPLocalPortRange, &out.NetIpv4IPLocalPortRange
+		*out = new(string)
+		**out = **in
+	}
+	if in.NetIpv4NeighDefaultGcThresh1 != nil {
+		in, out := &in.NetIpv4NeighDefaultGcThresh1, &out.NetIpv4NeighDefaultGcThresh1
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetIpv4NeighDefaultGcThresh2 != nil {
+		in, out := &in.NetIpv4NeighDefaultGcThresh2, &out.NetIpv4NeighDefaultGcThresh2
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetIpv4NeighDefaultGcThresh3 != nil {
+		in, out := &in.NetIpv4NeighDefaultGcThresh3, &out.NetIpv4NeighDefaultGcThresh3
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetNetfilterNfConntrackMax != nil {
+		in, out := &in.NetNetfilterNfConntrackMax, &out.NetNetfilterNfConntrackMax
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetNetfilterNfConntrackBuckets != nil {
+		in, out := &in.NetNetfilterNfConntrackBuckets, &out.NetNetfilterNfConntrackBuckets
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FsInotifyMaxUserWatches != nil {
+		in, out := &in.FsInotifyMaxUserWatches, &out.FsInotifyMaxUserWatches
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FsFileMax != nil {
+		in, out := &in.FsFileMax, &out.FsFileMax
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FsAioMaxNr != nil {
+		in, out := &in.FsAioMaxNr, &out.FsAioMaxNr
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FsNrOpen != nil {
+		in, out := &in.FsNrOpen, &out.FsNrOpen
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KernelThreadsMax != nil {
+		in, out := &in.KernelThreadsMax, &out.KernelThreadsMax
+		*out = new(int32)
+		**out = **in
+	}
+	if in.VMMaxMapCount != nil {
+		in, out := &in.VMMaxMapCount, &out.VMMaxMapCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.VMSwappiness != nil {
+		in, out := &in.VMSwappiness, &out.VMSwappiness
+		*out = new(int32)
+		**out = **in
+	}
+	if in.VMVfsCachePressure != nil {
+		in, out := &in.VMVfsCachePressure, &out.VMVfsCachePressure
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SysctlConfig.
+func (in *SysctlConfig) DeepCopy() *SysctlConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SysctlConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Taint) DeepCopyInto(out *Taint) {
 	*out = *in
@@ -1091,3 +1725,40 @@ func (in Taints) DeepCopy() Taints {
 	in.DeepCopyInto(out)
 	return *out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimeInWeek) DeepCopyInto(out *TimeInWeek) {
+	*out = *in
+	if in.HourSlots != nil {
+		in, out := &in.HourSlots, &out.HourSlots
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeInWeek.
+func (in *TimeInWeek) DeepCopy() *TimeInWeek {
+	if in == nil {
+		return nil
+	}
+	out := new(TimeInWeek)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimeSpan) DeepCopyInto(out *TimeSpan) {
+	*out = *in
+	in.Start.DeepCopyInto(&out.Start)
+	in.End.DeepCopyInto(&out.End)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeSpan.
+func (in *TimeSpan) DeepCopy() *TimeSpan {
+	if in == nil {
+		return nil
+	}
+	out := new(TimeSpan)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -31,9 +31,11 @@ import (
 	infracontroller "sigs.k8s.io/cluster-api-provider-azure/controllers"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/ratelimit"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	capierrors "sigs.k8s.io/cluster-api/errors"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
@@ -90,7 +92,7 @@ func (ampr *AzureMachinePoolReconciler) SetupWithManager(ctx context.Context, mg
 
 	var r reconcile.Reconciler = ampr
 	if options.Cache != nil {
-		r = coalescing.NewReconciler(ampr, options.Cache, log)
+		r = coalescing.NewReconciler(ampr, ampr.Client, &infrav1exp.AzureMachinePool{}, options.Cache, log)
 	}
 
 	// create mapper to transform incoming AzureClusters into AzureMachinePool requests
@@ -289,10 +291,18 @@ func (ampr *AzureMachinePoolReconciler) reconcileNormal(ctx context.Context, mac
 
 	if err := ams.Reconcile(ctx); err != nil {
 		// Handle transient and terminal errors
+		category := infracontroller.ReportAzureError(machinePoolScope.AzureMachinePool, err)
 		var reconcileError azure.ReconcileError
 		if errors.As(err, &reconcileError) {
 			if reconcileError.IsTerminal() {
 				log.Error(err, "failed to reconcile AzureMachinePool", "name", machinePoolScope.Name())
+				if category == azure.TerminalErrorCategory {
+					machinePoolScope.SetFailureReason(capierrors.InvalidConfigurationMachineError)
+				} else {
+					machinePoolScope.SetFailureReason(capierrors.CreateMachineError)
+				}
+				machinePoolScope.SetFailureMessage(err)
+				machinePoolScope.SetNotReady()
 				return reconcile.Result{}, nil
 			}
 
@@ -334,6 +344,7 @@ func (ampr *AzureMachinePoolReconciler) reconcileNormal(ctx context.Context, mac
 func (ampr *AzureMachinePoolReconciler) reconcileDelete(ctx context.Context, machinePoolScope *scope.MachinePoolScope, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.AzureMachinePoolReconciler.reconcileDelete")
 	defer done()
+	ctx = ratelimit.WithPriority(ctx)
 
 	log.V(2).Info("handling deleted AzureMachinePool")
 
@@ -33,6 +33,7 @@ import (
 	infracontroller "sigs.k8s.io/cluster-api-provider-azure/controllers"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/ratelimit"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	capierrors "sigs.k8s.io/cluster-api/errors"
@@ -87,7 +88,7 @@ func (ampmr *AzureMachinePoolMachineController) SetupWithManager(ctx context.Con
 
 	var r reconcile.Reconciler = ampmr
 	if options.Cache != nil {
-		r = coalescing.NewReconciler(ampmr, options.Cache, log)
+		r = coalescing.NewReconciler(ampmr, ampmr.Client, &infrav1exp.AzureMachinePoolMachine{}, options.Cache, log)
 	}
 
 	c, err := ctrl.NewControllerManagedBy(mgr).
@@ -301,6 +302,7 @@ func (ampmr *AzureMachinePoolMachineController) reconcileNormal(ctx context.Cont
 func (ampmr *AzureMachinePoolMachineController) reconcileDelete(ctx context.Context, machineScope *scope.MachinePoolMachineScope) (_ reconcile.Result, reterr error) {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.AzureMachinePoolMachineController.reconcileDelete")
 	defer done()
+	ctx = ratelimit.WithPriority(ctx)
 
 	log.Info("Handling deleted AzureMachinePoolMachine")
 
@@ -59,7 +59,7 @@ func (amcr *AzureManagedClusterReconciler) SetupWithManager(ctx context.Context,
 
 	var r reconcile.Reconciler = amcr
 	if options.Cache != nil {
-		r = coalescing.NewReconciler(amcr, options.Cache, log)
+		r = coalescing.NewReconciler(amcr, amcr.Client, &infrav1exp.AzureManagedCluster{}, options.Cache, log)
 	}
 
 	azManagedCluster := &infrav1exp.AzureManagedCluster{}
@@ -31,6 +31,7 @@ import (
 	infracontroller "sigs.k8s.io/cluster-api-provider-azure/controllers"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/ratelimit"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -65,7 +66,7 @@ func (amcpr *AzureManagedControlPlaneReconciler) SetupWithManager(ctx context.Co
 
 	var r reconcile.Reconciler = amcpr
 	if options.Cache != nil {
-		r = coalescing.NewReconciler(amcpr, options.Cache, log)
+		r = coalescing.NewReconciler(amcpr, amcpr.Client, &infrav1exp.AzureManagedControlPlane{}, options.Cache, log)
 	}
 
 	azManagedControlPlane := &infrav1exp.AzureManagedControlPlane{}
@@ -75,6 +76,13 @@ func (amcpr *AzureManagedControlPlaneReconciler) SetupWithManager(ctx context.Co
 		return errors.Wrap(err, "failed to create AzureManagedCluster to AzureManagedControlPlane mapper")
 	}
 
+	// create mapper to transform incoming AzureManagedMachinePools into AzureManagedControlPlane requests, so that node
+	// pool upgrades progress as soon as the previous node pool is upgraded
+	azureManagedMachinePoolToControlPlaneMapper, err := AzureManagedMachinePoolToAzureManagedControlPlaneMapper(ctx, amcpr.Client, log)
+	if err != nil {
+		return errors.Wrap(err, "failed to create AzureManagedMachinePool to AzureManagedControlPlane mapper")
+	}
+
 	// map requests for machine pools corresponding to AzureManagedControlPlane's defaultPool back to the corresponding AzureManagedControlPlane.
 	azureManagedMachinePoolMapper := MachinePoolToAzureManagedControlPlaneMapFunc(ctx, amcpr.Client, infrav1exp.GroupVersion.WithKind("AzureManagedControlPlane"), log)
 
@@ -92,6 +100,11 @@ func (amcpr *AzureManagedControlPlaneReconciler) SetupWithManager(ctx context.Co
 			&source.Kind{Type: &expv1.MachinePool{}},
 			handler.EnqueueRequestsFromMapFunc(azureManagedMachinePoolMapper),
 		).
+		// watch AzureManagedMachinePool resources
+		Watches(
+			&source.Kind{Type: &infrav1exp.AzureManagedMachinePool{}},
+			handler.EnqueueRequestsFromMapFunc(azureManagedMachinePoolToControlPlaneMapper),
+		).
 		Build(r)
 	if err != nil {
 		return errors.Wrap(err, "error creating controller")
@@ -235,7 +248,9 @@ func (amcpr *AzureManagedControlPlaneReconciler) reconcileNormal(ctx context.Con
 		return reconcile.Result{}, err
 	}
 
-	if err := newAzureManagedControlPlaneReconciler(scope).Reconcile(ctx); err != nil {
+	err := newAzureManagedControlPlaneReconciler(scope).Reconcile(ctx)
+	infracontroller.ReportDryRun(amcpr.Recorder, scope.ControlPlane, scope)
+	if err != nil {
 		// Handle transient and terminal errors
 		log := log.WithValues("name", scope.ControlPlane.Name, "namespace", scope.ControlPlane.Namespace)
 		var reconcileError azure.ReconcileError
@@ -265,10 +280,13 @@ func (amcpr *AzureManagedControlPlaneReconciler) reconcileNormal(ctx context.Con
 func (amcpr *AzureManagedControlPlaneReconciler) reconcileDelete(ctx context.Context, scope *scope.ManagedControlPlaneScope) (reconcile.Result, error) {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.AzureManagedControlPlaneReconciler.reconcileDelete")
 	defer done()
+	ctx = ratelimit.WithPriority(ctx)
 
 	log.Info("Reconciling AzureManagedControlPlane delete")
 
-	if err := newAzureManagedControlPlaneReconciler(scope).Delete(ctx); err != nil {
+	err := newAzureManagedControlPlaneReconciler(scope).Delete(ctx)
+	infracontroller.ReportDryRun(amcpr.Recorder, scope.ControlPlane, scope)
+	if err != nil {
 		return reconcile.Result{}, errors.Wrapf(err, "error deleting AzureManagedControlPlane %s/%s", scope.ControlPlane.Namespace, scope.ControlPlane.Name)
 	}
 
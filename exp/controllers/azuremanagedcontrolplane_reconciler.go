@@ -23,7 +23,9 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/maintenanceconfigurations"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/managedclusters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routetables"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
@@ -33,10 +35,16 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
+// managedControlPlaneScope defines the scope interface used by the AzureManagedControlPlane services.
+type managedControlPlaneScope interface {
+	managedclusters.ManagedClusterScope
+	UpdateNodePoolUpgrades()
+}
+
 // azureManagedControlPlaneService contains the services required by the cluster controller.
 type azureManagedControlPlaneService struct {
 	kubeclient client.Client
-	scope      managedclusters.ManagedClusterScope
+	scope      managedControlPlaneScope
 	services   []azure.ServiceReconciler
 }
 
@@ -48,8 +56,10 @@ func newAzureManagedControlPlaneReconciler(scope *scope.ManagedControlPlaneScope
 		services: []azure.ServiceReconciler{
 			groups.New(scope),
 			virtualnetworks.New(scope),
+			routetables.New(scope),
 			subnets.New(scope),
 			managedclusters.New(scope),
+			maintenanceconfigurations.New(scope),
 			tags.New(scope),
 		},
 	}
@@ -70,6 +80,9 @@ func (r *azureManagedControlPlaneService) Reconcile(ctx context.Context) error {
 		return errors.Wrap(err, "failed to reconcile kubeconfig secret")
 	}
 
+	// Node pools only upgrade once the control plane runs the new version.
+	r.scope.UpdateNodePoolUpgrades()
+
 	return nil
 }
 
@@ -343,6 +343,50 @@ func AzureManagedControlPlaneToAzureManagedClusterMapper(ctx context.Context, c
 	}, nil
 }
 
+// AzureManagedMachinePoolToAzureManagedControlPlaneMapper creates a mapping handler to transform AzureManagedMachinePools
+// into AzureManagedControlPlane. The transform requires AzureManagedMachinePool to map to the Cluster it belongs to,
+// then from the Cluster, collect the control plane infrastructure reference.
+func AzureManagedMachinePoolToAzureManagedControlPlaneMapper(ctx context.Context, c client.Client, log logr.Logger) (handler.MapFunc, error) {
+	return func(o client.Object) []ctrl.Request {
+		ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultMappingTimeout)
+		defer cancel()
+
+		azManagedMachinePool, ok := o.(*infrav1exp.AzureManagedMachinePool)
+		if !ok {
+			log.Error(errors.Errorf("expected an AzureManagedMachinePool, got %T instead", o), "failed to map AzureManagedMachinePool")
+			return nil
+		}
+
+		log = log.WithValues("AzureManagedMachinePool", azManagedMachinePool.Name, "Namespace", azManagedMachinePool.Namespace)
+
+		clusterName, ok := azManagedMachinePool.Labels[clusterv1.ClusterLabelName]
+		if !ok {
+			log.V(4).Info("AzureManagedMachinePool does not have a cluster label, skipping mapping.")
+			return nil
+		}
+
+		cluster, err := util.GetClusterByName(ctx, c, azManagedMachinePool.Namespace, clusterName)
+		if err != nil {
+			log.Error(err, "failed to get the cluster")
+			return nil
+		}
+
+		ref := cluster.Spec.ControlPlaneRef
+		if ref == nil || ref.Name == "" {
+			return nil
+		}
+
+		return []ctrl.Request{
+			{
+				NamespacedName: types.NamespacedName{
+					Namespace: ref.Namespace,
+					Name:      ref.Name,
+				},
+			},
+		}
+	}, nil
+}
+
 // MachinePoolToAzureManagedControlPlaneMapFunc returns a handler.MapFunc that watches for
 // MachinePool events and returns reconciliation requests for a control plane object.
 func MachinePoolToAzureManagedControlPlaneMapFunc(ctx context.Context, c client.Client, gvk schema.GroupVersionKind, log logr.Logger) handler.MapFunc {
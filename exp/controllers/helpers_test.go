@@ -348,6 +348,48 @@ func TestAzureManagedClusterToAzureManagedControlPlaneMapper(t *testing.T) {
 	}))
 }
 
+func TestAzureManagedMachinePoolToAzureManagedControlPlaneMapper(t *testing.T) {
+	g := NewWithT(t)
+	scheme := newScheme(g)
+	cluster := newCluster("my-cluster")
+	cluster.Spec.ControlPlaneRef = &corev1.ObjectReference{
+		APIVersion: infrav1exp.GroupVersion.String(),
+		Kind:       "AzureManagedControlPlane",
+		Name:       cpName,
+		Namespace:  cluster.Namespace,
+	}
+
+	initObjects := []runtime.Object{
+		cluster,
+		newAzureManagedControlPlane(cpName),
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(initObjects...).Build()
+
+	sink := mock_log.NewMockLogSink(gomock.NewController(t))
+	sink.EXPECT().Init(logr.RuntimeInfo{CallDepth: 1})
+	sink.EXPECT().WithValues("AzureManagedMachinePool", "my-pool", "Namespace", "default")
+
+	mapper, err := AzureManagedMachinePoolToAzureManagedControlPlaneMapper(context.Background(), fakeClient, logr.New(sink))
+	g.Expect(err).NotTo(HaveOccurred())
+	requests := mapper(&infrav1exp.AzureManagedMachinePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-pool",
+			Namespace: "default",
+			Labels: map[string]string{
+				clusterv1.ClusterLabelName: cluster.Name,
+			},
+		},
+	})
+	g.Expect(requests).To(Equal([]reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Name:      cpName,
+				Namespace: cluster.Namespace,
+			},
+		},
+	}))
+}
+
 func TestAzureManagedControlPlaneToAzureManagedClusterMapper(t *testing.T) {
 	g := NewWithT(t)
 	scheme := newScheme(g)
@@ -34,6 +34,11 @@ const (
 	// owner: @alexeldeib
 	// alpha: v0.4
 	AKS featuregate.Feature = "AKS"
+
+	// AKSPreview is the feature gate for the AKS features which are only supported by the preview AKS API, i.e. the
+	// OIDC issuer and workload identity. The GA AKS API is used when it is disabled.
+	// alpha: v1.5
+	AKSPreview featuregate.Feature = "AKSPreview"
 )
 
 func init() {
@@ -44,5 +49,6 @@ func init() {
 // To add a new feature, define a key for it above and add it here.
 var defaultCAPZFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	// Every feature should be initiated here:
-	AKS: {Default: false, PreRelease: featuregate.Alpha},
+	AKS:        {Default: false, PreRelease: featuregate.Alpha},
+	AKSPreview: {Default: false, PreRelease: featuregate.Alpha},
 }
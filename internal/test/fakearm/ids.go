@@ -0,0 +1,109 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakearm
+
+import (
+	"strings"
+)
+
+const (
+	providersSegment      = "providers"
+	resourceGroupsSegment = "resourcegroups"
+	subscriptionsSegment  = "subscriptions"
+
+	// resourceGroupType is the ARM type of a resource group.
+	resourceGroupType = "Microsoft.Resources/resourceGroups"
+	// tagsType is the ARM type of the tags extension resource.
+	tagsType = "Microsoft.Resources/tags"
+)
+
+// resourceID is a parsed ARM resource or collection path such as
+// /subscriptions/{sub}/resourceGroups/{rg}/providers/Microsoft.Network/virtualNetworks/{vnet}/subnets/{subnet}.
+type resourceID struct {
+	path     string
+	segments []string
+}
+
+// parseResourceID parses an ARM request path into a resourceID.
+func parseResourceID(path string) resourceID {
+	path = "/" + strings.Trim(path, "/")
+	var segments []string
+	if path != "/" {
+		segments = strings.Split(strings.TrimPrefix(path, "/"), "/")
+	}
+	return resourceID{path: path, segments: segments}
+}
+
+// key returns the case-insensitive key used to store the resource.
+func (id resourceID) key() string {
+	return strings.ToLower(id.path)
+}
+
+// isCollection returns true if the path refers to a collection of resources rather than a single resource.
+func (id resourceID) isCollection() bool {
+	return len(id.segments)%2 == 1
+}
+
+// name returns the name of the resource, which is the last segment of the path.
+func (id resourceID) name() string {
+	if len(id.segments) == 0 {
+		return ""
+	}
+	return id.segments[len(id.segments)-1]
+}
+
+// resourceType returns the fully qualified ARM type of the resource, e.g. Microsoft.Network/virtualNetworks/subnets.
+func (id resourceID) resourceType() string {
+	provider := -1
+	for i, s := range id.segments {
+		if strings.EqualFold(s, providersSegment) {
+			provider = i
+		}
+	}
+	if provider < 0 || provider+2 >= len(id.segments) {
+		if len(id.segments) >= 3 && strings.EqualFold(id.segments[2], resourceGroupsSegment) {
+			return resourceGroupType
+		}
+		return ""
+	}
+	types := []string{id.segments[provider+1]}
+	for i := provider + 2; i < len(id.segments); i += 2 {
+		types = append(types, id.segments[i])
+	}
+	return strings.Join(types, "/")
+}
+
+// parent returns the ID of the resource this resource is nested in, and false if the resource is top level in a
+// subscription or the ID refers to a collection.
+func (id resourceID) parent() (resourceID, bool) {
+	if len(id.segments) <= 4 || id.isCollection() {
+		return resourceID{}, false
+	}
+	segments := id.segments[:len(id.segments)-2]
+	if len(segments) >= 2 && strings.EqualFold(segments[len(segments)-2], providersSegment) {
+		segments = segments[:len(segments)-2]
+	}
+	if len(segments) <= 2 {
+		return resourceID{}, false
+	}
+	return resourceID{path: "/" + strings.Join(segments, "/"), segments: segments}, true
+}
+
+// isResourceGroup returns true if the resource is a resource group.
+func (id resourceID) isResourceGroup() bool {
+	return len(id.segments) == 4 && strings.EqualFold(id.segments[0], subscriptionsSegment) && strings.EqualFold(id.segments[2], resourceGroupsSegment)
+}
@@ -0,0 +1,539 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fakearm provides an in-process fake of the Azure Resource Manager API for tests.
+//
+// The Server stores resources in memory keyed by their resource ID and implements the subset of ARM semantics
+// the azure/services clients rely on: PUT, PATCH, GET, DELETE and list requests, parent resource checks,
+// ARM error bodies, and long-running operations tracked through Azure-AsyncOperation polling URLs. Point
+// AzureClients.ResourceManagerEndpoint at Server.URL to run service reconcilers against it.
+package fakearm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// AllResourceTypes can be passed to WithLongRunningOperations to make operations on every resource type long running.
+	AllResourceTypes = "*"
+
+	// operationsPath is the path prefix used for the Azure-AsyncOperation polling URLs.
+	operationsPath = "/fakearm/operations/"
+
+	// DefaultRetryAfter is the Retry-After returned with long-running operations. It is longer than
+	// reconciler.DefaultAzureCallTimeout so that clients return a future instead of waiting for the operation.
+	DefaultRetryAfter = 10 * time.Second
+)
+
+const (
+	statusInProgress = "InProgress"
+	statusSucceeded  = "Succeeded"
+	statusCreating   = "Creating"
+	statusUpdating   = "Updating"
+	statusDeleting   = "Deleting"
+)
+
+// Request is a record of a request served by the Server.
+type Request struct {
+	Method string
+	Path   string
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithLongRunningOperations makes PUT and DELETE requests for resources of the given ARM type (e.g.
+// Microsoft.Network/virtualNetworks) long running. The operation completes once its status has been polled the given
+// number of times.
+func WithLongRunningOperations(resourceType string, polls int) Option {
+	return func(s *Server) {
+		s.lroPolls[strings.ToLower(resourceType)] = polls
+	}
+}
+
+// WithRetryAfter sets the Retry-After header returned with long-running operations.
+func WithRetryAfter(retryAfter time.Duration) Option {
+	return func(s *Server) {
+		s.retryAfter = retryAfter
+	}
+}
+
+// Server is an in-process fake Azure Resource Manager.
+type Server struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	resources  map[string]map[string]interface{}
+	operations map[string]*operation
+	injected   map[string][]injectedError
+	requests   []Request
+	lroPolls   map[string]int
+	retryAfter time.Duration
+	nextOpID   int
+}
+
+// operation is a long-running operation in progress.
+type operation struct {
+	method    string
+	id        resourceID
+	remaining int
+	status    string
+}
+
+// injectedError is an error returned instead of processing a request.
+type injectedError struct {
+	statusCode int
+	code       string
+	message    string
+}
+
+// NewServer starts and returns a new Server. Callers should call Close when finished.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		resources:  map[string]map[string]interface{}{},
+		operations: map[string]*operation{},
+		injected:   map[string][]injectedError{},
+		lroPolls:   map[string]int{},
+		retryAfter: DefaultRetryAfter,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Put stores a resource directly, bypassing parent checks. It can be used to seed pre-existing or unmanaged resources.
+func (s *Server) Put(id string, resource map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rid := parseResourceID(id)
+	s.resources[rid.key()] = withIdentity(rid, deepCopy(resource), statusSucceeded)
+}
+
+// Get returns a copy of the stored representation of a resource and whether it exists. Changing the copy doesn't
+// change the state of the server.
+func (s *Server) Get(id string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resource, ok := s.resources[parseResourceID(id).key()]
+	if !ok {
+		return nil, false
+	}
+	return deepCopy(resource), true
+}
+
+// Delete removes a resource and all of its nested resources directly.
+func (s *Server) Delete(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deleteLocked(parseResourceID(id))
+}
+
+// ResourceIDs returns the sorted IDs of all stored resources.
+func (s *Server) ResourceIDs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]string, 0, len(s.resources))
+	for _, r := range s.resources {
+		ids = append(ids, r["id"].(string))
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Requests returns the requests served so far, in order. Polling requests for long-running operations are not included.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request{}, s.requests...)
+}
+
+// InjectError makes the next request with the given HTTP method against the given resource ID fail with the given
+// HTTP status code and ARM error code.
+func (s *Server) InjectError(method, id string, statusCode int, code, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := injectedKey(method, parseResourceID(id))
+	s.injected[k] = append(s.injected[k], injectedError{statusCode: statusCode, code: code, message: message})
+}
+
+func injectedKey(method string, id resourceID) string {
+	return strings.ToUpper(method) + " " + id.key()
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if strings.HasPrefix(r.URL.Path, operationsPath) {
+		s.serveOperation(w, strings.TrimPrefix(r.URL.Path, operationsPath))
+		return
+	}
+
+	id := parseResourceID(r.URL.Path)
+	s.requests = append(s.requests, Request{Method: r.Method, Path: id.path})
+
+	k := injectedKey(r.Method, id)
+	if errs := s.injected[k]; len(errs) > 0 {
+		s.injected[k] = errs[1:]
+		writeError(w, errs[0].statusCode, errs[0].code, errs[0].message)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.serveGet(w, id)
+	case http.MethodPut:
+		s.servePut(w, r, id, false)
+	case http.MethodPatch:
+		s.servePut(w, r, id, true)
+	case http.MethodDelete:
+		s.serveDelete(w, id)
+	case http.MethodPost:
+		s.servePost(w, id)
+	case http.MethodHead:
+		if _, ok := s.resources[id.key()]; ok {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", fmt.Sprintf("method %s is not supported", r.Method))
+	}
+}
+
+func (s *Server) serveGet(w http.ResponseWriter, id resourceID) {
+	if id.isCollection() {
+		prefix := id.key() + "/"
+		values := []interface{}{}
+		for k, resource := range s.resources {
+			if strings.HasPrefix(k, prefix) && !strings.Contains(strings.TrimPrefix(k, prefix), "/") {
+				values = append(values, resource)
+			}
+		}
+		sort.Slice(values, func(i, j int) bool {
+			return values[i].(map[string]interface{})["id"].(string) < values[j].(map[string]interface{})["id"].(string)
+		})
+		writeJSON(w, http.StatusOK, map[string]interface{}{"value": values})
+		return
+	}
+
+	resource, ok := s.resources[id.key()]
+	if !ok {
+		writeNotFound(w, id)
+		return
+	}
+	writeJSON(w, http.StatusOK, resource)
+}
+
+func (s *Server) servePut(w http.ResponseWriter, r *http.Request, id resourceID, merge bool) {
+	if id.isCollection() {
+		writeError(w, http.StatusBadRequest, "InvalidResourceType", fmt.Sprintf("%s is not a resource", id.path))
+		return
+	}
+	if parent, ok := id.parent(); ok {
+		if _, exists := s.resources[parent.key()]; !exists {
+			writeParentNotFound(w, parent)
+			return
+		}
+	}
+
+	body := map[string]interface{}{}
+	if err := decodeBody(r.Body, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+		return
+	}
+
+	existing, exists := s.resources[id.key()]
+	if strings.EqualFold(id.resourceType(), tagsType) {
+		body = applyTagsOperation(existing, body)
+	} else if merge && exists {
+		body = mergeResource(existing, body)
+	}
+
+	polls := s.pollsFor(id)
+	state := statusSucceeded
+	if polls > 0 {
+		state = statusCreating
+		if exists {
+			state = statusUpdating
+		}
+	}
+	resource := withIdentity(id, body, state)
+	s.resources[id.key()] = resource
+
+	statusCode := http.StatusCreated
+	if exists {
+		statusCode = http.StatusOK
+	}
+	if polls > 0 {
+		s.startOperation(w, r.Method, id, polls)
+		statusCode = http.StatusCreated
+	}
+	writeJSON(w, statusCode, resource)
+}
+
+func (s *Server) serveDelete(w http.ResponseWriter, id resourceID) {
+	resource, ok := s.resources[id.key()]
+	if !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if polls := s.pollsFor(id); polls > 0 {
+		setProvisioningState(resource, statusDeleting)
+		s.startOperation(w, http.MethodDelete, id, polls)
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	s.deleteLocked(id)
+	w.WriteHeader(http.StatusOK)
+}
+
+// servePost handles resource actions such as .../virtualMachines/{vm}/powerOff, which succeed on any existing resource.
+func (s *Server) servePost(w http.ResponseWriter, id resourceID) {
+	target := parseResourceID(strings.TrimSuffix(id.path, "/"+id.name()))
+	if _, exists := s.resources[target.key()]; !exists {
+		writeNotFound(w, target)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
+
+func (s *Server) serveOperation(w http.ResponseWriter, opID string) {
+	op, ok := s.operations[opID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "OperationNotFound", fmt.Sprintf("operation %s was not found", opID))
+		return
+	}
+
+	if op.status == statusInProgress {
+		op.remaining--
+		if op.remaining <= 0 {
+			op.status = statusSucceeded
+			if op.method == http.MethodDelete {
+				s.deleteLocked(op.id)
+			} else if resource, ok := s.resources[op.id.key()]; ok {
+				setProvisioningState(resource, statusSucceeded)
+			}
+		}
+	}
+	if op.status == statusInProgress {
+		w.Header().Set("Retry-After", strconv.Itoa(int(s.retryAfter.Seconds())))
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"status": op.status})
+}
+
+// startOperation registers a long-running operation and sets its polling headers on the response.
+func (s *Server) startOperation(w http.ResponseWriter, method string, id resourceID, polls int) {
+	s.nextOpID++
+	opID := strconv.Itoa(s.nextOpID)
+	s.operations[opID] = &operation{
+		method:    method,
+		id:        id,
+		remaining: polls,
+		status:    statusInProgress,
+	}
+	w.Header().Set("Azure-AsyncOperation", s.URL+operationsPath+opID)
+	w.Header().Set("Retry-After", strconv.Itoa(int(s.retryAfter.Seconds())))
+}
+
+// pollsFor returns the number of polls needed for an operation on the resource to complete, or 0 if it is synchronous.
+func (s *Server) pollsFor(id resourceID) int {
+	if polls, ok := s.lroPolls[strings.ToLower(id.resourceType())]; ok {
+		return polls
+	}
+	return s.lroPolls[AllResourceTypes]
+}
+
+// deleteLocked removes the resource and all nested resources. s.mu must be held.
+func (s *Server) deleteLocked(id resourceID) {
+	prefix := id.key() + "/"
+	for k := range s.resources {
+		if k == id.key() || strings.HasPrefix(k, prefix) {
+			delete(s.resources, k)
+		}
+	}
+}
+
+// withIdentity sets the read-only id, name, type and provisioning state fields ARM returns for every resource.
+func withIdentity(id resourceID, resource map[string]interface{}, state string) map[string]interface{} {
+	if resource == nil {
+		resource = map[string]interface{}{}
+	}
+	resource["id"] = id.path
+	resource["name"] = id.name()
+	resource["type"] = id.resourceType()
+	setProvisioningState(resource, state)
+	return resource
+}
+
+func setProvisioningState(resource map[string]interface{}, state string) {
+	properties, ok := resource["properties"].(map[string]interface{})
+	if !ok {
+		properties = map[string]interface{}{}
+		resource["properties"] = properties
+	}
+	properties["provisioningState"] = state
+}
+
+// deepCopy copies a JSON object, its nested objects and arrays.
+func deepCopy(resource map[string]interface{}) map[string]interface{} {
+	if resource == nil {
+		return nil
+	}
+	copied := make(map[string]interface{}, len(resource))
+	for k, v := range resource {
+		copied[k] = deepCopyValue(v)
+	}
+	return copied
+}
+
+func deepCopyValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return deepCopy(v)
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i := range v {
+			copied[i] = deepCopyValue(v[i])
+		}
+		return copied
+	default:
+		return v
+	}
+}
+
+// mergeResource applies a PATCH body onto an existing resource as a JSON merge patch.
+func mergeResource(existing, patch map[string]interface{}) map[string]interface{} {
+	merged := map[string]interface{}{}
+	for k, v := range existing {
+		merged[k] = v
+	}
+	for k, v := range patch {
+		if v == nil {
+			delete(merged, k)
+			continue
+		}
+		existingMap, ok1 := merged[k].(map[string]interface{})
+		patchMap, ok2 := v.(map[string]interface{})
+		if ok1 && ok2 {
+			merged[k] = mergeResource(existingMap, patchMap)
+			continue
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+// applyTagsOperation implements the Merge, Replace and Delete operations of the tags API.
+func applyTagsOperation(existing, body map[string]interface{}) map[string]interface{} {
+	tags := map[string]interface{}{}
+	if existing != nil {
+		if properties, ok := existing["properties"].(map[string]interface{}); ok {
+			if t, ok := properties["tags"].(map[string]interface{}); ok {
+				for k, v := range t {
+					tags[k] = v
+				}
+			}
+		}
+	}
+
+	var requested map[string]interface{}
+	if properties, ok := body["properties"].(map[string]interface{}); ok {
+		requested, _ = properties["tags"].(map[string]interface{})
+	}
+
+	switch operation, _ := body["operation"].(string); {
+	case strings.EqualFold(operation, "Merge"):
+		for k, v := range requested {
+			tags[k] = v
+		}
+	case strings.EqualFold(operation, "Delete"):
+		for k := range requested {
+			delete(tags, k)
+		}
+	default:
+		tags = requested
+	}
+
+	return map[string]interface{}{"properties": map[string]interface{}{"tags": tags}}
+}
+
+func decodeBody(body io.Reader, into *map[string]interface{}) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, into)
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(data)
+}
+
+// writeError writes an ARM error response body.
+func writeError(w http.ResponseWriter, statusCode int, code, message string) {
+	writeJSON(w, statusCode, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
+
+func writeNotFound(w http.ResponseWriter, id resourceID) {
+	if id.isResourceGroup() {
+		writeError(w, http.StatusNotFound, "ResourceGroupNotFound", fmt.Sprintf("Resource group '%s' could not be found.", id.name()))
+		return
+	}
+	writeError(w, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("The Resource '%s' was not found.", id.path))
+}
+
+func writeParentNotFound(w http.ResponseWriter, parent resourceID) {
+	if parent.isResourceGroup() {
+		writeNotFound(w, parent)
+		return
+	}
+	writeError(w, http.StatusNotFound, "ParentResourceNotFound", fmt.Sprintf("Can not perform requested operation on nested resource. Parent resource '%s' not found.", parent.path))
+}
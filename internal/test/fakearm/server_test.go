@@ -0,0 +1,312 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakearm
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testSubscriptionID = "123"
+	testGroupID        = "/subscriptions/123/resourceGroups/my-rg"
+	testVnetID         = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet"
+	testSubnetID       = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-subnet"
+)
+
+func TestParseResourceID(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		wantType         string
+		wantParent       string
+		wantIsCollection bool
+	}{
+		{
+			name:     "resource group",
+			path:     testGroupID,
+			wantType: "Microsoft.Resources/resourceGroups",
+		},
+		{
+			name:       "top level resource",
+			path:       testVnetID,
+			wantType:   "Microsoft.Network/virtualNetworks",
+			wantParent: testGroupID,
+		},
+		{
+			name:       "nested resource",
+			path:       testSubnetID,
+			wantType:   "Microsoft.Network/virtualNetworks/subnets",
+			wantParent: testVnetID,
+		},
+		{
+			name:       "extension resource",
+			path:       testVnetID + "/providers/Microsoft.Resources/tags/default",
+			wantType:   "Microsoft.Resources/tags",
+			wantParent: testVnetID,
+		},
+		{
+			name:             "collection",
+			path:             testVnetID + "/subnets",
+			wantType:         "Microsoft.Network/virtualNetworks/subnets",
+			wantIsCollection: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			id := parseResourceID(tc.path)
+			g.Expect(id.resourceType()).To(Equal(tc.wantType))
+			g.Expect(id.isCollection()).To(Equal(tc.wantIsCollection))
+			parent, ok := id.parent()
+			g.Expect(ok).To(Equal(tc.wantParent != ""))
+			g.Expect(parent.path).To(Equal(tc.wantParent))
+		})
+	}
+}
+
+func TestServerResourceLifecycle(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := NewServer()
+	defer s.Close()
+
+	groupsClient := resources.NewGroupsClientWithBaseURI(s.URL, testSubscriptionID)
+	groupsClient.Authorizer = autorest.NullAuthorizer{}
+	vnetsClient := network.NewVirtualNetworksClientWithBaseURI(s.URL, testSubscriptionID)
+	vnetsClient.Authorizer = autorest.NullAuthorizer{}
+	subnetsClient := network.NewSubnetsClientWithBaseURI(s.URL, testSubscriptionID)
+	subnetsClient.Authorizer = autorest.NullAuthorizer{}
+
+	// Creating a vnet in a resource group that doesn't exist fails like it does in ARM.
+	_, err := vnetsClient.CreateOrUpdate(ctx, "my-rg", "my-vnet", network.VirtualNetwork{Location: to.StringPtr("eastus")})
+	g.Expect(azure.ResourceNotFound(err)).To(BeTrue())
+	g.Expect(err.Error()).To(ContainSubstring("ResourceGroupNotFound"))
+
+	_, err = groupsClient.CreateOrUpdate(ctx, "my-rg", resources.Group{Location: to.StringPtr("eastus")})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	_, err = vnetsClient.Get(ctx, "my-rg", "my-vnet", "")
+	g.Expect(azure.ResourceNotFound(err)).To(BeTrue())
+
+	_, err = subnetsClient.CreateOrUpdate(ctx, "my-rg", "my-vnet", "my-subnet", network.Subnet{})
+	g.Expect(azure.ResourceNotFound(err)).To(BeTrue())
+	g.Expect(err.Error()).To(ContainSubstring("ParentResourceNotFound"))
+
+	vnetFuture, err := vnetsClient.CreateOrUpdate(ctx, "my-rg", "my-vnet", network.VirtualNetwork{
+		Location: to.StringPtr("eastus"),
+		VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+			AddressSpace: &network.AddressSpace{AddressPrefixes: &[]string{"10.0.0.0/8"}},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	vnet, err := vnetFuture.Result(vnetsClient)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(to.String(vnet.ID)).To(Equal(testVnetID))
+	g.Expect(vnet.ProvisioningState).To(Equal(network.ProvisioningStateSucceeded))
+
+	_, err = subnetsClient.CreateOrUpdate(ctx, "my-rg", "my-vnet", "my-subnet", network.Subnet{
+		SubnetPropertiesFormat: &network.SubnetPropertiesFormat{AddressPrefix: to.StringPtr("10.0.0.0/16")},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	subnets, err := subnetsClient.List(ctx, "my-rg", "my-vnet")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(subnets.Values()).To(HaveLen(1))
+	g.Expect(to.String(subnets.Values()[0].Name)).To(Equal("my-subnet"))
+
+	s.InjectError(http.MethodGet, testVnetID, http.StatusForbidden, "RequestDisallowedByPolicy", "denied")
+	_, err = vnetsClient.Get(ctx, "my-rg", "my-vnet", "")
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("RequestDisallowedByPolicy"))
+	_, err = vnetsClient.Get(ctx, "my-rg", "my-vnet", "")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// Deleting the resource group deletes everything in it.
+	_, err = groupsClient.Delete(ctx, "my-rg")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(s.ResourceIDs()).To(BeEmpty())
+}
+
+func TestServerGetReturnsCopy(t *testing.T) {
+	g := NewWithT(t)
+
+	s := NewServer()
+	defer s.Close()
+
+	seeded := map[string]interface{}{
+		"location": "eastus",
+		"properties": map[string]interface{}{
+			"addressSpace": map[string]interface{}{"addressPrefixes": []interface{}{"10.0.0.0/8"}},
+		},
+	}
+	s.Put(testVnetID, seeded)
+	// Changing the seeded resource doesn't change the stored one.
+	seeded["location"] = "westus"
+
+	vnet, ok := s.Get(testVnetID)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(vnet["location"]).To(Equal("eastus"))
+
+	vnet["location"] = "westus"
+	vnet["properties"].(map[string]interface{})["provisioningState"] = "Failed"
+	vnet["properties"].(map[string]interface{})["addressSpace"].(map[string]interface{})["addressPrefixes"].([]interface{})[0] = "192.168.0.0/16"
+
+	stored, ok := s.Get(testVnetID)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(stored["location"]).To(Equal("eastus"))
+	g.Expect(stored["properties"].(map[string]interface{})["provisioningState"]).To(Equal(statusSucceeded))
+	g.Expect(stored["properties"].(map[string]interface{})["addressSpace"].(map[string]interface{})["addressPrefixes"]).To(Equal([]interface{}{"10.0.0.0/8"}))
+
+	_, ok = s.Get("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/missing")
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestServerLongRunningOperations(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := NewServer(WithLongRunningOperations("Microsoft.Network/virtualNetworks", 2))
+	defer s.Close()
+	s.Put(testGroupID, map[string]interface{}{"location": "eastus"})
+
+	vnetsClient := network.NewVirtualNetworksClientWithBaseURI(s.URL, testSubscriptionID)
+	vnetsClient.Authorizer = autorest.NullAuthorizer{}
+
+	createFuture, err := vnetsClient.CreateOrUpdate(ctx, "my-rg", "my-vnet", network.VirtualNetwork{Location: to.StringPtr("eastus")})
+	g.Expect(err).NotTo(HaveOccurred())
+	delay, ok := createFuture.GetPollingDelay()
+	g.Expect(ok).To(BeTrue())
+	g.Expect(delay).To(Equal(DefaultRetryAfter))
+
+	// The future survives being stored in and read back from a CAPZ status.
+	future, err := converters.SDKToFuture(&createFuture, infrav1.PutFuture, "virtualnetworks", "my-vnet", "my-rg")
+	g.Expect(err).NotTo(HaveOccurred())
+	sdkFuture, err := converters.FutureToSDK(*future)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	vnet, ok := s.Get(testVnetID)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(vnet["properties"]).To(HaveKeyWithValue("provisioningState", "Creating"))
+
+	done, err := sdkFuture.DoneWithContext(ctx, vnetsClient)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(done).To(BeFalse())
+	done, err = sdkFuture.DoneWithContext(ctx, vnetsClient)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(done).To(BeTrue())
+
+	vnet, _ = s.Get(testVnetID)
+	g.Expect(vnet["properties"]).To(HaveKeyWithValue("provisioningState", "Succeeded"))
+
+	deleteFuture, err := vnetsClient.Delete(ctx, "my-rg", "my-vnet")
+	g.Expect(err).NotTo(HaveOccurred())
+	_, ok = s.Get(testVnetID)
+	g.Expect(ok).To(BeTrue())
+	for i := 0; i < 2; i++ {
+		done, err = deleteFuture.DoneWithContext(ctx, vnetsClient)
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+	g.Expect(done).To(BeTrue())
+	_, ok = s.Get(testVnetID)
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestServiceReconcileAgainstServer(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := NewServer(WithLongRunningOperations("Microsoft.Network/virtualNetworks", 1))
+	defer s.Close()
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+
+	cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"}}
+	azureCluster := &infrav1.AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+		Spec: infrav1.AzureClusterSpec{
+			ResourceGroup: "my-rg",
+			AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+				SubscriptionID: testSubscriptionID,
+				Location:       "eastus",
+			},
+			NetworkSpec: infrav1.NetworkSpec{
+				Vnet: infrav1.VnetSpec{
+					ResourceGroup: "my-rg",
+					Name:          "my-vnet",
+					VnetClassSpec: infrav1.VnetClassSpec{CIDRBlocks: []string{"10.0.0.0/8"}},
+				},
+			},
+		},
+	}
+	clusterScope, err := scope.NewClusterScope(ctx, scope.ClusterScopeParams{
+		AzureClients: scope.AzureClients{
+			Authorizer:              autorest.NullAuthorizer{},
+			ResourceManagerEndpoint: s.URL,
+		},
+		Client:       fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, azureCluster).Build(),
+		Cluster:      cluster,
+		AzureCluster: azureCluster,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(groups.New(clusterScope).Reconcile(ctx)).To(Succeed())
+
+	// The vnet PUT is long running, so the first reconcile stores the future in status and requeues.
+	vnetService := virtualnetworks.New(clusterScope)
+	err = vnetService.Reconcile(ctx)
+	g.Expect(azure.IsOperationNotDoneError(err)).To(BeTrue())
+	g.Expect(azureCluster.Status.LongRunningOperationStates).To(HaveLen(1))
+
+	// The next reconcile picks up the future from status, finds it done and records the vnet.
+	g.Expect(vnetService.Reconcile(ctx)).To(Succeed())
+	g.Expect(azureCluster.Status.LongRunningOperationStates).To(BeEmpty())
+	g.Expect(azureCluster.Spec.NetworkSpec.Vnet.ID).To(Equal(testVnetID))
+	g.Expect(azureCluster.Spec.NetworkSpec.Vnet.Tags.HasOwned("my-cluster")).To(BeTrue())
+
+	// The groups client addresses resource groups with a lower case "resourcegroups" segment.
+	g.Expect(s.Requests()).To(Equal([]Request{
+		{Method: http.MethodGet, Path: "/subscriptions/123/resourcegroups/my-rg"},
+		{Method: http.MethodPut, Path: "/subscriptions/123/resourcegroups/my-rg"},
+		{Method: http.MethodGet, Path: testVnetID},
+		{Method: http.MethodPut, Path: testVnetID},
+		{Method: http.MethodGet, Path: testVnetID},
+	}))
+}
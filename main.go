@@ -31,6 +31,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	cgrecord "k8s.io/client-go/tools/record"
@@ -39,6 +40,7 @@ import (
 	infrav1alpha3 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
 	infrav1alpha4 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha4"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/controllers"
 	infrav1alpha3exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1alpha3"
 	infrav1alpha4exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1alpha4"
@@ -47,7 +49,9 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/feature"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/ot"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/ratelimit"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/system"
 	"sigs.k8s.io/cluster-api-provider-azure/util/webhook"
 	"sigs.k8s.io/cluster-api-provider-azure/version"
 	clusterv1alpha4 "sigs.k8s.io/cluster-api/api/v1alpha4"
@@ -110,11 +114,21 @@ var (
 	azureMachinePoolConcurrency        int
 	azureMachinePoolMachineConcurrency int
 	debouncingTimer                    time.Duration
+	debouncingTimers                   map[string]string
+	debouncingInProgressTimer          time.Duration
+	debouncingJitter                   float64
+	debouncingStartupSpread            time.Duration
+	debouncingCacheSize                int
 	syncPeriod                         time.Duration
+	driftScanInterval                  time.Duration
 	healthAddr                         string
 	webhookPort                        int
 	reconcileTimeout                   time.Duration
 	enableTracing                      bool
+	tracingOptions                     ot.TracingOptions
+	rateLimitOptions                   ratelimit.Options
+	resourceSKUCacheRefreshInterval    time.Duration
+	persistResourceSKUCache            bool
 )
 
 // InitFlags initializes all command-line flags.
@@ -210,12 +224,48 @@ func InitFlags(fs *pflag.FlagSet) {
 		"The minimum interval the controller should wait after a successful reconciliation of a particular object before reconciling it again",
 	)
 
+	fs.StringToStringVar(&debouncingTimers,
+		"debouncing-timers",
+		nil,
+		"Per-controller overrides of --debouncing-timer, by kind, e.g. AzureMachine=30s,AzureCluster=1m",
+	)
+
+	fs.DurationVar(&debouncingInProgressTimer,
+		"debouncing-in-progress-timer",
+		2*time.Second,
+		"The minimum interval the controller should wait after a successful reconciliation which requested a requeue sooner than the debouncing timer, e.g. while a long-running Azure operation is in progress, before reconciling the object again",
+	)
+
+	fs.Float64Var(&debouncingJitter,
+		"debouncing-jitter",
+		0.1,
+		"The maximum fraction of the debouncing timers randomly added to them, to spread the reconciliations of objects reconciled at the same time",
+	)
+
+	fs.DurationVar(&debouncingStartupSpread,
+		"debouncing-startup-spread",
+		0,
+		"The maximum random delay of the first reconciliation of each object after the manager starts, to spread the reconciliations of all the objects over that duration. Objects being deleted are not delayed. 0 disables the delay",
+	)
+
+	fs.IntVar(&debouncingCacheSize,
+		"debouncing-cache-size",
+		1024,
+		"The maximum number of objects tracked by the debouncing cache of each controller. 0 tracks all the objects",
+	)
+
 	fs.DurationVar(&syncPeriod,
 		"sync-period",
 		10*time.Minute,
 		"The minimum interval at which watched resources are reconciled (e.g. 15m)",
 	)
 
+	fs.DurationVar(&driftScanInterval,
+		"drift-scan-interval",
+		0,
+		"The interval at which the Azure resources of AzureClusters and AzureMachines are checked for changes made outside of CAPZ. It must not be shorter than --sync-period. 0 only checks them when the objects are reconciled, at least once per sync period",
+	)
+
 	fs.StringVar(&healthAddr,
 		"health-addr",
 		":9440",
@@ -238,7 +288,23 @@ func InitFlags(fs *pflag.FlagSet) {
 		&enableTracing,
 		"enable-tracing",
 		false,
-		"Enable tracing to the opentelemetry-collector service in the same namespace.",
+		"Enable tracing. By default traces are sent to the opentelemetry-collector service in the same namespace, see the --tracing-* flags.",
+	)
+
+	tracingOptions.AddFlags(fs)
+
+	rateLimitOptions.AddFlags(fs)
+
+	fs.DurationVar(&resourceSKUCacheRefreshInterval,
+		"resource-sku-cache-refresh-interval",
+		24*time.Hour,
+		"The interval between two listings of the resource SKUs of a subscription and location, which are shared by all the clusters using them. The SKUs unused for the interval are dropped. 0 disables the refresh",
+	)
+
+	fs.BoolVar(&persistResourceSKUCache,
+		"persist-resource-sku-cache",
+		false,
+		"Persist the resource SKUs listed from Azure in ConfigMaps of the manager namespace, so that they are not listed again when the manager restarts",
 	)
 
 	feature.MutableGates.AddFlag(fs)
@@ -251,6 +317,13 @@ func main() {
 
 	ctrl.SetLogger(klogr.New())
 
+	for kind := range debouncingTimers {
+		if !debouncedKinds.Has(kind) {
+			setupLog.Error(fmt.Errorf("unknown kind %q, expected one of %v", kind, debouncedKinds.List()), "invalid --debouncing-timers")
+			os.Exit(1)
+		}
+	}
+
 	if watchNamespace != "" {
 		setupLog.Info("Watching cluster-api objects only in namespace for reconciliation", "namespace", watchNamespace)
 	}
@@ -306,17 +379,38 @@ func main() {
 	ctx := ctrl.SetupSignalHandler()
 
 	if enableTracing {
-		if err := ot.RegisterTracing(ctx, setupLog); err != nil {
+		if err := ot.RegisterTracing(ctx, setupLog, tracingOptions); err != nil {
 			setupLog.Error(err, "unable to initialize tracing")
 			os.Exit(1)
 		}
 	}
 
+	if driftScanInterval != 0 && driftScanInterval < syncPeriod {
+		setupLog.Error(fmt.Errorf("drift scan interval %s is shorter than the sync period %s", driftScanInterval, syncPeriod), "invalid drift scan interval")
+		os.Exit(1)
+	}
+
+	if err := rateLimitOptions.Validate(); err != nil {
+		setupLog.Error(err, "invalid Azure API rate limits")
+		os.Exit(1)
+	}
+	ratelimit.Configure(rateLimitOptions)
+
 	if err := ot.RegisterMetrics(); err != nil {
 		setupLog.Error(err, "unable to initialize metrics")
 		os.Exit(1)
 	}
 
+	skuCacheOptions := resourceskus.CacheOptions{RefreshInterval: resourceSKUCacheRefreshInterval}
+	if persistResourceSKUCache {
+		skuCacheOptions.Store = resourceskus.NewConfigMapStore(mgr.GetAPIReader(), mgr.GetClient(), system.GetManagerNamespace())
+	}
+	resourceskus.Configure(skuCacheOptions)
+	if err := mgr.Add(&resourceskus.Refresher{}); err != nil {
+		setupLog.Error(err, "unable to add the resource sku cache refresher to the manager")
+		os.Exit(1)
+	}
+
 	registerControllers(ctx, mgr)
 
 	registerWebhooks(mgr)
@@ -330,29 +424,31 @@ func main() {
 }
 
 func registerControllers(ctx context.Context, mgr manager.Manager) {
-	machineCache, err := coalescing.NewRequestCache(debouncingTimer)
+	machineCache, err := newReconcileCache("AzureMachine")
 	if err != nil {
 		setupLog.Error(err, "failed to build machineCache ReconcileCache")
+		os.Exit(1)
 	}
 	if err := controllers.NewAzureMachineReconciler(mgr.GetClient(),
 		mgr.GetEventRecorderFor("azuremachine-reconciler"),
 		reconcileTimeout,
 		watchFilterValue,
-	).SetupWithManager(ctx, mgr, controllers.Options{Options: controller.Options{MaxConcurrentReconciles: azureMachineConcurrency}, Cache: machineCache}); err != nil {
+	).SetupWithManager(ctx, mgr, controllers.Options{Options: controller.Options{MaxConcurrentReconciles: azureMachineConcurrency}, Cache: machineCache, DriftScanInterval: driftScanInterval}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AzureMachine")
 		os.Exit(1)
 	}
 
-	clusterCache, err := coalescing.NewRequestCache(debouncingTimer)
+	clusterCache, err := newReconcileCache("AzureCluster")
 	if err != nil {
 		setupLog.Error(err, "failed to build clusterCache ReconcileCache")
+		os.Exit(1)
 	}
 	if err := controllers.NewAzureClusterReconciler(
 		mgr.GetClient(),
 		mgr.GetEventRecorderFor("azurecluster-reconciler"),
 		reconcileTimeout,
 		watchFilterValue,
-	).SetupWithManager(ctx, mgr, controllers.Options{Options: controller.Options{MaxConcurrentReconciles: azureClusterConcurrency}, Cache: clusterCache}); err != nil {
+	).SetupWithManager(ctx, mgr, controllers.Options{Options: controller.Options{MaxConcurrentReconciles: azureClusterConcurrency}, Cache: clusterCache, DriftScanInterval: driftScanInterval}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AzureCluster")
 		os.Exit(1)
 	}
@@ -390,9 +486,10 @@ func registerControllers(ctx context.Context, mgr manager.Manager) {
 	// just use CAPI MachinePool feature flag rather than create a new one
 	setupLog.V(1).Info(fmt.Sprintf("%+v\n", feature.Gates))
 	if feature.Gates.Enabled(capifeature.MachinePool) {
-		mpCache, err := coalescing.NewRequestCache(debouncingTimer)
+		mpCache, err := newReconcileCache("AzureMachinePool")
 		if err != nil {
 			setupLog.Error(err, "failed to build mpCache ReconcileCache")
+			os.Exit(1)
 		}
 
 		if err := infrav1controllersexp.NewAzureMachinePoolReconciler(
@@ -405,9 +502,10 @@ func registerControllers(ctx context.Context, mgr manager.Manager) {
 			os.Exit(1)
 		}
 
-		mpmCache, err := coalescing.NewRequestCache(debouncingTimer)
+		mpmCache, err := newReconcileCache("AzureMachinePoolMachine")
 		if err != nil {
 			setupLog.Error(err, "failed to build mpmCache ReconcileCache")
+			os.Exit(1)
 		}
 
 		if err := infrav1controllersexp.NewAzureMachinePoolMachineController(
@@ -431,9 +529,10 @@ func registerControllers(ctx context.Context, mgr manager.Manager) {
 		}
 
 		if feature.Gates.Enabled(feature.AKS) {
-			mmpmCache, err := coalescing.NewRequestCache(debouncingTimer)
+			mmpmCache, err := newReconcileCache("AzureManagedMachinePool")
 			if err != nil {
 				setupLog.Error(err, "failed to build mmpmCache ReconcileCache")
+				os.Exit(1)
 			}
 
 			if err := infrav1controllersexp.NewAzureManagedMachinePoolReconciler(
@@ -446,9 +545,10 @@ func registerControllers(ctx context.Context, mgr manager.Manager) {
 				os.Exit(1)
 			}
 
-			mcCache, err := coalescing.NewRequestCache(debouncingTimer)
+			mcCache, err := newReconcileCache("AzureManagedCluster")
 			if err != nil {
 				setupLog.Error(err, "failed to build mcCache ReconcileCache")
+				os.Exit(1)
 			}
 
 			if err := (&infrav1controllersexp.AzureManagedClusterReconciler{
@@ -461,9 +561,10 @@ func registerControllers(ctx context.Context, mgr manager.Manager) {
 				os.Exit(1)
 			}
 
-			mcpCache, err := coalescing.NewRequestCache(debouncingTimer)
+			mcpCache, err := newReconcileCache("AzureManagedControlPlane")
 			if err != nil {
 				setupLog.Error(err, "failed to build mcpCache ReconcileCache")
+				os.Exit(1)
 			}
 
 			if err := (&infrav1controllersexp.AzureManagedControlPlaneReconciler{
@@ -479,6 +580,37 @@ func registerControllers(ctx context.Context, mgr manager.Manager) {
 	}
 }
 
+// debouncedKinds are the kinds of the controllers whose requests are coalesced.
+var debouncedKinds = sets.NewString(
+	"AzureCluster",
+	"AzureMachine",
+	"AzureMachinePool",
+	"AzureMachinePoolMachine",
+	"AzureManagedCluster",
+	"AzureManagedControlPlane",
+	"AzureManagedMachinePool",
+)
+
+// newReconcileCache returns the coalescing cache of the controller of the given kind.
+func newReconcileCache(kind string) (*coalescing.ReconcileCache, error) {
+	window := debouncingTimer
+	if override, ok := debouncingTimers[kind]; ok {
+		var err error
+		if window, err = time.ParseDuration(override); err != nil {
+			return nil, fmt.Errorf("invalid debouncing timer for %s: %w", kind, err)
+		}
+	}
+
+	return coalescing.NewRequestCache(coalescing.Options{
+		Name:             kind,
+		Window:           window,
+		InProgressWindow: debouncingInProgressTimer,
+		Jitter:           debouncingJitter,
+		StartupSpread:    debouncingStartupSpread,
+		Size:             debouncingCacheSize,
+	})
+}
+
 func registerWebhooks(mgr manager.Manager) {
 	if err := (&infrav1.AzureCluster{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "AzureCluster")
@@ -0,0 +1,87 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coalescing
+
+import (
+	"sync"
+	"time"
+
+	"sigs.k8s.io/cluster-api-provider-azure/util/cache/ttllru"
+)
+
+// minPruneSize is the minimum number of requests tracked by mapExpirations before expired requests are removed.
+const minPruneSize = 1024
+
+type (
+	// expirations stores the expiration of each request key.
+	expirations interface {
+		get(key string) (time.Time, bool)
+		set(key string, expiration time.Time)
+	}
+
+	// lruExpirations stores the expirations of the most recently reconciled requests.
+	lruExpirations struct {
+		cache ttllru.PeekingCacher
+	}
+
+	// mapExpirations stores the expirations of all the requests. Expired requests are removed as the map grows.
+	mapExpirations struct {
+		mu          sync.Mutex
+		expirations map[string]time.Time
+		pruneAt     int
+	}
+)
+
+func (e *lruExpirations) get(key string) (time.Time, bool) {
+	value, _, ok := e.cache.Peek(key)
+	if !ok {
+		return time.Time{}, false
+	}
+	expiration, ok := value.(time.Time)
+	return expiration, ok
+}
+
+func (e *lruExpirations) set(key string, expiration time.Time) {
+	e.cache.Add(key, expiration)
+}
+
+func (e *mapExpirations) get(key string) (time.Time, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	expiration, ok := e.expirations[key]
+	return expiration, ok
+}
+
+func (e *mapExpirations) set(key string, expiration time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.expirations[key] = expiration
+
+	if len(e.expirations) < e.pruneAt {
+		return
+	}
+	now := time.Now()
+	for k, v := range e.expirations {
+		if !v.After(now) {
+			delete(e.expirations, k)
+		}
+	}
+	e.pruneAt = 2 * len(e.expirations)
+	if e.pruneAt < minPruneSize {
+		e.pruneAt = minPruneSize
+	}
+}
@@ -25,6 +25,7 @@ import (
 	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	reconcile "sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 // MockReconcileCacher is a mock of ReconcileCacher interface.
@@ -51,28 +52,28 @@ func (m *MockReconcileCacher) EXPECT() *MockReconcileCacherMockRecorder {
 }
 
 // Reconciled mocks base method.
-func (m *MockReconcileCacher) Reconciled(key string) {
+func (m *MockReconcileCacher) Reconciled(key string, result reconcile.Result) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Reconciled", key)
+	m.ctrl.Call(m, "Reconciled", key, result)
 }
 
 // Reconciled indicates an expected call of Reconciled.
-func (mr *MockReconcileCacherMockRecorder) Reconciled(key interface{}) *gomock.Call {
+func (mr *MockReconcileCacherMockRecorder) Reconciled(key, result interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconciled", reflect.TypeOf((*MockReconcileCacher)(nil).Reconciled), key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconciled", reflect.TypeOf((*MockReconcileCacher)(nil).Reconciled), key, result)
 }
 
 // ShouldProcess mocks base method.
-func (m *MockReconcileCacher) ShouldProcess(key string) (time.Time, bool) {
+func (m *MockReconcileCacher) ShouldProcess(key string, deleting bool) (time.Time, bool) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ShouldProcess", key)
+	ret := m.ctrl.Call(m, "ShouldProcess", key, deleting)
 	ret0, _ := ret[0].(time.Time)
 	ret1, _ := ret[1].(bool)
 	return ret0, ret1
 }
 
 // ShouldProcess indicates an expected call of ShouldProcess.
-func (mr *MockReconcileCacherMockRecorder) ShouldProcess(key interface{}) *gomock.Call {
+func (mr *MockReconcileCacherMockRecorder) ShouldProcess(key, deleting interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShouldProcess", reflect.TypeOf((*MockReconcileCacher)(nil).ShouldProcess), key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShouldProcess", reflect.TypeOf((*MockReconcileCacher)(nil).ShouldProcess), key, deleting)
 }
@@ -18,69 +18,146 @@ package coalescing
 
 import (
 	"context"
+	"hash/fnv"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/ot"
 	"sigs.k8s.io/cluster-api-provider-azure/util/cache/ttllru"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 type (
-	// ReconcileCache uses and underlying time to live last recently used cache to track high frequency requests.
-	// A reconciler should call ShouldProcess to determine if the key has expired. If the key has expired, a zero value
-	// time.Time and true is returned. If the key has not expired, the expiration and false is returned. Upon successful
-	// reconciliation a reconciler should call Reconciled to update the cache expiry.
+	// Options configures the debounce windows of a ReconcileCache.
+	Options struct {
+		// Name is the name of the controller using the cache, used to label metrics.
+		Name string
+		// Window is the minimum interval after a successful reconciliation of an object before reconciling it again.
+		Window time.Duration
+		// InProgressWindow is used instead of Window after a successful reconciliation which requested a requeue sooner
+		// than Window, e.g. while a long-running Azure operation is in progress. Requeues after Window, e.g. to scan the
+		// Azure resources for drift, are steady reconciliations.
+		InProgressWindow time.Duration
+		// Jitter is the maximum factor of the window randomly added to it, so that objects reconciled together are not
+		// reconciled together again. 0 disables jitter.
+		Jitter float64
+		// StartupSpread is the maximum random delay of the first reconciliation of each object after the cache is
+		// created, so that all the objects are not reconciled at once when the manager starts. Objects being deleted
+		// are not delayed. 0 disables the delay.
+		StartupSpread time.Duration
+		// Size is the maximum number of objects tracked by the cache. 0 tracks all objects.
+		Size int
+	}
+
+	// ReconcileCache tracks the time until which each request should not be processed again. A reconciler should call
+	// ShouldProcess to determine if the key has expired, and whether the object is being deleted. If the key has
+	// expired, a zero value time.Time and true is returned. If the key has not expired, the expiration and false is returned. Upon successful reconciliation a
+	// reconciler should call Reconciled with the result of the reconciliation to update the cache expiry.
 	ReconcileCache struct {
-		lastSuccessfulReconciliationCache ttllru.PeekingCacher
+		opts        Options
+		expirations expirations
+		started     time.Time
 	}
 
 	// ReconcileCacher describes an interface for determining if a request should be reconciled through a call to
 	// ShouldProcess and if ok, reset the cool down through a call to Reconciled.
 	ReconcileCacher interface {
-		ShouldProcess(key string) (expiration time.Time, ok bool)
-		Reconciled(key string)
+		ShouldProcess(key string, deleting bool) (expiration time.Time, ok bool)
+		Reconciled(key string, result reconcile.Result)
 	}
 
 	// reconciler is the caching reconciler middleware that uses the cache.
 	reconciler struct {
 		upstream reconcile.Reconciler
+		reader   client.Reader
+		object   client.Object
 		cache    ReconcileCacher
 		log      logr.Logger
 	}
 )
 
-// NewRequestCache creates a new instance of a ReconcileCache given a specified window of expiration.
-func NewRequestCache(window time.Duration) (*ReconcileCache, error) {
-	cache, err := ttllru.New(1024, window)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to build ttllru cache")
+// NewRequestCache creates a new instance of a ReconcileCache given the specified windows of expiration.
+func NewRequestCache(opts Options) (*ReconcileCache, error) {
+	if opts.Window < 0 || opts.InProgressWindow < 0 || opts.Jitter < 0 || opts.StartupSpread < 0 || opts.Size < 0 {
+		return nil, errors.New("debounce windows, jitter and cache size must not be negative")
+	}
+
+	var store expirations = &mapExpirations{expirations: map[string]time.Time{}, pruneAt: minPruneSize}
+	if opts.Size > 0 {
+		// Keep the items until the longest expiration they could have.
+		longestWindow := opts.Window
+		if opts.InProgressWindow > longestWindow {
+			longestWindow = opts.InProgressWindow
+		}
+		timeToLive := longestWindow + time.Duration(opts.Jitter*float64(longestWindow))
+		cache, err := ttllru.New(opts.Size, timeToLive)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to build ttllru cache")
+		}
+		store = &lruExpirations{cache: cache}
 	}
 
 	return &ReconcileCache{
-		lastSuccessfulReconciliationCache: cache,
+		opts:        opts,
+		expirations: store,
+		started:     time.Now(),
 	}, nil
 }
 
 // ShouldProcess determines if the key has expired. If the key has expired, a zero value
 // time.Time and true is returned. If the key has not expired, the expiration and false is returned.
-func (cache *ReconcileCache) ShouldProcess(key string) (time.Time, bool) {
-	_, expiration, ok := cache.lastSuccessfulReconciliationCache.Peek(key)
-	return expiration, !ok
+// Keys not reconciled yet within the startup spread of the cache expire at a time within it derived from the key,
+// unless the object is being deleted.
+func (cache *ReconcileCache) ShouldProcess(key string, deleting bool) (time.Time, bool) {
+	now := time.Now()
+	expiration, ok := cache.expirations.get(key)
+	if !ok && !deleting && now.Sub(cache.started) < cache.opts.StartupSpread {
+		expiration = cache.started.Add(startupDelay(key, cache.opts.StartupSpread))
+	}
+
+	if !expiration.After(now) {
+		ot.RecordCoalescingRequest(context.Background(), cache.opts.Name, ot.CoalescingProcessed)
+		return time.Time{}, true
+	}
+	ot.RecordCoalescingRequest(context.Background(), cache.opts.Name, ot.CoalescingSkipped)
+	return expiration, false
 }
 
-// Reconciled updates the cache expiry for a given key.
-func (cache *ReconcileCache) Reconciled(key string) {
-	cache.lastSuccessfulReconciliationCache.Add(key, nil)
+// Reconciled updates the cache expiry for a given key. The in progress window is used if the result requests a
+// requeue sooner than the window.
+func (cache *ReconcileCache) Reconciled(key string, result reconcile.Result) {
+	window := cache.opts.Window
+	if (result.Requeue && result.RequeueAfter == 0) || (result.RequeueAfter > 0 && result.RequeueAfter < window) {
+		window = cache.opts.InProgressWindow
+	}
+	if cache.opts.Jitter > 0 {
+		window = wait.Jitter(window, cache.opts.Jitter)
+	}
+	cache.expirations.set(key, time.Now().Add(window))
+}
+
+// startupDelay returns the delay of the first reconciliation of a key within the startup spread. The delay is derived
+// from the key so that it doesn't change until the key is reconciled, without storing it.
+func startupDelay(key string, spread time.Duration) time.Duration {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return time.Duration(h.Sum64() % uint64(spread))
 }
 
 // NewReconciler returns a reconcile wrapper that will delay new reconcile.Requests
 // after the cache expiry of the request string key.
 // A successful reconciliation is defined as as one where no error is returned.
-func NewReconciler(upstream reconcile.Reconciler, cache ReconcileCacher, log logr.Logger) reconcile.Reconciler {
+// The reader and object, which are optional, are used to read the reconciled object to tell whether it is being deleted.
+func NewReconciler(upstream reconcile.Reconciler, reader client.Reader, object client.Object, cache ReconcileCacher, log logr.Logger) reconcile.Reconciler {
 	return &reconciler{
 		upstream: upstream,
+		reader:   reader,
+		object:   object,
 		cache:    cache,
 		log:      log.WithName("CoalescingReconciler"),
 	}
@@ -96,7 +173,7 @@ func (rc *reconciler) Reconcile(ctx context.Context, r reconcile.Request) (recon
 
 	log = log.WithValues("request", r.String())
 
-	if expiration, ok := rc.cache.ShouldProcess(r.String()); !ok {
+	if expiration, ok := rc.cache.ShouldProcess(r.String(), rc.deleting(ctx, r)); !ok {
 		log.V(4).Info("not processing", "expiration", expiration, "timeUntil", time.Until(expiration))
 		var requeueAfter = time.Until(expiration)
 		if requeueAfter < 1*time.Second {
@@ -113,6 +190,25 @@ func (rc *reconciler) Reconcile(ctx context.Context, r reconcile.Request) (recon
 	}
 
 	log.V(4).Info("successful")
-	rc.cache.Reconciled(r.String())
+	rc.cache.Reconciled(r.String(), result)
 	return result, nil
 }
+
+// deleting returns true if the object of the request is being deleted. Objects which cannot be read are considered
+// not being deleted, and left to the upstream reconciler.
+func (rc *reconciler) deleting(ctx context.Context, r reconcile.Request) bool {
+	if rc.reader == nil || rc.object == nil {
+		return false
+	}
+	obj, ok := rc.object.DeepCopyObject().(client.Object)
+	if !ok {
+		return false
+	}
+	if err := rc.reader.Get(ctx, r.NamespacedName, obj); err != nil {
+		if !apierrors.IsNotFound(err) {
+			rc.log.V(4).Info("failed to get object", "request", r.String(), "error", err.Error())
+		}
+		return false
+	}
+	return !obj.GetDeletionTimestamp().IsZero()
+}
@@ -18,6 +18,7 @@ package coalescing
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -26,8 +27,11 @@ import (
 	. "github.com/onsi/gomega"
 	gtypes "github.com/onsi/gomega/types"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	mock_coalescing "sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing/mocks"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -54,29 +58,72 @@ func TestCoalescingReconciler_Reconcile(t *testing.T) {
 		{
 			Name: "should call upstream reconciler if key does not exist in cache",
 			Reconciler: func(g *WithT, cacherMock *mock_coalescing.MockReconcileCacher, mockReconciler *mock_coalescing.MockReconciler) reconcile.Reconciler {
-				cacherMock.EXPECT().ShouldProcess(defaultRequestKey).Return(time.Now(), true)
-				cacherMock.EXPECT().Reconciled(defaultRequestKey)
+				cacherMock.EXPECT().ShouldProcess(defaultRequestKey, false).Return(time.Now(), true)
+				cacherMock.EXPECT().Reconciled(defaultRequestKey, reconcile.Result{})
 				mockReconciler.EXPECT().Reconcile(gomock.Any(), defaultRequest)
-				return NewReconciler(mockReconciler, cacherMock, logr.New(log.NullLogSink{}))
+				return NewReconciler(mockReconciler, nil, nil, cacherMock, logr.New(log.NullLogSink{}))
 			},
 			Request:   defaultRequest,
 			MatchThis: Equal(0 * time.Second),
 		},
+		{
+			Name: "should pass the upstream result to the cache",
+			Reconciler: func(g *WithT, cacherMock *mock_coalescing.MockReconcileCacher, mockReconciler *mock_coalescing.MockReconciler) reconcile.Reconciler {
+				cacherMock.EXPECT().ShouldProcess(defaultRequestKey, false).Return(time.Now(), true)
+				cacherMock.EXPECT().Reconciled(defaultRequestKey, reconcile.Result{RequeueAfter: 15 * time.Second})
+				mockReconciler.EXPECT().Reconcile(gomock.Any(), defaultRequest).Return(reconcile.Result{RequeueAfter: 15 * time.Second}, nil)
+				return NewReconciler(mockReconciler, nil, nil, cacherMock, logr.New(log.NullLogSink{}))
+			},
+			Request:   defaultRequest,
+			MatchThis: Equal(15 * time.Second),
+		},
 		{
 			Name: "should not call upstream reconciler if key does exists in cache and is not expired",
 			Reconciler: func(g *WithT, cacherMock *mock_coalescing.MockReconcileCacher, mockReconciler *mock_coalescing.MockReconciler) reconcile.Reconciler {
-				cacherMock.EXPECT().ShouldProcess(defaultRequestKey).Return(time.Now().Add(30*time.Second), false)
-				return NewReconciler(mockReconciler, cacherMock, logr.New(log.NullLogSink{}))
+				cacherMock.EXPECT().ShouldProcess(defaultRequestKey, false).Return(time.Now().Add(30*time.Second), false)
+				return NewReconciler(mockReconciler, nil, nil, cacherMock, logr.New(log.NullLogSink{}))
 			},
 			Request:   defaultRequest,
 			MatchThis: And(BeNumerically("<=", 30*time.Second), BeNumerically(">", 29*time.Second)),
 		},
+		{
+			Name: "should tell the cache the object is being deleted",
+			Reconciler: func(g *WithT, cacherMock *mock_coalescing.MockReconcileCacher, mockReconciler *mock_coalescing.MockReconciler) reconcile.Reconciler {
+				deleting := &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "aName",
+						Namespace:         "aNamespace",
+						DeletionTimestamp: &metav1.Time{Time: time.Now()},
+						Finalizers:        []string{"test"},
+					},
+				}
+				c := fake.NewClientBuilder().WithObjects(deleting).Build()
+				cacherMock.EXPECT().ShouldProcess(defaultRequestKey, true).Return(time.Now(), true)
+				cacherMock.EXPECT().Reconciled(defaultRequestKey, reconcile.Result{})
+				mockReconciler.EXPECT().Reconcile(gomock.Any(), defaultRequest)
+				return NewReconciler(mockReconciler, c, &corev1.ConfigMap{}, cacherMock, logr.New(log.NullLogSink{}))
+			},
+			Request:   defaultRequest,
+			MatchThis: Equal(0 * time.Second),
+		},
+		{
+			Name: "should tell the cache the object is not being deleted if it does not exist",
+			Reconciler: func(g *WithT, cacherMock *mock_coalescing.MockReconcileCacher, mockReconciler *mock_coalescing.MockReconciler) reconcile.Reconciler {
+				c := fake.NewClientBuilder().Build()
+				cacherMock.EXPECT().ShouldProcess(defaultRequestKey, false).Return(time.Now(), true)
+				cacherMock.EXPECT().Reconciled(defaultRequestKey, reconcile.Result{})
+				mockReconciler.EXPECT().Reconcile(gomock.Any(), defaultRequest)
+				return NewReconciler(mockReconciler, c, &corev1.ConfigMap{}, cacherMock, logr.New(log.NullLogSink{}))
+			},
+			Request:   defaultRequest,
+			MatchThis: Equal(0 * time.Second),
+		},
 		{
 			Name: "should call upstream reconciler if key does not exist in cache and return error",
 			Reconciler: func(g *WithT, cacherMock *mock_coalescing.MockReconcileCacher, mockReconciler *mock_coalescing.MockReconciler) reconcile.Reconciler {
-				cacherMock.EXPECT().ShouldProcess(defaultRequestKey).Return(time.Now(), true)
+				cacherMock.EXPECT().ShouldProcess(defaultRequestKey, false).Return(time.Now(), true)
 				mockReconciler.EXPECT().Reconcile(gomock.Any(), defaultRequest).Return(reconcile.Result{}, errors.New("boom"))
-				return NewReconciler(mockReconciler, cacherMock, logr.New(log.NullLogSink{}))
+				return NewReconciler(mockReconciler, nil, nil, cacherMock, logr.New(log.NullLogSink{}))
 			},
 			Request:   defaultRequest,
 			MatchThis: Equal(0 * time.Second),
@@ -103,3 +150,132 @@ func TestCoalescingReconciler_Reconcile(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileCache(t *testing.T) {
+	cases := []struct {
+		Name          string
+		Options       Options
+		Result        reconcile.Result
+		MinExpiration time.Duration
+		MaxExpiration time.Duration
+	}{
+		{
+			Name:          "should not process the key within the window",
+			Options:       Options{Window: time.Minute, InProgressWindow: time.Second, Size: 10},
+			MinExpiration: time.Minute - time.Second,
+			MaxExpiration: time.Minute,
+		},
+		{
+			Name:          "should use the in progress window when a requeue is requested",
+			Options:       Options{Window: time.Minute, InProgressWindow: 10 * time.Second, Size: 10},
+			Result:        reconcile.Result{RequeueAfter: 15 * time.Second},
+			MinExpiration: 9 * time.Second,
+			MaxExpiration: 10 * time.Second,
+		},
+		{
+			Name:          "should keep the window when a requeue after the window is requested",
+			Options:       Options{Window: time.Minute, InProgressWindow: 2 * time.Second, Size: 10},
+			Result:        reconcile.Result{RequeueAfter: 5 * time.Minute},
+			MinExpiration: time.Minute - time.Second,
+			MaxExpiration: time.Minute,
+		},
+		{
+			Name:          "should use the in progress window when an immediate requeue is requested",
+			Options:       Options{Window: time.Minute, InProgressWindow: 10 * time.Second, Size: 10},
+			Result:        reconcile.Result{Requeue: true},
+			MinExpiration: 9 * time.Second,
+			MaxExpiration: 10 * time.Second,
+		},
+		{
+			Name:          "should add jitter to the window",
+			Options:       Options{Window: time.Minute, Jitter: 0.5},
+			MinExpiration: time.Minute - time.Second,
+			MaxExpiration: 90 * time.Second,
+		},
+		{
+			Name:    "should process the key again without window",
+			Options: Options{InProgressWindow: time.Minute, Jitter: 0.5, Size: 10},
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			g := NewWithT(t)
+			cache, err := NewRequestCache(c.Options)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			expiration, ok := cache.ShouldProcess("key", false)
+			g.Expect(ok).To(BeTrue())
+			g.Expect(expiration).To(BeZero())
+
+			cache.Reconciled("key", c.Result)
+			expiration, ok = cache.ShouldProcess("key", false)
+			if c.MaxExpiration == 0 {
+				g.Expect(ok).To(BeTrue())
+				return
+			}
+			g.Expect(ok).To(BeFalse())
+			g.Expect(time.Until(expiration)).To(And(BeNumerically(">", c.MinExpiration), BeNumerically("<=", c.MaxExpiration)))
+
+			_, ok = cache.ShouldProcess("other-key", false)
+			g.Expect(ok).To(BeTrue())
+		})
+	}
+}
+
+func TestReconcileCache_StartupSpread(t *testing.T) {
+	g := NewWithT(t)
+	cache, err := NewRequestCache(Options{StartupSpread: time.Hour})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	skipped := 0
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		expiration, ok := cache.ShouldProcess(key, false)
+		if !ok {
+			skipped++
+			g.Expect(time.Until(expiration)).To(BeNumerically("<=", time.Hour))
+			// The delay of a key doesn't change until it is processed.
+			again, ok := cache.ShouldProcess(key, false)
+			g.Expect(ok).To(BeFalse())
+			g.Expect(again).To(Equal(expiration))
+		}
+	}
+	g.Expect(skipped).To(BeNumerically(">", 90))
+
+	// Objects being deleted are not delayed.
+	for i := 0; i < 100; i++ {
+		_, ok := cache.ShouldProcess(fmt.Sprintf("key-%d", i), true)
+		g.Expect(ok).To(BeTrue())
+	}
+
+	// Keys seen for the first time after the startup spread are processed right away.
+	cache.started = time.Now().Add(-time.Hour)
+	_, ok := cache.ShouldProcess("new-key", false)
+	g.Expect(ok).To(BeTrue())
+}
+
+func TestNewRequestCache_Invalid(t *testing.T) {
+	g := NewWithT(t)
+	_, err := NewRequestCache(Options{Window: -time.Second})
+	g.Expect(err).To(HaveOccurred())
+	_, err = NewRequestCache(Options{Size: -1})
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestMapExpirations_Prune(t *testing.T) {
+	g := NewWithT(t)
+	e := &mapExpirations{expirations: map[string]time.Time{}, pruneAt: minPruneSize}
+
+	for i := 0; i < minPruneSize-1; i++ {
+		e.set(fmt.Sprintf("expired-%d", i), time.Now().Add(-time.Second))
+	}
+	g.Expect(e.expirations).To(HaveLen(minPruneSize - 1))
+
+	e.set("key", time.Now().Add(time.Minute))
+	g.Expect(e.expirations).To(HaveLen(1))
+	g.Expect(e.pruneAt).To(Equal(minPruneSize))
+	_, ok := e.get("key")
+	g.Expect(ok).To(BeTrue())
+}
@@ -17,17 +17,290 @@ limitations under the License.
 package ot
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
 	crprometheus "github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/global"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/histogram"
 	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
 	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
 	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
 	selector "go.opentelemetry.io/otel/sdk/metric/selector/simple"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
+const (
+	// TokenCacheHit is the result of a token cache lookup which reused a cached token.
+	TokenCacheHit = "hit"
+	// TokenCacheMiss is the result of a token cache lookup which created a new token.
+	TokenCacheMiss = "miss"
+
+	// CoalescingProcessed is the result of a request the coalescing reconciler sent to its controller.
+	CoalescingProcessed = "processed"
+	// CoalescingSkipped is the result of a request the coalescing reconciler skipped because its object was reconciled
+	// recently.
+	CoalescingSkipped = "skipped"
+
+	// ResourceSKUCacheRefreshSuccess is the result of a successful listing of the resource SKUs of a cache.
+	ResourceSKUCacheRefreshSuccess = "success"
+	// ResourceSKUCacheRefreshFailure is the result of a failed listing of the resource SKUs of a cache.
+	ResourceSKUCacheRefreshFailure = "failure"
+
+	// rateLimitRemainingReadsHeader is the number of reads left in the current ARM throttling window of a subscription.
+	rateLimitRemainingReadsHeader = "x-ms-ratelimit-remaining-subscription-reads"
+	// rateLimitRemainingWritesHeader is the number of writes left in the current ARM throttling window of a subscription.
+	rateLimitRemainingWritesHeader = "x-ms-ratelimit-remaining-subscription-writes"
+
+	// unknownLabel is the value of the service and operation labels of Azure API requests which are not made by a client
+	// of an azure/services package.
+	unknownLabel = "unknown"
+)
+
+var (
+	meter = metric.Must(global.Meter("capz"))
+
+	tokenCacheLookups = meter.NewInt64Counter(
+		"capz_token_cache_lookups",
+		metric.WithDescription("Number of Azure credential token cache lookups, by result."),
+	)
+	tokenCacheEvictions = meter.NewInt64Counter(
+		"capz_token_cache_evictions",
+		metric.WithDescription("Number of Azure credential tokens evicted from the cache because their secret changed."),
+	)
+
+	coalescingRequests = meter.NewInt64Counter(
+		"capz_coalescing_requests",
+		metric.WithDescription("Number of reconcile requests of the coalescing reconciler, by controller and result (processed or skipped)."),
+	)
+
+	resourceSKUCacheRefreshes = meter.NewInt64Counter(
+		"capz_resource_sku_cache_refreshes",
+		metric.WithDescription("Number of listings of the resource SKUs of a subscription and location, by result."),
+	)
+	_ = meter.NewFloat64GaugeObserver(
+		"capz_resource_sku_cache_age_seconds",
+		observeResourceSKUCacheAge,
+		metric.WithDescription("Time in seconds since the cached resource SKUs of each subscription and location were listed."),
+	)
+
+	azureAPIRequestDuration = meter.NewFloat64Histogram(
+		"capz_azure_api_request_duration_seconds",
+		metric.WithDescription("Latency in seconds of Azure API requests, by service, operation and HTTP status code."),
+	)
+	azureAPIRequests = meter.NewInt64Counter(
+		"capz_azure_api_requests",
+		metric.WithDescription("Number of Azure API requests, by service, operation, HTTP status code and ARM error code."),
+	)
+	_ = meter.NewInt64GaugeObserver(
+		"capz_azure_api_ratelimit_remaining",
+		observeRateLimitRemaining,
+		metric.WithDescription("Number of Azure API requests left in the current ARM throttling window, by subscription and kind of request (reads or writes)."),
+	)
+
+	rateLimitWaitDuration = meter.NewFloat64Histogram(
+		"capz_azure_api_ratelimit_wait_seconds",
+		metric.WithDescription("Time in seconds Azure API requests waited for the client-side rate limiter, by kind of request (reads or writes) and priority."),
+	)
+	rateLimitQueued = meter.NewInt64UpDownCounter(
+		"capz_azure_api_ratelimit_queued_requests",
+		metric.WithDescription("Number of Azure API requests waiting for the client-side rate limiter, by kind of request (reads or writes) and priority."),
+	)
+
+	// rateLimitRemaining holds the last remaining number of reads and writes reported by ARM, by rateLimitKey.
+	rateLimitRemaining sync.Map
+
+	// resourceSKUCacheRefreshed holds the time the cached resource SKUs were listed, by resourceSKUCacheKey.
+	resourceSKUCacheRefreshed sync.Map
+
+	subscriptionPathRegex = regexp.MustCompile(`(?i)^/subscriptions/([^/]+)`)
+)
+
+// rateLimitKey identifies an ARM throttling budget.
+type rateLimitKey struct {
+	subscriptionID string
+	kind           string
+}
+
+// resourceSKUCacheKey identifies a resource SKU cache.
+type resourceSKUCacheKey struct {
+	subscriptionID string
+	location       string
+}
+
+// RecordTokenCacheLookup counts a lookup of the Azure credential token cache with the given result, either
+// TokenCacheHit or TokenCacheMiss.
+func RecordTokenCacheLookup(ctx context.Context, result string) {
+	tokenCacheLookups.Add(ctx, 1, attribute.String("result", result))
+}
+
+// RecordTokenCacheEviction counts a token evicted from the Azure credential token cache.
+func RecordTokenCacheEviction(ctx context.Context) {
+	tokenCacheEvictions.Add(ctx, 1)
+}
+
+// RecordCoalescingRequest counts a reconcile request of the given controller with the given result, either
+// CoalescingProcessed or CoalescingSkipped.
+func RecordCoalescingRequest(ctx context.Context, controller, result string) {
+	coalescingRequests.Add(ctx, 1, attribute.String("controller", controller), attribute.String("result", result))
+}
+
+// RecordResourceSKUCacheRefresh counts a listing of the resource SKUs of a subscription and location with the given
+// result, either ResourceSKUCacheRefreshSuccess or ResourceSKUCacheRefreshFailure.
+func RecordResourceSKUCacheRefresh(ctx context.Context, subscriptionID, location, result string) {
+	resourceSKUCacheRefreshes.Add(ctx, 1,
+		attribute.String("subscription_id", subscriptionID),
+		attribute.String("location", location),
+		attribute.String("result", result),
+	)
+}
+
+// SetResourceSKUCacheRefreshed sets the time the cached resource SKUs of a subscription and location were listed.
+func SetResourceSKUCacheRefreshed(subscriptionID, location string, refreshed time.Time) {
+	resourceSKUCacheRefreshed.Store(resourceSKUCacheKey{subscriptionID: subscriptionID, location: location}, refreshed)
+}
+
+// observeResourceSKUCacheAge reports the age of each resource SKU cache.
+func observeResourceSKUCacheAge(_ context.Context, result metric.Float64ObserverResult) {
+	resourceSKUCacheRefreshed.Range(func(k, v interface{}) bool {
+		key := k.(resourceSKUCacheKey)
+		result.Observe(time.Since(v.(time.Time)).Seconds(),
+			attribute.String("subscription_id", key.subscriptionID),
+			attribute.String("location", key.location),
+		)
+		return true
+	})
+}
+
+// RecordRateLimitQueued adds delta to the number of Azure API requests of the given kind and priority waiting for the
+// client-side rate limiter.
+func RecordRateLimitQueued(ctx context.Context, kind string, priority bool, delta int64) {
+	rateLimitQueued.Add(ctx, delta, attribute.String("kind", kind), attribute.Bool("priority", priority))
+}
+
+// RecordRateLimitWait records how long an Azure API request of the given kind and priority waited for the client-side
+// rate limiter.
+func RecordRateLimitWait(ctx context.Context, kind string, priority bool, wait time.Duration) {
+	rateLimitWaitDuration.Record(ctx, wait.Seconds(), attribute.String("kind", kind), attribute.Bool("priority", priority))
+}
+
+// AzureAPIMetricsSendDecorator is an autorest.SendDecorator which records the latency, the HTTP status code, the ARM
+// error code and the remaining ARM throttling budget of every Azure API request. Requests are labelled with the
+// service and operation of the innermost span started with tele.StartSpanWithLogger, e.g. "virtualmachines" and
+// "Get" for "virtualmachines.AzureClient.Get".
+func AzureAPIMetricsSendDecorator(s autorest.Sender) autorest.Sender {
+	return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		start := time.Now()
+		resp, err := s.Do(r)
+		recordAzureAPIRequest(r, resp, time.Since(start))
+		return resp, err
+	})
+}
+
+// recordAzureAPIRequest records the metrics of a completed Azure API request. resp is nil if the request failed
+// before a response was received.
+func recordAzureAPIRequest(r *http.Request, resp *http.Response, duration time.Duration) {
+	ctx := r.Context()
+	service, operation := azureAPIOperation(ctx)
+	statusCode := "none"
+	if resp != nil {
+		statusCode = strconv.Itoa(resp.StatusCode)
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.String("service", service),
+		attribute.String("operation", operation),
+		attribute.String("status_code", statusCode),
+	}
+	azureAPIRequestDuration.Record(ctx, duration.Seconds(), attrs...)
+	azureAPIRequests.Add(ctx, 1, append(attrs, attribute.String("error_code", armErrorCode(resp)))...)
+
+	if resp == nil {
+		return
+	}
+	m := subscriptionPathRegex.FindStringSubmatch(r.URL.Path)
+	if m == nil {
+		return
+	}
+	storeRateLimitRemaining(m[1], "reads", resp.Header.Get(rateLimitRemainingReadsHeader))
+	storeRateLimitRemaining(m[1], "writes", resp.Header.Get(rateLimitRemainingWritesHeader))
+}
+
+// azureAPIOperation returns the service and the operation of an Azure API request from the name of the innermost
+// span in its context.
+func azureAPIOperation(ctx context.Context) (service, operation string) {
+	spanName, ok := tele.SpanNameFromCtx(ctx)
+	if !ok || spanName == "" {
+		return unknownLabel, unknownLabel
+	}
+	parts := strings.Split(spanName, ".")
+	return parts[0], parts[len(parts)-1]
+}
+
+// armErrorCode returns the ARM error code of a failed Azure API request, or an empty string. The response body is
+// restored after it is read so that autorest can still unmarshal it.
+func armErrorCode(resp *http.Response) string {
+	if resp == nil || resp.StatusCode < http.StatusBadRequest || resp.Body == nil {
+		return ""
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return ""
+	}
+
+	var armError struct {
+		Code  string `json:"code"`
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &armError); err != nil {
+		return ""
+	}
+	if armError.Error.Code != "" {
+		return armError.Error.Code
+	}
+	return armError.Code
+}
+
+// storeRateLimitRemaining stores the remaining ARM throttling budget of a subscription reported in a response header.
+// Responses which do not report the budget are ignored.
+func storeRateLimitRemaining(subscriptionID, kind, header string) {
+	if header == "" {
+		return
+	}
+	remaining, err := strconv.ParseInt(header, 10, 64)
+	if err != nil {
+		return
+	}
+	rateLimitRemaining.Store(rateLimitKey{subscriptionID: strings.ToLower(subscriptionID), kind: kind}, remaining)
+}
+
+// observeRateLimitRemaining reports the last remaining ARM throttling budget of every subscription.
+func observeRateLimitRemaining(_ context.Context, result metric.Int64ObserverResult) {
+	rateLimitRemaining.Range(func(k, v interface{}) bool {
+		key := k.(rateLimitKey)
+		result.Observe(v.(int64), attribute.String("subscription_id", key.subscriptionID), attribute.String("kind", key.kind))
+		return true
+	})
+}
+
 // RegisterMetrics enables prometheus metrics for OpenTelemetry.
 func RegisterMetrics() error {
 	config := prometheus.Config{
@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ot
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+func TestAzureAPIOperation(t *testing.T) {
+	g := NewWithT(t)
+
+	service, operation := azureAPIOperation(context.Background())
+	g.Expect(service).To(Equal(unknownLabel))
+	g.Expect(operation).To(Equal(unknownLabel))
+
+	ctx, _, done := tele.StartSpanWithLogger(context.Background(), "virtualmachines.AzureClient.Get")
+	defer done()
+	service, operation = azureAPIOperation(ctx)
+	g.Expect(service).To(Equal("virtualmachines"))
+	g.Expect(operation).To(Equal("Get"))
+}
+
+func TestARMErrorCode(t *testing.T) {
+	testcases := []struct {
+		name       string
+		statusCode int
+		body       string
+		want       string
+	}{
+		{
+			name:       "successful request",
+			statusCode: http.StatusOK,
+			body:       `{"name":"my-vm"}`,
+			want:       "",
+		},
+		{
+			name:       "throttled request",
+			statusCode: http.StatusTooManyRequests,
+			body:       `{"error":{"code":"SubscriptionRequestsThrottled","message":"Number of requests exceeded the limit."}}`,
+			want:       "SubscriptionRequestsThrottled",
+		},
+		{
+			name:       "failed long-running operation",
+			statusCode: http.StatusConflict,
+			body:       `{"code":"OperationNotAllowed","message":"Operation not allowed."}`,
+			want:       "OperationNotAllowed",
+		},
+		{
+			name:       "body is not json",
+			statusCode: http.StatusBadGateway,
+			body:       "Bad Gateway",
+			want:       "",
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			resp := &http.Response{StatusCode: tc.statusCode, Body: io.NopCloser(strings.NewReader(tc.body))}
+			g.Expect(armErrorCode(resp)).To(Equal(tc.want))
+
+			// the body can still be read by autorest.
+			body, err := io.ReadAll(resp.Body)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(string(body)).To(Equal(tc.body))
+		})
+	}
+}
+
+func TestAzureAPIMetricsSendDecorator(t *testing.T) {
+	g := NewWithT(t)
+
+	sender := autorest.DecorateSender(autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		header := http.Header{}
+		header.Set(rateLimitRemainingReadsHeader, "11999")
+		header.Set(rateLimitRemainingWritesHeader, "1199")
+		return &http.Response{StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+	}), AzureAPIMetricsSendDecorator)
+
+	req, err := http.NewRequest(http.MethodGet, "https://management.azure.com/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm", http.NoBody)
+	g.Expect(err).NotTo(HaveOccurred())
+	resp, err := sender.Do(req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+	reads, ok := rateLimitRemaining.Load(rateLimitKey{subscriptionID: "123", kind: "reads"})
+	g.Expect(ok).To(BeTrue())
+	g.Expect(reads).To(Equal(int64(11999)))
+	writes, ok := rateLimitRemaining.Load(rateLimitKey{subscriptionID: "123", kind: "writes"})
+	g.Expect(ok).To(BeTrue())
+	g.Expect(writes).To(Equal(int64(1199)))
+}
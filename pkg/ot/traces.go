@@ -18,29 +18,149 @@ package ot
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"os"
 	"time"
 
 	"github.com/Azure/go-autorest/tracing"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/jaeger"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"google.golang.org/grpc/credentials"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	"sigs.k8s.io/cluster-api-provider-azure/version"
 )
 
+const (
+	// ExporterOTLPGRPC exports traces to an OpenTelemetry collector over OTLP/gRPC.
+	ExporterOTLPGRPC = "otlp-grpc"
+	// ExporterOTLPHTTP exports traces to an OpenTelemetry collector over OTLP/HTTP.
+	ExporterOTLPHTTP = "otlp-http"
+	// ExporterJaeger exports traces to a Jaeger collector.
+	ExporterJaeger = "jaeger"
+	// ExporterStdout writes traces to stdout, or to a file.
+	ExporterStdout = "stdout"
+
+	// defaultOTLPGRPCEndpoint is the opentelemetry-collector service in the namespace of the manager.
+	defaultOTLPGRPCEndpoint = "opentelemetry-collector:4317"
+)
+
+// TracingOptions configures how traces are exported and sampled.
+type TracingOptions struct {
+	// Exporter is one of ExporterOTLPGRPC, ExporterOTLPHTTP, ExporterJaeger or ExporterStdout.
+	Exporter string
+	// Endpoint is the host:port of the OTLP collector, the URL of the Jaeger collector or the file the stdout exporter
+	// writes to. When empty, the exporter falls back to the standard OTEL_EXPORTER_* environment variables and its
+	// default endpoint.
+	Endpoint string
+	// Insecure disables TLS for the connection to the collector.
+	Insecure bool
+	// CAFile is the PEM file of the certificate authorities trusted to verify the collector.
+	// The system certificate pool is used when empty.
+	CAFile string
+	// CertFile and KeyFile are the PEM files of the client certificate presented to the collector.
+	CertFile string
+	KeyFile  string
+	// Headers are sent with every export request, e.g. for authentication.
+	Headers map[string]string
+	// SamplingRatio is the fraction of traces sampled when there is no sampled parent span.
+	SamplingRatio float64
+
+	// flags are the flags the options are parsed from, to tell the options set explicitly from their defaults.
+	flags *pflag.FlagSet
+}
+
+// AddFlags adds the flags which configure the tracing options to the given flag set.
+func (o *TracingOptions) AddFlags(fs *pflag.FlagSet) {
+	o.flags = fs
+	fs.StringVar(&o.Exporter,
+		"tracing-exporter",
+		ExporterOTLPGRPC,
+		"The exporter traces are sent with, one of otlp-grpc, otlp-http, jaeger or stdout.",
+	)
+	fs.StringVar(&o.Endpoint,
+		"tracing-endpoint",
+		"",
+		"The host:port of the OTLP collector, the URL of the Jaeger collector or the file the stdout exporter writes to. "+
+			"Defaults to the standard OTEL_EXPORTER_* environment variables, or to opentelemetry-collector:4317 for otlp-grpc.",
+	)
+	fs.BoolVar(&o.Insecure,
+		"tracing-insecure",
+		false,
+		"Send traces to the collector without TLS. When not set, the OTEL_EXPORTER_OTLP_INSECURE environment variable applies, "+
+			"and the default otlp-grpc collector is reached without TLS.",
+	)
+	fs.StringVar(&o.CAFile,
+		"tracing-ca-file",
+		"",
+		"The PEM file of the certificate authorities used to verify the tracing collector. Cannot be set with --tracing-insecure.",
+	)
+	fs.StringVar(&o.CertFile,
+		"tracing-cert-file",
+		"",
+		"The PEM file of the client certificate presented to the tracing collector. Cannot be set with --tracing-insecure.",
+	)
+	fs.StringVar(&o.KeyFile,
+		"tracing-key-file",
+		"",
+		"The PEM file of the private key of the client certificate presented to the tracing collector.",
+	)
+	fs.StringToStringVar(&o.Headers,
+		"tracing-headers",
+		nil,
+		"Headers sent to the tracing collector with every export request, e.g. authorization=Bearer <token>.",
+	)
+	fs.Float64Var(&o.SamplingRatio,
+		"tracing-sampling-ratio",
+		1,
+		"The fraction of traces sampled, between 0 and 1. Spans with a parent span follow the sampling decision of their parent.",
+	)
+}
+
+// Validate returns an error if the tracing options are invalid.
+func (o TracingOptions) Validate() error {
+	switch o.Exporter {
+	case ExporterOTLPGRPC, ExporterOTLPHTTP, ExporterJaeger, ExporterStdout:
+	default:
+		return errors.Errorf("unknown tracing exporter %q, must be one of %s, %s, %s or %s", o.Exporter, ExporterOTLPGRPC, ExporterOTLPHTTP, ExporterJaeger, ExporterStdout)
+	}
+	if o.SamplingRatio < 0 || o.SamplingRatio > 1 {
+		return errors.Errorf("tracing sampling ratio %v must be between 0 and 1", o.SamplingRatio)
+	}
+	if (o.CertFile == "") != (o.KeyFile == "") {
+		return errors.New("tracing client certificate and key files must be set together")
+	}
+	if o.isInsecure() && (o.CAFile != "" || o.CertFile != "") {
+		return errors.New("tracing TLS files cannot be set when tracing is insecure")
+	}
+	return nil
+}
+
 // RegisterTracing enables code tracing via OpenTelemetry.
-func RegisterTracing(ctx context.Context, log logr.Logger) error {
-	tp, err := otlpTracerProvider(ctx, "opentelemetry-collector:4317")
+func RegisterTracing(ctx context.Context, log logr.Logger, opts TracingOptions) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	tp, err := newTracerProvider(ctx, opts)
 	if err != nil {
 		return err
 	}
 	otel.SetTracerProvider(tp)
+	// Only the W3C trace context is propagated to the Azure API, see tele.InjectTraceContext.
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
 	tracing.Register(NewOpenTelemetryAutorestTracer(tele.Tracer()))
 
 	// Give the tracer provider 5 seconds to shut down when the context closes.
@@ -56,12 +176,12 @@ func RegisterTracing(ctx context.Context, log logr.Logger) error {
 	return nil
 }
 
-// otlpTracerProvider initializes an OTLP exporter and configures the corresponding tracer provider.
-func otlpTracerProvider(ctx context.Context, url string) (*sdktrace.TracerProvider, error) {
+// newTracerProvider initializes the configured exporter and the corresponding tracer provider.
+func newTracerProvider(ctx context.Context, opts TracingOptions) (*sdktrace.TracerProvider, error) {
 	res, err := resource.New(ctx,
 		resource.WithAttributes(
 			semconv.ServiceNameKey.String("capz"),
-			attribute.String("exporter", "otlp"),
+			attribute.String("exporter", opts.Exporter),
 			attribute.String("version", version.Get().String()),
 			attribute.String("azuresdk.version", version.Get().AzureSdkVersion),
 		),
@@ -70,22 +190,165 @@ func otlpTracerProvider(ctx context.Context, url string) (*sdktrace.TracerProvid
 		return nil, errors.Wrap(err, "failed to create opentelemetry resource")
 	}
 
-	traceExporter, err := otlptracegrpc.New(ctx,
-		otlptracegrpc.WithInsecure(),
-		otlptracegrpc.WithEndpoint(url),
-	)
+	traceExporter, err := newSpanExporter(ctx, opts)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create otlp trace exporter")
+		return nil, errors.Wrapf(err, "failed to create %s trace exporter", opts.Exporter)
 	}
 
 	bsp := sdktrace.NewBatchSpanProcessor(traceExporter)
-	tracerProvider := sdktrace.NewTracerProvider(
-		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SamplingRatio))),
 		sdktrace.WithResource(res),
 		sdktrace.WithSpanProcessor(bsp),
-	)
-	otel.SetTracerProvider(tracerProvider)
-	otel.SetTextMapPropagator(propagation.TraceContext{})
+	), nil
+}
 
-	return tracerProvider, nil
+// newSpanExporter creates the span exporter selected by the tracing options.
+func newSpanExporter(ctx context.Context, opts TracingOptions) (sdktrace.SpanExporter, error) {
+	tlsConfig, err := opts.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	switch opts.Exporter {
+	// The headers and the connection security are only passed to the OTLP exporters when they are set explicitly, so
+	// that the exporters otherwise fall back to the OTEL_EXPORTER_OTLP_* environment variables.
+	case ExporterOTLPHTTP:
+		var httpOpts []otlptracehttp.Option
+		if len(opts.Headers) > 0 {
+			httpOpts = append(httpOpts, otlptracehttp.WithHeaders(opts.Headers))
+		}
+		if opts.Endpoint != "" {
+			httpOpts = append(httpOpts, otlptracehttp.WithEndpoint(opts.Endpoint))
+		}
+		if tlsConfig != nil {
+			httpOpts = append(httpOpts, otlptracehttp.WithTLSClientConfig(tlsConfig))
+		} else if opts.isInsecure() {
+			httpOpts = append(httpOpts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, httpOpts...)
+
+	case ExporterJaeger:
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if tlsConfig != nil {
+			transport.TLSClientConfig = tlsConfig
+		}
+		jaegerOpts := []jaeger.CollectorEndpointOption{
+			jaeger.WithHTTPClient(&http.Client{Transport: &headerRoundTripper{headers: opts.Headers, next: transport}}),
+		}
+		if opts.Endpoint != "" {
+			jaegerOpts = append(jaegerOpts, jaeger.WithEndpoint(opts.Endpoint))
+		}
+		return jaeger.New(jaeger.WithCollectorEndpoint(jaegerOpts...))
+
+	case ExporterStdout:
+		if opts.Endpoint == "" {
+			return stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		}
+		f, err := os.OpenFile(opts.Endpoint, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to open trace file %s", opts.Endpoint)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+		return &fileSpanExporter{Exporter: exporter, file: f}, nil
+
+	default:
+		var grpcOpts []otlptracegrpc.Option
+		if len(opts.Headers) > 0 {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithHeaders(opts.Headers))
+		}
+		defaultEndpoint := false
+		if endpoint := opts.Endpoint; endpoint != "" {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithEndpoint(endpoint))
+		} else if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithEndpoint(defaultOTLPGRPCEndpoint))
+			defaultEndpoint = true
+		}
+		// The default collector in the namespace of the manager is reached without TLS, unless TLS is set explicitly.
+		if tlsConfig != nil {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
+		} else if opts.isInsecure() || defaultEndpoint {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithInsecure())
+		}
+		return otlptracegrpc.New(ctx, grpcOpts...)
+	}
+}
+
+// fileSpanExporter is a stdout exporter writing to a file, which it closes when it shuts down.
+type fileSpanExporter struct {
+	*stdouttrace.Exporter
+	file *os.File
+}
+
+// Shutdown shuts down the exporter and closes its file.
+func (e *fileSpanExporter) Shutdown(ctx context.Context) error {
+	err := e.Exporter.Shutdown(ctx)
+	if closeErr := e.file.Close(); closeErr != nil && err == nil {
+		err = errors.Wrapf(closeErr, "failed to close trace file %s", e.file.Name())
+	}
+	return err
+}
+
+// isSet returns true if the flag with the given name was set explicitly, or if the options were not parsed from flags.
+func (o TracingOptions) isSet(name string) bool {
+	return o.flags == nil || o.flags.Changed(name)
+}
+
+// isInsecure returns true if TLS is disabled explicitly for the connection to the collector.
+func (o TracingOptions) isInsecure() bool {
+	return o.Insecure && o.isSet("tracing-insecure")
+}
+
+// tlsConfig returns the TLS configuration of the connection to the collector, or nil if TLS is not set explicitly
+// with --tracing-insecure=false or the TLS files.
+func (o TracingOptions) tlsConfig() (*tls.Config, error) {
+	if o.isInsecure() {
+		return nil, nil
+	}
+	if o.CAFile == "" && o.CertFile == "" && !(o.isSet("tracing-insecure") && !o.Insecure) {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if o.CAFile != "" {
+		pem, err := os.ReadFile(o.CAFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read tracing CA file %s", o.CAFile)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.Errorf("no certificates found in tracing CA file %s", o.CAFile)
+		}
+		cfg.RootCAs = pool
+	}
+	if o.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(o.CertFile, o.KeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load tracing client certificate")
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+// headerRoundTripper adds headers to every request, since the Jaeger exporter cannot be configured with headers.
+type headerRoundTripper struct {
+	headers map[string]string
+	next    http.RoundTripper
+}
+
+// RoundTrip adds the headers to a copy of the request and sends it.
+func (h *headerRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	if len(h.headers) == 0 {
+		return h.next.RoundTrip(r)
+	}
+	r = r.Clone(r.Context())
+	for k, v := range h.headers {
+		r.Header.Set(k, v)
+	}
+	return h.next.RoundTrip(r)
 }
@@ -0,0 +1,168 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ot
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+)
+
+func TestTracingOptionsDefaults(t *testing.T) {
+	g := NewWithT(t)
+
+	var opts TracingOptions
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	opts.AddFlags(fs)
+	g.Expect(fs.Parse(nil)).To(Succeed())
+
+	g.Expect(opts.Exporter).To(Equal(ExporterOTLPGRPC))
+	g.Expect(opts.Insecure).To(BeFalse())
+	g.Expect(opts.SamplingRatio).To(Equal(1.0))
+	g.Expect(opts.Validate()).To(Succeed())
+
+	// The connection security is left to the exporter unless it is set explicitly.
+	g.Expect(opts.isInsecure()).To(BeFalse())
+	tlsConfig, err := opts.tlsConfig()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tlsConfig).To(BeNil())
+}
+
+func TestTracingOptionsConnectionSecurity(t *testing.T) {
+	testcases := []struct {
+		name         string
+		args         []string
+		wantInsecure bool
+		wantTLS      bool
+	}{
+		{
+			name: "not set explicitly",
+		},
+		{
+			name:         "insecure",
+			args:         []string{"--tracing-insecure"},
+			wantInsecure: true,
+		},
+		{
+			name:    "secure",
+			args:    []string{"--tracing-insecure=false"},
+			wantTLS: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var opts TracingOptions
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			opts.AddFlags(fs)
+			g.Expect(fs.Parse(tc.args)).To(Succeed())
+			g.Expect(opts.Validate()).To(Succeed())
+
+			g.Expect(opts.isInsecure()).To(Equal(tc.wantInsecure))
+			tlsConfig, err := opts.tlsConfig()
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(tlsConfig != nil).To(Equal(tc.wantTLS))
+		})
+	}
+}
+
+func TestStdoutExporterClosesFile(t *testing.T) {
+	g := NewWithT(t)
+
+	path := filepath.Join(t.TempDir(), "traces.json")
+	exporter, err := newSpanExporter(context.Background(), TracingOptions{Exporter: ExporterStdout, Endpoint: path})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(exporter.Shutdown(context.Background())).To(Succeed())
+
+	fileExporter, ok := exporter.(*fileSpanExporter)
+	g.Expect(ok).To(BeTrue())
+	_, err = fileExporter.file.WriteString("{}")
+	g.Expect(err).To(MatchError(os.ErrClosed))
+}
+
+func TestTracingOptionsValidate(t *testing.T) {
+	testcases := []struct {
+		name    string
+		opts    TracingOptions
+		wantErr string
+	}{
+		{
+			name: "otlp http exporter with TLS and headers",
+			opts: TracingOptions{
+				Exporter:      ExporterOTLPHTTP,
+				Endpoint:      "collector.observability:4318",
+				CAFile:        "/etc/tracing/ca.crt",
+				CertFile:      "/etc/tracing/tls.crt",
+				KeyFile:       "/etc/tracing/tls.key",
+				Headers:       map[string]string{"authorization": "Bearer token"},
+				SamplingRatio: 0.1,
+			},
+		},
+		{
+			name:    "unknown exporter",
+			opts:    TracingOptions{Exporter: "zipkin", Insecure: true, SamplingRatio: 1},
+			wantErr: "unknown tracing exporter \"zipkin\", must be one of otlp-grpc, otlp-http, jaeger or stdout",
+		},
+		{
+			name:    "sampling ratio above 1",
+			opts:    TracingOptions{Exporter: ExporterJaeger, Insecure: true, SamplingRatio: 1.5},
+			wantErr: "tracing sampling ratio 1.5 must be between 0 and 1",
+		},
+		{
+			name:    "client certificate without key",
+			opts:    TracingOptions{Exporter: ExporterOTLPGRPC, CertFile: "/etc/tracing/tls.crt", SamplingRatio: 1},
+			wantErr: "tracing client certificate and key files must be set together",
+		},
+		{
+			name: "TLS files without setting the connection security explicitly",
+			opts: func() TracingOptions {
+				var opts TracingOptions
+				fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+				opts.AddFlags(fs)
+				_ = fs.Parse([]string{"--tracing-ca-file=/etc/tracing/ca.crt"})
+				return opts
+			}(),
+		},
+		{
+			name:    "TLS files with an insecure connection",
+			opts:    TracingOptions{Exporter: ExporterOTLPGRPC, Insecure: true, CAFile: "/etc/tracing/ca.crt", SamplingRatio: 1},
+			wantErr: "tracing TLS files cannot be set when tracing is insecure",
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			err := tc.opts.Validate()
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(tc.wantErr))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
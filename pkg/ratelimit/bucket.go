@@ -0,0 +1,114 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// minWait is the shortest time a waiter sleeps before trying to take a token again. Normal waiters which yield to
+// priority waiters while tokens are available sleep for this long.
+const minWait = 10 * time.Millisecond
+
+// bucket is a token bucket which refills at a constant rate up to its burst. Waiters with priority take tokens before
+// any other waiter.
+type bucket struct {
+	mu              sync.Mutex
+	rate            float64 // tokens per second
+	burst           float64
+	tokens          float64
+	last            time.Time
+	priorityWaiters int
+	now             func() time.Time
+}
+
+// newBucket returns a full bucket which allows perHour tokens per hour, in bursts of up to a tenth of that.
+func newBucket(perHour int, now func() time.Time) *bucket {
+	burst := float64(perHour / 10)
+	if burst < 1 {
+		burst = 1
+	}
+	return &bucket{
+		rate:   float64(perHour) / time.Hour.Seconds(),
+		burst:  burst,
+		tokens: burst,
+		last:   now(),
+		now:    now,
+	}
+}
+
+// take takes a token from the bucket and returns 0, or returns how long to wait before trying again if no token is
+// available for the waiter.
+func (b *bucket) take(priority bool) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	if b.tokens >= 1 && (priority || b.priorityWaiters == 0) {
+		b.tokens--
+		return 0
+	}
+	wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	if wait < minWait {
+		wait = minWait
+	}
+	return wait
+}
+
+// queue adds or removes a priority waiter. Other waiters don't take tokens while priority waiters are queued.
+func (b *bucket) queue(delta int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.priorityWaiters += delta
+}
+
+// wait blocks until a token is taken from the bucket or ctx is done. onQueued is called once the waiter has to wait,
+// and the func it returns once the waiter leaves the queue.
+func (b *bucket) wait(ctx context.Context, priority bool, onQueued func() func()) error {
+	d := b.take(priority)
+	if d == 0 {
+		return nil
+	}
+
+	if priority {
+		b.queue(1)
+		defer b.queue(-1)
+	}
+	defer onQueued()()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+		if d = b.take(priority); d == 0 {
+			return nil
+		}
+		timer.Reset(d)
+	}
+}
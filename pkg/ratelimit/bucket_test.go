@@ -0,0 +1,109 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+// fakeClock is a clock which only moves when advanced.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.t
+}
+
+func TestBucketTake(t *testing.T) {
+	g := NewWithT(t)
+	clock := &fakeClock{t: time.Now()}
+
+	// 3600 tokens per hour is one per second, in bursts of 360.
+	b := newBucket(3600, clock.now)
+	for i := 0; i < 360; i++ {
+		g.Expect(b.take(false)).To(BeZero())
+	}
+	g.Expect(b.take(false)).To(Equal(time.Second))
+
+	clock.t = clock.t.Add(500 * time.Millisecond)
+	g.Expect(b.take(false)).To(Equal(500 * time.Millisecond))
+
+	clock.t = clock.t.Add(500 * time.Millisecond)
+	g.Expect(b.take(false)).To(BeZero())
+
+	// The bucket doesn't refill beyond its burst.
+	clock.t = clock.t.Add(time.Hour)
+	for i := 0; i < 360; i++ {
+		g.Expect(b.take(false)).To(BeZero())
+	}
+	g.Expect(b.take(false)).NotTo(BeZero())
+}
+
+func TestBucketTakeSmallBudget(t *testing.T) {
+	g := NewWithT(t)
+	clock := &fakeClock{t: time.Now()}
+
+	b := newBucket(5, clock.now)
+	g.Expect(b.burst).To(Equal(1.0))
+	g.Expect(b.take(false)).To(BeZero())
+	g.Expect(b.take(false)).To(Equal(12 * time.Minute))
+}
+
+func TestBucketTakePriority(t *testing.T) {
+	g := NewWithT(t)
+	clock := &fakeClock{t: time.Now()}
+
+	b := newBucket(3600, clock.now)
+	b.queue(1)
+	g.Expect(b.take(false)).To(Equal(minWait))
+	g.Expect(b.take(true)).To(BeZero())
+
+	b.queue(-1)
+	g.Expect(b.take(false)).To(BeZero())
+}
+
+func TestBucketWait(t *testing.T) {
+	g := NewWithT(t)
+
+	// 36000 tokens per hour is one every 100ms, in bursts of 3600.
+	b := newBucket(36000, time.Now)
+	b.tokens = 0
+
+	queued, dequeued := 0, 0
+	onQueued := func() func() {
+		queued++
+		return func() { dequeued++ }
+	}
+
+	start := time.Now()
+	g.Expect(b.wait(context.Background(), true, onQueued)).To(Succeed())
+	g.Expect(time.Since(start)).To(BeNumerically(">=", 90*time.Millisecond))
+	g.Expect(queued).To(Equal(1))
+	g.Expect(dequeued).To(Equal(1))
+	g.Expect(b.priorityWaiters).To(BeZero())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	g.Expect(b.wait(ctx, false, onQueued)).To(MatchError(context.Canceled))
+	g.Expect(queued).To(Equal(2))
+	g.Expect(dequeued).To(Equal(2))
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ratelimit limits the rate of Azure API requests sent by the controllers, so that all the clusters of a
+// management cluster share the ARM throttling budget of their subscriptions instead of exhausting it.
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/ot"
+)
+
+const (
+	// Reads is the kind of GET and HEAD requests.
+	Reads = "reads"
+	// Writes is the kind of all other requests.
+	Writes = "writes"
+)
+
+// Options configures the budgets of the Azure API rate limiters.
+type Options struct {
+	// ReadsPerHour is the number of reads allowed per hour for each subscription and identity. 0 disables the limit.
+	ReadsPerHour int
+	// WritesPerHour is the number of writes allowed per hour for each subscription and identity. 0 disables the limit.
+	WritesPerHour int
+}
+
+// AddFlags adds the rate limiting flags to the given flag set.
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.IntVar(&o.ReadsPerHour, "azure-api-reads-per-hour", 0,
+		"Number of Azure API reads allowed per hour for each subscription and identity, shared by all the clusters using them. ARM allows 12000. 0 disables the limit.")
+	fs.IntVar(&o.WritesPerHour, "azure-api-writes-per-hour", 0,
+		"Number of Azure API writes allowed per hour for each subscription and identity, shared by all the clusters using them. ARM allows 1200. 0 disables the limit.")
+}
+
+// Validate returns an error if the options are invalid.
+func (o *Options) Validate() error {
+	if o.ReadsPerHour < 0 || o.WritesPerHour < 0 {
+		return errors.New("the Azure API reads and writes per hour must not be negative")
+	}
+	return nil
+}
+
+var (
+	mu       sync.Mutex
+	options  Options
+	limiters = map[string]*Limiter{}
+)
+
+// Configure sets the budgets of the rate limiters and discards the existing limiters.
+func Configure(opts Options) {
+	mu.Lock()
+	defer mu.Unlock()
+	options = opts
+	limiters = map[string]*Limiter{}
+}
+
+// For returns the limiter shared by the Azure credentials with the given key, usually the HashKey of the scope, or nil
+// if rate limiting is disabled.
+func For(key string) *Limiter {
+	mu.Lock()
+	defer mu.Unlock()
+	if options.ReadsPerHour == 0 && options.WritesPerHour == 0 {
+		return nil
+	}
+	l, ok := limiters[key]
+	if !ok {
+		l = newLimiter(options, time.Now)
+		limiters[key] = l
+	}
+	return l
+}
+
+// Limiter limits the rate of the Azure API requests sent with one subscription and identity. Reads and writes have
+// separate budgets. DELETE requests and requests whose context was returned by WithPriority are sent before other
+// queued requests.
+type Limiter struct {
+	reads  *bucket
+	writes *bucket
+}
+
+func newLimiter(opts Options, now func() time.Time) *Limiter {
+	l := &Limiter{}
+	if opts.ReadsPerHour > 0 {
+		l.reads = newBucket(opts.ReadsPerHour, now)
+	}
+	if opts.WritesPerHour > 0 {
+		l.writes = newBucket(opts.WritesPerHour, now)
+	}
+	return l
+}
+
+// Wait blocks until the request may be sent or its context is done.
+func (l *Limiter) Wait(r *http.Request) error {
+	ctx := r.Context()
+	kind, b := Writes, l.writes
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		kind, b = Reads, l.reads
+	}
+	if b == nil {
+		return nil
+	}
+
+	priority := r.Method == http.MethodDelete || isPriority(ctx)
+	start := time.Now()
+	err := b.wait(ctx, priority, func() func() {
+		ot.RecordRateLimitQueued(ctx, kind, priority, 1)
+		return func() { ot.RecordRateLimitQueued(ctx, kind, priority, -1) }
+	})
+	ot.RecordRateLimitWait(ctx, kind, priority, time.Since(start))
+	return err
+}
+
+// SendDecorator is an autorest.SendDecorator which waits for the limiter before sending each request.
+func (l *Limiter) SendDecorator(s autorest.Sender) autorest.Sender {
+	return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		if err := l.Wait(r); err != nil {
+			return nil, errors.Wrap(err, "failed waiting for the Azure API rate limiter")
+		}
+		return s.Do(r)
+	})
+}
+
+type priorityKey struct{}
+
+// WithPriority returns a context whose Azure API requests are sent before the other queued requests, e.g. for
+// operations which release resources or unblock other clusters.
+func WithPriority(ctx context.Context) context.Context {
+	return context.WithValue(ctx, priorityKey{}, true)
+}
+
+func isPriority(ctx context.Context) bool {
+	priority, _ := ctx.Value(priorityKey{}).(bool)
+	return priority
+}
+
+// Authorizer is an autorest.Authorizer whose requests are rate limited by a Limiter. azure.SetAutoRestClientDefaults
+// unwraps it and adds the limiter to the client.
+type Authorizer struct {
+	autorest.Authorizer
+	Limiter *Limiter
+}
+
+// NewAuthorizer returns the given authorizer with the limiter of the Azure credentials with the given key, or the
+// authorizer itself if rate limiting is disabled.
+func NewAuthorizer(authorizer autorest.Authorizer, key string) autorest.Authorizer {
+	if authorizer == nil {
+		return nil
+	}
+	l := For(key)
+	if l == nil {
+		return authorizer
+	}
+	return &Authorizer{Authorizer: authorizer, Limiter: l}
+}
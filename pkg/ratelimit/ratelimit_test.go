@@ -0,0 +1,178 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	. "github.com/onsi/gomega"
+)
+
+func TestFor(t *testing.T) {
+	g := NewWithT(t)
+	defer Configure(Options{})
+
+	Configure(Options{})
+	g.Expect(For("key")).To(BeNil())
+
+	Configure(Options{ReadsPerHour: 12000})
+	l := For("key")
+	g.Expect(l).NotTo(BeNil())
+	g.Expect(l.reads).NotTo(BeNil())
+	g.Expect(l.writes).To(BeNil())
+	g.Expect(For("key")).To(BeIdenticalTo(l))
+	g.Expect(For("other-key")).NotTo(BeIdenticalTo(l))
+}
+
+func TestNewAuthorizer(t *testing.T) {
+	g := NewWithT(t)
+	defer Configure(Options{})
+	authorizer := autorest.NullAuthorizer{}
+
+	Configure(Options{})
+	g.Expect(NewAuthorizer(authorizer, "key")).To(Equal(authorizer))
+	g.Expect(NewAuthorizer(nil, "key")).To(BeNil())
+
+	Configure(Options{WritesPerHour: 1200})
+	g.Expect(NewAuthorizer(authorizer, "key")).To(Equal(&Authorizer{Authorizer: authorizer, Limiter: For("key")}))
+}
+
+func TestLimiterSendDecorator(t *testing.T) {
+	testcases := []struct {
+		name      string
+		method    string
+		ctx       context.Context
+		opts      Options
+		exhausted string
+		expectErr bool
+	}{
+		{
+			name:   "reads are sent within the read budget",
+			method: http.MethodGet,
+			opts:   Options{ReadsPerHour: 3600},
+		},
+		{
+			name:      "reads wait when the read budget is exhausted",
+			method:    http.MethodHead,
+			opts:      Options{ReadsPerHour: 3600, WritesPerHour: 3600},
+			exhausted: Reads,
+			expectErr: true,
+		},
+		{
+			name:      "reads are not limited by the write budget",
+			method:    http.MethodGet,
+			opts:      Options{ReadsPerHour: 3600, WritesPerHour: 3600},
+			exhausted: Writes,
+		},
+		{
+			name:   "reads are not limited without a read budget",
+			method: http.MethodGet,
+			opts:   Options{WritesPerHour: 3600},
+		},
+		{
+			name:   "writes are sent within the write budget",
+			method: http.MethodPut,
+			opts:   Options{WritesPerHour: 3600},
+		},
+		{
+			name:      "writes wait when the write budget is exhausted",
+			method:    http.MethodPatch,
+			opts:      Options{ReadsPerHour: 3600, WritesPerHour: 3600},
+			exhausted: Writes,
+			expectErr: true,
+		},
+		{
+			name:      "deletes wait when the write budget is exhausted",
+			method:    http.MethodDelete,
+			opts:      Options{WritesPerHour: 3600},
+			exhausted: Writes,
+			expectErr: true,
+		},
+		{
+			name:      "priority requests wait when the budget is exhausted",
+			method:    http.MethodPost,
+			ctx:       WithPriority(context.Background()),
+			opts:      Options{WritesPerHour: 3600},
+			exhausted: Writes,
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			l := newLimiter(tc.opts, time.Now)
+			switch tc.exhausted {
+			case Reads:
+				l.reads.tokens = 0
+			case Writes:
+				l.writes.tokens = 0
+			}
+
+			ctx := tc.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+			defer cancel()
+			req, err := http.NewRequestWithContext(ctx, tc.method, "https://management.azure.com/subscriptions/123", http.NoBody)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			sent := false
+			sender := autorest.DecorateSender(autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+				sent = true
+				return &http.Response{StatusCode: http.StatusOK}, nil
+			}), l.SendDecorator)
+			_, err = sender.Do(req)
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(ContainSubstring(context.DeadlineExceeded.Error())))
+				g.Expect(sent).To(BeFalse())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(sent).To(BeTrue())
+			}
+		})
+	}
+}
+
+func TestLimiterPriority(t *testing.T) {
+	g := NewWithT(t)
+
+	// 36000 writes per hour is one every 100ms.
+	l := newLimiter(Options{WritesPerHour: 36000}, time.Now)
+	l.writes.tokens = 0
+	send := func(ctx context.Context, method string, order chan<- string) {
+		req, _ := http.NewRequestWithContext(ctx, method, "https://management.azure.com/subscriptions/123", http.NoBody)
+		g.Expect(l.Wait(req)).To(Succeed())
+		order <- method
+	}
+
+	order := make(chan string, 2)
+	go send(context.Background(), http.MethodPut, order)
+	// Let the PUT queue before the DELETE.
+	time.Sleep(20 * time.Millisecond)
+	go send(context.Background(), http.MethodDelete, order)
+
+	g.Eventually(order, time.Second).Should(Receive(Equal(http.MethodDelete)))
+	g.Eventually(order, time.Second).Should(Receive(Equal(http.MethodPut)))
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package diff compares the desired parameters of Azure resources with the existing resources, taking into account
+// that Azure adds read-only properties and that resources may have sub-resources added outside of CAPZ.
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ChangedFields returns the JSON paths of the fields set in desired which differ from existing, sorted
+// alphabetically. Both are compared as they are sent to or received from Azure, so that:
+//   - fields which are not set in desired, such as read-only properties, are ignored,
+//   - zero values in desired are equal to fields which are not set in existing, as Azure omits them,
+//   - names and IDs are compared case-insensitively, as Azure resource names are case-insensitive,
+//   - items of arrays of named objects are matched by name, other arrays are compared item by item.
+func ChangedFields(existing, desired interface{}) ([]string, error) {
+	existingJSON, err := toJSON(existing)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert existing resource to JSON")
+	}
+	desiredJSON, err := toJSON(desired)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert desired resource to JSON")
+	}
+
+	var fields []string
+	compare("", "", existingJSON, desiredJSON, &fields)
+	sort.Strings(fields)
+	return fields, nil
+}
+
+// MergeByName merges the desired sub-resources, such as load balancing rules or security rules, into the existing
+// ones, matching them by case-insensitive name:
+//   - sub-resources which only exist in Azure were added outside of CAPZ and are kept as they are,
+//   - existing sub-resources which differ from the desired ones in the fields CAPZ sets are replaced,
+//   - desired sub-resources which do not exist yet are appended.
+//
+// It returns the merged sub-resources and the changes made to the existing ones, as "<name>" for the added
+// sub-resources and "<name>.<field path>" for the replaced ones. There are no changes if the existing sub-resources
+// are up to date.
+func MergeByName[T any](existing, desired []T, name func(T) string) (merged []T, changes []string, err error) {
+	desiredByName := make(map[string]int, len(desired))
+	for i, d := range desired {
+		desiredByName[strings.ToLower(name(d))] = i
+	}
+
+	merged = make([]T, 0, len(existing)+len(desired))
+	found := make([]bool, len(desired))
+	for _, e := range existing {
+		i, ok := desiredByName[strings.ToLower(name(e))]
+		if !ok {
+			merged = append(merged, e)
+			continue
+		}
+		found[i] = true
+		fields, err := ChangedFields(e, desired[i])
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to compare %s", name(e))
+		}
+		if len(fields) == 0 {
+			merged = append(merged, e)
+			continue
+		}
+		merged = append(merged, desired[i])
+		for _, field := range fields {
+			changes = append(changes, name(e)+"."+field)
+		}
+	}
+	for i, d := range desired {
+		if !found[i] {
+			merged = append(merged, d)
+			changes = append(changes, name(d))
+		}
+	}
+	return merged, changes, nil
+}
+
+func toJSON(in interface{}) (interface{}, error) {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// compare appends to fields the paths under path where desired is set and differs from existing. key is the name of
+// the field being compared.
+func compare(path, key string, existing, desired interface{}, fields *[]string) {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		e, _ := existing.(map[string]interface{})
+		for k, v := range d {
+			compare(join(path, k), k, e[k], v, fields)
+		}
+	case []interface{}:
+		e, _ := existing.([]interface{})
+		if names, ok := itemNames(d); ok {
+			compareNamedItems(path, e, d, names, fields)
+			return
+		}
+		if len(e) != len(d) {
+			if len(d) != 0 {
+				*fields = append(*fields, path)
+			}
+			return
+		}
+		for i := range d {
+			compare(fmt.Sprintf("%s[%d]", path, i), "", e[i], d[i], fields)
+		}
+	case string:
+		e, _ := existing.(string)
+		if e == d || (caseInsensitive(key) && strings.EqualFold(e, d)) {
+			return
+		}
+		*fields = append(*fields, path)
+	default:
+		if desired == nil || (existing == nil && isZero(desired)) {
+			return
+		}
+		if !reflect.DeepEqual(existing, desired) {
+			*fields = append(*fields, path)
+		}
+	}
+}
+
+// compareNamedItems compares arrays of named objects by matching their items by name, so that their order does not
+// matter. Items which would be added or removed are reported with their name.
+func compareNamedItems(path string, existing, desired []interface{}, names []string, fields *[]string) {
+	existingByName := make(map[string]interface{}, len(existing))
+	for _, item := range existing {
+		if m, ok := item.(map[string]interface{}); ok {
+			if name, ok := m["name"].(string); ok {
+				existingByName[strings.ToLower(name)] = item
+			}
+		}
+	}
+
+	for i, name := range names {
+		itemPath := fmt.Sprintf("%s[%s]", path, name)
+		e, ok := existingByName[strings.ToLower(name)]
+		if !ok {
+			*fields = append(*fields, itemPath)
+			continue
+		}
+		delete(existingByName, strings.ToLower(name))
+		compare(itemPath, "", e, desired[i], fields)
+	}
+	for name := range existingByName {
+		*fields = append(*fields, fmt.Sprintf("%s[%s]", path, name))
+	}
+}
+
+// itemNames returns the names of the items of an array if all of them are named objects.
+func itemNames(items []interface{}) ([]string, bool) {
+	if len(items) == 0 {
+		return nil, false
+	}
+	names := make([]string, len(items))
+	for i, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		name, ok := m["name"].(string)
+		if !ok || name == "" {
+			return nil, false
+		}
+		names[i] = name
+	}
+	return names, true
+}
+
+// caseInsensitive returns true if the field with the given name is a resource name or ID.
+func caseInsensitive(key string) bool {
+	return strings.EqualFold(key, "id") || strings.EqualFold(key, "name")
+}
+
+func isZero(v interface{}) bool {
+	switch v := v.(type) {
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	}
+	return false
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
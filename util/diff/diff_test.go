@@ -0,0 +1,212 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diff
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+)
+
+func TestChangedFields(t *testing.T) {
+	testcases := []struct {
+		name     string
+		existing interface{}
+		desired  interface{}
+		expected []string
+	}{
+		{
+			name: "no changes",
+			existing: resources.GenericResource{
+				ID:       to.StringPtr("/subscriptions/123/resourceGroups/test-group"),
+				Location: to.StringPtr("westus"),
+				Tags:     map[string]*string{"foo": to.StringPtr("bar")},
+			},
+			desired: resources.GenericResource{
+				Location: to.StringPtr("westus"),
+				Tags:     map[string]*string{"foo": to.StringPtr("bar")},
+			},
+			expected: nil,
+		},
+		{
+			name: "fields only set in the existing resource are ignored",
+			existing: resources.GenericResource{
+				Location:  to.StringPtr("westus"),
+				ManagedBy: to.StringPtr("someone"),
+				Tags:      map[string]*string{"foo": to.StringPtr("bar"), "baz": to.StringPtr("qux")},
+			},
+			desired: resources.GenericResource{
+				Tags: map[string]*string{"foo": to.StringPtr("bar")},
+			},
+			expected: nil,
+		},
+		{
+			name: "changed and added fields",
+			existing: resources.GenericResource{
+				Location: to.StringPtr("westus"),
+				Tags:     map[string]*string{"foo": to.StringPtr("bar")},
+			},
+			desired: resources.GenericResource{
+				Location:  to.StringPtr("eastus"),
+				ManagedBy: to.StringPtr("someone"),
+				Tags:      map[string]*string{"foo": to.StringPtr("baz"), "new": to.StringPtr("tag")},
+			},
+			expected: []string{"location", "managedBy", "tags.foo", "tags.new"},
+		},
+		{
+			name: "arrays of values are compared item by item",
+			existing: resources.GenericResource{
+				Properties: map[string]interface{}{
+					"addressPrefixes": []string{"10.0.0.0/16"},
+					"dnsServers":      []string{"10.0.0.4", "10.0.0.5"},
+				},
+			},
+			desired: resources.GenericResource{
+				Properties: map[string]interface{}{
+					"addressPrefixes": []string{"10.0.0.0/16", "10.1.0.0/16"},
+					"dnsServers":      []string{"10.0.0.4", "10.0.0.6"},
+				},
+			},
+			expected: []string{"properties.addressPrefixes", "properties.dnsServers[1]"},
+		},
+		{
+			name: "IDs are case-insensitive and zero values match missing fields",
+			existing: network.SecurityRule{
+				ID: to.StringPtr("/subscriptions/123/resourceGroups/TEST-GROUP/providers/Microsoft.Network/networkSecurityGroups/nsg/securityRules/rule"),
+				SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
+					Priority: to.Int32Ptr(100),
+				},
+			},
+			desired: network.SecurityRule{
+				ID: to.StringPtr("/subscriptions/123/resourceGroups/test-group/providers/Microsoft.Network/networkSecurityGroups/nsg/securityRules/rule"),
+				SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
+					Description: to.StringPtr(""),
+					Priority:    to.Int32Ptr(100),
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "arrays of named objects are matched by name",
+			existing: network.SecurityGroup{
+				SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+					SecurityRules: &[]network.SecurityRule{
+						{Name: to.StringPtr("b"), SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{Priority: to.Int32Ptr(200)}},
+						{Name: to.StringPtr("A"), SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{Priority: to.Int32Ptr(100)}},
+						{Name: to.StringPtr("extra"), SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{Priority: to.Int32Ptr(300)}},
+					},
+				},
+			},
+			desired: network.SecurityGroup{
+				SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+					SecurityRules: &[]network.SecurityRule{
+						{Name: to.StringPtr("a"), SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{Priority: to.Int32Ptr(100)}},
+						{Name: to.StringPtr("b"), SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{Priority: to.Int32Ptr(250)}},
+						{Name: to.StringPtr("c"), SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{Priority: to.Int32Ptr(400)}},
+					},
+				},
+			},
+			expected: []string{
+				"properties.securityRules[b].properties.priority",
+				"properties.securityRules[c]",
+				"properties.securityRules[extra]",
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			fields, err := ChangedFields(tc.existing, tc.desired)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(fields).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestMergeByName(t *testing.T) {
+	rule := func(name string, priority int32) network.SecurityRule {
+		return network.SecurityRule{
+			Name: to.StringPtr(name),
+			SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
+				Priority: to.Int32Ptr(priority),
+			},
+		}
+	}
+	ruleName := func(r network.SecurityRule) string { return to.String(r.Name) }
+
+	testcases := []struct {
+		name            string
+		existing        []network.SecurityRule
+		desired         []network.SecurityRule
+		expectedMerged  []network.SecurityRule
+		expectedChanges []string
+	}{
+		{
+			name:           "up to date",
+			existing:       []network.SecurityRule{rule("a", 100), rule("b", 200)},
+			desired:        []network.SecurityRule{rule("B", 200), rule("a", 100)},
+			expectedMerged: []network.SecurityRule{rule("a", 100), rule("b", 200)},
+		},
+		{
+			name:            "missing sub-resources are appended",
+			existing:        []network.SecurityRule{rule("a", 100)},
+			desired:         []network.SecurityRule{rule("a", 100), rule("b", 200)},
+			expectedMerged:  []network.SecurityRule{rule("a", 100), rule("b", 200)},
+			expectedChanges: []string{"b"},
+		},
+		{
+			name:            "drifted sub-resources are replaced",
+			existing:        []network.SecurityRule{rule("a", 150), rule("b", 200)},
+			desired:         []network.SecurityRule{rule("a", 100), rule("b", 200)},
+			expectedMerged:  []network.SecurityRule{rule("a", 100), rule("b", 200)},
+			expectedChanges: []string{"a.properties.priority"},
+		},
+		{
+			name:            "sub-resources added out-of-band are kept",
+			existing:        []network.SecurityRule{rule("custom", 4000), rule("a", 150)},
+			desired:         []network.SecurityRule{rule("a", 100)},
+			expectedMerged:  []network.SecurityRule{rule("custom", 4000), rule("a", 100)},
+			expectedChanges: []string{"a.properties.priority"},
+		},
+		{
+			name:            "no existing sub-resources",
+			desired:         []network.SecurityRule{rule("a", 100)},
+			expectedMerged:  []network.SecurityRule{rule("a", 100)},
+			expectedChanges: []string{"a"},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			merged, changes, err := MergeByName(tc.existing, tc.desired, ruleName)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(merged).To(Equal(tc.expectedMerged))
+			g.Expect(changes).To(Equal(tc.expectedChanges))
+		})
+	}
+}
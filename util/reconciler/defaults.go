@@ -33,6 +33,8 @@ const (
 	DefaultReconcilerRequeue = 15 * time.Second
 	// DefaultHTTP429RetryAfter is a default backoff wait time when we get a HTTP 429 response with no Retry-After data.
 	DefaultHTTP429RetryAfter = 1 * time.Minute
+	// DefaultUserActionableErrorRequeue is the backoff wait time for Azure errors which need an action from the user, such as a policy denial or an exhausted quota.
+	DefaultUserActionableErrorRequeue = 10 * time.Minute
 )
 
 // DefaultedLoopTimeout will default the timeout if it is zero-valued.
@@ -161,6 +161,7 @@ func StartSpanWithLogger(
 		spanName,
 		trace.WithAttributes(cfg.teleKeyValues()...),
 	)
+	ctx = ctxWithSpanName(ctx, spanName)
 	endFn := func() {
 		span.End()
 	}
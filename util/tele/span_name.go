@@ -0,0 +1,36 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tele
+
+import "context"
+
+type spanNameKey struct{}
+
+// ctxWithSpanName returns a new context.Context which records the name
+// of the innermost span started with StartSpanWithLogger.
+func ctxWithSpanName(ctx context.Context, spanName string) context.Context {
+	return context.WithValue(ctx, spanNameKey{}, spanName)
+}
+
+// SpanNameFromCtx returns the name of the innermost span started with
+// StartSpanWithLogger in the given context.Context, e.g.
+// "virtualmachines.AzureClient.Get". If there is none, it returns an
+// empty string and false.
+func SpanNameFromCtx(ctx context.Context) (string, bool) {
+	spanName, ok := ctx.Value(spanNameKey{}).(string)
+	return spanName, ok
+}